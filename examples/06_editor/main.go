@@ -107,6 +107,22 @@ func main() {
 		fmt.Println(diffLine.String())
 	}
 
+	// 事务与回滚
+	fmt.Println("\n6. 事务预演与回滚")
+	if err := gradleEditor.Begin(); err != nil {
+		log.Fatalf("开始事务失败: %v", err)
+	}
+	if err := gradleEditor.UpdateProperty("version", "2.0.0"); err != nil {
+		fmt.Printf("❌ 更新项目版本失败: %v\n", err)
+	}
+	if preview, err := gradleEditor.DryRun(); err == nil {
+		fmt.Printf("预演修改数: %d\n", preview.Summary.TotalModifications)
+	}
+	if err := gradleEditor.Rollback(); err != nil {
+		log.Fatalf("回滚失败: %v", err)
+	}
+	fmt.Printf("✅ 已回滚，当前修改数: %d\n", len(gradleEditor.GetModifications()))
+
 	// 可选：将修改后的内容写入新文件
 	outputPath := "build.gradle.new"
 	if err := os.WriteFile(outputPath, []byte(finalText), 0o644); err != nil {
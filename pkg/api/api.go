@@ -1,15 +1,33 @@
 // Package api 提供解析Gradle配置文件的API。
+//
+// Parse* 函数每次调用都返回新构建的结果，不同调用的结果之间不共享任何对象，可以各自修改。
+// 同一个结果要交给多个会修改它的使用方（例如编辑器）时，先用 model.CloneParseResult 等函数复制。
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/scagogogo/gradle-parser/pkg/config"
 	"github.com/scagogogo/gradle-parser/pkg/dependency"
 	"github.com/scagogogo/gradle-parser/pkg/editor"
+	"github.com/scagogogo/gradle-parser/pkg/export"
+	"github.com/scagogogo/gradle-parser/pkg/graph"
+	"github.com/scagogogo/gradle-parser/pkg/license"
+	"github.com/scagogogo/gradle-parser/pkg/lint"
+	"github.com/scagogogo/gradle-parser/pkg/lockfile"
 	"github.com/scagogogo/gradle-parser/pkg/model"
 	"github.com/scagogogo/gradle-parser/pkg/parser"
+	"github.com/scagogogo/gradle-parser/pkg/security"
+	"github.com/scagogogo/gradle-parser/pkg/util"
 )
 
 // 版本信息.
@@ -17,6 +35,12 @@ const (
 	Version = "0.1.0"
 )
 
+// Spring Boot相关插件的ID.
+const (
+	springBootPluginID           = "org.springframework.boot"
+	dependencyManagementPluginID = "io.spring.dependency-management"
+)
+
 // ParseFile 解析指定路径的Gradle文件.
 func ParseFile(filePath string) (*model.ParseResult, error) {
 	parser := parser.NewParser()
@@ -35,6 +59,24 @@ func ParseReader(reader io.Reader) (*model.ParseResult, error) {
 	return parser.ParseReader(reader)
 }
 
+// ParseFileContext 解析指定路径的Gradle文件，ctx被取消或超时时中止解析.
+func ParseFileContext(ctx context.Context, filePath string) (*model.ParseResult, error) {
+	parser := parser.NewParser()
+	return parser.ParseFileContext(ctx, filePath)
+}
+
+// ParseStringContext 解析Gradle字符串内容，ctx被取消或超时时中止解析.
+func ParseStringContext(ctx context.Context, content string) (*model.ParseResult, error) {
+	parser := parser.NewParser()
+	return parser.ParseContext(ctx, content)
+}
+
+// ParseReaderContext 从Reader解析Gradle内容，ctx被取消或超时时中止读取和解析.
+func ParseReaderContext(ctx context.Context, reader io.Reader) (*model.ParseResult, error) {
+	parser := parser.NewParser()
+	return parser.ParseReaderContext(ctx, reader)
+}
+
 // GetDependencies 从文件提取依赖信息.
 func GetDependencies(filePath string) ([]*model.Dependency, error) {
 	// 尝试打开文件。
@@ -77,10 +119,38 @@ func GetRepositories(filePath string) ([]*model.Repository, error) {
 	return result.Project.Repositories, nil
 }
 
-// DependenciesByScope 按范围对依赖进行分组.
-func DependenciesByScope(dependencies []*model.Dependency) []*model.DependencySet {
+// GetTasks 从文件提取任务信息，包括 dependsOn、finalizedBy 等任务关系.
+func GetTasks(filePath string) ([]*model.Task, error) {
+	result, err := ParseFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Project.Tasks, nil
+}
+
+// GetProperties 从文件提取所有顶层赋值及其推断类型（string、bool、int、float、list、expression）和源码位置.
+func GetProperties(filePath string) ([]*model.Property, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return parser.ExtractProperties(string(content)), nil
+}
+
+// DependenciesByScope 按范围对依赖进行分组，只保留通过所有filters的依赖.
+// 分组按配置首次出现的顺序排列，未声明配置的依赖放在Scope为空的分组中。
+// 传入 dependency.ApplicationDependencies 或 dependency.BuildToolingDependencies 可以把应用依赖与构建工具依赖分开统计。
+func DependenciesByScope(dependencies []*model.Dependency, filters ...dependency.DependencyFilter) []*model.DependencySet {
 	depParser := dependency.NewParser()
-	return depParser.GroupDependenciesByScope(dependencies)
+	return depParser.GroupDependenciesByScope(dependencies, filters...)
+}
+
+// DependenciesByScopeOrdered 与 DependenciesByScope 相同，分组按order排列：声明顺序或配置名称的字母顺序.
+func DependenciesByScopeOrdered(dependencies []*model.Dependency, order dependency.ScopeOrder, filters ...dependency.DependencyFilter) []*model.DependencySet {
+	depParser := dependency.NewParser()
+	return depParser.GroupDependenciesByScopeOrdered(dependencies, order, filters...)
 }
 
 // IsAndroidProject 检查是否是Android项目.
@@ -101,6 +171,33 @@ func IsSpringBootProject(plugins []*model.Plugin) bool {
 	return pluginParser.IsSpringBootProject(plugins)
 }
 
+// GetSpringBootInfo 汇总Spring Boot项目的插件版本、主类、bootJar归档命名和依赖管理插件等元数据.
+// 项目没有应用 org.springframework.boot 插件时返回nil。
+func GetSpringBootInfo(project *model.Project) *model.SpringBootInfo {
+	if project == nil || !IsSpringBootProject(project.Plugins) {
+		return nil
+	}
+
+	info := &model.SpringBootInfo{}
+	for _, plugin := range project.Plugins {
+		switch plugin.ID {
+		case springBootPluginID:
+			if info.PluginVersion == "" {
+				info.PluginVersion = plugin.Version
+			}
+		case dependencyManagementPluginID:
+			info.DependencyManagement = true
+			if info.DependencyManagementVersion == "" {
+				info.DependencyManagementVersion = plugin.Version
+			}
+		}
+	}
+	if project.SpringBoot != nil {
+		info.SpringBootConfig = *project.SpringBoot
+	}
+	return info
+}
+
 // Options 解析选项.
 type Options struct {
 	SkipComments      bool
@@ -109,6 +206,44 @@ type Options struct {
 	ParseDependencies bool
 	ParseRepositories bool
 	ParseTasks        bool
+
+	// 是否保留源码中重复的依赖声明，默认（false）按 group+name+scope+raw 对依赖去重。
+	KeepDuplicateDependencies bool
+
+	// 是否用ext和gradle.properties中的属性解析依赖版本中的变量，结果写入 Dependency.ResolvedVersion。
+	ResolveVersionVariables bool
+
+	// 是否把buildscript中的classpath依赖（配置为 buildscript-classpath）和plugins块中声明了版本的插件
+	// （配置为 plugin）也作为依赖提取，用 DependenciesByScope 的过滤条件可以与应用依赖分开统计。
+	IncludeBuildToolingDependencies bool
+
+	// 只提取这些配置中声明的依赖，为空表示所有配置；ExcludeScopes中的配置总是被忽略。
+	IncludeScopes []string
+	ExcludeScopes []string
+
+	// 只提取这些块的内容，例如 []string{"plugins"}，为空表示提取所有块。
+	// 比 Parse* 开关更细粒度，两者都开启的部分才会提取。
+	OnlyBlocks []string
+
+	// 严格模式，括号不配对等结构问题和dependencies块中无法解析的依赖声明记录在 ParseResult.Errors 中，
+	// 默认的宽松模式忽略这些问题。
+	Strict bool
+
+	// 是否在依赖、插件和仓库的 DeclaredAtLine 中记录声明所在的行号，
+	// 不需要完整的源码映射（ParseFileWithSourceMapping）就能让诊断指出位置。
+	TrackLineNumbers bool
+
+	// 是否为依赖、插件和仓库记录声明来源（model.Origin：文件、块路径和行号），开启时同时记录行号。
+	TrackOrigins bool
+
+	// 输入限制，用于解析不可信的仓库。0表示使用 parser.DefaultMaxFileSizeBytes 和 parser.DefaultMaxBlockDepth，
+	// 负数表示不限制。
+	// 超过限制时返回包装了 parser.ErrFileTooLarge 或 parser.ErrBlockTooDeep 的错误。
+	MaxFileSizeBytes int64
+	MaxBlockDepth    int
+
+	// 指标接收者，为nil时使用 SetMetrics 设置的接收者。
+	Metrics parser.Metrics
 }
 
 // DefaultOptions 创建默认选项.
@@ -120,6 +255,9 @@ func DefaultOptions() *Options {
 		ParseDependencies: true,
 		ParseRepositories: true,
 		ParseTasks:        true,
+
+		MaxFileSizeBytes: parser.DefaultMaxFileSizeBytes,
+		MaxBlockDepth:    parser.DefaultMaxBlockDepth,
 	}
 }
 
@@ -137,11 +275,63 @@ func NewParser(options *Options) parser.Parser {
 		p.WithParseDependencies(options.ParseDependencies)
 		p.WithParseRepositories(options.ParseRepositories)
 		p.WithParseTasks(options.ParseTasks)
+		p.WithDeduplicateDependencies(!options.KeepDuplicateDependencies)
+		p.WithResolveVariables(options.ResolveVersionVariables)
+		p.WithBuildToolingDependencies(options.IncludeBuildToolingDependencies)
+		p.WithStrict(options.Strict)
+		p.WithLineNumbers(options.TrackLineNumbers)
+		p.WithOrigins(options.TrackOrigins)
+		p.WithScopeFilter(options.IncludeScopes, options.ExcludeScopes)
+		p.WithOnlyBlocks(options.OnlyBlocks...)
+		p.WithMaxFileSize(options.MaxFileSizeBytes)
+		p.WithMaxBlockDepth(options.MaxBlockDepth)
+		p.WithMetrics(options.Metrics)
 	}
 
 	return p
 }
 
+// ParseAll 用相同的选项解析内存中的多个文件（路径 → 内容），返回路径 → 解析结果，不访问文件系统，
+// 适合已经从Git树等来源取得文件内容的服务，路径使用 / 分隔。只解析 .gradle 和 .gradle.kts 文件，
+// 开启 ResolveVersionVariables 时同目录的 gradle.properties 用于解析版本变量，其他文件被忽略.
+// options为nil时使用默认选项。解析失败的文件不出现在结果中，所有失败按路径排序合并在返回的错误中，
+// 每条错误带有文件路径，其他文件的结果仍然返回.
+func ParseAll(files map[string]string, options *Options) (map[string]*model.ParseResult, error) {
+	if options == nil {
+		options = DefaultOptions()
+	}
+	p, ok := NewParser(options).(*parser.GradleParser)
+	if !ok {
+		return nil, fmt.Errorf("不支持的解析器类型")
+	}
+
+	paths := make([]string, 0, len(files))
+	for name := range files {
+		if strings.HasSuffix(name, ".gradle") || strings.HasSuffix(name, ".gradle.kts") {
+			paths = append(paths, name)
+		}
+	}
+	sort.Strings(paths)
+
+	results := make(map[string]*model.ParseResult, len(paths))
+	var errs []error
+	for _, name := range paths {
+		var fileProps map[string]string
+		if content, ok := files[path.Join(path.Dir(name), "gradle.properties")]; ok {
+			fileProps = config.ParseProperties(content)
+		}
+
+		result, err := p.ParseFileContent(context.Background(), name, files[name], fileProps)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		results[name] = result
+	}
+
+	return results, errors.Join(errs...)
+}
+
 // ParseFileWithSourceMapping 解析文件并返回带源码位置信息的结果.
 func ParseFileWithSourceMapping(filePath string) (*model.SourceMappedParseResult, error) {
 	// 读取文件内容。
@@ -183,6 +373,37 @@ func CreateGradleEditor(filePath string) (*editor.GradleEditor, error) {
 	return editor.NewGradleEditor(result.SourceMappedProject), nil
 }
 
+// CreateProjectEditor 为项目模式解析的构建中的每个模块构建文件和settings文件创建跨文件的编辑会话.
+// 模块按 tree.Modules 的顺序（根项目在前）加入，没有构建文件的模块被跳过。
+func CreateProjectEditor(tree *model.ProjectTree) (*editor.ProjectEditor, error) {
+	if tree == nil {
+		return nil, fmt.Errorf("项目树为空")
+	}
+
+	projects := make([]*model.SourceMappedProject, 0, len(tree.Modules))
+	for _, module := range tree.Modules {
+		if module.FilePath == "" {
+			continue
+		}
+		result, err := ParseFileWithSourceMapping(module.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("解析 %s 失败: %w", module.FilePath, err)
+		}
+		projects = append(projects, result.SourceMappedProject)
+	}
+
+	var settings *model.SourceMappedProject
+	if tree.Settings != nil && tree.Settings.Project != nil && tree.Settings.Project.FilePath != "" {
+		result, err := ParseFileWithSourceMapping(tree.Settings.Project.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("解析 %s 失败: %w", tree.Settings.Project.FilePath, err)
+		}
+		settings = result.SourceMappedProject
+	}
+
+	return editor.NewProjectEditor(projects, settings), nil
+}
+
 // UpdateDependencyVersion 更新依赖版本（便捷方法）.
 func UpdateDependencyVersion(filePath, group, name, newVersion string) (string, error) {
 	// 创建编辑器。
@@ -218,3 +439,753 @@ func UpdatePluginVersion(filePath, pluginId, newVersion string) (string, error)
 	serializer := editor.NewGradleSerializer(gradleEditor.GetSourceMappedProject().OriginalText)
 	return serializer.ApplyModifications(gradleEditor.GetModifications())
 }
+
+// ScanVulnerabilities 扫描依赖的已知漏洞.
+// provider 为nil时使用默认的OSV.dev数据源。
+func ScanVulnerabilities(deps []*model.Dependency, provider security.Provider) ([]*security.DependencyReport, error) {
+	if provider == nil {
+		provider = security.NewOSVProvider()
+	}
+	return security.Scan(deps, provider)
+}
+
+// ExportDependencyGraph 将项目的模块与依赖关系导出为DOT或Mermaid文本.
+func ExportDependencyGraph(project *model.Project, format graph.Format) (string, error) {
+	return graph.Export(project, format)
+}
+
+// BuildDependencyGraph 从项目声明的依赖构建依赖图，resolver 不为nil时用于补全传递依赖.
+// resolver 为nil或解析失败的外部依赖作为占位节点保留，返回的图可用于检测环和统计深度。
+func BuildDependencyGraph(project *model.Project, resolver graph.TransitiveResolver) (*model.DependencyGraph, error) {
+	return graph.BuildDependencyGraph(project, resolver)
+}
+
+// ToPackageURLs 返回依赖的purl（pkg:maven/group/name@version），用于对接基于purl的漏洞和许可证服务.
+// 结果按依赖顺序去重，项目依赖等无法表示为Maven坐标的依赖会被跳过。
+func ToPackageURLs(deps []*model.Dependency) []string {
+	purls := make([]string, 0, len(deps))
+	seen := make(map[string]bool)
+	for _, dep := range deps {
+		purl := dep.PackageURL()
+		if purl == "" || seen[purl] {
+			continue
+		}
+		seen[purl] = true
+		purls = append(purls, purl)
+	}
+	return purls
+}
+
+// Encode 将解析结果或项目编码为JSON、YAML或TOML.
+func Encode(result any, format export.Format) ([]byte, error) {
+	return export.Encode(result, format)
+}
+
+// LoadResult 从 Encode 或 json.Marshal 导出的JSON还原解析结果，流水线可以缓存解析结果而不必重新解析.
+// 只支持JSON格式。Errors 中的错误还原为只包含消息的错误。还原成功时向 SetMetrics 设置的接收者计一次缓存命中。
+func LoadResult(data []byte) (*model.ParseResult, error) {
+	return LoadResultWithOptions(data, nil)
+}
+
+// LoadResultWithOptions 与 LoadResult 相同，但缓存命中上报给 options.Metrics，
+// 使设置了自己的指标接收者的服务在同一处统计解析和缓存命中。options或其Metrics为nil时上报给 SetMetrics 设置的接收者.
+func LoadResultWithOptions(data []byte, options *Options) (*model.ParseResult, error) {
+	result, err := model.UnmarshalParseResult(data)
+	if err != nil {
+		return nil, fmt.Errorf("还原解析结果失败: %w", err)
+	}
+	optionsMetrics(options).CacheHit()
+	return result, nil
+}
+
+// LoadSourceMappedResult 从导出的JSON还原带位置信息的解析结果，可以直接传给 editor.NewGradleEditor 生成修改而不必重新解析.
+// 还原成功时向 SetMetrics 设置的接收者计一次缓存命中。
+func LoadSourceMappedResult(data []byte) (*model.SourceMappedParseResult, error) {
+	return LoadSourceMappedResultWithOptions(data, nil)
+}
+
+// LoadSourceMappedResultWithOptions 与 LoadSourceMappedResult 相同，但缓存命中上报给 options.Metrics，
+// options或其Metrics为nil时上报给 SetMetrics 设置的接收者.
+func LoadSourceMappedResultWithOptions(data []byte, options *Options) (*model.SourceMappedParseResult, error) {
+	result, err := model.UnmarshalSourceMappedParseResult(data)
+	if err != nil {
+		return nil, fmt.Errorf("还原解析结果失败: %w", err)
+	}
+	if result.SourceMappedProject == nil {
+		return nil, fmt.Errorf("还原解析结果失败: 缺少sourceMappedProject")
+	}
+	optionsMetrics(options).CacheHit()
+	return result, nil
+}
+
+// optionsMetrics 返回选项中的指标接收者，没有设置时返回 SetMetrics 设置的接收者.
+func optionsMetrics(options *Options) parser.Metrics {
+	if options != nil && options.Metrics != nil {
+		return options.Metrics
+	}
+	return parser.DefaultMetrics()
+}
+
+// CompareWithLockfile 比较项目声明的依赖与锁文件中的锁定版本，返回存在差异的依赖.
+func CompareWithLockfile(project *model.Project, lock *lockfile.Lockfile) []*lockfile.Drift {
+	return lockfile.Compare(project, lock)
+}
+
+// GetGradleWrapperInfo 读取项目根目录下gradle/wrapper/gradle-wrapper.properties中的Wrapper配置.
+// 根目录的build.gradle或build.gradle.kts中配置了 wrapper 任务时，任务中声明的版本、分发类型和地址优先于properties文件,
+// 此时即使没有properties文件也会返回结果。
+func GetGradleWrapperInfo(rootDir string) (*model.WrapperInfo, error) {
+	info, err := config.ParseWrapperFile(filepath.Join(rootDir, config.WrapperPropertiesPath))
+
+	var task *model.WrapperTask
+	for _, name := range []string{"build.gradle", "build.gradle.kts"} {
+		path := filepath.Join(rootDir, name)
+		content, readErr := os.ReadFile(path)
+		if readErr != nil {
+			continue
+		}
+		if task = config.ParseWrapperTask(string(content)); task != nil {
+			task.FilePath = path
+			break
+		}
+	}
+
+	if task == nil {
+		return info, err
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return config.ApplyWrapperTask(info, task), nil
+}
+
+// CheckGradleWrapperVersion 检查Wrapper的Gradle版本是否满足插件要求.
+func CheckGradleWrapperVersion(info *model.WrapperInfo, plugins []*model.Plugin) []*config.GradleVersionMismatch {
+	if info == nil {
+		return []*config.GradleVersionMismatch{}
+	}
+	return config.CheckGradleVersion(info.GradleVersion, plugins)
+}
+
+// CheckAndroidCompatibility 检查脚本中AGP版本与compileSdk、Kotlin插件和Gradle版本的组合，返回带位置的诊断.
+// toolchain 提供脚本中没有声明的值（例如Wrapper中的Gradle版本），可以为nil。
+func CheckAndroidCompatibility(content string, toolchain *config.AndroidToolchain) []*model.Diagnostic {
+	return parser.FindAndroidCompatibilityProblems(content, toolchain)
+}
+
+// ResolveManagedVersions 根据BOM和dependencyManagement补全解析结果中未声明版本的依赖.
+// provider 为nil时只使用脚本中显式声明的版本和内置规则，返回仍未补全版本的依赖。
+func ResolveManagedVersions(result *model.ParseResult, provider dependency.BOMProvider) ([]*model.Dependency, error) {
+	if result == nil || result.Project == nil {
+		return nil, fmt.Errorf("解析结果为空")
+	}
+
+	depParser := dependency.NewParser()
+	dm := depParser.ExtractDependencyManagement(result.RawText, result.Project)
+	return depParser.ResolveManagedVersions(result.Project.Dependencies, dm, provider)
+}
+
+// Lint 使用规则集检查项目，ruleset 为nil时使用内置的默认规则.
+func Lint(project *model.Project, ruleset lint.Ruleset) []*model.Diagnostic {
+	if ruleset == nil {
+		ruleset = lint.DefaultRegistry.Rules()
+	}
+	return ruleset.Run(project)
+}
+
+// LintSource 使用规则集检查项目及其脚本内容，需要脚本内容的规则（如配置缓存规则）直接使用content.
+// ruleset 为nil时使用内置的默认规则。
+func LintSource(project *model.Project, content string, ruleset lint.Ruleset) []*model.Diagnostic {
+	if ruleset == nil {
+		ruleset = lint.DefaultRegistry.Rules()
+	}
+	return ruleset.RunSource(project, content)
+}
+
+// BaselineComparison 是构建文件与基线模板的比较结果.
+type BaselineComparison struct {
+	// Diagnostics 是各项偏差，多出的仓库和使用了不允许配置的依赖带有源码位置。
+	Diagnostics []*model.Diagnostic `json:"diagnostics"`
+	// Modifications 是可以自动修复的偏差对应的修改：添加缺少的仓库、删除多出的仓库。
+	// 可以直接传给 editor.GradleSerializer.ApplyModifications；文件中没有顶层repositories块时不生成添加仓库的修改。
+	Modifications []editor.Modification `json:"modifications,omitempty"`
+}
+
+// CompareAgainstBaseline 比较构建文件与平台团队批准的基线模板，报告缺少的必需插件、缺少或多出的仓库以及不允许的依赖配置.
+// 基线可以用 lint.NewBaseline 从模板构建文件创建。project 不会被修改。
+func CompareAgainstBaseline(project *model.SourceMappedProject, baseline *lint.Baseline) (*BaselineComparison, error) {
+	comparison := &BaselineComparison{Diagnostics: make([]*model.Diagnostic, 0)}
+	if project == nil || project.Project == nil || baseline == nil {
+		return comparison, nil
+	}
+
+	// 源码映射中的元素与 Project 中的元素不是同一对象，按仓库标识和依赖坐标依次对应。
+	repoRanges := make(map[string][]model.SourceRange)
+	for _, repo := range project.SourceMappedRepositories {
+		key := lint.RepositoryKey(repo.Repository)
+		repoRanges[key] = append(repoRanges[key], repo.SourceRange)
+	}
+	depRanges := make(map[string][]model.SourceRange)
+	for _, dep := range project.SourceMappedDependencies {
+		key := dep.Group + ":" + dep.Name + ":" + dep.Scope
+		depRanges[key] = append(depRanges[key], dep.SourceRange)
+	}
+	nextRange := func(ranges map[string][]model.SourceRange, key string) model.SourceRange {
+		if len(ranges[key]) == 0 {
+			return model.SourceRange{}
+		}
+		r := ranges[key][0]
+		ranges[key] = ranges[key][1:]
+		return r
+	}
+
+	// 在副本上生成修改，避免编辑器更新调用方的项目。
+	gradleEditor := editor.NewGradleEditor(model.CloneSourceMappedProject(project))
+	for _, deviation := range baseline.Deviations(project.Project) {
+		diag := deviation.Diagnostic
+		switch diag.Code {
+		case lint.RuleBaselineMissingRepository:
+			repo := deviation.Repository
+			name := repo.Name
+			if repo.URL != "" {
+				name = ""
+			}
+			err := gradleEditor.AddRepository(name, repo.URL)
+			if err != nil && !errors.Is(err, editor.ErrRepositoriesBlockMissing) && !errors.Is(err, editor.ErrAlreadyExists) {
+				return nil, fmt.Errorf("生成添加仓库 %s 的修改失败: %w", lint.RepositoryKey(repo), err)
+			}
+		case lint.RuleBaselineExtraRepository:
+			diag.SourceRange = nextRange(repoRanges, lint.RepositoryKey(deviation.Repository))
+			err := gradleEditor.RemoveRepository(lint.RepositoryKey(deviation.Repository))
+			if err != nil && !errors.Is(err, editor.ErrRepositoryNotFound) {
+				return nil, fmt.Errorf("生成删除仓库 %s 的修改失败: %w", lint.RepositoryKey(deviation.Repository), err)
+			}
+		case lint.RuleBaselineDisallowedScope:
+			dep := deviation.Dependency
+			diag.SourceRange = nextRange(depRanges, dep.Group+":"+dep.Name+":"+dep.Scope)
+		}
+		comparison.Diagnostics = append(comparison.Diagnostics, diag)
+	}
+	comparison.Modifications = gradleEditor.GetModifications()
+	return comparison, nil
+}
+
+// EnrichLicenses 为依赖补全许可证信息并生成按SPDX ID分组的汇总报告.
+// source 为nil时从Maven中央仓库下载POM。
+func EnrichLicenses(deps []*model.Dependency, source license.Source) (*license.Report, error) {
+	if source == nil {
+		source = license.NewRepositorySource()
+	}
+	return license.Enrich(deps, source)
+}
+
+// ResolvePlugins 根据settings文件中的pluginManagement配置确定插件实际的版本、模块和仓库.
+// settings 为nil或没有pluginManagement块时使用Gradle的默认解析方式。
+func ResolvePlugins(settings *model.Project, plugins []*model.Plugin) []*model.PluginResolution {
+	var pm *model.PluginManagement
+	if settings != nil {
+		pm = settings.PluginManagement
+	}
+
+	resolutions := make([]*model.PluginResolution, 0, len(plugins))
+	for _, plugin := range plugins {
+		resolutions = append(resolutions, pm.Resolve(plugin))
+	}
+	return resolutions
+}
+
+// TaskDependsOnGraph 构建项目任务之间的关系图，边的类型为 dependsOn、finalizedBy、mustRunAfter 或 shouldRunAfter.
+// 返回的图可以通过 ToDOT 或 ToMermaid 导出用于可视化。
+func TaskDependsOnGraph(project *model.Project) *graph.Graph {
+	return graph.BuildTaskGraph(project)
+}
+
+// GetBlock 按点号分隔的路径返回脚本块子树，例如 "android.defaultConfig"，找不到时返回nil.
+// 返回的块包含其中的语句、键值和源码范围，可用于读取本包未建模的插件DSL配置。
+func GetBlock(result *model.ParseResult, path string) *model.ScriptBlock {
+	if result == nil {
+		return nil
+	}
+	return result.RootBlock.FindBlock(path)
+}
+
+// MirrorRewrite 是在一个构建文件中强制使用内部镜像所需的修改.
+type MirrorRewrite struct {
+	FilePath      string                `json:"filePath"`
+	Modifications []editor.Modification `json:"modifications"`
+}
+
+// SuggestMirrorRewrites 根据镜像映射（例如 mavenCentral → https://nexus.corp/maven-central）生成项目树中各构建文件需要的修改.
+// 映射的键可以是内置仓库名称或仓库地址。每个文件的修改可以直接传给 editor.GradleSerializer.ApplyModifications，
+// 不需要修改的文件不会出现在结果中。
+func SuggestMirrorRewrites(projects []*model.SourceMappedProject, mirrors map[string]string) ([]*MirrorRewrite, error) {
+	rewrites := make([]*MirrorRewrite, 0)
+	for _, project := range projects {
+		if project == nil {
+			continue
+		}
+
+		// 在副本上生成修改，避免编辑器更新调用方的项目。
+		gradleEditor := editor.NewGradleEditor(model.CloneSourceMappedProject(project))
+		count, err := gradleEditor.RewriteRepositoriesToMirrors(mirrors)
+		if err != nil {
+			return nil, fmt.Errorf("生成 %s 的镜像替换失败: %w", project.FilePath, err)
+		}
+		if count == 0 {
+			continue
+		}
+
+		rewrites = append(rewrites, &MirrorRewrite{
+			FilePath:      project.FilePath,
+			Modifications: gradleEditor.GetModifications(),
+		})
+	}
+	return rewrites, nil
+}
+
+// SuggestRelocations 根据内置的坐标迁移数据库找出已改为在新坐标发布的依赖（例如 mysql:mysql-connector-java → com.mysql:mysql-connector-j）.
+func SuggestRelocations(deps []*model.Dependency) []*dependency.RelocationSuggestion {
+	return dependency.SuggestRelocations(deps)
+}
+
+// SuggestRelocationRewrites 为项目树中各构建文件生成把已迁移依赖改为新坐标的修改，可以直接传给 editor.GradleSerializer.ApplyModifications.
+// dropInOnly 为true时只处理可以直接替换的迁移，跳过需要修改代码的后继项目。不需要修改的文件不会出现在结果中。
+func SuggestRelocationRewrites(projects []*model.SourceMappedProject, dropInOnly bool) ([]*FileModifications, error) {
+	rewrites := make([]*FileModifications, 0)
+	for _, project := range projects {
+		if project == nil {
+			continue
+		}
+
+		deps := make([]*model.Dependency, 0, len(project.SourceMappedDependencies))
+		for _, dep := range project.SourceMappedDependencies {
+			deps = append(deps, dep.Dependency)
+		}
+
+		// ReplaceDependency 会替换同一坐标的所有声明并修改内存中的依赖，所以先按旧坐标去重。
+		type relocation struct {
+			group, name string
+			suggestion  *dependency.RelocationSuggestion
+		}
+		relocations := make([]relocation, 0)
+		seen := make(map[string]bool)
+		for _, suggestion := range dependency.SuggestRelocations(deps) {
+			key := suggestion.Dependency.Group + ":" + suggestion.Dependency.Name
+			if seen[key] || (dropInOnly && !suggestion.Relocation.DropIn) {
+				continue
+			}
+			seen[key] = true
+			relocations = append(relocations, relocation{suggestion.Dependency.Group, suggestion.Dependency.Name, suggestion})
+		}
+
+		// 在副本上生成修改，避免编辑器更新调用方的项目。
+		gradleEditor := editor.NewGradleEditor(model.CloneSourceMappedProject(project))
+		for _, r := range relocations {
+			if err := gradleEditor.ReplaceDependency(r.group, r.name,
+				r.suggestion.NewGroup, r.suggestion.NewName, r.suggestion.NewVersion); err != nil {
+				return nil, fmt.Errorf("生成 %s 的坐标迁移失败: %w", project.FilePath, err)
+			}
+		}
+		if len(gradleEditor.GetModifications()) == 0 {
+			continue
+		}
+
+		rewrites = append(rewrites, &FileModifications{
+			FilePath:      project.FilePath,
+			Modifications: gradleEditor.GetModifications(),
+		})
+	}
+	return rewrites, nil
+}
+
+// AnalyzeDependencyFamilies 把依赖按内置家族（Spring Boot、Jackson、JUnit、Kotlin等）分组，报告家族内不一致的版本并给出升级建议.
+func AnalyzeDependencyFamilies(deps []*model.Dependency) []*dependency.FamilyReport {
+	return dependency.AnalyzeFamilies(deps)
+}
+
+// Fingerprint 汇总项目（及其子项目）使用的Gradle特性，用于大规模的构建清点分析.
+// 脚本中的特性来自解析结果，buildSrc和libs.versions.toml按项目文件所在的构建根目录检测。
+func Fingerprint(project *model.Project) *model.Fingerprint {
+	fingerprint := &model.Fingerprint{}
+	if project == nil {
+		return fingerprint
+	}
+
+	fingerprint.Merge(project.Features)
+	fingerprint.KotlinDSL = util.IsKotlinDSL(project.FilePath)
+
+	if project.PluginManagement != nil && len(project.PluginManagement.IncludedBuilds) > 0 {
+		fingerprint.Merge(&model.Fingerprint{
+			CompositeBuild: true,
+			IncludedBuilds: project.PluginManagement.IncludedBuilds,
+		})
+	}
+
+	if project.FilePath != "" {
+		rootDir := filepath.Dir(project.FilePath)
+		if settingsRoot, err := util.FindSettingsRoot(rootDir); err == nil {
+			rootDir = settingsRoot
+		}
+		fingerprint.BuildSrc = fingerprint.BuildSrc || util.DirExists(filepath.Join(rootDir, "buildSrc"))
+		if _, err := os.Stat(filepath.Join(rootDir, "gradle", "libs.versions.toml")); err == nil {
+			fingerprint.VersionCatalog = true
+		}
+	}
+
+	for _, sub := range project.SubProjects {
+		fingerprint.Merge(Fingerprint(sub))
+	}
+
+	return fingerprint
+}
+
+// ParseProjectTree 以项目模式解析rootDir下的整个构建，包括settings中include的模块、buildSrc、includeBuild引入的构建和约定插件.
+func ParseProjectTree(rootDir string) (*model.ProjectTree, error) {
+	return parser.ParseProjectTree(parser.NewParser(), rootDir)
+}
+
+// ModuleSummary 是项目树中一个模块的统计信息.
+type ModuleSummary struct {
+	Path     string `json:"path"`
+	FilePath string `json:"filePath,omitempty"` // 模块没有构建文件时为空，其余统计均为零值。
+
+	Dependencies        int            `json:"dependencies"`
+	DependenciesByScope map[string]int `json:"dependenciesByScope"`
+	Plugins             []string       `json:"plugins"`      // 插件ID，按声明顺序去重。
+	Repositories        []string       `json:"repositories"` // 仓库地址，内置仓库为名称，例如 mavenCentral。
+
+	SnapshotDependencies int `json:"snapshotDependencies"` // 版本以 -SNAPSHOT 结尾的依赖数量。
+	DynamicVersions      int `json:"dynamicVersions"`      // 使用 1.+、latest.release 或版本范围的依赖数量。
+	Lines                int `json:"lines"`                // 构建文件的行数。
+}
+
+// Summarize 按模块统计项目树中的依赖、插件、仓库、SNAPSHOT和动态版本的使用情况以及构建文件行数.
+// 结果与 tree.Modules 的顺序相同，版本优先使用变量解析后的ResolvedVersion。
+func Summarize(tree *model.ProjectTree) []*ModuleSummary {
+	summaries := make([]*ModuleSummary, 0)
+	if tree == nil {
+		return summaries
+	}
+
+	for _, module := range tree.Modules {
+		summary := &ModuleSummary{
+			Path:                module.Path,
+			FilePath:            module.FilePath,
+			DependenciesByScope: make(map[string]int),
+			Plugins:             make([]string, 0),
+			Repositories:        make([]string, 0),
+		}
+		summaries = append(summaries, summary)
+		if module.Result == nil || module.Result.Project == nil {
+			continue
+		}
+
+		project := module.Result.Project
+		for _, dep := range project.Dependencies {
+			summary.Dependencies++
+			summary.DependenciesByScope[dep.Scope]++
+
+			version := dep.Version
+			if dep.ResolvedVersion != "" {
+				version = dep.ResolvedVersion
+			}
+			if strings.HasSuffix(version, "-SNAPSHOT") {
+				summary.SnapshotDependencies++
+			}
+			if parser.IsDynamicVersion(version) {
+				summary.DynamicVersions++
+			}
+		}
+
+		seen := make(map[string]bool)
+		for _, plugin := range project.Plugins {
+			if !seen[plugin.ID] {
+				seen[plugin.ID] = true
+				summary.Plugins = append(summary.Plugins, plugin.ID)
+			}
+		}
+		for _, repo := range project.Repositories {
+			location := repo.URL
+			if location == "" {
+				location = repo.Name
+			}
+			summary.Repositories = append(summary.Repositories, location)
+		}
+
+		if raw := module.Result.RawText; raw != "" {
+			summary.Lines = strings.Count(strings.TrimSuffix(raw, "\n"), "\n") + 1
+		}
+	}
+	return summaries
+}
+
+// NormalizeScopes 将依赖的配置归类为compile/runtime/test/annotation-processor，并为废弃配置给出迁移建议.
+// mapping 为nil时使用 dependency.DefaultScopeMapping。
+func NormalizeScopes(deps []*model.Dependency, mapping dependency.ScopeMapping) []*dependency.NormalizedScope {
+	return dependency.NormalizeScopes(deps, mapping)
+}
+
+// FindDependencyUsages 在项目模式解析的构建中查找坐标的所有声明位置（模块、配置和源码位置）,
+// 包括直接声明、版本目录别名和buildscript中的声明. name为空时匹配group下的所有制品。
+func FindDependencyUsages(tree *model.ProjectTree, group, name string) []*model.DependencyUsage {
+	return parser.FindDependencyUsages(tree, group, name)
+}
+
+// FindHardcodedCredentials 扫描项目模式解析的构建中直接以字面量写出的凭证,
+// 包括settings、各模块构建文件和约定插件中 credentials { } 块里的用户名、密码和令牌,
+// 以及构建根目录和模块目录下gradle.properties中的密码、令牌和用户名属性. 结果带源码位置和遮盖了凭证值的预览.
+func FindHardcodedCredentials(tree *model.ProjectTree) ([]*model.HardcodedCredential, error) {
+	findings := make([]*model.HardcodedCredential, 0)
+	scanned := make(map[string]bool)
+
+	scan := func(path string, find func(string) []*model.HardcodedCredential) error {
+		if path == "" || scanned[path] {
+			return nil
+		}
+		scanned[path] = true
+		content, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, finding := range find(string(content)) {
+			finding.FilePath = path
+			findings = append(findings, finding)
+		}
+		return nil
+	}
+
+	var walk func(tree *model.ProjectTree) error
+	walk = func(tree *model.ProjectTree) error {
+		if tree == nil {
+			return nil
+		}
+		scripts := make([]string, 0)
+		if tree.Settings != nil && tree.Settings.Project != nil {
+			scripts = append(scripts, tree.Settings.Project.FilePath)
+		}
+		dirs := []string{tree.RootDir}
+		for _, module := range tree.Modules {
+			scripts = append(scripts, module.FilePath)
+			dirs = append(dirs, module.Dir)
+		}
+		for _, plugin := range tree.ConventionPlugins {
+			scripts = append(scripts, plugin.FilePath)
+		}
+
+		for _, script := range scripts {
+			if err := scan(script, parser.FindHardcodedCredentials); err != nil {
+				return err
+			}
+		}
+		for _, dir := range dirs {
+			if dir == "" {
+				continue
+			}
+			if err := scan(filepath.Join(dir, "gradle.properties"), parser.FindHardcodedPropertyCredentials); err != nil {
+				return err
+			}
+		}
+
+		if err := walk(tree.BuildSrc); err != nil {
+			return err
+		}
+		for _, included := range tree.IncludedBuilds {
+			if err := walk(included); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(tree); err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// UpdateManifest 把带源码位置的解析结果转换为依赖更新机器人使用的更新清单（file、line、datasource、depName、currentValue）,
+// 可以用 Encode 输出为JSON.
+func UpdateManifest(projects []*model.SourceMappedProject) []*export.UpdateDependency {
+	entries := make([]*export.UpdateDependency, 0)
+	for _, project := range projects {
+		entries = append(entries, export.UpdateManifest(project)...)
+	}
+	return entries
+}
+
+// RegisterBlockHandler 注册自定义脚本块处理器，之后本包中的解析函数会把名称匹配的块（例如 shadowJar、jib）交给处理器,
+// 处理器可以把提取的数据写入 Project.Extensions.
+func RegisterBlockHandler(handler parser.BlockHandler) error {
+	return parser.RegisterBlockHandler(handler)
+}
+
+// SetMetrics 设置本包解析函数上报指标（解析的文件数、解析耗时、提取的依赖数、缓存命中数）的接收者，
+// 嵌入解析器的服务可以把它绑定到Prometheus，nil表示不上报.
+func SetMetrics(metrics parser.Metrics) {
+	parser.SetDefaultMetrics(metrics)
+}
+
+// Validate 对文件做语法健全性检查（括号配对、未闭合的字符串和注释、重复的插件和依赖声明、空块）,
+// 不需要完整解析即可得到带位置的诊断，适合作为pre-commit钩子的后端.
+func Validate(filePath string) ([]*model.Diagnostic, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return parser.Validate(string(content)), nil
+}
+
+// ValidateString 对构建脚本文本做与 Validate 相同的检查.
+func ValidateString(content string) []*model.Diagnostic {
+	return parser.Validate(content)
+}
+
+// CheckDependencyVerification 检查项目声明的依赖在gradle/verification-metadata.xml中是否有校验记录,
+// 返回开启依赖校验后会导致构建失败的依赖.
+func CheckDependencyVerification(project *model.Project, metadata *lockfile.VerificationMetadata) []*lockfile.VerificationIssue {
+	return lockfile.CheckVerification(project, metadata)
+}
+
+// InferMinimumGradleVersion 根据插件版本（AGP、Kotlin、Spring Boot等）和DSL写法（工具链、版本目录、已移除的配置等）
+// 推断构建大致支持的Gradle最低版本和上限，并给出每条依据.
+func InferMinimumGradleVersion(project *model.Project) *config.GradleVersionRange {
+	return config.InferGradleVersionRange(project)
+}
+
+// CreatePropertiesEditor 读取properties文件（例如gradle.properties）并创建编辑器,
+// 修改保留原有的注释和属性顺序，用 Apply 取得修改后的文本.
+func CreatePropertiesEditor(filePath string) (*editor.PropertiesEditor, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return editor.NewPropertiesEditor(string(content)), nil
+}
+
+// SuggestTasks 根据项目（及其子项目）应用的插件（Spring Boot、Android、maven-publish、jacoco等）
+// 列出可用的常用任务，例如 bootJar、assembleRelease、publish、jacocoTestReport，CI生成器可以据此自动编排流水线.
+func SuggestTasks(project *model.Project) []*config.SuggestedTask {
+	return config.SuggestTasks(project)
+}
+
+// FileModifications 是对一个构建文件的修改.
+type FileModifications struct {
+	FilePath      string                `json:"filePath"`
+	Modifications []editor.Modification `json:"modifications"`
+}
+
+// VersionExtractionPlan 是在多个构建文件中提取硬编码版本的结果.
+type VersionExtractionPlan struct {
+	Extraction *editor.VersionExtraction `json:"extraction"`
+	Files      []*FileModifications      `json:"files"`
+}
+
+// ExtractVersions 把多个构建文件中硬编码的依赖版本统一提取到ext属性或版本目录，同一坐标在所有文件中使用相同的属性名或别名.
+// projects 的第一个元素视为根项目，ext策略的属性定义生成在其中；版本目录策略的条目在 Extraction.CatalogEntries 中。
+// 不需要修改的文件不会出现在结果中。
+func ExtractVersions(projects []*model.SourceMappedProject, strategy editor.VersionExtractionStrategy) (*VersionExtractionPlan, error) {
+	extractor, err := editor.NewVersionExtractor(strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	editors := make([]*editor.GradleEditor, 0, len(projects))
+	for _, project := range projects {
+		if project == nil {
+			continue
+		}
+		// 在副本上生成修改，避免编辑器更新调用方的项目。
+		gradleEditor := editor.NewGradleEditor(model.CloneSourceMappedProject(project))
+		if err := extractor.Extract(gradleEditor); err != nil {
+			return nil, fmt.Errorf("提取 %s 中的版本失败: %w", project.FilePath, err)
+		}
+		editors = append(editors, gradleEditor)
+	}
+	if len(editors) > 0 {
+		if err := extractor.Define(editors[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	plan := &VersionExtractionPlan{
+		Extraction: extractor.Result(),
+		Files:      make([]*FileModifications, 0),
+	}
+	for _, gradleEditor := range editors {
+		if len(gradleEditor.GetModifications()) == 0 {
+			continue
+		}
+		plan.Files = append(plan.Files, &FileModifications{
+			FilePath:      gradleEditor.GetSourceMappedProject().FilePath,
+			Modifications: gradleEditor.GetModifications(),
+		})
+	}
+	return plan, nil
+}
+
+// SimulateResolutionOrder 按项目声明的仓库顺序和内容过滤条件（content、mavenContent、exclusiveContent）
+// 模拟坐标（group:name[:version]）的解析，报告哪些仓库会被查询、哪些被跳过以及第一个被查询的仓库,
+// 可用于评估依赖混淆风险.
+func SimulateResolutionOrder(project *model.Project, coordinate string) (*config.ResolutionSimulation, error) {
+	if project == nil {
+		return nil, fmt.Errorf("项目为空")
+	}
+	dep := dependency.ParseCoordinate(coordinate)
+	if dep == nil {
+		return nil, fmt.Errorf("无效的坐标: %s", coordinate)
+	}
+	return config.SimulateResolution(project.Repositories, dep.Group, dep.Name, dep.Version), nil
+}
+
+// DependencyConfusionReport 是依赖混淆风险分析的结果.
+type DependencyConfusionReport struct {
+	// Findings 是会从公共仓库解析的内部group，按严重程度排列。
+	Findings []*security.ConfusionFinding `json:"findings"`
+	// Modifications 在涉及的公共仓库中加入排除内部group的 content { } 过滤条件，
+	// 可以直接传给 editor.GradleSerializer.ApplyModifications。
+	Modifications []editor.Modification `json:"modifications,omitempty"`
+}
+
+// AnalyzeDependencyConfusion 查找匹配内部group模式（例如 com.mycorp.*）的依赖在没有 exclusiveContent 保护时
+// 会从公共仓库解析的风险，并生成在公共仓库中排除这些group的修改.
+// project 不会被修改。
+func AnalyzeDependencyConfusion(project *model.SourceMappedProject, internalGroups ...string) (*DependencyConfusionReport, error) {
+	analyzer, err := security.NewConfusionAnalyzer(internalGroups...)
+	if err != nil {
+		return nil, err
+	}
+	report := &DependencyConfusionReport{Findings: make([]*security.ConfusionFinding, 0)}
+	if project == nil || project.Project == nil {
+		return report, nil
+	}
+	report.Findings = analyzer.Analyze(project.Project)
+
+	gradleEditor := editor.NewGradleEditor(model.CloneSourceMappedProject(project))
+	fixed := make(map[string]bool)
+	for _, finding := range report.Findings {
+		for _, repo := range finding.PublicRepositories {
+			key := lint.RepositoryKey(repo)
+			if fixed[key] {
+				continue
+			}
+			fixed[key] = true
+			err := gradleEditor.AddRepositoryContentFilter(key, analyzer.ExclusionContent())
+			if err != nil && !errors.Is(err, editor.ErrRepositoryNotFound) {
+				return nil, fmt.Errorf("生成仓库 %s 的内容过滤修改失败: %w", key, err)
+			}
+		}
+	}
+	report.Modifications = gradleEditor.GetModifications()
+	return report, nil
+}
+
+// CheckReleaseReadiness 检查项目模式解析的构建是否可以发布：报告SNAPSHOT依赖、动态版本、SNAPSHOT项目版本和SNAPSHOT仓库,
+// Ready 为false时发布流水线应当失败.
+func CheckReleaseReadiness(tree *model.ProjectTree) *lint.ReleaseReadiness {
+	return lint.CheckReleaseReadiness(tree)
+}
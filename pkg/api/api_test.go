@@ -2,12 +2,27 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
-
+	"time"
+
+	"github.com/scagogogo/gradle-parser/pkg/config"
+	"github.com/scagogogo/gradle-parser/pkg/dependency"
+	"github.com/scagogogo/gradle-parser/pkg/editor"
+	"github.com/scagogogo/gradle-parser/pkg/export"
+	"github.com/scagogogo/gradle-parser/pkg/graph"
+	"github.com/scagogogo/gradle-parser/pkg/lint"
+	"github.com/scagogogo/gradle-parser/pkg/lockfile"
 	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/parser"
+	"github.com/scagogogo/gradle-parser/pkg/security"
 )
 
 // 测试用的Gradle文件内容。
@@ -348,6 +363,18 @@ func TestDefaultOptions(t *testing.T) {
 	if !options.ParseTasks {
 		t.Error("DefaultOptions() should have ParseTasks = true")
 	}
+
+	if options.KeepDuplicateDependencies {
+		t.Error("DefaultOptions() should have KeepDuplicateDependencies = false")
+	}
+
+	if options.MaxFileSizeBytes != parser.DefaultMaxFileSizeBytes {
+		t.Errorf("DefaultOptions() MaxFileSizeBytes = %d, want %d", options.MaxFileSizeBytes, parser.DefaultMaxFileSizeBytes)
+	}
+
+	if options.MaxBlockDepth != parser.DefaultMaxBlockDepth {
+		t.Errorf("DefaultOptions() MaxBlockDepth = %d, want %d", options.MaxBlockDepth, parser.DefaultMaxBlockDepth)
+	}
 }
 
 func TestNewParser(t *testing.T) {
@@ -376,6 +403,95 @@ func TestNewParser(t *testing.T) {
 	}
 }
 
+func TestNewParser_Strict(t *testing.T) {
+	content := "dependencies {\n    implementation 'guava'\n"
+
+	options := DefaultOptions()
+	result, err := NewParser(options).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("Expected no errors in lenient mode, got %v", result.Errors)
+	}
+
+	options.Strict = true
+	result, err = NewParser(options).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Errors) != 2 || !errors.Is(result.Errors[0], parser.ErrMalformedScript) ||
+		!errors.Is(result.Errors[1], parser.ErrUnparseableDependency) {
+		t.Errorf("Unexpected errors in strict mode: %v", result.Errors)
+	}
+}
+
+func TestNewParser_TrackLineNumbers(t *testing.T) {
+	content := "plugins {\n    id 'java'\n}\n\nrepositories {\n    mavenCentral()\n}\n\ndependencies {\n    implementation 'org.slf4j:slf4j-api:1.7.36'\n}\n"
+
+	options := DefaultOptions()
+	options.TrackLineNumbers = true
+	result, err := NewParser(options).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	project := result.Project
+	if project.Plugins[0].DeclaredAtLine != 2 || project.Repositories[0].DeclaredAtLine != 6 ||
+		project.Dependencies[0].DeclaredAtLine != 10 {
+		t.Errorf("Unexpected lines: plugin %d, repository %d, dependency %d", project.Plugins[0].DeclaredAtLine,
+			project.Repositories[0].DeclaredAtLine, project.Dependencies[0].DeclaredAtLine)
+	}
+}
+
+func TestNewParser_Filters(t *testing.T) {
+	options := DefaultOptions()
+	options.IncludeScopes = []string{"testImplementation"}
+	options.OnlyBlocks = []string{"dependencies"}
+
+	result, err := NewParser(options).Parse(testGradleContent)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Project.Plugins) != 0 || len(result.Project.Repositories) != 0 {
+		t.Errorf("Expected only dependencies, got %d plugins and %d repositories",
+			len(result.Project.Plugins), len(result.Project.Repositories))
+	}
+	if len(result.Project.Dependencies) == 0 {
+		t.Fatal("Expected testImplementation dependencies")
+	}
+	for _, dep := range result.Project.Dependencies {
+		if dep.Scope != "testImplementation" {
+			t.Errorf("Unexpected dependency %s:%s in scope %s", dep.Group, dep.Name, dep.Scope)
+		}
+	}
+}
+
+func TestParseResultsAreIndependent(t *testing.T) {
+	first, err := ParseString(testGradleContent)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+	second, err := ParseString(testGradleContent)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+	want := model.CloneParseResult(second)
+
+	first.Project.Dependencies[0].Version = "changed"
+	first.Project.Plugins[0].ID = "changed"
+	first.Project.Repositories[0].Name = "changed"
+	first.Project.Properties["group"] = "changed"
+	first.RootBlock.Children[0].Name = "changed"
+
+	if second.Project.Dependencies[0].Version != want.Project.Dependencies[0].Version ||
+		second.Project.Plugins[0].ID != want.Project.Plugins[0].ID ||
+		second.Project.Repositories[0].Name != want.Project.Repositories[0].Name ||
+		second.Project.Properties["group"] != want.Project.Properties["group"] ||
+		second.RootBlock.Children[0].Name != want.RootBlock.Children[0].Name {
+		t.Error("Modifying one parse result changed another")
+	}
+}
+
 func TestParseFileWithSourceMapping(t *testing.T) {
 	filePath := createTempGradleFile(t, testGradleContent)
 
@@ -576,3 +692,1370 @@ func TestVersion(t *testing.T) {
 		t.Error("Version should contain dots (semantic versioning)")
 	}
 }
+
+// stubVulnProvider 是测试用的漏洞数据源。
+type stubVulnProvider struct{}
+
+func (stubVulnProvider) Query(dep *model.Dependency) ([]*security.Vulnerability, error) {
+	if dep.Name == "mysql-connector-java" {
+		return []*security.Vulnerability{{ID: "GHSA-test", Aliases: []string{"CVE-2022-0000"}, FixedVersions: []string{"8.0.31"}}}, nil
+	}
+	return nil, nil
+}
+
+func TestScanVulnerabilities(t *testing.T) {
+	filePath := createTempGradleFile(t, testGradleContent)
+
+	deps, err := GetDependencies(filePath)
+	if err != nil {
+		t.Fatalf("GetDependencies() error = %v", err)
+	}
+
+	reports, err := ScanVulnerabilities(deps, stubVulnProvider{})
+	if err != nil {
+		t.Fatalf("ScanVulnerabilities() error = %v", err)
+	}
+
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 vulnerable dependency, got %d", len(reports))
+	}
+
+	if reports[0].Vulnerabilities[0].FixedVersions[0] != "8.0.31" {
+		t.Errorf("Expected fixed version 8.0.31, got %v", reports[0].Vulnerabilities[0].FixedVersions)
+	}
+}
+
+func TestExportDependencyGraph(t *testing.T) {
+	result, err := ParseString(testGradleContent)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	dot, err := ExportDependencyGraph(result.Project, graph.FormatDOT)
+	if err != nil {
+		t.Fatalf("ExportDependencyGraph() error = %v", err)
+	}
+	if !strings.Contains(dot, "mysql:mysql-connector-java:8.0.29") {
+		t.Errorf("DOT output should contain mysql dependency, got:\n%s", dot)
+	}
+
+	mermaid, err := ExportDependencyGraph(result.Project, graph.FormatMermaid)
+	if err != nil {
+		t.Fatalf("ExportDependencyGraph() error = %v", err)
+	}
+	if !strings.HasPrefix(mermaid, "graph LR") {
+		t.Errorf("Mermaid output should start with 'graph LR', got:\n%s", mermaid)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	result, err := ParseString(testGradleContent)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	yaml, err := Encode(result, export.FormatYAML)
+	if err != nil {
+		t.Fatalf("Encode(yaml) error = %v", err)
+	}
+	if !strings.Contains(string(yaml), "project:\n") {
+		t.Errorf("YAML output should contain project section, got:\n%s", yaml)
+	}
+
+	toml, err := Encode(result.Project, export.FormatTOML)
+	if err != nil {
+		t.Fatalf("Encode(toml) error = %v", err)
+	}
+	if !strings.Contains(string(toml), "[[dependencies]]") {
+		t.Errorf("TOML output should contain dependency tables, got:\n%s", toml)
+	}
+}
+
+func TestCompareWithLockfile(t *testing.T) {
+	result, err := ParseString(testGradleContent)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	lock, err := lockfile.ParseString("mysql:mysql-connector-java:8.0.28=compileClasspath,runtimeClasspath\n")
+	if err != nil {
+		t.Fatalf("lockfile.ParseString() error = %v", err)
+	}
+
+	drifts := CompareWithLockfile(result.Project, lock)
+	found := false
+	for _, drift := range drifts {
+		if drift.Dependency.Name == "mysql-connector-java" {
+			found = true
+			if drift.Kind != lockfile.DriftVersionMismatch {
+				t.Errorf("Expected version mismatch for mysql, got %s", drift.Kind)
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected drift for mysql-connector-java")
+	}
+}
+
+func TestGetSpringBootInfo(t *testing.T) {
+	result, err := ParseString(`
+plugins {
+    id 'org.springframework.boot' version '3.2.0'
+    id 'io.spring.dependency-management' version '1.1.4'
+    id 'java'
+}
+
+springBoot {
+    mainClass = 'com.example.Application'
+}
+
+bootJar {
+    archiveFileName = 'app.jar'
+}
+`)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	info := GetSpringBootInfo(result.Project)
+	if info == nil {
+		t.Fatal("Expected Spring Boot info")
+	}
+	if info.PluginVersion != "3.2.0" || !info.DependencyManagement || info.DependencyManagementVersion != "1.1.4" {
+		t.Errorf("Unexpected plugin info: %+v", info)
+	}
+	if info.MainClass != "com.example.Application" || info.ArchiveFileName != "app.jar" {
+		t.Errorf("Unexpected boot configuration: %+v", info.SpringBootConfig)
+	}
+
+	plain, err := ParseString("plugins { id 'java' }\nspringBoot { mainClass = 'x' }")
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+	if GetSpringBootInfo(plain.Project) != nil {
+		t.Error("Expected nil for a project without the Spring Boot plugin")
+	}
+}
+
+func TestCheckAndroidCompatibility(t *testing.T) {
+	content := "plugins {\n    id 'com.android.application' version '8.0.2'\n}\nandroid {\n    compileSdk 34\n}\n"
+	diagnostics := CheckAndroidCompatibility(content, &config.AndroidToolchain{GradleVersion: "8.0"})
+	if len(diagnostics) != 1 || diagnostics[0].Code != model.DiagnosticAndroidCompileSdk {
+		t.Fatalf("Expected one compileSdk diagnostic, got %v", diagnostics)
+	}
+	if diagnostics[0].SourceRange.Start.Line != 5 {
+		t.Errorf("Expected diagnostic on line 5, got %s", diagnostics[0].SourceRange.Start.String())
+	}
+}
+
+func TestGetGradleWrapperInfo(t *testing.T) {
+	rootDir := t.TempDir()
+	wrapperDir := filepath.Join(rootDir, "gradle", "wrapper")
+	if err := os.MkdirAll(wrapperDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "distributionUrl=https\\://services.gradle.org/distributions/gradle-7.4-bin.zip\n"
+	if err := os.WriteFile(filepath.Join(wrapperDir, "gradle-wrapper.properties"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := GetGradleWrapperInfo(rootDir)
+	if err != nil {
+		t.Fatalf("GetGradleWrapperInfo() error = %v", err)
+	}
+	if info.GradleVersion != "7.4" {
+		t.Errorf("Expected Gradle 7.4, got %s", info.GradleVersion)
+	}
+
+	plugins := []*model.Plugin{{ID: "org.springframework.boot", Version: "3.0.0"}}
+	if mismatches := CheckGradleWrapperVersion(info, plugins); len(mismatches) != 1 {
+		t.Errorf("Expected 1 mismatch, got %d", len(mismatches))
+	}
+
+	if _, err := GetGradleWrapperInfo(t.TempDir()); err == nil {
+		t.Error("Expected error when wrapper properties are missing")
+	}
+
+	buildFile := filepath.Join(rootDir, "build.gradle.kts")
+	if err := os.WriteFile(buildFile, []byte("tasks.wrapper {\n    gradleVersion = \"8.7\"\n}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	info, err = GetGradleWrapperInfo(rootDir)
+	if err != nil {
+		t.Fatalf("GetGradleWrapperInfo() error = %v", err)
+	}
+	if info.GradleVersion != "8.7" || info.Task == nil || info.Task.FilePath != buildFile {
+		t.Errorf("Expected the wrapper task to take precedence, got %+v", info)
+	}
+	if mismatches := CheckGradleWrapperVersion(info, plugins); len(mismatches) != 0 {
+		t.Errorf("Expected no mismatch with Gradle 8.7, got %d", len(mismatches))
+	}
+
+	// 只有wrapper任务时同样返回结果。
+	onlyTask := t.TempDir()
+	if err := os.WriteFile(filepath.Join(onlyTask, "build.gradle"), []byte("wrapper { gradleVersion = '8.5' }\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := GetGradleWrapperInfo(onlyTask); err != nil || info.GradleVersion != "8.5" {
+		t.Errorf("GetGradleWrapperInfo() = %+v, %v; want Gradle 8.5", info, err)
+	}
+}
+
+func TestResolveManagedVersions(t *testing.T) {
+	result, err := ParseString(testGradleContent)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	if _, err := ResolveManagedVersions(result, nil); err != nil {
+		t.Fatalf("ResolveManagedVersions() error = %v", err)
+	}
+
+	for _, dep := range result.Project.Dependencies {
+		if dep.Group == "org.springframework.boot" {
+			if dep.Version != "2.7.0" || dep.ResolvedFrom == "" {
+				t.Errorf("Expected %s to resolve to 2.7.0 with provenance, got %q from %q", dep.Name, dep.Version, dep.ResolvedFrom)
+			}
+		}
+	}
+
+	if _, err := ResolveManagedVersions(nil, nil); err == nil {
+		t.Error("Expected error for nil result")
+	}
+}
+
+func TestLint(t *testing.T) {
+	result, err := ParseString(testGradleContent)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	diagnostics := Lint(result.Project, lint.Ruleset{lint.NewRepositoryAllowListRule("mavenCentral")})
+	for _, diag := range diagnostics {
+		if diag.Code != lint.RuleRepositoryAllowList {
+			t.Errorf("Unexpected diagnostic: %s", diag)
+		}
+	}
+
+	if defaults := Lint(result.Project, nil); defaults == nil {
+		t.Error("Lint with default rules should return a non-nil slice")
+	}
+}
+
+func TestLintSource(t *testing.T) {
+	content := "def env = System.getProperty('env')\n"
+	result, err := ParseString(content)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	diagnostics := LintSource(result.Project, content, nil)
+	if len(diagnostics) != 1 || diagnostics[0].Code != lint.RuleConfigCacheSystemProperty {
+		t.Fatalf("Expected one system property diagnostic, got %v", diagnostics)
+	}
+	if diagnostics[0].SourceRange.Start.Column != 11 {
+		t.Errorf("Expected diagnostic at column 11, got %s", diagnostics[0].SourceRange.Start.String())
+	}
+}
+
+func TestResolvePlugins(t *testing.T) {
+	settings, err := ParseString(`pluginManagement {
+    plugins {
+        id 'org.springframework.boot' version '3.1.0'
+    }
+}`)
+	if err != nil {
+		t.Fatalf("ParseString failed: %v", err)
+	}
+
+	resolutions := ResolvePlugins(settings.Project, []*model.Plugin{{ID: "org.springframework.boot"}, {ID: "java"}})
+	if len(resolutions) != 2 {
+		t.Fatalf("Expected 2 resolutions, got %d", len(resolutions))
+	}
+	if resolutions[0].Version != "3.1.0" || resolutions[0].VersionSource != model.PluginVersionPluginManagement {
+		t.Errorf("Unexpected resolution: %+v", resolutions[0])
+	}
+	if resolutions[1].Repositories[0] != model.DefaultPluginRepository {
+		t.Errorf("Expected default plugin repository, got %v", resolutions[1].Repositories)
+	}
+}
+
+func TestGetTasksAndTaskDependsOnGraph(t *testing.T) {
+	filePath := createTempGradleFile(t, `task dist(type: Zip) {
+    dependsOn 'jar'
+    finalizedBy 'report'
+}
+build.dependsOn(dist)
+`)
+
+	tasks, err := GetTasks(filePath)
+	if err != nil {
+		t.Fatalf("GetTasks() error = %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].Name != "dist" || tasks[1].Name != "build" {
+		t.Fatalf("Unexpected tasks: %+v", tasks)
+	}
+
+	g := TaskDependsOnGraph(&model.Project{Tasks: tasks})
+	if len(g.Edges) != 3 {
+		t.Errorf("Expected 3 edges, got %d", len(g.Edges))
+	}
+	if mermaid := g.ToMermaid(); !strings.Contains(mermaid, "-->|finalizedBy|") {
+		t.Errorf("Expected finalizedBy edge in Mermaid output, got:\n%s", mermaid)
+	}
+
+	if _, err := GetTasks(filepath.Join(t.TempDir(), "missing.gradle")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestGetBlock(t *testing.T) {
+	result, err := ParseString(`android {
+    defaultConfig {
+        applicationId "com.example.app"
+        minSdk 24
+    }
+}`)
+	if err != nil {
+		t.Fatalf("ParseString failed: %v", err)
+	}
+
+	block := GetBlock(result, "android.defaultConfig")
+	if block == nil {
+		t.Fatal("Expected android.defaultConfig block")
+	}
+	if block.Values["minSdk"] != "24" || block.SourceRange.Start.Line != 2 {
+		t.Errorf("Unexpected block: values=%v range=%s", block.Values, block.SourceRange)
+	}
+	if GetBlock(result, "android.buildTypes") != nil || GetBlock(nil, "android") != nil {
+		t.Error("Expected nil for missing block")
+	}
+}
+
+func TestParseContext(t *testing.T) {
+	filePath := createTempGradleFile(t, testGradleContent)
+
+	result, err := ParseFileContext(context.Background(), filePath)
+	if err != nil {
+		t.Fatalf("ParseFileContext() error = %v", err)
+	}
+	if result.Project.Group != "com.example" {
+		t.Errorf("Expected group 'com.example', got '%s'", result.Project.Group)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ParseFileContext(ctx, filePath); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseFileContext() error = %v, want context.Canceled", err)
+	}
+	if _, err := ParseStringContext(ctx, testGradleContent); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseStringContext() error = %v, want context.Canceled", err)
+	}
+	if _, err := ParseReaderContext(ctx, strings.NewReader(testGradleContent)); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseReaderContext() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestSuggestMirrorRewrites(t *testing.T) {
+	rootPath := createTempGradleFile(t, "repositories {\n    mavenCentral()\n    google()\n}\n")
+	root, err := ParseFileWithSourceMapping(rootPath)
+	if err != nil {
+		t.Fatalf("ParseFileWithSourceMapping() error = %v", err)
+	}
+
+	mirroredPath := createTempGradleFile(t, "repositories {\n    maven { url 'https://nexus.corp/maven-central' }\n}\n")
+	mirrored, err := ParseFileWithSourceMapping(mirroredPath)
+	if err != nil {
+		t.Fatalf("ParseFileWithSourceMapping() error = %v", err)
+	}
+
+	before := model.CloneSourceMappedProject(root.SourceMappedProject)
+	rewrites, err := SuggestMirrorRewrites(
+		[]*model.SourceMappedProject{root.SourceMappedProject, mirrored.SourceMappedProject, nil},
+		map[string]string{"mavenCentral": "https://nexus.corp/maven-central"},
+	)
+	if err != nil {
+		t.Fatalf("SuggestMirrorRewrites() error = %v", err)
+	}
+	if len(rewrites) != 1 {
+		t.Fatalf("Expected rewrites for 1 file, got %d", len(rewrites))
+	}
+	if rewrites[0].FilePath != rootPath || len(rewrites[0].Modifications) != 1 {
+		t.Fatalf("Unexpected rewrite: %+v", rewrites[0])
+	}
+	if !reflect.DeepEqual(root.SourceMappedProject, before) {
+		t.Error("SuggestMirrorRewrites() should not modify the input project")
+	}
+
+	newText, err := editor.NewGradleSerializer(root.SourceMappedProject.OriginalText).ApplyModifications(rewrites[0].Modifications)
+	if err != nil {
+		t.Fatalf("ApplyModifications() error = %v", err)
+	}
+	if !strings.Contains(newText, "maven { url 'https://nexus.corp/maven-central' }\n    google()") {
+		t.Errorf("Unexpected rewritten text:\n%s", newText)
+	}
+}
+
+func TestSuggestRelocationRewrites(t *testing.T) {
+	path := createTempGradleFile(t, `dependencies {
+    implementation 'mysql:mysql-connector-java:8.0.28'
+    testRuntimeOnly 'mysql:mysql-connector-java:8.0.28'
+    implementation 'commons-lang:commons-lang:2.6'
+    implementation 'com.google.guava:guava:32.1.3-jre'
+}
+`)
+	result, err := ParseFileWithSourceMapping(path)
+	if err != nil {
+		t.Fatalf("ParseFileWithSourceMapping() error = %v", err)
+	}
+
+	if suggestions := SuggestRelocations(result.SourceMappedProject.Dependencies); len(suggestions) != 3 {
+		t.Errorf("Expected 3 relocation suggestions, got %d", len(suggestions))
+	}
+
+	before := model.CloneSourceMappedProject(result.SourceMappedProject)
+	rewrites, err := SuggestRelocationRewrites([]*model.SourceMappedProject{result.SourceMappedProject, nil}, true)
+	if err != nil {
+		t.Fatalf("SuggestRelocationRewrites() error = %v", err)
+	}
+	if len(rewrites) != 1 || rewrites[0].FilePath != path || len(rewrites[0].Modifications) != 2 {
+		t.Fatalf("Unexpected rewrites: %+v", rewrites)
+	}
+	if !reflect.DeepEqual(result.SourceMappedProject, before) {
+		t.Error("SuggestRelocationRewrites() should not modify the input project")
+	}
+
+	newText, err := editor.NewGradleSerializer(result.SourceMappedProject.OriginalText).ApplyModifications(rewrites[0].Modifications)
+	if err != nil {
+		t.Fatalf("ApplyModifications() error = %v", err)
+	}
+	for _, expected := range []string{
+		"implementation 'com.mysql:mysql-connector-j:8.0.31'",
+		"testRuntimeOnly 'com.mysql:mysql-connector-j:8.0.31'",
+		"implementation 'commons-lang:commons-lang:2.6'",
+	} {
+		if !strings.Contains(newText, expected) {
+			t.Errorf("Expected %q in rewritten text:\n%s", expected, newText)
+		}
+	}
+}
+
+func TestAnalyzeDependencyFamilies(t *testing.T) {
+	result, err := ParseString(`dependencies {
+    implementation 'com.fasterxml.jackson.core:jackson-databind:2.15.2'
+    implementation 'com.fasterxml.jackson.module:jackson-module-kotlin:2.13.5'
+    testImplementation 'org.junit.jupiter:junit-jupiter:5.10.0'
+    implementation 'com.google.guava:guava:32.1.3-jre'
+}
+`)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	reports := AnalyzeDependencyFamilies(result.Project.Dependencies)
+	if len(reports) != 2 {
+		t.Fatalf("Expected 2 family reports, got %d", len(reports))
+	}
+	if reports[0].Family.ID != "jackson" || reports[0].Consistent || len(reports[0].Upgrades) != 1 {
+		t.Errorf("Expected inconsistent jackson family with one upgrade, got %+v", reports[0])
+	}
+	if reports[1].Family.ID != "junit-jupiter" || !reports[1].Consistent {
+		t.Errorf("Expected consistent junit-jupiter family, got %+v", reports[1])
+	}
+}
+
+func TestCompareAgainstBaseline(t *testing.T) {
+	template, err := ParseString(`plugins {
+    id 'java'
+    id 'jacoco'
+}
+repositories {
+    mavenCentral()
+    google()
+}
+`)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+	baseline := lint.NewBaseline(template.Project)
+	baseline.DisallowedScopes = []string{"compile"}
+
+	path := createTempGradleFile(t, `plugins {
+    id 'java'
+}
+repositories {
+    mavenCentral()
+    maven { url 'https://jitpack.io' }
+}
+dependencies {
+    compile 'com.google.guava:guava:32.1.3-jre'
+}
+`)
+	result, err := ParseFileWithSourceMapping(path)
+	if err != nil {
+		t.Fatalf("ParseFileWithSourceMapping() error = %v", err)
+	}
+	project := result.SourceMappedProject
+
+	comparison, err := CompareAgainstBaseline(project, baseline)
+	if err != nil {
+		t.Fatalf("CompareAgainstBaseline() error = %v", err)
+	}
+	codes := make([]string, 0, len(comparison.Diagnostics))
+	for _, diag := range comparison.Diagnostics {
+		codes = append(codes, diag.Code)
+	}
+	want := []string{lint.RuleBaselineMissingPlugin, lint.RuleBaselineMissingRepository,
+		lint.RuleBaselineExtraRepository, lint.RuleBaselineDisallowedScope}
+	if !reflect.DeepEqual(codes, want) {
+		t.Fatalf("codes = %v, want %v", codes, want)
+	}
+	if line := comparison.Diagnostics[2].SourceRange.Start.Line; line != 6 {
+		t.Errorf("Expected extra repository diagnostic on line 6, got %d", line)
+	}
+	if line := comparison.Diagnostics[3].SourceRange.Start.Line; line != 9 {
+		t.Errorf("Expected disallowed scope diagnostic on line 9, got %d", line)
+	}
+
+	newText, err := editor.NewGradleSerializer(project.OriginalText).ApplyModifications(comparison.Modifications)
+	if err != nil {
+		t.Fatalf("ApplyModifications() error = %v", err)
+	}
+	if !strings.Contains(newText, "    mavenCentral()\n    google()\n}") || strings.Contains(newText, "jitpack") {
+		t.Errorf("Unexpected fixed text:\n%s", newText)
+	}
+	if len(project.SourceMappedRepositories) != 2 {
+		t.Error("CompareAgainstBaseline should not modify the given project")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	rootDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(rootDir, "buildSrc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(rootDir, "app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rootDir, "settings.gradle.kts"), []byte("include(\":app\")\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	buildFile := filepath.Join(rootDir, "app", "build.gradle.kts")
+	content := "kotlin {\n    jvmToolchain(17)\n}\nval sha = System.getenv(\"GIT_SHA\")\n"
+	if err := os.WriteFile(buildFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ParseFile(buildFile)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	fingerprint := Fingerprint(result.Project)
+	if !fingerprint.KotlinDSL || !fingerprint.BuildSrc || !fingerprint.Toolchains || !fingerprint.ConfigurationCacheSensitive {
+		t.Errorf("Unexpected fingerprint: %+v", fingerprint)
+	}
+	if fingerprint.VersionCatalog || fingerprint.CompositeBuild {
+		t.Errorf("Unexpected version catalog or composite build: %+v", fingerprint)
+	}
+
+	if Fingerprint(nil) == nil {
+		t.Error("Fingerprint(nil) should return an empty fingerprint")
+	}
+}
+
+func TestParseProjectTree(t *testing.T) {
+	rootDir := t.TempDir()
+	files := map[string]string{
+		"settings.gradle":  "include ':app'\n",
+		"build.gradle":     "group = 'com.example'\n",
+		"app/build.gradle": "dependencies {\n    implementation 'org.slf4j:slf4j-api:1.7.36'\n}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(rootDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tree, err := ParseProjectTree(rootDir)
+	if err != nil {
+		t.Fatalf("ParseProjectTree() error = %v", err)
+	}
+	app := tree.Module(":app")
+	if app == nil || app.Result == nil || len(app.Result.Project.Dependencies) != 1 {
+		t.Fatalf("Expected :app with one dependency, got %+v", app)
+	}
+}
+
+func TestFindHardcodedCredentials(t *testing.T) {
+	rootDir := t.TempDir()
+	files := map[string]string{
+		"settings.gradle":       "include ':app'\n",
+		"build.gradle":          "repositories {\n    mavenCentral()\n}\n",
+		"gradle.properties":     "org.gradle.caching=true\nnexusPassword=changeit-please\n",
+		"app/build.gradle":      "repositories {\n    maven {\n        url 'https://nexus.example.com/repo'\n        credentials {\n            username nexusUser\n            password 'hardcoded-secret'\n        }\n    }\n}\n",
+		"app/gradle.properties": "nexusUser=ci\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(rootDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tree, err := ParseProjectTree(rootDir)
+	if err != nil {
+		t.Fatalf("ParseProjectTree() error = %v", err)
+	}
+	findings, err := FindHardcodedCredentials(tree)
+	if err != nil {
+		t.Fatalf("FindHardcodedCredentials() error = %v", err)
+	}
+
+	got := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		rel, _ := filepath.Rel(rootDir, finding.FilePath)
+		got = append(got, fmt.Sprintf("%s:%d %s %s", filepath.ToSlash(rel), finding.SourceRange.Start.Line, finding.Key, finding.Preview))
+	}
+	want := []string{
+		"app/build.gradle:6 password password 'ha****'",
+		"gradle.properties:2 nexusPassword nexusPassword=ch****",
+		"app/gradle.properties:1 nexusUser nexusUser=****",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindHardcodedCredentials() = %v, want %v", got, want)
+	}
+}
+
+func TestNormalizeScopes(t *testing.T) {
+	result, err := ParseString("dependencies {\n    compile 'org.slf4j:slf4j-api:1.7.36'\n    testImplementation 'junit:junit:4.13.2'\n}\n")
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	scopes := NormalizeScopes(result.Project.Dependencies, nil)
+	if len(scopes) != 2 {
+		t.Fatalf("Expected 2 normalized scopes, got %d", len(scopes))
+	}
+	if !scopes[0].Deprecated || scopes[0].Replacement != "implementation" || scopes[0].Suggestion == "" {
+		t.Errorf("Expected compile to be deprecated in favor of implementation, got %+v", scopes[0])
+	}
+	if scopes[1].Deprecated || scopes[1].Category != dependency.ScopeCategoryTest {
+		t.Errorf("Expected testImplementation in the test category, got %+v", scopes[1])
+	}
+}
+
+func TestGetProperties(t *testing.T) {
+	filePath := createTempGradleFile(t, "group = 'com.example'\next.retries = 3\njava {\n    withSourcesJar = true\n}\n")
+
+	properties, err := GetProperties(filePath)
+	if err != nil {
+		t.Fatalf("GetProperties() error = %v", err)
+	}
+	if len(properties) != 2 {
+		t.Fatalf("Expected 2 top-level properties, got %d", len(properties))
+	}
+	if properties[1].Key != "ext.retries" || properties[1].Type != model.PropertyTypeInt {
+		t.Errorf("Unexpected property: %+v", properties[1])
+	}
+
+	if _, err := GetProperties(filepath.Join(t.TempDir(), "missing.gradle")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestFindDependencyUsages(t *testing.T) {
+	rootDir := t.TempDir()
+	files := map[string]string{
+		"settings.gradle":  "include ':app'\n",
+		"app/build.gradle": "dependencies {\n    implementation 'org.apache.logging.log4j:log4j-core:2.20.0'\n}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(rootDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tree, err := ParseProjectTree(rootDir)
+	if err != nil {
+		t.Fatalf("ParseProjectTree() error = %v", err)
+	}
+	usages := FindDependencyUsages(tree, "org.apache.logging.log4j", "log4j-core")
+	if len(usages) != 1 || usages[0].Module != ":app" || usages[0].Scope != "implementation" || usages[0].SourceRange.Start.Line != 2 {
+		t.Errorf("Unexpected usages: %+v", usages)
+	}
+}
+
+func TestUpdateManifest(t *testing.T) {
+	filePath := createTempGradleFile(t, "dependencies {\n    implementation 'com.google.guava:guava:31.1-jre'\n}\n")
+	result, err := ParseFileWithSourceMapping(filePath)
+	if err != nil {
+		t.Fatalf("ParseFileWithSourceMapping() error = %v", err)
+	}
+
+	entries := UpdateManifest([]*model.SourceMappedProject{result.SourceMappedProject})
+	if len(entries) != 1 || entries[0].File != filePath || entries[0].Line != 2 ||
+		entries[0].DepName != "com.google.guava:guava" || entries[0].CurrentValue != "31.1-jre" {
+		t.Errorf("Unexpected manifest: %+v", entries)
+	}
+}
+
+func TestRegisterBlockHandler(t *testing.T) {
+	err := RegisterBlockHandler(parser.NewBlockHandler("apiTestDsl", func(block *model.ScriptBlock, project *model.Project) error {
+		project.Extensions["apiTestDsl"] = block.Values["endpoint"]
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("RegisterBlockHandler() error = %v", err)
+	}
+
+	filePath := createTempGradleFile(t, "apiTestDsl {\n    endpoint = 'https://example.com'\n}\n")
+	result, err := ParseFile(filePath)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	if got := result.Project.Extensions["apiTestDsl"]; got != "'https://example.com'" {
+		t.Errorf("Extensions[apiTestDsl] = %v, want 'https://example.com'", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	filePath := createTempGradleFile(t, "plugins {\n    id 'java'\n    id 'java'\n}\n\ndependencies {\n    implementation 'a:b:1.0'\n")
+	diagnostics, err := Validate(filePath)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if len(diagnostics) != 2 ||
+		diagnostics[0].Code != model.DiagnosticDuplicatePlugin ||
+		diagnostics[1].Code != model.DiagnosticUnbalancedBrace || diagnostics[1].SourceRange.Start.Line != 6 {
+		t.Errorf("Unexpected diagnostics: %v", diagnostics)
+	}
+
+	if _, err := Validate("/nonexistent/build.gradle"); err == nil {
+		t.Error("Expected error for missing file")
+	}
+	if diagnostics := ValidateString("dependencies {\n    implementation 'a:b:1.0'\n}\n"); len(diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics, got %v", diagnostics)
+	}
+}
+
+func TestCheckDependencyVerification(t *testing.T) {
+	metadata, err := lockfile.ParseVerificationMetadata(strings.NewReader(`<verification-metadata>
+   <components>
+      <component group="com.google.guava" name="guava" version="31.1-jre"/>
+   </components>
+</verification-metadata>`))
+	if err != nil {
+		t.Fatalf("ParseVerificationMetadata() error = %v", err)
+	}
+
+	result, err := ParseString("dependencies {\n    implementation 'com.google.guava:guava:31.1-jre'\n    implementation 'org.slf4j:slf4j-api:2.0.9'\n}\n")
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	issues := CheckDependencyVerification(result.Project, metadata)
+	if len(issues) != 1 || issues[0].Dependency.Name != "slf4j-api" || issues[0].Kind != lockfile.VerificationMissing {
+		t.Errorf("Unexpected issues: %+v", issues)
+	}
+}
+
+func TestInferMinimumGradleVersion(t *testing.T) {
+	result, err := ParseString(`plugins {
+    id 'org.springframework.boot' version '3.3.0'
+}
+
+java {
+    toolchain {
+        languageVersion = JavaLanguageVersion.of(17)
+    }
+}
+`)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	inferred := InferMinimumGradleVersion(result.Project)
+	if inferred.MinGradleVersion != "7.6.4" || inferred.MaxGradleVersion != "" || len(inferred.Constraints) != 2 {
+		t.Errorf("Unexpected inferred range: %+v", inferred)
+	}
+}
+
+func TestCreatePropertiesEditor(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "gradle.properties")
+	if err := os.WriteFile(filePath, []byte("# versions\nguavaVersion=31.0-jre\n"), 0o644); err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+
+	propertiesEditor, err := CreatePropertiesEditor(filePath)
+	if err != nil {
+		t.Fatalf("CreatePropertiesEditor() error = %v", err)
+	}
+	if err := propertiesEditor.Set("guavaVersion", "32.1.2-jre"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	text, err := propertiesEditor.Apply()
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if text != "# versions\nguavaVersion=32.1.2-jre\n" {
+		t.Errorf("Unexpected text %q", text)
+	}
+
+	if _, err := CreatePropertiesEditor(filepath.Join(t.TempDir(), "missing.properties")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestExtractVersions(t *testing.T) {
+	rootPath := createTempGradleFile(t, "plugins {\n    id 'java'\n}\n")
+	root, err := ParseFileWithSourceMapping(rootPath)
+	if err != nil {
+		t.Fatalf("ParseFileWithSourceMapping() error = %v", err)
+	}
+	appPath := createTempGradleFile(t, "dependencies {\n    implementation 'com.google.guava:guava:31.0-jre'\n}\n")
+	app, err := ParseFileWithSourceMapping(appPath)
+	if err != nil {
+		t.Fatalf("ParseFileWithSourceMapping() error = %v", err)
+	}
+
+	before := model.CloneSourceMappedProject(app.SourceMappedProject)
+	plan, err := ExtractVersions([]*model.SourceMappedProject{root.SourceMappedProject, app.SourceMappedProject}, editor.ExtractToCatalog)
+	if err != nil {
+		t.Fatalf("ExtractVersions() error = %v", err)
+	}
+	if len(plan.Files) != 1 || plan.Files[0].FilePath != appPath || plan.Files[0].Modifications[0].NewText != "libs.guava" {
+		t.Errorf("Unexpected files: %+v", plan.Files)
+	}
+	if !strings.Contains(plan.Extraction.CatalogEntries, `guava = { module = "com.google.guava:guava", version.ref = "guava" }`) {
+		t.Errorf("Unexpected catalog entries:\n%s", plan.Extraction.CatalogEntries)
+	}
+
+	if !reflect.DeepEqual(app.SourceMappedProject, before) {
+		t.Error("ExtractVersions() should not modify the input projects")
+	}
+
+	// 输入没有被修改，可以直接用同样的项目再试ext策略。
+	plan, err = ExtractVersions([]*model.SourceMappedProject{root.SourceMappedProject, app.SourceMappedProject}, editor.ExtractToExt)
+	if err != nil {
+		t.Fatalf("ExtractVersions() error = %v", err)
+	}
+	if len(plan.Files) != 2 || plan.Files[0].FilePath != rootPath {
+		t.Fatalf("Expected definitions in the root file, got %+v", plan.Files)
+	}
+}
+
+func TestBuildDependencyGraph(t *testing.T) {
+	result, err := ParseString("dependencies {\n    implementation 'org.foo:a:1.0'\n}\n")
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	resolver := func(dep *model.Dependency) ([]*model.Dependency, error) {
+		if dep.Name == "a" {
+			return []*model.Dependency{{Group: "org.foo", Name: "b", Version: "2.0"}}, nil
+		}
+		return nil, nil
+	}
+	g, err := BuildDependencyGraph(result.Project, resolver)
+	if err != nil {
+		t.Fatalf("BuildDependencyGraph() error = %v", err)
+	}
+	if stats := g.Stats(); stats.Direct != 1 || stats.Transitive != 1 || stats.MaxDepth != 2 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestToPackageURLs(t *testing.T) {
+	deps := []*model.Dependency{
+		{Group: "org.foo", Name: "bar", Version: "1.0", Scope: "implementation"},
+		{Group: "org.foo", Name: "bar", Version: "1.0", Scope: "testImplementation"},
+		{Name: "core", Project: &model.ProjectRef{Path: ":core"}},
+		{Group: "org.foo", Name: "baz", Version: "2.0", Classifier: "tests"},
+	}
+
+	want := []string{"pkg:maven/org.foo/bar@1.0", "pkg:maven/org.foo/baz@2.0?classifier=tests"}
+	if got := ToPackageURLs(deps); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToPackageURLs() = %v, want %v", got, want)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	rootDir := t.TempDir()
+	files := map[string]string{
+		"settings.gradle": "include ':app', ':docs'\n",
+		"build.gradle":    "plugins {\n    id 'base'\n}\n\nrepositories {\n    mavenCentral()\n}\n",
+		"app/build.gradle": `plugins {
+    id 'java'
+}
+
+repositories {
+    maven { url 'https://repo.example.com/maven' }
+}
+
+dependencies {
+    implementation 'org.slf4j:slf4j-api:1.7.36'
+    implementation 'org.foo:bar:1.+'
+    testImplementation 'org.foo:baz:2.0-SNAPSHOT'
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(rootDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tree, err := ParseProjectTree(rootDir)
+	if err != nil {
+		t.Fatalf("ParseProjectTree() error = %v", err)
+	}
+	summaries := Summarize(tree)
+	if len(summaries) != 3 {
+		t.Fatalf("Expected 3 module summaries, got %d", len(summaries))
+	}
+
+	root, app, docs := summaries[0], summaries[1], summaries[2]
+	if root.Path != ":" || !reflect.DeepEqual(root.Plugins, []string{"base"}) ||
+		!reflect.DeepEqual(root.Repositories, []string{"mavenCentral"}) || root.Lines != 7 {
+		t.Errorf("Unexpected root summary %+v", root)
+	}
+	if app.Dependencies != 3 || !reflect.DeepEqual(app.DependenciesByScope, map[string]int{"implementation": 2, "testImplementation": 1}) {
+		t.Errorf("Unexpected app dependency counts %+v", app)
+	}
+	if app.SnapshotDependencies != 1 || app.DynamicVersions != 1 || app.Lines != 13 {
+		t.Errorf("Unexpected app summary %+v", app)
+	}
+	if !reflect.DeepEqual(app.Repositories, []string{"https://repo.example.com/maven"}) {
+		t.Errorf("Unexpected app repositories %v", app.Repositories)
+	}
+	if docs.Path != ":docs" || docs.FilePath != "" || docs.Dependencies != 0 {
+		t.Errorf("Expected empty summary for module without build file, got %+v", docs)
+	}
+
+	if len(Summarize(nil)) != 0 {
+		t.Error("Expected no summaries for nil tree")
+	}
+}
+
+func TestDependenciesByScopeBuildTooling(t *testing.T) {
+	content := `buildscript {
+    dependencies {
+        classpath 'com.android.tools.build:gradle:8.1.0'
+    }
+}
+
+dependencies {
+    implementation 'org.slf4j:slf4j-api:1.7.36'
+}
+`
+	options := DefaultOptions()
+	options.IncludeBuildToolingDependencies = true
+	result, err := NewParser(options).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	app := DependenciesByScope(result.Project.Dependencies, dependency.ApplicationDependencies)
+	if len(app) != 1 || app[0].Scope != "implementation" {
+		t.Errorf("Unexpected application dependency sets %+v", app)
+	}
+	tooling := DependenciesByScope(result.Project.Dependencies, dependency.BuildToolingDependencies)
+	if len(tooling) != 1 || tooling[0].Scope != dependency.ScopeBuildscriptClasspath {
+		t.Errorf("Unexpected build tooling dependency sets %+v", tooling)
+	}
+}
+
+func TestCreateProjectEditor(t *testing.T) {
+	rootDir := t.TempDir()
+	files := map[string]string{
+		"settings.gradle":  "rootProject.name = 'demo'\ninclude ':app'\n",
+		"build.gradle":     "dependencies {\n    implementation 'org.slf4j:slf4j-api:1.7.36'\n}\n",
+		"app/build.gradle": "dependencies {\n    implementation 'org.slf4j:slf4j-api:1.7.36'\n}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(rootDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tree, err := ParseProjectTree(rootDir)
+	if err != nil {
+		t.Fatalf("ParseProjectTree() error = %v", err)
+	}
+	pe, err := CreateProjectEditor(tree)
+	if err != nil {
+		t.Fatalf("CreateProjectEditor() error = %v", err)
+	}
+	if len(pe.Editors()) != 2 || pe.Settings() == nil {
+		t.Fatalf("Expected 2 build file editors and a settings editor")
+	}
+
+	if updated, err := pe.UpdateDependencyVersion("org.slf4j", "slf4j-api", "2.0.9"); err != nil || updated != 2 {
+		t.Errorf("UpdateDependencyVersion() = %d, %v", updated, err)
+	}
+	changes := pe.Changes()
+	if _, ok := changes[filepath.Join(rootDir, "app", "build.gradle")]; !ok || len(changes) != 2 {
+		t.Errorf("Unexpected changes %v", changes)
+	}
+}
+
+func TestSimulateResolutionOrder(t *testing.T) {
+	result, err := ParseString(`repositories {
+    maven {
+        url 'https://repo.example.org/releases'
+        content { includeGroup 'com.example' }
+    }
+    mavenCentral()
+}
+`)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	sim, err := SimulateResolutionOrder(result.Project, "com.example:lib:1.0")
+	if err != nil {
+		t.Fatalf("SimulateResolutionOrder() error = %v", err)
+	}
+	if sim.First == nil || sim.First.URL != "https://repo.example.org/releases" {
+		t.Errorf("First = %+v, want the example repository", sim.First)
+	}
+
+	sim, err = SimulateResolutionOrder(result.Project, "org.slf4j:slf4j-api")
+	if err != nil {
+		t.Fatalf("SimulateResolutionOrder() error = %v", err)
+	}
+	if sim.First == nil || sim.First.Name != "mavenCentral" || sim.Steps[0].Queried {
+		t.Errorf("simulation = %+v, want the example repository skipped", sim)
+	}
+
+	if _, err := SimulateResolutionOrder(result.Project, "invalid"); err == nil {
+		t.Error("SimulateResolutionOrder() with invalid coordinate returned no error")
+	}
+}
+
+func TestAnalyzeDependencyConfusion(t *testing.T) {
+	path := createTempGradleFile(t, `repositories {
+    mavenCentral()
+    maven { url 'https://nexus.mycorp.com/repository/internal' }
+}
+dependencies {
+    implementation 'com.mycorp.billing:api:1.0'
+    implementation 'org.slf4j:slf4j-api:2.0.9'
+}
+`)
+	result, err := ParseFileWithSourceMapping(path)
+	if err != nil {
+		t.Fatalf("ParseFileWithSourceMapping() error = %v", err)
+	}
+
+	report, err := AnalyzeDependencyConfusion(result.SourceMappedProject, "com.mycorp.*")
+	if err != nil {
+		t.Fatalf("AnalyzeDependencyConfusion() error = %v", err)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Group != "com.mycorp.billing" {
+		t.Fatalf("Findings = %v, want com.mycorp.billing", report.Findings)
+	}
+	if report.Findings[0].Severity != model.SeverityError {
+		t.Errorf("Severity = %s, want error", report.Findings[0].Severity)
+	}
+	if len(report.Modifications) != 1 {
+		t.Fatalf("Modifications = %d, want 1", len(report.Modifications))
+	}
+	if got := report.Modifications[0].NewText; !strings.Contains(got, "mavenCentral { content { excludeGroupByRegex") {
+		t.Errorf("Modification NewText = %q, want a content filter on mavenCentral", got)
+	}
+	if result.SourceMappedProject.SourceMappedRepositories[0].RawText != "mavenCentral()" {
+		t.Error("AnalyzeDependencyConfusion() modified the caller's project")
+	}
+
+	if _, err := AnalyzeDependencyConfusion(result.SourceMappedProject); err == nil {
+		t.Error("AnalyzeDependencyConfusion() without patterns returned no error")
+	}
+}
+
+func TestCheckReleaseReadiness(t *testing.T) {
+	rootDir := t.TempDir()
+	files := map[string]string{
+		"settings.gradle": "include ':app'\n",
+		"build.gradle":    "version = '2.0.0'\n",
+		"app/build.gradle": `repositories {
+    maven { url 'https://repo.example.com/maven-snapshots' }
+}
+
+dependencies {
+    implementation 'org.slf4j:slf4j-api:2.0.9'
+    implementation 'org.foo:baz:2.0-SNAPSHOT'
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(rootDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tree, err := ParseProjectTree(rootDir)
+	if err != nil {
+		t.Fatalf("ParseProjectTree() error = %v", err)
+	}
+	readiness := CheckReleaseReadiness(tree)
+	if readiness.Ready {
+		t.Error("Expected release check to fail because of the SNAPSHOT dependency")
+	}
+	if len(readiness.Findings) != 2 {
+		t.Fatalf("Expected 2 findings, got %d", len(readiness.Findings))
+	}
+	dep := readiness.Findings[0]
+	if dep.Kind != lint.ReleaseSnapshotDependency || dep.Module != ":app" || dep.Line != 7 {
+		t.Errorf("Unexpected dependency finding %+v", dep)
+	}
+	if readiness.Findings[1].Kind != lint.ReleaseSnapshotRepository {
+		t.Errorf("Unexpected repository finding %+v", readiness.Findings[1])
+	}
+}
+
+func TestLoadResult(t *testing.T) {
+	result, err := ParseString(testGradleContent)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+	data, err := Encode(result, export.FormatJSON)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	loaded, err := LoadResult(data)
+	if err != nil {
+		t.Fatalf("LoadResult() error = %v", err)
+	}
+	// 空集合与nil在JSON中不做区分，比较再次编码的结果。
+	reencoded, err := Encode(loaded, export.FormatJSON)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if string(reencoded) != string(data) {
+		t.Errorf("LoadResult() should reconstruct the same result, got:\n%s\nwant:\n%s", reencoded, data)
+	}
+
+	if _, err := LoadResult([]byte("not json")); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+	if _, err := LoadSourceMappedResult(data); err == nil {
+		t.Error("Expected error when the result has no source mapping")
+	}
+}
+
+func TestLoadSourceMappedResult(t *testing.T) {
+	path := createTempGradleFile(t, testGradleContent)
+	result, err := ParseFileWithSourceMapping(path)
+	if err != nil {
+		t.Fatalf("ParseFileWithSourceMapping() error = %v", err)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadSourceMappedResult(data)
+	if err != nil {
+		t.Fatalf("LoadSourceMappedResult() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.SourceMappedProject.SourceMappedDependencies, result.SourceMappedProject.SourceMappedDependencies) {
+		t.Error("Source-mapped dependencies should survive the round trip")
+	}
+
+	// 从缓存的结果驱动编辑器，与从解析结果编辑得到相同的文本。
+	edit := func(project *model.SourceMappedProject) string {
+		gradleEditor := editor.NewGradleEditor(project)
+		if err := gradleEditor.UpdateDependencyVersion("mysql", "mysql-connector-java", "8.0.33"); err != nil {
+			t.Fatalf("UpdateDependencyVersion() error = %v", err)
+		}
+		text, err := editor.NewGradleSerializer(project.OriginalText).ApplyModifications(gradleEditor.GetModifications())
+		if err != nil {
+			t.Fatalf("ApplyModifications() error = %v", err)
+		}
+		return text
+	}
+	if got, want := edit(loaded.SourceMappedProject), edit(result.SourceMappedProject); got != want {
+		t.Errorf("Editing the loaded result gave:\n%s\nwant:\n%s", got, want)
+	}
+
+	metrics := &countingMetrics{}
+	if _, err := LoadSourceMappedResultWithOptions(data, &Options{Metrics: metrics}); err != nil {
+		t.Fatalf("LoadSourceMappedResultWithOptions() error = %v", err)
+	}
+	if metrics.cacheHits != 1 {
+		t.Errorf("Expected 1 cache hit reported to Options.Metrics, got %d", metrics.cacheHits)
+	}
+}
+
+func TestSuggestTasks(t *testing.T) {
+	result, err := ParseString(`plugins {
+    id 'org.springframework.boot' version '3.3.0'
+    id 'maven-publish'
+}
+apply plugin: 'jacoco'
+`)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	names := make([]string, 0)
+	for _, task := range SuggestTasks(result.Project) {
+		names = append(names, task.Path)
+	}
+	expected := []string{"bootJar", "bootRun", "bootBuildImage", "publish", "publishToMavenLocal", "jacocoTestReport", "jacocoTestCoverageVerification"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("SuggestTasks() = %v, want %v", names, expected)
+	}
+}
+
+// countingMetrics 统计上报的解析次数和缓存命中次数。
+type countingMetrics struct {
+	parser.NopMetrics
+	files, cacheHits int
+}
+
+func (m *countingMetrics) FileParsed(model.FileKind, time.Duration, error) { m.files++ }
+
+func (m *countingMetrics) CacheHit() { m.cacheHits++ }
+
+func TestSetMetrics(t *testing.T) {
+	metrics := &countingMetrics{}
+	SetMetrics(metrics)
+	defer SetMetrics(nil)
+
+	result, err := ParseString(testGradleContent)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+	data, err := Encode(result, export.FormatJSON)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if _, err := LoadResult(data); err != nil {
+		t.Fatalf("LoadResult() error = %v", err)
+	}
+	if metrics.files != 1 || metrics.cacheHits != 1 {
+		t.Errorf("Expected 1 parsed file and 1 cache hit, got %+v", metrics)
+	}
+
+	own := &countingMetrics{}
+	if _, err := NewParser(&Options{Metrics: own}).Parse(testGradleContent); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if own.files != 1 || metrics.files != 1 {
+		t.Error("Options.Metrics should take precedence over SetMetrics")
+	}
+
+	// 缓存命中与解析上报给同一个接收者。
+	if _, err := LoadResultWithOptions(data, &Options{Metrics: own}); err != nil {
+		t.Fatalf("LoadResultWithOptions() error = %v", err)
+	}
+	if own.cacheHits != 1 || metrics.cacheHits != 1 {
+		t.Errorf("Expected the cache hit to reach Options.Metrics, got own %+v, default %+v", own, metrics)
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	files := map[string]string{
+		"settings.gradle":           "rootProject.name = 'demo'\ninclude 'app'\n",
+		"app/build.gradle":          "dependencies {\n    implementation \"com.google.guava:guava:$guavaVersion\"\n}\n",
+		"app/gradle.properties":     "guavaVersion=33.0.0-jre\n",
+		"lib/build.gradle.kts":      "dependencies {\n    implementation(\"org.slf4j:slf4j-api:2.0.9\")\n}\n",
+		"too-large/build.gradle":    strings.Repeat("// padding\n", 20),
+		"gradle/libs.versions.toml": "[versions]\n",
+	}
+	options := DefaultOptions()
+	options.ResolveVersionVariables = true
+	options.MaxFileSizeBytes = 100
+
+	results, err := ParseAll(files, options)
+	if err == nil || !strings.Contains(err.Error(), "too-large/build.gradle") || !errors.Is(err, parser.ErrFileTooLarge) {
+		t.Errorf("Expected a combined error for too-large/build.gradle, got %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+
+	app := results["app/build.gradle"]
+	if app.Project.FilePath != "app/build.gradle" || app.Project.Name != "app" || app.FileKind != model.FileKindBuild {
+		t.Errorf("Unexpected app result %+v", app.Project)
+	}
+	if len(app.Project.Dependencies) != 1 || app.Project.Dependencies[0].ResolvedVersion != "33.0.0-jre" {
+		t.Errorf("Version variables should be resolved from gradle.properties, got %+v", app.Project.Dependencies)
+	}
+	if results["settings.gradle"].FileKind != model.FileKindSettings {
+		t.Errorf("Expected settings file kind, got %s", results["settings.gradle"].FileKind)
+	}
+
+	if _, err := ParseAll(map[string]string{"build.gradle": "version = '1.0'\n"}, nil); err != nil {
+		t.Errorf("ParseAll() with nil options error = %v", err)
+	}
+}
+
+func TestKeepDuplicateDependencies(t *testing.T) {
+	content := "dependencies {\n    implementation 'org.slf4j:slf4j-api:1.7.36'\n    implementation 'org.slf4j:slf4j-api:1.7.36'\n}\n"
+
+	// 直接构造的选项默认去重。
+	result, err := NewParser(&Options{ParseDependencies: true}).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Project.Dependencies) != 1 {
+		t.Errorf("Expected 1 dependency with zero-value options, got %d", len(result.Project.Dependencies))
+	}
+
+	result, err = NewParser(&Options{ParseDependencies: true, KeepDuplicateDependencies: true}).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Project.Dependencies) != 2 {
+		t.Errorf("Expected 2 dependencies with KeepDuplicateDependencies, got %d", len(result.Project.Dependencies))
+	}
+}
+
+func TestOptionsInputLimits(t *testing.T) {
+	deep := strings.Repeat("a {\n", parser.DefaultMaxBlockDepth+1) + strings.Repeat("}\n", parser.DefaultMaxBlockDepth+1)
+
+	// 直接构造的选项使用默认限制，负数表示不限制。
+	if _, err := NewParser(&Options{}).Parse(deep); !errors.Is(err, parser.ErrBlockTooDeep) {
+		t.Errorf("Parse() with zero-value options error = %v, want ErrBlockTooDeep", err)
+	}
+	if _, err := NewParser(&Options{MaxBlockDepth: -1}).Parse(deep); err != nil {
+		t.Errorf("Parse() with MaxBlockDepth -1 error = %v", err)
+	}
+
+	large := strings.Repeat("// padding\n", parser.DefaultMaxFileSizeBytes/10)
+	if _, err := NewParser(&Options{}).Parse(large); !errors.Is(err, parser.ErrFileTooLarge) {
+		t.Errorf("Parse() with zero-value options error = %v, want ErrFileTooLarge", err)
+	}
+	if _, err := NewParser(&Options{MaxFileSizeBytes: -1}).Parse(large); err != nil {
+		t.Errorf("Parse() with MaxFileSizeBytes -1 error = %v", err)
+	}
+}
@@ -0,0 +1,273 @@
+// Package catalog 提供Gradle版本目录（gradle/libs.versions.toml）的解析功能。
+package catalog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// DefaultPath 是默认版本目录相对于构建根目录的路径。
+const DefaultPath = "gradle/libs.versions.toml"
+
+// Parse 解析版本目录内容。只支持版本目录用到的TOML子集：
+// [versions]、[libraries]、[bundles]、[plugins] 四个表，值为字符串、内联表或字符串数组，数组可以跨行。
+func Parse(r io.Reader) (*model.VersionCatalog, error) {
+	catalog := &model.VersionCatalog{
+		Versions:  make(map[string]string),
+		Libraries: make([]*model.CatalogLibrary, 0),
+		Bundles:   make(map[string][]string),
+		Plugins:   make([]*model.CatalogPlugin, 0),
+	}
+
+	scanner := bufio.NewScanner(r)
+	section := ""
+	lineNum := 0
+	startLine := 0
+	var pending strings.Builder
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if pending.Len() == 0 {
+			if line == "" {
+				continue
+			}
+			if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+				section = strings.TrimSpace(line[1 : len(line)-1])
+				continue
+			}
+			startLine = lineNum
+		}
+
+		pending.WriteString(line)
+		pending.WriteString(" ")
+		if !balanced(pending.String()) {
+			continue
+		}
+
+		entry := strings.TrimSpace(pending.String())
+		pending.Reset()
+		if err := addEntry(catalog, section, entry, startLine); err != nil {
+			return nil, fmt.Errorf("第%d行: %w", startLine, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if pending.Len() > 0 {
+		return nil, fmt.Errorf("第%d行: 值没有结束", startLine)
+	}
+	return catalog, nil
+}
+
+// ParseString 解析版本目录字符串。
+func ParseString(content string) (*model.VersionCatalog, error) {
+	return Parse(strings.NewReader(content))
+}
+
+// ParseFile 解析指定路径的版本目录文件。
+func ParseFile(filePath string) (*model.VersionCatalog, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	catalog, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("解析版本目录 %s 失败: %w", filePath, err)
+	}
+	catalog.FilePath = filePath
+	return catalog, nil
+}
+
+// addEntry 把一条 key = value 记录加入对应的表。
+func addEntry(catalog *model.VersionCatalog, section, entry string, line int) error {
+	key, value, ok := strings.Cut(entry, "=")
+	if !ok {
+		return fmt.Errorf("无法解析 %s", entry)
+	}
+	key = unquote(strings.TrimSpace(key))
+	value = strings.TrimSpace(value)
+
+	switch section {
+	case "versions":
+		catalog.Versions[key] = versionValue(value)
+	case "libraries":
+		lib := &model.CatalogLibrary{Alias: key, Line: line}
+		if isString(value) {
+			if dep := splitCoordinate(unquote(value)); len(dep) >= 2 {
+				lib.Group, lib.Name = dep[0], dep[1]
+				if len(dep) > 2 {
+					lib.Version = dep[2]
+				}
+			}
+		} else {
+			table := parseInlineTable(value)
+			if module := table["module"]; module != "" {
+				lib.Group, lib.Name, _ = strings.Cut(module, ":")
+			} else {
+				lib.Group, lib.Name = table["group"], table["name"]
+			}
+			lib.Version, lib.VersionRef = table["version"], table["version.ref"]
+		}
+		if lib.Group == "" || lib.Name == "" {
+			return fmt.Errorf("库 %s 缺少group或name", key)
+		}
+		catalog.Libraries = append(catalog.Libraries, lib)
+	case "bundles":
+		catalog.Bundles[key] = parseStringArray(value)
+	case "plugins":
+		plugin := &model.CatalogPlugin{Alias: key, Line: line}
+		if isString(value) {
+			plugin.ID, plugin.Version, _ = strings.Cut(unquote(value), ":")
+		} else {
+			table := parseInlineTable(value)
+			plugin.ID, plugin.Version, plugin.VersionRef = table["id"], table["version"], table["version.ref"]
+		}
+		if plugin.ID == "" {
+			return fmt.Errorf("插件 %s 缺少id", key)
+		}
+		catalog.Plugins = append(catalog.Plugins, plugin)
+	}
+	return nil
+}
+
+// parseInlineTable 解析内联表，嵌套的富版本表按 strictly、require、prefer 的顺序取版本，
+// 键 version.ref 也可以写成 version = { ref = "..." }。
+func parseInlineTable(value string) map[string]string {
+	table := make(map[string]string)
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "{"), "}")
+	for _, field := range splitTopLevel(value) {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ReplaceAll(strings.TrimSpace(key), " ", "")
+		val = strings.TrimSpace(val)
+		if key == "version" && strings.HasPrefix(val, "{") {
+			if ref := parseInlineTable(val)["ref"]; ref != "" {
+				table["version.ref"] = ref
+				continue
+			}
+		}
+		table[key] = versionValue(val)
+	}
+	return table
+}
+
+// versionValue 返回版本字符串，富版本表按 strictly、require、prefer 的顺序取值。
+func versionValue(value string) string {
+	if !strings.HasPrefix(value, "{") {
+		return unquote(value)
+	}
+	rich := parseInlineTable(value)
+	for _, key := range []string{"strictly", "require", "prefer"} {
+		if v := rich[key]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseStringArray 解析字符串数组，例如 ["a", "b"]。
+func parseStringArray(value string) []string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	items := make([]string, 0)
+	for _, item := range splitTopLevel(value) {
+		if item = unquote(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// splitTopLevel 按不在字符串和嵌套括号中的逗号拆分文本。
+func splitTopLevel(text string) []string {
+	parts := make([]string, 0)
+	depth := 0
+	start := 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, text[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, text[start:])
+}
+
+// balanced 判断文本中字符串外的括号是否已经闭合，用于合并跨行的数组和内联表。
+func balanced(text string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// stripComment 去除字符串外的 # 注释。
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// splitCoordinate 拆分 group:name[:version] 形式的坐标。
+func splitCoordinate(coordinate string) []string {
+	return strings.SplitN(coordinate, ":", 3)
+}
+
+// isString 判断值是否为字符串字面量。
+func isString(value string) bool {
+	return strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'")
+}
+
+// unquote 去除值两端的引号。
+func unquote(value string) string {
+	return strings.Trim(value, `"'`)
+}
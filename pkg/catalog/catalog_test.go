@@ -0,0 +1,99 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testCatalog = `# Shared versions
+[versions]
+log4j = "2.20.0"
+kotlin = { strictly = "1.9.0" }
+
+[libraries]
+log4j-core = { module = "org.apache.logging.log4j:log4j-core", version.ref = "log4j" }
+log4j-api = { group = "org.apache.logging.log4j", name = "log4j-api", version = { ref = "log4j" } }
+guava = "com.google.guava:guava:32.1.2-jre"
+junit_jupiter = { module = "org.junit.jupiter:junit-jupiter", version = "5.10.0" } # inline comment
+
+[bundles]
+logging = [
+    "log4j-core",
+    "log4j-api",
+]
+
+[plugins]
+kotlin-jvm = { id = "org.jetbrains.kotlin.jvm", version.ref = "kotlin" }
+spotless = "com.diffplug.spotless:6.21.0"
+`
+
+func TestParse(t *testing.T) {
+	catalog, err := ParseString(testCatalog)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	if want := map[string]string{"log4j": "2.20.0", "kotlin": "1.9.0"}; !reflect.DeepEqual(catalog.Versions, want) {
+		t.Errorf("Versions = %v, want %v", catalog.Versions, want)
+	}
+
+	if len(catalog.Libraries) != 4 {
+		t.Fatalf("Expected 4 libraries, got %d", len(catalog.Libraries))
+	}
+	core := catalog.Libraries[0]
+	if core.Alias != "log4j-core" || core.Group != "org.apache.logging.log4j" || core.Name != "log4j-core" ||
+		core.VersionRef != "log4j" || core.Line != 7 {
+		t.Errorf("Unexpected library: %+v", core)
+	}
+	if api := catalog.Libraries[1]; api.Name != "log4j-api" || api.VersionRef != "log4j" {
+		t.Errorf("Unexpected library: %+v", api)
+	}
+	if guava := catalog.Libraries[2]; guava.Group != "com.google.guava" || guava.Version != "32.1.2-jre" {
+		t.Errorf("Unexpected library: %+v", guava)
+	}
+	if junit := catalog.Libraries[3]; junit.Version != "5.10.0" {
+		t.Errorf("Unexpected library: %+v", junit)
+	}
+
+	if want := []string{"log4j-core", "log4j-api"}; !reflect.DeepEqual(catalog.Bundles["logging"], want) {
+		t.Errorf("Bundle = %v, want %v", catalog.Bundles["logging"], want)
+	}
+
+	if len(catalog.Plugins) != 2 || catalog.Plugins[0].ID != "org.jetbrains.kotlin.jvm" || catalog.Plugins[0].VersionRef != "kotlin" ||
+		catalog.Plugins[1].ID != "com.diffplug.spotless" || catalog.Plugins[1].Version != "6.21.0" {
+		t.Errorf("Unexpected plugins: %+v %+v", catalog.Plugins[0], catalog.Plugins[1])
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, content := range []string{
+		"[libraries]\nbroken = { version = \"1.0\" }\n",
+		"[libraries]\nnot an entry\n",
+		"[bundles]\nlogging = [\"a\",\n",
+	} {
+		if _, err := ParseString(content); err == nil {
+			t.Errorf("Expected error for %q", content)
+		}
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "libs.versions.toml")
+	if err := os.WriteFile(path, []byte(testCatalog), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	catalog, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	if catalog.FilePath != path {
+		t.Errorf("Expected FilePath %s, got %s", path, catalog.FilePath)
+	}
+
+	if _, err := ParseFile(filepath.Join(t.TempDir(), "missing.toml")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -0,0 +1,163 @@
+// Package config 提供Android Gradle插件与compileSdk、Kotlin插件和Gradle版本的兼容性检查功能。
+package config
+
+import (
+	"fmt"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/util"
+)
+
+// AGPCompileSdkSupport 表示从某个AGP版本起支持的最高compileSdk。
+type AGPCompileSdkSupport struct {
+	MinAGPVersion string `json:"minAgpVersion"`
+	MaxCompileSdk int    `json:"maxCompileSdk"`
+}
+
+// KotlinAGPCompatibility 表示从某个Kotlin插件版本起经过测试的AGP版本范围（含两端）。
+type KotlinAGPCompatibility struct {
+	MinKotlinVersion string `json:"minKotlinVersion"`
+	MinAGPVersion    string `json:"minAgpVersion"`
+	MaxAGPVersion    string `json:"maxAgpVersion"`
+}
+
+// KnownAGPCompileSdkSupport 是AGP版本与支持的最高compileSdk的对应表，按AGP版本升序排列。
+var KnownAGPCompileSdkSupport = []AGPCompileSdkSupport{
+	{MinAGPVersion: "7.0", MaxCompileSdk: 31},
+	{MinAGPVersion: "7.1", MaxCompileSdk: 32},
+	{MinAGPVersion: "7.3", MaxCompileSdk: 33},
+	{MinAGPVersion: "8.1.1", MaxCompileSdk: 34},
+	{MinAGPVersion: "8.6", MaxCompileSdk: 35},
+	{MinAGPVersion: "8.9.1", MaxCompileSdk: 36},
+}
+
+// KnownKotlinAGPCompatibility 是Kotlin Gradle插件经过测试的AGP版本范围，按Kotlin版本升序排列。
+var KnownKotlinAGPCompatibility = []KotlinAGPCompatibility{
+	{MinKotlinVersion: "1.8.0", MinAGPVersion: "4.1.3", MaxAGPVersion: "7.2.1"},
+	{MinKotlinVersion: "1.8.20", MinAGPVersion: "4.1.3", MaxAGPVersion: "7.4.0"},
+	{MinKotlinVersion: "1.9.0", MinAGPVersion: "4.2.2", MaxAGPVersion: "7.4.0"},
+	{MinKotlinVersion: "1.9.20", MinAGPVersion: "4.2.2", MaxAGPVersion: "8.1.0"},
+	{MinKotlinVersion: "2.0.0", MinAGPVersion: "7.1.3", MaxAGPVersion: "8.3.1"},
+	{MinKotlinVersion: "2.0.20", MinAGPVersion: "7.1.3", MaxAGPVersion: "8.5"},
+	{MinKotlinVersion: "2.1.0", MinAGPVersion: "7.3.1", MaxAGPVersion: "8.7.2"},
+}
+
+// AndroidToolchain 是兼容性检查的输入，未知的值为空（CompileSdk为0）。
+type AndroidToolchain struct {
+	AGPVersion    string `json:"agpVersion,omitempty"`
+	KotlinVersion string `json:"kotlinVersion,omitempty"`
+	CompileSdk    int    `json:"compileSdk,omitempty"`
+	GradleVersion string `json:"gradleVersion,omitempty"`
+}
+
+// AndroidCompatibilityProblem 表示一组不兼容的版本组合。
+type AndroidCompatibilityProblem struct {
+	Code     string         `json:"code"` // model.DiagnosticAndroid* 之一。
+	Severity model.Severity `json:"severity"`
+	Message  string         `json:"message"`
+	// RequiredGradleVersion 是解决问题后的AGP版本所需的Gradle最低版本，未知时为空。
+	RequiredGradleVersion string `json:"requiredGradleVersion,omitempty"`
+}
+
+// CheckAndroidCompatibility 按内置兼容表检查AGP版本与compileSdk、Kotlin插件和Gradle版本的组合。
+// AGP版本未知时无法检查，返回空列表；其他值未知时跳过对应的检查。
+// Kotlin插件版本超出测试范围只是警告，低于要求的AGP版本、compileSdk过高和Gradle版本过低是错误。
+func CheckAndroidCompatibility(toolchain *AndroidToolchain) []*AndroidCompatibilityProblem {
+	problems := make([]*AndroidCompatibilityProblem, 0)
+	if toolchain == nil || toolchain.AGPVersion == "" {
+		return problems
+	}
+	agp := toolchain.AGPVersion
+
+	if toolchain.CompileSdk > 0 {
+		if maxSdk := maxCompileSdk(agp); maxSdk > 0 && toolchain.CompileSdk > maxSdk {
+			problem := &AndroidCompatibilityProblem{
+				Code:     model.DiagnosticAndroidCompileSdk,
+				Severity: model.SeverityError,
+				Message:  fmt.Sprintf("AGP %s 最高支持 compileSdk %d，当前为 %d", agp, maxSdk, toolchain.CompileSdk),
+			}
+			if required := minAGPForCompileSdk(toolchain.CompileSdk); required != "" {
+				problem.RequiredGradleVersion = RequiredGradleVersion(androidApplicationPlugin, required)
+				problem.Message += fmt.Sprintf("，需要升级到 AGP %s", required) + gradleRequirementSuffix(problem.RequiredGradleVersion)
+			}
+			problems = append(problems, problem)
+		}
+	}
+
+	if toolchain.KotlinVersion != "" {
+		if compat := kotlinAGPCompatibility(toolchain.KotlinVersion); compat != nil {
+			switch {
+			case util.CompareVersions(agp, compat.MinAGPVersion) < 0:
+				required := RequiredGradleVersion(androidApplicationPlugin, compat.MinAGPVersion)
+				problems = append(problems, &AndroidCompatibilityProblem{
+					Code:     model.DiagnosticAndroidKotlinVersion,
+					Severity: model.SeverityError,
+					Message: fmt.Sprintf("Kotlin插件 %s 需要 AGP %s 及以上版本，当前为 %s",
+						toolchain.KotlinVersion, compat.MinAGPVersion, agp) + gradleRequirementSuffix(required),
+					RequiredGradleVersion: required,
+				})
+			case util.CompareVersions(agp, compat.MaxAGPVersion) > 0:
+				problems = append(problems, &AndroidCompatibilityProblem{
+					Code:     model.DiagnosticAndroidKotlinVersion,
+					Severity: model.SeverityWarning,
+					Message: fmt.Sprintf("Kotlin插件 %s 只在 AGP %s 至 %s 上测试过，当前为 %s，建议升级Kotlin插件",
+						toolchain.KotlinVersion, compat.MinAGPVersion, compat.MaxAGPVersion, agp),
+				})
+			}
+		}
+	}
+
+	if toolchain.GradleVersion != "" {
+		required := RequiredGradleVersion(androidApplicationPlugin, agp)
+		if required != "" && util.CompareVersions(toolchain.GradleVersion, required) < 0 {
+			problems = append(problems, &AndroidCompatibilityProblem{
+				Code:                  model.DiagnosticAndroidGradleVersion,
+				Severity:              model.SeverityError,
+				Message:               fmt.Sprintf("AGP %s 需要 Gradle %s 及以上版本，当前为 %s", agp, required, toolchain.GradleVersion),
+				RequiredGradleVersion: required,
+			})
+		}
+	}
+
+	return problems
+}
+
+// maxCompileSdk 返回AGP版本支持的最高compileSdk，早于兼容表的版本返回0。
+func maxCompileSdk(agpVersion string) int {
+	maxSdk := 0
+	for _, support := range KnownAGPCompileSdkSupport {
+		if util.CompareVersions(agpVersion, support.MinAGPVersion) >= 0 {
+			maxSdk = support.MaxCompileSdk
+		}
+	}
+	return maxSdk
+}
+
+// minAGPForCompileSdk 返回支持指定compileSdk的最低AGP版本，兼容表中没有时返回空字符串。
+func minAGPForCompileSdk(compileSdk int) string {
+	for _, support := range KnownAGPCompileSdkSupport {
+		if support.MaxCompileSdk >= compileSdk {
+			return support.MinAGPVersion
+		}
+	}
+	return ""
+}
+
+// kotlinAGPCompatibility 返回Kotlin插件版本适用的兼容记录，早于兼容表的版本返回nil。
+func kotlinAGPCompatibility(kotlinVersion string) *KotlinAGPCompatibility {
+	var found *KotlinAGPCompatibility
+	for i := range KnownKotlinAGPCompatibility {
+		if util.CompareVersions(kotlinVersion, KnownKotlinAGPCompatibility[i].MinKotlinVersion) >= 0 {
+			found = &KnownKotlinAGPCompatibility[i]
+		}
+	}
+	return found
+}
+
+// gradleRequirementSuffix 返回描述Gradle版本要求的消息后缀，版本未知时返回空字符串。
+func gradleRequirementSuffix(gradleVersion string) string {
+	if gradleVersion == "" {
+		return ""
+	}
+	return fmt.Sprintf("（需要 Gradle %s 及以上版本）", gradleVersion)
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestCheckAndroidCompatibility(t *testing.T) {
+	tests := []struct {
+		name      string
+		toolchain *AndroidToolchain
+		want      []string
+		severity  []model.Severity
+	}{
+		{
+			name:      "unknown AGP version",
+			toolchain: &AndroidToolchain{KotlinVersion: "1.9.0", CompileSdk: 34},
+		},
+		{
+			name:      "compatible combination",
+			toolchain: &AndroidToolchain{AGPVersion: "8.1.0", KotlinVersion: "1.9.22", CompileSdk: 33, GradleVersion: "8.2"},
+			want:      []string{},
+		},
+		{
+			name:      "compileSdk too new",
+			toolchain: &AndroidToolchain{AGPVersion: "7.4.2", CompileSdk: 34},
+			want:      []string{"AGP 7.4.2 最高支持 compileSdk 33，当前为 34，需要升级到 AGP 8.1.1（需要 Gradle 8.0 及以上版本）"},
+			severity:  []model.Severity{model.SeverityError},
+		},
+		{
+			name:      "kotlin requires newer AGP and Gradle too old",
+			toolchain: &AndroidToolchain{AGPVersion: "7.0.4", KotlinVersion: "2.0.0", GradleVersion: "6.9"},
+			want: []string{
+				"Kotlin插件 2.0.0 需要 AGP 7.1.3 及以上版本，当前为 7.0.4（需要 Gradle 7.2 及以上版本）",
+				"AGP 7.0.4 需要 Gradle 7.0 及以上版本，当前为 6.9",
+			},
+			severity: []model.Severity{model.SeverityError, model.SeverityError},
+		},
+		{
+			name:      "kotlin not tested with AGP",
+			toolchain: &AndroidToolchain{AGPVersion: "8.4.0", KotlinVersion: "1.9.0"},
+			want:      []string{"Kotlin插件 1.9.0 只在 AGP 4.2.2 至 7.4.0 上测试过，当前为 8.4.0，建议升级Kotlin插件"},
+			severity:  []model.Severity{model.SeverityWarning},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := CheckAndroidCompatibility(tt.toolchain)
+			if len(problems) != len(tt.want) {
+				t.Fatalf("Expected %d problems, got %d: %+v", len(tt.want), len(problems), problems)
+			}
+			for i, problem := range problems {
+				if problem.Message != tt.want[i] {
+					t.Errorf("problems[%d].Message = %q, want %q", i, problem.Message, tt.want[i])
+				}
+				if problem.Severity != tt.severity[i] {
+					t.Errorf("problems[%d].Severity = %q, want %q", i, problem.Severity, tt.severity[i])
+				}
+			}
+		})
+	}
+}
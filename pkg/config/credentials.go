@@ -0,0 +1,148 @@
+// Package config 提供仓库凭证与认证配置的解析功能。
+package config
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配 credentials(PasswordCredentials) 或 credentials(AwsCredentials::class) 形式的凭证类型。
+	credentialsTypeRegex = regexp.MustCompile(`^credentials\s*\(\s*(\w+)(?:::class(?:\.java)?)?\s*\)`)
+
+	// 匹配凭证块中的赋值，例如 username 'user'、password = System.getenv("PASS")。
+	credentialAssignRegex = regexp.MustCompile(`^(username|password|name|value|accessKey|secretKey|sessionToken)\b\s*(?:=\s*)?(.+)$`)
+
+	// 匹配认证方式，例如 basic(BasicAuthentication)、create<HttpHeaderAuthentication>("header")。
+	authenticationRegex = regexp.MustCompile(`\b\w+\s*\(\s*(\w+Authentication)(?:::class(?:\.java)?)?\s*\)|` +
+		`\b(?:create|register)\s*<\s*(\w+Authentication)\s*>`)
+
+	// 匹配各种凭证来源的引用。
+	envReferenceRegex = regexp.MustCompile(
+		`^(?:System\.getenv\s*\(\s*['"]([^'"]+)['"]\s*\)|` +
+			`(?:project\.)?providers\.environmentVariable\s*\(\s*['"]([^'"]+)['"]\s*\)|` +
+			`System\.env\.(\w+)|System\.env\s*\[\s*['"]([^'"]+)['"]\s*\])`)
+	systemPropertyReferenceRegex = regexp.MustCompile(
+		`^(?:System\.getProperty\s*\(\s*['"]([^'"]+)['"]|` +
+			`(?:project\.)?providers\.systemProperty\s*\(\s*['"]([^'"]+)['"]\s*\))`)
+	propertyReferenceRegex = regexp.MustCompile(
+		`^(?:(?:project\.|rootProject\.)?(?:findProperty|property)\s*\(\s*['"]([^'"]+)['"]\s*\)|` +
+			`(?:project\.)?providers\.gradleProperty\s*\(\s*['"]([^'"]+)['"]\s*\)|` +
+			`(?:project\.|rootProject\.)?(?:ext\.)?properties\s*\[\s*['"]([^'"]+)['"]\s*\]|` +
+			`(?:project\.|rootProject\.|ext\.)?([A-Za-z_]\w*)$)`)
+	literalValueRegex = regexp.MustCompile(`^(?:'([^']*)'|"([^"$]*)")$`)
+)
+
+// providedCredentialSuffixes 是 credentials(Type) 形式下Gradle读取的属性后缀。
+var providedCredentialSuffixes = map[string][][2]string{
+	model.CredentialsPassword:   {{"username", "Username"}, {"password", "Password"}},
+	model.CredentialsHTTPHeader: {{"name", "AuthHeaderName"}, {"value", "AuthHeaderValue"}},
+	model.CredentialsAWS:        {{"accessKey", "AccessKey"}, {"secretKey", "SecretKey"}, {"sessionToken", "SessionToken"}},
+}
+
+// newProvidedCredentials 创建 credentials(Type) 形式的凭证，值为Gradle按仓库名读取的属性。
+func newProvidedCredentials(credentialsType, repoName string) *model.RepositoryCredentials {
+	credentials := &model.RepositoryCredentials{
+		Type:     credentialsType,
+		Provided: true,
+		Values:   make(map[string]*model.CredentialValue),
+	}
+	if repoName == "" {
+		return credentials
+	}
+	for _, suffix := range providedCredentialSuffixes[credentialsType] {
+		credentials.Values[suffix[0]] = &model.CredentialValue{
+			Source: model.CredentialProperty,
+			Value:  repoName + suffix[1],
+		}
+	}
+	return credentials
+}
+
+// parseCredentialsBlock 解析 credentials { } 块中的凭证值。
+func parseCredentialsBlock(credentialsType, body string) *model.RepositoryCredentials {
+	if credentialsType == "" {
+		credentialsType = model.CredentialsPassword
+	}
+	credentials := &model.RepositoryCredentials{
+		Type:   credentialsType,
+		Values: make(map[string]*model.CredentialValue),
+	}
+	for _, item := range splitClosure(body) {
+		if item.block {
+			continue
+		}
+		if m := credentialAssignRegex.FindStringSubmatch(item.statement); m != nil {
+			credentials.Values[m[1]] = ClassifyCredentialValue(m[2])
+		}
+	}
+	return credentials
+}
+
+// parseAuthentication 解析 authentication { } 块中的认证方式类型。
+func parseAuthentication(body string) []string {
+	types := make([]string, 0)
+	for _, m := range authenticationRegex.FindAllStringSubmatch(body, -1) {
+		if m[1] != "" {
+			types = append(types, m[1])
+		} else {
+			types = append(types, m[2])
+		}
+	}
+	return types
+}
+
+// ClassifyCredentialValue 识别凭证表达式的来源，例如字面量、环境变量或Gradle属性。
+func ClassifyCredentialValue(raw string) *model.CredentialValue {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "(") && strings.HasSuffix(raw, ")") {
+		raw = strings.TrimSpace(raw[1 : len(raw)-1])
+	}
+	value := classifyCredentialExpression(raw)
+	if value.Source == model.CredentialExpression {
+		// a ?: b 形式优先识别左侧的来源，左侧无法识别时使用右侧。
+		if idx := strings.Index(raw, "?:"); idx != -1 {
+			for _, operand := range []string{raw[:idx], raw[idx+2:]} {
+				if v := classifyCredentialExpression(strings.TrimSpace(operand)); v.Source != model.CredentialExpression {
+					value.Source, value.Value = v.Source, v.Value
+					break
+				}
+			}
+		}
+	}
+	return value
+}
+
+// classifyCredentialExpression 识别单个表达式的来源。
+func classifyCredentialExpression(raw string) *model.CredentialValue {
+	expr := strings.TrimSuffix(raw, ".get()")
+	expr = strings.TrimSuffix(expr, ".orNull")
+
+	value := &model.CredentialValue{Source: model.CredentialExpression, Raw: raw}
+	if m := literalValueRegex.FindStringSubmatch(expr); m != nil {
+		value.Source = model.CredentialLiteral
+		value.Value = firstNonEmpty(m[1:]...)
+	} else if m := envReferenceRegex.FindStringSubmatch(expr); m != nil {
+		value.Source = model.CredentialEnvironment
+		value.Value = firstNonEmpty(m[1:]...)
+	} else if m := systemPropertyReferenceRegex.FindStringSubmatch(expr); m != nil {
+		value.Source = model.CredentialSystemProperty
+		value.Value = firstNonEmpty(m[1:]...)
+	} else if m := propertyReferenceRegex.FindStringSubmatch(expr); m != nil {
+		value.Source = model.CredentialProperty
+		value.Value = firstNonEmpty(m[1:]...)
+	}
+	return value
+}
+
+// firstNonEmpty 返回第一个非空字符串。
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestClassifyCredentialValue(t *testing.T) {
+	tests := []struct {
+		raw    string
+		source model.CredentialSource
+		value  string
+	}{
+		{`'admin'`, model.CredentialLiteral, "admin"},
+		{`"secret"`, model.CredentialLiteral, "secret"},
+		{`System.getenv("REPO_USER")`, model.CredentialEnvironment, "REPO_USER"},
+		{`System.env.REPO_TOKEN`, model.CredentialEnvironment, "REPO_TOKEN"},
+		{`providers.environmentVariable("TOKEN").get()`, model.CredentialEnvironment, "TOKEN"},
+		{`System.getProperty('repo.user')`, model.CredentialSystemProperty, "repo.user"},
+		{`project.findProperty("gpr.user")`, model.CredentialProperty, "gpr.user"},
+		{`providers.gradleProperty('repoPassword').orNull`, model.CredentialProperty, "repoPassword"},
+		{`mavenPassword`, model.CredentialProperty, "mavenPassword"},
+		{`project.findProperty("gpr.key") ?: System.getenv("TOKEN")`, model.CredentialProperty, "gpr.key"},
+		{`"${token}"`, model.CredentialExpression, ""},
+	}
+
+	for _, tt := range tests {
+		got := ClassifyCredentialValue(tt.raw)
+		if got.Source != tt.source || got.Value != tt.value || got.Raw != tt.raw {
+			t.Errorf("ClassifyCredentialValue(%q) = %+v, want source %s value %q", tt.raw, got, tt.source, tt.value)
+		}
+	}
+}
+
+func TestExtractRepositoriesFromText_CredentialsAndAuthentication(t *testing.T) {
+	text := `repositories {
+    mavenCentral()
+    maven {
+        name = 'mySecureRepo'
+        url 'https://repo.example.com/releases'
+        credentials(PasswordCredentials)
+    }
+    maven {
+        name "GitLab"
+        url "https://gitlab.example.com/api/v4/packages/maven"
+        credentials(HttpHeaderCredentials) {
+            name = "Private-Token"
+            value = System.getenv("CI_JOB_TOKEN")
+        }
+        authentication {
+            header(HttpHeaderAuthentication)
+        }
+    }
+    maven {
+        url = uri("http://nexus.internal/repository/public")
+        allowInsecureProtocol = true
+        credentials {
+            username 'deployer'
+            password project.findProperty('nexusPassword')
+        }
+        authentication {
+            basic(BasicAuthentication)
+        }
+    }
+    exclusiveContent {
+        forRepository {
+            maven { url 'https://jitpack.io' }
+        }
+        filter { includeGroup 'com.github.foo' }
+    }
+}`
+
+	repos := NewRepositoryParser().ExtractRepositoriesFromText(text)
+	names := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		names = append(names, repo.Name)
+	}
+	wantNames := []string{"mavenCentral", "mySecureRepo", "GitLab", "nexus.internal", "jitpack.io"}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Fatalf("Repository names = %v, want %v", names, wantNames)
+	}
+
+	secure := repos[1]
+	if secure.Credentials == nil || !secure.Credentials.Provided || secure.Credentials.Type != model.CredentialsPassword {
+		t.Fatalf("Expected provided PasswordCredentials, got %+v", secure.Credentials)
+	}
+	if v := secure.Credentials.Values["password"]; v == nil || v.Source != model.CredentialProperty || v.Value != "mySecureRepoPassword" {
+		t.Errorf("Unexpected provided password: %+v", v)
+	}
+
+	gitlab := repos[2]
+	if gitlab.Credentials == nil || gitlab.Credentials.Type != model.CredentialsHTTPHeader || gitlab.Credentials.Provided {
+		t.Fatalf("Expected HttpHeaderCredentials block, got %+v", gitlab.Credentials)
+	}
+	if v := gitlab.Credentials.Values["value"]; v == nil || v.Source != model.CredentialEnvironment || v.Value != "CI_JOB_TOKEN" {
+		t.Errorf("Unexpected header value: %+v", v)
+	}
+	if !reflect.DeepEqual(gitlab.Authentication, []string{"HttpHeaderAuthentication"}) {
+		t.Errorf("Unexpected authentication: %v", gitlab.Authentication)
+	}
+
+	nexus := repos[3]
+	if !nexus.AllowInsecureProtocol || nexus.URL != "http://nexus.internal/repository/public" {
+		t.Errorf("Unexpected insecure repository: %+v", nexus)
+	}
+	if nexus.Username != "deployer" || nexus.Password != "" {
+		t.Errorf("Expected only literal username, got %q / %q", nexus.Username, nexus.Password)
+	}
+	if v := nexus.Credentials.Values["password"]; v == nil || v.Source != model.CredentialProperty || v.Value != "nexusPassword" {
+		t.Errorf("Unexpected password reference: %+v", v)
+	}
+	if !reflect.DeepEqual(nexus.Authentication, []string{"BasicAuthentication"}) {
+		t.Errorf("Unexpected authentication: %v", nexus.Authentication)
+	}
+}
+
+func TestExtractRepositoriesFromText_CredentialReferences(t *testing.T) {
+	text := `repositories {
+    maven {
+        url 'https://repo.example.com/releases'
+        credentials {
+            username = findProperty('repoUser')
+            password = System.getenv("TOKEN")
+        }
+    }
+    maven {
+        name = 'mySecureRepo'
+        url 'https://secure.example.com/maven'
+        credentials(PasswordCredentials)
+    }
+    maven {
+        url 'https://legacy.example.com/maven'
+        credentials {
+            username 'deployer'
+            password "${deployPassword}"
+        }
+    }
+}
+`
+	repos := NewRepositoryParser().ExtractRepositoriesFromText(text)
+	if len(repos) != 3 {
+		t.Fatalf("Expected 3 repositories, got %d", len(repos))
+	}
+
+	expected := []struct {
+		username, password       string
+		usernameRef, passwordRef string
+	}{
+		{"", "", "property:repoUser", "environment:TOKEN"},
+		{"", "", "property:mySecureRepoUsername", "property:mySecureRepoPassword"},
+		{"deployer", "", "", `"${deployPassword}"`},
+	}
+	for i, want := range expected {
+		repo := repos[i]
+		if repo.Username != want.username || repo.Password != want.password ||
+			repo.UsernameRef != want.usernameRef || repo.PasswordRef != want.passwordRef {
+			t.Errorf("Repository %d: got username %q/%q password %q/%q", i,
+				repo.Username, repo.UsernameRef, repo.Password, repo.PasswordRef)
+		}
+	}
+}
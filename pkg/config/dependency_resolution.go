@@ -0,0 +1,189 @@
+// Package config 提供settings文件中dependencyResolutionManagement配置的解析功能。
+package config
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配dependencyResolutionManagement块的开头。
+	dependencyResolutionBlockRegex = regexp.MustCompile(`\bdependencyResolutionManagement\s*\{`)
+
+	// 匹配 repositoriesMode.set(RepositoriesMode.FAIL_ON_PROJECT_REPOS) 或
+	// repositoriesMode = RepositoriesMode.PREFER_SETTINGS。
+	repositoriesModeRegex = regexp.MustCompile(`^repositoriesMode\b.*?\b(PREFER_PROJECT|PREFER_SETTINGS|FAIL_ON_PROJECT_REPOS)\b`)
+
+	// 匹配 create("libs") 或 create('libs') 形式的版本目录声明。
+	catalogCreateRegex = regexp.MustCompile(`^create\s*\(\s*['"]([^'"]+)['"]`)
+
+	// 匹配 from(files("gradle/libs.versions.toml")) 或 from("com.example:catalog:1.0")。
+	catalogFromRegex = regexp.MustCompile(`^from\s*\(?\s*(?:files\s*\(\s*)?['"]([^'"]+)['"]`)
+
+	// 匹配版本目录构建器的调用，例如 library("groovy-core", "org.codehaus.groovy:groovy:3.0.5")。
+	catalogBuilderRegex = regexp.MustCompile(`^(version|library|bundle|plugin)\s*\(`)
+
+	// 匹配链式调用中的版本，例如 .version("1.0")、.versionRef("groovy")。
+	chainedVersionRegex    = regexp.MustCompile(`\.version\s*\(\s*['"]([^'"]+)['"]`)
+	chainedVersionRefRegex = regexp.MustCompile(`\.versionRef\s*\(\s*['"]([^'"]+)['"]`)
+
+	// 匹配字符串字面量。
+	quotedStringRegex = regexp.MustCompile(`['"]([^'"]*)['"]`)
+)
+
+// ParseDependencyResolutionManagement 解析settings文件中的 dependencyResolutionManagement { } 块，
+// 包括 repositoriesMode、repositories { } 和 versionCatalogs { }。文本中没有该块时返回nil。
+func ParseDependencyResolutionManagement(text string) *model.DependencyResolutionManagement {
+	if !strings.Contains(text, "dependencyResolutionManagement") {
+		return nil
+	}
+	loc := dependencyResolutionBlockRegex.FindStringIndex(text)
+	if loc == nil {
+		return nil
+	}
+	open := loc[1] - 1
+	body := text[open+1 : matchingBrace(text, open)]
+
+	drm := &model.DependencyResolutionManagement{
+		Repositories: make([]*model.Repository, 0),
+	}
+
+	for _, item := range splitClosure(body) {
+		if !item.block {
+			if m := repositoriesModeRegex.FindStringSubmatch(item.statement); m != nil {
+				drm.RepositoriesMode = m[1]
+			}
+			continue
+		}
+
+		switch closureName(item.header) {
+		case "repositories":
+			drm.Repositories = append(drm.Repositories, parseRepositoriesBody(item.body)...)
+		case "versionCatalogs":
+			drm.VersionCatalogs = append(drm.VersionCatalogs, parseVersionCatalogs(item.body)...)
+		}
+	}
+
+	return drm
+}
+
+// parseVersionCatalogs 解析 versionCatalogs { } 块中的版本目录声明，
+// 支持Groovy的 libs { } 和Kotlin的 create("libs") { }。
+func parseVersionCatalogs(body string) []*model.CatalogReference {
+	refs := make([]*model.CatalogReference, 0)
+	for _, item := range splitClosure(body) {
+		if !item.block {
+			continue
+		}
+		name := closureName(item.header)
+		if m := catalogCreateRegex.FindStringSubmatch(item.header); m != nil {
+			name = m[1]
+		}
+		if name == "" {
+			continue
+		}
+
+		ref := &model.CatalogReference{Name: name}
+		for _, stmt := range splitClosure(item.body) {
+			if stmt.block {
+				continue
+			}
+			if m := catalogFromRegex.FindStringSubmatch(stmt.statement); m != nil {
+				ref.From = m[1]
+				continue
+			}
+			addCatalogBuilderCall(ref, stmt.statement)
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// addCatalogBuilderCall 把 version、library、bundle、plugin 调用加入版本目录。
+func addCatalogBuilderCall(ref *model.CatalogReference, statement string) {
+	m := catalogBuilderRegex.FindStringSubmatch(statement)
+	if m == nil {
+		return
+	}
+	open := len(m[0]) - 1
+	end := matchingParen(statement, open)
+	args := make([]string, 0)
+	for _, q := range quotedStringRegex.FindAllStringSubmatch(statement[open+1:end], -1) {
+		args = append(args, q[1])
+	}
+	chain := ""
+	if end < len(statement) {
+		chain = statement[end+1:]
+	}
+	if len(args) < 2 {
+		return
+	}
+
+	if ref.Catalog == nil {
+		ref.Catalog = &model.VersionCatalog{
+			Versions:  make(map[string]string),
+			Libraries: make([]*model.CatalogLibrary, 0),
+			Bundles:   make(map[string][]string),
+			Plugins:   make([]*model.CatalogPlugin, 0),
+		}
+	}
+	catalog := ref.Catalog
+
+	switch m[1] {
+	case "version":
+		catalog.Versions[args[0]] = args[1]
+	case "library":
+		lib := &model.CatalogLibrary{Alias: args[0]}
+		if parts := strings.SplitN(args[1], ":", 3); len(parts) >= 2 {
+			lib.Group, lib.Name = parts[0], parts[1]
+			if len(parts) == 3 {
+				lib.Version = parts[2]
+			}
+		} else if len(args) >= 3 {
+			lib.Group, lib.Name = args[1], args[2]
+		}
+		if lib.Group == "" || lib.Name == "" {
+			return
+		}
+		lib.Version, lib.VersionRef = chainedVersion(chain, lib.Version)
+		catalog.Libraries = append(catalog.Libraries, lib)
+	case "bundle":
+		catalog.Bundles[args[0]] = args[1:]
+	case "plugin":
+		plugin := &model.CatalogPlugin{Alias: args[0], ID: args[1]}
+		plugin.Version, plugin.VersionRef = chainedVersion(chain, "")
+		catalog.Plugins = append(catalog.Plugins, plugin)
+	}
+}
+
+// chainedVersion 返回链式调用 .version(...) 或 .versionRef(...) 给出的版本，没有时返回declared。
+func chainedVersion(chain, declared string) (version, versionRef string) {
+	if m := chainedVersionRefRegex.FindStringSubmatch(chain); m != nil {
+		return declared, m[1]
+	}
+	if m := chainedVersionRegex.FindStringSubmatch(chain); m != nil {
+		return m[1], ""
+	}
+	return declared, ""
+}
+
+// matchingParen 返回与open处左括号匹配的右括号位置，忽略字符串中的括号，未闭合时返回文本长度。
+func matchingParen(text string, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '\'', '"':
+			i = skipQuoted(text, i)
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(text)
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestParseDependencyResolutionManagement(t *testing.T) {
+	settings := `pluginManagement {
+    repositories {
+        gradlePluginPortal()
+    }
+}
+
+dependencyResolutionManagement {
+    repositoriesMode.set(RepositoriesMode.FAIL_ON_PROJECT_REPOS)
+    repositories {
+        mavenCentral()
+        maven { url 'https://nexus.example.com/repository/maven-public/' }
+    }
+    versionCatalogs {
+        libs {
+            from(files("gradle/libs.versions.toml"))
+        }
+        testLibs {
+            version('junit', '5.10.0')
+            library('junit-api', 'org.junit.jupiter', 'junit-jupiter-api').versionRef('junit')
+            library('assertj', 'org.assertj:assertj-core:3.24.2')
+            library('mockito', 'org.mockito', 'mockito-core').version('5.5.0')
+            bundle('testing', ['junit-api', 'assertj'])
+            plugin('versions', 'com.github.ben-manes.versions').version('0.48.0')
+        }
+        create("company") {
+            from("com.example:catalog:1.2")
+        }
+    }
+}
+
+rootProject.name = 'demo'
+`
+	drm := ParseDependencyResolutionManagement(settings)
+	if drm == nil {
+		t.Fatal("Expected dependencyResolutionManagement to be parsed")
+	}
+
+	if drm.RepositoriesMode != model.RepositoriesModeFailOnProjectRepos {
+		t.Errorf("RepositoriesMode = %q, want FAIL_ON_PROJECT_REPOS", drm.RepositoriesMode)
+	}
+	if len(drm.Repositories) != 2 || drm.Repositories[0].Name != "mavenCentral" ||
+		drm.Repositories[1].URL != "https://nexus.example.com/repository/maven-public/" {
+		t.Errorf("Unexpected repositories: %+v", drm.Repositories)
+	}
+
+	if len(drm.VersionCatalogs) != 3 {
+		t.Fatalf("Expected 3 version catalogs, got %d", len(drm.VersionCatalogs))
+	}
+	libs, testLibs, company := drm.VersionCatalogs[0], drm.VersionCatalogs[1], drm.VersionCatalogs[2]
+	if libs.Name != "libs" || libs.From != "gradle/libs.versions.toml" || libs.Catalog != nil {
+		t.Errorf("Unexpected libs catalog: %+v", libs)
+	}
+	if company.Name != "company" || company.From != "com.example:catalog:1.2" {
+		t.Errorf("Unexpected company catalog: %+v", company)
+	}
+
+	catalog := testLibs.Catalog
+	if testLibs.Name != "testLibs" || catalog == nil {
+		t.Fatalf("Unexpected testLibs catalog: %+v", testLibs)
+	}
+	if catalog.Versions["junit"] != "5.10.0" {
+		t.Errorf("Versions = %v", catalog.Versions)
+	}
+	wantLibs := []model.CatalogLibrary{
+		{Alias: "junit-api", Group: "org.junit.jupiter", Name: "junit-jupiter-api", VersionRef: "junit"},
+		{Alias: "assertj", Group: "org.assertj", Name: "assertj-core", Version: "3.24.2"},
+		{Alias: "mockito", Group: "org.mockito", Name: "mockito-core", Version: "5.5.0"},
+	}
+	if len(catalog.Libraries) != len(wantLibs) {
+		t.Fatalf("Expected %d libraries, got %d", len(wantLibs), len(catalog.Libraries))
+	}
+	for i, want := range wantLibs {
+		if *catalog.Libraries[i] != want {
+			t.Errorf("library %d = %+v, want %+v", i, *catalog.Libraries[i], want)
+		}
+	}
+	if !reflect.DeepEqual(catalog.Bundles["testing"], []string{"junit-api", "assertj"}) {
+		t.Errorf("Bundles = %v", catalog.Bundles)
+	}
+	if len(catalog.Plugins) != 1 || catalog.Plugins[0].ID != "com.github.ben-manes.versions" || catalog.Plugins[0].Version != "0.48.0" {
+		t.Errorf("Unexpected plugins: %+v", catalog.Plugins)
+	}
+	if catalog.ResolveVersion(catalog.Library("junit.api")) != "5.10.0" {
+		t.Errorf("junit-api should resolve to 5.10.0")
+	}
+}
+
+func TestParseDependencyResolutionManagementKotlin(t *testing.T) {
+	settings := `dependencyResolutionManagement {
+    repositoriesMode = RepositoriesMode.PREFER_SETTINGS
+    repositories {
+        google()
+    }
+}
+`
+	drm := ParseDependencyResolutionManagement(settings)
+	if drm == nil || drm.RepositoriesMode != model.RepositoriesModePreferSettings || len(drm.Repositories) != 1 {
+		t.Errorf("Unexpected result: %+v", drm)
+	}
+
+	if drm := ParseDependencyResolutionManagement("rootProject.name = 'demo'\n"); drm != nil {
+		t.Errorf("Expected nil without dependencyResolutionManagement, got %+v", drm)
+	}
+}
@@ -0,0 +1,154 @@
+// Package config 提供根据插件和DSL写法推断构建所需Gradle版本范围的功能。
+package config
+
+import (
+	"fmt"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/util"
+)
+
+// GradleConstructRequirement 表示某种DSL写法对Gradle版本的要求。
+// MinGradleVersion 是引入该写法的版本，MaxGradleVersion 是移除该写法的版本（不含），未知时为空。
+type GradleConstructRequirement struct {
+	Construct        string `json:"construct"`
+	MinGradleVersion string `json:"minGradleVersion,omitempty"`
+	MaxGradleVersion string `json:"maxGradleVersion,omitempty"`
+	Reason           string `json:"reason"`
+}
+
+// 可识别的DSL写法。
+const (
+	ConstructKotlinDSL                      = "kotlin-dsl"
+	ConstructToolchains                     = "toolchains"
+	ConstructVersionCatalog                 = "version-catalog"
+	ConstructDependencyResolutionManagement = "dependency-resolution-management"
+	ConstructRemovedConfiguration           = "removed-configuration"
+	ConstructMavenPlugin                    = "maven-plugin"
+	ConstructJCenter                        = "jcenter"
+)
+
+// KnownConstructRequirements 是DSL写法与Gradle版本的兼容表。
+var KnownConstructRequirements = map[string]GradleConstructRequirement{
+	ConstructKotlinDSL:                      {Construct: ConstructKotlinDSL, MinGradleVersion: "5.0", Reason: "Kotlin DSL 1.0 随 Gradle 5.0 发布"},
+	ConstructToolchains:                     {Construct: ConstructToolchains, MinGradleVersion: "6.7", Reason: "Java工具链从 Gradle 6.7 开始支持"},
+	ConstructVersionCatalog:                 {Construct: ConstructVersionCatalog, MinGradleVersion: "7.4", Reason: "版本目录从 Gradle 7.4 起成为稳定特性"},
+	ConstructDependencyResolutionManagement: {Construct: ConstructDependencyResolutionManagement, MinGradleVersion: "6.8", Reason: "dependencyResolutionManagement 从 Gradle 6.8 开始支持"},
+	ConstructRemovedConfiguration:           {Construct: ConstructRemovedConfiguration, MaxGradleVersion: "7.0", Reason: "compile、runtime、testCompile、testRuntime 配置在 Gradle 7.0 中移除"},
+	ConstructMavenPlugin:                    {Construct: ConstructMavenPlugin, MaxGradleVersion: "7.0", Reason: "maven 插件在 Gradle 7.0 中移除，应改用 maven-publish"},
+	ConstructJCenter:                        {Construct: ConstructJCenter, MaxGradleVersion: "9.0", Reason: "jcenter() 在 Gradle 9.0 中移除"},
+}
+
+// Gradle 7.0 移除的依赖配置。
+var removedConfigurations = map[string]bool{
+	"compile":     true,
+	"runtime":     true,
+	"testCompile": true,
+	"testRuntime": true,
+}
+
+// GradleVersionConstraint 表示推断Gradle版本范围时的一条依据。
+type GradleVersionConstraint struct {
+	Source           string `json:"source"` // 插件ID或DSL写法，例如 org.springframework.boot、toolchains。
+	MinGradleVersion string `json:"minGradleVersion,omitempty"`
+	MaxGradleVersion string `json:"maxGradleVersion,omitempty"` // 不再支持的第一个版本（不含）。
+	Reason           string `json:"reason"`
+}
+
+// GradleVersionRange 表示构建大致支持的Gradle版本范围。
+type GradleVersionRange struct {
+	// MinGradleVersion 是所有依据中最高的最低版本，没有依据时为空。
+	MinGradleVersion string `json:"minGradleVersion,omitempty"`
+	// MaxGradleVersion 是所有依据中最低的上限（不含），没有依据时为空。
+	MaxGradleVersion string                     `json:"maxGradleVersion,omitempty"`
+	Constraints      []*GradleVersionConstraint `json:"constraints"`
+}
+
+// Satisfiable 判断版本范围是否非空。最低版本不低于上限时，说明构建中的写法相互矛盾。
+func (r *GradleVersionRange) Satisfiable() bool {
+	return r.MinGradleVersion == "" || r.MaxGradleVersion == "" ||
+		util.CompareVersions(r.MinGradleVersion, r.MaxGradleVersion) < 0
+}
+
+// InferGradleVersionRange 根据插件版本（见 KnownGradleRequirements）和DSL写法（见 KnownConstructRequirements）
+// 推断构建支持的Gradle版本范围。这是启发式的结果：只覆盖兼容表中的插件和写法。
+// 工具链和版本目录的使用情况来自解析器填充的 project.Features，Kotlin DSL 由文件扩展名判断。
+func InferGradleVersionRange(project *model.Project) *GradleVersionRange {
+	result := &GradleVersionRange{Constraints: make([]*GradleVersionConstraint, 0)}
+	if project == nil {
+		return result
+	}
+
+	for _, plugin := range project.Plugins {
+		if plugin == nil {
+			continue
+		}
+		if plugin.ID == "maven" {
+			result.addConstruct(ConstructMavenPlugin)
+			continue
+		}
+		if plugin.Version == "" {
+			continue
+		}
+		if required := RequiredGradleVersion(plugin.ID, plugin.Version); required != "" {
+			result.add(&GradleVersionConstraint{
+				Source:           plugin.ID,
+				MinGradleVersion: required,
+				Reason:           fmt.Sprintf("插件 %s %s 需要 Gradle %s 及以上版本", plugin.ID, plugin.Version, required),
+			})
+		}
+	}
+
+	features := project.Features
+	if util.IsKotlinDSL(project.FilePath) || features != nil && features.KotlinDSL {
+		result.addConstruct(ConstructKotlinDSL)
+	}
+	if features != nil {
+		if features.Toolchains {
+			result.addConstruct(ConstructToolchains)
+		}
+		if features.VersionCatalog {
+			result.addConstruct(ConstructVersionCatalog)
+		}
+	}
+	if project.DependencyResolutionManagement != nil {
+		result.addConstruct(ConstructDependencyResolutionManagement)
+	}
+
+	for _, dep := range project.Dependencies {
+		if dep != nil && removedConfigurations[dep.Scope] {
+			result.addConstruct(ConstructRemovedConfiguration)
+			break
+		}
+	}
+	for _, repo := range project.Repositories {
+		if repo != nil && repo.Name == jcenterRepo {
+			result.addConstruct(ConstructJCenter)
+			break
+		}
+	}
+
+	return result
+}
+
+// addConstruct 按兼容表加入一条DSL写法的依据。
+func (r *GradleVersionRange) addConstruct(construct string) {
+	req := KnownConstructRequirements[construct]
+	r.add(&GradleVersionConstraint{
+		Source:           construct,
+		MinGradleVersion: req.MinGradleVersion,
+		MaxGradleVersion: req.MaxGradleVersion,
+		Reason:           req.Reason,
+	})
+}
+
+// add 加入一条依据并收紧版本范围。
+func (r *GradleVersionRange) add(constraint *GradleVersionConstraint) {
+	r.Constraints = append(r.Constraints, constraint)
+	if v := constraint.MinGradleVersion; v != "" && (r.MinGradleVersion == "" || util.CompareVersions(v, r.MinGradleVersion) > 0) {
+		r.MinGradleVersion = v
+	}
+	if v := constraint.MaxGradleVersion; v != "" && (r.MaxGradleVersion == "" || util.CompareVersions(v, r.MaxGradleVersion) < 0) {
+		r.MaxGradleVersion = v
+	}
+}
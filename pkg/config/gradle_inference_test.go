@@ -0,0 +1,56 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestInferGradleVersionRange(t *testing.T) {
+	project := &model.Project{
+		FilePath: "app/build.gradle.kts",
+		Plugins: []*model.Plugin{
+			{ID: "com.android.application", Version: "8.3.0"},
+			{ID: "org.jetbrains.kotlin.android", Version: "1.9.22"},
+			{ID: "java"},
+		},
+		Features:     &model.Fingerprint{Toolchains: true, VersionCatalog: true},
+		Repositories: []*model.Repository{{Name: "jcenter", Type: "maven"}},
+	}
+
+	result := InferGradleVersionRange(project)
+	if result.MinGradleVersion != "8.4" || result.MaxGradleVersion != "9.0" || !result.Satisfiable() {
+		t.Errorf("Unexpected range: %s - %s", result.MinGradleVersion, result.MaxGradleVersion)
+	}
+	sources := make([]string, 0, len(result.Constraints))
+	for _, c := range result.Constraints {
+		sources = append(sources, c.Source)
+	}
+	expected := []string{"com.android.application", "org.jetbrains.kotlin.android", ConstructKotlinDSL, ConstructToolchains, ConstructVersionCatalog, ConstructJCenter}
+	if len(sources) != len(expected) {
+		t.Fatalf("Expected constraints %v, got %v", expected, sources)
+	}
+	for i := range expected {
+		if sources[i] != expected[i] {
+			t.Errorf("Constraint %d = %s, want %s", i, sources[i], expected[i])
+		}
+	}
+}
+
+func TestInferGradleVersionRangeConflicting(t *testing.T) {
+	result := InferGradleVersionRange(&model.Project{
+		Plugins:                        []*model.Plugin{{ID: "org.springframework.boot", Version: "3.1.0"}, {ID: "maven"}},
+		Dependencies:                   []*model.Dependency{{Group: "junit", Name: "junit", Version: "4.13.2", Scope: "testCompile"}},
+		DependencyResolutionManagement: &model.DependencyResolutionManagement{},
+	})
+	if result.MinGradleVersion != "7.5" || result.MaxGradleVersion != "7.0" || result.Satisfiable() {
+		t.Errorf("Expected unsatisfiable 7.5 - 7.0 range, got %s - %s", result.MinGradleVersion, result.MaxGradleVersion)
+	}
+	if len(result.Constraints) != 4 {
+		t.Errorf("Expected 4 constraints, got %d", len(result.Constraints))
+	}
+
+	if empty := InferGradleVersionRange(nil); empty.MinGradleVersion != "" || !empty.Satisfiable() {
+		t.Errorf("Expected empty range for nil project, got %+v", empty)
+	}
+}
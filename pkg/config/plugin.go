@@ -23,22 +23,69 @@ const (
 var (
 	// 匹配插件ID的正则表达式。
 	// 例如: id 'com.android.application' version '7.0.0'。
-	// 或者: id("org.jetbrains.kotlin.android") version "1.5.30"。
-	pluginRegex = regexp.MustCompile(`id\s*\(?['"](.*?)['"](\))?(\s+version\s*['"](.*?)['"])?`)
+	// 或者: id("org.jetbrains.kotlin.android") version "1.5.30" apply false。
+	pluginRegex = regexp.MustCompile(`id\s*\(?['"](.*?)['"](\))?(\s+version\s*['"](.*?)['"])?(\s+apply\s*\(?\s*(true|false)\s*\)?)?`)
 
 	// 匹配apply plugin的正则表达式。
 	// 例如: apply plugin: 'java'。
 	applyPluginRegex = regexp.MustCompile(`apply\s+plugin:\s*['"](.*?)['"]`)
+
+	// 匹配以插件类应用的apply plugin。
+	// 例如: apply plugin: JavaPlugin 或 apply plugin: org.gradle.api.plugins.JavaPlugin。
+	applyPluginClassRegex = regexp.MustCompile(`\bapply\s+plugin:\s*([A-Za-z_][\w.]*)`)
+
+	// 匹配Kotlin DSL的apply。
+	// 例如: apply(plugin = "java-library")。
+	applyKotlinRegex = regexp.MustCompile(`\bapply\s*\(\s*plugin\s*=\s*"([^"]+)"\s*\)`)
+
+	// 匹配对插件容器或插件管理器的apply调用，参数为插件ID或插件类。
+	// 例如: plugins.apply("java")、project.pluginManager.apply 'java' 或 plugins.apply(JavaPlugin::class.java)。
+	pluginsApplyRegex = regexp.MustCompile(
+		`\b(?:plugins|pluginManager)\.apply\s*\(?\s*(?:['"]([^'"]+)['"]|([A-Za-z_][\w.]*?)(?:::class(?:\.java)?|\.class)?\s*(?:\)|$))`)
 )
 
+// corePluginClasses 是Gradle核心插件类名到插件ID的映射。
+var corePluginClasses = map[string]string{
+	"BasePlugin":             "base",
+	"JavaBasePlugin":         "java-base",
+	"JavaPlugin":             "java",
+	"JavaLibraryPlugin":      "java-library",
+	"JavaPlatformPlugin":     "java-platform",
+	"ApplicationPlugin":      "application",
+	"GroovyPlugin":           "groovy",
+	"ScalaPlugin":            "scala",
+	"WarPlugin":              "war",
+	"EarPlugin":              "ear",
+	"DistributionPlugin":     "distribution",
+	"JavaGradlePluginPlugin": "java-gradle-plugin",
+	"MavenPublishPlugin":     "maven-publish",
+	"IvyPublishPlugin":       "ivy-publish",
+	"SigningPlugin":          "signing",
+	"JacocoPlugin":           "jacoco",
+	"CheckstylePlugin":       "checkstyle",
+	"PmdPlugin":              "pmd",
+	"CodeNarcPlugin":         "codenarc",
+	"IdeaPlugin":             "idea",
+	"EclipsePlugin":          "eclipse",
+}
+
 // PluginParser 处理Gradle插件解析.
-type PluginParser struct{}
+type PluginParser struct {
+	// 是否为提取出的插件记录声明所在的行号。
+	lineNumbers bool
+}
 
 // NewPluginParser 创建新的插件解析器.
 func NewPluginParser() *PluginParser {
 	return &PluginParser{}
 }
 
+// WithLineNumbers 设置 ExtractPluginsFromText 是否在 Plugin.DeclaredAtLine 中记录声明所在的行号，默认关闭.
+func (pp *PluginParser) WithLineNumbers(track bool) *PluginParser {
+	pp.lineNumbers = track
+	return pp
+}
+
 // ParsePluginBlock 解析插件块.
 func (pp *PluginParser) ParsePluginBlock(block *model.ScriptBlock) ([]*model.Plugin, error) {
 	if block == nil {
@@ -51,17 +98,7 @@ func (pp *PluginParser) ParsePluginBlock(block *model.ScriptBlock) ([]*model.Plu
 	for _, value := range block.Values {
 		valueStr := fmt.Sprintf("%v", value)
 		if matches := pluginRegex.FindStringSubmatch(valueStr); len(matches) > 1 {
-			plugin := &model.Plugin{
-				ID:    matches[1],
-				Apply: true,
-			}
-
-			// 检查是否有版本信息。
-			if len(matches) > 4 && matches[4] != "" {
-				plugin.Version = matches[4]
-			}
-
-			plugins = append(plugins, plugin)
+			plugins = append(plugins, pluginFromMatch(matches))
 		}
 	}
 
@@ -75,6 +112,7 @@ func (pp *PluginParser) ParsePluginBlock(block *model.ScriptBlock) ([]*model.Plu
 					plugin := &model.Plugin{
 						ID:    valueStr,
 						Apply: true,
+						Style: model.PluginStylePluginsBlock,
 					}
 					plugins = append(plugins, plugin)
 				}
@@ -92,30 +130,20 @@ func (pp *PluginParser) ExtractPluginsFromText(text string) []*model.Plugin {
 	// 分析文本中的插件声明。
 	lines := strings.Split(text, "\n")
 
-	for _, line := range lines {
+	for i, line := range lines {
 		trimmedLine := strings.TrimSpace(line)
-
-		// 检查plugins块中的插件声明。
-		if matches := pluginRegex.FindStringSubmatch(trimmedLine); len(matches) > 1 {
-			plugin := &model.Plugin{
-				ID:    matches[1],
-				Apply: true,
-			}
-
-			// 检查是否有版本信息。
-			if len(matches) > 4 && matches[4] != "" {
-				plugin.Version = matches[4]
-			}
-
-			plugins = append(plugins, plugin)
+		lineNumber := 0
+		if pp.lineNumbers {
+			lineNumber = i + 1
 		}
 
-		// 检查apply plugin语句。
-		if matches := applyPluginRegex.FindStringSubmatch(trimmedLine); len(matches) > 1 {
-			plugin := &model.Plugin{
-				ID:    matches[1],
-				Apply: true,
-			}
+		// 依次检查各种apply写法和plugins块中的插件声明，apply写法优先，避免插件ID中的文本被误认为 id 声明。
+		if plugin := applyPluginFromLine(trimmedLine); plugin != nil {
+			plugin.DeclaredAtLine = lineNumber
+			plugins = append(plugins, plugin)
+		} else if matches := pluginRegex.FindStringSubmatch(trimmedLine); len(matches) > 1 {
+			plugin := pluginFromMatch(matches)
+			plugin.DeclaredAtLine = lineNumber
 			plugins = append(plugins, plugin)
 		}
 	}
@@ -123,6 +151,50 @@ func (pp *PluginParser) ExtractPluginsFromText(text string) []*model.Plugin {
 	return plugins
 }
 
+// pluginFromMatch 根据pluginRegex的匹配结果创建插件，带 apply false 的插件只声明版本而不应用。
+func pluginFromMatch(matches []string) *model.Plugin {
+	return &model.Plugin{
+		ID:      matches[1],
+		Version: matches[4],
+		Apply:   matches[6] != "false",
+		Style:   model.PluginStylePluginsBlock,
+	}
+}
+
+// applyPluginFromLine 识别一行中以 apply plugin:、apply(plugin = ...) 或 plugins.apply(...) 应用的插件，不是这些写法时返回nil。
+func applyPluginFromLine(line string) *model.Plugin {
+	// 各写法都包含 apply，先过滤掉大多数行，避免每行都运行这些正则。
+	if !strings.Contains(line, "apply") {
+		return nil
+	}
+	if matches := applyPluginRegex.FindStringSubmatch(line); len(matches) > 1 {
+		return &model.Plugin{ID: matches[1], Apply: true, Style: model.PluginStyleApplyPlugin}
+	}
+	if matches := applyPluginClassRegex.FindStringSubmatch(line); len(matches) > 1 {
+		return pluginFromClass(matches[1], model.PluginStyleApplyPlugin)
+	}
+	if matches := applyKotlinRegex.FindStringSubmatch(line); len(matches) > 1 {
+		return &model.Plugin{ID: matches[1], Apply: true, Style: model.PluginStyleApplyKotlin}
+	}
+	if matches := pluginsApplyRegex.FindStringSubmatch(line); len(matches) > 2 {
+		if matches[1] != "" {
+			return &model.Plugin{ID: matches[1], Apply: true, Style: model.PluginStylePluginsApply}
+		}
+		return pluginFromClass(matches[2], model.PluginStylePluginsApply)
+	}
+	return nil
+}
+
+// pluginFromClass 根据插件类名创建插件，Gradle核心插件类使用对应的插件ID。
+func pluginFromClass(className string, style model.PluginStyle) *model.Plugin {
+	plugin := &model.Plugin{ID: className, Apply: true, Style: style, Class: className}
+	simpleName := className[strings.LastIndex(className, ".")+1:]
+	if id, ok := corePluginClasses[simpleName]; ok {
+		plugin.ID = id
+	}
+	return plugin
+}
+
 // GetPluginConfigurations 获取插件相关的配置块.
 func (pp *PluginParser) GetPluginConfigurations(
 	rootBlock *model.ScriptBlock,
@@ -0,0 +1,78 @@
+// Package config 提供传统 buildscript classpath 与 apply plugin 声明的关联功能。
+package config
+
+import (
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// pluginClasspathArtifacts 记录常见插件ID由哪个buildscript classpath制品（group:name）提供。
+var pluginClasspathArtifacts = map[string]string{
+	androidApplicationPlugin:                    "com.android.tools.build:gradle",
+	androidLibraryPlugin:                        "com.android.tools.build:gradle",
+	"com.android.test":                          "com.android.tools.build:gradle",
+	"com.android.dynamic-feature":               "com.android.tools.build:gradle",
+	"android":                                   "com.android.tools.build:gradle",
+	"android-library":                           "com.android.tools.build:gradle",
+	"org.springframework.boot":                  "org.springframework.boot:spring-boot-gradle-plugin",
+	"io.spring.dependency-management":           "io.spring.gradle:dependency-management-plugin",
+	"com.google.gms.google-services":            "com.google.gms:google-services",
+	"com.google.firebase.crashlytics":           "com.google.firebase:firebase-crashlytics-gradle",
+	"dagger.hilt.android.plugin":                "com.google.dagger:hilt-android-gradle-plugin",
+	"com.google.dagger.hilt.android":            "com.google.dagger:hilt-android-gradle-plugin",
+	"androidx.navigation.safeargs":              "androidx.navigation:navigation-safe-args-gradle-plugin",
+	"androidx.navigation.safeargs.kotlin":       "androidx.navigation:navigation-safe-args-gradle-plugin",
+	"kotlinx-serialization":                     "org.jetbrains.kotlin:kotlin-serialization",
+	"org.jetbrains.kotlin.plugin.serialization": "org.jetbrains.kotlin:kotlin-serialization",
+	"org.jetbrains.kotlin.plugin.spring":        "org.jetbrains.kotlin:kotlin-allopen",
+	"kotlin-spring":                             "org.jetbrains.kotlin:kotlin-allopen",
+	"kotlin-allopen":                            "org.jetbrains.kotlin:kotlin-allopen",
+	"org.jetbrains.kotlin.plugin.jpa":           "org.jetbrains.kotlin:kotlin-noarg",
+	"kotlin-jpa":                                "org.jetbrains.kotlin:kotlin-noarg",
+	"kotlin-noarg":                              "org.jetbrains.kotlin:kotlin-noarg",
+}
+
+// kotlinGradlePlugin 是提供其余Kotlin插件（kotlin、kotlin-android、org.jetbrains.kotlin.jvm 等）的制品。
+const kotlinGradlePlugin = "org.jetbrains.kotlin:kotlin-gradle-plugin"
+
+// PluginClasspathArtifact 返回提供指定插件的buildscript classpath制品（group:name），未知插件返回空字符串。
+// 插件标记制品 <id>:<id>.gradle.plugin 总是可以提供插件，不在返回值中。
+func PluginClasspathArtifact(pluginID string) string {
+	if artifact, ok := pluginClasspathArtifacts[pluginID]; ok {
+		return artifact
+	}
+	if pluginID == kotlinPlugin || strings.HasPrefix(pluginID, "kotlin-") || strings.HasPrefix(pluginID, "org.jetbrains.kotlin.") {
+		return kotlinGradlePlugin
+	}
+	return ""
+}
+
+// ResolvePluginClasspathVersions 为没有版本的插件（例如 apply plugin: 'com.android.application'）补全版本，
+// 版本取自提供该插件的buildscript classpath依赖，并在Plugin.Classpath中记录该依赖的坐标。
+// 依赖版本中的变量已解析时使用解析后的版本，仍含有未解析的变量时不补全。
+func ResolvePluginClasspathVersions(plugins []*model.Plugin, classpath []*model.Dependency) {
+	for _, plugin := range plugins {
+		if plugin == nil || plugin.Version != "" {
+			continue
+		}
+		artifact := PluginClasspathArtifact(plugin.ID)
+		marker := plugin.ID + ":" + plugin.ID + ".gradle.plugin"
+		for _, dep := range classpath {
+			coordinate := dep.Group + ":" + dep.Name
+			if coordinate != artifact && coordinate != marker {
+				continue
+			}
+			version := dep.Version
+			if dep.ResolvedVersion != "" {
+				version = dep.ResolvedVersion
+			}
+			if version == "" || strings.Contains(version, "$") {
+				continue
+			}
+			plugin.Version = version
+			plugin.Classpath = coordinate + ":" + version
+			break
+		}
+	}
+}
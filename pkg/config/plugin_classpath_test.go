@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestPluginClasspathArtifact(t *testing.T) {
+	tests := map[string]string{
+		"com.android.library":      "com.android.tools.build:gradle",
+		"kotlin":                   "org.jetbrains.kotlin:kotlin-gradle-plugin",
+		"org.jetbrains.kotlin.jvm": "org.jetbrains.kotlin:kotlin-gradle-plugin",
+		"org.jetbrains.kotlin.plugin.serialization": "org.jetbrains.kotlin:kotlin-serialization",
+		"org.springframework.boot":                  "org.springframework.boot:spring-boot-gradle-plugin",
+		"java":                                      "",
+	}
+	for id, expected := range tests {
+		if got := PluginClasspathArtifact(id); got != expected {
+			t.Errorf("PluginClasspathArtifact(%q) = %q, want %q", id, got, expected)
+		}
+	}
+}
+
+func TestResolvePluginClasspathVersions(t *testing.T) {
+	plugins := []*model.Plugin{
+		{ID: "org.springframework.boot", Apply: true},
+		{ID: "kotlin", Apply: true},
+		{ID: "com.android.application", Version: "8.0.0", Apply: true},
+	}
+	classpath := []*model.Dependency{
+		{Group: "org.springframework.boot", Name: "spring-boot-gradle-plugin", Version: "2.7.18"},
+		{Group: "org.jetbrains.kotlin", Name: "kotlin-gradle-plugin", Version: "$kotlinVersion", HasDynamicVersion: true},
+		{Group: "com.android.tools.build", Name: "gradle", Version: "7.4.2"},
+	}
+
+	ResolvePluginClasspathVersions(plugins, classpath)
+
+	if plugins[0].Version != "2.7.18" || plugins[0].Classpath != "org.springframework.boot:spring-boot-gradle-plugin:2.7.18" {
+		t.Errorf("Unexpected Spring Boot plugin %+v", plugins[0])
+	}
+	if plugins[1].Version != "" || plugins[1].Classpath != "" {
+		t.Errorf("Unresolved variable versions should not be used, got %+v", plugins[1])
+	}
+	if plugins[2].Version != "8.0.0" || plugins[2].Classpath != "" {
+		t.Errorf("Declared versions should be kept, got %+v", plugins[2])
+	}
+
+	classpath[1].ResolvedVersion = "1.9.22"
+	ResolvePluginClasspathVersions(plugins, classpath)
+	if plugins[1].Version != "1.9.22" {
+		t.Errorf("Expected the resolved version to be used, got %+v", plugins[1])
+	}
+}
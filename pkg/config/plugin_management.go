@@ -0,0 +1,171 @@
+// Package config 提供settings文件中pluginManagement配置的解析功能。
+package config
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配pluginManagement块的开头。
+	pluginManagementBlockRegex = regexp.MustCompile(`\bpluginManagement\s*\{`)
+
+	// 匹配 includeBuild('build-logic') 或 includeBuild "build-logic"。
+	includeBuildRegex = regexp.MustCompile(`^includeBuild\s*\(?\s*['"]([^'"]+)['"]`)
+
+	// 匹配eachPlugin规则中的条件和动作。
+	requestedIDRegex        = regexp.MustCompile(`requested\.id\.id\s*==\s*['"]([^'"]+)['"]`)
+	requestedNamespaceRegex = regexp.MustCompile(`requested\.id\.namespace\s*==\s*['"]([^'"]+)['"]`)
+	useModuleRegex          = regexp.MustCompile(`\buseModule\s*\(?\s*(['"])(.+?)['"]`)
+	useVersionRegex         = regexp.MustCompile(`\buseVersion\s*\(?\s*['"]([^'"]+)['"]`)
+
+	// 匹配Kotlin DSL中 when (requested.id.id) 的分支，例如 "com.foo" -> useModule("...")。
+	whenBranchRegex = regexp.MustCompile(`^['"]([^'"]+)['"]\s*->\s*(.+)$`)
+)
+
+// ParsePluginManagement 解析settings文件中的 pluginManagement { } 块。
+// 文本中没有pluginManagement块时返回nil。
+func ParsePluginManagement(text string) *model.PluginManagement {
+	if !strings.Contains(text, "pluginManagement") {
+		return nil
+	}
+	loc := pluginManagementBlockRegex.FindStringIndex(text)
+	if loc == nil {
+		return nil
+	}
+	open := loc[1] - 1
+	body := text[open+1 : matchingBrace(text, open)]
+
+	pm := &model.PluginManagement{
+		Repositories: make([]*model.Repository, 0),
+		Plugins:      make([]*model.Plugin, 0),
+	}
+
+	for _, item := range splitClosure(body) {
+		if !item.block {
+			if m := includeBuildRegex.FindStringSubmatch(item.statement); m != nil {
+				pm.IncludedBuilds = append(pm.IncludedBuilds, m[1])
+			}
+			continue
+		}
+
+		switch closureName(item.header) {
+		case "repositories":
+			pm.Repositories = append(pm.Repositories, parseRepositoriesBody(item.body)...)
+		case "plugins":
+			pm.Plugins = append(pm.Plugins, parsePluginDeclarations(item.body)...)
+		case "resolutionStrategy":
+			for _, strategy := range splitClosure(item.body) {
+				if strategy.block && closureName(strategy.header) == "eachPlugin" {
+					pm.ResolutionRules = append(pm.ResolutionRules, parseEachPlugin(strategy.body)...)
+				}
+			}
+		}
+	}
+
+	return pm
+}
+
+// parsePluginDeclarations 解析plugins块体中的插件声明。
+func parsePluginDeclarations(body string) []*model.Plugin {
+	plugins := make([]*model.Plugin, 0)
+	for _, item := range splitClosure(body) {
+		if item.block {
+			continue
+		}
+		if matches := pluginRegex.FindStringSubmatch(item.statement); len(matches) > 1 {
+			plugin := &model.Plugin{ID: matches[1], Apply: true}
+			if len(matches) > 4 && matches[4] != "" {
+				plugin.Version = matches[4]
+			}
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins
+}
+
+// parseEachPlugin 解析 eachPlugin { } 块中的规则。
+// 支持无条件的 useModule/useVersion、if (requested.id...) 条件以及Kotlin的 when 分支。
+func parseEachPlugin(body string) []*model.PluginResolutionRule {
+	rules := make([]*model.PluginResolutionRule, 0)
+	for _, item := range splitClosure(body) {
+		if !item.block {
+			condition, action := splitCondition(item.statement)
+			if rule := newResolutionRule(condition, action); rule != nil {
+				rules = append(rules, rule)
+			}
+			continue
+		}
+
+		header := strings.TrimSpace(strings.TrimPrefix(item.header, "else"))
+		switch closureName(header) {
+		case "if":
+			condition, _ := splitCondition(header)
+			if rule := newResolutionRule(condition, item.body); rule != nil {
+				rules = append(rules, rule)
+			}
+		case "when":
+			if !strings.Contains(header, "requested.id.id") {
+				continue
+			}
+			for _, branch := range splitClosure(item.body) {
+				if m := whenBranchRegex.FindStringSubmatch(branch.statement); m != nil {
+					condition := "requested.id.id == '" + m[1] + "'"
+					if rule := newResolutionRule(condition, m[2]); rule != nil {
+						rules = append(rules, rule)
+					}
+				}
+			}
+		}
+	}
+	return rules
+}
+
+// splitCondition 将 if (cond) action 形式的语句拆分为条件和动作，不是if语句时条件为空。
+func splitCondition(statement string) (string, string) {
+	if closureName(statement) != "if" {
+		return "", statement
+	}
+	open := strings.IndexByte(statement, '(')
+	if open == -1 {
+		return "", statement
+	}
+	depth := 0
+	for i := open; i < len(statement); i++ {
+		switch statement[i] {
+		case '\'', '"':
+			i = skipQuoted(statement, i)
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(statement[open+1 : i]), strings.TrimSpace(statement[i+1:])
+			}
+		}
+	}
+	return strings.TrimSpace(statement[open+1:]), ""
+}
+
+// newResolutionRule 根据条件和动作创建规则，动作中没有useModule或useVersion时返回nil。
+func newResolutionRule(condition, action string) *model.PluginResolutionRule {
+	rule := &model.PluginResolutionRule{Condition: condition}
+	if m := useModuleRegex.FindStringSubmatch(action); m != nil {
+		rule.UseModule = m[2]
+	}
+	if m := useVersionRegex.FindStringSubmatch(action); m != nil {
+		rule.UseVersion = m[1]
+	}
+	if rule.UseModule == "" && rule.UseVersion == "" {
+		return nil
+	}
+	if m := requestedIDRegex.FindStringSubmatch(condition); m != nil {
+		rule.PluginID = m[1]
+	}
+	if m := requestedNamespaceRegex.FindStringSubmatch(condition); m != nil {
+		rule.Namespace = m[1]
+	}
+	return rule
+}
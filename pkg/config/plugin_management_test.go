@@ -0,0 +1,95 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+const settingsWithPluginManagement = `pluginManagement {
+    includeBuild("build-logic")
+    repositories {
+        maven { url 'https://plugins.example.com/m2' }
+        gradlePluginPortal()
+        google()
+    }
+    plugins {
+        id 'org.springframework.boot' version '3.1.0'
+        id("org.jetbrains.kotlin.jvm") version "1.9.0"
+    }
+    resolutionStrategy {
+        eachPlugin {
+            if (requested.id.namespace == 'com.android') {
+                useModule("com.android.tools.build:gradle:${requested.version}")
+            } else if (requested.id.id == 'com.example.legacy') {
+                useModule('com.example:legacy-plugin:2.0')
+                useVersion('2.0')
+            }
+            if (requested.id.id == "io.freefair.lombok") useVersion("8.0.1")
+        }
+    }
+}
+
+rootProject.name = 'demo'
+`
+
+func TestParsePluginManagement(t *testing.T) {
+	pm := ParsePluginManagement(settingsWithPluginManagement)
+	if pm == nil {
+		t.Fatal("Expected pluginManagement to be parsed")
+	}
+
+	if !reflect.DeepEqual(pm.IncludedBuilds, []string{"build-logic"}) {
+		t.Errorf("IncludedBuilds = %v", pm.IncludedBuilds)
+	}
+
+	repoNames := make([]string, 0, len(pm.Repositories))
+	for _, repo := range pm.Repositories {
+		repoNames = append(repoNames, repo.Name)
+	}
+	if !reflect.DeepEqual(repoNames, []string{"plugins.example.com", "gradlePluginPortal", "google"}) {
+		t.Errorf("Repository names = %v", repoNames)
+	}
+
+	if len(pm.Plugins) != 2 || pm.Plugins[1].ID != "org.jetbrains.kotlin.jvm" || pm.Plugins[1].Version != "1.9.0" {
+		t.Errorf("Unexpected pinned plugins: %+v", pm.Plugins)
+	}
+
+	if len(pm.ResolutionRules) != 3 {
+		t.Fatalf("Expected 3 resolution rules, got %d", len(pm.ResolutionRules))
+	}
+	android := pm.ResolutionRules[0]
+	if android.Namespace != "com.android" || android.UseModule != "com.android.tools.build:gradle:${requested.version}" {
+		t.Errorf("Unexpected android rule: %+v", android)
+	}
+	legacy := pm.ResolutionRules[1]
+	if legacy.PluginID != "com.example.legacy" || legacy.UseModule != "com.example:legacy-plugin:2.0" || legacy.UseVersion != "2.0" {
+		t.Errorf("Unexpected legacy rule: %+v", legacy)
+	}
+	lombok := pm.ResolutionRules[2]
+	if lombok.PluginID != "io.freefair.lombok" || lombok.UseVersion != "8.0.1" {
+		t.Errorf("Unexpected single-line rule: %+v", lombok)
+	}
+}
+
+func TestParsePluginManagement_KotlinWhen(t *testing.T) {
+	text := `pluginManagement {
+    resolutionStrategy {
+        eachPlugin {
+            when (requested.id.id) {
+                "com.google.protobuf" -> useModule("com.google.protobuf:protobuf-gradle-plugin:0.9.4")
+            }
+        }
+    }
+}`
+	pm := ParsePluginManagement(text)
+	if pm == nil || len(pm.ResolutionRules) != 1 {
+		t.Fatalf("Expected one rule, got %+v", pm)
+	}
+	if rule := pm.ResolutionRules[0]; rule.PluginID != "com.google.protobuf" || rule.UseModule != "com.google.protobuf:protobuf-gradle-plugin:0.9.4" {
+		t.Errorf("Unexpected rule: %+v", rule)
+	}
+
+	if ParsePluginManagement("rootProject.name = 'demo'") != nil {
+		t.Error("Expected nil without pluginManagement block")
+	}
+}
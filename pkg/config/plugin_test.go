@@ -142,6 +142,33 @@ func TestExtractPluginsFromText(t *testing.T) {
 	}
 }
 
+func TestExtractPluginsApplyFalse(t *testing.T) {
+	text := `plugins {
+    id 'java'
+    id 'org.springframework.boot' version '3.1.0' apply false
+    id("org.jetbrains.kotlin.jvm") version "1.9.0" apply(false)
+    id 'io.spring.dependency-management' version '1.1.0' apply true
+}`
+	plugins := NewPluginParser().ExtractPluginsFromText(text)
+	expected := []struct {
+		id, version string
+		apply       bool
+	}{
+		{"java", "", true},
+		{"org.springframework.boot", "3.1.0", false},
+		{"org.jetbrains.kotlin.jvm", "1.9.0", false},
+		{"io.spring.dependency-management", "1.1.0", true},
+	}
+	if len(plugins) != len(expected) {
+		t.Fatalf("Expected %d plugins, got %d", len(expected), len(plugins))
+	}
+	for i, e := range expected {
+		if p := plugins[i]; p.ID != e.id || p.Version != e.version || p.Apply != e.apply {
+			t.Errorf("Plugin %d = %+v, want %+v", i, p, e)
+		}
+	}
+}
+
 func TestGetPluginConfigurations(t *testing.T) {
 	parser := NewPluginParser()
 
@@ -333,3 +360,39 @@ func TestIsKotlinProject(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractPluginsApplyStyles(t *testing.T) {
+	text := `plugins {
+    id 'java'
+}
+apply plugin: 'kotlin'
+apply plugin: JavaLibraryPlugin
+apply plugin: com.mycorp.gradle.ConventionPlugin
+apply(plugin = "maven-publish")
+plugins.apply("jacoco")
+project.pluginManager.apply 'idea'
+plugins.apply(org.gradle.api.plugins.ApplicationPlugin::class.java)`
+	plugins := NewPluginParser().ExtractPluginsFromText(text)
+	expected := []struct {
+		id    string
+		style model.PluginStyle
+		class string
+	}{
+		{"java", model.PluginStylePluginsBlock, ""},
+		{"kotlin", model.PluginStyleApplyPlugin, ""},
+		{"java-library", model.PluginStyleApplyPlugin, "JavaLibraryPlugin"},
+		{"com.mycorp.gradle.ConventionPlugin", model.PluginStyleApplyPlugin, "com.mycorp.gradle.ConventionPlugin"},
+		{"maven-publish", model.PluginStyleApplyKotlin, ""},
+		{"jacoco", model.PluginStylePluginsApply, ""},
+		{"idea", model.PluginStylePluginsApply, ""},
+		{"application", model.PluginStylePluginsApply, "org.gradle.api.plugins.ApplicationPlugin"},
+	}
+	if len(plugins) != len(expected) {
+		t.Fatalf("Expected %d plugins, got %d", len(expected), len(plugins))
+	}
+	for i, e := range expected {
+		if p := plugins[i]; p.ID != e.id || p.Style != e.style || p.Class != e.class || !p.Apply {
+			t.Errorf("Plugin %d = %+v, want %+v", i, p, e)
+		}
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/util"
 )
 
 const (
@@ -14,6 +16,7 @@ const (
 	mavenLocalRepo   = "mavenLocal"
 	jcenterRepo      = "jcenter"
 	googleRepo       = "google"
+	pluginPortalRepo = "gradlePluginPortal"
 )
 
 var (
@@ -23,18 +26,36 @@ var (
 	mavenUrlRegex = regexp.MustCompile(`url\s*=?\s*(?:uri\()?['"](https?://[^'"]+)['"]`)
 
 	// 匹配Maven仓库名称的正则表达式。
-	// 例如: mavenCentral()。
-	mavenNameRegex = regexp.MustCompile(`(mavenCentral|mavenLocal|jcenter|google)\(\)`)
+	// 例如: mavenCentral()、gradlePluginPortal()。
+	mavenNameRegex = regexp.MustCompile(`(mavenCentral|mavenLocal|jcenter|google|gradlePluginPortal)\(\)`)
+
+	// 匹配repositories块的开头。
+	repositoriesBlockRegex = regexp.MustCompile(`\brepositories\s*\{`)
+
+	// 匹配仓库块中声明的名称，例如 name 'mySecureRepo' 或 name = "GitHubPackages"。
+	repoNameRegex = regexp.MustCompile(`^name\s*(?:=\s*|\(\s*)?['"]([^'"]+)['"]`)
+
+	// 匹配 allowInsecureProtocol = true 或Kotlin DSL的 isAllowInsecureProtocol = true。
+	allowInsecureRegex = regexp.MustCompile(`^(?:is)?[aA]llowInsecureProtocol\s*(?:=\s*|\(\s*)?(true|false)\b`)
 )
 
 // RepositoryParser 处理Gradle仓库解析.
-type RepositoryParser struct{}
+type RepositoryParser struct {
+	// 是否为提取出的仓库记录声明所在的行号。
+	lineNumbers bool
+}
 
 // NewRepositoryParser 创建新的仓库解析器.
 func NewRepositoryParser() *RepositoryParser {
 	return &RepositoryParser{}
 }
 
+// WithLineNumbers 设置 ExtractRepositoriesFromText 是否在 Repository.DeclaredAtLine 中记录声明所在的行号，默认关闭.
+func (rp *RepositoryParser) WithLineNumbers(track bool) *RepositoryParser {
+	rp.lineNumbers = track
+	return rp
+}
+
 // ParseRepositoryBlock 解析仓库块.
 func (rp *RepositoryParser) ParseRepositoryBlock(block *model.ScriptBlock) ([]*model.Repository, error) {
 	if block == nil {
@@ -94,13 +115,7 @@ func (rp *RepositoryParser) ParseRepositoryBlock(block *model.ScriptBlock) ([]*m
 				for subName, subClosures := range closure.Closures {
 					if subName == "credentials" && len(subClosures) > 0 {
 						for key, value := range subClosures[0].Values {
-							valueStr := fmt.Sprintf("%v", value)
-							switch key {
-							case "username":
-								repo.Username = strings.Trim(valueStr, "'\"")
-							case "password":
-								repo.Password = strings.Trim(valueStr, "'\"")
-							}
+							setCredentialField(repo, key, ClassifyCredentialValue(fmt.Sprintf("%v", value)))
 						}
 					}
 				}
@@ -150,60 +165,270 @@ func (rp *RepositoryParser) ParseRepositoryBlock(block *model.ScriptBlock) ([]*m
 }
 
 // ExtractRepositoriesFromText 从原始文本中提取仓库。
+// 除URL外还会识别 maven/ivy 块中声明的仓库名称、凭证、认证方式和 allowInsecureProtocol。
 func (rp *RepositoryParser) ExtractRepositoriesFromText(text string) []*model.Repository {
 	repos := make([]*model.Repository, 0)
+	var lines *util.LineIndex
+	if rp.lineNumbers {
+		lines = util.NewLineIndex(text)
+	}
+
+	end := 0
+	for _, loc := range repositoriesBlockRegex.FindAllStringIndex(text, -1) {
+		if loc[0] < end {
+			// 位于已处理的repositories块内部。
+			continue
+		}
+		open := loc[1] - 1
+		end = matchingBrace(text, open)
+		repos = append(repos, parseRepositoriesBodyAt(text[open+1:end], open+1, lines)...)
+	}
 
-	// 分析文本中的仓库声明。
-	lines := strings.Split(text, "\n")
-	inRepoBlock := false
+	return repos
+}
+
+// parseRepositoriesBody 解析repositories块体中的仓库声明，按出现顺序返回。
+func parseRepositoriesBody(body string) []*model.Repository {
+	return parseRepositoriesBodyAt(body, 0, nil)
+}
 
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
+// parseRepositoriesBodyAt 与 parseRepositoriesBody 相同，lines不为nil时根据块体在原文中的偏移量base记录仓库声明所在的行号。
+func parseRepositoriesBodyAt(body string, base int, lines *util.LineIndex) []*model.Repository {
+	repos := make([]*model.Repository, 0)
 
-		// 检查是否进入repositories块。
-		if strings.Contains(trimmedLine, "repositories") && strings.Contains(trimmedLine, "{") {
-			inRepoBlock = true
+	for _, item := range splitClosure(body) {
+		var repo *model.Repository
+		if !item.block {
+			if match := mavenNameRegex.FindStringSubmatch(item.statement); len(match) > 1 {
+				repo = &model.Repository{
+					Name: match[1],
+					Type: "maven",
+				}
+			} else if match := mavenUrlRegex.FindStringSubmatch(item.statement); len(match) > 1 {
+				repo = &model.Repository{
+					Name: repositoryNameFromURL(match[1]),
+					URL:  match[1],
+					Type: "maven",
+				}
+			}
+		} else {
+			switch name := closureName(item.header); name {
+			case mavenCentralRepo, mavenLocalRepo, jcenterRepo, googleRepo, pluginPortalRepo:
+				repo = &model.Repository{
+					Name:    name,
+					Type:    "maven",
+					Content: applyRepositoryContentBlocks(item.body),
+				}
+			case "maven", "ivy":
+				repo = parseRepositoryClosure(name, item.header, item.body)
+			case "exclusiveContent":
+				repos = append(repos, parseExclusiveContent(item.body, base+item.bodyStart, lines)...)
+			default:
+				// exclusiveContent { forRepository { maven { } } } 等嵌套写法。
+				repos = append(repos, parseRepositoriesBodyAt(item.body, base+item.bodyStart, lines)...)
+			}
+		}
+
+		if repo == nil {
 			continue
 		}
+		if lines != nil {
+			repo.DeclaredAtLine = lines.Line(base + item.start)
+		}
+		repos = append(repos, repo)
+	}
 
-		// 检查是否离开repositories块。
-		if inRepoBlock && trimmedLine == "}" {
-			inRepoBlock = false
+	return repos
+}
+
+// parseRepositoryClosure 解析 maven { } 或 ivy { } 块中的仓库配置。
+// 头部也可能包含URL，例如Kotlin DSL的 maven(url = "https://jitpack.io") { }。
+func parseRepositoryClosure(repoType, header, body string) *model.Repository {
+	repo := &model.Repository{Type: repoType}
+	if match := mavenUrlRegex.FindStringSubmatch(header); len(match) > 1 {
+		repo.URL = match[1]
+	}
+	declaredName := ""
+	providedType := ""
+
+	for _, item := range splitClosure(body) {
+		if item.block {
+			switch closureName(item.header) {
+			case "credentials":
+				credentialsType := ""
+				if m := credentialsTypeRegex.FindStringSubmatch(item.header); m != nil {
+					credentialsType = m[1]
+				}
+				repo.Credentials = parseCredentialsBlock(credentialsType, item.body)
+			case "authentication":
+				repo.Authentication = append(repo.Authentication, parseAuthentication(item.body)...)
+			case "content", "mavenContent":
+				repo.Content = parseRepositoryContent(repo.Content, item.body)
+			}
 			continue
 		}
 
-		// 在repositories块内部。
-		if inRepoBlock {
-			// 检查预定义仓库。
-			if match := mavenNameRegex.FindStringSubmatch(trimmedLine); len(match) > 1 {
-				repos = append(repos, &model.Repository{
-					Name: match[1],
-					Type: "maven",
-				})
-				continue
+		if match := mavenUrlRegex.FindStringSubmatch(item.statement); len(match) > 1 {
+			repo.URL = match[1]
+		} else if match := repoNameRegex.FindStringSubmatch(item.statement); match != nil {
+			declaredName = match[1]
+		} else if match := allowInsecureRegex.FindStringSubmatch(item.statement); match != nil {
+			repo.AllowInsecureProtocol = match[1] == "true"
+		} else if match := credentialsTypeRegex.FindStringSubmatch(item.statement); match != nil {
+			providedType = match[1]
+		}
+	}
+
+	switch {
+	case declaredName != "":
+		repo.Name = declaredName
+	case repoType == "ivy":
+		repo.Name = "ivy"
+	default:
+		repo.Name = repositoryNameFromURL(repo.URL)
+	}
+
+	if providedType != "" {
+		repo.Credentials = newProvidedCredentials(providedType, declaredName)
+	}
+	if repo.Credentials != nil {
+		for key, value := range repo.Credentials.Values {
+			setCredentialField(repo, key, value)
+		}
+	}
+
+	return repo
+}
+
+// setCredentialField 把用户名或密码写入仓库：字面量写入 Username/Password，引用写入 UsernameRef/PasswordRef。
+func setCredentialField(repo *model.Repository, key string, value *model.CredentialValue) {
+	switch key {
+	case "username":
+		if value.Source == model.CredentialLiteral {
+			repo.Username = value.Value
+		} else {
+			repo.UsernameRef = value.Reference()
+		}
+	case "password":
+		if value.Source == model.CredentialLiteral {
+			repo.Password = value.Value
+		} else {
+			repo.PasswordRef = value.Reference()
+		}
+	}
+}
+
+// repositoryNameFromURL 使用URL中的域名作为仓库名称。
+func repositoryNameFromURL(url string) string {
+	parts := strings.Split(url, "/")
+	if len(parts) > 2 {
+		return parts[2]
+	}
+	return "custom-maven"
+}
+
+// closureItem 表示闭包体中的一条顶层语句或一个子块。
+type closureItem struct {
+	block     bool
+	statement string // 语句文本，仅在block为false时有效。
+	header    string // 子块的头部文本，例如 credentials(PasswordCredentials)。
+	body      string // 子块花括号内的文本。
+
+	start     int // 语句或子块头部去掉前导空白后在闭包体中的偏移量。
+	bodyStart int // 子块花括号内文本在闭包体中的偏移量。
+}
+
+// splitClosure 将闭包体拆分为顶层语句和子块，忽略字符串中的花括号和注释。
+func splitClosure(body string) []closureItem {
+	items := make([]closureItem, 0)
+	stmtStart := 0
+	flush := func(end int) {
+		if stmt := strings.TrimSpace(body[stmtStart:end]); stmt != "" {
+			items = append(items, closureItem{statement: stmt, start: skipLeadingSpace(body, stmtStart)})
+		}
+	}
+
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case c == '\'' || c == '"':
+			i = skipQuoted(body, i)
+		case c == '/' && i+1 < len(body) && body[i+1] == '/':
+			flush(i)
+			for i < len(body) && body[i] != '\n' {
+				i++
 			}
+			stmtStart = i
+		case c == '\n' || c == ';':
+			flush(i)
+			stmtStart = i + 1
+		case c == '{':
+			end := matchingBrace(body, i)
+			items = append(items, closureItem{
+				block:     true,
+				header:    strings.TrimSpace(body[stmtStart:i]),
+				body:      body[i+1 : end],
+				start:     skipLeadingSpace(body, stmtStart),
+				bodyStart: i + 1,
+			})
+			i = end
+			stmtStart = end + 1
+		}
+	}
+	if stmtStart < len(body) {
+		flush(len(body))
+	}
 
-			// 检查Maven URL。
-			if match := mavenUrlRegex.FindStringSubmatch(trimmedLine); len(match) > 1 {
-				url := match[1]
+	return items
+}
 
-				// 从URL推断名称。
-				name := "custom-maven"
-				parts := strings.Split(url, "/")
-				if len(parts) > 2 {
-					name = parts[2]
-				}
+// skipLeadingSpace 返回从start开始第一个非空白字符的位置。
+func skipLeadingSpace(text string, start int) int {
+	for start < len(text) && unicode.IsSpace(rune(text[start])) {
+		start++
+	}
+	return start
+}
 
-				repos = append(repos, &model.Repository{
-					Name: name,
-					URL:  url,
-					Type: "maven",
-				})
+// closureName 返回块头部开头的标识符，例如 credentials(PasswordCredentials) 返回 credentials。
+func closureName(header string) string {
+	end := 0
+	for end < len(header) && (header[end] == '_' || unicode.IsLetter(rune(header[end])) || unicode.IsDigit(rune(header[end]))) {
+		end++
+	}
+	return header[:end]
+}
+
+// matchingBrace 返回与open处左花括号匹配的右花括号位置，未闭合时返回文本长度。
+func matchingBrace(text string, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '\'', '"':
+			i = skipQuoted(text, i)
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
 			}
 		}
 	}
+	return len(text)
+}
 
-	return repos
+// skipQuoted 跳过从start开始的单行字符串字面量，返回结束引号的位置。
+func skipQuoted(text string, start int) int {
+	quote := text[start]
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case quote, '\n':
+			return i
+		}
+	}
+	return len(text)
 }
 
 // GetDefaultRepositories 获取常见的默认仓库。
@@ -245,7 +470,7 @@ func (rp *RepositoryParser) HasJitPackRepository(repos []*model.Repository) bool
 func (rp *RepositoryParser) HasCustomRepository(repos []*model.Repository) bool {
 	for _, repo := range repos {
 		if repo.Name != "mavenCentral" && repo.Name != "mavenLocal" &&
-			repo.Name != "google" && repo.Name != "jcenter" {
+			repo.Name != "google" && repo.Name != "jcenter" && repo.Name != pluginPortalRepo {
 			return true
 		}
 	}
@@ -0,0 +1,123 @@
+// Package config 提供仓库内容过滤条件的解析功能。
+package config
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/util"
+)
+
+var (
+	// 匹配内容过滤规则，例如 includeGroup 'com.example'、excludeModuleByRegex("com\\.example", ".*-internal")。
+	// 第1组是 include 或 exclude，第2组是规则种类，第3组是参数。
+	contentRuleRegex = regexp.MustCompile(
+		`^(include|exclude)(GroupAndSubgroups|GroupByRegex|Group|ModuleByRegex|Module|VersionByRegex|Version)\b\s*(.*)$`)
+
+	// 匹配 mavenContent { releasesOnly() } 和 snapshotsOnly()。
+	releasesOnlyRegex  = regexp.MustCompile(`^releasesOnly\b`)
+	snapshotsOnlyRegex = regexp.MustCompile(`^snapshotsOnly\b`)
+)
+
+// parseRepositoryContent 把 content { } 或 mavenContent { } 块中的过滤条件合并到content中，content为nil时创建新的条件。
+func parseRepositoryContent(content *model.RepositoryContent, body string) *model.RepositoryContent {
+	if content == nil {
+		content = &model.RepositoryContent{}
+	}
+	for _, item := range splitClosure(body) {
+		if item.block {
+			continue
+		}
+		switch {
+		case releasesOnlyRegex.MatchString(item.statement):
+			content.ReleasesOnly = true
+		case snapshotsOnlyRegex.MatchString(item.statement):
+			content.SnapshotsOnly = true
+		default:
+			m := contentRuleRegex.FindStringSubmatch(item.statement)
+			if m == nil {
+				continue
+			}
+			rule := newContentRule(m[2], m[3])
+			if rule == nil {
+				continue
+			}
+			if m[1] == "include" {
+				content.Includes = append(content.Includes, rule)
+			} else {
+				content.Excludes = append(content.Excludes, rule)
+			}
+		}
+	}
+	return content
+}
+
+// newContentRule 根据方法名中的规则种类（例如 GroupByRegex）和参数创建规则，参数不足时返回nil。
+// 参数中的 \\ 按字符串转义还原为 \，使正则表达式与Gradle看到的一致。
+func newContentRule(method, args string) *model.ContentRule {
+	values := make([]string, 0, 3)
+	for _, m := range quotedStringRegex.FindAllStringSubmatch(args, -1) {
+		values = append(values, strings.ReplaceAll(m[1], `\\`, `\`))
+	}
+
+	rule := &model.ContentRule{Kind: strings.ToLower(method[:1]) + method[1:]}
+	required := 1
+	switch rule.Kind {
+	case model.ContentRuleModule, model.ContentRuleModuleByRegex:
+		required = 2
+	case model.ContentRuleVersion, model.ContentRuleVersionByRegex:
+		required = 3
+	}
+	if len(values) < required {
+		return nil
+	}
+	rule.Group = values[0]
+	if required >= 2 {
+		rule.Module = values[1]
+	}
+	if required == 3 {
+		rule.Version = values[2]
+	}
+	return rule
+}
+
+// applyRepositoryContentBlocks 解析仓库块体中的 content { } 和 mavenContent { } 子块，没有过滤条件时返回nil。
+func applyRepositoryContentBlocks(body string) *model.RepositoryContent {
+	var content *model.RepositoryContent
+	for _, item := range splitClosure(body) {
+		if item.block {
+			switch closureName(item.header) {
+			case "content", "mavenContent":
+				content = parseRepositoryContent(content, item.body)
+			}
+		}
+	}
+	return content
+}
+
+// parseExclusiveContent 解析 exclusiveContent { forRepository { } filter { } } 块：
+// forRepository 中声明的仓库使用 filter 中的条件，并标记为独占。
+func parseExclusiveContent(body string, base int, lines *util.LineIndex) []*model.Repository {
+	var filter *model.RepositoryContent
+	repos := make([]*model.Repository, 0)
+	for _, item := range splitClosure(body) {
+		if !item.block {
+			continue
+		}
+		switch closureName(item.header) {
+		case "forRepository", "forRepositories":
+			repos = append(repos, parseRepositoriesBodyAt(item.body, base+item.bodyStart, lines)...)
+		case "filter":
+			filter = parseRepositoryContent(filter, item.body)
+		}
+	}
+	if filter == nil {
+		return repos
+	}
+	filter.Exclusive = true
+	for _, repo := range repos {
+		repo.Content = filter
+	}
+	return repos
+}
@@ -0,0 +1,88 @@
+// Package config 提供按仓库声明顺序和内容过滤条件模拟依赖解析的功能。
+package config
+
+import (
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// ResolutionStep 是模拟解析时对一个仓库的处理结果。
+type ResolutionStep struct {
+	Repository *model.Repository `json:"repository"`
+	Queried    bool              `json:"queried"`
+	// Reason 说明仓库被跳过的原因，仓库会被查询时为空。
+	Reason string `json:"reason,omitempty"`
+}
+
+// ResolutionSimulation 是一个模块按仓库声明顺序解析的模拟结果。
+type ResolutionSimulation struct {
+	Group   string            `json:"group"`
+	Name    string            `json:"name"`
+	Version string            `json:"version,omitempty"`
+	Steps   []*ResolutionStep `json:"steps"`
+	// First 是第一个会被查询的仓库，也就是模块同时存在于多个仓库时实际使用的仓库；没有仓库会被查询时为nil。
+	First *model.Repository `json:"first,omitempty"`
+	// Exclusive 为true表示模块匹配了 exclusiveContent 的过滤条件，只会在对应的仓库中查找。
+	Exclusive bool `json:"exclusive,omitempty"`
+}
+
+// Candidates 返回会被查询的仓库，按查询顺序排列。
+func (s *ResolutionSimulation) Candidates() []*model.Repository {
+	repos := make([]*model.Repository, 0)
+	for _, step := range s.Steps {
+		if step.Queried {
+			repos = append(repos, step.Repository)
+		}
+	}
+	return repos
+}
+
+// SimulateResolution 模拟Gradle为模块查找仓库的顺序：仓库按声明顺序依次查询，
+// 模块匹配 exclusiveContent 的过滤条件时只查询对应的仓库，其余仓库按 content { } 和 mavenContent { } 的条件跳过。
+// version为空时忽略版本相关的条件。模拟只依据构建脚本中的声明，无法知道模块是否真的存在于仓库中。
+func SimulateResolution(repos []*model.Repository, group, name, version string) *ResolutionSimulation {
+	sim := &ResolutionSimulation{Group: group, Name: name, Version: version, Steps: make([]*ResolutionStep, 0, len(repos))}
+	for _, repo := range repos {
+		if repo != nil && repo.Content != nil && repo.Content.Exclusive && repo.Content.IncludesModule(group, name, version) {
+			sim.Exclusive = true
+			break
+		}
+	}
+
+	for _, repo := range repos {
+		if repo == nil {
+			continue
+		}
+		step := &ResolutionStep{Repository: repo}
+		content := repo.Content
+		exclusiveMatch := content != nil && content.Exclusive && content.IncludesModule(group, name, version)
+		switch {
+		case sim.Exclusive && !exclusiveMatch:
+			step.Reason = "模块由 exclusiveContent 限定在其他仓库中查找"
+		case content != nil && content.Exclusive && !exclusiveMatch:
+			step.Reason = "exclusiveContent 的过滤条件不包含该模块"
+		case !content.Allows(group, name, version):
+			step.Reason = contentSkipReason(content, group, name, version)
+		default:
+			step.Queried = true
+			if sim.First == nil {
+				sim.First = repo
+			}
+		}
+		sim.Steps = append(sim.Steps, step)
+	}
+	return sim
+}
+
+// contentSkipReason 返回仓库内容过滤条件排除模块的原因。
+func contentSkipReason(content *model.RepositoryContent, group, name, version string) string {
+	switch {
+	case len(content.Includes) > 0 && !content.IncludesModule(group, name, version):
+		return "仓库的 include 规则不包含该模块"
+	case content.ReleasesOnly && content.Allows(group, name, ""):
+		return "仓库只提供正式版本（releasesOnly）"
+	case content.SnapshotsOnly && content.Allows(group, name, ""):
+		return "仓库只提供SNAPSHOT版本（snapshotsOnly）"
+	default:
+		return "模块被仓库的 exclude 规则排除"
+	}
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"testing"
+)
+
+const contentFilteredRepositories = `repositories {
+    exclusiveContent {
+        forRepository {
+            maven { url 'https://maven.example.com/internal' }
+        }
+        filter {
+            includeGroupAndSubgroups 'com.example'
+        }
+    }
+    mavenCentral {
+        content {
+            excludeGroupByRegex "com\\.example.*"
+        }
+    }
+    maven {
+        url 'https://repo.example.org/snapshots'
+        mavenContent {
+            snapshotsOnly()
+        }
+    }
+    maven {
+        url = uri("https://jitpack.io")
+        content {
+            includeModule("com.github.user", "lib")
+            includeVersion 'org.acme', 'tool', '1.0'
+        }
+    }
+}`
+
+func TestParseRepositoryContent(t *testing.T) {
+	repos := NewRepositoryParser().ExtractRepositoriesFromText(contentFilteredRepositories)
+	if len(repos) != 4 {
+		t.Fatalf("ExtractRepositoriesFromText() returned %d repositories, want 4", len(repos))
+	}
+
+	internal := repos[0]
+	if internal.URL != "https://maven.example.com/internal" || internal.Content == nil || !internal.Content.Exclusive {
+		t.Fatalf("exclusive repository = %+v, want exclusive content", internal)
+	}
+	if len(internal.Content.Includes) != 1 || internal.Content.Includes[0].Kind != "groupAndSubgroups" ||
+		internal.Content.Includes[0].Group != "com.example" {
+		t.Errorf("exclusive includes = %+v", internal.Content.Includes)
+	}
+
+	central := repos[1]
+	if central.Name != "mavenCentral" || central.Content == nil || len(central.Content.Excludes) != 1 {
+		t.Fatalf("mavenCentral = %+v, want one exclude rule", central)
+	}
+	if got := central.Content.Excludes[0].Group; got != `com\.example.*` {
+		t.Errorf("exclude regex = %q, want %q", got, `com\.example.*`)
+	}
+
+	if repos[2].Content == nil || !repos[2].Content.SnapshotsOnly {
+		t.Errorf("snapshots repository content = %+v, want snapshotsOnly", repos[2].Content)
+	}
+
+	jitpack := repos[3].Content
+	if jitpack == nil || len(jitpack.Includes) != 2 {
+		t.Fatalf("jitpack content = %+v, want two include rules", jitpack)
+	}
+	if rule := jitpack.Includes[0]; rule.Kind != "module" || rule.Group != "com.github.user" || rule.Module != "lib" {
+		t.Errorf("includeModule rule = %+v", rule)
+	}
+	if rule := jitpack.Includes[1]; rule.Kind != "version" || rule.Version != "1.0" {
+		t.Errorf("includeVersion rule = %+v", rule)
+	}
+}
+
+func TestSimulateResolution(t *testing.T) {
+	repos := NewRepositoryParser().ExtractRepositoriesFromText(contentFilteredRepositories)
+
+	tests := []struct {
+		name      string
+		group     string
+		module    string
+		version   string
+		queried   []string
+		exclusive bool
+	}{
+		{"exclusive group", "com.example.core", "api", "1.0", []string{"https://maven.example.com/internal"}, true},
+		{"release", "org.slf4j", "slf4j-api", "2.0.9", []string{""}, false},
+		{"snapshot", "org.slf4j", "slf4j-api", "2.1.0-SNAPSHOT", []string{"", "https://repo.example.org/snapshots"}, false},
+		{"included module", "com.github.user", "lib", "1.2", []string{"", "https://jitpack.io"}, false},
+		{"version rule", "org.acme", "tool", "2.0", []string{""}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := SimulateResolution(repos, tt.group, tt.module, tt.version)
+			if sim.Exclusive != tt.exclusive {
+				t.Errorf("Exclusive = %v, want %v", sim.Exclusive, tt.exclusive)
+			}
+			if len(sim.Steps) != len(repos) {
+				t.Fatalf("len(Steps) = %d, want %d", len(sim.Steps), len(repos))
+			}
+			candidates := sim.Candidates()
+			if len(candidates) != len(tt.queried) {
+				t.Fatalf("Candidates() = %d repositories, want %d", len(candidates), len(tt.queried))
+			}
+			for i, repo := range candidates {
+				if repo.URL != tt.queried[i] {
+					t.Errorf("Candidates()[%d].URL = %q, want %q", i, repo.URL, tt.queried[i])
+				}
+			}
+			if sim.First != candidates[0] {
+				t.Errorf("First = %+v, want first candidate", sim.First)
+			}
+			for _, step := range sim.Steps {
+				if !step.Queried && step.Reason == "" {
+					t.Errorf("skipped repository %s has no reason", step.Repository.Name)
+				}
+			}
+		})
+	}
+
+	if sim := SimulateResolution(nil, "a", "b", ""); sim.First != nil || len(sim.Steps) != 0 {
+		t.Errorf("SimulateResolution(nil) = %+v, want empty simulation", sim)
+	}
+}
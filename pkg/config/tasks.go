@@ -0,0 +1,119 @@
+// Package config 提供根据已应用插件推断可用Gradle任务的功能。
+package config
+
+import (
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// SuggestedTask 表示插件提供的一个常用任务。
+type SuggestedTask struct {
+	// Name 是任务名，例如 bootJar。
+	Name string `json:"name"`
+	// Path 是任务路径，子项目中的任务带有项目路径前缀，例如 :app:assembleRelease；根项目中的任务与 Name 相同。
+	Path string `json:"path"`
+	// Plugin 是提供该任务的插件ID。
+	Plugin      string `json:"plugin"`
+	Description string `json:"description"`
+}
+
+// PluginTask 是插件提供的任务及其说明。
+type PluginTask struct {
+	Name        string
+	Description string
+}
+
+// KnownPluginTasks 是插件ID到该插件提供的常用任务的映射，按CI流水线中通常执行的顺序排列。
+var KnownPluginTasks = map[string][]PluginTask{
+	"java": {
+		{Name: "build", Description: "编译、测试并打包"},
+		{Name: "test", Description: "运行单元测试"},
+		{Name: "jar", Description: "打包jar"},
+	},
+	"java-library": {
+		{Name: "build", Description: "编译、测试并打包"},
+		{Name: "test", Description: "运行单元测试"},
+		{Name: "jar", Description: "打包jar"},
+	},
+	"application": {
+		{Name: "run", Description: "运行应用"},
+		{Name: "installDist", Description: "生成可执行的发行目录"},
+		{Name: "distZip", Description: "打包发行zip"},
+	},
+	"org.springframework.boot": {
+		{Name: "bootJar", Description: "打包可执行jar"},
+		{Name: "bootRun", Description: "运行Spring Boot应用"},
+		{Name: "bootBuildImage", Description: "构建OCI镜像"},
+	},
+	"com.android.application": {
+		{Name: "assembleDebug", Description: "构建debug APK"},
+		{Name: "assembleRelease", Description: "构建release APK"},
+		{Name: "bundleRelease", Description: "构建release AAB"},
+		{Name: "testDebugUnitTest", Description: "运行debug单元测试"},
+		{Name: "lintRelease", Description: "运行Android Lint"},
+		{Name: "connectedAndroidTest", Description: "在设备上运行仪器测试"},
+	},
+	"com.android.library": {
+		{Name: "assembleRelease", Description: "构建release AAR"},
+		{Name: "testDebugUnitTest", Description: "运行debug单元测试"},
+		{Name: "lintRelease", Description: "运行Android Lint"},
+	},
+	"maven-publish": {
+		{Name: "publish", Description: "发布到所有配置的仓库"},
+		{Name: "publishToMavenLocal", Description: "发布到本地Maven仓库"},
+	},
+	"jacoco": {
+		{Name: "jacocoTestReport", Description: "生成测试覆盖率报告"},
+		{Name: "jacocoTestCoverageVerification", Description: "校验测试覆盖率"},
+	},
+	"checkstyle": {
+		{Name: "checkstyleMain", Description: "检查主代码风格"},
+	},
+	"org.jetbrains.kotlin.jvm": {
+		{Name: "build", Description: "编译、测试并打包"},
+		{Name: "test", Description: "运行单元测试"},
+	},
+}
+
+// SuggestTasks 根据项目（及其子项目）应用的插件列出可用的常用任务，供CI生成器直接使用。
+// 只考虑 KnownPluginTasks 中的插件；apply false 的插件不会生成任务，同一项目中多个插件提供的同名任务只列出一次。
+func SuggestTasks(project *model.Project) []*SuggestedTask {
+	tasks := make([]*SuggestedTask, 0)
+	if project != nil {
+		tasks = appendSuggestedTasks(tasks, project, "")
+	}
+	return tasks
+}
+
+// appendSuggestedTasks 递归添加项目中插件提供的任务。
+func appendSuggestedTasks(tasks []*SuggestedTask, project *model.Project, modulePath string) []*SuggestedTask {
+	seen := make(map[string]bool)
+	for _, plugin := range project.Plugins {
+		if plugin == nil || !plugin.Apply {
+			continue
+		}
+		for _, task := range KnownPluginTasks[plugin.ID] {
+			if seen[task.Name] {
+				continue
+			}
+			seen[task.Name] = true
+			path := task.Name
+			if modulePath != "" {
+				path = modulePath + ":" + task.Name
+			}
+			tasks = append(tasks, &SuggestedTask{
+				Name:        task.Name,
+				Path:        path,
+				Plugin:      plugin.ID,
+				Description: task.Description,
+			})
+		}
+	}
+
+	for _, sub := range project.SubProjects {
+		if sub == nil || sub.Name == "" {
+			continue
+		}
+		tasks = appendSuggestedTasks(tasks, sub, modulePath+":"+sub.Name)
+	}
+	return tasks
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestSuggestTasks(t *testing.T) {
+	project := &model.Project{
+		Plugins: []*model.Plugin{
+			{ID: "java", Apply: true},
+			{ID: "org.springframework.boot", Apply: false},
+			{ID: "jacoco", Apply: true},
+		},
+		SubProjects: []*model.Project{{
+			Name: "app",
+			Plugins: []*model.Plugin{
+				{ID: "com.android.application", Apply: true},
+				{ID: "maven-publish", Apply: true},
+				{ID: "unknown.plugin", Apply: true},
+			},
+		}},
+	}
+
+	tasks := SuggestTasks(project)
+	paths := make(map[string]string)
+	for _, task := range tasks {
+		paths[task.Path] = task.Plugin
+	}
+	for path, plugin := range map[string]string{
+		"build":                "java",
+		"jacocoTestReport":     "jacoco",
+		":app:assembleRelease": "com.android.application",
+		":app:publish":         "maven-publish",
+	} {
+		if paths[path] != plugin {
+			t.Errorf("Expected task %s from %s, got %q", path, plugin, paths[path])
+		}
+	}
+	if _, ok := paths["bootJar"]; ok {
+		t.Error("Plugins with apply false should not contribute tasks")
+	}
+	if tasks[0].Name != "build" || tasks[0].Description == "" {
+		t.Errorf("Unexpected first task %+v", tasks[0])
+	}
+
+	if len(SuggestTasks(nil)) != 0 {
+		t.Error("Expected no tasks for nil project")
+	}
+}
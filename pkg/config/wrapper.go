@@ -0,0 +1,280 @@
+// Package config 提供Gradle Wrapper配置解析与版本兼容性检查功能。
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/util"
+)
+
+// WrapperPropertiesPath 是Wrapper配置文件相对于项目根目录的路径。
+var WrapperPropertiesPath = filepath.Join("gradle", "wrapper", "gradle-wrapper.properties")
+
+// distributionURLRegex 从分发地址中提取Gradle版本和分发类型。
+// 例如: https://services.gradle.org/distributions/gradle-8.5-bin.zip。
+var distributionURLRegex = regexp.MustCompile(`gradle-([0-9][^/]*?)-(bin|all)\.zip$`)
+
+var (
+	// 匹配 wrapper 任务配置块的开头，例如 wrapper {、tasks.wrapper {、tasks.named<Wrapper>("wrapper") {、
+	// tasks.withType(Wrapper) { 和 task wrapper(type: Wrapper) {。
+	wrapperTaskBlockRegex = regexp.MustCompile(`(?m)^[ \t]*(?:tasks\s*\.\s*)?(?:wrapper|` +
+		`(?:named|getByName)\s*(?:<\s*Wrapper\s*>)?\s*\(\s*['"]wrapper['"][^)]*\)|` +
+		`withType\s*(?:<\s*Wrapper\s*>|\(\s*Wrapper\s*\))|` +
+		`task\s+wrapper\s*\(\s*type\s*:\s*Wrapper\s*\))\s*\{`)
+
+	// 匹配点号形式的单条配置，例如 wrapper.gradleVersion = '8.7'、tasks.wrapper.distributionType = DistributionType.ALL。
+	wrapperTaskStatementRegex = regexp.MustCompile(`(?m)^[ \t]*(?:tasks\s*\.\s*)?wrapper\s*\.\s*(.+)$`)
+
+	// 匹配 wrapper 任务中的属性赋值，第1组是属性名，第2组是值。
+	wrapperPropertyRegex = regexp.MustCompile(`^(gradleVersion|distributionType|distributionUrl|distributionSha256Sum)\b\s*=?\s*(.+)$`)
+)
+
+// GradleRequirement 表示插件版本对Gradle最低版本的要求。
+type GradleRequirement struct {
+	PluginID         string `json:"pluginId"`
+	MinPluginVersion string `json:"minPluginVersion"` // 从该插件版本起生效。
+	MinGradleVersion string `json:"minGradleVersion"`
+}
+
+// GradleVersionMismatch 表示Wrapper中的Gradle版本低于插件要求的版本。
+type GradleVersionMismatch struct {
+	Plugin           *model.Plugin `json:"plugin"`
+	GradleVersion    string        `json:"gradleVersion"`
+	MinGradleVersion string        `json:"minGradleVersion"`
+}
+
+// String 返回不匹配的描述。
+func (m *GradleVersionMismatch) String() string {
+	return fmt.Sprintf("插件 %s %s 需要 Gradle %s 及以上版本，当前为 %s",
+		m.Plugin.ID, m.Plugin.Version, m.MinGradleVersion, m.GradleVersion)
+}
+
+// KnownGradleRequirements 是常见插件的Gradle最低版本要求，按插件版本升序排列。
+var KnownGradleRequirements = []GradleRequirement{
+	{PluginID: androidApplicationPlugin, MinPluginVersion: "7.0", MinGradleVersion: "7.0"},
+	{PluginID: androidApplicationPlugin, MinPluginVersion: "7.1", MinGradleVersion: "7.2"},
+	{PluginID: androidApplicationPlugin, MinPluginVersion: "7.2", MinGradleVersion: "7.3.3"},
+	{PluginID: androidApplicationPlugin, MinPluginVersion: "7.3", MinGradleVersion: "7.4"},
+	{PluginID: androidApplicationPlugin, MinPluginVersion: "7.4", MinGradleVersion: "7.5"},
+	{PluginID: androidApplicationPlugin, MinPluginVersion: "8.0", MinGradleVersion: "8.0"},
+	{PluginID: androidApplicationPlugin, MinPluginVersion: "8.2", MinGradleVersion: "8.2"},
+	{PluginID: androidApplicationPlugin, MinPluginVersion: "8.3", MinGradleVersion: "8.4"},
+	{PluginID: androidApplicationPlugin, MinPluginVersion: "8.4", MinGradleVersion: "8.6"},
+	{PluginID: androidApplicationPlugin, MinPluginVersion: "8.5", MinGradleVersion: "8.7"},
+	{PluginID: androidApplicationPlugin, MinPluginVersion: "8.7", MinGradleVersion: "8.9"},
+	{PluginID: androidLibraryPlugin, MinPluginVersion: "7.0", MinGradleVersion: "7.0"},
+	{PluginID: androidLibraryPlugin, MinPluginVersion: "8.0", MinGradleVersion: "8.0"},
+	{PluginID: androidLibraryPlugin, MinPluginVersion: "8.3", MinGradleVersion: "8.4"},
+	{PluginID: androidLibraryPlugin, MinPluginVersion: "8.5", MinGradleVersion: "8.7"},
+	{PluginID: "org.springframework.boot", MinPluginVersion: "2.7", MinGradleVersion: "6.8"},
+	{PluginID: "org.springframework.boot", MinPluginVersion: "3.0", MinGradleVersion: "7.5"},
+	{PluginID: "org.springframework.boot", MinPluginVersion: "3.3", MinGradleVersion: "7.6.4"},
+	{PluginID: kotlinJVMPlugin, MinPluginVersion: "1.9", MinGradleVersion: "6.8.3"},
+	{PluginID: kotlinJVMPlugin, MinPluginVersion: "2.1", MinGradleVersion: "7.6.3"},
+	{PluginID: kotlinAndroidPlugin, MinPluginVersion: "1.9", MinGradleVersion: "6.8.3"},
+	{PluginID: kotlinAndroidPlugin, MinPluginVersion: "2.1", MinGradleVersion: "7.6.3"},
+}
+
+// ParseWrapperProperties 解析gradle-wrapper.properties的内容。
+func ParseWrapperProperties(content string) (*model.WrapperInfo, error) {
+	props := ParseProperties(content)
+
+	info := &model.WrapperInfo{
+		DistributionURL:       props["distributionUrl"],
+		DistributionSHA256Sum: props["distributionSha256Sum"],
+		DistributionBase:      props["distributionBase"],
+		DistributionPath:      props["distributionPath"],
+		ZipStoreBase:          props["zipStoreBase"],
+		ZipStorePath:          props["zipStorePath"],
+		Properties:            props,
+	}
+
+	if info.DistributionURL == "" {
+		return nil, fmt.Errorf("缺少distributionUrl属性")
+	}
+
+	info.GradleVersion, info.DistributionType = ParseDistributionURL(info.DistributionURL)
+	return info, nil
+}
+
+// ParseWrapperFile 解析指定路径的gradle-wrapper.properties文件。
+func ParseWrapperFile(filePath string) (*model.WrapperInfo, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := ParseWrapperProperties(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("解析Wrapper配置 %s 失败: %w", filePath, err)
+	}
+	info.FilePath = filePath
+	return info, nil
+}
+
+// ParseWrapperTask 从构建脚本中读取 wrapper 任务的配置，没有配置任何已知属性时返回nil。
+// 支持 wrapper { }、tasks.wrapper { }、tasks.named("wrapper") { }、tasks.withType<Wrapper> { }、
+// task wrapper(type: Wrapper) { } 和 wrapper.gradleVersion = '8.7' 等写法，多处配置时后面的值覆盖前面的值。
+func ParseWrapperTask(content string) *model.WrapperTask {
+	if !strings.Contains(content, "rapper") {
+		return nil
+	}
+	task := &model.WrapperTask{}
+	lines := util.NewLineIndex(content)
+	found := false
+	apply := func(statement string, offset int) {
+		if applyWrapperProperty(task, statement) {
+			if !found {
+				task.Line = lines.Line(offset)
+			}
+			found = true
+		}
+	}
+
+	type location struct {
+		start, end int
+	}
+	blocks := make([]location, 0)
+	for _, loc := range wrapperTaskBlockRegex.FindAllStringIndex(content, -1) {
+		end := matchingBrace(content, loc[1]-1)
+		blocks = append(blocks, location{loc[0], end})
+		for _, item := range splitClosure(content[loc[1]:end]) {
+			if !item.block {
+				apply(item.statement, loc[0])
+			}
+		}
+	}
+	for _, m := range wrapperTaskStatementRegex.FindAllStringSubmatchIndex(content, -1) {
+		inBlock := false
+		for _, block := range blocks {
+			inBlock = inBlock || (m[0] >= block.start && m[0] < block.end)
+		}
+		if !inBlock {
+			apply(strings.TrimSpace(content[m[2]:m[3]]), m[0])
+		}
+	}
+
+	if !found {
+		return nil
+	}
+	return task
+}
+
+// applyWrapperProperty 把一条属性赋值写入任务配置，不是已知属性时返回false。
+func applyWrapperProperty(task *model.WrapperTask, statement string) bool {
+	m := wrapperPropertyRegex.FindStringSubmatch(strings.TrimSpace(statement))
+	if m == nil {
+		return false
+	}
+	value := strings.TrimSpace(m[2])
+	if quoted := quotedStringRegex.FindStringSubmatch(value); quoted != nil {
+		value = quoted[1]
+	}
+	switch m[1] {
+	case "gradleVersion":
+		task.GradleVersion = value
+	case "distributionType":
+		// Wrapper.DistributionType.ALL 或 DistributionType.BIN。
+		task.DistributionType = strings.ToLower(value[strings.LastIndex(value, ".")+1:])
+	case "distributionUrl":
+		task.DistributionURL = value
+	case "distributionSha256Sum":
+		task.DistributionSHA256Sum = value
+	}
+	return true
+}
+
+// ApplyWrapperTask 用构建文件中的 wrapper 任务配置更新Wrapper信息并返回结果，任务中声明的值优先于properties文件。
+// 任务只声明了版本或分发类型时，标准格式的分发地址中的版本和类型也会相应替换，版本变化时原有的校验和不再有效会被清空。
+// info 为nil时只根据任务配置创建；task 为nil时原样返回info。
+func ApplyWrapperTask(info *model.WrapperInfo, task *model.WrapperTask) *model.WrapperInfo {
+	if task == nil {
+		return info
+	}
+	if info == nil {
+		info = &model.WrapperInfo{}
+	}
+	info.Task = task
+	previousVersion := info.GradleVersion
+
+	if task.DistributionURL != "" {
+		info.DistributionURL = task.DistributionURL
+		info.GradleVersion, info.DistributionType = ParseDistributionURL(task.DistributionURL)
+	}
+	if task.GradleVersion != "" {
+		info.GradleVersion = task.GradleVersion
+	}
+	if task.DistributionType != "" {
+		info.DistributionType = task.DistributionType
+	}
+	if info.DistributionType == "" {
+		info.DistributionType = "bin"
+	}
+
+	switch {
+	case task.DistributionURL != "":
+	case info.DistributionURL == "" && info.GradleVersion != "":
+		info.DistributionURL = fmt.Sprintf("https://services.gradle.org/distributions/gradle-%s-%s.zip", info.GradleVersion, info.DistributionType)
+	case distributionURLRegex.MatchString(info.DistributionURL):
+		info.DistributionURL = distributionURLRegex.ReplaceAllString(info.DistributionURL,
+			"gradle-"+info.GradleVersion+"-"+info.DistributionType+".zip")
+	}
+
+	if task.DistributionSHA256Sum != "" {
+		info.DistributionSHA256Sum = task.DistributionSHA256Sum
+	} else if previousVersion != "" && previousVersion != info.GradleVersion {
+		info.DistributionSHA256Sum = ""
+	}
+	return info
+}
+
+// ParseDistributionURL 从分发地址中提取Gradle版本和分发类型，无法识别时返回空字符串。
+func ParseDistributionURL(distributionURL string) (version, distributionType string) {
+	matches := distributionURLRegex.FindStringSubmatch(distributionURL)
+	if len(matches) < 3 {
+		return "", ""
+	}
+	return matches[1], matches[2]
+}
+
+// CheckGradleVersion 检查Gradle版本是否满足插件要求，返回所有不满足的插件。
+// 未声明版本的插件和Gradle版本未知时不做检查。
+func CheckGradleVersion(gradleVersion string, plugins []*model.Plugin) []*GradleVersionMismatch {
+	mismatches := make([]*GradleVersionMismatch, 0)
+	if gradleVersion == "" {
+		return mismatches
+	}
+
+	for _, plugin := range plugins {
+		if plugin == nil || plugin.Version == "" {
+			continue
+		}
+
+		required := RequiredGradleVersion(plugin.ID, plugin.Version)
+		if required != "" && util.CompareVersions(gradleVersion, required) < 0 {
+			mismatches = append(mismatches, &GradleVersionMismatch{
+				Plugin:           plugin,
+				GradleVersion:    gradleVersion,
+				MinGradleVersion: required,
+			})
+		}
+	}
+
+	return mismatches
+}
+
+// RequiredGradleVersion 返回指定插件版本所需的Gradle最低版本，未知时返回空字符串。
+func RequiredGradleVersion(pluginID, pluginVersion string) string {
+	required := ""
+	for _, req := range KnownGradleRequirements {
+		if req.PluginID == pluginID && util.CompareVersions(pluginVersion, req.MinPluginVersion) >= 0 {
+			required = req.MinGradleVersion
+		}
+	}
+	return required
+}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+const testWrapperProperties = `distributionBase=GRADLE_USER_HOME
+distributionPath=wrapper/dists
+distributionSha256Sum=9d926787066a081739e8200858338b4a69e837c3a821a33aca9db09dd4a41026
+distributionUrl=https\://services.gradle.org/distributions/gradle-8.5-bin.zip
+networkTimeout=10000
+validateDistributionUrl=true
+zipStoreBase=GRADLE_USER_HOME
+zipStorePath=wrapper/dists
+`
+
+func TestParseProperties(t *testing.T) {
+	content := `# comment
+! another comment
+key1=value1
+key2 : value2
+key3 value3
+multi=first \
+      second
+escaped\=key=aAb
+empty=
+`
+	props := ParseProperties(content)
+
+	expected := map[string]string{
+		"key1":        "value1",
+		"key2":        "value2",
+		"key3":        "value3",
+		"multi":       "first second",
+		"escaped=key": "aAb",
+		"empty":       "",
+	}
+	if len(props) != len(expected) {
+		t.Errorf("Expected %d properties, got %d: %v", len(expected), len(props), props)
+	}
+	for key, want := range expected {
+		if got, ok := props[key]; !ok || got != want {
+			t.Errorf("props[%q] = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestParseWrapperProperties(t *testing.T) {
+	info, err := ParseWrapperProperties(testWrapperProperties)
+	if err != nil {
+		t.Fatalf("ParseWrapperProperties() error = %v", err)
+	}
+
+	if info.DistributionURL != "https://services.gradle.org/distributions/gradle-8.5-bin.zip" {
+		t.Errorf("Unexpected distributionUrl: %s", info.DistributionURL)
+	}
+	if info.GradleVersion != "8.5" || info.DistributionType != "bin" {
+		t.Errorf("Expected 8.5/bin, got %s/%s", info.GradleVersion, info.DistributionType)
+	}
+	if info.DistributionSHA256Sum == "" || info.Properties["networkTimeout"] != "10000" {
+		t.Errorf("Checksum and raw properties should be populated: %+v", info)
+	}
+
+	if _, err := ParseWrapperProperties("zipStoreBase=GRADLE_USER_HOME\n"); err == nil {
+		t.Error("Expected error when distributionUrl is missing")
+	}
+}
+
+func TestParseWrapperFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gradle-wrapper.properties")
+	if err := os.WriteFile(path, []byte(testWrapperProperties), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := ParseWrapperFile(path)
+	if err != nil {
+		t.Fatalf("ParseWrapperFile() error = %v", err)
+	}
+	if info.FilePath != path {
+		t.Errorf("Expected FilePath %s, got %s", path, info.FilePath)
+	}
+}
+
+func TestParseWrapperTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *model.WrapperTask
+	}{
+		{
+			name: "Groovy block",
+			content: `plugins { id 'java' }
+
+// wrapper { gradleVersion = '1.0' }
+wrapper {
+    gradleVersion = '8.7'
+    distributionType = Wrapper.DistributionType.ALL
+}`,
+			want: &model.WrapperTask{GradleVersion: "8.7", DistributionType: "all", Line: 4},
+		},
+		{
+			name: "Kotlin named task",
+			content: `tasks.named<Wrapper>("wrapper") {
+    gradleVersion = "8.6"
+    distributionSha256Sum = "abc123"
+}`,
+			want: &model.WrapperTask{GradleVersion: "8.6", DistributionSHA256Sum: "abc123", Line: 1},
+		},
+		{
+			name:    "Legacy task",
+			content: "task wrapper(type: Wrapper) {\n    gradleVersion '4.10.3'\n}\n",
+			want:    &model.WrapperTask{GradleVersion: "4.10.3", Line: 1},
+		},
+		{
+			name:    "Dot notation",
+			content: "tasks.wrapper.distributionUrl = 'https://mirror.example.com/gradle-8.5-bin.zip'\n",
+			want:    &model.WrapperTask{DistributionURL: "https://mirror.example.com/gradle-8.5-bin.zip", Line: 1},
+		},
+		{
+			name:    "No wrapper",
+			content: "plugins { id 'java' }\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseWrapperTask(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWrapperTask() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyWrapperTask(t *testing.T) {
+	info, err := ParseWrapperProperties(testWrapperProperties)
+	if err != nil {
+		t.Fatalf("ParseWrapperProperties() error = %v", err)
+	}
+	task := &model.WrapperTask{GradleVersion: "8.7", DistributionType: "all"}
+	info = ApplyWrapperTask(info, task)
+	if info.GradleVersion != "8.7" || info.DistributionType != "all" || info.Task != task {
+		t.Errorf("Task values should take precedence: %+v", info)
+	}
+	if info.DistributionURL != "https://services.gradle.org/distributions/gradle-8.7-all.zip" {
+		t.Errorf("Unexpected distributionUrl: %s", info.DistributionURL)
+	}
+	if info.DistributionSHA256Sum != "" {
+		t.Errorf("Checksum of the old version should be cleared, got %s", info.DistributionSHA256Sum)
+	}
+
+	info = ApplyWrapperTask(nil, &model.WrapperTask{GradleVersion: "8.5"})
+	if info.DistributionURL != "https://services.gradle.org/distributions/gradle-8.5-bin.zip" || info.DistributionType != "bin" {
+		t.Errorf("Unexpected info created from task: %+v", info)
+	}
+
+	if ApplyWrapperTask(nil, nil) != nil {
+		t.Error("Expected nil without properties and task")
+	}
+}
+
+func TestParseDistributionURL(t *testing.T) {
+	tests := []struct {
+		url         string
+		wantVersion string
+		wantType    string
+	}{
+		{"https://services.gradle.org/distributions/gradle-7.6.4-all.zip", "7.6.4", "all"},
+		{"https://services.gradle.org/distributions/gradle-8.6-rc-1-bin.zip", "8.6-rc-1", "bin"},
+		{"https://example.com/custom.zip", "", ""},
+	}
+
+	for _, tt := range tests {
+		version, distType := ParseDistributionURL(tt.url)
+		if version != tt.wantVersion || distType != tt.wantType {
+			t.Errorf("ParseDistributionURL(%q) = %q, %q; want %q, %q", tt.url, version, distType, tt.wantVersion, tt.wantType)
+		}
+	}
+}
+
+func TestCheckGradleVersion(t *testing.T) {
+	plugins := []*model.Plugin{
+		{ID: "com.android.application", Version: "8.3.0"},
+		{ID: "org.springframework.boot", Version: "3.1.0"},
+		{ID: "java"},
+	}
+
+	mismatches := CheckGradleVersion("8.2", plugins)
+	if len(mismatches) != 1 {
+		t.Fatalf("Expected 1 mismatch, got %d", len(mismatches))
+	}
+	if mismatches[0].Plugin.ID != "com.android.application" || mismatches[0].MinGradleVersion != "8.4" {
+		t.Errorf("Unexpected mismatch: %s", mismatches[0])
+	}
+
+	if got := CheckGradleVersion("8.5", plugins); len(got) != 0 {
+		t.Errorf("Expected no mismatches for Gradle 8.5, got %d", len(got))
+	}
+	if got := RequiredGradleVersion("com.android.application", "4.2.0"); got != "" {
+		t.Errorf("Unknown plugin version should have no requirement, got %s", got)
+	}
+}
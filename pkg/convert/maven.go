@@ -0,0 +1,218 @@
+// Package convert 提供Gradle模型到其他构建工具格式的转换功能。
+package convert
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// Maven POM相关常量。
+const (
+	pomModelVersion = "4.0.0"
+	pomNamespace    = "http://maven.apache.org/POM/4.0.0"
+	pomXSI          = "http://www.w3.org/2001/XMLSchema-instance"
+	pomSchema       = "http://maven.apache.org/POM/4.0.0 https://maven.apache.org/xsd/maven-4.0.0.xsd"
+)
+
+// 已知仓库的URL，用于为没有显式URL的仓库补全地址。
+var knownRepositoryURLs = map[string]string{
+	"mavenCentral": "https://repo.maven.apache.org/maven2/",
+	"google":       "https://dl.google.com/dl/android/maven2/",
+	"jcenter":      "https://jcenter.bintray.com/",
+}
+
+// MavenPOM 表示pom.xml的根元素。
+type MavenPOM struct {
+	XMLName        xml.Name          `xml:"project"`
+	Xmlns          string            `xml:"xmlns,attr"`
+	XmlnsXSI       string            `xml:"xmlns:xsi,attr"`
+	SchemaLocation string            `xml:"xsi:schemaLocation,attr"`
+	ModelVersion   string            `xml:"modelVersion"`
+	GroupID        string            `xml:"groupId,omitempty"`
+	ArtifactID     string            `xml:"artifactId"`
+	Version        string            `xml:"version,omitempty"`
+	Description    string            `xml:"description,omitempty"`
+	Properties     *MavenProperties  `xml:"properties,omitempty"`
+	Repositories   []MavenRepository `xml:"repositories>repository,omitempty"`
+	Dependencies   []MavenDependency `xml:"dependencies>dependency,omitempty"`
+}
+
+// MavenProperties 表示pom.xml中的properties元素。
+type MavenProperties struct {
+	Entries []MavenProperty
+}
+
+// MavenProperty 表示单个Maven属性。
+type MavenProperty struct {
+	Name  string
+	Value string
+}
+
+// MarshalXML 将属性序列化为 <name>value</name> 形式。
+func (mp MavenProperties) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, entry := range mp.Entries {
+		if err := e.EncodeElement(entry.Value, xml.StartElement{Name: xml.Name{Local: entry.Name}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// MavenDependency 表示pom.xml中的依赖。
+type MavenDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version,omitempty"`
+	Scope      string `xml:"scope,omitempty"`
+}
+
+// MavenRepository 表示pom.xml中的仓库。
+type MavenRepository struct {
+	ID  string `xml:"id"`
+	URL string `xml:"url"`
+}
+
+// ToMavenPOM 将Gradle项目转换为等价的pom.xml文本。
+func ToMavenPOM(project *model.Project) (string, error) {
+	pom, err := BuildMavenPOM(project)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := xml.MarshalIndent(pom, "", "    ")
+	if err != nil {
+		return "", fmt.Errorf("序列化POM失败: %w", err)
+	}
+
+	return xml.Header + string(out) + "\n", nil
+}
+
+// BuildMavenPOM 将Gradle项目转换为POM结构。
+func BuildMavenPOM(project *model.Project) (*MavenPOM, error) {
+	if project == nil {
+		return nil, fmt.Errorf("项目为空")
+	}
+
+	pom := &MavenPOM{
+		Xmlns:          pomNamespace,
+		XmlnsXSI:       pomXSI,
+		SchemaLocation: pomSchema,
+		ModelVersion:   pomModelVersion,
+		GroupID:        project.Group,
+		ArtifactID:     project.Name,
+		Version:        project.Version,
+		Description:    project.Description,
+	}
+
+	// Java版本映射为maven.compiler属性。
+	props := make([]MavenProperty, 0)
+	if project.SourceCompatibility != "" {
+		props = append(props, MavenProperty{Name: "maven.compiler.source", Value: project.SourceCompatibility})
+	}
+	if project.TargetCompatibility != "" {
+		props = append(props, MavenProperty{Name: "maven.compiler.target", Value: project.TargetCompatibility})
+	}
+	if len(props) > 0 {
+		pom.Properties = &MavenProperties{Entries: props}
+	}
+
+	for _, repo := range project.Repositories {
+		if mavenRepo, ok := convertRepository(repo); ok {
+			pom.Repositories = append(pom.Repositories, mavenRepo)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, dep := range project.Dependencies {
+		mavenDep, ok := convertDependency(dep, project)
+		if !ok {
+			continue
+		}
+		key := mavenDep.GroupID + ":" + mavenDep.ArtifactID + ":" + mavenDep.Scope
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		pom.Dependencies = append(pom.Dependencies, mavenDep)
+	}
+
+	return pom, nil
+}
+
+// MapScope 将Gradle依赖配置映射为Maven scope。
+// 返回空字符串表示使用Maven默认的compile范围。
+func MapScope(gradleScope string) string {
+	scope := gradleScope
+	if strings.HasPrefix(scope, "test") || strings.HasPrefix(scope, "androidTest") {
+		return "test"
+	}
+
+	switch {
+	case strings.HasSuffix(scope, "CompileOnly") || scope == "compileOnly" || scope == "provided":
+		return "provided"
+	case strings.HasSuffix(scope, "RuntimeOnly") || scope == "runtimeOnly" || scope == "runtime":
+		return "runtime"
+	default:
+		return ""
+	}
+}
+
+// convertDependency 将Gradle依赖转换为Maven依赖。
+func convertDependency(dep *model.Dependency, project *model.Project) (MavenDependency, bool) {
+	if dep == nil || dep.Name == "" {
+		return MavenDependency{}, false
+	}
+
+	// project(':x') 依赖映射为同组的模块依赖。
+	if dep.Group == "" {
+		if project.Group == "" {
+			return MavenDependency{}, false
+		}
+		name := dep.Name
+		if idx := strings.LastIndex(name, ":"); idx != -1 {
+			name = name[idx+1:]
+		}
+		return MavenDependency{
+			GroupID:    project.Group,
+			ArtifactID: name,
+			Version:    "${project.version}",
+			Scope:      MapScope(dep.Scope),
+		}, true
+	}
+
+	return MavenDependency{
+		GroupID:    dep.Group,
+		ArtifactID: dep.Name,
+		Version:    dep.Version,
+		Scope:      MapScope(dep.Scope),
+	}, true
+}
+
+// convertRepository 将Gradle仓库转换为Maven仓库。
+func convertRepository(repo *model.Repository) (MavenRepository, bool) {
+	if repo == nil || repo.Type != "maven" {
+		return MavenRepository{}, false
+	}
+
+	url := repo.URL
+	if url == "" {
+		url = knownRepositoryURLs[repo.Name]
+	}
+	// mavenLocal等没有远程地址的仓库无法映射。
+	if url == "" {
+		return MavenRepository{}, false
+	}
+
+	id := repo.Name
+	if id == "" {
+		id = "repo"
+	}
+
+	return MavenRepository{ID: id, URL: url}, true
+}
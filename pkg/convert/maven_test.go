@@ -0,0 +1,119 @@
+package convert
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func createTestProject() *model.Project {
+	return &model.Project{
+		Group:               "com.example",
+		Name:                "demo",
+		Version:             "1.0.0",
+		Description:         "Demo project",
+		SourceCompatibility: "17",
+		Dependencies: []*model.Dependency{
+			{Group: "com.google.guava", Name: "guava", Version: "31.1-jre", Scope: "implementation"},
+			{Group: "org.projectlombok", Name: "lombok", Version: "1.18.24", Scope: "compileOnly"},
+			{Group: "org.postgresql", Name: "postgresql", Version: "42.5.0", Scope: "runtimeOnly"},
+			{Group: "org.junit.jupiter", Name: "junit-jupiter", Version: "5.9.0", Scope: "testImplementation"},
+			{Name: "core", Scope: "api", Raw: "project(':core')"},
+		},
+		Repositories: []*model.Repository{
+			{Name: "mavenCentral", Type: "maven"},
+			{Name: "mavenLocal", Type: "maven"},
+			{Name: "jitpack.io", Type: "maven", URL: "https://jitpack.io"},
+		},
+	}
+}
+
+func TestMapScope(t *testing.T) {
+	tests := map[string]string{
+		"implementation":            "",
+		"api":                       "",
+		"compile":                   "",
+		"compileOnly":               "provided",
+		"runtimeOnly":               "runtime",
+		"runtime":                   "runtime",
+		"testImplementation":        "test",
+		"testRuntimeOnly":           "test",
+		"androidTestImplementation": "test",
+		"debugRuntimeOnly":          "runtime",
+	}
+
+	for gradleScope, want := range tests {
+		if got := MapScope(gradleScope); got != want {
+			t.Errorf("MapScope(%q) = %q, want %q", gradleScope, got, want)
+		}
+	}
+}
+
+func TestBuildMavenPOM(t *testing.T) {
+	pom, err := BuildMavenPOM(createTestProject())
+	if err != nil {
+		t.Fatalf("BuildMavenPOM() error = %v", err)
+	}
+
+	if pom.GroupID != "com.example" || pom.ArtifactID != "demo" || pom.Version != "1.0.0" {
+		t.Errorf("Unexpected coordinates: %s:%s:%s", pom.GroupID, pom.ArtifactID, pom.Version)
+	}
+
+	if len(pom.Dependencies) != 5 {
+		t.Fatalf("Expected 5 dependencies, got %d", len(pom.Dependencies))
+	}
+
+	moduleDep := pom.Dependencies[4]
+	if moduleDep.GroupID != "com.example" || moduleDep.ArtifactID != "core" || moduleDep.Version != "${project.version}" {
+		t.Errorf("Unexpected module dependency: %+v", moduleDep)
+	}
+
+	// mavenLocal没有远程地址，应被忽略。
+	if len(pom.Repositories) != 2 {
+		t.Fatalf("Expected 2 repositories, got %d", len(pom.Repositories))
+	}
+	if pom.Repositories[0].URL != "https://repo.maven.apache.org/maven2/" {
+		t.Errorf("Expected mavenCentral URL, got %s", pom.Repositories[0].URL)
+	}
+}
+
+func TestToMavenPOM(t *testing.T) {
+	out, err := ToMavenPOM(createTestProject())
+	if err != nil {
+		t.Fatalf("ToMavenPOM() error = %v", err)
+	}
+
+	expected := []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		"<modelVersion>4.0.0</modelVersion>",
+		"<artifactId>demo</artifactId>",
+		"<maven.compiler.source>17</maven.compiler.source>",
+		"<scope>provided</scope>",
+		"<scope>test</scope>",
+		"<url>https://jitpack.io</url>",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("POM missing %q\n%s", want, out)
+		}
+	}
+
+	// 输出应为合法的XML。
+	var decoded struct {
+		ArtifactID string `xml:"artifactId"`
+	}
+	if err := xml.Unmarshal([]byte(out), &decoded); err != nil {
+		t.Fatalf("Generated POM is not valid XML: %v", err)
+	}
+	if decoded.ArtifactID != "demo" {
+		t.Errorf("Expected artifactId demo, got %s", decoded.ArtifactID)
+	}
+}
+
+func TestToMavenPOMNilProject(t *testing.T) {
+	if _, err := ToMavenPOM(nil); err == nil {
+		t.Error("Expected error for nil project")
+	}
+}
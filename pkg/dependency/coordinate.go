@@ -0,0 +1,95 @@
+// Package dependency 提供依赖坐标的拆分功能。
+package dependency
+
+import (
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// ParseCoordinate 解析 group:name[:version[:classifier]][@extension] 形式的坐标字符串，
+// 两端的引号会被去除。缺少group或name时返回nil。
+func ParseCoordinate(coordinate string) *model.Dependency {
+	coordinate = strings.TrimSpace(coordinate)
+	interpolated := isInterpolated(coordinate)
+	coordinate = strings.Trim(coordinate, `"'`)
+
+	extension := ""
+	if i := strings.LastIndexByte(coordinate, '@'); i >= 0 {
+		coordinate, extension = coordinate[:i], coordinate[i+1:]
+	}
+
+	parts := strings.SplitN(coordinate, ":", 4)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil
+	}
+
+	dep := &model.Dependency{
+		Group:     parts[0],
+		Name:      parts[1],
+		Extension: extension,
+	}
+	if len(parts) > 2 {
+		dep.Version = parts[2]
+	}
+	if len(parts) > 3 {
+		dep.Classifier = parts[3]
+	}
+	dep.HasDynamicVersion = interpolated && strings.Contains(dep.Version, "$")
+	return dep
+}
+
+// scanCoordinate 拆分可能带引号的 group:name[:version] 坐标字面量，返回的字段都是输入的子串，不分配内存。
+// 两端各允许一个引号，中间不能再有引号；group和name不能为空且不含冒号，
+// version为第二个冒号之后的全部内容（可能包含分类器和扩展名，由normalizeCoordinate拆分）。
+func scanCoordinate(s string) (group, name, version string, ok bool) {
+	if s != "" && (s[0] == '\'' || s[0] == '"') {
+		s = s[1:]
+	}
+	if s != "" && (s[len(s)-1] == '\'' || s[len(s)-1] == '"') {
+		s = s[:len(s)-1]
+	}
+	if strings.ContainsAny(s, `'"`) {
+		return "", "", "", false
+	}
+
+	group, rest, found := strings.Cut(s, ":")
+	if !found || group == "" {
+		return "", "", "", false
+	}
+	name, version, found = strings.Cut(rest, ":")
+	if name == "" || found && version == "" {
+		return "", "", "", false
+	}
+	return group, name, version, true
+}
+
+// normalizeCoordinate 把正则匹配时混入版本号（或无版本时混入名称）的分类器和扩展名拆分到独立字段，
+// 并标记由变量插值给出的版本。
+// 例如版本 "2.4:jdk15" 拆分为版本 "2.4" 和分类器 "jdk15"，"1.0@zip" 拆分为版本 "1.0" 和扩展名 "zip"。
+func normalizeCoordinate(dep *model.Dependency) {
+	field := &dep.Version
+	if dep.Version == "" {
+		field = &dep.Name
+	}
+
+	if i := strings.LastIndexByte(*field, '@'); i >= 0 {
+		dep.Extension = (*field)[i+1:]
+		*field = (*field)[:i]
+	}
+
+	if dep.Version != "" {
+		if i := strings.IndexByte(dep.Version, ':'); i >= 0 {
+			dep.Classifier = dep.Version[i+1:]
+			dep.Version = dep.Version[:i]
+		}
+	}
+
+	dep.HasDynamicVersion = isInterpolated(dep.Raw) && strings.Contains(dep.Version, "$")
+}
+
+// isInterpolated 判断字符串字面量是否会进行变量插值。
+// Groovy的单引号字符串不做插值，其中的 $ 只是普通字符。
+func isInterpolated(literal string) bool {
+	return !strings.HasPrefix(strings.TrimSpace(literal), "'") && strings.Contains(literal, "$")
+}
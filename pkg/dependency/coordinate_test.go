@@ -0,0 +1,104 @@
+package dependency
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestParseCoordinate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  *model.Dependency
+	}{
+		{"'org.foo:bar:1.0'", &model.Dependency{Group: "org.foo", Name: "bar", Version: "1.0"}},
+		{`"org.foo:bar"`, &model.Dependency{Group: "org.foo", Name: "bar"}},
+		{"net.sf.json-lib:json-lib:2.4:jdk15", &model.Dependency{Group: "net.sf.json-lib", Name: "json-lib", Version: "2.4", Classifier: "jdk15"}},
+		{"org.foo:bar:1.0@zip", &model.Dependency{Group: "org.foo", Name: "bar", Version: "1.0", Extension: "zip"}},
+		{"org.foo:bar:1.0:sources@jar", &model.Dependency{Group: "org.foo", Name: "bar", Version: "1.0", Classifier: "sources", Extension: "jar"}},
+		{"org.foo:bar@aar", &model.Dependency{Group: "org.foo", Name: "bar", Extension: "aar"}},
+		{"org.foo", nil},
+		{":bar:1.0", nil},
+	}
+
+	for _, tt := range tests {
+		got := ParseCoordinate(tt.input)
+		if tt.want == nil {
+			if got != nil {
+				t.Errorf("ParseCoordinate(%q) = %+v, want nil", tt.input, got)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseCoordinate(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestExtractDependenciesClassifierAndExtension(t *testing.T) {
+	text := `
+dependencies {
+    implementation 'net.sf.json-lib:json-lib:2.4:jdk15'
+    implementation 'org.foo:bar:1.0@zip'
+    runtimeOnly 'org.foo:native:2.0:linux-x86_64@so'
+    implementation 'com.example:widget@aar'
+}
+`
+	deps := NewParser().ExtractDependenciesFromText(text)
+	if len(deps) != 4 {
+		t.Fatalf("Expected 4 dependencies, got %d", len(deps))
+	}
+
+	want := []struct{ name, version, classifier, extension string }{
+		{"json-lib", "2.4", "jdk15", ""},
+		{"bar", "1.0", "", "zip"},
+		{"native", "2.0", "linux-x86_64", "so"},
+		{"widget", "", "", "aar"},
+	}
+	for i, w := range want {
+		dep := deps[i]
+		if dep.Name != w.name || dep.Version != w.version || dep.Classifier != w.classifier || dep.Extension != w.extension {
+			t.Errorf("dependency %d = %s:%s:%s classifier=%q extension=%q, want %s:%s classifier=%q extension=%q",
+				i, dep.Group, dep.Name, dep.Version, dep.Classifier, dep.Extension, w.name, w.version, w.classifier, w.extension)
+		}
+	}
+}
+
+func TestScanCoordinate(t *testing.T) {
+	tests := []struct {
+		input                string
+		group, name, version string
+		ok                   bool
+	}{
+		{"'org.foo:bar:1.0'", "org.foo", "bar", "1.0", true},
+		{`"org.foo:bar"`, "org.foo", "bar", "", true},
+		{"org.foo:bar:2.4:jdk15@zip", "org.foo", "bar", "2.4:jdk15@zip", true},
+		{`"org.foo:bar:$version"`, "org.foo", "bar", "$version", true},
+		{"'org.foo:bar:1.0", "org.foo", "bar", "1.0", true},
+		{"'org.foo:bar:1.0' // comment", "", "", "", false},
+		{"platform('org.foo:bom:1.0')", "", "", "", false},
+		{"'org.foo:bar:'", "", "", "", false},
+		{"':bar:1.0'", "", "", "", false},
+		{"'org.foo'", "", "", "", false},
+		{"libs.foo", "", "", "", false},
+		{"'", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		group, name, version, ok := scanCoordinate(tt.input)
+		if ok != tt.ok || group != tt.group || name != tt.name || version != tt.version {
+			t.Errorf("scanCoordinate(%q) = %q, %q, %q, %v, want %q, %q, %q, %v",
+				tt.input, group, name, version, ok, tt.group, tt.name, tt.version, tt.ok)
+		}
+	}
+}
+
+func BenchmarkScanCoordinate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, _, ok := scanCoordinate("'org.springframework.boot:spring-boot-starter-web:2.7.0'"); !ok {
+			b.Fatal("coordinate not recognized")
+		}
+	}
+}
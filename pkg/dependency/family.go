@@ -0,0 +1,189 @@
+// Package dependency 提供常见依赖家族（Spring Boot、Jackson、JUnit等）的识别和家族内版本一致性检查。
+package dependency
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/util"
+)
+
+// Family 描述一组应该使用相同版本发布和使用的制品。
+type Family struct {
+	ID     string   `json:"id"`
+	Groups []string `json:"groups"`
+	// NamePrefix 不为空时只有以它开头的制品属于该家族，用于区分同一group下的不同家族。
+	NamePrefix string `json:"namePrefix,omitempty"`
+	// Precision 是版本需要一致的段数，例如2表示只要求主版本和次版本一致；0表示完整版本需要一致。
+	Precision int `json:"precision,omitempty"`
+	// BOM 是统一管理家族版本的BOM坐标，没有时为空。
+	BOM string `json:"bom,omitempty"`
+}
+
+// FamilyUpgrade 是对家族中版本落后的单个依赖的升级建议。
+type FamilyUpgrade struct {
+	Dependency    *model.Dependency `json:"dependency"`
+	TargetVersion string            `json:"targetVersion"`
+}
+
+// FamilyReport 是构建中一个依赖家族的分析结果。
+type FamilyReport struct {
+	Family       *Family             `json:"family"`
+	Dependencies []*model.Dependency `json:"dependencies"`
+	// Versions 是家族中出现的不同版本，按版本升序排列，不包括无法比较的版本。
+	Versions   []string `json:"versions"`
+	Consistent bool     `json:"consistent"`
+	// TargetVersion 是家族中最高的版本，版本不一致时建议统一升级到该版本。
+	TargetVersion string           `json:"targetVersion,omitempty"`
+	Upgrades      []*FamilyUpgrade `json:"upgrades,omitempty"`
+	Suggestion    string           `json:"suggestion,omitempty"`
+}
+
+// knownFamilies 是内置的依赖家族，同一group下前缀更具体的家族在前。
+var knownFamilies = []*Family{
+	{ID: "spring-boot", Groups: []string{"org.springframework.boot"},
+		BOM: "org.springframework.boot:spring-boot-dependencies"},
+	{ID: "spring-framework", Groups: []string{"org.springframework"},
+		BOM: "org.springframework:spring-framework-bom"},
+	{ID: "jackson", Groups: []string{"com.fasterxml.jackson.core", "com.fasterxml.jackson.dataformat",
+		"com.fasterxml.jackson.datatype", "com.fasterxml.jackson.module", "com.fasterxml.jackson.jaxrs",
+		"com.fasterxml.jackson.jakarta.rs"},
+		// jackson-annotations 等模块的补丁版本可能落后于其他模块，只要求次版本一致。
+		Precision: 2, BOM: "com.fasterxml.jackson:jackson-bom"},
+	{ID: "junit-jupiter", Groups: []string{"org.junit.jupiter", "org.junit.vintage"},
+		BOM: "org.junit:junit-bom"},
+	// junit-bom 使用Jupiter的版本号，与Platform的版本号不同，因此不给出BOM建议。
+	{ID: "junit-platform", Groups: []string{"org.junit.platform"}},
+	{ID: "kotlinx-coroutines", Groups: []string{"org.jetbrains.kotlinx"}, NamePrefix: "kotlinx-coroutines-",
+		BOM: "org.jetbrains.kotlinx:kotlinx-coroutines-bom"},
+	{ID: "kotlin", Groups: []string{"org.jetbrains.kotlin"}, NamePrefix: "kotlin-",
+		BOM: "org.jetbrains.kotlin:kotlin-bom"},
+	{ID: "netty", Groups: []string{"io.netty"}, NamePrefix: "netty-",
+		BOM: "io.netty:netty-bom"},
+	{ID: "log4j", Groups: []string{"org.apache.logging.log4j"},
+		BOM: "org.apache.logging.log4j:log4j-bom"},
+	{ID: "slf4j", Groups: []string{"org.slf4j"}},
+	{ID: "okhttp", Groups: []string{"com.squareup.okhttp3"},
+		BOM: "com.squareup.okhttp3:okhttp-bom"},
+	{ID: "grpc", Groups: []string{"io.grpc"},
+		BOM: "io.grpc:grpc-bom"},
+}
+
+// KnownFamilies 返回内置依赖家族的副本。
+func KnownFamilies() []*Family {
+	families := make([]*Family, 0, len(knownFamilies))
+	for _, f := range knownFamilies {
+		copied := *f
+		copied.Groups = append([]string(nil), f.Groups...)
+		families = append(families, &copied)
+	}
+	return families
+}
+
+// FindFamily 查找坐标所属的依赖家族，返回家族的副本，不属于任何家族时返回nil。
+func FindFamily(group, name string) *Family {
+	for _, f := range knownFamilies {
+		if f.matches(group, name) {
+			copied := *f
+			copied.Groups = append([]string(nil), f.Groups...)
+			return &copied
+		}
+	}
+	return nil
+}
+
+// matches 判断坐标是否属于该家族。
+func (f *Family) matches(group, name string) bool {
+	if f.NamePrefix != "" && !strings.HasPrefix(name, f.NamePrefix) {
+		return false
+	}
+	for _, g := range f.Groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// versionKey 返回版本中需要在家族内保持一致的部分。
+func (f *Family) versionKey(version string) string {
+	if f.Precision <= 0 {
+		return version
+	}
+	parts := strings.SplitN(version, ".", f.Precision+1)
+	if len(parts) > f.Precision {
+		parts = parts[:f.Precision]
+	}
+	return strings.Join(parts, ".")
+}
+
+// AnalyzeFamilies 把依赖按内置家族分组，检查家族内的版本是否一致，并为版本落后的依赖给出升级建议。
+// 结果按家族在依赖列表中首次出现的顺序排列。未声明版本（通常由BOM管理）、使用变量和动态版本的依赖归入家族但不参与比较。
+func AnalyzeFamilies(deps []*model.Dependency) []*FamilyReport {
+	reports := make([]*FamilyReport, 0)
+	byID := make(map[string]*FamilyReport)
+	for _, dep := range deps {
+		if dep == nil || dep.Group == "" || dep.Name == "" {
+			continue
+		}
+		family := FindFamily(dep.Group, dep.Name)
+		if family == nil {
+			continue
+		}
+		report := byID[family.ID]
+		if report == nil {
+			report = &FamilyReport{Family: family, Dependencies: make([]*model.Dependency, 0), Versions: make([]string, 0)}
+			byID[family.ID] = report
+			reports = append(reports, report)
+		}
+		report.Dependencies = append(report.Dependencies, dep)
+	}
+
+	for _, report := range reports {
+		report.analyze()
+	}
+	return reports
+}
+
+// analyze 统计家族中的版本并生成升级建议。
+func (r *FamilyReport) analyze() {
+	seen := make(map[string]bool)
+	for _, dep := range r.Dependencies {
+		if !comparableVersion(dep.Version) || seen[dep.Version] {
+			continue
+		}
+		seen[dep.Version] = true
+		r.Versions = append(r.Versions, dep.Version)
+	}
+	sort.SliceStable(r.Versions, func(i, j int) bool { return util.CompareVersions(r.Versions[i], r.Versions[j]) < 0 })
+
+	r.Consistent = true
+	if len(r.Versions) == 0 {
+		return
+	}
+	r.TargetVersion = r.Versions[len(r.Versions)-1]
+	targetKey := r.Family.versionKey(r.TargetVersion)
+	for _, dep := range r.Dependencies {
+		if !comparableVersion(dep.Version) || r.Family.versionKey(dep.Version) == targetKey {
+			continue
+		}
+		r.Consistent = false
+		r.Upgrades = append(r.Upgrades, &FamilyUpgrade{Dependency: dep, TargetVersion: r.TargetVersion})
+	}
+	if r.Consistent {
+		return
+	}
+
+	r.Suggestion = fmt.Sprintf("%s 依赖的版本不一致（%s），建议统一升级到 %s",
+		r.Family.ID, strings.Join(r.Versions, "、"), r.TargetVersion)
+	if r.Family.BOM != "" {
+		r.Suggestion += fmt.Sprintf("，或使用 platform('%s:%s') 统一管理版本", r.Family.BOM, r.TargetVersion)
+	}
+}
+
+// comparableVersion 判断版本是否可以参与比较：未声明的版本、变量引用和动态版本无法比较。
+func comparableVersion(version string) bool {
+	return version != "" && !strings.ContainsAny(version, "$+[]()") && !strings.HasPrefix(version, "latest.")
+}
@@ -0,0 +1,69 @@
+package dependency
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestAnalyzeFamilies(t *testing.T) {
+	deps := []*model.Dependency{
+		{Group: "com.fasterxml.jackson.core", Name: "jackson-databind", Version: "2.15.2"},
+		{Group: "org.springframework.boot", Name: "spring-boot-starter-web"},
+		{Group: "com.fasterxml.jackson.core", Name: "jackson-annotations", Version: "2.15.0"},
+		{Group: "com.fasterxml.jackson.datatype", Name: "jackson-datatype-jsr310", Version: "2.13.4"},
+		{Group: "org.springframework.boot", Name: "spring-boot-starter-test", Version: "$bootVersion"},
+		{Group: "org.jetbrains.kotlin", Name: "kotlin-stdlib", Version: "1.9.22"},
+		{Group: "org.jetbrains.kotlinx", Name: "kotlinx-coroutines-core", Version: "1.7.3"},
+		{Group: "org.jetbrains.kotlin", Name: "kotlin-reflect", Version: "1.9.22"},
+		{Group: "com.google.guava", Name: "guava", Version: "32.1.3-jre"},
+	}
+
+	reports := AnalyzeFamilies(deps)
+	ids := make([]string, 0, len(reports))
+	for _, report := range reports {
+		ids = append(ids, report.Family.ID)
+	}
+	if want := []string{"jackson", "spring-boot", "kotlin", "kotlinx-coroutines"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("family IDs = %v, want %v", ids, want)
+	}
+
+	jackson := reports[0]
+	if jackson.Consistent || jackson.TargetVersion != "2.15.2" {
+		t.Errorf("Expected inconsistent jackson targeting 2.15.2, got %+v", jackson)
+	}
+	if want := []string{"2.13.4", "2.15.0", "2.15.2"}; !reflect.DeepEqual(jackson.Versions, want) {
+		t.Errorf("jackson versions = %v, want %v", jackson.Versions, want)
+	}
+	if len(jackson.Upgrades) != 1 || jackson.Upgrades[0].Dependency.Name != "jackson-datatype-jsr310" {
+		t.Errorf("Expected only jackson-datatype-jsr310 to need an upgrade, got %+v", jackson.Upgrades)
+	}
+	wantSuggestion := "jackson 依赖的版本不一致（2.13.4、2.15.0、2.15.2），建议统一升级到 2.15.2，" +
+		"或使用 platform('com.fasterxml.jackson:jackson-bom:2.15.2') 统一管理版本"
+	if jackson.Suggestion != wantSuggestion {
+		t.Errorf("Unexpected suggestion: %s", jackson.Suggestion)
+	}
+
+	boot := reports[1]
+	if !boot.Consistent || len(boot.Dependencies) != 2 || len(boot.Versions) != 0 {
+		t.Errorf("Expected spring-boot without comparable versions to be consistent, got %+v", boot)
+	}
+	if kotlin := reports[2]; !kotlin.Consistent || kotlin.Suggestion != "" {
+		t.Errorf("Expected consistent kotlin family, got %+v", kotlin)
+	}
+}
+
+func TestFindFamily(t *testing.T) {
+	if family := FindFamily("org.jetbrains.kotlinx", "kotlinx-serialization-json"); family != nil {
+		t.Errorf("Expected no family for kotlinx-serialization, got %s", family.ID)
+	}
+	family := FindFamily("org.junit.jupiter", "junit-jupiter-api")
+	if family == nil || family.ID != "junit-jupiter" {
+		t.Fatalf("Expected junit-jupiter family, got %+v", family)
+	}
+	family.Groups[0] = "changed"
+	if FindFamily("org.junit.jupiter", "junit-jupiter-api") == nil {
+		t.Error("Modifying a returned family should not affect the built-in table")
+	}
+}
@@ -0,0 +1,293 @@
+// Package dependency 提供基于BOM和dependencyManagement的依赖版本补全功能。
+package dependency
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// BOM来源类型。
+const (
+	BOMSourceMavenBom         = "mavenBom"
+	BOMSourcePlatform         = "platform"
+	BOMSourceEnforcedPlatform = "enforcedPlatform"
+	BOMSourceSpringBoot       = "spring-boot-plugin"
+)
+
+// 版本来源前缀，写入Dependency.ResolvedFrom。
+const (
+	resolvedFromManagement = "dependencyManagement"
+	resolvedFromBOM        = "bom:"
+)
+
+const (
+	springBootPlugin           = "org.springframework.boot"
+	springDependencyManagement = "io.spring.dependency-management"
+)
+
+var (
+	// 匹配 mavenBom 'group:name:version'。
+	mavenBomRegex = regexp.MustCompile(`mavenBom\s*\(?\s*['"]([^'"]+)['"]`)
+
+	// 匹配 platform('group:name:version') 和 enforcedPlatform("group:name:version")。
+	platformRegex = regexp.MustCompile(`\b(enforcedPlatform|platform)\s*\(\s*['"]([^'"]+)['"]\s*\)`)
+
+	// 匹配dependencyManagement中的 dependency 'group:name:version'。
+	managedDependencyRegex = regexp.MustCompile(`\bdependency\s*\(?\s*['"]([^:'"]+):([^:'"]+):([^'"]+)['"]`)
+
+	// 匹配 dependencySet(group: 'g', version: 'v') { entry 'a' }。
+	dependencySetRegex = regexp.MustCompile(
+		`dependencySet\s*\(\s*group\s*:\s*['"]([^'"]+)['"]\s*,\s*version\s*:\s*['"]([^'"]+)['"]\s*\)\s*\{([^}]*)\}`)
+	dependencySetEntryRegex = regexp.MustCompile(`entry\s*\(?\s*['"]([^'"]+)['"]`)
+
+	// 匹配 ${name} 或 $name 形式的属性引用。
+	propertyRefRegex = regexp.MustCompile(`\$\{([A-Za-z_][\w.]*)\}|\$([A-Za-z_]\w*)`)
+)
+
+// 部分BOM的版本与其所管理的某个group的版本保持一致，没有BOM内容时用于兜底。
+var bomGroupFallbacks = map[string]string{
+	"org.springframework.boot:spring-boot-dependencies": "org.springframework.boot",
+	"org.jetbrains.kotlin:kotlin-bom":                   "org.jetbrains.kotlin",
+}
+
+// BOM 表示一个导入的物料清单（Bill of Materials）。
+type BOM struct {
+	Group   string `json:"group"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Source  string `json:"source"` // mavenBom、platform、enforcedPlatform 或 spring-boot-plugin。
+}
+
+// Coordinate 返回BOM的 group:name:version 坐标。
+func (b *BOM) Coordinate() string {
+	return fmt.Sprintf("%s:%s:%s", b.Group, b.Name, b.Version)
+}
+
+// ManagedVersion 表示一个受管理的依赖版本。
+type ManagedVersion struct {
+	Group   string `json:"group"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// DependencyManagement 表示从构建脚本中提取的版本管理信息。
+type DependencyManagement struct {
+	BOMs     []*BOM            `json:"boms"`
+	Versions []*ManagedVersion `json:"versions"` // dependencyManagement中显式声明的版本。
+}
+
+// BOMProvider 提供BOM中管理的依赖版本，例如从本地Maven仓库或远程仓库读取BOM的POM文件。
+type BOMProvider interface {
+	// ManagedVersions 返回指定BOM管理的依赖版本。
+	ManagedVersions(bom *BOM) ([]*ManagedVersion, error)
+}
+
+// StaticBOMProvider 是基于内存数据的BOMProvider，键为BOM的 group:name:version 坐标。
+type StaticBOMProvider map[string][]*ManagedVersion
+
+// ManagedVersions 返回指定BOM管理的依赖版本。
+func (p StaticBOMProvider) ManagedVersions(bom *BOM) ([]*ManagedVersion, error) {
+	return p[bom.Coordinate()], nil
+}
+
+// ExtractDependencyManagement 从构建脚本文本中提取BOM和dependencyManagement声明。
+// 同时应用了Spring Boot插件和io.spring.dependency-management插件时，会隐式导入spring-boot-dependencies。
+func (dp *Parser) ExtractDependencyManagement(text string, project *model.Project) *DependencyManagement {
+	dm := &DependencyManagement{
+		BOMs:     make([]*BOM, 0),
+		Versions: make([]*ManagedVersion, 0),
+	}
+
+	var props map[string]string
+	if project != nil {
+		props = project.Properties
+	}
+
+	for _, match := range mavenBomRegex.FindAllStringSubmatch(text, -1) {
+		if bom := parseBOM(substituteProperties(match[1], props), BOMSourceMavenBom); bom != nil {
+			dm.BOMs = append(dm.BOMs, bom)
+		}
+	}
+
+	for _, match := range platformRegex.FindAllStringSubmatch(text, -1) {
+		if bom := parseBOM(substituteProperties(match[2], props), match[1]); bom != nil {
+			dm.BOMs = append(dm.BOMs, bom)
+		}
+	}
+
+	for _, match := range managedDependencyRegex.FindAllStringSubmatch(text, -1) {
+		dm.Versions = append(dm.Versions, &ManagedVersion{
+			Group:   match[1],
+			Name:    match[2],
+			Version: substituteProperties(match[3], props),
+		})
+	}
+
+	for _, match := range dependencySetRegex.FindAllStringSubmatch(text, -1) {
+		version := substituteProperties(match[2], props)
+		for _, entry := range dependencySetEntryRegex.FindAllStringSubmatch(match[3], -1) {
+			dm.Versions = append(dm.Versions, &ManagedVersion{Group: match[1], Name: entry[1], Version: version})
+		}
+	}
+
+	if bom := springBootBOM(project); bom != nil {
+		dm.BOMs = append(dm.BOMs, bom)
+	}
+
+	return dm
+}
+
+// ResolveManagedVersions 为未声明版本的依赖补全版本，并在ResolvedFrom中记录来源。
+// 优先使用dependencyManagement中的显式版本，其次按声明顺序使用BOM，先声明的BOM优先。
+// provider为nil时只使用显式版本和内置的兜底规则。返回仍未能补全版本的依赖。
+func (dp *Parser) ResolveManagedVersions(deps []*model.Dependency, dm *DependencyManagement, provider BOMProvider) ([]*model.Dependency, error) {
+	unresolved := make([]*model.Dependency, 0)
+	if dm == nil {
+		dm = &DependencyManagement{}
+	}
+
+	bomVersions := make([][]*ManagedVersion, len(dm.BOMs))
+	if provider != nil {
+		for i, bom := range dm.BOMs {
+			versions, err := provider.ManagedVersions(bom)
+			if err != nil {
+				return nil, fmt.Errorf("读取BOM %s 失败: %w", bom.Coordinate(), err)
+			}
+			bomVersions[i] = versions
+		}
+	}
+
+	for _, dep := range deps {
+		if dep == nil || dep.Version != "" || dep.Group == "" {
+			continue
+		}
+
+		if version := findManagedVersion(dm.Versions, dep.Group, dep.Name); version != "" {
+			dep.Version = version
+			dep.ResolvedFrom = resolvedFromManagement
+			continue
+		}
+
+		resolved := false
+		for i, bom := range dm.BOMs {
+			version := findManagedVersion(bomVersions[i], dep.Group, dep.Name)
+			if version == "" && bomGroupFallbacks[bom.Group+":"+bom.Name] == dep.Group {
+				version = bom.Version
+			}
+			if version != "" {
+				dep.Version = version
+				dep.ResolvedFrom = resolvedFromBOM + bom.Coordinate()
+				resolved = true
+				break
+			}
+		}
+
+		if !resolved {
+			unresolved = append(unresolved, dep)
+		}
+	}
+
+	return unresolved, nil
+}
+
+// ParseBOMManagedVersions 解析BOM的POM文件，返回 <dependencyManagement> 中声明的版本。
+// POM中 <properties> 定义的属性会被替换。
+func ParseBOMManagedVersions(r io.Reader) ([]*ManagedVersion, error) {
+	var pom struct {
+		Properties struct {
+			Entries []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"properties"`
+		Dependencies []struct {
+			GroupID    string `xml:"groupId"`
+			ArtifactID string `xml:"artifactId"`
+			Version    string `xml:"version"`
+		} `xml:"dependencyManagement>dependencies>dependency"`
+	}
+	if err := xml.NewDecoder(r).Decode(&pom); err != nil {
+		return nil, fmt.Errorf("解析BOM失败: %w", err)
+	}
+
+	props := make(map[string]string, len(pom.Properties.Entries))
+	for _, entry := range pom.Properties.Entries {
+		props[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+	}
+
+	versions := make([]*ManagedVersion, 0, len(pom.Dependencies))
+	for _, dep := range pom.Dependencies {
+		versions = append(versions, &ManagedVersion{
+			Group:   substituteProperties(strings.TrimSpace(dep.GroupID), props),
+			Name:    substituteProperties(strings.TrimSpace(dep.ArtifactID), props),
+			Version: substituteProperties(strings.TrimSpace(dep.Version), props),
+		})
+	}
+	return versions, nil
+}
+
+// springBootBOM 返回Spring Boot插件隐式导入的BOM。
+func springBootBOM(project *model.Project) *BOM {
+	if project == nil {
+		return nil
+	}
+
+	var bootVersion string
+	hasDependencyManagement := false
+	for _, plugin := range project.Plugins {
+		switch plugin.ID {
+		case springBootPlugin:
+			bootVersion = plugin.Version
+		case springDependencyManagement:
+			hasDependencyManagement = true
+		}
+	}
+
+	if bootVersion == "" || !hasDependencyManagement {
+		return nil
+	}
+	return &BOM{
+		Group:   springBootPlugin,
+		Name:    "spring-boot-dependencies",
+		Version: bootVersion,
+		Source:  BOMSourceSpringBoot,
+	}
+}
+
+// parseBOM 解析 group:name:version 形式的BOM坐标。
+func parseBOM(coordinate, source string) *BOM {
+	parts := strings.Split(coordinate, ":")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil
+	}
+	return &BOM{Group: parts[0], Name: parts[1], Version: parts[2], Source: source}
+}
+
+// findManagedVersion 在受管理版本中查找指定依赖的版本。
+func findManagedVersion(versions []*ManagedVersion, group, name string) string {
+	for _, mv := range versions {
+		if mv.Group == group && mv.Name == name {
+			return mv.Version
+		}
+	}
+	return ""
+}
+
+// substituteProperties 替换文本中引用的属性，无法解析的引用保持原样。
+func substituteProperties(s string, props map[string]string) string {
+	if len(props) == 0 || !strings.Contains(s, "$") {
+		return s
+	}
+	return propertyRefRegex.ReplaceAllStringFunc(s, func(ref string) string {
+		name := strings.Trim(strings.TrimPrefix(ref, "$"), "{}")
+		if value, ok := props[name]; ok {
+			return value
+		}
+		return ref
+	})
+}
@@ -0,0 +1,139 @@
+package dependency
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+const managedBuildScript = `plugins {
+    id 'org.springframework.boot' version '3.1.0'
+    id 'io.spring.dependency-management' version '1.1.0'
+}
+
+dependencyManagement {
+    imports {
+        mavenBom "org.springframework.cloud:spring-cloud-dependencies:${springCloudVersion}"
+    }
+    dependencies {
+        dependency 'com.google.guava:guava:32.0.0-jre'
+        dependencySet(group: 'org.slf4j', version: '2.0.7') {
+            entry 'slf4j-api'
+            entry 'slf4j-simple'
+        }
+    }
+}
+
+dependencies {
+    implementation platform('com.fasterxml.jackson:jackson-bom:2.15.2')
+    implementation 'org.springframework.boot:spring-boot-starter-web'
+    implementation 'org.springframework.cloud:spring-cloud-starter-config'
+    implementation 'com.fasterxml.jackson.core:jackson-databind'
+    implementation 'com.google.guava:guava'
+    implementation 'org.slf4j:slf4j-api'
+    implementation 'org.example:unmanaged'
+}
+`
+
+func TestExtractDependencyManagement(t *testing.T) {
+	project := &model.Project{
+		Properties: map[string]string{"springCloudVersion": "2022.0.3"},
+		Plugins: []*model.Plugin{
+			{ID: "org.springframework.boot", Version: "3.1.0"},
+			{ID: "io.spring.dependency-management", Version: "1.1.0"},
+		},
+	}
+
+	dm := NewParser().ExtractDependencyManagement(managedBuildScript, project)
+
+	if len(dm.BOMs) != 3 {
+		t.Fatalf("Expected 3 BOMs, got %d", len(dm.BOMs))
+	}
+	if dm.BOMs[0].Coordinate() != "org.springframework.cloud:spring-cloud-dependencies:2022.0.3" || dm.BOMs[0].Source != BOMSourceMavenBom {
+		t.Errorf("Unexpected mavenBom: %+v", dm.BOMs[0])
+	}
+	if dm.BOMs[1].Name != "jackson-bom" || dm.BOMs[1].Source != BOMSourcePlatform {
+		t.Errorf("Unexpected platform BOM: %+v", dm.BOMs[1])
+	}
+	if dm.BOMs[2].Name != "spring-boot-dependencies" || dm.BOMs[2].Version != "3.1.0" {
+		t.Errorf("Unexpected Spring Boot BOM: %+v", dm.BOMs[2])
+	}
+
+	if len(dm.Versions) != 3 {
+		t.Errorf("Expected 3 managed versions, got %d", len(dm.Versions))
+	}
+}
+
+func TestResolveManagedVersions(t *testing.T) {
+	parser := NewParser()
+	project := &model.Project{
+		Properties: map[string]string{"springCloudVersion": "2022.0.3"},
+		Plugins: []*model.Plugin{
+			{ID: "org.springframework.boot", Version: "3.1.0"},
+			{ID: "io.spring.dependency-management", Version: "1.1.0"},
+		},
+	}
+	deps := parser.ExtractDependenciesFromText(managedBuildScript)
+	dm := parser.ExtractDependencyManagement(managedBuildScript, project)
+
+	provider := StaticBOMProvider{
+		"com.fasterxml.jackson:jackson-bom:2.15.2": {
+			{Group: "com.fasterxml.jackson.core", Name: "jackson-databind", Version: "2.15.2"},
+		},
+	}
+
+	unresolved, err := parser.ResolveManagedVersions(deps, dm, provider)
+	if err != nil {
+		t.Fatalf("ResolveManagedVersions() error = %v", err)
+	}
+
+	expected := map[string][2]string{
+		"spring-boot-starter-web": {"3.1.0", "bom:org.springframework.boot:spring-boot-dependencies:3.1.0"},
+		"jackson-databind":        {"2.15.2", "bom:com.fasterxml.jackson:jackson-bom:2.15.2"},
+		"guava":                   {"32.0.0-jre", "dependencyManagement"},
+		"slf4j-api":               {"2.0.7", "dependencyManagement"},
+	}
+	for _, dep := range deps {
+		want, ok := expected[dep.Name]
+		if !ok {
+			continue
+		}
+		if dep.Version != want[0] || dep.ResolvedFrom != want[1] {
+			t.Errorf("%s: got version %q from %q, want %q from %q", dep.Name, dep.Version, dep.ResolvedFrom, want[0], want[1])
+		}
+	}
+
+	names := make([]string, 0, len(unresolved))
+	for _, dep := range unresolved {
+		names = append(names, dep.Name)
+	}
+	if strings.Join(names, ",") != "spring-cloud-starter-config,unmanaged" {
+		t.Errorf("Unexpected unresolved dependencies: %v", names)
+	}
+}
+
+func TestParseBOMManagedVersions(t *testing.T) {
+	pom := `<project>
+  <properties>
+    <jackson.version>2.15.2</jackson.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>com.fasterxml.jackson.core</groupId>
+        <artifactId>jackson-core</artifactId>
+        <version>${jackson.version}</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`
+
+	versions, err := ParseBOMManagedVersions(strings.NewReader(pom))
+	if err != nil {
+		t.Fatalf("ParseBOMManagedVersions() error = %v", err)
+	}
+	if len(versions) != 1 || versions[0].Name != "jackson-core" || versions[0].Version != "2.15.2" {
+		t.Errorf("Unexpected managed versions: %+v", versions)
+	}
+}
@@ -4,29 +4,20 @@ package dependency
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/util"
 )
 
-// 常见的依赖声明正则表达式。
-var (
-	// 格式: group:name:version。
-	// 例如: org.springframework:spring-core:5.3.10。
-	gavRegex = regexp.MustCompile(`^(['"]?)([^:'"]+):([^:'"]+):([^'"]+)(['"]?)$`)
+// 格式: "${name}" 或 "$name"，整个坐标由变量给出。
+// 例如: "${deps.spring}"。
+var interpolatedCoordinateRegex = regexp.MustCompile(`^"(\$\{[A-Za-z_][\w.]*\}|\$[A-Za-z_]\w*)"$`)
 
-	// 格式: group:name (没有版本号)。
-	// 例如: org.springframework.boot:spring-boot-starter-web。
-	gaRegex = regexp.MustCompile(`^(['"]?)([^:'"]+):([^:'"]+)(['"]?)$`)
-
-	// 格式: group.name:name:version。
-	// 例如: org.springframework.boot:spring-boot-starter:2.5.5。
-	dotNameRegex = regexp.MustCompile(`^(['"]?)([^:'"]+)\.([^:'"]+):([^:'"]+):([^'"]+)(['"]?)$`)
-
-	// 格式: project(":name")。
-	// 例如: project(":app")。
-	projectRefRegex = regexp.MustCompile(`^project\(['"]:(.*)['"]\)$`)
-)
+// 匹配map形式依赖中的一个属性，Groovy使用冒号，Kotlin使用等号。
+// 例如: group: 'org.foo'、version = "1.0"。
+var mapAttributeRegex = regexp.MustCompile(`\b(group|name|version|classifier|ext)\s*[:=]\s*['"]([^'"]*)['"]`)
 
 // 依赖配置范围。
 var commonScopes = []string{
@@ -36,14 +27,86 @@ var commonScopes = []string{
 	"debugImplementation", "releaseImplementation",
 }
 
+// commonScopeSet 是commonScopes的集合形式，用于逐行解析时快速判断配置名称。
+var commonScopeSet = func() map[string]bool {
+	set := make(map[string]bool, len(commonScopes))
+	for _, scope := range commonScopes {
+		set[scope] = true
+	}
+	return set
+}()
+
+// 依赖原文中包含这些内容时视为仓库地址等非依赖声明。
+var skipPatterns = []string{
+	"https://github.com",
+	"https://central.sonatype.com/repository/maven-snapshots",
+	"https://ossrh-staging-api.central.sonatype.com/service/local/",
+	"http://",
+	"https://",
+}
+
 // Parser 处理Gradle依赖解析。
-type Parser struct{}
+type Parser struct {
+	// 是否保留重复的依赖声明，默认按 group+name+scope+raw 去重。
+	keepDuplicates bool
+
+	// 只提取includeScopes中的配置声明的依赖，为空表示不限制；excludeScopes中的配置总是被忽略。
+	includeScopes map[string]bool
+	excludeScopes map[string]bool
+
+	// 是否为提取出的依赖记录声明所在的行号。
+	lineNumbers bool
+}
 
 // NewParser 创建新的依赖解析器。
 func NewParser() *Parser {
 	return &Parser{}
 }
 
+// WithDeduplicate 设置是否对提取出的依赖去重，默认开启。
+func (dp *Parser) WithDeduplicate(dedup bool) *Parser {
+	dp.keepDuplicates = !dedup
+	return dp
+}
+
+// WithLineNumbers 设置是否在 Dependency.DeclaredAtLine 中记录声明所在的行号，默认关闭。
+func (dp *Parser) WithLineNumbers(track bool) *Parser {
+	dp.lineNumbers = track
+	return dp
+}
+
+// WithScopeFilter 设置只提取哪些配置中声明的依赖，include为空表示所有配置，exclude中的配置总是被忽略。
+// 变体配置（例如 freeDebugImplementation）既按本身也按基础配置（implementation）匹配。
+// 被过滤掉的声明在解析依赖表达式之前就被跳过。
+func (dp *Parser) WithScopeFilter(include, exclude []string) *Parser {
+	dp.includeScopes = scopeSet(include)
+	dp.excludeScopes = scopeSet(exclude)
+	return dp
+}
+
+// scopeSet 把配置列表转换为集合，列表为空时返回nil。
+func scopeSet(scopes []string) map[string]bool {
+	if len(scopes) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		if scope != "" {
+			set[scope] = true
+		}
+	}
+	return set
+}
+
+// scopeAllowed 判断配置是否通过WithScopeFilter设置的过滤条件。
+func (dp *Parser) scopeAllowed(scope string) bool {
+	base, _, _ := SplitVariantScope(scope)
+	if dp.excludeScopes[scope] || dp.excludeScopes[base] {
+		return false
+	}
+	return dp.includeScopes == nil || dp.includeScopes[scope] || dp.includeScopes[base]
+}
+
 // ParseDependencyBlock 解析依赖块。
 func (dp *Parser) ParseDependencyBlock(block *model.ScriptBlock) ([]*model.Dependency, error) {
 	if block == nil {
@@ -83,6 +146,7 @@ func (dp *Parser) parseScopedDependencies(block *model.ScriptBlock,
 		for _, closure := range closures {
 			for _, value := range closure.Values {
 				if dep, ok := dp.parseDependencyString(fmt.Sprintf("%v", value), scope); ok {
+					ApplyVariantScope(dep)
 					deps = append(deps, dep)
 				}
 			}
@@ -100,6 +164,7 @@ func (dp *Parser) parseCustomDependencies(block *model.ScriptBlock,
 
 	for _, value := range block.Values {
 		if dep, ok := dp.parseDependencyString(fmt.Sprintf("%v", value), scope); ok {
+			ApplyVariantScope(dep)
 			deps = append(deps, dep)
 		}
 	}
@@ -113,46 +178,13 @@ func (dp *Parser) parseDependencyString(depStr string, scope string) (*model.Dep
 	depStr = strings.TrimSpace(depStr)
 
 	// 项目依赖。
-	if match := projectRefRegex.FindStringSubmatch(depStr); len(match) > 1 {
-		return &model.Dependency{
-			Name:  match[1],
-			Scope: scope,
-			Raw:   depStr,
-		}, true
-	}
-
-	// 标准GAV格式: group:name:version。
-	if match := gavRegex.FindStringSubmatch(depStr); len(match) > 4 {
-		return &model.Dependency{
-			Group:   match[2],
-			Name:    match[3],
-			Version: match[4],
-			Scope:   scope,
-			Raw:     depStr,
-		}, true
+	if dep := newProjectDependency(depStr, scope); dep != nil {
+		return dep, true
 	}
 
-	// GA格式: group:name (没有版本号)。
-	if match := gaRegex.FindStringSubmatch(depStr); len(match) > 3 {
-		return &model.Dependency{
-			Group:   match[2],
-			Name:    match[3],
-			Version: "", // 版本号为空，可能由dependency-management管理。
-			Scope:   scope,
-			Raw:     depStr,
-		}, true
-	}
-
-	// 带命名空间的格式: group.name:name:version。
-	if match := dotNameRegex.FindStringSubmatch(depStr); len(match) > 5 {
-		group := match[2] + "." + match[3]
-		return &model.Dependency{
-			Group:   group,
-			Name:    match[4],
-			Version: match[5],
-			Scope:   scope,
-			Raw:     depStr,
-		}, true
+	// 坐标格式: group:name[:version]。
+	if dep := newCoordinateDependency(depStr, scope); dep != nil {
+		return dep, true
 	}
 
 	// 未识别的依赖格式。
@@ -160,68 +192,134 @@ func (dp *Parser) parseDependencyString(depStr string, scope string) (*model.Dep
 }
 
 // ExtractDependenciesFromText 从原始文本中提取依赖。
+// 返回的依赖按其在源码中出现的顺序排列；除非关闭了去重，同一声明只保留第一次出现。
 func (dp *Parser) ExtractDependenciesFromText(text string) []*model.Dependency {
 	deps := make([]*model.Dependency, 0)
+	var lines *util.LineIndex
+	if dp.lineNumbers {
+		lines = util.NewLineIndex(text)
+	}
 
-	// 分析文本中的依赖声明。
-	lines := strings.Split(text, "\n")
-
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-
-		// 跳过空行和注释
-		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "//") || strings.HasPrefix(trimmedLine, "/*") {
-			continue
-		}
-
-		// 检查并解析依赖声明行
-		if dep := dp.parseDependencyLine(trimmedLine); dep != nil {
+	// 按语句分析依赖声明，一行中用分号分隔的多条声明和跨行的声明都各自作为一条语句。
+	for _, statement := range SplitStatements(text) {
+		if dep := dp.parseDependencyLine(joinLines(statement.Text)); dep != nil {
+			ApplyVariantScope(dep)
 			// 过滤掉不需要的URL
 			if dp.shouldSkipDependency(dep.Raw) {
 				continue
 			}
+			if lines != nil {
+				dep.DeclaredAtLine = lines.Line(statement.Start)
+			}
 			deps = append(deps, dep)
 		}
 	}
 
+	if !dp.keepDuplicates {
+		deps = Deduplicate(deps)
+	}
 	return deps
 }
 
-// parseDependencyLine 解析单行依赖声明
+// ParseDeclaration 解析一条依赖声明语句（例如SplitStatements返回的语句），语句可以跨行。
+// 不是依赖声明时返回nil。
+func ParseDeclaration(statement string) *model.Dependency {
+	dep := (&Parser{}).parseDependencyLine(joinLines(statement))
+	if dep != nil {
+		ApplyVariantScope(dep)
+	}
+	return dep
+}
+
+// Deduplicate 按 group+name+scope+raw 去除重复的依赖，保留第一次出现的依赖并保持原有顺序。
+// 比较raw时忽略首尾空白和引号风格的差异。
+func Deduplicate(deps []*model.Dependency) []*model.Dependency {
+	seen := make(map[string]bool, len(deps))
+	result := make([]*model.Dependency, 0, len(deps))
+	for _, dep := range deps {
+		if dep == nil {
+			continue
+		}
+		key := dependencyKey(dep)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, dep)
+	}
+	return result
+}
+
+// dependencyKey 返回用于去重的规范化键。
+func dependencyKey(dep *model.Dependency) string {
+	raw := strings.ReplaceAll(strings.TrimSpace(dep.Raw), `"`, "'")
+	return dep.Group + "\x00" + dep.Name + "\x00" + dep.Scope + "\x00" + raw
+}
+
+// parseDependencyLine 解析一条依赖声明语句。
+// 行首的标识符必须是可以声明依赖的配置名称（见IsDependencyScope），后面跟空白和依赖表达式，
+// 或者是方法调用形式 scope(...)，调用后面可以跟配置闭包。依赖表达式可以是字符串坐标、
+// 用 + 连接的字符串坐标、map形式（group: 'g', name: 'n' 或Kotlin的 group = "g", name = "n"）以及项目依赖。
 func (dp *Parser) parseDependencyLine(line string) *model.Dependency {
-	// 检测scope和依赖声明
-	for _, scope := range commonScopes {
-		scopePattern := fmt.Sprintf(`^%s\s+(.+)$`, regexp.QuoteMeta(scope))
-		re := regexp.MustCompile(scopePattern)
-		if matches := re.FindStringSubmatch(line); len(matches) > 1 {
-			depPart := strings.TrimSpace(matches[1])
-
-			// 按优先级顺序尝试解析依赖格式，避免重复匹配
-			if dep := dp.tryParseProjectDependency(depPart, scope); dep != nil {
-				return dep
-			}
-			if dep := dp.tryParseGAVDependency(depPart, scope); dep != nil {
-				return dep
-			}
-			if dep := dp.tryParseGADependency(depPart, scope); dep != nil {
-				return dep
-			}
+	name, args := splitDeclaration(line)
+	if !IsDependencyScope(name) || !dp.scopeAllowed(name) {
+		return nil
+	}
+	return dp.parseDependencyArgs(args, name)
+}
+
+// splitDeclaration 把声明拆分为开头的标识符和其后的参数部分，参数保留开头的空白。
+func splitDeclaration(line string) (name, args string) {
+	i := 0
+	for i < len(line) && isIdentifierByte(line[i]) {
+		i++
+	}
+	return line[:i], line[i:]
+}
+
+// parseDependencyArgs 解析配置名称之后的依赖表达式，args 以空白或方法调用的左括号开头。
+func (dp *Parser) parseDependencyArgs(args, scope string) *model.Dependency {
+	rest := strings.TrimLeft(args, " \t")
+	if rest == "" {
+		return nil
+	}
+
+	depPart := rest
+	if rest[0] == '(' {
+		// 方法调用形式: implementation('g:a:v')、implementation(group: 'g', name: 'n') { ... }。
+		end := closingParen(rest)
+		if end == -1 {
+			return nil
 		}
+		if trailing := strings.TrimSpace(rest[end+1:]); trailing != "" && trailing[0] != '{' {
+			return nil
+		}
+		depPart = strings.TrimSpace(rest[1:end])
+	} else if !isSpaceByte(args[0]) {
+		return nil
+	}
+	if depPart == "" {
+		return nil
+	}
+	if folded, n := FoldConcatenation(depPart); n == len(depPart) {
+		depPart = folded
 	}
 
-	return nil
+	// 按优先级顺序尝试解析依赖格式，避免重复匹配
+	if dep := dp.tryParseProjectDependency(depPart, scope); dep != nil {
+		return dep
+	}
+	if dep := newCoordinateDependency(depPart, scope); dep != nil {
+		return dep
+	}
+	if dep := newMapDependency(depPart, scope); dep != nil {
+		return dep
+	}
+	return dp.tryParseInterpolatedDependency(depPart, scope)
 }
 
 // shouldSkipDependency 检查是否应该跳过某个依赖
 func (dp *Parser) shouldSkipDependency(rawDep string) bool {
-	skipPatterns := []string{
-		"https://github.com",
-		"https://central.sonatype.com/repository/maven-snapshots",
-		"https://ossrh-staging-api.central.sonatype.com/service/local/",
-		"http://",
-		"https://",
-	}
-
 	for _, pattern := range skipPatterns {
 		if strings.Contains(rawDep, pattern) {
 			return true
@@ -232,84 +330,151 @@ func (dp *Parser) shouldSkipDependency(rawDep string) bool {
 
 // tryParseProjectDependency 尝试解析project依赖
 func (dp *Parser) tryParseProjectDependency(depPart, scope string) *model.Dependency {
-	if match := projectRefRegex.FindStringSubmatch(depPart); len(match) > 1 {
-		return &model.Dependency{
-			Name:  match[1],
-			Scope: scope,
-			Raw:   depPart,
-		}
+	if !strings.HasPrefix(depPart, "project") {
+		return nil
 	}
-	return nil
+	return newProjectDependency(depPart, scope)
 }
 
-// tryParseGAVDependency 尝试解析group:name:version格式依赖
-func (dp *Parser) tryParseGAVDependency(depPart, scope string) *model.Dependency {
-	// 先尝试带命名空间的格式: group.name:name:version
-	if match := dotNameRegex.FindStringSubmatch(depPart); len(match) > 5 {
-		group := match[2] + "." + match[3]
-		return &model.Dependency{
-			Group:   group,
-			Name:    match[4],
-			Version: match[5],
-			Scope:   scope,
-			Raw:     depPart,
-		}
+// newCoordinateDependency 根据 group:name[:version] 形式的坐标字符串创建依赖，
+// 没有版本号时Version为空（可能由dependency-management管理）。不是坐标时返回nil。
+func newCoordinateDependency(depPart, scope string) *model.Dependency {
+	group, name, version, ok := scanCoordinate(depPart)
+	if !ok {
+		return nil
+	}
+	dep := &model.Dependency{
+		Group:   group,
+		Name:    name,
+		Version: version,
+		Scope:   scope,
+		Raw:     depPart,
+	}
+	normalizeCoordinate(dep)
+	return dep
+}
+
+// newMapDependency 根据map形式的参数创建依赖，例如 group: 'g', name: 'n', version: 'v'。
+// 缺少group或name时返回nil。
+func newMapDependency(depPart, scope string) *model.Dependency {
+	matches := mapAttributeRegex.FindAllStringSubmatch(depPart, -1)
+	if len(matches) == 0 {
+		return nil
 	}
 
-	// 标准GAV格式: group:name:version
-	if match := gavRegex.FindStringSubmatch(depPart); len(match) > 4 {
-		return &model.Dependency{
-			Group:   match[2],
-			Name:    match[3],
-			Version: match[4],
-			Scope:   scope,
-			Raw:     depPart,
+	dep := &model.Dependency{Scope: scope, Raw: depPart}
+	for _, m := range matches {
+		switch m[1] {
+		case "group":
+			dep.Group = m[2]
+		case "name":
+			dep.Name = m[2]
+		case "version":
+			dep.Version = m[2]
+		case "classifier":
+			dep.Classifier = m[2]
+		case "ext":
+			dep.Extension = m[2]
 		}
 	}
+	if dep.Group == "" || dep.Name == "" {
+		return nil
+	}
+	return dep
+}
 
-	return nil
+// closingParen 返回与开头的左括号匹配的右括号位置，忽略字符串中的括号，没有匹配时返回-1。
+func closingParen(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\'', '"':
+			end := strings.IndexByte(s[i+1:], c)
+			if end == -1 {
+				return -1
+			}
+			i += end + 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
 }
 
-// tryParseGADependency 尝试解析group:name格式依赖（无版本）
-func (dp *Parser) tryParseGADependency(depPart, scope string) *model.Dependency {
-	if match := gaRegex.FindStringSubmatch(depPart); len(match) > 3 {
+// tryParseInterpolatedDependency 尝试解析整个坐标都由变量给出的依赖，例如 "${deps.spring}"。
+// 此时无法得知group和name，Version保存原始表达式，开启变量解析后可由ResolveVersionVariables补全。
+func (dp *Parser) tryParseInterpolatedDependency(depPart, scope string) *model.Dependency {
+	if !strings.HasPrefix(depPart, `"$`) {
+		return nil
+	}
+	if match := interpolatedCoordinateRegex.FindStringSubmatch(depPart); match != nil {
 		return &model.Dependency{
-			Group:   match[2],
-			Name:    match[3],
-			Version: "", // 版本号为空，可能由dependency-management管理
-			Scope:   scope,
-			Raw:     depPart,
+			Version:           match[1],
+			Scope:             scope,
+			Raw:               depPart,
+			HasDynamicVersion: true,
 		}
 	}
 	return nil
 }
 
-// GroupDependenciesByScope 按范围对依赖进行分组。
-func (dp *Parser) GroupDependenciesByScope(deps []*model.Dependency) []*model.DependencySet {
-	// 使用map收集按范围分组的依赖。
-	scopeMap := make(map[string][]*model.Dependency)
+// ScopeOrder 决定 GroupDependenciesByScopeOrdered 返回的分组顺序。
+type ScopeOrder int
+
+const (
+	// ScopeOrderDeclaration 按配置在依赖列表中首次出现的顺序排列。
+	ScopeOrderDeclaration ScopeOrder = iota
+	// ScopeOrderAlphabetical 按配置名称的字母顺序排列，未声明配置的分组在最前。
+	ScopeOrderAlphabetical
+)
+
+// GroupDependenciesByScope 按范围对依赖进行分组，只保留通过所有filters的依赖，
+// 例如传入 ApplicationDependencies 排除buildscript classpath和插件依赖。
+// 分组按配置首次出现的顺序排列，未声明配置的依赖放在Scope为空的分组中。
+func (dp *Parser) GroupDependenciesByScope(deps []*model.Dependency, filters ...DependencyFilter) []*model.DependencySet {
+	return dp.GroupDependenciesByScopeOrdered(deps, ScopeOrderDeclaration, filters...)
+}
+
+// GroupDependenciesByScopeOrdered 与 GroupDependenciesByScope 相同，分组按order排列。
+// 每个分组中的依赖保持在deps中的顺序。
+func (dp *Parser) GroupDependenciesByScopeOrdered(deps []*model.Dependency, order ScopeOrder, filters ...DependencyFilter) []*model.DependencySet {
+	sets := make([]*model.DependencySet, 0)
+	byScope := make(map[string]*model.DependencySet)
 
 	for _, dep := range deps {
-		if dep.Scope != "" {
-			scopeMap[dep.Scope] = append(scopeMap[dep.Scope], dep)
-		} else {
-			// 默认范围。
-			scopeMap["implementation"] = append(scopeMap["implementation"], dep)
+		if !keepDependency(dep, filters) {
+			continue
+		}
+		set := byScope[dep.Scope]
+		if set == nil {
+			set = &model.DependencySet{Scope: dep.Scope}
+			byScope[dep.Scope] = set
+			sets = append(sets, set)
 		}
+		set.Dependencies = append(set.Dependencies, dep)
 	}
 
-	// 转换为DependencySet列表。
-	sets := make([]*model.DependencySet, 0, len(scopeMap))
-	for scope, scopeDeps := range scopeMap {
-		sets = append(sets, &model.DependencySet{
-			Scope:        scope,
-			Dependencies: scopeDeps,
-		})
+	if order == ScopeOrderAlphabetical {
+		sort.SliceStable(sets, func(i, j int) bool { return sets[i].Scope < sets[j].Scope })
 	}
-
 	return sets
 }
 
+// keepDependency 判断依赖是否通过所有过滤条件。
+func keepDependency(dep *model.Dependency, filters []DependencyFilter) bool {
+	for _, filter := range filters {
+		if !filter(dep) {
+			return false
+		}
+	}
+	return true
+}
+
 // 辅助函数: 检查字符串是否在切片中。
 func contains(slice []string, str string) bool {
 	for _, item := range slice {
@@ -319,3 +484,13 @@ func contains(slice []string, str string) bool {
 	}
 	return false
 }
+
+// isIdentifierByte 判断字节是否可以出现在配置名称中。
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// isSpaceByte 判断字节是否为空白字符。
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f'
+}
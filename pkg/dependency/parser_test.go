@@ -242,6 +242,7 @@ func TestParseDependencyString(t *testing.T) {
 				Version: "",
 				Scope:   "implementation",
 				Raw:     "project(':app')",
+				Project: &model.ProjectRef{Path: ":app"},
 			},
 			success: true,
 		},
@@ -341,6 +342,130 @@ func TestExtractDependenciesFromText(t *testing.T) {
 	}
 }
 
+func TestExtractDependenciesFromTextCallSyntax(t *testing.T) {
+	text := `dependencies {
+    implementation('org.slf4j:slf4j-api:2.0.9')
+    implementation ("com.google.guava:guava:32.1.2-jre")
+    testImplementation(group: 'org.junit.jupiter', name: 'junit-jupiter', version: '5.10.0')
+    runtimeOnly group: 'org.postgresql', name: 'postgresql', version: '42.6.0', classifier: 'jre7'
+    implementation(group = "io.ktor", name = "ktor-client", version = "2.3.4")
+    implementation('org.hibernate:hibernate-core:6.2.0') {
+        exclude group: 'org.jboss'
+    }
+    implementation(platform('org.springframework.boot:spring-boot-dependencies:3.1.0'))
+    implementation('a:b:1') + files('x')
+}`
+
+	want := []struct {
+		scope, group, name, version string
+	}{
+		{"implementation", "org.slf4j", "slf4j-api", "2.0.9"},
+		{"implementation", "com.google.guava", "guava", "32.1.2-jre"},
+		{"testImplementation", "org.junit.jupiter", "junit-jupiter", "5.10.0"},
+		{"runtimeOnly", "org.postgresql", "postgresql", "42.6.0"},
+		{"implementation", "io.ktor", "ktor-client", "2.3.4"},
+		{"implementation", "org.hibernate", "hibernate-core", "6.2.0"},
+	}
+
+	deps := NewParser().ExtractDependenciesFromText(text)
+	if len(deps) != len(want) {
+		for _, dep := range deps {
+			t.Logf("%+v", dep)
+		}
+		t.Fatalf("ExtractDependenciesFromText() returned %d dependencies, want %d", len(deps), len(want))
+	}
+	for i, w := range want {
+		dep := deps[i]
+		if dep.Scope != w.scope || dep.Group != w.group || dep.Name != w.name || dep.Version != w.version {
+			t.Errorf("Dependency %d = %s %s:%s:%s, want %s %s:%s:%s", i, dep.Scope, dep.Group, dep.Name, dep.Version,
+				w.scope, w.group, w.name, w.version)
+		}
+	}
+	if deps[3].Classifier != "jre7" {
+		t.Errorf("Expected classifier jre7, got %q", deps[3].Classifier)
+	}
+	if deps[0].Raw != "'org.slf4j:slf4j-api:2.0.9'" {
+		t.Errorf("Expected raw to be the call argument, got %q", deps[0].Raw)
+	}
+}
+
+func TestExtractDependenciesFromTextMultipleStatements(t *testing.T) {
+	text := `dependencies {
+    implementation 'a:b:1'; implementation 'c:d:2'
+    api("e:f:3"); testImplementation "g:h:4";
+    implementation group: 'org.foo',
+        name: 'bar', // artifact
+        version: '1.0'
+    implementation "com.example:lib:" +
+        "2.0"
+    runtimeOnly 'org.baz:baz:' + bazVersion
+    /* implementation 'x:y:1'; implementation 'x:z:1' */
+}`
+
+	want := []struct {
+		scope, group, name, version string
+	}{
+		{"implementation", "a", "b", "1"},
+		{"implementation", "c", "d", "2"},
+		{"api", "e", "f", "3"},
+		{"testImplementation", "g", "h", "4"},
+		{"implementation", "org.foo", "bar", "1.0"},
+		{"implementation", "com.example", "lib", "2.0"},
+		{"runtimeOnly", "org.baz", "baz", "${bazVersion}"},
+	}
+
+	deps := NewParser().ExtractDependenciesFromText(text)
+	if len(deps) != len(want) {
+		for _, dep := range deps {
+			t.Logf("%+v", dep)
+		}
+		t.Fatalf("ExtractDependenciesFromText() returned %d dependencies, want %d", len(deps), len(want))
+	}
+	for i, w := range want {
+		dep := deps[i]
+		if dep.Scope != w.scope || dep.Group != w.group || dep.Name != w.name || dep.Version != w.version {
+			t.Errorf("Dependency %d = %s %s:%s:%s, want %s %s:%s:%s", i, dep.Scope, dep.Group, dep.Name, dep.Version,
+				w.scope, w.group, w.name, w.version)
+		}
+	}
+	if deps[5].Raw != `"com.example:lib:2.0"` {
+		t.Errorf("Expected concatenated raw to be folded, got %q", deps[5].Raw)
+	}
+	if !deps[6].HasDynamicVersion {
+		t.Error("Expected version from a variable to be marked dynamic")
+	}
+}
+
+func TestExtractDependenciesFromTextScopeFilter(t *testing.T) {
+	text := `dependencies {
+    implementation 'a:b:1'
+    testImplementation 'c:d:2'
+    testFreeDebugImplementation 'e:f:3'
+    debugImplementation 'g:h:4'
+}`
+
+	tests := []struct {
+		include, exclude []string
+		want             []string
+	}{
+		{nil, nil, []string{"b", "d", "f", "h"}},
+		{[]string{"testImplementation"}, nil, []string{"d", "f"}},
+		{[]string{"implementation"}, []string{"debugImplementation"}, []string{"b"}},
+		{nil, []string{"testImplementation"}, []string{"b", "h"}},
+	}
+
+	for _, tt := range tests {
+		deps := NewParser().WithScopeFilter(tt.include, tt.exclude).ExtractDependenciesFromText(text)
+		names := make([]string, 0, len(deps))
+		for _, dep := range deps {
+			names = append(names, dep.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("WithScopeFilter(%v, %v) extracted %v, want %v", tt.include, tt.exclude, names, tt.want)
+		}
+	}
+}
+
 func TestExtractDependenciesFromText2(t *testing.T) {
 	parser := NewParser()
 
@@ -515,28 +640,42 @@ func TestGroupDependenciesByScope(t *testing.T) {
 
 	sets := parser.GroupDependenciesByScope(deps)
 
-	// Verify we have 2 sets: implementation and testImplementation。
-	if len(sets) != 2 {
-		t.Errorf("GroupDependenciesByScope() returned %v sets, want 2", len(sets))
-		return
+	// Verify the sets follow declaration order and keep the no-scope bucket。
+	scopes := make([]string, 0, len(sets))
+	counts := make([]int, 0, len(sets))
+	for _, set := range sets {
+		scopes = append(scopes, set.Scope)
+		counts = append(counts, set.Count())
+	}
+	if !reflect.DeepEqual(scopes, []string{"implementation", "testImplementation", ""}) {
+		t.Errorf("GroupDependenciesByScope() scopes = %q, want implementation, testImplementation and the no-scope bucket", scopes)
+	}
+	if !reflect.DeepEqual(counts, []int{3, 2, 1}) {
+		t.Errorf("GroupDependenciesByScope() counts = %v, want [3 2 1]", counts)
+	}
+	if got := sets[1].Dependencies[1].Name; got != "mockito-core" {
+		t.Errorf("Dependencies should keep declaration order, got %s", got)
 	}
 
-	// Verify each scope's dependency count。
+	// Alphabetical order puts the no-scope bucket first。
+	sets = parser.GroupDependenciesByScopeOrdered(deps, ScopeOrderAlphabetical)
+	scopes = scopes[:0]
 	for _, set := range sets {
-		switch set.Scope {
-		case "implementation":
-			// Should have 4 dependencies: 3 explicit + 1 default no-scope。
-			if len(set.Dependencies) != 4 {
-				t.Errorf("Implementation scope set has %v dependencies, want 4", len(set.Dependencies))
-			}
-		case "testImplementation":
-			// Should have 2 dependencies。
-			if len(set.Dependencies) != 2 {
-				t.Errorf("TestImplementation scope set has %v dependencies, want 2", len(set.Dependencies))
-			}
-		default:
-			t.Errorf("Unexpected scope found: %s", set.Scope)
-		}
+		scopes = append(scopes, set.Scope)
+	}
+	if !reflect.DeepEqual(scopes, []string{"", "implementation", "testImplementation"}) {
+		t.Errorf("GroupDependenciesByScopeOrdered() scopes = %q, want alphabetical order", scopes)
+	}
+
+	total := model.CountDependencies(sets)
+	if total != 6 {
+		t.Errorf("CountDependencies() = %d, want 6", total)
+	}
+	if got := sets[1].Percentage(total); got != 50 {
+		t.Errorf("Percentage() = %v, want 50", got)
+	}
+	if got := sets[1].Percentage(0); got != 0 {
+		t.Errorf("Percentage(0) = %v, want 0", got)
 	}
 }
 
@@ -697,3 +836,70 @@ nexusPublishing {
 		}
 	}
 }
+
+func TestExtractDependenciesFromTextDedup(t *testing.T) {
+	text := `
+buildscript {
+    dependencies {
+        implementation 'org.slf4j:slf4j-api:1.7.36'
+    }
+}
+
+dependencies {
+    implementation "org.slf4j:slf4j-api:1.7.36"
+    testImplementation 'junit:junit:4.13.2'
+    implementation 'org.slf4j:slf4j-api:1.7.36'
+    testImplementation 'org.slf4j:slf4j-api:1.7.36'
+    implementation 'com.google.guava:guava:31.1-jre'
+}
+`
+	deps := NewParser().ExtractDependenciesFromText(text)
+
+	want := []string{
+		"implementation org.slf4j:slf4j-api",
+		"testImplementation junit:junit",
+		"testImplementation org.slf4j:slf4j-api",
+		"implementation com.google.guava:guava",
+	}
+	got := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		got = append(got, dep.Scope+" "+dep.Group+":"+dep.Name)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractDependenciesFromText() = %v, want %v", got, want)
+	}
+
+	all := NewParser().WithDeduplicate(false).ExtractDependenciesFromText(text)
+	if len(all) != 6 {
+		t.Errorf("Expected 6 dependencies without dedup, got %d", len(all))
+	}
+}
+
+func TestDeduplicate(t *testing.T) {
+	a := &model.Dependency{Group: "org.a", Name: "a", Version: "1.0", Scope: "api", Raw: "'org.a:a:1.0'"}
+	b := &model.Dependency{Group: "org.a", Name: "a", Version: "1.0", Scope: "api", Raw: ` "org.a:a:1.0" `}
+	c := &model.Dependency{Group: "org.a", Name: "a", Version: "2.0", Scope: "api", Raw: "'org.a:a:2.0'"}
+
+	got := Deduplicate([]*model.Dependency{a, nil, b, c})
+	if len(got) != 2 || got[0] != a || got[1] != c {
+		t.Errorf("Deduplicate() = %v, want [a c]", got)
+	}
+}
+
+func BenchmarkExtractDependenciesFromText(b *testing.B) {
+	var text strings.Builder
+	text.WriteString("dependencies {\n")
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&text, "    testImplementation 'com.example:lib%d:1.0.0'\n", i)
+	}
+	text.WriteString("}\n")
+	content := text.String()
+
+	dp := NewParser()
+	b.ReportAllocs()
+	b.SetBytes(int64(len(content)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dp.ExtractDependenciesFromText(content)
+	}
+}
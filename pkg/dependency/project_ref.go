@@ -0,0 +1,62 @@
+// Package dependency 提供项目依赖引用的解析功能。
+package dependency
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 格式: project(...)，参数在第一个分组中。
+	// 例如: project(':app')、project(path: ':app', configuration: 'shadow')。
+	projectCallRegex = regexp.MustCompile(`^project\s*\((.*)\)$`)
+
+	// Groovy的命名参数 path: ':app' 或Kotlin的命名参数 path = ":app"。
+	projectArgRegex = regexp.MustCompile(`\b(path|configuration)\s*[:=]\s*['"]([^'"]*)['"]`)
+
+	// 单个字符串参数，例如 ':app'。
+	projectPathArgRegex = regexp.MustCompile(`^['"]([^'"]*)['"]$`)
+)
+
+// ParseProjectRef 解析 project(...) 形式的项目依赖表达式，
+// 支持单个路径参数以及Groovy、Kotlin的 path/configuration 命名参数。不是项目依赖时返回nil。
+func ParseProjectRef(expr string) *model.ProjectRef {
+	match := projectCallRegex.FindStringSubmatch(strings.TrimSpace(expr))
+	if match == nil {
+		return nil
+	}
+	args := strings.TrimSpace(match[1])
+
+	if m := projectPathArgRegex.FindStringSubmatch(args); m != nil {
+		return &model.ProjectRef{Path: m[1]}
+	}
+
+	ref := &model.ProjectRef{}
+	for _, m := range projectArgRegex.FindAllStringSubmatch(args, -1) {
+		if m[1] == "path" {
+			ref.Path = m[2]
+		} else {
+			ref.Configuration = m[2]
+		}
+	}
+	if ref.Path == "" {
+		return nil
+	}
+	return ref
+}
+
+// newProjectDependency 根据project(...)表达式创建项目依赖，Name为去掉开头冒号的项目路径。
+func newProjectDependency(expr, scope string) *model.Dependency {
+	ref := ParseProjectRef(expr)
+	if ref == nil {
+		return nil
+	}
+	return &model.Dependency{
+		Name:    strings.TrimPrefix(ref.Path, ":"),
+		Scope:   scope,
+		Raw:     expr,
+		Project: ref,
+	}
+}
@@ -0,0 +1,57 @@
+package dependency
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestParseProjectRef(t *testing.T) {
+	tests := []struct {
+		expr string
+		want *model.ProjectRef
+	}{
+		{"project(':app')", &model.ProjectRef{Path: ":app"}},
+		{`project(":libs:core")`, &model.ProjectRef{Path: ":libs:core"}},
+		{"project(path: ':app', configuration: 'shadow')", &model.ProjectRef{Path: ":app", Configuration: "shadow"}},
+		{`project(path = ":app", configuration = "shadow")`, &model.ProjectRef{Path: ":app", Configuration: "shadow"}},
+		{"project(configuration: 'shadow', path: ':app')", &model.ProjectRef{Path: ":app", Configuration: "shadow"}},
+		{"project('sibling')", &model.ProjectRef{Path: "sibling"}},
+		{"project(configuration: 'shadow')", nil},
+		{"'org.example:lib:1.0'", nil},
+	}
+
+	for _, tt := range tests {
+		if got := ParseProjectRef(tt.expr); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseProjectRef(%q) = %+v, want %+v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestExtractProjectDependencies(t *testing.T) {
+	text := `dependencies {
+    implementation project(path: ':app', configuration: 'shadow')
+    implementation(project(":libs:core"))
+    api(project(path = ":api", configuration = "apiElements"))
+}`
+
+	deps := NewParser().ExtractDependenciesFromText(text)
+	want := []struct {
+		name, scope, path, configuration string
+	}{
+		{"app", "implementation", ":app", "shadow"},
+		{"libs:core", "implementation", ":libs:core", ""},
+		{"api", "api", ":api", "apiElements"},
+	}
+	if len(deps) != len(want) {
+		t.Fatalf("Expected %d project dependencies, got %d", len(want), len(deps))
+	}
+	for i, w := range want {
+		dep := deps[i]
+		if dep.Name != w.name || dep.Scope != w.scope || dep.Project == nil ||
+			dep.Project.Path != w.path || dep.Project.Configuration != w.configuration {
+			t.Errorf("Dependency %d: got %+v (project %+v), want %+v", i, dep, dep.Project, w)
+		}
+	}
+}
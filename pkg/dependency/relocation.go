@@ -0,0 +1,155 @@
+// Package dependency 提供常见制品坐标迁移（relocation）的内置数据库和迁移建议。
+package dependency
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/util"
+)
+
+// Relocation 描述一个制品从旧坐标迁移到新坐标。
+type Relocation struct {
+	Group string `json:"group"`
+	// Name 为空表示整个group下的制品都迁移，新坐标沿用原来的制品名。
+	Name     string `json:"name,omitempty"`
+	NewGroup string `json:"newGroup"`
+	NewName  string `json:"newName,omitempty"`
+	// Version 是新坐标下最早可用的版本，当前版本更早时迁移需要同时升级到该版本。
+	Version string `json:"version"`
+	// DropIn 为true表示新坐标只是换了发布位置，API和包名不变，可以直接替换；
+	// 为false表示新坐标是后继项目，迁移后需要修改代码。
+	DropIn bool   `json:"dropIn,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// RelocationSuggestion 是对单个依赖的坐标迁移建议。
+type RelocationSuggestion struct {
+	Dependency *model.Dependency `json:"dependency"`
+	Relocation *Relocation       `json:"relocation"`
+	NewGroup   string            `json:"newGroup"`
+	NewName    string            `json:"newName"`
+	// NewVersion 是迁移后应使用的版本，为空表示保留当前版本（包括变量引用）。
+	NewVersion string `json:"newVersion,omitempty"`
+	Suggestion string `json:"suggestion"`
+}
+
+// knownRelocations 是内置的坐标迁移数据库，按具体制品在前、整个group在后的顺序排列。
+var knownRelocations = []*Relocation{
+	{Group: "mysql", Name: "mysql-connector-java", NewGroup: "com.mysql", NewName: "mysql-connector-j", Version: "8.0.31", DropIn: true,
+		Note: "MySQL Connector/J 8.0.31起只在新坐标发布"},
+	{Group: "org.bouncycastle", Name: "bcprov-jdk15on", NewGroup: "org.bouncycastle", NewName: "bcprov-jdk18on", Version: "1.71", DropIn: true,
+		Note: "jdk15on系列已停止更新"},
+	{Group: "org.bouncycastle", Name: "bcpkix-jdk15on", NewGroup: "org.bouncycastle", NewName: "bcpkix-jdk18on", Version: "1.71", DropIn: true,
+		Note: "jdk15on系列已停止更新"},
+	{Group: "org.bouncycastle", Name: "bcutil-jdk15on", NewGroup: "org.bouncycastle", NewName: "bcutil-jdk18on", Version: "1.71", DropIn: true,
+		Note: "jdk15on系列已停止更新"},
+	{Group: "org.jetbrains.kotlin", Name: "kotlin-stdlib-jdk8", NewGroup: "org.jetbrains.kotlin", NewName: "kotlin-stdlib", Version: "1.8.0", DropIn: true,
+		Note: "Kotlin 1.8起jdk7/jdk8扩展已合并到kotlin-stdlib"},
+	{Group: "org.jetbrains.kotlin", Name: "kotlin-stdlib-jdk7", NewGroup: "org.jetbrains.kotlin", NewName: "kotlin-stdlib", Version: "1.8.0", DropIn: true,
+		Note: "Kotlin 1.8起jdk7/jdk8扩展已合并到kotlin-stdlib"},
+	{Group: "javax.servlet", Name: "javax.servlet-api", NewGroup: "jakarta.servlet", NewName: "jakarta.servlet-api", Version: "4.0.2", DropIn: true,
+		Note: "4.x仍使用javax.servlet包名，5.0起改为jakarta.servlet"},
+	{Group: "javax.xml.bind", Name: "jaxb-api", NewGroup: "jakarta.xml.bind", NewName: "jakarta.xml.bind-api", Version: "2.3.2", DropIn: true,
+		Note: "2.3.x仍使用javax.xml.bind包名，3.0起改为jakarta.xml.bind"},
+	{Group: "javax.activation", Name: "activation", NewGroup: "jakarta.activation", NewName: "jakarta.activation-api", Version: "1.2.1", DropIn: true,
+		Note: "1.2.x仍使用javax.activation包名，2.0起改为jakarta.activation"},
+	{Group: "javax.annotation", Name: "javax.annotation-api", NewGroup: "jakarta.annotation", NewName: "jakarta.annotation-api", Version: "1.3.4", DropIn: true,
+		Note: "1.3.x仍使用javax.annotation包名，2.0起改为jakarta.annotation"},
+	{Group: "org.hibernate", Name: "hibernate-core", NewGroup: "org.hibernate.orm", NewName: "hibernate-core", Version: "6.0.0.Final",
+		Note: "Hibernate ORM 6起改为org.hibernate.orm发布，需要按迁移指南修改代码"},
+	{Group: "org.hibernate", Name: "hibernate-validator", NewGroup: "org.hibernate.validator", NewName: "hibernate-validator", Version: "6.0.0.Final",
+		Note: "Hibernate Validator 6起改为org.hibernate.validator发布"},
+	{Group: "commons-lang", Name: "commons-lang", NewGroup: "org.apache.commons", NewName: "commons-lang3", Version: "3.0",
+		Note: "commons-lang已停止维护，commons-lang3使用org.apache.commons.lang3包名"},
+	{Group: "log4j", Name: "log4j", NewGroup: "org.apache.logging.log4j", NewName: "log4j-1.2-api", Version: "2.17.1",
+		Note: "Log4j 1.x已停止维护且存在已知漏洞，log4j-1.2-api是桥接层，还需要引入log4j-core"},
+	{Group: "asm", Name: "asm", NewGroup: "org.ow2.asm", NewName: "asm", Version: "4.0",
+		Note: "ASM 4起改为org.ow2.asm发布，访问器接口改为抽象类"},
+	{Group: "com.android.support", Name: "appcompat-v7", NewGroup: "androidx.appcompat", NewName: "appcompat", Version: "1.0.0",
+		Note: "Android支持库已被AndroidX取代，需要迁移包名"},
+	{Group: "io.reactivex.rxjava2", Name: "rxjava", NewGroup: "io.reactivex.rxjava3", NewName: "rxjava", Version: "3.0.0",
+		Note: "RxJava 2已停止维护，RxJava 3使用io.reactivex.rxjava3包名"},
+	{Group: "com.squareup.okhttp", Name: "okhttp", NewGroup: "com.squareup.okhttp3", NewName: "okhttp", Version: "3.0.0",
+		Note: "OkHttp 3起改为okhttp3包名"},
+	{Group: "net.sf.ehcache", Name: "ehcache", NewGroup: "org.ehcache", NewName: "ehcache", Version: "3.0.0",
+		Note: "Ehcache 3的API与2.x不兼容"},
+	{Group: "org.codehaus.groovy", NewGroup: "org.apache.groovy", Version: "4.0.0",
+		Note: "Groovy 4起改为org.apache.groovy发布，部分类的包名有变化"},
+}
+
+// KnownRelocations 返回内置坐标迁移数据库的副本。
+func KnownRelocations() []*Relocation {
+	relocations := make([]*Relocation, 0, len(knownRelocations))
+	for _, r := range knownRelocations {
+		copied := *r
+		relocations = append(relocations, &copied)
+	}
+	return relocations
+}
+
+// FindRelocation 查找坐标的迁移记录，返回记录的副本，没有记录时返回nil。
+func FindRelocation(group, name string) *Relocation {
+	for _, r := range knownRelocations {
+		if r.Group == group && (r.Name == "" || r.Name == name) {
+			copied := *r
+			return &copied
+		}
+	}
+	return nil
+}
+
+// SuggestRelocations 找出已迁移到新坐标发布的依赖并给出迁移建议。
+// 可以直接替换的依赖在当前版本不早于新坐标的最早版本时保留原版本，否则建议使用新坐标的最早版本。
+func SuggestRelocations(deps []*model.Dependency) []*RelocationSuggestion {
+	suggestions := make([]*RelocationSuggestion, 0)
+	for _, dep := range deps {
+		if dep == nil || dep.Group == "" || dep.Name == "" {
+			continue
+		}
+		relocation := FindRelocation(dep.Group, dep.Name)
+		if relocation == nil {
+			continue
+		}
+
+		suggestion := &RelocationSuggestion{
+			Dependency: dep,
+			Relocation: relocation,
+			NewGroup:   relocation.NewGroup,
+			NewName:    relocation.NewName,
+		}
+		if suggestion.NewName == "" {
+			suggestion.NewName = dep.Name
+		}
+		if needsRelocationVersion(dep.Version, relocation) {
+			suggestion.NewVersion = relocation.Version
+		}
+
+		target := suggestion.NewGroup + ":" + suggestion.NewName
+		if suggestion.NewVersion != "" {
+			target += ":" + suggestion.NewVersion
+		}
+		suggestion.Suggestion = fmt.Sprintf("将 %s 改为 '%s'", dependencyLabel(dep), target)
+		if relocation.Note != "" {
+			suggestion.Suggestion += "（" + relocation.Note + "）"
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions
+}
+
+// needsRelocationVersion 判断迁移时是否需要改用新坐标的最早版本。
+// 没有版本时保留原样；不能直接替换的迁移总是需要新版本；版本来自变量时无法比较，保留原样。
+func needsRelocationVersion(version string, relocation *Relocation) bool {
+	switch {
+	case version == "":
+		return false
+	case !relocation.DropIn:
+		return true
+	case strings.Contains(version, "$"):
+		return false
+	default:
+		return util.CompareVersions(version, relocation.Version) < 0
+	}
+}
@@ -0,0 +1,65 @@
+package dependency
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestSuggestRelocations(t *testing.T) {
+	deps := []*model.Dependency{
+		{Group: "mysql", Name: "mysql-connector-java", Version: "8.0.33", Scope: "runtimeOnly"},
+		{Group: "mysql", Name: "mysql-connector-java", Version: "8.0.28", Scope: "runtimeOnly"},
+		{Group: "org.bouncycastle", Name: "bcprov-jdk15on", Version: "$bcVersion"},
+		{Group: "commons-lang", Name: "commons-lang", Version: "2.6"},
+		{Group: "org.codehaus.groovy", Name: "groovy-json", Version: "3.0.19"},
+		{Group: "javax.servlet", Name: "javax.servlet-api"},
+		{Group: "com.google.guava", Name: "guava", Version: "32.1.3-jre"},
+	}
+
+	expected := []struct {
+		coordinate string
+		version    string
+	}{
+		{"com.mysql:mysql-connector-j", ""},
+		{"com.mysql:mysql-connector-j", "8.0.31"},
+		{"org.bouncycastle:bcprov-jdk18on", ""},
+		{"org.apache.commons:commons-lang3", "3.0"},
+		{"org.apache.groovy:groovy-json", "4.0.0"},
+		{"jakarta.servlet:jakarta.servlet-api", ""},
+	}
+
+	suggestions := SuggestRelocations(deps)
+	if len(suggestions) != len(expected) {
+		t.Fatalf("Expected %d suggestions, got %d", len(expected), len(suggestions))
+	}
+	for i, want := range expected {
+		got := suggestions[i]
+		if coordinate := got.NewGroup + ":" + got.NewName; coordinate != want.coordinate || got.NewVersion != want.version {
+			t.Errorf("Suggestion %d: expected %s (%q), got %s (%q)", i, want.coordinate, want.version, coordinate, got.NewVersion)
+		}
+		if got.Dependency != deps[i] || !strings.Contains(got.Suggestion, want.coordinate) {
+			t.Errorf("Suggestion %d: unexpected %+v", i, got)
+		}
+	}
+	if suggestions[0].Relocation == nil || !suggestions[0].Relocation.DropIn || suggestions[3].Relocation.DropIn {
+		t.Errorf("Unexpected drop-in flags: %+v, %+v", suggestions[0].Relocation, suggestions[3].Relocation)
+	}
+}
+
+func TestFindRelocation(t *testing.T) {
+	if r := FindRelocation("org.hibernate", "hibernate-core"); r == nil || r.NewGroup != "org.hibernate.orm" {
+		t.Errorf("Expected hibernate-core relocation, got %+v", r)
+	}
+	if r := FindRelocation("org.hibernate", "hibernate-search"); r != nil {
+		t.Errorf("Expected no relocation for hibernate-search, got %+v", r)
+	}
+
+	// 返回的记录是副本，修改不会影响内置数据库。
+	FindRelocation("mysql", "mysql-connector-java").NewGroup = "changed"
+	KnownRelocations()[0].NewGroup = "changed"
+	if r := FindRelocation("mysql", "mysql-connector-java"); r.NewGroup != "com.mysql" {
+		t.Errorf("Expected built-in relocation to be unchanged, got %+v", r)
+	}
+}
@@ -0,0 +1,144 @@
+// Package dependency 提供依赖配置范围的归一化与废弃配置迁移建议。
+package dependency
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// ScopeCategory 是依赖配置所属的类别。
+type ScopeCategory string
+
+const (
+	ScopeCategoryCompile             ScopeCategory = "compile"
+	ScopeCategoryRuntime             ScopeCategory = "runtime"
+	ScopeCategoryTest                ScopeCategory = "test"
+	ScopeCategoryAnnotationProcessor ScopeCategory = "annotation-processor"
+	ScopeCategoryUnknown             ScopeCategory = "unknown"
+)
+
+// ScopeRule 描述一个依赖配置的类别以及它是否已废弃。
+type ScopeRule struct {
+	Category    ScopeCategory `json:"category"`
+	Deprecated  bool          `json:"deprecated,omitempty"`
+	Replacement string        `json:"replacement,omitempty"` // 废弃配置的替代配置。
+}
+
+// ScopeMapping 是配置名称到规则的映射。
+type ScopeMapping map[string]ScopeRule
+
+// NormalizedScope 是单个依赖的配置归一化结果。
+type NormalizedScope struct {
+	Dependency *model.Dependency `json:"dependency"`
+	Scope      string            `json:"scope"`
+	ScopeRule
+	// Suggestion 是废弃配置的迁移建议，未废弃时为空。
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// DefaultScopeMapping 返回内置的配置映射，调用方可以在返回的映射上增删规则。
+func DefaultScopeMapping() ScopeMapping {
+	return ScopeMapping{
+		"api":                     {Category: ScopeCategoryCompile},
+		"implementation":          {Category: ScopeCategoryCompile},
+		"compileOnly":             {Category: ScopeCategoryCompile},
+		"compileOnlyApi":          {Category: ScopeCategoryCompile},
+		"runtimeOnly":             {Category: ScopeCategoryRuntime},
+		"developmentOnly":         {Category: ScopeCategoryRuntime},
+		"annotationProcessor":     {Category: ScopeCategoryAnnotationProcessor},
+		"kapt":                    {Category: ScopeCategoryAnnotationProcessor},
+		"ksp":                     {Category: ScopeCategoryAnnotationProcessor},
+		"testAnnotationProcessor": {Category: ScopeCategoryAnnotationProcessor},
+		"testImplementation":      {Category: ScopeCategoryTest},
+		"testApi":                 {Category: ScopeCategoryTest},
+		"testCompileOnly":         {Category: ScopeCategoryTest},
+		"testRuntimeOnly":         {Category: ScopeCategoryTest},
+		"testFixturesApi":         {Category: ScopeCategoryTest},
+		"compile":                 {Category: ScopeCategoryCompile, Deprecated: true, Replacement: "implementation"},
+		"runtime":                 {Category: ScopeCategoryRuntime, Deprecated: true, Replacement: "runtimeOnly"},
+		"provided":                {Category: ScopeCategoryCompile, Deprecated: true, Replacement: "compileOnly"},
+		"testCompile":             {Category: ScopeCategoryTest, Deprecated: true, Replacement: "testImplementation"},
+		"testRuntime":             {Category: ScopeCategoryTest, Deprecated: true, Replacement: "testRuntimeOnly"},
+		"androidTestCompile":      {Category: ScopeCategoryTest, Deprecated: true, Replacement: "androidTestImplementation"},
+	}
+}
+
+// Classify 返回配置对应的规则。映射中没有的配置按名称推断类别，
+// 例如 debugImplementation 属于compile，androidTestRuntimeOnly 属于test；
+// 以 Compile 结尾的变体配置（例如 debugCompile）视为废弃，替代配置为对应的 Implementation。
+func (m ScopeMapping) Classify(scope string) ScopeRule {
+	if rule, ok := m[scope]; ok {
+		return rule
+	}
+
+	rule := ScopeRule{Category: inferScopeCategory(scope)}
+	if prefix, ok := strings.CutSuffix(scope, "Compile"); ok && prefix != "" {
+		rule.Deprecated = true
+		rule.Replacement = prefix + "Implementation"
+	}
+	return rule
+}
+
+// inferScopeCategory 根据配置名称推断类别。
+func inferScopeCategory(scope string) ScopeCategory {
+	lower := strings.ToLower(scope)
+	switch {
+	case strings.Contains(lower, "annotationprocessor") || strings.HasPrefix(lower, "kapt") || strings.HasPrefix(lower, "ksp"):
+		return ScopeCategoryAnnotationProcessor
+	case strings.Contains(lower, "test"):
+		return ScopeCategoryTest
+	case strings.HasSuffix(lower, "runtimeonly") || strings.HasSuffix(lower, "runtime"):
+		return ScopeCategoryRuntime
+	case strings.HasSuffix(lower, "implementation") || strings.HasSuffix(lower, "api") ||
+		strings.HasSuffix(lower, "compileonly") || strings.HasSuffix(lower, "compile"):
+		return ScopeCategoryCompile
+	default:
+		return ScopeCategoryUnknown
+	}
+}
+
+// NormalizeScopes 按映射对依赖的配置进行归类，并为废弃配置生成迁移建议。
+// mapping 为nil时使用 DefaultScopeMapping；没有配置的依赖按 implementation 处理。
+func NormalizeScopes(deps []*model.Dependency, mapping ScopeMapping) []*NormalizedScope {
+	if mapping == nil {
+		mapping = DefaultScopeMapping()
+	}
+
+	results := make([]*NormalizedScope, 0, len(deps))
+	for _, dep := range deps {
+		if dep == nil {
+			continue
+		}
+		scope := dep.Scope
+		if scope == "" {
+			scope = "implementation"
+		}
+
+		normalized := &NormalizedScope{
+			Dependency: dep,
+			Scope:      scope,
+			ScopeRule:  mapping.Classify(scope),
+		}
+		if normalized.Deprecated && normalized.Replacement != "" {
+			normalized.Suggestion = fmt.Sprintf("将 %s %s 改为 %s %s",
+				scope, dependencyLabel(dep), normalized.Replacement, dependencyLabel(dep))
+		}
+		results = append(results, normalized)
+	}
+
+	return results
+}
+
+// dependencyLabel 返回依赖的简短描述，用于迁移建议。
+func dependencyLabel(dep *model.Dependency) string {
+	switch {
+	case dep.Group != "" && dep.Version != "":
+		return fmt.Sprintf("'%s:%s:%s'", dep.Group, dep.Name, dep.Version)
+	case dep.Group != "":
+		return fmt.Sprintf("'%s:%s'", dep.Group, dep.Name)
+	default:
+		return dep.Raw
+	}
+}
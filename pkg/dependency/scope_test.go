@@ -0,0 +1,91 @@
+package dependency
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestNormalizeScopes(t *testing.T) {
+	deps := []*model.Dependency{
+		{Group: "org.slf4j", Name: "slf4j-api", Version: "1.7.36", Scope: "compile"},
+		{Group: "junit", Name: "junit", Version: "4.13.2", Scope: "testCompile"},
+		{Group: "com.h2database", Name: "h2", Scope: "runtime"},
+		{Group: "org.projectlombok", Name: "lombok", Scope: "annotationProcessor"},
+		{Group: "com.google.guava", Name: "guava", Version: "31.1-jre"},
+		{Group: "com.squareup.leakcanary", Name: "leakcanary-android", Version: "2.9", Scope: "debugImplementation"},
+		{Group: "org.example", Name: "legacy", Version: "1.0", Scope: "debugCompile"},
+		{Group: "androidx.test", Name: "runner", Version: "1.5.2", Scope: "androidTestRuntimeOnly"},
+		{Group: "com.google.dagger", Name: "dagger-compiler", Version: "2.48", Scope: "kaptTest"},
+		{Group: "org.example", Name: "custom", Version: "1.0", Scope: "shadow"},
+	}
+
+	expected := []struct {
+		scope       string
+		category    ScopeCategory
+		replacement string
+	}{
+		{"compile", ScopeCategoryCompile, "implementation"},
+		{"testCompile", ScopeCategoryTest, "testImplementation"},
+		{"runtime", ScopeCategoryRuntime, "runtimeOnly"},
+		{"annotationProcessor", ScopeCategoryAnnotationProcessor, ""},
+		{"implementation", ScopeCategoryCompile, ""},
+		{"debugImplementation", ScopeCategoryCompile, ""},
+		{"debugCompile", ScopeCategoryCompile, "debugImplementation"},
+		{"androidTestRuntimeOnly", ScopeCategoryTest, ""},
+		{"kaptTest", ScopeCategoryAnnotationProcessor, ""},
+		{"shadow", ScopeCategoryUnknown, ""},
+	}
+
+	results := NormalizeScopes(deps, nil)
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(results))
+	}
+	for i, want := range expected {
+		got := results[i]
+		if got.Dependency != deps[i] || got.Scope != want.scope || got.Category != want.category {
+			t.Errorf("Result %d: expected %s/%s, got %s/%s", i, want.scope, want.category, got.Scope, got.Category)
+		}
+		if got.Deprecated != (want.replacement != "") || got.Replacement != want.replacement {
+			t.Errorf("Result %d: expected replacement %q, got deprecated=%v replacement=%q",
+				i, want.replacement, got.Deprecated, got.Replacement)
+		}
+		if got.Deprecated == (got.Suggestion == "") {
+			t.Errorf("Result %d: suggestion %q does not match deprecation", i, got.Suggestion)
+		}
+	}
+
+	if suggestion := results[0].Suggestion; !strings.Contains(suggestion, "compile 'org.slf4j:slf4j-api:1.7.36'") ||
+		!strings.Contains(suggestion, "implementation 'org.slf4j:slf4j-api:1.7.36'") {
+		t.Errorf("Unexpected suggestion: %s", suggestion)
+	}
+}
+
+func TestNormalizeScopesCustomMapping(t *testing.T) {
+	mapping := DefaultScopeMapping()
+	mapping["shadow"] = ScopeRule{Category: ScopeCategoryRuntime}
+	mapping["compile"] = ScopeRule{Category: ScopeCategoryCompile}
+
+	results := NormalizeScopes([]*model.Dependency{
+		{Group: "org.example", Name: "custom", Scope: "shadow"},
+		{Group: "org.example", Name: "old", Scope: "compile"},
+	}, mapping)
+
+	if results[0].Category != ScopeCategoryRuntime {
+		t.Errorf("Expected custom mapping to classify shadow as runtime, got %s", results[0].Category)
+	}
+	if results[1].Deprecated {
+		t.Error("Expected custom mapping to override deprecation of compile")
+	}
+	if DefaultScopeMapping()["shadow"].Category != "" {
+		t.Error("DefaultScopeMapping should return a fresh mapping")
+	}
+}
+
+func TestNormalizeScopesSkipsNil(t *testing.T) {
+	results := NormalizeScopes([]*model.Dependency{nil, {Group: "org.projectlombok", Name: "lombok", Scope: "annotationProcessor"}}, nil)
+	if len(results) != 1 || results[0].Category != ScopeCategoryAnnotationProcessor {
+		t.Errorf("Expected nil dependencies to be skipped, got %+v", results)
+	}
+}
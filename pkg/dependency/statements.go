@@ -0,0 +1,165 @@
+// Package dependency 提供把脚本文本切分为语句的功能。
+package dependency
+
+import (
+	"strings"
+)
+
+// continuationOperators 是行尾出现时表示语句延续到下一行的运算符。
+const continuationOperators = ",+=:?&|."
+
+// Statement 是从脚本文本中切分出的一条语句。
+type Statement struct {
+	// 语句原文，不含首尾空白和行尾注释，跨行时保留原有的换行。
+	Text string
+	// 语句在原文中的起止偏移量，End不包含在内。
+	Start int
+	End   int
+}
+
+// SplitStatements 把脚本文本切分为语句。分号和换行结束一条语句，
+// 但括号未闭合或行尾是运算符（例如逗号、+）时语句延续到下一行；以 { 或 } 结尾的行总是结束语句，
+// 避免不配对的括号吞掉后续内容。字符串中的分隔符和注释会被忽略。
+func SplitStatements(text string) []Statement {
+	var statements []Statement
+	start, end, depth := -1, 0, 0
+	var last byte
+	flush := func() {
+		if start != -1 {
+			statements = append(statements, Statement{Text: text[start:end], Start: start, End: end})
+		}
+		start, depth, last = -1, 0, 0
+	}
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '/' && strings.HasPrefix(text[i:], "//"):
+			// 行注释，停在换行符之前以便结束语句。
+			if n := strings.IndexByte(text[i:], '\n'); n != -1 {
+				i += n - 1
+			} else {
+				i = len(text)
+			}
+			continue
+		case c == '/' && strings.HasPrefix(text[i:], "/*"):
+			if n := strings.Index(text[i+2:], "*/"); n != -1 {
+				i += n + 3
+			} else {
+				i = len(text)
+			}
+			continue
+		case c == '\n':
+			if start != -1 && (depth <= 0 || last == '{' || last == '}') &&
+				(last == 0 || !strings.ContainsRune(continuationOperators, rune(last))) {
+				flush()
+			}
+			continue
+		case c == ';' && depth <= 0:
+			flush()
+			continue
+		case isSpaceByte(c):
+			continue
+		}
+
+		if start == -1 {
+			start = i
+		}
+		switch c {
+		case '\'', '"':
+			i = stringEnd(text, i)
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		}
+		last = text[i]
+		end = i + 1
+	}
+	flush()
+
+	return statements
+}
+
+// stringEnd 返回从start开始的字符串字面量的结束引号位置。
+// 单行字符串在行尾没有闭合时返回换行符之前的位置。
+func stringEnd(text string, start int) int {
+	quote := text[start]
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case quote:
+			return i
+		case '\n':
+			return i - 1
+		}
+	}
+	return len(text) - 1
+}
+
+// joinLines 把跨行的语句合并为一行，换行及其两侧的空白替换为一个空格。
+func joinLines(statement string) string {
+	if !strings.Contains(statement, "\n") {
+		return statement
+	}
+	lines := strings.Split(statement, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.Join(lines, " ")
+}
+
+// FoldConcatenation 把expr开头用 + 连接的字符串字面量合并为一个字面量，
+// 例如 'org.foo:bar:' + '1.0' 合并为 'org.foo:bar:1.0'，连接可以跨行。
+// 连接中的变量（例如 versions.bar）转换为 ${versions.bar} 插值，此时结果使用双引号。
+// 返回合并后的字面量和连接表达式在expr中的长度，expr开头不是字符串连接时返回 "", 0。
+func FoldConcatenation(expr string) (string, int) {
+	var sb strings.Builder
+	quote := byte('\'')
+	pos, end, parts := 0, 0, 0
+	for {
+		for pos < len(expr) && isSpaceByte(expr[pos]) {
+			pos++
+		}
+		if pos == len(expr) {
+			break
+		}
+		if c := expr[pos]; c == '\'' || c == '"' {
+			closeQuote := stringEnd(expr, pos)
+			if closeQuote == pos || expr[closeQuote] != c {
+				break
+			}
+			if c == '"' {
+				quote = '"'
+			}
+			sb.WriteString(expr[pos+1 : closeQuote])
+			pos = closeQuote + 1
+		} else {
+			n := pos
+			for n < len(expr) && (isIdentifierByte(expr[n]) || expr[n] == '.') {
+				n++
+			}
+			if n == pos {
+				break
+			}
+			quote = '"'
+			sb.WriteString("${" + expr[pos:n] + "}")
+			pos = n
+		}
+		parts++
+		end = pos
+
+		for pos < len(expr) && isSpaceByte(expr[pos]) {
+			pos++
+		}
+		if pos == len(expr) || expr[pos] != '+' {
+			break
+		}
+		pos++
+	}
+	if parts < 2 {
+		return "", 0
+	}
+	return string(quote) + sb.String() + string(quote), end
+}
@@ -0,0 +1,68 @@
+package dependency
+
+import "testing"
+
+func TestSplitStatements(t *testing.T) {
+	text := "implementation 'a:b:1'; implementation 'c:d:2'\n" +
+		"implementation group: 'g',\n    name: 'n' // trailing\n" +
+		"api(\n    'e:f:3'\n)\n" +
+		"implementation 'x;y:z:1' /* ; */\n" +
+		"dependencies {\n}\n"
+
+	want := []string{
+		"implementation 'a:b:1'",
+		"implementation 'c:d:2'",
+		"implementation group: 'g',\n    name: 'n'",
+		"api(\n    'e:f:3'\n)",
+		"implementation 'x;y:z:1'",
+		"dependencies {",
+		"}",
+	}
+
+	statements := SplitStatements(text)
+	if len(statements) != len(want) {
+		for _, s := range statements {
+			t.Logf("%q", s.Text)
+		}
+		t.Fatalf("SplitStatements() returned %d statements, want %d", len(statements), len(want))
+	}
+	for i, w := range want {
+		s := statements[i]
+		if s.Text != w {
+			t.Errorf("Statement %d = %q, want %q", i, s.Text, w)
+		}
+		if text[s.Start:s.End] != s.Text {
+			t.Errorf("Statement %d offsets [%d,%d) do not match its text", i, s.Start, s.End)
+		}
+	}
+}
+
+func TestSplitStatements_UnbalancedParenthesis(t *testing.T) {
+	// 行尾的 { 总是结束语句，未闭合的括号不会吞掉后面的声明。
+	statements := SplitStatements("foo(bar {\n    implementation 'a:b:1'\n}")
+	if len(statements) != 3 || statements[1].Text != "implementation 'a:b:1'" {
+		t.Errorf("Unexpected statements: %+v", statements)
+	}
+}
+
+func TestFoldConcatenation(t *testing.T) {
+	tests := []struct {
+		expr, want string
+		n          int
+	}{
+		{`'a:b:' + '1.0'`, `'a:b:1.0'`, 14},
+		{"\"a:b:\" +\n    \"1.0\"", `"a:b:1.0"`, 18},
+		{`'a:b:' + versions.b`, `"a:b:${versions.b}"`, 19},
+		{`'a:b:' + '1' { transitive = false }`, `'a:b:1'`, 12},
+		{`'a:b:1'`, "", 0},
+		{`'a:b:' +`, "", 0},
+		{`libs.foo`, "", 0},
+	}
+
+	for _, tt := range tests {
+		got, n := FoldConcatenation(tt.expr)
+		if got != tt.want || n != tt.n {
+			t.Errorf("FoldConcatenation(%q) = %q, %d, want %q, %d", tt.expr, got, n, tt.want, tt.n)
+		}
+	}
+}
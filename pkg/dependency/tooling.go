@@ -0,0 +1,70 @@
+// Package dependency 提供构建工具依赖（buildscript classpath和插件）的识别与过滤。
+package dependency
+
+import (
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+const (
+	// ScopeBuildscriptClasspath 是 buildscript { dependencies { classpath ... } } 中的依赖使用的配置标签。
+	ScopeBuildscriptClasspath = "buildscript-classpath"
+
+	// ScopePlugin 是plugins块中声明了版本的插件对应的依赖使用的配置标签。
+	ScopePlugin = "plugin"
+)
+
+// pluginMarkerSuffix 是Gradle插件标记制品的名称后缀，插件 <id> 的标记制品为 <id>:<id>.gradle.plugin。
+const pluginMarkerSuffix = ".gradle.plugin"
+
+// DependencyFilter 判断依赖是否保留。
+type DependencyFilter func(dep *model.Dependency) bool
+
+// IsBuildToolingScope 判断配置是否为构建工具依赖的标签（ScopeBuildscriptClasspath 或 ScopePlugin）。
+func IsBuildToolingScope(scope string) bool {
+	return scope == ScopeBuildscriptClasspath || scope == ScopePlugin
+}
+
+// ApplicationDependencies 只保留应用依赖，排除buildscript classpath和插件依赖。
+func ApplicationDependencies(dep *model.Dependency) bool {
+	return !IsBuildToolingScope(dep.Scope)
+}
+
+// BuildToolingDependencies 只保留buildscript classpath和插件依赖。
+func BuildToolingDependencies(dep *model.Dependency) bool {
+	return IsBuildToolingScope(dep.Scope)
+}
+
+// ParseClasspathDeclaration 解析buildscript的dependencies块中的一条classpath声明，依赖的配置为 ScopeBuildscriptClasspath。
+// 不是classpath声明或该配置被过滤时返回nil。
+func (dp *Parser) ParseClasspathDeclaration(statement string) *model.Dependency {
+	name, args := splitDeclaration(strings.TrimSpace(joinLines(statement)))
+	if name != "classpath" || !dp.scopeAllowed(ScopeBuildscriptClasspath) {
+		return nil
+	}
+	dep := dp.parseDependencyArgs(args, ScopeBuildscriptClasspath)
+	if dep == nil || dep.Project != nil {
+		return nil
+	}
+	return dep
+}
+
+// PluginDependency 返回插件对应的插件标记制品依赖，坐标为 <id>:<id>.gradle.plugin:<version>，配置为 ScopePlugin。
+// 没有版本的插件（例如核心插件或由pluginManagement决定版本的插件）和该配置被过滤时返回nil。
+func (dp *Parser) PluginDependency(plugin *model.Plugin) *model.Dependency {
+	if plugin == nil || plugin.ID == "" || plugin.Version == "" || !dp.scopeAllowed(ScopePlugin) {
+		return nil
+	}
+	dep := &model.Dependency{
+		Group:   plugin.ID,
+		Name:    plugin.ID + pluginMarkerSuffix,
+		Version: plugin.Version,
+		Scope:   ScopePlugin,
+		Raw:     plugin.ID + ":" + plugin.ID + pluginMarkerSuffix + ":" + plugin.Version,
+
+		DeclaredAtLine: plugin.DeclaredAtLine,
+	}
+	dep.HasDynamicVersion = strings.Contains(plugin.Version, "$")
+	return dep
+}
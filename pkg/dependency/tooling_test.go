@@ -0,0 +1,64 @@
+package dependency
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestParseClasspathDeclaration(t *testing.T) {
+	dep := NewParser().ParseClasspathDeclaration("classpath 'com.android.tools.build:gradle:8.1.0'")
+	if dep == nil || dep.Scope != ScopeBuildscriptClasspath || dep.Name != "gradle" || dep.Version != "8.1.0" {
+		t.Errorf("Unexpected classpath dependency %+v", dep)
+	}
+
+	for _, statement := range []string{
+		"implementation 'org.foo:bar:1.0'",
+		"classpath files('libs/tool.jar')",
+		"classpath project(':tools')",
+		"classpathFoo 'org.foo:bar:1.0'",
+	} {
+		if dep := NewParser().ParseClasspathDeclaration(statement); dep != nil {
+			t.Errorf("Expected nil for %q, got %+v", statement, dep)
+		}
+	}
+
+	if dep := NewParser().WithScopeFilter([]string{"implementation"}, nil).ParseClasspathDeclaration("classpath 'a:b:1.0'"); dep != nil {
+		t.Errorf("Expected filtered classpath dependency to be nil, got %+v", dep)
+	}
+}
+
+func TestPluginDependency(t *testing.T) {
+	dep := NewParser().PluginDependency(&model.Plugin{ID: "org.springframework.boot", Version: "3.1.0"})
+	if dep == nil || dep.Group != "org.springframework.boot" || dep.Name != "org.springframework.boot.gradle.plugin" ||
+		dep.Version != "3.1.0" || dep.Scope != ScopePlugin {
+		t.Errorf("Unexpected plugin dependency %+v", dep)
+	}
+	if dep := NewParser().PluginDependency(&model.Plugin{ID: "java"}); dep != nil {
+		t.Errorf("Expected nil for plugin without version, got %+v", dep)
+	}
+}
+
+func TestGroupDependenciesByScopeFilters(t *testing.T) {
+	deps := []*model.Dependency{
+		{Group: "org.foo", Name: "bar", Scope: "implementation"},
+		{Group: "com.android.tools.build", Name: "gradle", Scope: ScopeBuildscriptClasspath},
+		{Group: "org.springframework.boot", Name: "org.springframework.boot.gradle.plugin", Scope: ScopePlugin},
+	}
+
+	sets := NewParser().GroupDependenciesByScope(deps, ApplicationDependencies)
+	if len(sets) != 1 || sets[0].Scope != "implementation" {
+		t.Errorf("Expected only application dependencies, got %+v", sets)
+	}
+
+	count := 0
+	for _, set := range NewParser().GroupDependenciesByScope(deps, BuildToolingDependencies) {
+		if !IsBuildToolingScope(set.Scope) {
+			t.Errorf("Unexpected scope %s", set.Scope)
+		}
+		count += len(set.Dependencies)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 build tooling dependencies, got %d", count)
+	}
+}
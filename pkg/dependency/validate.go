@@ -0,0 +1,114 @@
+// Package dependency 提供依赖坐标的命名规则校验。
+package dependency
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidCoordinate 表示依赖坐标不符合Maven的命名规则，校验函数返回的错误都包装了它。
+var ErrInvalidCoordinate = errors.New("无效的依赖坐标")
+
+// ValidateGroupID 检查group是否符合Maven的命名规则：非空，只包含字母、数字、_、-、.，
+// 且不以 . 开头或结尾、不含连续的 .。包含变量插值（$）时不做检查。
+func ValidateGroupID(group string) error {
+	if strings.Contains(group, "$") {
+		return nil
+	}
+	if err := validateID("group", group); err != nil {
+		return err
+	}
+	if strings.HasPrefix(group, ".") || strings.HasSuffix(group, ".") || strings.Contains(group, "..") {
+		return fmt.Errorf("%w: group %q 中的 . 位置不正确", ErrInvalidCoordinate, group)
+	}
+	return nil
+}
+
+// ValidateArtifactID 检查name是否符合Maven的命名规则：非空，只包含字母、数字、_、-、.。
+// 包含变量插值（$）时不做检查。
+func ValidateArtifactID(name string) error {
+	if strings.Contains(name, "$") {
+		return nil
+	}
+	return validateID("name", name)
+}
+
+// ValidateVersion 检查版本是否可用：不含空白、引号、斜杠等字符。
+// 动态版本（1.+、latest.release）和版本范围（[1.0,2.0)）是合法的，空版本表示未声明版本，
+// 包含变量插值（$）时不做检查。
+func ValidateVersion(version string) error {
+	if version == "" || strings.Contains(version, "$") {
+		return nil
+	}
+	for i := 0; i < len(version); i++ {
+		c := version[i]
+		if c <= ' ' || c == '\'' || c == '"' || c == '/' || c == '\\' || c == ':' || c == '@' || c == ';' {
+			return fmt.Errorf("%w: 版本 %q 包含非法字符 %q", ErrInvalidCoordinate, version, c)
+		}
+	}
+	return nil
+}
+
+// ValidateCoordinate 检查 group:name[:version[:classifier]][@extension] 形式的坐标字符串，
+// 能识别漏写或多写冒号、使用空格或分号分隔等常见的笔误。两端的引号会被去除，
+// 返回的错误包装了 ErrInvalidCoordinate。
+func ValidateCoordinate(coordinate string) error {
+	text := strings.Trim(strings.TrimSpace(coordinate), `"'`)
+	if text == "" {
+		return fmt.Errorf("%w: 坐标为空", ErrInvalidCoordinate)
+	}
+
+	body, extension, hasExtension := strings.Cut(text, "@")
+	if hasExtension {
+		if err := validateID("extension", extension); err != nil {
+			return err
+		}
+	}
+
+	parts := strings.Split(body, ":")
+	switch {
+	case len(parts) < 2:
+		if strings.Contains(body, "$") {
+			// 整个坐标可能来自变量，例如 "$guava"。
+			return nil
+		}
+		return fmt.Errorf("%w: %q 缺少 group:name 之间的冒号", ErrInvalidCoordinate, text)
+	case len(parts) > 4:
+		return fmt.Errorf("%w: %q 包含过多的冒号", ErrInvalidCoordinate, text)
+	case len(parts) > 2 && parts[2] == "" && (len(parts) == 3 || parts[3] == ""):
+		return fmt.Errorf("%w: %q 以冒号结尾但没有版本", ErrInvalidCoordinate, text)
+	}
+
+	if err := ValidateGroupID(parts[0]); err != nil {
+		return err
+	}
+	if err := ValidateArtifactID(parts[1]); err != nil {
+		return err
+	}
+	if len(parts) > 2 {
+		if err := ValidateVersion(parts[2]); err != nil {
+			return err
+		}
+	}
+	if len(parts) > 3 && !strings.Contains(parts[3], "$") {
+		if err := validateID("classifier", parts[3]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateID 检查标识符非空且只包含字母、数字、_、-、.。
+func validateID(field, value string) error {
+	if value == "" {
+		return fmt.Errorf("%w: %s 为空", ErrInvalidCoordinate, field)
+	}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.') {
+			return fmt.Errorf("%w: %s %q 包含非法字符 %q", ErrInvalidCoordinate, field, value, c)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package dependency
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateCoordinate(t *testing.T) {
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"'org.foo:bar:1.0'", true},
+		{`"org.foo:bar"`, true},
+		{"net.sf.json-lib:json-lib:2.4:jdk15", true},
+		{"org.foo:bar:1.0:sources@jar", true},
+		{"org.foo:bar:1.+", true},
+		{"org.foo:bar:[1.0,2.0)", true},
+		{"org.foo:bar:$barVersion", true},
+		{"${deps.bar}", true},
+		{"org.foo.bar", false},
+		{"org.foo bar:1.0", false},
+		{"org.foo:bar baz:1.0", false},
+		{"org.foo;bar:1.0", false},
+		{"org..foo:bar:1.0", false},
+		{"org.foo:bar:", false},
+		{"org.foo::1.0", false},
+		{":bar:1.0", false},
+		{"org.foo:bar:1.0:a:b", false},
+		{"org.foo:bar:1.0/2", false},
+		{"org.foo:bar:1.0@", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateCoordinate(tt.input)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateCoordinate(%q) error = %v, want nil", tt.input, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidCoordinate) {
+			t.Errorf("ValidateCoordinate(%q) error = %v, want ErrInvalidCoordinate", tt.input, err)
+		}
+	}
+}
+
+func TestValidateIDs(t *testing.T) {
+	if err := ValidateGroupID("com.example_1-x"); err != nil {
+		t.Errorf("ValidateGroupID() error = %v", err)
+	}
+	if err := ValidateGroupID(".com.example"); err == nil {
+		t.Error("Expected error for group with leading dot")
+	}
+	if err := ValidateArtifactID("my artifact"); err == nil {
+		t.Error("Expected error for artifact with space")
+	}
+	if err := ValidateArtifactID(""); err == nil {
+		t.Error("Expected error for empty artifact")
+	}
+	if err := ValidateVersion("latest.release"); err != nil {
+		t.Errorf("ValidateVersion() error = %v", err)
+	}
+	if err := ValidateVersion("1.0 "); err == nil {
+		t.Error("Expected error for version with trailing space")
+	}
+}
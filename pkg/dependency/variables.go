@@ -0,0 +1,37 @@
+// Package dependency 提供依赖版本变量的解析功能。
+package dependency
+
+import (
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// ResolveVersionVariables 用属性替换版本中引用的变量，结果写入ResolvedVersion，Version保留原始表达式。
+// 整个坐标都由变量给出时（例如 "${deps.spring}"），替换后的坐标还用于补全group和name。
+// 仍有无法解析的引用时不设置ResolvedVersion。
+func ResolveVersionVariables(deps []*model.Dependency, props map[string]string) {
+	for _, dep := range deps {
+		if dep == nil || !dep.HasDynamicVersion {
+			continue
+		}
+
+		resolved := substituteProperties(dep.Version, props)
+		if strings.Contains(resolved, "$") {
+			continue
+		}
+
+		if dep.Group == "" && dep.Name == "" {
+			coordinate := ParseCoordinate(resolved)
+			if coordinate == nil {
+				continue
+			}
+			dep.Group = coordinate.Group
+			dep.Name = coordinate.Name
+			dep.Classifier = coordinate.Classifier
+			dep.Extension = coordinate.Extension
+			resolved = coordinate.Version
+		}
+		dep.ResolvedVersion = resolved
+	}
+}
@@ -0,0 +1,69 @@
+package dependency
+
+import (
+	"testing"
+)
+
+func TestExtractInterpolatedDependencies(t *testing.T) {
+	text := `
+dependencies {
+    implementation "org.foo:bar:$barVersion"
+    implementation "org.foo:baz:${versions.baz}"
+    implementation "${deps.spring}"
+    implementation 'org.foo:literal:$notInterpolated'
+    implementation "org.foo:fixed:1.0"
+}
+`
+	deps := NewParser().ExtractDependenciesFromText(text)
+	if len(deps) != 5 {
+		t.Fatalf("Expected 5 dependencies, got %d", len(deps))
+	}
+
+	want := []struct {
+		name    string
+		version string
+		dynamic bool
+	}{
+		{"bar", "$barVersion", true},
+		{"baz", "${versions.baz}", true},
+		{"", "${deps.spring}", true},
+		{"literal", "$notInterpolated", false},
+		{"fixed", "1.0", false},
+	}
+	for i, w := range want {
+		if deps[i].Name != w.name || deps[i].Version != w.version || deps[i].HasDynamicVersion != w.dynamic {
+			t.Errorf("dependency %d = {Name:%q Version:%q HasDynamicVersion:%v}, want %+v",
+				i, deps[i].Name, deps[i].Version, deps[i].HasDynamicVersion, w)
+		}
+	}
+}
+
+func TestResolveVersionVariables(t *testing.T) {
+	text := `
+dependencies {
+    implementation "org.foo:bar:$barVersion"
+    implementation "org.foo:baz:${versions.baz}-jre"
+    implementation "${deps.spring}"
+    implementation "org.foo:missing:$unknown"
+}
+`
+	deps := NewParser().ExtractDependenciesFromText(text)
+	ResolveVersionVariables(deps, map[string]string{
+		"barVersion":   "1.2.3",
+		"versions.baz": "31.1",
+		"deps.spring":  "org.springframework:spring-core:5.3.20",
+	})
+
+	if deps[0].ResolvedVersion != "1.2.3" || deps[0].Version != "$barVersion" {
+		t.Errorf("Unexpected resolution for bar: version=%q resolved=%q", deps[0].Version, deps[0].ResolvedVersion)
+	}
+	if deps[1].ResolvedVersion != "31.1-jre" {
+		t.Errorf("Expected resolved version 31.1-jre, got %q", deps[1].ResolvedVersion)
+	}
+	if deps[2].Group != "org.springframework" || deps[2].Name != "spring-core" || deps[2].ResolvedVersion != "5.3.20" {
+		t.Errorf("Unexpected resolution for whole-coordinate variable: %+v", deps[2])
+	}
+	if deps[3].ResolvedVersion != "" {
+		t.Errorf("Expected unresolved version to stay empty, got %q", deps[3].ResolvedVersion)
+	}
+}
@@ -0,0 +1,110 @@
+// Package dependency 提供Android变体配置的识别与拆分功能。
+package dependency
+
+import (
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// variantBaseScopes 是可以带变体前缀的基础配置，按长度从长到短排列以优先匹配更具体的名称。
+var variantBaseScopes = []string{
+	"AnnotationProcessor", "CompileOnly", "RuntimeOnly", "Implementation", "Compile", "Api",
+}
+
+// variantSourceSets 是可以出现在变体之前的源码集前缀，例如 testFreeDebugImplementation。
+// testFixtures 必须排在 test 之前。
+var variantSourceSets = []string{"androidTest", "testFixtures", "test"}
+
+// standaloneScopes 是没有变体形式、但同样用于声明依赖的配置：Android的独立配置，
+// 以及注解处理器配置（带变体的注解处理器配置，例如 debugAnnotationProcessor，由变体规则识别）。
+var standaloneScopes = map[string]bool{
+	"androidTestUtil":       true,
+	"lintChecks":            true,
+	"lintPublish":           true,
+	"coreLibraryDesugaring": true,
+
+	"annotationProcessor":            true,
+	"testAnnotationProcessor":        true,
+	"androidTestAnnotationProcessor": true,
+	"kapt":                           true,
+	"kaptTest":                       true,
+	"kaptAndroidTest":                true,
+	"ksp":                            true,
+	"kspTest":                        true,
+	"kspAndroidTest":                 true,
+}
+
+// SplitVariantScope 把Android变体配置拆分为基础配置和变体名称。
+// 例如 freeDebugImplementation 拆分为 implementation 和 freeDebug，
+// testFreeDebugImplementation 拆分为 testImplementation 和 freeDebug。不是变体配置时返回false。
+func SplitVariantScope(scope string) (base, variant string, ok bool) {
+	sourceSet := ""
+	rest := scope
+	for _, prefix := range variantSourceSets {
+		if after, found := strings.CutPrefix(scope, prefix); found && after != "" && isUpper(after[0]) {
+			sourceSet, rest = prefix, after
+			break
+		}
+	}
+
+	for _, suffix := range variantBaseScopes {
+		name, found := strings.CutSuffix(rest, suffix)
+		if !found || name == "" || !isIdentifier(name) {
+			continue
+		}
+		if sourceSet != "" {
+			// 源码集之后的变体以大写字母开头，例如 testFreeDebug 中的 FreeDebug。
+			if !isUpper(name[0]) {
+				return "", "", false
+			}
+			return sourceSet + suffix, lowerFirst(name), true
+		}
+		if !isLower(name[0]) {
+			return "", "", false
+		}
+		return lowerFirst(suffix), name, true
+	}
+	return "", "", false
+}
+
+// IsDependencyScope 判断名称是否为可以声明依赖的配置，包括常见配置、Android变体配置、Android的独立配置和注解处理器配置。
+func IsDependencyScope(scope string) bool {
+	if commonScopeSet[scope] || standaloneScopes[scope] {
+		return true
+	}
+	_, _, ok := SplitVariantScope(scope)
+	return ok
+}
+
+// ApplyVariantScope 根据依赖的配置设置BaseScope和Variant，不是变体配置时保持为空。
+func ApplyVariantScope(dep *model.Dependency) {
+	if dep == nil {
+		return
+	}
+	if base, variant, ok := SplitVariantScope(dep.Scope); ok {
+		dep.BaseScope, dep.Variant = base, variant
+	}
+}
+
+// isIdentifier 判断字符串是否只由字母和数字组成。
+func isIdentifier(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isIdentifierByte(s[i]) || s[i] == '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpper(c byte) bool { return c >= 'A' && c <= 'Z' }
+
+func isLower(c byte) bool { return c >= 'a' && c <= 'z' }
+
+// lowerFirst 把首字母转换为小写。
+func lowerFirst(s string) string {
+	if s == "" || !isUpper(s[0]) {
+		return s
+	}
+	return string(s[0]+'a'-'A') + s[1:]
+}
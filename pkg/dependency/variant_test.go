@@ -0,0 +1,88 @@
+package dependency
+
+import "testing"
+
+func TestSplitVariantScope(t *testing.T) {
+	tests := []struct {
+		scope, base, variant string
+		ok                   bool
+	}{
+		{"freeDebugImplementation", "implementation", "freeDebug", true},
+		{"paidReleaseRuntimeOnly", "runtimeOnly", "paidRelease", true},
+		{"debugImplementation", "implementation", "debug", true},
+		{"stagingApi", "api", "staging", true},
+		{"freeCompileOnly", "compileOnly", "free", true},
+		{"debugAnnotationProcessor", "annotationProcessor", "debug", true},
+		{"releaseCompile", "compile", "release", true},
+		{"testFreeDebugImplementation", "testImplementation", "freeDebug", true},
+		{"androidTestPaidImplementation", "androidTestImplementation", "paid", true},
+		{"testFixturesDebugApi", "testFixturesApi", "debug", true},
+		{"implementation", "", "", false},
+		{"testImplementation", "", "", false},
+		{"androidTestImplementation", "", "", false},
+		{"testCompileOnly", "", "", false},
+		{"testFixturesApi", "", "", false},
+		{"androidTestUtil", "", "", false},
+		{"FreeImplementation", "", "", false},
+		{"free_debugImplementation", "", "", false},
+		{"classpath", "", "", false},
+	}
+
+	for _, tt := range tests {
+		base, variant, ok := SplitVariantScope(tt.scope)
+		if base != tt.base || variant != tt.variant || ok != tt.ok {
+			t.Errorf("SplitVariantScope(%q) = %q, %q, %v, want %q, %q, %v",
+				tt.scope, base, variant, ok, tt.base, tt.variant, tt.ok)
+		}
+	}
+}
+
+func TestExtractVariantDependencies(t *testing.T) {
+	text := `
+dependencies {
+    implementation 'androidx.core:core-ktx:1.12.0'
+    freeDebugImplementation 'com.example:free-debug:1.0'
+    paidReleaseRuntimeOnly "com.example:paid-runtime:2.0"
+    testFreeImplementation 'junit:junit:4.13.2'
+    androidTestUtil 'androidx.test:orchestrator:1.4.2'
+    freeImplementation(project(":feature-free"))
+    notAScope 'com.example:ignored:1.0'
+}
+`
+	deps := NewParser().ExtractDependenciesFromText(text)
+
+	want := []struct{ name, scope, base, variant string }{
+		{"core-ktx", "implementation", "", ""},
+		{"free-debug", "freeDebugImplementation", "implementation", "freeDebug"},
+		{"paid-runtime", "paidReleaseRuntimeOnly", "runtimeOnly", "paidRelease"},
+		{"junit", "testFreeImplementation", "testImplementation", "free"},
+		{"orchestrator", "androidTestUtil", "", ""},
+		{"feature-free", "freeImplementation", "implementation", "free"},
+	}
+	if len(deps) != len(want) {
+		t.Fatalf("Expected %d dependencies, got %d: %+v", len(want), len(deps), deps)
+	}
+	for i, w := range want {
+		dep := deps[i]
+		if dep.Name != w.name || dep.Scope != w.scope || dep.BaseScope != w.base || dep.Variant != w.variant {
+			t.Errorf("dependency %d = %s scope=%q base=%q variant=%q, want %s scope=%q base=%q variant=%q",
+				i, dep.Name, dep.Scope, dep.BaseScope, dep.Variant, w.name, w.scope, w.base, w.variant)
+		}
+	}
+}
+
+func TestIsDependencyScope(t *testing.T) {
+	for _, scope := range []string{
+		"implementation", "debugImplementation", "lintChecks",
+		"annotationProcessor", "testAnnotationProcessor", "debugAnnotationProcessor", "kapt", "kaptTest", "ksp",
+	} {
+		if !IsDependencyScope(scope) {
+			t.Errorf("IsDependencyScope(%q) = false, want true", scope)
+		}
+	}
+	for _, scope := range []string{"repositories", "exclude", "debug"} {
+		if IsDependencyScope(scope) {
+			t.Errorf("IsDependencyScope(%q) = true, want false", scope)
+		}
+	}
+}
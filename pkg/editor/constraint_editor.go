@@ -0,0 +1,191 @@
+// Package editor 提供依赖约束相关的结构化编辑功能。
+package editor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// AddConstraint 在顶层dependencies块的constraints块中添加依赖约束，例如 implementation 'g:a:1.2.3'。
+// 同一配置下已有该模块的约束时改为更新其版本；没有constraints块时在dependencies块末尾创建。
+// scope为空时使用 implementation。
+func (ge *GradleEditor) AddConstraint(group, name, version, scope string) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+	if group == "" || name == "" || version == "" {
+		return fmt.Errorf("%w: constraint group, name and version are required", ErrInvalidArgument)
+	}
+	if scope == "" {
+		scope = "implementation"
+	}
+
+	for _, constraint := range ge.sourceMappedProject.SourceMappedConstraints {
+		if constraint.Group == group && constraint.Name == name && constraint.Scope == scope {
+			return ge.updateConstraintVersion(constraint, version)
+		}
+	}
+
+	declaration := ge.formatDependency(group, name, version, scope)
+	description := fmt.Sprintf("Add constraint %s:%s:%s with scope %s", group, name, version, scope)
+
+	if block := ge.findConstraintsBlock(); block != nil {
+		ge.insertIntoConstraintsBlock(block, declaration, description)
+		return nil
+	}
+
+	blockStart, blockEnd := ge.findTopLevelBlock("dependencies")
+	if blockStart == -1 {
+		return ErrDependenciesBlockMissing
+	}
+	if blockEnd == -1 {
+		return fmt.Errorf("%w: could not find block end", ErrDependenciesBlockMissing)
+	}
+
+	lines := ge.sourceMappedProject.Lines
+	indent := ge.detectBlockIndent(blockStart, blockEnd)
+	unit := strings.TrimPrefix(indent, leadingWhitespace(lines[blockStart-1]))
+	if unit == "" {
+		unit = ge.Style().Indent
+	}
+
+	newText := indent + "constraints {\n" + indent + unit + declaration + "\n" + indent + "}\n"
+	if blockEnd-1 > blockStart && strings.TrimSpace(lines[blockEnd-2]) != "" {
+		newText = "\n" + newText
+	}
+
+	ge.modifications = append(ge.modifications, Modification{
+		Type:        ModificationTypeInsert,
+		SourceRange: pointRange(blockEnd, 1, ge.lineStartPos(blockEnd)),
+		OldText:     "",
+		NewText:     newText,
+		Description: description,
+	})
+
+	return nil
+}
+
+// UpdateConstraintVersion 更新所有匹配group和name的依赖约束的版本。
+// 版本来自配置闭包中的 strictly、require 或 prefer 时更新闭包中的版本；没有版本时把版本添加到坐标中。
+func (ge *GradleEditor) UpdateConstraintVersion(group, name, newVersion string) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+	if newVersion == "" {
+		return fmt.Errorf("%w: constraint version is empty", ErrInvalidArgument)
+	}
+
+	found := false
+	for _, constraint := range ge.sourceMappedProject.SourceMappedConstraints {
+		if constraint.Group != group || constraint.Name != name {
+			continue
+		}
+		found = true
+		if err := ge.updateConstraintVersion(constraint, newVersion); err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s:%s", ErrConstraintNotFound, group, name)
+	}
+	return nil
+}
+
+// updateConstraintVersion 为指定约束生成版本更新修改。
+func (ge *GradleEditor) updateConstraintVersion(constraint *model.SourceMappedConstraint, newVersion string) error {
+	oldVersion := constraint.Version
+	if oldVersion == newVersion {
+		return nil
+	}
+
+	sourceRange := constraint.VersionRange
+	newText := newVersion
+	if sourceRange.Start.Length == 0 {
+		// 没有版本，把版本添加到坐标中。
+		if constraint.CoordinateRange.Start.Length == 0 {
+			return fmt.Errorf("%w: constraint %s:%s has no version to update", ErrInvalidArgument,
+				constraint.Group, constraint.Name)
+		}
+		sourceRange = constraint.CoordinateRange
+		newText = constraint.Group + ":" + constraint.Name + ":" + newVersion
+	}
+
+	original := ge.sourceMappedProject.OriginalText
+	start, end := sourceRange.Start.StartPos, sourceRange.End.StartPos
+	oldText := original[start:end]
+
+	ge.modifications = append(ge.modifications, Modification{
+		Type:        ModificationTypeReplace,
+		SourceRange: sourceRange,
+		OldText:     oldText,
+		NewText:     newText,
+		Description: fmt.Sprintf("Update constraint %s:%s version from '%s' to '%s'",
+			constraint.Group, constraint.Name, oldVersion, newVersion),
+	})
+
+	// 更新内存中的约束信息。
+	declStart := constraint.SourceRange.Start.StartPos
+	constraint.RawText = constraint.RawText[:start-declStart] + newText + constraint.RawText[end-declStart:]
+	constraint.Raw = constraint.RawText
+	constraint.Version = newVersion
+	switch {
+	case constraint.CoordinateRange.Start.Length > 0 && start >= constraint.CoordinateRange.Start.StartPos &&
+		end <= constraint.CoordinateRange.End.StartPos:
+		// 坐标中的版本。
+	case constraint.Strictly == oldVersion:
+		constraint.Strictly = newVersion
+	case constraint.Require == oldVersion:
+		constraint.Require = newVersion
+	case constraint.Prefer == oldVersion:
+		constraint.Prefer = newVersion
+	}
+
+	return nil
+}
+
+// findConstraintsBlock 查找顶层dependencies块中的constraints块。
+func (ge *GradleEditor) findConstraintsBlock() *model.SourceMappedBlock {
+	for _, block := range ge.sourceMappedProject.SourceMappedBlocks {
+		if block.Path == "dependencies.constraints" {
+			return block
+		}
+	}
+	return nil
+}
+
+// insertIntoConstraintsBlock 在constraints块的右花括号之前插入声明。
+func (ge *GradleEditor) insertIntoConstraintsBlock(block *model.SourceMappedBlock, declaration, description string) {
+	startLine, endLine := block.SourceRange.Start.Line, block.SourceRange.End.Line
+	lines := ge.sourceMappedProject.Lines
+
+	if startLine < endLine && strings.TrimSpace(lines[endLine-1]) == "}" {
+		ge.modifications = append(ge.modifications, Modification{
+			Type:        ModificationTypeInsert,
+			SourceRange: pointRange(endLine, 1, ge.lineStartPos(endLine)),
+			OldText:     "",
+			NewText:     ge.detectBlockIndent(startLine, endLine) + declaration + "\n",
+			Description: description,
+		})
+		return
+	}
+
+	// 右花括号前还有其他内容（例如单行的 constraints { }），把右花括号前的空白替换为换行后的声明。
+	closePos := block.SourceRange.End.StartPos - 1
+	original := ge.sourceMappedProject.OriginalText
+	startPos := len(strings.TrimRight(original[:closePos], " \t"))
+	column := block.SourceRange.End.Column - (closePos - startPos)
+
+	ge.modifications = append(ge.modifications, Modification{
+		Type: ModificationTypeReplace,
+		SourceRange: model.SourceRange{
+			Start: model.SourcePosition{Line: endLine, Column: column, StartPos: startPos, EndPos: closePos, Length: closePos - startPos},
+			End:   model.SourcePosition{Line: endLine, Column: block.SourceRange.End.Column, StartPos: closePos, EndPos: closePos},
+		},
+		OldText:     original[startPos:closePos],
+		NewText:     "\n" + ge.detectBlockIndent(startLine, startLine+1) + declaration + "\n" + leadingWhitespace(lines[startLine-1]),
+		Description: description,
+	})
+}
@@ -0,0 +1,109 @@
+package editor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAddConstraint(t *testing.T) {
+	t.Run("Existing constraints block", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, `dependencies {
+    implementation 'org.foo:bar'
+    constraints {
+        implementation 'org.foo:bar:1.0'
+    }
+}
+`)
+		if err := editor.AddConstraint("com.example", "util", "2.0", ""); err != nil {
+			t.Fatalf("AddConstraint() error = %v", err)
+		}
+		expected := "        implementation 'org.foo:bar:1.0'\n        implementation 'com.example:util:2.0'\n    }\n}"
+		if newText := applyEditorModifications(t, editor); !strings.Contains(newText, expected) {
+			t.Errorf("Constraint should be appended to the constraints block, got:\n%s", newText)
+		}
+	})
+
+	t.Run("New constraints block", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, "dependencies {\n    implementation 'org.foo:bar'\n}\n")
+		if err := editor.AddConstraint("org.foo", "bar", "1.2.3", "implementation"); err != nil {
+			t.Fatalf("AddConstraint() error = %v", err)
+		}
+		expected := "dependencies {\n    implementation 'org.foo:bar'\n\n    constraints {\n        implementation 'org.foo:bar:1.2.3'\n    }\n}\n"
+		if newText := applyEditorModifications(t, editor); newText != expected {
+			t.Errorf("Unexpected result:\n%s", newText)
+		}
+	})
+
+	t.Run("Single-line constraints block", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, "dependencies {\n    constraints { }\n}\n")
+		editor.GetSourceMappedProject().FilePath = "build.gradle.kts"
+		if err := editor.AddConstraint("org.foo", "bar", "1.0", "api"); err != nil {
+			t.Fatalf("AddConstraint() error = %v", err)
+		}
+		expected := "dependencies {\n    constraints {\n        api(\"org.foo:bar:1.0\")\n    }\n}\n"
+		if newText := applyEditorModifications(t, editor); newText != expected {
+			t.Errorf("Unexpected result:\n%s", newText)
+		}
+	})
+
+	t.Run("Existing constraint is updated", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, "dependencies {\n    constraints {\n        implementation 'org.foo:bar:1.0'\n    }\n}\n")
+		if err := editor.AddConstraint("org.foo", "bar", "1.1", "implementation"); err != nil {
+			t.Fatalf("AddConstraint() error = %v", err)
+		}
+		if newText := applyEditorModifications(t, editor); !strings.Contains(newText, "implementation 'org.foo:bar:1.1'\n    }") ||
+			strings.Contains(newText, "1.0") {
+			t.Errorf("Expected the existing constraint to be updated, got:\n%s", newText)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, "plugins {\n    id 'java'\n}\n")
+		if err := editor.AddConstraint("org.foo", "bar", "1.0", ""); !errors.Is(err, ErrDependenciesBlockMissing) {
+			t.Errorf("Expected ErrDependenciesBlockMissing, got %v", err)
+		}
+		if err := editor.AddConstraint("org.foo", "bar", "", ""); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Expected ErrInvalidArgument, got %v", err)
+		}
+	})
+}
+
+func TestUpdateConstraintVersion(t *testing.T) {
+	editor := createRepositoryTestEditor(t, `dependencies {
+    constraints {
+        implementation('org.foo:bar') {
+            version { strictly '1.2.3' }
+        }
+        testImplementation 'org.foo:bar'
+        api "com.example:util:2.0"
+    }
+}
+`)
+	if err := editor.UpdateConstraintVersion("org.foo", "bar", "1.2.4"); err != nil {
+		t.Fatalf("UpdateConstraintVersion() error = %v", err)
+	}
+	if err := editor.UpdateConstraintVersion("com.example", "util", "2.1"); err != nil {
+		t.Fatalf("UpdateConstraintVersion() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	for _, expected := range []string{
+		"version { strictly '1.2.4' }",
+		"testImplementation 'org.foo:bar:1.2.4'",
+		`api "com.example:util:2.1"`,
+	} {
+		if !strings.Contains(newText, expected) {
+			t.Errorf("Expected %q in result:\n%s", expected, newText)
+		}
+	}
+
+	constraint := editor.GetSourceMappedProject().SourceMappedConstraints[0]
+	if constraint.Version != "1.2.4" || constraint.Strictly != "1.2.4" || !strings.Contains(constraint.RawText, "'1.2.4'") {
+		t.Errorf("In-memory constraint not updated: %+v", constraint.DependencyConstraint)
+	}
+
+	if err := editor.UpdateConstraintVersion("org.missing", "lib", "1.0"); !errors.Is(err, ErrConstraintNotFound) {
+		t.Errorf("Expected ErrConstraintNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+// Package editor 提供跨构建文件和settings文件更新项目坐标的功能。
+package editor
+
+import "fmt"
+
+// rootProjectNameKey 是settings文件中设置根项目名称的属性。
+const rootProjectNameKey = "rootProject.name"
+
+// FileModifications 是一个文件中的全部修改。
+type FileModifications struct {
+	FilePath      string         `json:"filePath"`
+	Modifications []Modification `json:"modifications"`
+
+	// Diff 是修改对应的diff，只由 ProjectEditor.Changes 设置。
+	Diff []DiffLine `json:"diff,omitempty"`
+}
+
+// UpdateProjectCoordinates 一次更新项目坐标：在根项目的构建文件中更新或插入 group 和 version，
+// 在settings文件中更新或插入 rootProject.name。参数为空表示不修改对应的坐标；
+// 只修改group和version时settings可以为nil。
+// 修改记录在各自的编辑器中，返回值按构建文件、settings文件的顺序列出有修改的文件。
+func UpdateProjectCoordinates(build, settings *GradleEditor, group, version, name string) ([]*FileModifications, error) {
+	if build == nil || build.sourceMappedProject == nil {
+		return nil, ErrNilProject
+	}
+	if name != "" && (settings == nil || settings.sourceMappedProject == nil) {
+		return nil, fmt.Errorf("%w: settings editor is required to update %s", ErrInvalidArgument, rootProjectNameKey)
+	}
+
+	buildStart := len(build.modifications)
+	for _, property := range []struct{ key, value string }{{"group", group}, {"version", version}} {
+		if property.value == "" {
+			continue
+		}
+		if err := build.UpsertProperty(property.key, property.value); err != nil {
+			return nil, err
+		}
+	}
+
+	changes := make([]*FileModifications, 0, 2)
+	if mods := build.modifications[buildStart:]; len(mods) > 0 {
+		changes = append(changes, &FileModifications{FilePath: build.sourceMappedProject.FilePath, Modifications: append([]Modification(nil), mods...)})
+	}
+
+	if name != "" {
+		settingsStart := len(settings.modifications)
+		if err := settings.UpsertProperty(rootProjectNameKey, name); err != nil {
+			return nil, err
+		}
+		if mods := settings.modifications[settingsStart:]; len(mods) > 0 {
+			changes = append(changes, &FileModifications{FilePath: settings.sourceMappedProject.FilePath, Modifications: append([]Modification(nil), mods...)})
+		}
+	}
+	return changes, nil
+}
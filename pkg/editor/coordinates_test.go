@@ -0,0 +1,67 @@
+package editor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUpdateProjectCoordinates(t *testing.T) {
+	build := createRepositoryTestEditor(t, `plugins {
+    id 'java'
+}
+
+group = 'com.example'
+
+dependencies {
+    implementation 'org.slf4j:slf4j-api:1.7.36'
+}
+`)
+	build.GetSourceMappedProject().FilePath = "build.gradle"
+	settings := createRepositoryTestEditor(t, `pluginManagement {
+    repositories {
+        gradlePluginPortal()
+    }
+}
+
+include ':app'
+`)
+	settings.GetSourceMappedProject().FilePath = "settings.gradle"
+
+	changes, err := UpdateProjectCoordinates(build, settings, "org.acme", "2.0.0", "acme")
+	if err != nil {
+		t.Fatalf("UpdateProjectCoordinates() error = %v", err)
+	}
+	if len(changes) != 2 || changes[0].FilePath != "build.gradle" || len(changes[0].Modifications) != 2 ||
+		changes[1].FilePath != "settings.gradle" || len(changes[1].Modifications) != 1 {
+		t.Fatalf("Unexpected changes %+v", changes)
+	}
+
+	if newText := applyEditorModifications(t, build); !strings.Contains(newText, "group = 'org.acme'\nversion = '2.0.0'\n") {
+		t.Errorf("Expected group and version to be updated, got:\n%s", newText)
+	}
+	// rootProject.name 必须插入到 pluginManagement 块之后。
+	if newText := applyEditorModifications(t, settings); !strings.Contains(newText, "}\n\nrootProject.name = 'acme'\n\ninclude ':app'") {
+		t.Errorf("Expected rootProject.name after pluginManagement, got:\n%s", newText)
+	}
+}
+
+func TestUpdateProjectCoordinatesPartial(t *testing.T) {
+	build := createRepositoryTestEditor(t, "group = 'com.example'\nversion = '1.0.0'\n")
+	settings := createRepositoryTestEditor(t, "rootProject.name = 'demo'\n")
+
+	changes, err := UpdateProjectCoordinates(build, settings, "", "1.1.0", "demo")
+	if err != nil {
+		t.Fatalf("UpdateProjectCoordinates() error = %v", err)
+	}
+	if len(changes) != 1 || len(changes[0].Modifications) != 1 || len(settings.GetModifications()) != 0 {
+		t.Errorf("Expected only the version to change, got %+v", changes)
+	}
+
+	if _, err := UpdateProjectCoordinates(build, nil, "", "", "demo"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument without settings editor, got %v", err)
+	}
+	if _, err := UpdateProjectCoordinates(nil, settings, "org.acme", "", ""); !errors.Is(err, ErrNilProject) {
+		t.Errorf("Expected ErrNilProject without build editor, got %v", err)
+	}
+}
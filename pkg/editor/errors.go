@@ -0,0 +1,37 @@
+// Package editor 定义编辑器返回的错误。
+package editor
+
+import "errors"
+
+// 编辑器返回的错误都包装了以下哨兵错误之一，调用方可以用 errors.Is 判断错误类别，
+// 而不必匹配错误消息。
+var (
+	// ErrNilProject 表示编辑器没有关联的源码映射项目。
+	ErrNilProject = errors.New("source mapped project is nil")
+	// ErrDependencyNotFound 表示要修改的依赖不存在。
+	ErrDependencyNotFound = errors.New("dependency not found")
+	// ErrPluginNotFound 表示要修改的插件不存在。
+	ErrPluginNotFound = errors.New("plugin not found")
+	// ErrPropertyNotFound 表示要修改的属性不存在。
+	ErrPropertyNotFound = errors.New("property not found")
+	// ErrConstraintNotFound 表示要修改的依赖约束不存在。
+	ErrConstraintNotFound = errors.New("dependency constraint not found")
+	// ErrRepositoryNotFound 表示要修改的仓库不存在。
+	ErrRepositoryNotFound = errors.New("repository not found")
+	// ErrAlreadyExists 表示要添加的声明已经存在，编辑器没有生成修改，重复运行的自动化可以把它当作成功。
+	ErrAlreadyExists = errors.New("declaration already exists")
+	// ErrDependenciesBlockMissing 表示文件中没有完整的顶层 dependencies 块。
+	ErrDependenciesBlockMissing = errors.New("dependencies block not found")
+	// ErrRepositoriesBlockMissing 表示文件中没有完整的顶层 repositories 块。
+	ErrRepositoriesBlockMissing = errors.New("repositories block not found")
+	// ErrInvalidRange 表示修改操作的源码范围超出文本或起止位置颠倒。
+	ErrInvalidRange = errors.New("invalid source range")
+	// ErrTextMismatch 表示替换操作的原文本与源码中的内容不一致。
+	ErrTextMismatch = errors.New("text mismatch")
+	// ErrInvalidArgument 表示调用参数不合法，例如空的属性名或非法的升级规则。
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrTransactionInProgress 表示已有事务尚未提交或回滚。
+	ErrTransactionInProgress = errors.New("transaction already in progress")
+	// ErrNoTransaction 表示当前没有进行中的事务。
+	ErrNoTransaction = errors.New("no transaction in progress")
+)
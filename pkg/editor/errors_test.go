@@ -0,0 +1,75 @@
+package editor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEditorErrors(t *testing.T) {
+	content := `plugins {
+    id 'java'
+}
+
+version = '1.0.0'
+
+repositories {
+    mavenCentral()
+}
+
+dependencies {
+    implementation 'com.google.guava:guava:31.1-jre'
+}
+`
+	editor := createRepositoryTestEditor(t, content)
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"missing dependency", editor.UpdateDependencyVersion("org.example", "missing", "1.0"), ErrDependencyNotFound},
+		{"missing plugin", editor.UpdatePluginVersion("org.example.missing", "1.0"), ErrPluginNotFound},
+		{"missing property", editor.UpdateProperty("missing", "1.0"), ErrPropertyNotFound},
+		{"missing repository", editor.RemoveRepository("https://missing.example.com"), ErrRepositoryNotFound},
+		{"existing dependency", editor.AddDependency("com.google.guava", "guava", "31.1-jre", ""), ErrAlreadyExists},
+		{"existing repository", editor.AddRepository("", "https://repo1.maven.org/maven2/"), ErrAlreadyExists},
+		{"empty property key", editor.UpsertProperty("", "1.0"), ErrInvalidArgument},
+		{"commit without transaction", editor.Commit(), ErrNoTransaction},
+		{"nil project", NewGradleEditor(nil).UpdateDependencyVersion("a", "b", "1.0"), ErrNilProject},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, tt.err)
+		}
+	}
+
+	noBlocks := createRepositoryTestEditor(t, "version = '1.0.0'\n")
+	if err := noBlocks.AddDependency("org.example", "lib", "1.0", "implementation"); !errors.Is(err, ErrDependenciesBlockMissing) {
+		t.Errorf("Expected ErrDependenciesBlockMissing, got %v", err)
+	}
+	if err := noBlocks.AddRepository("mavenCentral", ""); !errors.Is(err, ErrRepositoriesBlockMissing) {
+		t.Errorf("Expected ErrRepositoriesBlockMissing, got %v", err)
+	}
+}
+
+func TestSerializerErrors(t *testing.T) {
+	serializer := NewGradleSerializer("version = '1.0.0'\n")
+
+	outOfRange := Modification{Type: ModificationTypeDelete}
+	outOfRange.SourceRange.Start.StartPos = 5
+	outOfRange.SourceRange.End.StartPos = 100
+	if _, err := serializer.ApplyModifications([]Modification{outOfRange}); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange, got %v", err)
+	}
+
+	mismatch := Modification{Type: ModificationTypeReplace, OldText: "group", NewText: "name"}
+	mismatch.SourceRange.End.StartPos = 7
+	if _, err := serializer.ApplyModifications([]Modification{mismatch}); !errors.Is(err, ErrTextMismatch) {
+		t.Errorf("Expected ErrTextMismatch, got %v", err)
+	}
+
+	errs := serializer.ValidateModifications([]Modification{outOfRange, mismatch})
+	if len(errs) != 2 || !errors.Is(errs[0], ErrInvalidRange) || !errors.Is(errs[1], ErrTextMismatch) {
+		t.Errorf("Expected ErrInvalidRange and ErrTextMismatch from validation, got %v", errs)
+	}
+}
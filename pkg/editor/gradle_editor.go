@@ -13,6 +13,14 @@ import (
 type GradleEditor struct {
 	sourceMappedProject *model.SourceMappedProject
 	modifications       []Modification
+
+	// 事务快照，为nil表示当前不在事务中。
+	snapshot *editorSnapshot
+
+	// 创建编辑器时从文件推断的生成文本风格。
+	detectedStyle EditorStyle
+	// 显式设置的生成文本风格，为nil时使用 detectedStyle。
+	editorStyle *EditorStyle
 }
 
 // Modification 表示一个修改操作。
@@ -38,6 +46,7 @@ func NewGradleEditor(sourceMappedProject *model.SourceMappedProject) *GradleEdit
 	return &GradleEditor{
 		sourceMappedProject: sourceMappedProject,
 		modifications:       make([]Modification, 0),
+		detectedStyle:       DetectEditorStyle(sourceMappedProject),
 	}
 }
 
@@ -45,7 +54,7 @@ func NewGradleEditor(sourceMappedProject *model.SourceMappedProject) *GradleEdit
 func (ge *GradleEditor) UpdateDependencyVersion(group, name, newVersion string) error {
 	// 检查项目是否为nil。
 	if ge.sourceMappedProject == nil {
-		return fmt.Errorf("source mapped project is nil")
+		return ErrNilProject
 	}
 
 	// 查找匹配的依赖。
@@ -58,28 +67,39 @@ func (ge *GradleEditor) UpdateDependencyVersion(group, name, newVersion string)
 	}
 
 	if targetDep == nil {
-		return fmt.Errorf("dependency %s:%s not found", group, name)
+		return fmt.Errorf("%w: %s:%s", ErrDependencyNotFound, group, name)
 	}
 
+	ge.updateDependencyVersion(targetDep, newVersion)
+
+	return nil
+}
+
+// updateDependencyVersion 为指定依赖生成版本更新修改。
+func (ge *GradleEditor) updateDependencyVersion(targetDep *model.SourceMappedDependency, newVersion string) {
+	group, name := targetDep.Group, targetDep.Name
+
 	// 如果当前版本和新版本相同，不需要修改。
 	if targetDep.Version == newVersion {
-		return nil
+		return
 	}
 
 	// 生成新的依赖声明。
 	var newText string
 	if targetDep.Version == "" {
-		// 原来没有版本号，需要添加版本号。
-		if strings.Contains(targetDep.RawText, "'") {
-			newText = fmt.Sprintf("'%s:%s:%s'", group, name, newVersion)
-		} else {
-			newText = fmt.Sprintf("\"%s:%s:%s\"", group, name, newVersion)
+		// 原来没有版本号，需要添加版本号，引号与原来的坐标保持一致。
+		coordinate := fmt.Sprintf("%s:%s:%s", group, name, newVersion)
+		switch {
+		case strings.Contains(targetDep.RawText, "'"):
+			newText = "'" + coordinate + "'"
+		case strings.Contains(targetDep.RawText, "\""):
+			newText = "\"" + coordinate + "\""
+		default:
+			newText = ge.quoted(coordinate)
 		}
 	} else {
 		// 替换现有版本号。
-		oldVersionPattern := regexp.QuoteMeta(targetDep.Version)
-		re := regexp.MustCompile(oldVersionPattern)
-		newText = re.ReplaceAllString(targetDep.RawText, newVersion)
+		newText = replaceDependencyVersion(targetDep.RawText, targetDep.Version, newVersion)
 	}
 
 	// 创建修改操作。
@@ -96,15 +116,13 @@ func (ge *GradleEditor) UpdateDependencyVersion(group, name, newVersion string)
 	// 更新内存中的依赖信息。
 	targetDep.Version = newVersion
 	targetDep.RawText = newText
-
-	return nil
 }
 
 // UpdatePluginVersion 更新插件版本。
 func (ge *GradleEditor) UpdatePluginVersion(pluginId, newVersion string) error {
 	// 检查项目是否为nil。
 	if ge.sourceMappedProject == nil {
-		return fmt.Errorf("source mapped project is nil")
+		return ErrNilProject
 	}
 
 	// 查找匹配的插件。
@@ -117,7 +135,7 @@ func (ge *GradleEditor) UpdatePluginVersion(pluginId, newVersion string) error {
 	}
 
 	if targetPlugin == nil {
-		return fmt.Errorf("plugin %s not found", pluginId)
+		return fmt.Errorf("%w: %s", ErrPluginNotFound, pluginId)
 	}
 
 	// 如果当前版本和新版本相同，不需要修改。
@@ -128,12 +146,8 @@ func (ge *GradleEditor) UpdatePluginVersion(pluginId, newVersion string) error {
 	// 生成新的插件声明。
 	var newText string
 	if targetPlugin.Version == "" {
-		// 原来没有版本号，需要添加版本号。
-		if strings.Contains(targetPlugin.RawText, "'") {
-			newText = fmt.Sprintf("id '%s' version '%s'", pluginId, newVersion)
-		} else {
-			newText = fmt.Sprintf("id \"%s\" version \"%s\"", pluginId, newVersion)
-		}
+		// 原来没有版本号，在插件ID之后添加版本号，保留 apply false 等后续内容。
+		newText = insertPluginVersion(targetPlugin.RawText, newVersion)
 	} else {
 		// 替换现有版本号。
 		oldVersionPattern := regexp.QuoteMeta(targetPlugin.Version)
@@ -163,7 +177,7 @@ func (ge *GradleEditor) UpdatePluginVersion(pluginId, newVersion string) error {
 func (ge *GradleEditor) UpdateProperty(key, newValue string) error {
 	// 检查项目是否为nil。
 	if ge.sourceMappedProject == nil {
-		return fmt.Errorf("source mapped project is nil")
+		return ErrNilProject
 	}
 
 	// 查找匹配的属性。
@@ -176,20 +190,29 @@ func (ge *GradleEditor) UpdateProperty(key, newValue string) error {
 	}
 
 	if targetProperty == nil {
-		return fmt.Errorf("property %s not found", key)
+		return fmt.Errorf("%w: %s", ErrPropertyNotFound, key)
 	}
 
+	ge.updateProperty(targetProperty, newValue)
+
+	return nil
+}
+
+// updateProperty 为指定属性生成值更新修改。
+func (ge *GradleEditor) updateProperty(targetProperty *model.SourceMappedProperty, newValue string) {
+	key := targetProperty.Key
+
 	// 如果当前值和新值相同，不需要修改。
 	if targetProperty.Value == newValue {
-		return nil
+		return
 	}
 
-	// 生成新的属性声明。
+	// 生成新的属性声明，引号与原来的声明保持一致。
 	var newText string
 	if strings.Contains(targetProperty.RawText, "'") {
-		newText = fmt.Sprintf("%s = '%s'", key, newValue)
+		newText = ge.assignment(key, "'"+newValue+"'")
 	} else {
-		newText = fmt.Sprintf("%s = \"%s\"", key, newValue)
+		newText = ge.assignment(key, "\""+newValue+"\"")
 	}
 
 	// 创建修改操作。
@@ -206,74 +229,11 @@ func (ge *GradleEditor) UpdateProperty(key, newValue string) error {
 	// 更新内存中的属性信息。
 	targetProperty.Value = newValue
 	targetProperty.RawText = newText
-
-	return nil
 }
 
-// AddDependency 添加新依赖。
+// AddDependency 添加新依赖，使用默认的插入选项。
 func (ge *GradleEditor) AddDependency(group, name, version, scope string) error {
-	// 检查项目是否为nil。
-	if ge.sourceMappedProject == nil {
-		return fmt.Errorf("source mapped project is nil")
-	}
-
-	// 查找dependencies块的位置。
-	dependenciesBlockLine := ge.findDependenciesBlock()
-	if dependenciesBlockLine == -1 {
-		return fmt.Errorf("dependencies block not found")
-	}
-
-	// 生成新的依赖声明。
-	var newText string
-	if scope == "" {
-		scope = "implementation"
-	}
-
-	if version != "" {
-		newText = fmt.Sprintf("    %s '%s:%s:%s'", scope, group, name, version)
-	} else {
-		newText = fmt.Sprintf("    %s '%s:%s'", scope, group, name)
-	}
-
-	// 找到插入位置（dependencies块的最后一行之前）。
-	insertLine := ge.findDependenciesBlockEnd(dependenciesBlockLine)
-	if insertLine == -1 {
-		return fmt.Errorf("could not find dependencies block end")
-	}
-
-	// 计算插入位置。
-	insertPos := 0
-	for i := 0; i < insertLine-1; i++ {
-		insertPos += len(ge.sourceMappedProject.Lines[i]) + 1 // +1 for newline。
-	}
-
-	// 创建插入操作。
-	modification := Modification{
-		Type: ModificationTypeInsert,
-		SourceRange: model.SourceRange{
-			Start: model.SourcePosition{
-				Line:     insertLine,
-				Column:   1,
-				StartPos: insertPos,
-				EndPos:   insertPos,
-				Length:   0,
-			},
-			End: model.SourcePosition{
-				Line:     insertLine,
-				Column:   1,
-				StartPos: insertPos,
-				EndPos:   insertPos,
-				Length:   0,
-			},
-		},
-		OldText:     "",
-		NewText:     newText + "\n",
-		Description: fmt.Sprintf("Add dependency %s:%s:%s with scope %s", group, name, version, scope),
-	}
-
-	ge.modifications = append(ge.modifications, modification)
-
-	return nil
+	return ge.AddDependencyWithOptions(group, name, version, scope, DefaultInsertOptions())
 }
 
 // GetModifications 获取所有修改操作。
@@ -291,44 +251,25 @@ func (ge *GradleEditor) ClearModifications() {
 	ge.modifications = make([]Modification, 0)
 }
 
-// findDependenciesBlock 查找dependencies块的起始行。
-func (ge *GradleEditor) findDependenciesBlock() int {
-	if ge.sourceMappedProject == nil {
-		return -1
-	}
+// replaceDependencyVersion 替换依赖声明中的版本号。
+// 优先替换 version: 'x' 形式或坐标末尾的 :x，避免误改group或name中相同的文本。
+func replaceDependencyVersion(rawText, oldVersion, newVersion string) string {
+	quotedOld := regexp.QuoteMeta(oldVersion)
 
-	for i, line := range ge.sourceMappedProject.Lines {
-		if strings.Contains(strings.TrimSpace(line), "dependencies") && strings.Contains(line, "{") {
-			return i + 1 // 返回1-based行号。
-		}
+	mapVersionRegex := regexp.MustCompile(`(version\s*[:=]\s*['"])` + quotedOld + `(['"])`)
+	if mapVersionRegex.MatchString(rawText) {
+		return mapVersionRegex.ReplaceAllString(rawText, "${1}"+escapeReplacement(newVersion)+"${2}")
 	}
-	return -1
-}
 
-// findDependenciesBlockEnd 查找dependencies块的结束行。
-func (ge *GradleEditor) findDependenciesBlockEnd(startLine int) int {
-	if ge.sourceMappedProject == nil {
-		return -1
+	if idx := strings.LastIndex(rawText, ":"+oldVersion); idx != -1 {
+		start := idx + 1
+		return rawText[:start] + newVersion + rawText[start+len(oldVersion):]
 	}
 
-	braceCount := 0
-	started := false
-
-	for i := startLine - 1; i < len(ge.sourceMappedProject.Lines); i++ {
-		line := strings.TrimSpace(ge.sourceMappedProject.Lines[i])
-
-		if strings.Contains(line, "{") {
-			braceCount++
-			started = true
-		}
-
-		if strings.Contains(line, "}") {
-			braceCount--
-			if started && braceCount == 0 {
-				return i + 1 // 返回1-based行号。
-			}
-		}
-	}
+	return regexp.MustCompile(quotedOld).ReplaceAllString(rawText, escapeReplacement(newVersion))
+}
 
-	return -1
+// escapeReplacement 转义正则替换文本中的 $ 符号。
+func escapeReplacement(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
 }
@@ -520,3 +520,40 @@ func TestGradleEditorEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestGradleEditor_UpdateMultiLineDependencyVersion(t *testing.T) {
+	content := `dependencies {
+    implementation group: 'org.foo', name: 'bar-1.2.3',
+        version: '1.2.3'
+    implementation 'com.example1:lib:1'
+}
+`
+	result, err := parser.NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("Failed to parse content: %v", err)
+	}
+	editor := NewGradleEditor(result.SourceMappedProject)
+
+	if err := editor.UpdateDependencyVersion("org.foo", "bar-1.2.3", "2.0.0"); err != nil {
+		t.Fatalf("UpdateDependencyVersion() error = %v", err)
+	}
+	if err := editor.UpdateDependencyVersion("com.example1", "lib", "2"); err != nil {
+		t.Fatalf("UpdateDependencyVersion() error = %v", err)
+	}
+
+	serializer := NewGradleSerializer(content)
+	newText, err := serializer.ApplyModifications(editor.GetModifications())
+	if err != nil {
+		t.Fatalf("ApplyModifications() error = %v", err)
+	}
+
+	expected := `dependencies {
+    implementation group: 'org.foo', name: 'bar-1.2.3',
+        version: '2.0.0'
+    implementation 'com.example1:lib:2'
+}
+`
+	if newText != expected {
+		t.Errorf("Unexpected result:\n%s", newText)
+	}
+}
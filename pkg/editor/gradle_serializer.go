@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
 )
 
 // GradleSerializer 最小diff序列化器。
+// 编辑器生成的新文本统一使用 \n 换行，应用修改时转换为原始文本的换行风格，CRLF文件编辑后仍使用CRLF。
 type GradleSerializer struct {
 	originalText string
 	lines        []string
+	lineEnding   string
 }
 
 // NewGradleSerializer 创建新的序列化器。
@@ -18,6 +22,7 @@ func NewGradleSerializer(originalText string) *GradleSerializer {
 	return &GradleSerializer{
 		originalText: originalText,
 		lines:        strings.Split(originalText, "\n"),
+		lineEnding:   model.DetectLineEnding(originalText),
 	}
 }
 
@@ -57,7 +62,7 @@ func (gs *GradleSerializer) applyModification(text string, mod Modification) (st
 	case ModificationTypeDelete:
 		return gs.applyDelete(text, mod)
 	default:
-		return "", fmt.Errorf("unknown modification type: %s", mod.Type)
+		return "", fmt.Errorf("%w: unknown modification type %s", ErrInvalidArgument, mod.Type)
 	}
 }
 
@@ -67,7 +72,7 @@ func (gs *GradleSerializer) applyReplace(text string, mod Modification) (string,
 	endPos := mod.SourceRange.End.StartPos
 
 	if startPos < 0 || endPos > len(text) || startPos > endPos {
-		return "", fmt.Errorf("invalid source range for replace operation")
+		return "", fmt.Errorf("%w for replace operation", ErrInvalidRange)
 	}
 
 	// 验证要替换的文本是否匹配。
@@ -82,13 +87,13 @@ func (gs *GradleSerializer) applyReplace(text string, mod Modification) (string,
 			if relativePos != -1 {
 				actualStartPos := lineStart + relativePos
 				actualEndPos := actualStartPos + len(mod.OldText)
-				return text[:actualStartPos] + mod.NewText + text[actualEndPos:], nil
+				return text[:actualStartPos] + gs.withLineEnding(mod.NewText) + text[actualEndPos:], nil
 			}
 		}
-		return "", fmt.Errorf("text mismatch: expected '%s', got '%s'", mod.OldText, actualText)
+		return "", fmt.Errorf("%w: expected '%s', got '%s'", ErrTextMismatch, mod.OldText, actualText)
 	}
 
-	return text[:startPos] + mod.NewText + text[endPos:], nil
+	return text[:startPos] + gs.withLineEnding(mod.NewText) + text[endPos:], nil
 }
 
 // applyInsert 应用插入操作。
@@ -96,10 +101,18 @@ func (gs *GradleSerializer) applyInsert(text string, mod Modification) (string,
 	insertPos := mod.SourceRange.Start.StartPos
 
 	if insertPos < 0 || insertPos > len(text) {
-		return "", fmt.Errorf("invalid insert position")
+		return "", fmt.Errorf("%w: invalid insert position %d", ErrInvalidRange, insertPos)
 	}
 
-	return text[:insertPos] + mod.NewText + text[insertPos:], nil
+	return text[:insertPos] + gs.withLineEnding(mod.NewText) + text[insertPos:], nil
+}
+
+// withLineEnding 把新文本中的换行转换为原始文本的换行风格。
+func (gs *GradleSerializer) withLineEnding(text string) string {
+	if gs.lineEnding != model.LineEndingCRLF || !strings.Contains(text, "\n") {
+		return text
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n")
 }
 
 // applyDelete 应用删除操作。
@@ -108,7 +121,7 @@ func (gs *GradleSerializer) applyDelete(text string, mod Modification) (string,
 	endPos := mod.SourceRange.End.StartPos
 
 	if startPos < 0 || endPos > len(text) || startPos > endPos {
-		return "", fmt.Errorf("invalid source range for delete operation")
+		return "", fmt.Errorf("%w for delete operation", ErrInvalidRange)
 	}
 
 	return text[:startPos] + text[endPos:], nil
@@ -224,19 +237,19 @@ func (gs *GradleSerializer) ValidateModifications(modifications []Modification)
 	for i, mod := range modifications {
 		// 检查位置范围。
 		if mod.SourceRange.Start.StartPos < 0 {
-			errors = append(errors, fmt.Errorf("modification %d: invalid start position %d", i, mod.SourceRange.Start.StartPos))
+			errors = append(errors, fmt.Errorf("modification %d: %w: start position %d", i, ErrInvalidRange, mod.SourceRange.Start.StartPos))
 		}
 
 		if mod.SourceRange.End.StartPos > len(gs.originalText) {
 			errors = append(errors, fmt.Errorf(
-				"modification %d: end position %d exceeds text length %d",
-				i, mod.SourceRange.End.StartPos, len(gs.originalText)))
+				"modification %d: %w: end position %d exceeds text length %d",
+				i, ErrInvalidRange, mod.SourceRange.End.StartPos, len(gs.originalText)))
 		}
 
 		if mod.SourceRange.Start.StartPos > mod.SourceRange.End.StartPos {
 			errors = append(errors, fmt.Errorf(
-				"modification %d: start position %d > end position %d",
-				i, mod.SourceRange.Start.StartPos, mod.SourceRange.End.StartPos))
+				"modification %d: %w: start position %d > end position %d",
+				i, ErrInvalidRange, mod.SourceRange.Start.StartPos, mod.SourceRange.End.StartPos))
 		}
 
 		// 检查替换操作的文本匹配。
@@ -248,8 +261,8 @@ func (gs *GradleSerializer) ValidateModifications(modifications []Modification)
 				actualText := gs.originalText[startPos:endPos]
 				if actualText != mod.OldText {
 					errors = append(errors, fmt.Errorf(
-						"modification %d: text mismatch, expected '%s', got '%s'",
-						i, mod.OldText, actualText))
+						"modification %d: %w: expected '%s', got '%s'",
+						i, ErrTextMismatch, mod.OldText, actualText))
 				}
 			}
 		}
@@ -391,3 +391,72 @@ func TestGradleSerializer_GetModificationSummary(t *testing.T) {
 		t.Errorf("Expected 4 descriptions, got %d", len(summary.Descriptions))
 	}
 }
+
+func TestGradleSerializer_PreservesCRLF(t *testing.T) {
+	content := strings.ReplaceAll(testSerializerContent, "\n", "\r\n")
+	editor := createRepositoryTestEditor(t, content)
+	if err := editor.UpdateProperty("version", "1.0.0"); err != nil {
+		t.Fatalf("UpdateProperty() error = %v", err)
+	}
+	if err := editor.AddDependency("org.slf4j", "slf4j-api", "2.0.9", "implementation"); err != nil {
+		t.Fatalf("AddDependency() error = %v", err)
+	}
+	if err := editor.UpsertProperty("description", "Demo"); err != nil {
+		t.Fatalf("UpsertProperty() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	expected := strings.ReplaceAll(`plugins {
+    id 'java'
+    id 'org.springframework.boot' version '2.7.0'
+}
+
+group = 'com.example'
+version = '1.0.0'
+description = 'Demo'
+
+dependencies {
+    implementation 'mysql:mysql-connector-java:8.0.29'
+    implementation 'com.google.guava:guava:31.0-jre'
+    implementation 'org.slf4j:slf4j-api:2.0.9'
+}
+`, "\n", "\r\n")
+	if newText != expected {
+		t.Errorf("Line endings not preserved, got:\n%q", newText)
+	}
+
+	// LF文件中的新文本保持不变。
+	serializer := NewGradleSerializer("a\nb\n")
+	result, err := serializer.ApplyModifications([]Modification{
+		{Type: ModificationTypeInsert, SourceRange: model.SourceRange{Start: model.SourcePosition{StartPos: 2}}, NewText: "x\n"},
+	})
+	if err != nil || result != "a\nx\nb\n" {
+		t.Errorf("ApplyModifications() = %q, %v", result, err)
+	}
+}
+
+func TestGradleSerializer_MultiByteContent(t *testing.T) {
+	content := `description = '构建脚本'
+// 依赖版本
+dependencies {
+    /* 日志 */ implementation 'org.slf4j:slf4j-api:1.7.36' // 说明
+}
+`
+	editor := createRepositoryTestEditor(t, content)
+	if err := editor.UpdateDependencyVersion("org.slf4j", "slf4j-api", "2.0.9"); err != nil {
+		t.Fatalf("UpdateDependencyVersion() error = %v", err)
+	}
+	if err := editor.UpdateProperty("description", "示例"); err != nil {
+		t.Fatalf("UpdateProperty() error = %v", err)
+	}
+
+	expected := `description = '示例'
+// 依赖版本
+dependencies {
+    /* 日志 */ implementation 'org.slf4j:slf4j-api:2.0.9' // 说明
+}
+`
+	if newText := applyEditorModifications(t, editor); newText != expected {
+		t.Errorf("Unexpected result:\n%s", newText)
+	}
+}
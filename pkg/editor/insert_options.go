@@ -0,0 +1,262 @@
+// Package editor 提供保持格式的插入策略。
+package editor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InsertOptions 控制新声明的插入方式。
+type InsertOptions struct {
+	// Indent 指定缩进字符串，为空时根据文件已有的缩进自动检测。
+	Indent string
+	// GroupByScope 为true时插入到同范围依赖之后，测试依赖放在最后。
+	GroupByScope bool
+	// Comment 附加在新声明后的行尾注释，为空时不添加。
+	Comment string
+	// Alphabetical 为true时在同范围的依赖中按 group:name 的字母顺序插入，隐含 GroupByScope。
+	Alphabetical bool
+	// CreateScopeSection 为true时，如果块中还没有该范围的依赖，则新建一个以注释标题开头、
+	// 前后用空行分隔的分组，隐含 GroupByScope。
+	CreateScopeSection bool
+	// SectionHeader 是新分组的注释标题，其中的 {scope} 会被替换为配置名称，为空时使用 "// {scope}"。
+	SectionHeader string
+}
+
+// DefaultInsertOptions 返回默认的插入选项：自动检测缩进，插入到块末尾。
+func DefaultInsertOptions() InsertOptions {
+	return InsertOptions{}
+}
+
+// AddDependencyWithOptions 按指定的插入选项添加新依赖。
+// 已有相同坐标、版本和配置的依赖声明时不生成修改，返回包装了 ErrAlreadyExists 的错误。
+func (ge *GradleEditor) AddDependencyWithOptions(group, name, version, scope string, opts InsertOptions) error {
+	// 检查项目是否为nil。
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+
+	if scope == "" {
+		scope = "implementation"
+	}
+	for _, dep := range ge.sourceMappedProject.SourceMappedDependencies {
+		if dep.Group == group && dep.Name == name && dep.Version == version && dep.Scope == scope {
+			return fmt.Errorf("%w: dependency %s:%s:%s with scope %s", ErrAlreadyExists, group, name, version, scope)
+		}
+	}
+
+	// 查找dependencies块的位置。
+	blockStart, blockEnd := ge.findTopLevelBlock("dependencies")
+	if blockStart == -1 {
+		return ErrDependenciesBlockMissing
+	}
+	if blockEnd == -1 {
+		return fmt.Errorf("%w: could not find block end", ErrDependenciesBlockMissing)
+	}
+
+	// 生成新的依赖声明。
+	indent := opts.Indent
+	if indent == "" {
+		indent = ge.detectBlockIndent(blockStart, blockEnd)
+	}
+	newText := indent + ge.formatDependency(group, name, version, scope)
+	if opts.Comment != "" {
+		newText += " // " + opts.Comment
+	}
+
+	newText += "\n"
+
+	// 找到插入位置。
+	insertLine := blockEnd
+	if opts.GroupByScope || opts.Alphabetical || opts.CreateScopeSection {
+		sortKey := ""
+		if opts.Alphabetical {
+			sortKey = group + ":" + name
+		}
+		var scopeExists bool
+		insertLine, scopeExists = ge.findScopeInsertLine(blockStart, blockEnd, scope, sortKey)
+		if opts.CreateScopeSection && !scopeExists {
+			newText = ge.scopeSection(blockStart, blockEnd, insertLine, indent, scope, opts.SectionHeader, newText)
+		}
+	}
+
+	// 创建插入操作，单行的块在闭合的 } 之前插入。
+	modification := ge.blockInsertion(blockStart, blockEnd, insertLine, newText)
+	modification.Description = fmt.Sprintf("Add dependency %s:%s:%s with scope %s", group, name, version, scope)
+
+	ge.modifications = append(ge.modifications, modification)
+
+	return nil
+}
+
+// formatDependency 按文件的DSL和引号风格生成依赖声明（不含缩进）。
+func (ge *GradleEditor) formatDependency(group, name, version, scope string) string {
+	coordinate := group + ":" + name
+	if version != "" {
+		coordinate += ":" + version
+	}
+
+	if ge.isKotlinDSL() || ge.Style().ParenthesizedCalls {
+		return fmt.Sprintf("%s(%s)", scope, ge.quoted(coordinate))
+	}
+
+	return scope + " " + ge.quoted(coordinate)
+}
+
+// dependencyDeclaration 表示dependencies块中的一条声明，带闭包的声明跨越多行。
+type dependencyDeclaration struct {
+	scope     string
+	startLine int
+	endLine   int
+	sortKey   string // 坐标的 group:name，不是字符串坐标时为配置名之后的文本。
+}
+
+// scanDeclarations 返回块中的所有依赖声明，行号均为1-based。
+func (ge *GradleEditor) scanDeclarations(blockStart, blockEnd int) []dependencyDeclaration {
+	lines := ge.sourceMappedProject.Lines
+	declarations := make([]dependencyDeclaration, 0)
+
+	for lineNumber := blockStart + 1; lineNumber < blockEnd; lineNumber++ {
+		trimmed := strings.TrimSpace(lines[lineNumber-1])
+		declScope := declarationScope(trimmed)
+		if declScope == "" {
+			continue
+		}
+
+		// 带闭包的多行声明，跳到闭包结束行。
+		endLine := lineNumber
+		if strings.Count(trimmed, "{") > strings.Count(trimmed, "}") {
+			if end := ge.findBlockEnd(lineNumber); end != -1 && end < blockEnd {
+				endLine = end
+			}
+		}
+
+		declarations = append(declarations, dependencyDeclaration{
+			scope:     declScope,
+			startLine: lineNumber,
+			endLine:   endLine,
+			sortKey:   declarationSortKey(trimmed[len(declScope):]),
+		})
+		lineNumber = endLine
+	}
+	return declarations
+}
+
+// findScopeInsertLine 查找依赖的插入行（1-based），并返回块中是否已有同范围的依赖。
+// 优先放在同范围的最后一个依赖之后，sortKey不为空时放在同范围中第一个排在它后面的依赖之前；
+// 没有同范围依赖时，非测试依赖放在测试依赖之前，测试依赖放在块末尾。
+func (ge *GradleEditor) findScopeInsertLine(blockStart, blockEnd int, scope, sortKey string) (int, bool) {
+	lastSameScope := -1
+	lastMainScope := -1
+	firstTestScope := -1
+
+	for _, decl := range ge.scanDeclarations(blockStart, blockEnd) {
+		switch {
+		case decl.scope == scope:
+			if sortKey != "" && strings.ToLower(decl.sortKey) > strings.ToLower(sortKey) {
+				return decl.startLine, true
+			}
+			lastSameScope = decl.endLine
+		case isTestScope(decl.scope):
+			if firstTestScope == -1 {
+				firstTestScope = decl.startLine
+			}
+		default:
+			lastMainScope = decl.endLine
+		}
+	}
+
+	switch {
+	case lastSameScope != -1:
+		return lastSameScope + 1, true
+	case isTestScope(scope):
+		return blockEnd, false
+	case lastMainScope != -1:
+		return lastMainScope + 1, false
+	case firstTestScope != -1:
+		return firstTestScope, false
+	default:
+		return blockEnd, false
+	}
+}
+
+// scopeSection 把新声明包装为一个新的范围分组：注释标题在前，与前后的声明之间各有一个空行。
+func (ge *GradleEditor) scopeSection(blockStart, blockEnd, insertLine int, indent, scope, header, declaration string) string {
+	lines := ge.sourceMappedProject.Lines
+	if header == "" {
+		header = "// {scope}"
+	}
+
+	section := indent + strings.ReplaceAll(header, "{scope}", scope) + "\n" + declaration
+	if insertLine-1 > blockStart && strings.TrimSpace(lines[insertLine-2]) != "" {
+		section = "\n" + section
+	}
+	if insertLine < blockEnd && strings.TrimSpace(lines[insertLine-1]) != "" {
+		section += "\n"
+	}
+	return section
+}
+
+// declarationSortKey 返回声明参数的排序键：字符串坐标取 group:name，其他形式取去掉括号和空白后的文本。
+func declarationSortKey(args string) string {
+	args = strings.TrimLeft(args, " \t(")
+	if args != "" && (args[0] == '\'' || args[0] == '"') {
+		if end := strings.IndexByte(args[1:], args[0]); end != -1 {
+			args = args[1 : end+1]
+		}
+		parts := strings.SplitN(args, ":", 3)
+		if len(parts) >= 2 {
+			return parts[0] + ":" + parts[1]
+		}
+	}
+	return strings.TrimSpace(args)
+}
+
+// detectBlockIndent 检测块内声明使用的缩进，块为空时使用风格中的缩进单位。
+// 显式设置了风格时总是在块的缩进上加一级风格中的缩进。
+func (ge *GradleEditor) detectBlockIndent(blockStart, blockEnd int) string {
+	lines := ge.sourceMappedProject.Lines
+	blockIndent := leadingWhitespace(lines[blockStart-1])
+	if ge.editorStyle != nil {
+		return blockIndent + ge.editorStyle.Indent
+	}
+
+	for lineNumber := blockStart + 1; lineNumber < blockEnd; lineNumber++ {
+		line := lines[lineNumber-1]
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if indent := leadingWhitespace(line); len(indent) > len(blockIndent) {
+			return indent
+		}
+	}
+
+	return blockIndent + ge.Style().Indent
+}
+
+// declarationScope 返回依赖声明行的配置名称，不是依赖声明时返回空字符串。
+func declarationScope(trimmedLine string) string {
+	if trimmedLine == "" || strings.HasPrefix(trimmedLine, "//") || strings.HasPrefix(trimmedLine, "/*") {
+		return ""
+	}
+	end := strings.IndexAny(trimmedLine, " \t(")
+	if end <= 0 {
+		return ""
+	}
+	rest := strings.TrimSpace(trimmedLine[end:])
+	if !strings.HasPrefix(rest, "'") && !strings.HasPrefix(rest, "\"") && !strings.HasPrefix(rest, "(") &&
+		!strings.HasPrefix(rest, "project") && !strings.HasPrefix(rest, "platform") {
+		return ""
+	}
+	return trimmedLine[:end]
+}
+
+// isTestScope 判断依赖配置是否是测试范围。
+func isTestScope(scope string) bool {
+	return strings.HasPrefix(scope, "test") || strings.HasPrefix(scope, "androidTest")
+}
+
+// leadingWhitespace 返回行首的空白字符。
+func leadingWhitespace(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
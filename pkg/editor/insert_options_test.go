@@ -0,0 +1,245 @@
+package editor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const insertOptionsTestContent = "buildscript {\n" +
+	"\tdependencies {\n" +
+	"\t\tclasspath 'com.android.tools.build:gradle:7.4.0'\n" +
+	"\t}\n" +
+	"}\n" +
+	"\n" +
+	"dependencies {\n" +
+	"\timplementation 'com.google.guava:guava:31.1-jre'\n" +
+	"\timplementation('org.hibernate:hibernate-core:5.6.0') {\n" +
+	"\t\texclude group: 'org.jboss'\n" +
+	"\t}\n" +
+	"\tcompileOnly 'org.projectlombok:lombok:1.18.24'\n" +
+	"\n" +
+	"\ttestImplementation 'junit:junit:4.13.2'\n" +
+	"}\n"
+
+func TestAddDependencyDetectsIndent(t *testing.T) {
+	editor := createRepositoryTestEditor(t, insertOptionsTestContent)
+
+	if err := editor.AddDependency("org.slf4j", "slf4j-api", "2.0.5", "implementation"); err != nil {
+		t.Fatalf("AddDependency() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	expected := "\ttestImplementation 'junit:junit:4.13.2'\n\timplementation 'org.slf4j:slf4j-api:2.0.5'\n}\n"
+	if !strings.HasSuffix(newText, expected) {
+		t.Errorf("Dependency should use tab indent in the top-level block, got:\n%s", newText)
+	}
+	if strings.Contains(newText, "\t\tclasspath 'com.android.tools.build:gradle:7.4.0'\n\timplementation") {
+		t.Error("Dependency should not be inserted into the buildscript block")
+	}
+}
+
+func TestAddDependencyGroupByScope(t *testing.T) {
+	opts := InsertOptions{GroupByScope: true}
+
+	tests := []struct {
+		name     string
+		scope    string
+		expected string
+	}{
+		{
+			name:     "After last same-scope declaration with closure",
+			scope:    "implementation",
+			expected: "\t\texclude group: 'org.jboss'\n\t}\n\timplementation 'org.slf4j:slf4j-api:2.0.5'\n\tcompileOnly",
+		},
+		{
+			name:     "Test scope at end of block",
+			scope:    "testRuntimeOnly",
+			expected: "\ttestImplementation 'junit:junit:4.13.2'\n\ttestRuntimeOnly 'org.slf4j:slf4j-api:2.0.5'\n}",
+		},
+		{
+			name:     "New main scope before tests",
+			scope:    "runtimeOnly",
+			expected: "\tcompileOnly 'org.projectlombok:lombok:1.18.24'\n\truntimeOnly 'org.slf4j:slf4j-api:2.0.5'\n\n\ttestImplementation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			editor := createRepositoryTestEditor(t, insertOptionsTestContent)
+			if err := editor.AddDependencyWithOptions("org.slf4j", "slf4j-api", "2.0.5", tt.scope, opts); err != nil {
+				t.Fatalf("AddDependencyWithOptions() error = %v", err)
+			}
+
+			newText := applyEditorModifications(t, editor)
+			if !strings.Contains(newText, tt.expected) {
+				t.Errorf("Expected %q in result, got:\n%s", tt.expected, newText)
+			}
+		})
+	}
+}
+
+func TestAddDependencyWithCommentAndIndent(t *testing.T) {
+	editor := createRepositoryTestEditor(t, insertOptionsTestContent)
+
+	opts := InsertOptions{Indent: "  ", Comment: "renovate: ignore"}
+	if err := editor.AddDependencyWithOptions("org.slf4j", "slf4j-api", "", "", opts); err != nil {
+		t.Fatalf("AddDependencyWithOptions() error = %v", err)
+	}
+
+	mods := editor.GetModifications()
+	if len(mods) != 1 {
+		t.Fatalf("Expected 1 modification, got %d", len(mods))
+	}
+	if mods[0].NewText != "  implementation 'org.slf4j:slf4j-api' // renovate: ignore\n" {
+		t.Errorf("Unexpected new text: %q", mods[0].NewText)
+	}
+}
+
+func TestAddDependencyEmptyBlockIndent(t *testing.T) {
+	editor := createRepositoryTestEditor(t, "plugins {\n  id 'java'\n}\n\ndependencies {\n}\n")
+
+	if err := editor.AddDependency("junit", "junit", "4.13.2", "testImplementation"); err != nil {
+		t.Fatalf("AddDependency() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	if !strings.Contains(newText, "dependencies {\n  testImplementation 'junit:junit:4.13.2'\n}") {
+		t.Errorf("Empty block should use the file's indent unit, got:\n%s", newText)
+	}
+}
+
+func TestAddDependencyAlphabetical(t *testing.T) {
+	opts := InsertOptions{Alphabetical: true}
+
+	tests := []struct {
+		name     string
+		group    string
+		artifact string
+		scope    string
+		expected string
+	}{
+		{
+			name:     "Before first later coordinate",
+			group:    "com.fasterxml.jackson.core",
+			artifact: "jackson-databind",
+			scope:    "implementation",
+			expected: "dependencies {\n\timplementation 'com.fasterxml.jackson.core:jackson-databind:1.0'\n\timplementation 'com.google.guava",
+		},
+		{
+			name:     "Between coordinates",
+			group:    "com.squareup.okhttp3",
+			artifact: "okhttp",
+			scope:    "implementation",
+			expected: "guava:31.1-jre'\n\timplementation 'com.squareup.okhttp3:okhttp:1.0'\n\timplementation('org.hibernate",
+		},
+		{
+			name:     "After closure of last coordinate",
+			group:    "org.slf4j",
+			artifact: "slf4j-api",
+			scope:    "implementation",
+			expected: "\t}\n\timplementation 'org.slf4j:slf4j-api:1.0'\n\tcompileOnly",
+		},
+		{
+			name:     "Test scope",
+			group:    "a",
+			artifact: "b",
+			scope:    "testImplementation",
+			expected: "\n\ttestImplementation 'a:b:1.0'\n\ttestImplementation 'junit:junit:4.13.2'\n}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			editor := createRepositoryTestEditor(t, insertOptionsTestContent)
+			if err := editor.AddDependencyWithOptions(tt.group, tt.artifact, "1.0", tt.scope, opts); err != nil {
+				t.Fatalf("AddDependencyWithOptions() error = %v", err)
+			}
+
+			newText := applyEditorModifications(t, editor)
+			if !strings.Contains(newText, tt.expected) {
+				t.Errorf("Expected %q in result, got:\n%s", tt.expected, newText)
+			}
+		})
+	}
+}
+
+func TestAddDependencyCreateScopeSection(t *testing.T) {
+	t.Run("Main scope before tests", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, insertOptionsTestContent)
+		opts := InsertOptions{CreateScopeSection: true}
+		if err := editor.AddDependencyWithOptions("org.postgresql", "postgresql", "42.6.0", "runtimeOnly", opts); err != nil {
+			t.Fatalf("AddDependencyWithOptions() error = %v", err)
+		}
+
+		expected := "\tcompileOnly 'org.projectlombok:lombok:1.18.24'\n\n\t// runtimeOnly\n\truntimeOnly 'org.postgresql:postgresql:42.6.0'\n\n\ttestImplementation"
+		if newText := applyEditorModifications(t, editor); !strings.Contains(newText, expected) {
+			t.Errorf("Expected %q in result, got:\n%s", expected, newText)
+		}
+	})
+
+	t.Run("Test scope at end with custom header", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, insertOptionsTestContent)
+		opts := InsertOptions{CreateScopeSection: true, SectionHeader: "// ---- {scope} ----"}
+		if err := editor.AddDependencyWithOptions("org.mockito", "mockito-core", "5.4.0", "testRuntimeOnly", opts); err != nil {
+			t.Fatalf("AddDependencyWithOptions() error = %v", err)
+		}
+
+		expected := "\ttestImplementation 'junit:junit:4.13.2'\n\n\t// ---- testRuntimeOnly ----\n\ttestRuntimeOnly 'org.mockito:mockito-core:5.4.0'\n}\n"
+		if newText := applyEditorModifications(t, editor); !strings.HasSuffix(newText, expected) {
+			t.Errorf("Expected suffix %q, got:\n%s", expected, newText)
+		}
+	})
+
+	t.Run("Existing scope gets no header", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, insertOptionsTestContent)
+		opts := InsertOptions{CreateScopeSection: true}
+		if err := editor.AddDependencyWithOptions("org.mapstruct", "mapstruct", "1.5.5", "compileOnly", opts); err != nil {
+			t.Fatalf("AddDependencyWithOptions() error = %v", err)
+		}
+
+		if newText := applyEditorModifications(t, editor); strings.Contains(newText, "// compileOnly") {
+			t.Errorf("Did not expect a section header, got:\n%s", newText)
+		}
+	})
+}
+
+func TestAddDependencyIdempotent(t *testing.T) {
+	editor := createRepositoryTestEditor(t, insertOptionsTestContent)
+
+	err := editor.AddDependencyWithOptions("junit", "junit", "4.13.2", "testImplementation", InsertOptions{GroupByScope: true})
+	if !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists, got %v", err)
+	}
+	if len(editor.GetModifications()) != 0 {
+		t.Error("Existing declarations should not generate modifications")
+	}
+
+	// 版本或配置不同时仍然添加。
+	if err := editor.AddDependency("junit", "junit", "4.13.2", "implementation"); err != nil {
+		t.Errorf("AddDependency() with another scope error = %v", err)
+	}
+	if err := editor.AddDependency("junit", "junit", "4.13.1", "testImplementation"); err != nil {
+		t.Errorf("AddDependency() with another version error = %v", err)
+	}
+	if len(editor.GetModifications()) != 2 {
+		t.Errorf("Expected 2 modifications, got %d", len(editor.GetModifications()))
+	}
+}
+
+func TestAddDependencyOneLineBlock(t *testing.T) {
+	for _, opts := range []InsertOptions{DefaultInsertOptions(), {GroupByScope: true}} {
+		editor := createRepositoryTestEditor(t, "  dependencies { implementation 'com.google.guava:guava:31.1-jre' }\n")
+		if err := editor.AddDependencyWithOptions("org.slf4j", "slf4j-api", "2.0.5", "implementation", opts); err != nil {
+			t.Fatalf("AddDependencyWithOptions() error = %v", err)
+		}
+
+		newText := applyEditorModifications(t, editor)
+		expected := "  dependencies { implementation 'com.google.guava:guava:31.1-jre'\n" +
+			"    implementation 'org.slf4j:slf4j-api:2.0.5'\n" +
+			"  }\n"
+		if newText != expected {
+			t.Errorf("Dependency should be inserted before the closing brace with %+v, got:\n%s", opts, newText)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Package editor 提供合并两组修改操作的功能。
+package editor
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MergeConflict 表示分别来自两组修改、无法同时应用的一对修改。
+type MergeConflict struct {
+	A      Modification `json:"a"`
+	B      Modification `json:"b"`
+	Reason string       `json:"reason"`
+}
+
+// MergeResult 表示合并两组修改的结果。
+type MergeResult struct {
+	// Text 是在base上应用所有无冲突修改后的文本。
+	Text string `json:"text"`
+	// Modifications 是合并后的无冲突修改，先是setA中的，再是setB中的，两组中相同的修改只保留一个。
+	Modifications []Modification `json:"modifications"`
+	// Conflicts 是范围重叠的修改对，涉及冲突的修改不会被应用。
+	Conflicts []MergeConflict `json:"conflicts"`
+}
+
+// HasConflicts 判断合并是否存在冲突。
+func (r *MergeResult) HasConflicts() bool {
+	return len(r.Conflicts) > 0
+}
+
+// MergeModifications 合并两个工具对同一份base文本产生的修改，例如版本升级和镜像改写。
+// 两组中范围不重叠的修改自动合并，完全相同的修改视为一个；范围重叠、在同一位置插入不同内容，
+// 或插入位置落在另一方替换/删除范围内部的修改记为冲突，冲突双方都不应用。
+// 修改的范围超出base时返回包装了 ErrInvalidRange 的错误。
+func MergeModifications(base string, setA, setB []Modification) (*MergeResult, error) {
+	for _, set := range [][]Modification{setA, setB} {
+		for _, mod := range set {
+			start, end := modificationSpan(mod)
+			if start < 0 || end > len(base) || start > end {
+				return nil, fmt.Errorf("%w: %s modification at %d-%d", ErrInvalidRange, mod.Type, start, end)
+			}
+		}
+	}
+
+	result := &MergeResult{
+		Modifications: make([]Modification, 0, len(setA)+len(setB)),
+		Conflicts:     make([]MergeConflict, 0),
+	}
+	conflictedA := make([]bool, len(setA))
+	conflictedB := make([]bool, len(setB))
+	duplicateB := make([]bool, len(setB))
+
+	for i, a := range setA {
+		for j, b := range setB {
+			if sameModification(a, b) {
+				duplicateB[j] = true
+				continue
+			}
+			if reason := modificationConflict(a, b); reason != "" {
+				conflictedA[i], conflictedB[j] = true, true
+				result.Conflicts = append(result.Conflicts, MergeConflict{A: a, B: b, Reason: reason})
+			}
+		}
+	}
+
+	for i, a := range setA {
+		if !conflictedA[i] {
+			result.Modifications = append(result.Modifications, a)
+		}
+	}
+	for j, b := range setB {
+		if !conflictedB[j] && !duplicateB[j] {
+			result.Modifications = append(result.Modifications, b)
+		}
+	}
+
+	text, err := applyInOrder(base, result.Modifications)
+	if err != nil {
+		return nil, err
+	}
+	result.Text = text
+	return result, nil
+}
+
+// modificationSpan 返回修改在原文中的起止位置，插入操作的起止位置相同。
+func modificationSpan(mod Modification) (int, int) {
+	start := mod.SourceRange.Start.StartPos
+	if mod.Type == ModificationTypeInsert {
+		return start, start
+	}
+	return start, mod.SourceRange.End.StartPos
+}
+
+// sameModification 判断两个修改是否对同一范围做相同的改动。
+func sameModification(a, b Modification) bool {
+	aStart, aEnd := modificationSpan(a)
+	bStart, bEnd := modificationSpan(b)
+	return a.Type == b.Type && aStart == bStart && aEnd == bEnd && a.NewText == b.NewText
+}
+
+// modificationConflict 返回两个修改冲突的原因，不冲突时返回空字符串。
+func modificationConflict(a, b Modification) string {
+	aStart, aEnd := modificationSpan(a)
+	bStart, bEnd := modificationSpan(b)
+	aInsert, bInsert := a.Type == ModificationTypeInsert, b.Type == ModificationTypeInsert
+
+	switch {
+	case aInsert && bInsert:
+		if aStart == bStart {
+			return "both insert at the same position"
+		}
+	case aInsert:
+		if aStart > bStart && aStart < bEnd {
+			return "insertion inside a changed range"
+		}
+	case bInsert:
+		if bStart > aStart && bStart < aEnd {
+			return "insertion inside a changed range"
+		}
+	default:
+		if aStart < bEnd && bStart < aEnd || aStart == bStart && aEnd == bEnd {
+			return "overlapping ranges"
+		}
+	}
+	return ""
+}
+
+// applyInOrder 从后往前应用互不重叠的修改。同一位置上先替换或删除再插入，
+// 多个插入按列表中的顺序排列。
+func applyInOrder(base string, modifications []Modification) (string, error) {
+	order := make([]int, len(modifications))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(x, y int) bool {
+		a, b := modifications[order[x]], modifications[order[y]]
+		aStart, _ := modificationSpan(a)
+		bStart, _ := modificationSpan(b)
+		if aStart != bStart {
+			return aStart > bStart
+		}
+		aInsert, bInsert := a.Type == ModificationTypeInsert, b.Type == ModificationTypeInsert
+		if aInsert != bInsert {
+			return bInsert
+		}
+		return order[x] > order[y]
+	})
+
+	serializer := NewGradleSerializer(base)
+	text := base
+	for _, i := range order {
+		var err error
+		text, err = serializer.applyModification(text, modifications[i])
+		if err != nil {
+			return "", fmt.Errorf("failed to apply modification: %w", err)
+		}
+	}
+	return text, nil
+}
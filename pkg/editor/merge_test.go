@@ -0,0 +1,107 @@
+package editor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestMergeModifications(t *testing.T) {
+	bumper := createTestEditor(t)
+	if err := bumper.UpdateDependencyVersion("com.google.guava", "guava", "32.1.2-jre"); err != nil {
+		t.Fatalf("UpdateDependencyVersion() error = %v", err)
+	}
+	if err := bumper.UpdatePluginVersion("org.springframework.boot", "3.1.0"); err != nil {
+		t.Fatalf("UpdatePluginVersion() error = %v", err)
+	}
+
+	mirror := createTestEditor(t)
+	if _, err := mirror.RewriteRepositoriesToMirrors(map[string]string{"https://jitpack.io": "https://mirror.example.com/jitpack"}); err != nil {
+		t.Fatalf("RewriteRepositoriesToMirrors() error = %v", err)
+	}
+	// 与bumper相同的修改应只应用一次。
+	if err := mirror.UpdatePluginVersion("org.springframework.boot", "3.1.0"); err != nil {
+		t.Fatalf("UpdatePluginVersion() error = %v", err)
+	}
+
+	result, err := MergeModifications(testGradleContent, bumper.GetModifications(), mirror.GetModifications())
+	if err != nil {
+		t.Fatalf("MergeModifications() error = %v", err)
+	}
+	if result.HasConflicts() {
+		t.Fatalf("Expected no conflicts, got %+v", result.Conflicts)
+	}
+	if len(result.Modifications) != 3 {
+		t.Errorf("Expected 3 merged modifications, got %d", len(result.Modifications))
+	}
+	for _, want := range []string{"guava:32.1.2-jre", "version '3.1.0'", "https://mirror.example.com/jitpack"} {
+		if strings.Count(result.Text, want) != 1 {
+			t.Errorf("Expected merged text to contain %q once", want)
+		}
+	}
+}
+
+func TestMergeModificationsConflicts(t *testing.T) {
+	a := createTestEditor(t)
+	if err := a.UpdateDependencyVersion("com.google.guava", "guava", "32.1.2-jre"); err != nil {
+		t.Fatalf("UpdateDependencyVersion() error = %v", err)
+	}
+	if err := a.UpdateProperty("version", "1.0.0"); err != nil {
+		t.Fatalf("UpdateProperty() error = %v", err)
+	}
+	b := createTestEditor(t)
+	if err := b.UpdateDependencyVersion("com.google.guava", "guava", "33.0.0-jre"); err != nil {
+		t.Fatalf("UpdateDependencyVersion() error = %v", err)
+	}
+
+	result, err := MergeModifications(testGradleContent, a.GetModifications(), b.GetModifications())
+	if err != nil {
+		t.Fatalf("MergeModifications() error = %v", err)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Reason != "overlapping ranges" {
+		t.Fatalf("Expected 1 overlapping conflict, got %+v", result.Conflicts)
+	}
+	if !strings.Contains(result.Conflicts[0].A.NewText, "32.1.2-jre") || !strings.Contains(result.Conflicts[0].B.NewText, "33.0.0-jre") {
+		t.Errorf("Unexpected conflict pair: %+v", result.Conflicts[0])
+	}
+	// 冲突的修改不应用，其他修改照常合并。
+	if !strings.Contains(result.Text, "guava:31.0-jre") || !strings.Contains(result.Text, "version = '1.0.0'") {
+		t.Errorf("Unexpected merged text:\n%s", result.Text)
+	}
+}
+
+func TestMergeModificationsInserts(t *testing.T) {
+	base := "dependencies {\n}\n"
+	insert := func(pos int, text string) Modification {
+		return Modification{Type: ModificationTypeInsert, SourceRange: pointRange(1, 1, pos), NewText: text}
+	}
+	replace := Modification{
+		Type:        ModificationTypeReplace,
+		SourceRange: model.SourceRange{Start: model.SourcePosition{StartPos: 0}, End: model.SourcePosition{StartPos: 12}},
+		OldText:     "dependencies",
+		NewText:     "deps",
+	}
+
+	// 插入在替换范围的边界上不冲突，同一组内的多个插入保持顺序。
+	result, err := MergeModifications(base, []Modification{replace}, []Modification{insert(15, "  a\n"), insert(15, "  b\n"), insert(0, "// x\n")})
+	if err != nil {
+		t.Fatalf("MergeModifications() error = %v", err)
+	}
+	if result.HasConflicts() || result.Text != "// x\ndeps {\n  a\n  b\n}\n" {
+		t.Errorf("Unexpected result %q, conflicts %+v", result.Text, result.Conflicts)
+	}
+
+	result, err = MergeModifications(base, []Modification{replace, insert(15, "  a\n")}, []Modification{insert(4, "x"), insert(15, "  b\n")})
+	if err != nil {
+		t.Fatalf("MergeModifications() error = %v", err)
+	}
+	if len(result.Conflicts) != 2 || result.Text != base {
+		t.Errorf("Expected 2 conflicts and unchanged text, got %q, %+v", result.Text, result.Conflicts)
+	}
+
+	if _, err := MergeModifications(base, []Modification{insert(100, "x")}, nil); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Package editor 提供插件相关的结构化编辑功能。
+package editor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配插件声明开头的ID部分，例如 id 'java' 或 id("java")，不包括之后的空白。
+	pluginIDPrefixRegex = regexp.MustCompile(`^id\s*\(?\s*['"][^'"]*['"](?:\s*\))?`)
+
+	// 匹配插件声明末尾的 apply 子句，例如 apply false 或 apply(false)。
+	pluginApplySuffixRegex = regexp.MustCompile(`\s+apply\s*\(?\s*(true|false)\s*\)?$`)
+)
+
+// SetPluginApply 设置plugins块中插件声明的 apply false 子句。
+// apply为false时在声明末尾添加 apply false（插件只声明版本，由子项目应用），为true时删除该子句。
+func (ge *GradleEditor) SetPluginApply(pluginID string, apply bool) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+
+	var targetPlugin *model.SourceMappedPlugin
+	for _, plugin := range ge.sourceMappedProject.SourceMappedPlugins {
+		if plugin.ID == pluginID {
+			targetPlugin = plugin
+			break
+		}
+	}
+	if targetPlugin == nil {
+		return fmt.Errorf("%w: %s", ErrPluginNotFound, pluginID)
+	}
+	if targetPlugin.Apply == apply {
+		return nil
+	}
+
+	newText := pluginApplySuffixRegex.ReplaceAllString(targetPlugin.RawText, "")
+	if !apply {
+		newText += " apply false"
+	}
+
+	ge.modifications = append(ge.modifications, Modification{
+		Type:        ModificationTypeReplace,
+		SourceRange: targetPlugin.SourceRange,
+		OldText:     targetPlugin.RawText,
+		NewText:     newText,
+		Description: fmt.Sprintf("Set plugin %s apply to %t", pluginID, apply),
+	})
+
+	// 更新内存中的插件信息。
+	targetPlugin.Apply = apply
+	targetPlugin.RawText = newText
+
+	return nil
+}
+
+// insertPluginVersion 在插件声明的ID之后插入版本，引号风格与ID保持一致。
+func insertPluginVersion(rawText, version string) string {
+	quote := "'"
+	if i := strings.IndexAny(rawText, `'"`); i != -1 {
+		quote = rawText[i : i+1]
+	}
+	versionClause := fmt.Sprintf(" version %s%s%s", quote, version, quote)
+
+	loc := pluginIDPrefixRegex.FindStringIndex(rawText)
+	if loc == nil {
+		return rawText + versionClause
+	}
+	return rawText[:loc[1]] + versionClause + rawText[loc[1]:]
+}
@@ -0,0 +1,72 @@
+package editor
+
+import (
+	"errors"
+	"testing"
+)
+
+const pluginEditorTestContent = `plugins {
+    id 'java'
+    id 'org.springframework.boot' version '3.1.0' apply false
+    id 'io.spring.dependency-management'
+}
+`
+
+func TestSetPluginApply(t *testing.T) {
+	editor := createRepositoryTestEditor(t, pluginEditorTestContent)
+	if plugin := editor.GetSourceMappedProject().SourceMappedPlugins[1]; plugin.Apply {
+		t.Fatalf("Expected apply false to be parsed, got %+v", plugin.Plugin)
+	}
+
+	if err := editor.SetPluginApply("org.springframework.boot", true); err != nil {
+		t.Fatalf("SetPluginApply() error = %v", err)
+	}
+	if err := editor.SetPluginApply("java", false); err != nil {
+		t.Fatalf("SetPluginApply() error = %v", err)
+	}
+	if err := editor.SetPluginApply("java", false); err != nil || len(editor.GetModifications()) != 2 {
+		t.Errorf("Setting the same value again should be a no-op, got %v", err)
+	}
+
+	expected := `plugins {
+    id 'java' apply false
+    id 'org.springframework.boot' version '3.1.0'
+    id 'io.spring.dependency-management'
+}
+`
+	if newText := applyEditorModifications(t, editor); newText != expected {
+		t.Errorf("Unexpected result:\n%s", newText)
+	}
+
+	if err := editor.SetPluginApply("com.example.missing", false); !errors.Is(err, ErrPluginNotFound) {
+		t.Errorf("Expected ErrPluginNotFound, got %v", err)
+	}
+}
+
+func TestUpdatePluginVersionPreservesApplyFalse(t *testing.T) {
+	editor := createRepositoryTestEditor(t, `plugins {
+    id 'org.springframework.boot' version '3.1.0' apply false
+    id("io.spring.dependency-management") apply false
+    id 'org.asciidoctor.jvm.convert' apply false
+}
+`)
+	if err := editor.UpdatePluginVersion("org.springframework.boot", "3.2.0"); err != nil {
+		t.Fatalf("UpdatePluginVersion() error = %v", err)
+	}
+	if err := editor.UpdatePluginVersion("io.spring.dependency-management", "1.1.4"); err != nil {
+		t.Fatalf("UpdatePluginVersion() error = %v", err)
+	}
+	if err := editor.UpdatePluginVersion("org.asciidoctor.jvm.convert", "4.0.2"); err != nil {
+		t.Fatalf("UpdatePluginVersion() error = %v", err)
+	}
+
+	expected := `plugins {
+    id 'org.springframework.boot' version '3.2.0' apply false
+    id("io.spring.dependency-management") version "1.1.4" apply false
+    id 'org.asciidoctor.jvm.convert' version '4.0.2' apply false
+}
+`
+	if newText := applyEditorModifications(t, editor); newText != expected {
+		t.Errorf("Unexpected result:\n%s", newText)
+	}
+}
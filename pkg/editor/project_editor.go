@@ -0,0 +1,153 @@
+// Package editor 提供跨多个构建文件的编辑会话。
+package editor
+
+import (
+	"fmt"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// ProjectEditor 同时编辑一个构建中的所有构建文件，每个文件对应一个 GradleEditor，
+// 修改按文件分别记录，最后通过 Changes 或 Apply 统一取得。文件按 FilePath 区分。
+type ProjectEditor struct {
+	editors  []*GradleEditor
+	settings *GradleEditor
+}
+
+// NewProjectEditor 为每个构建文件创建编辑器。projects 中根项目的构建文件应排在最前面，
+// settings 是settings文件，没有settings文件时为nil。
+func NewProjectEditor(projects []*model.SourceMappedProject, settings *model.SourceMappedProject) *ProjectEditor {
+	pe := &ProjectEditor{editors: make([]*GradleEditor, 0, len(projects))}
+	for _, project := range projects {
+		if project != nil {
+			pe.editors = append(pe.editors, NewGradleEditor(project))
+		}
+	}
+	if settings != nil {
+		pe.settings = NewGradleEditor(settings)
+	}
+	return pe
+}
+
+// Editors 返回各构建文件的编辑器，不包括settings文件。
+func (pe *ProjectEditor) Editors() []*GradleEditor {
+	return pe.editors
+}
+
+// Editor 返回指定文件（构建文件或settings文件）的编辑器，找不到时返回nil。
+func (pe *ProjectEditor) Editor(filePath string) *GradleEditor {
+	for _, ge := range pe.allEditors() {
+		if ge.sourceMappedProject.FilePath == filePath {
+			return ge
+		}
+	}
+	return nil
+}
+
+// Settings 返回settings文件的编辑器，没有settings文件时返回nil。
+func (pe *ProjectEditor) Settings() *GradleEditor {
+	return pe.settings
+}
+
+// UpdateDependencyVersion 在所有构建文件中更新依赖的每一处声明，返回更新的声明数量，
+// 已经是目标版本的声明不计入。没有任何文件声明该依赖时返回包装了 ErrDependencyNotFound 的错误。
+func (pe *ProjectEditor) UpdateDependencyVersion(group, name, newVersion string) (int, error) {
+	found := false
+	updated := 0
+	for _, ge := range pe.editors {
+		for _, dep := range ge.sourceMappedProject.SourceMappedDependencies {
+			if dep.Group != group || dep.Name != name {
+				continue
+			}
+			found = true
+			if dep.Version != newVersion {
+				ge.updateDependencyVersion(dep, newVersion)
+				updated++
+			}
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("%w: %s:%s", ErrDependencyNotFound, group, name)
+	}
+	return updated, nil
+}
+
+// AddRepository 在每个有顶层 repositories 块的构建文件中添加仓库，返回修改的文件数量。
+// 已经声明了该仓库（按名称或URL匹配）的文件和没有 repositories 块的文件被跳过，
+// settings文件中集中声明的仓库不受影响。
+func (pe *ProjectEditor) AddRepository(name, url string) (int, error) {
+	if name == "" && url == "" {
+		return 0, fmt.Errorf("%w: repository name and url are both empty", ErrInvalidArgument)
+	}
+
+	key := url
+	if key == "" {
+		key = name
+	}
+	added := 0
+	for _, ge := range pe.editors {
+		if ge.findRepositoryIndex(key) != -1 {
+			continue
+		}
+		if start, end := ge.findTopLevelBlock("repositories"); start == -1 || end == -1 {
+			continue
+		}
+		if err := ge.AddRepository(name, url); err != nil {
+			return added, err
+		}
+		added++
+	}
+	return added, nil
+}
+
+// UpdateProjectCoordinates 在根项目的构建文件（第一个构建文件）和settings文件中更新项目坐标，参见 UpdateProjectCoordinates。
+func (pe *ProjectEditor) UpdateProjectCoordinates(group, version, name string) ([]*FileModifications, error) {
+	if len(pe.editors) == 0 {
+		return nil, ErrNilProject
+	}
+	return UpdateProjectCoordinates(pe.editors[0], pe.settings, group, version, name)
+}
+
+// Changes 返回每个有修改的文件的修改和diff，键为文件路径。
+func (pe *ProjectEditor) Changes() map[string]*FileModifications {
+	changes := make(map[string]*FileModifications)
+	for _, ge := range pe.allEditors() {
+		modifications := ge.GetModifications()
+		if len(modifications) == 0 {
+			continue
+		}
+		serializer := NewGradleSerializer(ge.sourceMappedProject.OriginalText)
+		changes[ge.sourceMappedProject.FilePath] = &FileModifications{
+			FilePath:      ge.sourceMappedProject.FilePath,
+			Modifications: modifications,
+			Diff:          serializer.GenerateDiff(modifications),
+		}
+	}
+	return changes
+}
+
+// Apply 应用所有修改，返回每个有修改的文件的新内容，键为文件路径。任一文件的修改无法应用时返回错误。
+func (pe *ProjectEditor) Apply() (map[string]string, error) {
+	contents := make(map[string]string)
+	for _, ge := range pe.allEditors() {
+		modifications := ge.GetModifications()
+		if len(modifications) == 0 {
+			continue
+		}
+		filePath := ge.sourceMappedProject.FilePath
+		newText, err := NewGradleSerializer(ge.sourceMappedProject.OriginalText).ApplyModifications(modifications)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply modifications to %s: %w", filePath, err)
+		}
+		contents[filePath] = newText
+	}
+	return contents, nil
+}
+
+// allEditors 返回构建文件和settings文件的编辑器。
+func (pe *ProjectEditor) allEditors() []*GradleEditor {
+	if pe.settings == nil {
+		return pe.editors
+	}
+	return append(append(make([]*GradleEditor, 0, len(pe.editors)+1), pe.editors...), pe.settings)
+}
@@ -0,0 +1,129 @@
+package editor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/parser"
+)
+
+func parseSourceMapped(t *testing.T, filePath, content string) *model.SourceMappedProject {
+	result, err := parser.NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", filePath, err)
+	}
+	result.SourceMappedProject.FilePath = filePath
+	return result.SourceMappedProject
+}
+
+func newTestProjectEditor(t *testing.T) *ProjectEditor {
+	root := parseSourceMapped(t, "build.gradle", `group = 'com.example'
+
+repositories {
+    mavenCentral()
+}
+
+dependencies {
+    implementation 'com.google.guava:guava:31.0-jre'
+}
+`)
+	app := parseSourceMapped(t, "app/build.gradle", `repositories {
+    mavenCentral()
+    maven { url 'https://repo.example.com/maven' }
+}
+
+dependencies {
+    implementation 'com.google.guava:guava:31.0-jre'
+    testImplementation 'com.google.guava:guava:31.0-jre'
+    implementation 'org.slf4j:slf4j-api:1.7.36'
+}
+`)
+	lib := parseSourceMapped(t, "lib/build.gradle", `dependencies {
+    api 'com.google.guava:guava:32.1.2-jre'
+}
+`)
+	settings := parseSourceMapped(t, "settings.gradle", "rootProject.name = 'demo'\ninclude ':app', ':lib'\n")
+	return NewProjectEditor([]*model.SourceMappedProject{root, app, lib}, settings)
+}
+
+func TestProjectEditor_UpdateDependencyVersion(t *testing.T) {
+	pe := newTestProjectEditor(t)
+
+	updated, err := pe.UpdateDependencyVersion("com.google.guava", "guava", "32.1.2-jre")
+	if err != nil {
+		t.Fatalf("UpdateDependencyVersion() error = %v", err)
+	}
+	if updated != 3 {
+		t.Errorf("Expected 3 updated declarations, got %d", updated)
+	}
+
+	changes := pe.Changes()
+	if len(changes) != 2 || len(changes["build.gradle"].Modifications) != 1 || len(changes["app/build.gradle"].Modifications) != 2 {
+		t.Fatalf("Unexpected changes %+v", changes)
+	}
+	if len(changes["app/build.gradle"].Diff) != 4 {
+		t.Errorf("Expected a remove and an add line per modification, got %v", changes["app/build.gradle"].Diff)
+	}
+
+	contents, err := pe.Apply()
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if strings.Contains(contents["app/build.gradle"], "31.0-jre") {
+		t.Errorf("Expected every declaration to be updated, got:\n%s", contents["app/build.gradle"])
+	}
+
+	if _, err := pe.UpdateDependencyVersion("org.foo", "missing", "1.0"); !errors.Is(err, ErrDependencyNotFound) {
+		t.Errorf("Expected ErrDependencyNotFound, got %v", err)
+	}
+}
+
+func TestProjectEditor_AddRepository(t *testing.T) {
+	pe := newTestProjectEditor(t)
+
+	added, err := pe.AddRepository("", "https://repo.example.com/maven")
+	if err != nil {
+		t.Fatalf("AddRepository() error = %v", err)
+	}
+	// app已经声明了该仓库，lib没有repositories块。
+	if added != 1 {
+		t.Errorf("Expected 1 file to be modified, got %d", added)
+	}
+
+	contents, err := pe.Apply()
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(contents) != 1 || !strings.Contains(contents["build.gradle"], "maven { url 'https://repo.example.com/maven' }") {
+		t.Errorf("Unexpected contents %v", contents)
+	}
+
+	if _, err := pe.AddRepository("", ""); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+func TestProjectEditor_UpdateProjectCoordinates(t *testing.T) {
+	pe := newTestProjectEditor(t)
+
+	if _, err := pe.UpdateProjectCoordinates("org.acme", "", "acme"); err != nil {
+		t.Fatalf("UpdateProjectCoordinates() error = %v", err)
+	}
+	contents, err := pe.Apply()
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if !strings.Contains(contents["build.gradle"], "group = 'org.acme'") ||
+		!strings.Contains(contents["settings.gradle"], "rootProject.name = 'acme'") {
+		t.Errorf("Unexpected contents %v", contents)
+	}
+	if pe.Editor("settings.gradle") != pe.Settings() || pe.Editor("missing.gradle") != nil {
+		t.Error("Unexpected Editor lookup result")
+	}
+
+	if _, err := NewProjectEditor(nil, nil).UpdateProjectCoordinates("org.acme", "", ""); !errors.Is(err, ErrNilProject) {
+		t.Errorf("Expected ErrNilProject for empty project editor, got %v", err)
+	}
+}
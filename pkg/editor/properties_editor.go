@@ -0,0 +1,390 @@
+// Package editor 提供gradle.properties等properties文件的最小diff编辑功能。
+package editor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/config"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// PropertiesEditor 编辑Java properties格式的文本，例如gradle.properties。
+// 修改只涉及属性值或整行，注释、空行和属性顺序保持不变；修改通过 GetModifications 取得，
+// 与 GradleEditor 产生的修改一样可以交给 GradleSerializer 应用。
+type PropertiesEditor struct {
+	originalText  string
+	entries       []*propertiesEntry
+	appended      []*propertiesEntry
+	modifications []Modification
+
+	// appendIndex 是追加新属性的插入修改在modifications中的下标，-1表示还没有追加。
+	appendIndex int
+	separator   string
+	newline     string
+}
+
+// propertiesEntry 表示一条属性（可能跨越多个续行），位置均为原文中的偏移量。
+type propertiesEntry struct {
+	key        string
+	value      string
+	start      int // 行首。
+	valueStart int // 分隔符和空白之后的值起始位置。
+	end        int // 最后一个续行的行尾，不含换行符。
+
+	// modIndex 是该属性的修改在modifications中的下标，-1表示未修改。
+	modIndex int
+	removed  bool
+}
+
+// NewPropertiesEditor 解析properties文本并创建编辑器。
+func NewPropertiesEditor(content string) *PropertiesEditor {
+	pe := &PropertiesEditor{
+		originalText:  content,
+		entries:       scanPropertiesEntries(content),
+		modifications: make([]Modification, 0),
+		appendIndex:   -1,
+		separator:     "=",
+		newline:       "\n",
+	}
+	if len(pe.entries) > 0 {
+		first := pe.entries[0]
+		pe.separator = propertySeparator(content[first.start:first.valueStart])
+	}
+	if strings.Contains(content, "\r\n") {
+		pe.newline = "\r\n"
+	}
+	return pe
+}
+
+// Get 返回属性的当前值（包括尚未应用的修改）。同一属性出现多次时以最后一次为准，与Java的读取规则一致。
+func (pe *PropertiesEditor) Get(key string) (string, bool) {
+	if entry := pe.find(key); entry != nil {
+		return entry.value, true
+	}
+	return "", false
+}
+
+// Keys 按出现顺序返回当前所有属性名，新追加的属性排在最后。
+func (pe *PropertiesEditor) Keys() []string {
+	keys := make([]string, 0, len(pe.entries)+len(pe.appended))
+	seen := make(map[string]bool)
+	for _, entry := range append(pe.liveEntries(), pe.appended...) {
+		if !seen[entry.key] {
+			seen[entry.key] = true
+			keys = append(keys, entry.key)
+		}
+	}
+	return keys
+}
+
+// Set 设置属性值。属性已存在时只替换值部分（同名属性出现多次时替换生效的最后一个），
+// 否则按文件已有的分隔符风格追加到文件末尾。值中的换行、反斜杠和非ASCII字符会被转义。
+func (pe *PropertiesEditor) Set(key, value string) error {
+	if key == "" {
+		return fmt.Errorf("%w: property key is empty", ErrInvalidArgument)
+	}
+
+	for _, entry := range pe.appended {
+		if entry.key == key {
+			entry.value = value
+			pe.updateAppend()
+			return nil
+		}
+	}
+
+	entry := pe.find(key)
+	if entry == nil {
+		pe.appended = append(pe.appended, &propertiesEntry{key: key, value: value, modIndex: -1})
+		pe.updateAppend()
+		return nil
+	}
+	if entry.value == value {
+		return nil
+	}
+
+	oldValue := entry.value
+	entry.value = value
+	modification := Modification{
+		Type:        ModificationTypeReplace,
+		SourceRange: offsetRange(pe.originalText, entry.valueStart, entry.end),
+		OldText:     pe.originalText[entry.valueStart:entry.end],
+		NewText:     escapePropertyValue(value),
+		Description: fmt.Sprintf("Update property %s from '%s' to '%s'", key, oldValue, value),
+	}
+	pe.setModification(entry, modification)
+	return nil
+}
+
+// Remove 删除属性所在的整行（包括续行），同名属性出现多次时全部删除。属性前面的注释保留。
+// 属性不存在时返回包装了 ErrPropertyNotFound 的错误。
+func (pe *PropertiesEditor) Remove(key string) error {
+	found := false
+	for i := 0; i < len(pe.appended); i++ {
+		if pe.appended[i].key == key {
+			pe.appended = append(pe.appended[:i], pe.appended[i+1:]...)
+			pe.updateAppend()
+			found = true
+			i--
+		}
+	}
+
+	for _, entry := range pe.liveEntries() {
+		if entry.key != key {
+			continue
+		}
+		found = true
+		entry.removed = true
+
+		end := entry.end
+		if end < len(pe.originalText) {
+			end = strings.IndexByte(pe.originalText[end:], '\n') + end + 1
+		}
+		pe.setModification(entry, Modification{
+			Type:        ModificationTypeDelete,
+			SourceRange: offsetRange(pe.originalText, entry.start, end),
+			OldText:     pe.originalText[entry.start:end],
+			NewText:     "",
+			Description: fmt.Sprintf("Remove property %s", key),
+		})
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s", ErrPropertyNotFound, key)
+	}
+	return nil
+}
+
+// GetModifications 获取所有修改操作。
+func (pe *PropertiesEditor) GetModifications() []Modification {
+	return pe.modifications
+}
+
+// Apply 在原文上应用所有修改，返回新的文本。
+func (pe *PropertiesEditor) Apply() (string, error) {
+	return NewGradleSerializer(pe.originalText).ApplyModifications(pe.modifications)
+}
+
+// find 返回最后一个未删除的同名属性。
+func (pe *PropertiesEditor) find(key string) *propertiesEntry {
+	for i := len(pe.entries) - 1; i >= 0; i-- {
+		if entry := pe.entries[i]; entry.key == key && !entry.removed {
+			return entry
+		}
+	}
+	return nil
+}
+
+// liveEntries 返回原文中未删除的属性。
+func (pe *PropertiesEditor) liveEntries() []*propertiesEntry {
+	live := make([]*propertiesEntry, 0, len(pe.entries))
+	for _, entry := range pe.entries {
+		if !entry.removed {
+			live = append(live, entry)
+		}
+	}
+	return live
+}
+
+// setModification 记录属性的修改，同一属性的多次修改合并为一个，避免范围重叠。
+func (pe *PropertiesEditor) setModification(entry *propertiesEntry, modification Modification) {
+	if entry.modIndex == -1 {
+		entry.modIndex = len(pe.modifications)
+		pe.modifications = append(pe.modifications, modification)
+		return
+	}
+	pe.modifications[entry.modIndex] = modification
+}
+
+// updateAppend 根据追加的属性重新生成文件末尾的插入修改，多个新属性合并为一个插入，保证顺序稳定。
+func (pe *PropertiesEditor) updateAppend() {
+	var sb strings.Builder
+	if len(pe.appended) > 0 && pe.originalText != "" && !strings.HasSuffix(pe.originalText, "\n") {
+		sb.WriteString(pe.newline)
+	}
+	keys := make([]string, 0, len(pe.appended))
+	for _, entry := range pe.appended {
+		sb.WriteString(escapePropertyKey(entry.key) + pe.separator + escapePropertyValue(entry.value) + pe.newline)
+		keys = append(keys, entry.key)
+	}
+
+	end := len(pe.originalText)
+	modification := Modification{
+		Type:        ModificationTypeInsert,
+		SourceRange: offsetRange(pe.originalText, end, end),
+		OldText:     "",
+		NewText:     sb.String(),
+		Description: fmt.Sprintf("Add properties %s", strings.Join(keys, ", ")),
+	}
+	if pe.appendIndex == -1 {
+		pe.appendIndex = len(pe.modifications)
+		pe.modifications = append(pe.modifications, modification)
+		return
+	}
+	pe.modifications[pe.appendIndex] = modification
+}
+
+// scanPropertiesEntries 扫描properties文本中的所有属性及其位置，规则与 config.ParseProperties 相同。
+func scanPropertiesEntries(text string) []*propertiesEntry {
+	entries := make([]*propertiesEntry, 0)
+	for pos := 0; pos < len(text); {
+		lineEnd, next := physicalLine(text, pos)
+		trimmed := strings.TrimLeft(text[pos:lineEnd], " \t\f")
+		if trimmed == "" || trimmed[0] == '#' || trimmed[0] == '!' {
+			pos = next
+			continue
+		}
+
+		entry := &propertiesEntry{start: pos, modIndex: -1}
+		entry.valueStart = lineEnd - len(trimmed) + propertyValueOffset(trimmed)
+		for endsWithBackslash(text[pos:lineEnd]) && next < len(text) {
+			pos = next
+			lineEnd, next = physicalLine(text, pos)
+		}
+		entry.end = lineEnd
+
+		for key, value := range config.ParseProperties(text[entry.start:entry.end]) {
+			entry.key, entry.value = key, value
+		}
+		entries = append(entries, entry)
+		pos = next
+	}
+	return entries
+}
+
+// physicalLine 返回从pos开始的一行的行尾（不含 \r\n）和下一行的起始位置。
+func physicalLine(text string, pos int) (int, int) {
+	end := strings.IndexByte(text[pos:], '\n')
+	if end == -1 {
+		return len(text), len(text)
+	}
+	end += pos
+	if end > pos && text[end-1] == '\r' {
+		return end - 1, end + 1
+	}
+	return end, end + 1
+}
+
+// propertyValueOffset 返回值在行内的起始偏移量，分隔规则与 config.ParseProperties 相同。
+func propertyValueOffset(line string) int {
+	skipSpace := func(i int) int {
+		for i < len(line) && (line[i] == ' ' || line[i] == '\t' || line[i] == '\f') {
+			i++
+		}
+		return i
+	}
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=', ':':
+			return skipSpace(i + 1)
+		case ' ', '\t', '\f':
+			i = skipSpace(i)
+			if i < len(line) && (line[i] == '=' || line[i] == ':') {
+				i++
+			}
+			return skipSpace(i)
+		}
+	}
+	return len(line)
+}
+
+// propertySeparator 从已有属性的键和分隔符部分推断分隔符风格，例如 "="、" = " 或 ": "。
+func propertySeparator(keyAndSeparator string) string {
+	offset := 0
+	for offset < len(keyAndSeparator) {
+		c := keyAndSeparator[offset]
+		if c == '\\' {
+			offset += 2
+			continue
+		}
+		if c == '=' || c == ':' || c == ' ' || c == '\t' || c == '\f' {
+			break
+		}
+		offset++
+	}
+	if offset >= len(keyAndSeparator) {
+		return "="
+	}
+	return keyAndSeparator[offset:]
+}
+
+// endsWithBackslash 判断行是否以未转义的反斜杠结尾，即是否有续行。
+func endsWithBackslash(line string) bool {
+	count := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		count++
+	}
+	return count%2 == 1
+}
+
+// escapePropertyKey 转义属性名中的分隔符、空白和注释字符。
+func escapePropertyKey(key string) string {
+	var sb strings.Builder
+	for i, r := range key {
+		switch {
+		case r == '=' || r == ':' || r == ' ' || (i == 0 && (r == '#' || r == '!')):
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		default:
+			writeEscapedRune(&sb, r)
+		}
+	}
+	return sb.String()
+}
+
+// escapePropertyValue 转义属性值，开头的空格会被转义以免被读取时丢弃。
+func escapePropertyValue(value string) string {
+	var sb strings.Builder
+	for i, r := range value {
+		if i == 0 && r == ' ' {
+			sb.WriteString(`\ `)
+			continue
+		}
+		writeEscapedRune(&sb, r)
+	}
+	return sb.String()
+}
+
+// writeEscapedRune 写入一个字符，反斜杠和控制字符使用转义形式，非ASCII字符写为 \uXXXX
+// （properties文件按ISO-8859-1读取）。
+func writeEscapedRune(sb *strings.Builder, r rune) {
+	switch {
+	case r == '\\':
+		sb.WriteString(`\\`)
+	case r == '\n':
+		sb.WriteString(`\n`)
+	case r == '\r':
+		sb.WriteString(`\r`)
+	case r == '\t':
+		sb.WriteString(`\t`)
+	case r == '\f':
+		sb.WriteString(`\f`)
+	case r > 0x7e:
+		if r > 0xffff {
+			hi, lo := (r-0x10000)>>10+0xd800, (r-0x10000)&0x3ff+0xdc00
+			fmt.Fprintf(sb, `\u%04x\u%04x`, hi, lo)
+			return
+		}
+		fmt.Fprintf(sb, `\u%04x`, r)
+	default:
+		sb.WriteRune(r)
+	}
+}
+
+// offsetRange 根据文本偏移量计算源码范围。
+func offsetRange(text string, start, end int) model.SourceRange {
+	position := func(pos int) model.SourcePosition {
+		return model.SourcePosition{
+			Line:     strings.Count(text[:pos], "\n") + 1,
+			Column:   model.RuneColumn(text, pos),
+			StartPos: pos,
+			EndPos:   pos,
+		}
+	}
+	startPosition, endPosition := position(start), position(end)
+	startPosition.EndPos = end
+	startPosition.Length = end - start
+	return model.SourceRange{Start: startPosition, End: endPosition}
+}
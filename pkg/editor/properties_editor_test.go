@@ -0,0 +1,132 @@
+package editor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/config"
+)
+
+const testPropertiesContent = `# Project versions
+kotlinVersion = 1.9.0
+springBootVersion = 3.1.0
+
+! JVM settings
+org.gradle.jvmargs = -Xmx2g \
+    -Dfile.encoding=UTF-8
+org.gradle.parallel = true
+`
+
+func TestPropertiesEditorGet(t *testing.T) {
+	pe := NewPropertiesEditor(testPropertiesContent)
+
+	if value, ok := pe.Get("org.gradle.jvmargs"); !ok || value != "-Xmx2g -Dfile.encoding=UTF-8" {
+		t.Errorf("Get(org.gradle.jvmargs) = %q, %v", value, ok)
+	}
+	if _, ok := pe.Get("missing"); ok {
+		t.Error("Expected missing property to be absent")
+	}
+	want := []string{"kotlinVersion", "springBootVersion", "org.gradle.jvmargs", "org.gradle.parallel"}
+	if keys := pe.Keys(); !reflect.DeepEqual(keys, want) {
+		t.Errorf("Keys() = %v, want %v", keys, want)
+	}
+}
+
+func TestPropertiesEditorSetAndRemove(t *testing.T) {
+	pe := NewPropertiesEditor(testPropertiesContent)
+
+	if err := pe.Set("kotlinVersion", "1.9.10"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := pe.Set("kotlinVersion", "1.9.20"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := pe.Set("org.gradle.jvmargs", "-Xmx4g"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := pe.Set("guavaVersion", "32.1.2-jre"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := pe.Set("app.name", "Demo App"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := pe.Remove("springBootVersion"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := pe.Remove("missing"); !errors.Is(err, ErrPropertyNotFound) {
+		t.Errorf("Expected ErrPropertyNotFound, got %v", err)
+	}
+	if err := pe.Set("", "x"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+
+	if len(pe.GetModifications()) != 4 {
+		t.Errorf("Expected 4 modifications, got %d", len(pe.GetModifications()))
+	}
+
+	text, err := pe.Apply()
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	want := `# Project versions
+kotlinVersion = 1.9.20
+
+! JVM settings
+org.gradle.jvmargs = -Xmx4g
+org.gradle.parallel = true
+guavaVersion = 32.1.2-jre
+app.name = Demo App
+`
+	if text != want {
+		t.Errorf("Apply() =\n%s\nwant\n%s", text, want)
+	}
+}
+
+func TestPropertiesEditorEscaping(t *testing.T) {
+	pe := NewPropertiesEditor("a=1\r\nb:2")
+	values := map[string]string{
+		"b":        " C:\\tools\nnext",
+		"key with": "é",
+	}
+	for _, key := range []string{"b", "key with"} {
+		if err := pe.Set(key, values[key]); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+
+	text, err := pe.Apply()
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if want := "a=1\r\nb:\\ C:\\\\tools\\nnext\r\nkey\\ with=\\u00e9\r\n"; text != want {
+		t.Errorf("Apply() = %q, want %q", text, want)
+	}
+	parsed := config.ParseProperties(text)
+	for key, want := range values {
+		if parsed[key] != want {
+			t.Errorf("Round trip of %q = %q, want %q", key, parsed[key], want)
+		}
+	}
+}
+
+func TestPropertiesEditorDuplicateKeys(t *testing.T) {
+	pe := NewPropertiesEditor("v=1\nv=2\n")
+	if value, _ := pe.Get("v"); value != "2" {
+		t.Errorf("Expected the last value to win, got %q", value)
+	}
+	if err := pe.Set("v", "3"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	text, _ := pe.Apply()
+	if text != "v=1\nv=3\n" {
+		t.Errorf("Unexpected text %q", text)
+	}
+
+	if err := pe.Remove("v"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if text, _ := pe.Apply(); text != "" {
+		t.Errorf("Expected all occurrences to be removed, got %q", text)
+	}
+}
@@ -0,0 +1,127 @@
+// Package editor 提供属性相关的结构化编辑功能。
+package editor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// 项目坐标相关的属性，新属性优先插入到这些属性之后。
+var headerPropertyKeys = map[string]bool{
+	"group":       true,
+	"version":     true,
+	"description": true,
+}
+
+// UpsertProperty 更新顶层属性，属性不存在时在合适的位置插入 key = 'value'。
+// 插入位置依次为：group/version 属性簇之后、plugins（以及settings中的pluginManagement）块之后、文件开头的注释之后。
+func (ge *GradleEditor) UpsertProperty(key, value string) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+	if key == "" {
+		return fmt.Errorf("%w: property key is empty", ErrInvalidArgument)
+	}
+
+	if targetProperty := ge.findTopLevelProperty(key); targetProperty != nil {
+		ge.updateProperty(targetProperty, value)
+		return nil
+	}
+
+	insertLine, needBlankLine := ge.findPropertyInsertLine()
+	newText := ge.formatProperty(key, value) + "\n"
+	if needBlankLine {
+		newText = "\n" + newText
+	}
+	insertPos := ge.lineStartPos(insertLine)
+	if original := ge.sourceMappedProject.OriginalText; insertPos == len(original) &&
+		original != "" && !strings.HasSuffix(original, "\n") {
+		newText = "\n" + newText
+	}
+
+	modification := Modification{
+		Type:        ModificationTypeInsert,
+		SourceRange: pointRange(insertLine, 1, insertPos),
+		OldText:     "",
+		NewText:     newText,
+		Description: fmt.Sprintf("Add property %s = '%s'", key, value),
+	}
+
+	ge.modifications = append(ge.modifications, modification)
+
+	return nil
+}
+
+// findTopLevelProperty 查找不在任何块内的属性。
+func (ge *GradleEditor) findTopLevelProperty(key string) *model.SourceMappedProperty {
+	depths := ge.lineDepths()
+	for _, prop := range ge.sourceMappedProject.SourceMappedProperties {
+		line := prop.SourceRange.Start.Line
+		if prop.Key == key && line >= 1 && line <= len(depths) && depths[line-1] == 0 {
+			return prop
+		}
+	}
+	return nil
+}
+
+// findPropertyInsertLine 查找新属性的插入行（1-based），并返回是否需要额外的空行分隔。
+func (ge *GradleEditor) findPropertyInsertLine() (int, bool) {
+	lines := ge.sourceMappedProject.Lines
+	depths := ge.lineDepths()
+
+	// 1. group/version 属性簇之后。
+	lastHeaderLine := -1
+	for _, prop := range ge.sourceMappedProject.SourceMappedProperties {
+		line := prop.SourceRange.Start.Line
+		if headerPropertyKeys[prop.Key] && line <= len(depths) && depths[line-1] == 0 && line > lastHeaderLine {
+			lastHeaderLine = line
+		}
+	}
+	if lastHeaderLine != -1 {
+		return lastHeaderLine + 1, false
+	}
+
+	// 2. plugins 块之后；settings文件中的 pluginManagement 块必须位于最前面，属性也插入到它之后。
+	lastBlockEnd := -1
+	for _, name := range []string{"pluginManagement", "plugins"} {
+		if start, end := ge.findTopLevelBlock(name); start != -1 && end > lastBlockEnd {
+			lastBlockEnd = end
+		}
+	}
+	if lastBlockEnd != -1 {
+		return lastBlockEnd + 1, true
+	}
+
+	// 3. 文件开头的注释之后。
+	line := 1
+	for line <= len(lines) {
+		trimmed := strings.TrimSpace(lines[line-1])
+		if !strings.HasPrefix(trimmed, "//") && !strings.HasPrefix(trimmed, "/*") && !strings.HasPrefix(trimmed, "*") {
+			break
+		}
+		line++
+	}
+	return line, false
+}
+
+// formatProperty 按生成文本的风格生成属性声明。
+func (ge *GradleEditor) formatProperty(key, value string) string {
+	return ge.assignment(key, ge.quoted(value))
+}
+
+// lineDepths 计算每一行开始时所在的花括号嵌套深度。
+func (ge *GradleEditor) lineDepths() []int {
+	lines := ge.sourceMappedProject.Lines
+	depths := make([]int, len(lines))
+	depth := 0
+	for i, line := range lines {
+		depths[i] = depth
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		if depth < 0 {
+			depth = 0
+		}
+	}
+	return depths
+}
@@ -0,0 +1,101 @@
+package editor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUpsertPropertyUpdatesExisting(t *testing.T) {
+	editor := createTestEditor(t)
+
+	if err := editor.UpsertProperty("version", "1.0.0"); err != nil {
+		t.Fatalf("UpsertProperty() error = %v", err)
+	}
+
+	mods := editor.GetModifications()
+	if len(mods) != 1 || mods[0].Type != ModificationTypeReplace {
+		t.Fatalf("Expected a single replace modification, got %+v", mods)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	if !strings.Contains(newText, "version = '1.0.0'") {
+		t.Errorf("Expected version to be updated, got:\n%s", newText)
+	}
+}
+
+func TestUpsertPropertyInsertsAfterHeader(t *testing.T) {
+	editor := createTestEditor(t)
+
+	// 任务块中的 group 不应被当作顶层属性。
+	if err := editor.UpsertProperty("archivesBaseName", "demo"); err != nil {
+		t.Fatalf("UpsertProperty() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	expected := "description = 'Test project'\narchivesBaseName = 'demo'\n"
+	if !strings.Contains(newText, expected) {
+		t.Errorf("Property should be inserted after the group/version cluster, got:\n%s", newText)
+	}
+}
+
+func TestUpsertPropertyIgnoresNestedProperty(t *testing.T) {
+	content := `plugins {
+    id "java"
+}
+
+task hello {
+    description = "Says hello"
+}
+`
+	editor := createRepositoryTestEditor(t, content)
+
+	if err := editor.UpsertProperty("description", "Demo"); err != nil {
+		t.Fatalf("UpsertProperty() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	expected := "plugins {\n    id \"java\"\n}\n\ndescription = \"Demo\"\n\ntask hello {\n    description = \"Says hello\"\n}\n"
+	if newText != expected {
+		t.Errorf("Unexpected result:\n%s", newText)
+	}
+}
+
+func TestUpsertPropertyWithoutHeader(t *testing.T) {
+	content := "// Build file\ndependencies {\n}"
+	editor := createRepositoryTestEditor(t, content)
+
+	if err := editor.UpsertProperty("group", "com.example"); err != nil {
+		t.Fatalf("UpsertProperty() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	expected := "// Build file\ngroup = 'com.example'\ndependencies {\n}"
+	if newText != expected {
+		t.Errorf("Unexpected result:\n%s", newText)
+	}
+}
+
+func TestUpsertPropertyAtEndOfFile(t *testing.T) {
+	editor := createRepositoryTestEditor(t, "version = '1.0'")
+
+	if err := editor.UpsertProperty("group", "com.example"); err != nil {
+		t.Fatalf("UpsertProperty() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	if newText != "version = '1.0'\ngroup = 'com.example'\n" {
+		t.Errorf("Unexpected result: %q", newText)
+	}
+}
+
+func TestUpsertPropertyErrors(t *testing.T) {
+	editor := NewGradleEditor(nil)
+	if err := editor.UpsertProperty("version", "1.0"); err == nil {
+		t.Error("Expected error for nil project")
+	}
+
+	editor = createTestEditor(t)
+	if err := editor.UpsertProperty("", "1.0"); err == nil {
+		t.Error("Expected error for empty key")
+	}
+}
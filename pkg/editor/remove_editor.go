@@ -0,0 +1,195 @@
+// Package editor 提供删除依赖和插件声明的功能，可以选择注释掉声明而不是直接删除。
+package editor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// defaultRemoveMarker 是注释掉声明时默认使用的标记。
+const defaultRemoveMarker = "removed by bot"
+
+// RemoveOptions 控制删除声明的方式。
+type RemoveOptions struct {
+	// CommentOut 为true时注释掉声明而不是删除，便于人工恢复。
+	// 声明独占若干行时在上方加一行标记注释并用 // 注释掉每一行，否则用 /* ... */ 包住声明。
+	CommentOut bool
+	// Reason 是附加在标记后的删除原因，例如 "CVE-2021-44228"，为空时只写标记。
+	Reason string
+	// Marker 是标记注释的文本，为空时使用 "removed by bot"。
+	Marker string
+}
+
+// DefaultRemoveOptions 返回默认的删除选项：直接删除声明。
+func DefaultRemoveOptions() RemoveOptions {
+	return RemoveOptions{}
+}
+
+// RemoveDependency 删除 group:name 的所有依赖声明。
+func (ge *GradleEditor) RemoveDependency(group, name string) error {
+	return ge.RemoveDependencyWithOptions(group, name, DefaultRemoveOptions())
+}
+
+// RemoveDependencyWithOptions 按指定的删除选项删除或注释掉 group:name 的所有依赖声明。
+func (ge *GradleEditor) RemoveDependencyWithOptions(group, name string, opts RemoveOptions) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+
+	deps := ge.sourceMappedProject.SourceMappedDependencies
+	kept := make([]*model.SourceMappedDependency, 0, len(deps))
+	for _, dep := range deps {
+		if dep.Group != group || dep.Name != name {
+			kept = append(kept, dep)
+			continue
+		}
+		sourceRange, rawText := ge.dependencyStatement(dep)
+		ge.removeStatement(sourceRange, rawText, fmt.Sprintf("dependency %s:%s", group, name), opts)
+	}
+
+	if len(kept) == len(deps) {
+		return fmt.Errorf("%w: %s:%s", ErrDependencyNotFound, group, name)
+	}
+
+	// 更新内存中的依赖信息。
+	ge.sourceMappedProject.SourceMappedDependencies = kept
+	return nil
+}
+
+// RemovePlugin 删除plugins块中的插件声明。
+func (ge *GradleEditor) RemovePlugin(pluginID string) error {
+	return ge.RemovePluginWithOptions(pluginID, DefaultRemoveOptions())
+}
+
+// RemovePluginWithOptions 按指定的删除选项删除或注释掉plugins块中的插件声明。
+func (ge *GradleEditor) RemovePluginWithOptions(pluginID string, opts RemoveOptions) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+
+	plugins := ge.sourceMappedProject.SourceMappedPlugins
+	index := -1
+	for i, plugin := range plugins {
+		if plugin.ID == pluginID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return fmt.Errorf("%w: %s", ErrPluginNotFound, pluginID)
+	}
+
+	targetPlugin := plugins[index]
+	ge.removeStatement(targetPlugin.SourceRange, targetPlugin.RawText, "plugin "+pluginID, opts)
+
+	// 更新内存中的插件信息。
+	ge.sourceMappedProject.SourceMappedPlugins = append(plugins[:index:index], plugins[index+1:]...)
+	return nil
+}
+
+// removeStatement 为一条声明生成删除或注释修改，label 用于修改描述，例如 "plugin java"。
+// 声明独占若干行时删除或注释整行，否则只处理声明本身。
+func (ge *GradleEditor) removeStatement(sourceRange model.SourceRange, rawText, label string, opts RemoveOptions) {
+	firstLine, lastLine := sourceRange.Start.Line, sourceRange.End.Line
+	wholeLines := ge.ownsLines(firstLine, lastLine, rawText)
+	modification := Modification{
+		Type:        ModificationTypeDelete,
+		SourceRange: sourceRange,
+		OldText:     rawText,
+		Description: "Remove " + label,
+	}
+	if wholeLines {
+		startPos := ge.lineStartPos(firstLine)
+		endPos := ge.lineStartPos(lastLine + 1)
+		modification.SourceRange = model.SourceRange{
+			Start: model.SourcePosition{Line: firstLine, Column: 1, StartPos: startPos, EndPos: endPos, Length: endPos - startPos},
+			End:   model.SourcePosition{Line: lastLine + 1, Column: 1, StartPos: endPos, EndPos: endPos},
+		}
+		modification.OldText = ge.sourceMappedProject.OriginalText[startPos:endPos]
+	}
+
+	if opts.CommentOut {
+		marker := opts.Marker
+		if marker == "" {
+			marker = defaultRemoveMarker
+		}
+		if opts.Reason != "" {
+			marker += ": " + opts.Reason
+		}
+
+		modification.Type = ModificationTypeReplace
+		modification.Description = "Comment out " + label
+		if wholeLines {
+			modification.NewText = commentOutLines(modification.OldText, leadingWhitespace(ge.sourceMappedProject.Lines[firstLine-1]), marker)
+		} else {
+			modification.NewText = fmt.Sprintf("/* %s: %s */", marker, rawText)
+		}
+	}
+
+	ge.modifications = append(ge.modifications, modification)
+}
+
+// commentOutLines 用 // 注释掉每一行并保留原来的缩进，在第一行上方加一行标记注释。
+func commentOutLines(text, indent, marker string) string {
+	commented := []string{indent + "// " + marker}
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		lineIndent := leadingWhitespace(line)
+		commented = append(commented, lineIndent+"// "+strings.TrimPrefix(line, lineIndent))
+	}
+
+	newText := strings.Join(commented, "\n")
+	if strings.HasSuffix(text, "\n") {
+		newText += "\n"
+	}
+	return newText
+}
+
+// dependencyStatement 返回依赖所在的整条声明的范围和文本。
+// 依赖的 RawText 只是坐标，声明还包括前面的配置名、括号以及后面的闭包，例如 implementation('a:b:1') { ... }。
+func (ge *GradleEditor) dependencyStatement(dep *model.SourceMappedDependency) (model.SourceRange, string) {
+	text := ge.sourceMappedProject.OriginalText
+	lineNumber := dep.SourceRange.Start.Line
+	lineStart := ge.lineStartPos(lineNumber)
+	start, end := dep.SourceRange.Start.StartPos, dep.SourceRange.End.StartPos
+	if dep.Scope == "" || start < lineStart || end > len(text) {
+		return dep.SourceRange, dep.RawText
+	}
+
+	scopeIndex := strings.LastIndex(text[lineStart:start], dep.Scope)
+	if scopeIndex == -1 {
+		return dep.SourceRange, dep.RawText
+	}
+	if strings.Contains(text[lineStart+scopeIndex:start], "(") {
+		if rest := strings.TrimLeft(text[end:], " \t"); strings.HasPrefix(rest, ")") {
+			end = len(text) - len(rest) + 1
+		}
+	}
+	start = lineStart + scopeIndex
+
+	// 带闭包的声明延伸到闭包结束行的末尾。
+	endLine := strings.Count(text[:end], "\n") + 1
+	if rest := strings.TrimLeft(text[end:], " \t"); strings.HasPrefix(rest, "{") {
+		if blockEnd := ge.findBlockEnd(endLine); blockEnd != -1 {
+			endLine = blockEnd
+			end = ge.lineStartPos(blockEnd) + len(strings.TrimRight(ge.sourceMappedProject.Lines[blockEnd-1], "\r"))
+		}
+	}
+
+	return model.SourceRange{
+		Start: model.SourcePosition{Line: lineNumber, Column: scopeIndex + 1, StartPos: start, EndPos: end, Length: end - start},
+		End:   model.SourcePosition{Line: endLine, Column: end - ge.lineStartPos(endLine) + 1, StartPos: end, EndPos: end},
+	}, text[start:end]
+}
+
+// ownsLines 判断声明是否独占从 firstLine 到 lastLine 的所有行，即这些行去掉首尾空白后正好是声明文本。
+func (ge *GradleEditor) ownsLines(firstLine, lastLine int, rawText string) bool {
+	lines := ge.sourceMappedProject.Lines
+	if firstLine < 1 || lastLine < firstLine || lastLine > len(lines) {
+		return false
+	}
+	text := strings.Join(lines[firstLine-1:lastLine], "\n")
+	return strings.TrimSpace(strings.ReplaceAll(text, "\r", "")) == strings.TrimSpace(strings.ReplaceAll(rawText, "\r", ""))
+}
@@ -0,0 +1,84 @@
+package editor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const removeTestContent = `plugins {
+    id 'java'
+    id 'jacoco'
+}
+
+dependencies {
+    implementation 'com.google.guava:guava:31.1-jre'
+    implementation('org.apache.logging.log4j:log4j-core:2.14.1') {
+        exclude group: 'org.slf4j'
+    }
+    testImplementation 'junit:junit:4.13.2'
+}
+`
+
+func TestRemoveDependency(t *testing.T) {
+	editor := createRepositoryTestEditor(t, removeTestContent)
+	if err := editor.RemoveDependency("org.apache.logging.log4j", "log4j-core"); err != nil {
+		t.Fatalf("RemoveDependency() error = %v", err)
+	}
+	if err := editor.RemovePlugin("jacoco"); err != nil {
+		t.Fatalf("RemovePlugin() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	expected := strings.Replace(strings.Replace(removeTestContent, "    id 'jacoco'\n", "", 1),
+		"    implementation('org.apache.logging.log4j:log4j-core:2.14.1') {\n        exclude group: 'org.slf4j'\n    }\n", "", 1)
+	if newText != expected {
+		t.Errorf("Unexpected text after removal:\n%s", newText)
+	}
+	if len(editor.GetSourceMappedProject().SourceMappedDependencies) != 2 || len(editor.GetSourceMappedProject().SourceMappedPlugins) != 1 {
+		t.Error("Removed declarations should be dropped from the source-mapped project")
+	}
+
+	if err := editor.RemoveDependency("org.foo", "bar"); !errors.Is(err, ErrDependencyNotFound) {
+		t.Errorf("Expected ErrDependencyNotFound, got %v", err)
+	}
+	if err := editor.RemovePlugin("jacoco"); !errors.Is(err, ErrPluginNotFound) {
+		t.Errorf("Expected ErrPluginNotFound, got %v", err)
+	}
+}
+
+func TestRemoveWithCommentOut(t *testing.T) {
+	editor := createRepositoryTestEditor(t, removeTestContent)
+	opts := RemoveOptions{CommentOut: true, Reason: "CVE-2021-44228"}
+	if err := editor.RemoveDependencyWithOptions("org.apache.logging.log4j", "log4j-core", opts); err != nil {
+		t.Fatalf("RemoveDependencyWithOptions() error = %v", err)
+	}
+	if err := editor.RemovePluginWithOptions("jacoco", RemoveOptions{CommentOut: true, Marker: "disabled"}); err != nil {
+		t.Fatalf("RemovePluginWithOptions() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	for _, expected := range []string{
+		"    // disabled\n    // id 'jacoco'\n}",
+		"    // removed by bot: CVE-2021-44228\n" +
+			"    // implementation('org.apache.logging.log4j:log4j-core:2.14.1') {\n" +
+			"        // exclude group: 'org.slf4j'\n" +
+			"    // }\n    testImplementation",
+	} {
+		if !strings.Contains(newText, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, newText)
+		}
+	}
+}
+
+func TestRemoveWithCommentOutInline(t *testing.T) {
+	editor := createRepositoryTestEditor(t, "dependencies {\n    implementation 'a:b:1'; implementation 'c:d:2'\n}\n")
+	if err := editor.RemoveDependencyWithOptions("c", "d", RemoveOptions{CommentOut: true}); err != nil {
+		t.Fatalf("RemoveDependencyWithOptions() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	if !strings.Contains(newText, "implementation 'a:b:1'; /* removed by bot: implementation 'c:d:2' */\n") {
+		t.Errorf("Declarations sharing a line should be wrapped in a block comment, got:\n%s", newText)
+	}
+}
@@ -0,0 +1,115 @@
+// Package editor 提供依赖坐标迁移功能，用于处理制品改名（relocation）。
+package editor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// ReplaceDependency 把 oldGroup:oldName 的所有依赖声明原地改为 newGroup:newName，
+// 例如 mysql:mysql-connector-java 迁移到 com.mysql:mysql-connector-j。
+// 只替换坐标文本，依赖的配置（scope）、引号风格、classifier、扩展名以及后面的闭包配置都保持不变。
+// newVersion 为空时保留原来的版本（包括 $var 引用），否则同时更新版本。
+func (ge *GradleEditor) ReplaceDependency(oldGroup, oldName, newGroup, newName, newVersion string) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+	if oldGroup == "" || oldName == "" || newGroup == "" || newName == "" {
+		return fmt.Errorf("%w: old and new group and name are required", ErrInvalidArgument)
+	}
+
+	found := false
+	for _, dep := range ge.sourceMappedProject.SourceMappedDependencies {
+		if dep.Group != oldGroup || dep.Name != oldName {
+			continue
+		}
+		found = true
+		ge.replaceDependency(dep, newGroup, newName, newVersion)
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s:%s", ErrDependencyNotFound, oldGroup, oldName)
+	}
+	return nil
+}
+
+// replaceDependency 为单个依赖生成坐标替换修改，并同步更新内存中的依赖信息。
+func (ge *GradleEditor) replaceDependency(dep *model.SourceMappedDependency, newGroup, newName, newVersion string) {
+	version := dep.Version
+	if newVersion != "" {
+		version = newVersion
+	}
+
+	newText, ok := replaceDependencyCoordinate(dep.RawText, dep.Group, dep.Name, dep.Version, newGroup, newName, version)
+	if !ok || newText == dep.RawText {
+		return
+	}
+
+	ge.modifications = append(ge.modifications, Modification{
+		Type:        ModificationTypeReplace,
+		SourceRange: dep.SourceRange,
+		OldText:     dep.RawText,
+		NewText:     newText,
+		Description: fmt.Sprintf("Replace dependency %s:%s with %s:%s", dep.Group, dep.Name, newGroup, newName),
+	})
+
+	dep.Group = newGroup
+	dep.Name = newName
+	dep.Version = version
+	dep.RawText = newText
+}
+
+// replaceDependencyCoordinate 改写依赖声明文本中的坐标。
+// 支持 'g:n:v:classifier@ext' 字符串形式和 group: 'g', name: 'n', version: 'v' 映射形式，
+// 无法识别的文本返回 false。
+func replaceDependencyCoordinate(rawText, oldGroup, oldName, oldVersion, newGroup, newName, version string) (string, bool) {
+	if len(rawText) >= 2 && (rawText[0] == '\'' || rawText[0] == '"') && rawText[len(rawText)-1] == rawText[0] {
+		quote := rawText[:1]
+		inner := rawText[1 : len(rawText)-1]
+		prefix := oldGroup + ":" + oldName
+		if !strings.HasPrefix(inner, prefix) {
+			return "", false
+		}
+		rest := inner[len(prefix):]
+		switch {
+		case oldVersion != "" && strings.HasPrefix(rest, ":"+oldVersion):
+			rest = ":" + version + rest[1+len(oldVersion):]
+		case oldVersion == "" && version != "" && (rest == "" || strings.HasPrefix(rest, "@")):
+			rest = ":" + version + rest
+		}
+		return quote + newGroup + ":" + newName + rest + quote, true
+	}
+
+	groupRegex := mapAttrValueRegex("group", oldGroup)
+	nameRegex := mapAttrValueRegex("name", oldName)
+	if !groupRegex.MatchString(rawText) || !nameRegex.MatchString(rawText) {
+		return "", false
+	}
+	newText := groupRegex.ReplaceAllString(rawText, "${1}"+escapeReplacement(newGroup)+"${3}")
+	newText = nameRegex.ReplaceAllString(newText, "${1}"+escapeReplacement(newName)+"${3}")
+
+	switch {
+	case oldVersion != "" && version != oldVersion:
+		newText = replaceDependencyVersion(newText, oldVersion, version)
+	case oldVersion == "" && version != "":
+		// 映射形式原来没有版本，紧跟在 name 属性后面追加，沿用 name 属性的分隔符和引号。
+		newNameRegex := mapAttrValueRegex("name", newName)
+		m := newNameRegex.FindStringSubmatchIndex(newText)
+		quote := newText[m[6]:m[7]]
+		attr := ", version: " + quote + version + quote
+		if newText[m[4]:m[5]] == "=" {
+			attr = ", version = " + quote + version + quote
+		}
+		newText = newText[:m[1]] + attr + newText[m[1]:]
+	}
+	return newText, true
+}
+
+// mapAttrValueRegex 匹配映射形式依赖中指定属性的值，
+// 分组1为属性名、分隔符和左引号，分组2为分隔符，分组3为右引号。
+func mapAttrValueRegex(attr, value string) *regexp.Regexp {
+	return regexp.MustCompile(`(\b` + attr + `\s*([:=])\s*['"])` + regexp.QuoteMeta(value) + `(['"])`)
+}
@@ -0,0 +1,82 @@
+package editor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReplaceDependency(t *testing.T) {
+	editor := createRepositoryTestEditor(t, `dependencies {
+    runtimeOnly 'mysql:mysql-connector-java:8.0.33'
+    implementation("mysql:mysql-connector-java:8.0.33") {
+        exclude group: 'com.google.protobuf'
+    }
+    compileOnly group: 'mysql', name: 'mysql-connector-java', version: '8.0.33'
+    testImplementation "mysql:mysql-connector-java:$mysqlVersion:tests@jar"
+}
+`)
+
+	if err := editor.ReplaceDependency("mysql", "mysql-connector-java", "com.mysql", "mysql-connector-j", ""); err != nil {
+		t.Fatalf("ReplaceDependency() error = %v", err)
+	}
+	if len(editor.GetModifications()) != 4 {
+		t.Fatalf("Expected 4 modifications, got %d", len(editor.GetModifications()))
+	}
+
+	newText := applyEditorModifications(t, editor)
+	for _, expected := range []string{
+		"runtimeOnly 'com.mysql:mysql-connector-j:8.0.33'",
+		"implementation(\"com.mysql:mysql-connector-j:8.0.33\") {\n        exclude group: 'com.google.protobuf'\n    }",
+		"compileOnly group: 'com.mysql', name: 'mysql-connector-j', version: '8.0.33'",
+		"testImplementation \"com.mysql:mysql-connector-j:$mysqlVersion:tests@jar\"",
+	} {
+		if !strings.Contains(newText, expected) {
+			t.Errorf("Expected %q in result, got:\n%s", expected, newText)
+		}
+	}
+
+	for _, dep := range editor.GetSourceMappedProject().SourceMappedDependencies {
+		if dep.Group != "com.mysql" || dep.Name != "mysql-connector-j" {
+			t.Errorf("Expected in-memory dependency to be replaced, got %s:%s", dep.Group, dep.Name)
+		}
+	}
+}
+
+func TestReplaceDependencyWithVersion(t *testing.T) {
+	editor := createRepositoryTestEditor(t, `dependencies {
+    implementation 'mysql:mysql-connector-java:8.0.33'
+    runtimeOnly 'mysql:mysql-connector-java'
+    compileOnly(group = "mysql", name = "mysql-connector-java")
+}
+`)
+
+	if err := editor.ReplaceDependency("mysql", "mysql-connector-java", "com.mysql", "mysql-connector-j", "8.3.0"); err != nil {
+		t.Fatalf("ReplaceDependency() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	for _, expected := range []string{
+		"implementation 'com.mysql:mysql-connector-j:8.3.0'",
+		"runtimeOnly 'com.mysql:mysql-connector-j:8.3.0'",
+		`compileOnly(group = "com.mysql", name = "mysql-connector-j", version = "8.3.0")`,
+	} {
+		if !strings.Contains(newText, expected) {
+			t.Errorf("Expected %q in result, got:\n%s", expected, newText)
+		}
+	}
+}
+
+func TestReplaceDependencyErrors(t *testing.T) {
+	editor := createRepositoryTestEditor(t, "dependencies {\n    implementation 'org.foo:bar:1.0'\n}\n")
+
+	if err := editor.ReplaceDependency("mysql", "mysql-connector-java", "com.mysql", "mysql-connector-j", ""); !errors.Is(err, ErrDependencyNotFound) {
+		t.Errorf("Expected ErrDependencyNotFound, got %v", err)
+	}
+	if err := editor.ReplaceDependency("org.foo", "bar", "", "baz", ""); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+	if len(editor.GetModifications()) != 0 {
+		t.Errorf("Expected no modifications, got %d", len(editor.GetModifications()))
+	}
+}
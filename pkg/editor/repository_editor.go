@@ -0,0 +1,500 @@
+// Package editor 提供仓库相关的结构化编辑功能。
+package editor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// 内置仓库的规范地址，用于按URL匹配 mavenCentral() 等声明。
+var builtinRepositoryURLs = map[string][]string{
+	"mavenCentral":       {"https://repo.maven.apache.org/maven2", "https://repo1.maven.org/maven2"},
+	"google":             {"https://dl.google.com/dl/android/maven2", "https://maven.google.com"},
+	"jcenter":            {"https://jcenter.bintray.com"},
+	"gradlePluginPortal": {"https://plugins.gradle.org/m2"},
+}
+
+// AddRepository 在repositories块中添加仓库。
+// url为空时按内置仓库处理（例如 mavenCentral()），否则添加 maven { url '...' } 声明。
+// 已经声明了该仓库（url为空时按名称，否则按URL匹配）时不生成修改，返回包装了 ErrAlreadyExists 的错误。
+func (ge *GradleEditor) AddRepository(name, url string) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+	if name == "" && url == "" {
+		return fmt.Errorf("%w: repository name and url are both empty", ErrInvalidArgument)
+	}
+
+	key := url
+	if key == "" {
+		key = name
+	}
+	if ge.findRepositoryIndex(key) != -1 {
+		return fmt.Errorf("%w: repository %s", ErrAlreadyExists, repositoryLabel(name, url))
+	}
+
+	startLine, endLine := ge.findTopLevelBlock("repositories")
+	if startLine == -1 || endLine == -1 {
+		return ErrRepositoriesBlockMissing
+	}
+
+	newText := ge.detectBlockIndent(startLine, endLine) + ge.formatRepository(name, url) + "\n"
+	modification := ge.blockInsertion(startLine, endLine, endLine, newText)
+	modification.Description = fmt.Sprintf("Add repository %s", repositoryLabel(name, url))
+
+	ge.modifications = append(ge.modifications, modification)
+
+	return nil
+}
+
+// RemoveRepository 删除名称或URL匹配的仓库声明。
+func (ge *GradleEditor) RemoveRepository(nameOrURL string) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+
+	index := ge.findRepositoryIndex(nameOrURL)
+	if index == -1 {
+		return fmt.Errorf("%w: %s", ErrRepositoryNotFound, nameOrURL)
+	}
+	targetRepo := ge.sourceMappedProject.SourceMappedRepositories[index]
+
+	modification := Modification{
+		Type:        ModificationTypeDelete,
+		SourceRange: ge.statementDeleteRange(targetRepo.SourceRange, targetRepo.RawText),
+		NewText:     "",
+		Description: fmt.Sprintf("Remove repository %s", repositoryLabel(targetRepo.Name, targetRepo.URL)),
+	}
+
+	modification.OldText = ge.sourceMappedProject.OriginalText[modification.SourceRange.Start.StartPos:modification.SourceRange.End.StartPos]
+	ge.modifications = append(ge.modifications, modification)
+
+	// 更新内存中的仓库信息。
+	repos := ge.sourceMappedProject.SourceMappedRepositories
+	ge.sourceMappedProject.SourceMappedRepositories = append(repos[:index:index], repos[index+1:]...)
+
+	return nil
+}
+
+// ReplaceRepositoryURL 将仓库地址替换为新的地址。
+// oldURL 也可以是内置仓库名称或其规范地址，此时整条声明被替换为 maven { url '...' }。
+func (ge *GradleEditor) ReplaceRepositoryURL(oldURL, newURL string) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+
+	index := ge.findRepositoryIndex(oldURL)
+	if index == -1 {
+		return fmt.Errorf("%w: %s", ErrRepositoryNotFound, oldURL)
+	}
+
+	ge.replaceRepositoryURLAt(index, newURL)
+	return nil
+}
+
+// RewriteRepositoriesToMirrors 把所有匹配镜像映射的仓库替换为镜像地址，返回被替换的仓库数。
+// 映射的键可以是内置仓库名称（例如 mavenCentral）或仓库地址，值为镜像地址；
+// 以 maven { url '...' } 声明的内置仓库规范地址同样会按名称匹配，已经指向镜像的仓库保持不变。
+func (ge *GradleEditor) RewriteRepositoriesToMirrors(mirrors map[string]string) (int, error) {
+	if ge.sourceMappedProject == nil {
+		return 0, ErrNilProject
+	}
+
+	normalized := make(map[string]string, len(mirrors))
+	mirrorURLs := make(map[string]bool, len(mirrors))
+	for key, url := range mirrors {
+		if url == "" {
+			return 0, fmt.Errorf("%w: mirror url for %s is empty", ErrInvalidArgument, key)
+		}
+		normalized[strings.TrimSuffix(key, "/")] = url
+		mirrorURLs[strings.TrimSuffix(url, "/")] = true
+	}
+
+	count := 0
+	for i, repo := range ge.sourceMappedProject.SourceMappedRepositories {
+		if repo.URL != "" && mirrorURLs[strings.TrimSuffix(repo.URL, "/")] {
+			continue
+		}
+		if url, ok := mirrorFor(repo.Repository, normalized); ok {
+			ge.replaceRepositoryURLAt(i, url)
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// AddRepositoryContentFilter 为名称或URL匹配的仓库加入内容过滤条件，例如排除内部group，防止依赖混淆。
+// mavenCentral() 等内置仓库改写为 mavenCentral { content { ... } }，单行的 maven { url '...' } 在块内追加 content { }，
+// 多行的 maven 块在url语句后插入一行。声明了 releasesOnly/snapshotsOnly 时使用 mavenContent { }。
+func (ge *GradleEditor) AddRepositoryContentFilter(nameOrURL string, content *model.RepositoryContent) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+	if content == nil || (len(content.Includes) == 0 && len(content.Excludes) == 0 && !content.ReleasesOnly && !content.SnapshotsOnly) {
+		return fmt.Errorf("%w: content filter is empty", ErrInvalidArgument)
+	}
+
+	index := ge.findRepositoryIndex(nameOrURL)
+	if index == -1 {
+		return fmt.Errorf("%w: %s", ErrRepositoryNotFound, nameOrURL)
+	}
+	targetRepo := ge.sourceMappedProject.SourceMappedRepositories[index]
+	filter, err := ge.formatRepositoryContent(content)
+	if err != nil {
+		return err
+	}
+	description := fmt.Sprintf("Add content filter to repository %s", repositoryLabel(targetRepo.Name, targetRepo.URL))
+
+	raw := strings.TrimSpace(targetRepo.RawText)
+	switch {
+	case strings.HasSuffix(raw, "()"):
+		newText := strings.TrimSuffix(raw, "()") + " { " + filter + " }"
+		ge.modifications = append(ge.modifications, Modification{
+			Type:        ModificationTypeReplace,
+			SourceRange: targetRepo.SourceRange,
+			OldText:     targetRepo.RawText,
+			NewText:     newText,
+			Description: description,
+		})
+		targetRepo.RawText = newText
+	case strings.HasSuffix(raw, "}"):
+		closing := strings.LastIndex(targetRepo.RawText, "}")
+		body := strings.TrimRight(targetRepo.RawText[:closing], " \t")
+		newText := body + "; " + filter + " " + targetRepo.RawText[closing:]
+		ge.modifications = append(ge.modifications, Modification{
+			Type:        ModificationTypeReplace,
+			SourceRange: targetRepo.SourceRange,
+			OldText:     targetRepo.RawText,
+			NewText:     newText,
+			Description: description,
+		})
+		targetRepo.RawText = newText
+	default:
+		// 多行块中只映射了url语句，在其后插入一行，缩进与url语句相同。
+		line := targetRepo.SourceRange.End.Line
+		indent := leadingWhitespace(ge.sourceMappedProject.GetLineText(line))
+		insertPos := ge.lineStartPos(line + 1)
+		ge.modifications = append(ge.modifications, Modification{
+			Type:        ModificationTypeInsert,
+			SourceRange: pointRange(line+1, 1, insertPos),
+			NewText:     indent + filter + "\n",
+			Description: description,
+		})
+	}
+	return nil
+}
+
+// formatRepositoryContent 生成 content { } 或 mavenContent { } 块的单行文本。
+func (ge *GradleEditor) formatRepositoryContent(content *model.RepositoryContent) (string, error) {
+	statements := make([]string, 0, len(content.Includes)+len(content.Excludes)+2)
+	for _, group := range []struct {
+		prefix string
+		rules  []*model.ContentRule
+	}{{"include", content.Includes}, {"exclude", content.Excludes}} {
+		for _, rule := range group.rules {
+			if rule == nil || rule.Kind == "" {
+				return "", fmt.Errorf("%w: content rule without kind", ErrInvalidArgument)
+			}
+			args := []string{rule.Group}
+			switch rule.Kind {
+			case model.ContentRuleModule, model.ContentRuleModuleByRegex:
+				args = append(args, rule.Module)
+			case model.ContentRuleVersion, model.ContentRuleVersionByRegex:
+				args = append(args, rule.Module, rule.Version)
+			}
+			statements = append(statements, ge.formatCall(group.prefix+strings.ToUpper(rule.Kind[:1])+rule.Kind[1:], args))
+		}
+	}
+
+	block := "content"
+	if content.ReleasesOnly || content.SnapshotsOnly {
+		block = "mavenContent"
+		if content.ReleasesOnly {
+			statements = append(statements, "releasesOnly()")
+		}
+		if content.SnapshotsOnly {
+			statements = append(statements, "snapshotsOnly()")
+		}
+	}
+	return block + " { " + strings.Join(statements, "; ") + " }", nil
+}
+
+// formatCall 生成带字符串参数的方法调用：Groovy DSL 为 name 'a', 'b'，Kotlin DSL 为 name("a", "b")。
+// 参数中的反斜杠按字符串字面量转义，使用双引号时 $ 也会被转义。
+func (ge *GradleEditor) formatCall(name string, args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		arg = strings.ReplaceAll(arg, `\`, `\\`)
+		if ge.quote() == `"` {
+			quoted[i] = `"` + strings.ReplaceAll(strings.ReplaceAll(arg, `"`, `\"`), "$", `\$`) + `"`
+		} else {
+			quoted[i] = "'" + strings.ReplaceAll(arg, "'", `\'`) + "'"
+		}
+	}
+	if ge.isKotlinDSL() {
+		return name + "(" + strings.Join(quoted, ", ") + ")"
+	}
+	return name + " " + strings.Join(quoted, ", ")
+}
+
+// mirrorFor 按仓库名称、地址以及地址对应的内置仓库名称查找镜像地址。
+func mirrorFor(repo *model.Repository, mirrors map[string]string) (string, bool) {
+	url := strings.TrimSuffix(repo.URL, "/")
+	candidates := []string{repo.Name}
+	if url != "" {
+		candidates = append(candidates, url)
+		for name, builtinURLs := range builtinRepositoryURLs {
+			for _, builtinURL := range builtinURLs {
+				if builtinURL == url {
+					candidates = append(candidates, name)
+				}
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if mirror, ok := mirrors[candidate]; ok && candidate != "" {
+			return mirror, true
+		}
+	}
+	return "", false
+}
+
+// replaceRepositoryURLAt 把指定索引的仓库地址替换为新的地址。
+func (ge *GradleEditor) replaceRepositoryURLAt(index int, newURL string) {
+	targetRepo := ge.sourceMappedProject.SourceMappedRepositories[index]
+
+	if targetRepo.URL == newURL {
+		return
+	}
+
+	var newText string
+	if targetRepo.URL != "" {
+		// 只替换URL，保留原有格式。
+		newText = strings.Replace(targetRepo.RawText, targetRepo.URL, newURL, 1)
+	} else {
+		newText = ge.formatRepository("", newURL)
+	}
+
+	modification := Modification{
+		Type:        ModificationTypeReplace,
+		SourceRange: targetRepo.SourceRange,
+		OldText:     targetRepo.RawText,
+		NewText:     newText,
+		Description: fmt.Sprintf("Replace repository %s with %s", repositoryLabel(targetRepo.Name, targetRepo.URL), newURL),
+	}
+
+	ge.modifications = append(ge.modifications, modification)
+
+	// 更新内存中的仓库信息。
+	targetRepo.URL = newURL
+	targetRepo.RawText = newText
+}
+
+// statementDeleteRange 计算删除一条声明时的范围。
+// 声明独占一行时删除整行（含换行符），位于多行 maven { ... } 块中时删除整个块，否则只删除声明本身。
+func (ge *GradleEditor) statementDeleteRange(sourceRange model.SourceRange, rawText string) model.SourceRange {
+	firstLine := sourceRange.Start.Line
+	lastLine := sourceRange.End.Line
+
+	if blockStart, blockEnd := ge.findEnclosingMavenBlock(firstLine); blockStart != -1 {
+		firstLine, lastLine = blockStart, blockEnd
+	} else if strings.TrimSpace(ge.sourceMappedProject.GetLineText(firstLine)) != rawText {
+		return sourceRange
+	}
+
+	startPos := ge.lineStartPos(firstLine)
+	endPos := ge.lineStartPos(lastLine + 1)
+	return model.SourceRange{
+		Start: model.SourcePosition{Line: firstLine, Column: 1, StartPos: startPos, EndPos: endPos, Length: endPos - startPos},
+		End:   model.SourcePosition{Line: lastLine + 1, Column: 1, StartPos: endPos, EndPos: endPos},
+	}
+}
+
+// findRepositoryIndex 按名称或URL查找仓库的索引。
+func (ge *GradleEditor) findRepositoryIndex(nameOrURL string) int {
+	target := strings.TrimSuffix(nameOrURL, "/")
+	for i, repo := range ge.sourceMappedProject.SourceMappedRepositories {
+		if repo.Name == nameOrURL || (repo.URL != "" && strings.TrimSuffix(repo.URL, "/") == target) {
+			return i
+		}
+		for _, builtinURL := range builtinRepositoryURLs[repo.Name] {
+			if repo.URL == "" && builtinURL == target {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// formatRepository 生成仓库声明文本。
+func (ge *GradleEditor) formatRepository(name, url string) string {
+	if url == "" {
+		return name + "()"
+	}
+	if ge.isKotlinDSL() {
+		if name != "" {
+			return fmt.Sprintf("maven { %s; %s }", ge.assignment("name", ge.quoted(name)), ge.assignment("url", "uri("+ge.quoted(url)+")"))
+		}
+		return fmt.Sprintf("maven { %s }", ge.assignment("url", "uri("+ge.quoted(url)+")"))
+	}
+	if name != "" {
+		return fmt.Sprintf("maven { name %s; url %s }", ge.quoted(name), ge.quoted(url))
+	}
+	return fmt.Sprintf("maven { url %s }", ge.quoted(url))
+}
+
+// isKotlinDSL 判断当前编辑的文件是否是Kotlin DSL。
+func (ge *GradleEditor) isKotlinDSL() bool {
+	return ge.sourceMappedProject != nil && ge.sourceMappedProject.Project != nil &&
+		strings.HasSuffix(ge.sourceMappedProject.FilePath, ".kts")
+}
+
+// findTopLevelBlock 查找顶层（不在其他块内）的指定名称块，返回起止行号（1-based）。
+// 找不到顶层块时退回到第一个同名块。
+func (ge *GradleEditor) findTopLevelBlock(name string) (int, int) {
+	lines := ge.sourceMappedProject.Lines
+	fallback := -1
+	depth := 0
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, name) && strings.Contains(trimmed, "{") {
+			rest := strings.TrimSpace(strings.TrimPrefix(trimmed, name))
+			if strings.HasPrefix(rest, "{") {
+				if depth == 0 {
+					return i + 1, ge.findBlockEnd(i + 1)
+				}
+				if fallback == -1 {
+					fallback = i + 1
+				}
+			}
+		}
+		depth += strings.Count(trimmed, "{") - strings.Count(trimmed, "}")
+	}
+
+	if fallback == -1 {
+		return -1, -1
+	}
+	return fallback, ge.findBlockEnd(fallback)
+}
+
+// findBlockEnd 查找从指定行开始的块的结束行（1-based）。
+func (ge *GradleEditor) findBlockEnd(startLine int) int {
+	depth := 0
+	started := false
+
+	for i := startLine - 1; i < len(ge.sourceMappedProject.Lines); i++ {
+		line := ge.sourceMappedProject.Lines[i]
+		opens := strings.Count(line, "{")
+		closes := strings.Count(line, "}")
+		if opens > 0 {
+			started = true
+		}
+		depth += opens - closes
+		if started && depth <= 0 {
+			return i + 1
+		}
+	}
+
+	return -1
+}
+
+// findEnclosingMavenBlock 如果指定行位于多行 maven { ... } 块中，返回该块的起止行。
+func (ge *GradleEditor) findEnclosingMavenBlock(lineNumber int) (int, int) {
+	lines := ge.sourceMappedProject.Lines
+	if lineNumber < 1 || lineNumber > len(lines) {
+		return -1, -1
+	}
+
+	// 单行声明不需要扩展。
+	if strings.Contains(lines[lineNumber-1], "{") {
+		return -1, -1
+	}
+
+	depth := 0
+	for i := lineNumber - 2; i >= 0; i-- {
+		trimmed := strings.TrimSpace(lines[i])
+		depth += strings.Count(trimmed, "}") - strings.Count(trimmed, "{")
+		if depth < 0 {
+			if strings.HasPrefix(trimmed, "maven") {
+				end := ge.findBlockEnd(i + 1)
+				if end != -1 {
+					return i + 1, end
+				}
+			}
+			return -1, -1
+		}
+	}
+
+	return -1, -1
+}
+
+// lineStartPos 计算指定行（1-based）在原始文本中的起始位置。
+func (ge *GradleEditor) lineStartPos(lineNumber int) int {
+	pos := 0
+	for i := 0; i < lineNumber-1 && i < len(ge.sourceMappedProject.Lines); i++ {
+		pos += len(ge.sourceMappedProject.Lines[i]) + 1 // +1 for newline。
+	}
+	if pos > len(ge.sourceMappedProject.OriginalText) {
+		pos = len(ge.sourceMappedProject.OriginalText)
+	}
+	return pos
+}
+
+// blockInsertion 返回在块中 insertLine 行之前插入整行文本 newText（以换行结尾）的修改。
+// 块的开始和结束在同一行（例如 repositories { mavenCentral() }）时插入到闭合的 } 之前：
+// 新文本另起一行，} 移到下一行并保持该行的缩进。
+func (ge *GradleEditor) blockInsertion(blockStart, blockEnd, insertLine int, newText string) Modification {
+	lines := ge.sourceMappedProject.Lines
+	if blockStart == blockEnd && insertLine == blockEnd {
+		line := lines[blockEnd-1]
+		if brace := strings.LastIndexByte(line, '}'); brace != -1 {
+			lineStart := ge.lineStartPos(blockEnd)
+			contentEnd := len(strings.TrimRight(line[:brace], " \t"))
+			start, end := lineStart+contentEnd, lineStart+brace
+			return Modification{
+				Type: ModificationTypeReplace,
+				SourceRange: model.SourceRange{
+					Start: model.SourcePosition{Line: blockEnd, Column: contentEnd + 1, StartPos: start, EndPos: end, Length: end - start},
+					End:   model.SourcePosition{Line: blockEnd, Column: brace + 1, StartPos: end, EndPos: end},
+				},
+				OldText: ge.sourceMappedProject.OriginalText[start:end],
+				NewText: "\n" + newText + leadingWhitespace(line),
+			}
+		}
+	}
+
+	return Modification{
+		Type:        ModificationTypeInsert,
+		SourceRange: pointRange(insertLine, 1, ge.lineStartPos(insertLine)),
+		OldText:     "",
+		NewText:     newText,
+	}
+}
+
+// pointRange 创建一个零长度的位置范围，用于插入操作。
+func pointRange(line, column, pos int) model.SourceRange {
+	position := model.SourcePosition{
+		Line:     line,
+		Column:   column,
+		StartPos: pos,
+		EndPos:   pos,
+		Length:   0,
+	}
+	return model.SourceRange{Start: position, End: position}
+}
+
+// repositoryLabel 返回仓库的描述文本。
+func repositoryLabel(name, url string) string {
+	if url == "" {
+		return name
+	}
+	if name == "" {
+		return url
+	}
+	return fmt.Sprintf("%s (%s)", name, url)
+}
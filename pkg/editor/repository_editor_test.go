@@ -0,0 +1,290 @@
+package editor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/parser"
+)
+
+const repositoryTestContent = `buildscript {
+    repositories {
+        gradlePluginPortal()
+    }
+}
+
+repositories {
+    mavenCentral()
+    google()
+    maven {
+        url 'https://repo.spring.io/milestone'
+    }
+    maven { url 'https://jitpack.io' }
+}
+
+dependencies {
+    implementation 'com.google.guava:guava:31.1-jre'
+}
+`
+
+func createRepositoryTestEditor(t *testing.T, content string) *GradleEditor {
+	result, err := parser.NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("Failed to parse test content: %v", err)
+	}
+	return NewGradleEditor(result.SourceMappedProject)
+}
+
+func applyEditorModifications(t *testing.T, editor *GradleEditor) string {
+	serializer := NewGradleSerializer(editor.GetSourceMappedProject().OriginalText)
+	newText, err := serializer.ApplyModifications(editor.GetModifications())
+	if err != nil {
+		t.Fatalf("Failed to apply modifications: %v", err)
+	}
+	return newText
+}
+
+func TestAddRepository(t *testing.T) {
+	t.Run("Maven URL", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, repositoryTestContent)
+		if err := editor.AddRepository("", "https://artifactory.example.com/maven"); err != nil {
+			t.Fatalf("AddRepository() error = %v", err)
+		}
+
+		newText := applyEditorModifications(t, editor)
+		expected := "    maven { url 'https://jitpack.io' }\n    maven { url 'https://artifactory.example.com/maven' }\n}"
+		if !strings.Contains(newText, expected) {
+			t.Errorf("Repository should be appended to the top-level repositories block, got:\n%s", newText)
+		}
+	})
+
+	t.Run("Named maven URL", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, repositoryTestContent)
+		if err := editor.AddRepository("corp", "https://artifactory.example.com/maven"); err != nil {
+			t.Fatalf("AddRepository() error = %v", err)
+		}
+
+		newText := applyEditorModifications(t, editor)
+		if !strings.Contains(newText, "maven { name 'corp'; url 'https://artifactory.example.com/maven' }") {
+			t.Errorf("Named repository not added, got:\n%s", newText)
+		}
+	})
+
+	t.Run("Builtin repository", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, repositoryTestContent)
+		if err := editor.AddRepository("mavenLocal", ""); err != nil {
+			t.Fatalf("AddRepository() error = %v", err)
+		}
+
+		newText := applyEditorModifications(t, editor)
+		if !strings.Contains(newText, "    mavenLocal()\n}") {
+			t.Errorf("mavenLocal() not added, got:\n%s", newText)
+		}
+	})
+
+	t.Run("One-line block", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, "repositories { mavenCentral() }\n")
+		if err := editor.AddRepository("", "https://artifactory.example.com/maven"); err != nil {
+			t.Fatalf("AddRepository() error = %v", err)
+		}
+
+		newText := applyEditorModifications(t, editor)
+		expected := "repositories { mavenCentral()\n    maven { url 'https://artifactory.example.com/maven' }\n}\n"
+		if newText != expected {
+			t.Errorf("Repository should be inserted before the closing brace, got:\n%s", newText)
+		}
+	})
+
+	t.Run("No repositories block", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, "dependencies {\n}\n")
+		if err := editor.AddRepository("mavenCentral", ""); err == nil {
+			t.Error("Expected error when repositories block is missing")
+		}
+	})
+}
+
+func TestRemoveRepository(t *testing.T) {
+	t.Run("Builtin by name", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, repositoryTestContent)
+		if err := editor.RemoveRepository("google"); err != nil {
+			t.Fatalf("RemoveRepository() error = %v", err)
+		}
+
+		newText := applyEditorModifications(t, editor)
+		if strings.Contains(newText, "google()") {
+			t.Errorf("google() should be removed, got:\n%s", newText)
+		}
+		if !strings.Contains(newText, "    mavenCentral()\n    maven {") {
+			t.Errorf("Whole line should be removed without leaving blank lines, got:\n%s", newText)
+		}
+	})
+
+	t.Run("Multi-line maven block by URL", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, repositoryTestContent)
+		if err := editor.RemoveRepository("https://repo.spring.io/milestone/"); err != nil {
+			t.Fatalf("RemoveRepository() error = %v", err)
+		}
+
+		newText := applyEditorModifications(t, editor)
+		if strings.Contains(newText, "repo.spring.io") {
+			t.Errorf("Spring milestone repository should be removed, got:\n%s", newText)
+		}
+		if !strings.Contains(newText, "    google()\n    maven { url 'https://jitpack.io' }") {
+			t.Errorf("Whole maven block should be removed, got:\n%s", newText)
+		}
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, repositoryTestContent)
+		if err := editor.RemoveRepository("jcenter"); err == nil {
+			t.Error("Expected error for missing repository")
+		}
+	})
+}
+
+func TestReplaceRepositoryURL(t *testing.T) {
+	t.Run("Maven URL", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, repositoryTestContent)
+		if err := editor.ReplaceRepositoryURL("https://jitpack.io", "https://mirror.example.com/jitpack"); err != nil {
+			t.Fatalf("ReplaceRepositoryURL() error = %v", err)
+		}
+
+		newText := applyEditorModifications(t, editor)
+		if !strings.Contains(newText, "maven { url 'https://mirror.example.com/jitpack' }") {
+			t.Errorf("URL not replaced, got:\n%s", newText)
+		}
+	})
+
+	t.Run("Builtin repository to mirror", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, repositoryTestContent)
+		if err := editor.ReplaceRepositoryURL("https://repo.maven.apache.org/maven2/", "https://artifactory.example.com/maven"); err != nil {
+			t.Fatalf("ReplaceRepositoryURL() error = %v", err)
+		}
+
+		newText := applyEditorModifications(t, editor)
+		if strings.Contains(newText, "    mavenCentral()") {
+			t.Errorf("mavenCentral() should be replaced, got:\n%s", newText)
+		}
+		if !strings.Contains(newText, "    maven { url 'https://artifactory.example.com/maven' }\n    google()") {
+			t.Errorf("mirror declaration not found in place, got:\n%s", newText)
+		}
+
+		// gradlePluginPortal() 在buildscript中，不应受影响。
+		if !strings.Contains(newText, "gradlePluginPortal()") {
+			t.Error("Unrelated repositories should be preserved")
+		}
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, repositoryTestContent)
+		if err := editor.ReplaceRepositoryURL("https://nowhere.example.com", "https://x"); err == nil {
+			t.Error("Expected error for missing repository")
+		}
+	})
+}
+
+func TestAddRepositoryContentFilter(t *testing.T) {
+	exclusion := &model.RepositoryContent{Excludes: []*model.ContentRule{
+		{Kind: model.ContentRuleGroupByRegex, Group: `com\.mycorp(\..*)?`},
+	}}
+
+	editor := createRepositoryTestEditor(t, repositoryTestContent)
+	for _, repo := range []string{"mavenCentral", "https://jitpack.io", "https://repo.spring.io/milestone"} {
+		if err := editor.AddRepositoryContentFilter(repo, exclusion); err != nil {
+			t.Fatalf("AddRepositoryContentFilter(%s) error = %v", repo, err)
+		}
+	}
+
+	newText := applyEditorModifications(t, editor)
+	for _, expected := range []string{
+		`    mavenCentral { content { excludeGroupByRegex 'com\\.mycorp(\\..*)?' } }`,
+		`    maven { url 'https://jitpack.io'; content { excludeGroupByRegex 'com\\.mycorp(\\..*)?' } }`,
+		"        url 'https://repo.spring.io/milestone'\n        content { excludeGroupByRegex",
+	} {
+		if !strings.Contains(newText, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, newText)
+		}
+	}
+
+	t.Run("Kotlin DSL", func(t *testing.T) {
+		result, err := parser.NewSourceAwareParser().ParseWithSourceMapping("repositories {\n    google()\n}\n")
+		if err != nil {
+			t.Fatalf("Failed to parse test content: %v", err)
+		}
+		result.SourceMappedProject.FilePath = "build.gradle.kts"
+		editor := NewGradleEditor(result.SourceMappedProject)
+		content := &model.RepositoryContent{
+			Includes:     []*model.ContentRule{{Kind: model.ContentRuleModule, Group: "com.example", Module: "lib"}},
+			ReleasesOnly: true,
+		}
+		if err := editor.AddRepositoryContentFilter("google", content); err != nil {
+			t.Fatalf("AddRepositoryContentFilter() error = %v", err)
+		}
+		newText := applyEditorModifications(t, editor)
+		expected := `google { mavenContent { includeModule("com.example", "lib"); releasesOnly() } }`
+		if !strings.Contains(newText, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, newText)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		editor := createRepositoryTestEditor(t, repositoryTestContent)
+		if err := editor.AddRepositoryContentFilter("https://nowhere.example.com", exclusion); !errors.Is(err, ErrRepositoryNotFound) {
+			t.Errorf("Expected ErrRepositoryNotFound, got %v", err)
+		}
+		if err := editor.AddRepositoryContentFilter("google", &model.RepositoryContent{}); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Expected ErrInvalidArgument, got %v", err)
+		}
+	})
+}
+
+func TestRewriteRepositoriesToMirrors(t *testing.T) {
+	content := `buildscript {
+    repositories {
+        mavenCentral()
+    }
+}
+
+repositories {
+    mavenCentral()
+    maven { url 'https://repo1.maven.org/maven2/' }
+    maven { url 'https://jitpack.io' }
+    maven { url 'https://nexus.corp/google' }
+}
+`
+	editor := createRepositoryTestEditor(t, content)
+	count, err := editor.RewriteRepositoriesToMirrors(map[string]string{
+		"mavenCentral":       "https://nexus.corp/maven-central",
+		"google":             "https://nexus.corp/google",
+		"https://jitpack.io": "https://nexus.corp/jitpack",
+	})
+	if err != nil {
+		t.Fatalf("RewriteRepositoriesToMirrors() error = %v", err)
+	}
+	if count != 4 {
+		t.Errorf("Expected 4 rewritten repositories, got %d", count)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	if strings.Contains(newText, "mavenCentral()") || strings.Contains(newText, "repo1.maven.org") {
+		t.Errorf("mavenCentral declarations should all be rewritten, got:\n%s", newText)
+	}
+	if strings.Count(newText, "https://nexus.corp/maven-central") != 3 {
+		t.Errorf("Expected 3 maven-central mirror declarations, got:\n%s", newText)
+	}
+	if !strings.Contains(newText, "maven { url 'https://nexus.corp/jitpack' }") {
+		t.Errorf("jitpack not rewritten, got:\n%s", newText)
+	}
+
+	// 已指向镜像的仓库不会重复修改。
+	count, err = editor.RewriteRepositoriesToMirrors(map[string]string{"mavenCentral": "https://nexus.corp/maven-central"})
+	if err != nil || count != 0 {
+		t.Errorf("Expected no further rewrites, got %d (err %v)", count, err)
+	}
+
+	if _, err := editor.RewriteRepositoriesToMirrors(map[string]string{"google": ""}); err == nil {
+		t.Error("Expected error for empty mirror url")
+	}
+}
@@ -0,0 +1,140 @@
+// Package editor 提供编辑器生成文本时使用的格式风格。
+package editor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// 匹配属性赋值中的等号及其两侧的空白，例如 version = '1.0' 或 version='1.0'。
+var assignmentRegex = regexp.MustCompile(`^[\w.]+(\s*)=(\s*)[^=]`)
+
+// EditorStyle 控制编辑器生成的文本（新增的依赖、仓库、属性、约束等）的格式。
+type EditorStyle struct {
+	// Quote 是Groovy DSL中字符串使用的引号，"'" 或 "\""。Kotlin DSL 总是使用双引号。
+	Quote string
+	// Indent 是一级缩进，例如 "    " 或 "\t"。
+	Indent string
+	// SpaceAroundEquals 为true时赋值写作 key = value，否则写作 key=value。
+	SpaceAroundEquals bool
+	// ParenthesizedCalls 为true时依赖声明写作 scope("g:a:v")，否则写作 scope 'g:a:v'。Kotlin DSL 总是使用括号。
+	ParenthesizedCalls bool
+}
+
+// DefaultEditorStyle 返回默认风格：单引号、四个空格缩进、等号两侧有空格。
+func DefaultEditorStyle() EditorStyle {
+	return EditorStyle{Quote: "'", Indent: "    ", SpaceAroundEquals: true}
+}
+
+// DetectEditorStyle 根据文件中已有的声明推断风格。
+// 引号取依赖、插件、仓库和属性中使用较多的一种，缩进取文件中第一个缩进的行，等号空白取第一个属性赋值，
+// 依赖声明的调用写法取依赖中使用较多的一种（.kts 文件总是使用括号）；推断不出的部分使用 DefaultEditorStyle。
+func DetectEditorStyle(project *model.SourceMappedProject) EditorStyle {
+	style := DefaultEditorStyle()
+	if project == nil {
+		return style
+	}
+
+	var single, double int
+	count := func(rawText string) {
+		if i := strings.IndexAny(rawText, `'"`); i != -1 {
+			if rawText[i] == '\'' {
+				single++
+			} else {
+				double++
+			}
+		}
+	}
+	for _, dep := range project.SourceMappedDependencies {
+		count(dep.RawText)
+	}
+	for _, plugin := range project.SourceMappedPlugins {
+		count(plugin.RawText)
+	}
+	for _, repo := range project.SourceMappedRepositories {
+		count(repo.RawText)
+	}
+	for _, prop := range project.SourceMappedProperties {
+		count(prop.RawText)
+	}
+	kotlin := project.Project != nil && strings.HasSuffix(project.FilePath, ".kts")
+	if double > single || kotlin {
+		style.Quote = "\""
+	}
+
+	// 依赖的 RawText 只是坐标，看坐标前的字符判断是否写作 scope(...)。
+	var parenthesized, bare int
+	for _, dep := range project.SourceMappedDependencies {
+		before := strings.TrimRight(project.OriginalText[:min(dep.SourceRange.Start.StartPos, len(project.OriginalText))], " \t")
+		if strings.HasSuffix(before, "(") {
+			parenthesized++
+		} else {
+			bare++
+		}
+	}
+	style.ParenthesizedCalls = parenthesized > bare || kotlin
+
+	for _, line := range project.Lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if indent := leadingWhitespace(line); indent != "" {
+			style.Indent = indent
+			break
+		}
+	}
+
+	for _, prop := range project.SourceMappedProperties {
+		if matches := assignmentRegex.FindStringSubmatch(strings.TrimSpace(prop.RawText)); matches != nil {
+			style.SpaceAroundEquals = matches[1] != "" || matches[2] != ""
+			break
+		}
+	}
+
+	return style
+}
+
+// SetStyle 显式设置生成文本的风格，之后生成的所有文本都使用该风格，缩进也不再根据所在块推断。
+// 空的 Quote 或 Indent 使用从文件推断的值。
+func (ge *GradleEditor) SetStyle(style EditorStyle) {
+	if style.Quote == "" {
+		style.Quote = ge.detectedStyle.Quote
+	}
+	if style.Indent == "" {
+		style.Indent = ge.detectedStyle.Indent
+	}
+	ge.editorStyle = &style
+}
+
+// Style 返回生成文本使用的风格：显式设置过时返回设置的风格，否则返回创建编辑器时从文件推断的风格。
+func (ge *GradleEditor) Style() EditorStyle {
+	if ge.editorStyle != nil {
+		return *ge.editorStyle
+	}
+	return ge.detectedStyle
+}
+
+// quote 返回生成字符串字面量使用的引号，Kotlin DSL 总是使用双引号。
+func (ge *GradleEditor) quote() string {
+	if ge.isKotlinDSL() {
+		return "\""
+	}
+	return ge.Style().Quote
+}
+
+// quoted 用生成文本的引号包住字符串。
+func (ge *GradleEditor) quoted(s string) string {
+	q := ge.quote()
+	return q + s + q
+}
+
+// assignment 按等号空白风格生成赋值语句。
+func (ge *GradleEditor) assignment(key, value string) string {
+	if ge.Style().SpaceAroundEquals {
+		return fmt.Sprintf("%s = %s", key, value)
+	}
+	return fmt.Sprintf("%s=%s", key, value)
+}
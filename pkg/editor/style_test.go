@@ -0,0 +1,101 @@
+package editor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/parser"
+)
+
+func TestDetectEditorStyle(t *testing.T) {
+	editor := createRepositoryTestEditor(t, `version="1.0"
+
+repositories {
+	mavenCentral()
+}
+
+dependencies {
+	implementation "com.google.guava:guava:31.1-jre"
+	testImplementation 'junit:junit:4.13.2'
+	runtimeOnly "org.postgresql:postgresql:42.6.0"
+}
+`)
+	style := editor.Style()
+	if style.Quote != `"` || style.Indent != "\t" || style.SpaceAroundEquals {
+		t.Errorf("Unexpected detected style %+v", style)
+	}
+
+	if err := editor.UpsertProperty("group", "com.example"); err != nil {
+		t.Fatalf("UpsertProperty() error = %v", err)
+	}
+	if err := editor.AddRepository("", "https://jitpack.io"); err != nil {
+		t.Fatalf("AddRepository() error = %v", err)
+	}
+	newText := applyEditorModifications(t, editor)
+	for _, expected := range []string{`group="com.example"`, "\tmaven { url \"https://jitpack.io\" }\n}"} {
+		if !strings.Contains(newText, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, newText)
+		}
+	}
+
+	if got := DetectEditorStyle(nil); got != DefaultEditorStyle() {
+		t.Errorf("DetectEditorStyle(nil) = %+v, want default", got)
+	}
+}
+
+func TestDetectEditorStyleParenthesizedCalls(t *testing.T) {
+	// 没有文件路径时也能从已有声明看出是 Kotlin DSL 的写法。
+	editor := createRepositoryTestEditor(t, `dependencies {
+    implementation("com.google.guava:guava:31.1-jre")
+    testImplementation("junit:junit:4.13.2")
+}
+`)
+	if style := editor.Style(); !style.ParenthesizedCalls || style.Quote != `"` {
+		t.Errorf("Expected parenthesized double-quoted style, got %+v", style)
+	}
+	if err := editor.AddDependency("org.slf4j", "slf4j-api", "2.0.9", "implementation"); err != nil {
+		t.Fatalf("AddDependency() error = %v", err)
+	}
+	if newText := applyEditorModifications(t, editor); !strings.Contains(newText, "\n    implementation(\"org.slf4j:slf4j-api:2.0.9\")\n}") {
+		t.Errorf("Expected a parenthesized declaration, got:\n%s", newText)
+	}
+
+	// .kts 文件总是使用括号和双引号，即使文件中还没有依赖。
+	result, err := parser.NewSourceAwareParser().ParseWithSourceMapping("dependencies {\n}\n")
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping() error = %v", err)
+	}
+	result.SourceMappedProject.FilePath = "build.gradle.kts"
+	editor = NewGradleEditor(result.SourceMappedProject)
+	if style := editor.Style(); !style.ParenthesizedCalls || style.Quote != `"` {
+		t.Errorf("Expected Kotlin DSL style for .kts files, got %+v", style)
+	}
+	if err := editor.AddDependency("org.slf4j", "slf4j-api", "2.0.9", "implementation"); err != nil {
+		t.Fatalf("AddDependency() error = %v", err)
+	}
+	if newText := applyEditorModifications(t, editor); !strings.Contains(newText, `implementation("org.slf4j:slf4j-api:2.0.9")`) {
+		t.Errorf("Expected a Kotlin DSL declaration, got:\n%s", newText)
+	}
+}
+
+func TestSetStyle(t *testing.T) {
+	editor := createRepositoryTestEditor(t, repositoryTestContent)
+	editor.SetStyle(EditorStyle{Quote: `"`, Indent: "  ", SpaceAroundEquals: true})
+
+	if err := editor.AddDependency("org.slf4j", "slf4j-api", "2.0.9", "implementation"); err != nil {
+		t.Fatalf("AddDependency() error = %v", err)
+	}
+	if err := editor.UpsertProperty("version", "1.0.0"); err != nil {
+		t.Fatalf("UpsertProperty() error = %v", err)
+	}
+
+	newText := applyEditorModifications(t, editor)
+	for _, expected := range []string{
+		"\n  implementation \"org.slf4j:slf4j-api:2.0.9\"\n}",
+		`version = "1.0.0"`,
+	} {
+		if !strings.Contains(newText, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, newText)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Package editor 提供事务式编辑与预演功能。
+package editor
+
+import (
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// editorSnapshot 保存事务开始时编辑器的状态。
+type editorSnapshot struct {
+	modifications []Modification
+
+	dependencies     []*model.SourceMappedDependency
+	dependencyValues []model.SourceMappedDependency
+	dependencyModels []model.Dependency
+	plugins          []*model.SourceMappedPlugin
+	pluginValues     []model.SourceMappedPlugin
+	pluginModels     []model.Plugin
+	repositories     []*model.SourceMappedRepository
+	repositoryValues []model.SourceMappedRepository
+	repositoryModels []model.Repository
+	properties       []*model.SourceMappedProperty
+	propertyValues   []model.SourceMappedProperty
+}
+
+// DryRunResult 表示预演修改的结果。
+type DryRunResult struct {
+	Text    string              `json:"text"`    // 应用修改后的文本。
+	Diff    []DiffLine          `json:"diff"`    // 修改的diff信息。
+	Summary ModificationSummary `json:"summary"` // 修改摘要。
+}
+
+// Begin 开始一个编辑事务，之后的修改可以通过 Rollback 撤销。
+func (ge *GradleEditor) Begin() error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+	if ge.snapshot != nil {
+		return ErrTransactionInProgress
+	}
+
+	ge.snapshot = ge.takeSnapshot()
+	return nil
+}
+
+// Commit 提交当前事务，保留事务中产生的所有修改。
+func (ge *GradleEditor) Commit() error {
+	if ge.snapshot == nil {
+		return ErrNoTransaction
+	}
+
+	ge.snapshot = nil
+	return nil
+}
+
+// Rollback 回滚当前事务，撤销事务开始后的所有修改并恢复内存中的源码映射信息。
+func (ge *GradleEditor) Rollback() error {
+	if ge.snapshot == nil {
+		return ErrNoTransaction
+	}
+
+	ge.restoreSnapshot(ge.snapshot)
+	ge.snapshot = nil
+	return nil
+}
+
+// InTransaction 判断编辑器是否处于事务中。
+func (ge *GradleEditor) InTransaction() bool {
+	return ge.snapshot != nil
+}
+
+// DryRun 预演当前的所有修改，返回修改后的文本和diff，不改变编辑器状态。
+func (ge *GradleEditor) DryRun() (*DryRunResult, error) {
+	if ge.sourceMappedProject == nil {
+		return nil, ErrNilProject
+	}
+
+	modifications := make([]Modification, len(ge.modifications))
+	copy(modifications, ge.modifications)
+
+	serializer := NewGradleSerializer(ge.sourceMappedProject.OriginalText)
+	text, err := serializer.ApplyModifications(modifications)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DryRunResult{
+		Text:    text,
+		Diff:    serializer.GenerateDiff(modifications),
+		Summary: serializer.GetModificationSummary(modifications),
+	}, nil
+}
+
+// takeSnapshot 复制编辑器当前的状态。
+func (ge *GradleEditor) takeSnapshot() *editorSnapshot {
+	smp := ge.sourceMappedProject
+	snap := &editorSnapshot{
+		modifications: append([]Modification(nil), ge.modifications...),
+		dependencies:  append([]*model.SourceMappedDependency(nil), smp.SourceMappedDependencies...),
+		plugins:       append([]*model.SourceMappedPlugin(nil), smp.SourceMappedPlugins...),
+		repositories:  append([]*model.SourceMappedRepository(nil), smp.SourceMappedRepositories...),
+		properties:    append([]*model.SourceMappedProperty(nil), smp.SourceMappedProperties...),
+	}
+
+	for _, dep := range snap.dependencies {
+		snap.dependencyValues = append(snap.dependencyValues, *dep)
+		snap.dependencyModels = append(snap.dependencyModels, derefOrZero(dep.Dependency))
+	}
+	for _, plugin := range snap.plugins {
+		snap.pluginValues = append(snap.pluginValues, *plugin)
+		snap.pluginModels = append(snap.pluginModels, derefOrZero(plugin.Plugin))
+	}
+	for _, repo := range snap.repositories {
+		snap.repositoryValues = append(snap.repositoryValues, *repo)
+		snap.repositoryModels = append(snap.repositoryModels, derefOrZero(repo.Repository))
+	}
+	for _, prop := range snap.properties {
+		snap.propertyValues = append(snap.propertyValues, *prop)
+	}
+
+	return snap
+}
+
+// restoreSnapshot 将编辑器恢复到快照时的状态。
+// 已有对象原地恢复，因此调用方持有的指针仍然有效。
+func (ge *GradleEditor) restoreSnapshot(snap *editorSnapshot) {
+	smp := ge.sourceMappedProject
+
+	ge.modifications = append(make([]Modification, 0, len(snap.modifications)), snap.modifications...)
+
+	for i, dep := range snap.dependencies {
+		*dep = snap.dependencyValues[i]
+		if dep.Dependency != nil {
+			*dep.Dependency = snap.dependencyModels[i]
+		}
+	}
+	for i, plugin := range snap.plugins {
+		*plugin = snap.pluginValues[i]
+		if plugin.Plugin != nil {
+			*plugin.Plugin = snap.pluginModels[i]
+		}
+	}
+	for i, repo := range snap.repositories {
+		*repo = snap.repositoryValues[i]
+		if repo.Repository != nil {
+			*repo.Repository = snap.repositoryModels[i]
+		}
+	}
+	for i, prop := range snap.properties {
+		*prop = snap.propertyValues[i]
+	}
+
+	smp.SourceMappedDependencies = snap.dependencies
+	smp.SourceMappedPlugins = snap.plugins
+	smp.SourceMappedRepositories = snap.repositories
+	smp.SourceMappedProperties = snap.properties
+}
+
+// derefOrZero 返回指针指向的值，指针为nil时返回零值。
+func derefOrZero[T any](ptr *T) T {
+	var zero T
+	if ptr == nil {
+		return zero
+	}
+	return *ptr
+}
@@ -0,0 +1,120 @@
+package editor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTransactionCommit(t *testing.T) {
+	editor := createTestEditor(t)
+
+	if err := editor.Begin(); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if !editor.InTransaction() {
+		t.Error("Editor should be in transaction after Begin()")
+	}
+	if err := editor.Begin(); err == nil {
+		t.Error("Nested Begin() should return error")
+	}
+
+	if err := editor.UpdateProperty("version", "1.0.0"); err != nil {
+		t.Fatalf("UpdateProperty() error = %v", err)
+	}
+
+	if err := editor.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if editor.InTransaction() {
+		t.Error("Editor should not be in transaction after Commit()")
+	}
+	if len(editor.GetModifications()) != 1 {
+		t.Errorf("Expected 1 modification after commit, got %d", len(editor.GetModifications()))
+	}
+
+	if err := editor.Commit(); err == nil {
+		t.Error("Commit() without transaction should return error")
+	}
+	if err := editor.Rollback(); err == nil {
+		t.Error("Rollback() without transaction should return error")
+	}
+}
+
+func TestTransactionRollback(t *testing.T) {
+	editor := createTestEditor(t)
+
+	// 事务外的修改应在回滚后保留。
+	if err := editor.UpdateProperty("version", "1.0.0"); err != nil {
+		t.Fatalf("UpdateProperty() error = %v", err)
+	}
+
+	guava := editor.GetSourceMappedProject().SourceMappedDependencies[3]
+	if guava.Name != "guava" {
+		t.Fatalf("Unexpected test fixture, got %s", guava.Name)
+	}
+
+	if err := editor.Begin(); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+
+	if err := editor.UpdateDependencyVersion("com.google.guava", "guava", "32.0.0-jre"); err != nil {
+		t.Fatalf("UpdateDependencyVersion() error = %v", err)
+	}
+	if err := editor.RemoveRepository("google"); err != nil {
+		t.Fatalf("RemoveRepository() error = %v", err)
+	}
+	editor.ClearModifications()
+
+	if err := editor.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	if len(editor.GetModifications()) != 1 {
+		t.Errorf("Expected 1 modification after rollback, got %d", len(editor.GetModifications()))
+	}
+	if guava.Version != "31.0-jre" || !strings.Contains(guava.RawText, "31.0-jre") {
+		t.Errorf("Dependency state should be restored, got version=%s raw=%s", guava.Version, guava.RawText)
+	}
+	if len(editor.GetSourceMappedProject().SourceMappedRepositories) != 3 {
+		t.Errorf("Repositories should be restored, got %d", len(editor.GetSourceMappedProject().SourceMappedRepositories))
+	}
+
+	// 回滚后可以再次修改同一依赖。
+	if err := editor.UpdateDependencyVersion("com.google.guava", "guava", "31.1-jre"); err != nil {
+		t.Fatalf("UpdateDependencyVersion() after rollback error = %v", err)
+	}
+	newText := applyEditorModifications(t, editor)
+	if !strings.Contains(newText, "com.google.guava:guava:31.1-jre") {
+		t.Errorf("Expected guava 31.1-jre after rollback and re-edit, got:\n%s", newText)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	editor := createTestEditor(t)
+
+	if err := editor.UpdateDependencyVersion("mysql", "mysql-connector-java", "8.0.30"); err != nil {
+		t.Fatalf("UpdateDependencyVersion() error = %v", err)
+	}
+
+	result, err := editor.DryRun()
+	if err != nil {
+		t.Fatalf("DryRun() error = %v", err)
+	}
+
+	if !strings.Contains(result.Text, "mysql:mysql-connector-java:8.0.30") {
+		t.Error("DryRun text should contain the new version")
+	}
+	if len(result.Diff) != 2 {
+		t.Errorf("Expected 2 diff lines for a replace, got %d", len(result.Diff))
+	}
+	if result.Summary.TotalModifications != 1 {
+		t.Errorf("Expected 1 modification in summary, got %d", result.Summary.TotalModifications)
+	}
+
+	if len(editor.GetModifications()) != 1 {
+		t.Error("DryRun should not change modifications")
+	}
+	if editor.GetSourceMappedProject().OriginalText != testGradleContent {
+		t.Error("DryRun should not change original text")
+	}
+}
@@ -0,0 +1,130 @@
+// Package editor 提供基于规则的批量升级功能。
+package editor
+
+import (
+	"fmt"
+	"path"
+)
+
+// UpgradeRule 描述一条依赖升级规则。
+// Group 和 Name 支持 path.Match 风格的通配符，空字符串等价于 "*"。
+type UpgradeRule struct {
+	Group   string `json:"group"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"` // 目标版本。
+
+	// Catalog 以 "group:name" 为键提供最新版本，Version 为空时从中取值。
+	Catalog map[string]string `json:"catalog,omitempty"`
+}
+
+// UpgradeChange 描述一次实际发生的版本升级。
+type UpgradeChange struct {
+	Group       string `json:"group"`
+	Name        string `json:"name"`
+	FromVersion string `json:"fromVersion"`
+	ToVersion   string `json:"toVersion"`
+	Line        int    `json:"line"`
+	RuleIndex   int    `json:"ruleIndex"`
+}
+
+// UpgradeSummary 汇总一次批量升级的结果。
+type UpgradeSummary struct {
+	Changes   []UpgradeChange `json:"changes"`
+	UpToDate  int             `json:"upToDate"`  // 已是目标版本的依赖数。
+	Skipped   []string        `json:"skipped"`   // 匹配但无法升级的依赖（无版本或目录中缺失）。
+	Unmatched []int           `json:"unmatched"` // 没有匹配任何依赖的规则索引。
+}
+
+// Matches 判断规则是否匹配指定的依赖坐标。
+func (r UpgradeRule) Matches(group, name string) bool {
+	return globMatch(r.Group, group) && globMatch(r.Name, name)
+}
+
+// targetVersion 返回规则针对指定依赖的目标版本。
+func (r UpgradeRule) targetVersion(group, name string) string {
+	if r.Version != "" {
+		return r.Version
+	}
+	return r.Catalog[group+":"+name]
+}
+
+// ApplyUpgradeRules 按规则批量升级依赖版本。
+// 每个依赖使用第一条匹配的规则，所有修改合并到同一个修改集中。
+func (ge *GradleEditor) ApplyUpgradeRules(rules []UpgradeRule) (*UpgradeSummary, error) {
+	if ge.sourceMappedProject == nil {
+		return nil, ErrNilProject
+	}
+
+	for i, rule := range rules {
+		if !isValidGlob(rule.Group) || !isValidGlob(rule.Name) {
+			return nil, fmt.Errorf("%w: rule %d: invalid pattern %s:%s", ErrInvalidArgument, i, rule.Group, rule.Name)
+		}
+		if rule.Version == "" && rule.Catalog == nil {
+			return nil, fmt.Errorf("%w: rule %d: neither version nor catalog specified", ErrInvalidArgument, i)
+		}
+	}
+
+	summary := &UpgradeSummary{
+		Changes:   make([]UpgradeChange, 0),
+		Skipped:   make([]string, 0),
+		Unmatched: make([]int, 0),
+	}
+	matched := make([]bool, len(rules))
+
+	for _, dep := range ge.sourceMappedProject.SourceMappedDependencies {
+		// 项目依赖没有坐标，不参与升级。
+		if dep.Group == "" {
+			continue
+		}
+
+		for i, rule := range rules {
+			if !rule.Matches(dep.Group, dep.Name) {
+				continue
+			}
+			matched[i] = true
+
+			coordinate := dep.Group + ":" + dep.Name
+			target := rule.targetVersion(dep.Group, dep.Name)
+			switch {
+			case dep.Version == "" || target == "":
+				summary.Skipped = append(summary.Skipped, coordinate)
+			case dep.Version == target:
+				summary.UpToDate++
+			default:
+				summary.Changes = append(summary.Changes, UpgradeChange{
+					Group:       dep.Group,
+					Name:        dep.Name,
+					FromVersion: dep.Version,
+					ToVersion:   target,
+					Line:        dep.SourceRange.Start.Line,
+					RuleIndex:   i,
+				})
+				ge.updateDependencyVersion(dep, target)
+			}
+			break
+		}
+	}
+
+	for i, ok := range matched {
+		if !ok {
+			summary.Unmatched = append(summary.Unmatched, i)
+		}
+	}
+
+	return summary, nil
+}
+
+// globMatch 使用通配符匹配，空模式匹配任意值。
+func globMatch(pattern, value string) bool {
+	if pattern == "" || pattern == "*" {
+		return true
+	}
+	ok, err := path.Match(pattern, value)
+	return err == nil && ok
+}
+
+// isValidGlob 检查通配符模式是否合法。
+func isValidGlob(pattern string) bool {
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
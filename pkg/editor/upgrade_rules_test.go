@@ -0,0 +1,97 @@
+package editor
+
+import (
+	"strings"
+	"testing"
+)
+
+const upgradeRulesTestContent = `dependencies {
+    implementation 'org.springframework.boot:spring-boot-starter-web:2.7.0'
+    implementation 'org.springframework.boot:spring-boot-starter-data-jpa:2.7.0'
+    implementation 'org.springframework:spring-core'
+    implementation 'com.google.guava:guava:31.0-jre'
+    implementation 'org.apache.commons:commons-lang3:3.12.0'
+    testImplementation 'org.junit.jupiter:junit-jupiter-api:5.8.2'
+    implementation project(':core')
+}
+`
+
+func TestUpgradeRuleMatches(t *testing.T) {
+	tests := []struct {
+		rule  UpgradeRule
+		group string
+		name  string
+		want  bool
+	}{
+		{UpgradeRule{Group: "org.springframework.boot"}, "org.springframework.boot", "spring-boot-starter", true},
+		{UpgradeRule{Group: "org.springframework*"}, "org.springframework.boot", "x", true},
+		{UpgradeRule{Group: "com.google.*", Name: "guava"}, "com.google.guava", "guava", true},
+		{UpgradeRule{Group: "com.google.*", Name: "gson"}, "com.google.guava", "guava", false},
+		{UpgradeRule{Name: "junit-*"}, "org.junit.jupiter", "junit-jupiter-api", true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rule.Matches(tt.group, tt.name); got != tt.want {
+			t.Errorf("%+v.Matches(%s, %s) = %v, want %v", tt.rule, tt.group, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyUpgradeRules(t *testing.T) {
+	editor := createRepositoryTestEditor(t, upgradeRulesTestContent)
+
+	rules := []UpgradeRule{
+		{Group: "org.springframework*", Version: "2.7.5"},
+		{Group: "com.google.guava", Name: "guava", Version: "31.0-jre"},
+		{Name: "junit-*", Catalog: map[string]string{"org.junit.jupiter:junit-jupiter-api": "5.9.1"}},
+		{Group: "io.netty", Version: "4.1.85.Final"},
+	}
+
+	summary, err := editor.ApplyUpgradeRules(rules)
+	if err != nil {
+		t.Fatalf("ApplyUpgradeRules() error = %v", err)
+	}
+
+	if len(summary.Changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %d: %+v", len(summary.Changes), summary.Changes)
+	}
+	if summary.UpToDate != 1 {
+		t.Errorf("Expected 1 up-to-date dependency, got %d", summary.UpToDate)
+	}
+	if len(summary.Skipped) != 1 || summary.Skipped[0] != "org.springframework:spring-core" {
+		t.Errorf("Expected spring-core to be skipped, got %v", summary.Skipped)
+	}
+	if len(summary.Unmatched) != 1 || summary.Unmatched[0] != 3 {
+		t.Errorf("Expected rule 3 to be unmatched, got %v", summary.Unmatched)
+	}
+
+	if len(editor.GetModifications()) != 3 {
+		t.Errorf("Expected 3 modifications, got %d", len(editor.GetModifications()))
+	}
+
+	newText := applyEditorModifications(t, editor)
+	for _, want := range []string{
+		"spring-boot-starter-web:2.7.5",
+		"spring-boot-starter-data-jpa:2.7.5",
+		"junit-jupiter-api:5.9.1",
+		"commons-lang3:3.12.0",
+	} {
+		if !strings.Contains(newText, want) {
+			t.Errorf("Expected %q in result, got:\n%s", want, newText)
+		}
+	}
+}
+
+func TestApplyUpgradeRulesInvalid(t *testing.T) {
+	editor := createRepositoryTestEditor(t, upgradeRulesTestContent)
+
+	if _, err := editor.ApplyUpgradeRules([]UpgradeRule{{Group: "[", Version: "1"}}); err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+	if _, err := editor.ApplyUpgradeRules([]UpgradeRule{{Group: "com.google.guava"}}); err == nil {
+		t.Error("Expected error for rule without version or catalog")
+	}
+	if len(editor.GetModifications()) != 0 {
+		t.Error("Invalid rules should not produce modifications")
+	}
+}
@@ -0,0 +1,355 @@
+// Package editor 提供把依赖中硬编码的版本提取到ext属性或版本目录的重构功能。
+package editor
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// VersionExtractionStrategy 表示提取版本的目标位置。
+type VersionExtractionStrategy string
+
+const (
+	// ExtractToExt 把版本提取为ext属性，例如 guavaVersion = '31.0-jre'，依赖改为 "g:n:$guavaVersion"。
+	ExtractToExt VersionExtractionStrategy = "ext"
+	// ExtractToCatalog 把依赖提取为版本目录中的库，依赖改为 libs.guava 这样的访问路径。
+	ExtractToCatalog VersionExtractionStrategy = "catalog"
+)
+
+// ExtractedVersion 表示一个被提取的依赖版本。
+type ExtractedVersion struct {
+	Group   string `json:"group"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Key 是ext属性名，或版本目录 [versions] 中的键。同一group下版本相同的依赖共用一个键。
+	Key string `json:"key"`
+	// Alias 是版本目录 [libraries] 中的别名，只在 ExtractToCatalog 策略下设置。
+	Alias string `json:"alias,omitempty"`
+}
+
+// VersionExtraction 表示提取版本的结果。
+type VersionExtraction struct {
+	Strategy VersionExtractionStrategy `json:"strategy"`
+	// Versions 按首次出现的顺序排列，每个坐标只出现一次。
+	Versions []*ExtractedVersion `json:"versions"`
+	// CatalogEntries 是需要加入 gradle/libs.versions.toml 的 [versions] 和 [libraries] 条目，
+	// 只在 ExtractToCatalog 策略下生成。
+	CatalogEntries string `json:"catalogEntries,omitempty"`
+}
+
+// VersionExtractor 在一个或多个构建文件中提取依赖版本，并在所有文件间统一分配属性名和别名。
+// 先对每个文件调用 Extract 改写依赖声明，使用ext策略时再对根项目调用 Define 生成属性定义。
+type VersionExtractor struct {
+	strategy VersionExtractionStrategy
+	versions []*ExtractedVersion
+
+	byCoordinate map[string]*ExtractedVersion // group:name:version → 提取结果。
+	byGroup      map[string]string            // group:version → 共用的键。
+	keys         map[string]bool              // 已分配或文件中已存在的属性名/[versions]键。
+	aliases      map[string]bool              // 已分配的库别名。
+}
+
+// NewVersionExtractor 创建版本提取器，策略不合法时返回包装了 ErrInvalidArgument 的错误。
+func NewVersionExtractor(strategy VersionExtractionStrategy) (*VersionExtractor, error) {
+	if strategy != ExtractToExt && strategy != ExtractToCatalog {
+		return nil, fmt.Errorf("%w: unknown version extraction strategy %q", ErrInvalidArgument, strategy)
+	}
+	return &VersionExtractor{
+		strategy:     strategy,
+		versions:     make([]*ExtractedVersion, 0),
+		byCoordinate: make(map[string]*ExtractedVersion),
+		byGroup:      make(map[string]string),
+		keys:         make(map[string]bool),
+		aliases:      make(map[string]bool),
+	}, nil
+}
+
+// ExtractVersions 把文件中硬编码的依赖版本提取到ext属性或版本目录，改写所有使用处，
+// 使用ext策略时同时在文件中生成属性定义（已有的顶层 ext 块中追加，否则新建）。
+// 修改通过 GetModifications 取得；版本目录条目在返回结果的 CatalogEntries 中，需要写入版本目录文件。
+func (ge *GradleEditor) ExtractVersions(strategy VersionExtractionStrategy) (*VersionExtraction, error) {
+	extractor, err := NewVersionExtractor(strategy)
+	if err != nil {
+		return nil, err
+	}
+	if err := extractor.Extract(ge); err != nil {
+		return nil, err
+	}
+	if err := extractor.Define(ge); err != nil {
+		return nil, err
+	}
+	return extractor.Result(), nil
+}
+
+// Extract 改写文件中带有字面量版本的依赖声明。项目依赖、没有版本或版本中含变量插值的依赖保持不变；
+// 使用版本目录策略时，带分类器或扩展名的依赖也保持不变，因为版本目录无法表达它们。
+func (x *VersionExtractor) Extract(ge *GradleEditor) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+
+	for _, prop := range ge.sourceMappedProject.SourceMappedProperties {
+		x.keys[strings.TrimPrefix(prop.Key, "ext.")] = true
+	}
+
+	kotlin := ge.isKotlinDSL()
+	for _, dep := range ge.sourceMappedProject.SourceMappedDependencies {
+		if !x.extractable(dep) {
+			continue
+		}
+		extracted := x.allocate(dep.Dependency)
+
+		var newText, reference string
+		if x.strategy == ExtractToCatalog {
+			newText = catalogAccessor(extracted.Alias)
+		} else {
+			reference = "$" + extracted.Key
+			if kotlin {
+				reference = fmt.Sprintf(`${property("%s")}`, extracted.Key)
+			}
+			newText = replaceVersionLiteral(dep.RawText, dep.Version, reference)
+		}
+
+		ge.modifications = append(ge.modifications, Modification{
+			Type:        ModificationTypeReplace,
+			SourceRange: dep.SourceRange,
+			OldText:     dep.RawText,
+			NewText:     newText,
+			Description: fmt.Sprintf("Extract %s:%s version '%s' to %s %s", dep.Group, dep.Name, dep.Version, x.strategy, extracted.Key),
+		})
+
+		dep.RawText = newText
+		dep.Version = reference
+	}
+	return nil
+}
+
+// Define 在文件中生成ext策略所需的属性定义，应在所有 Extract 调用之后对根项目调用一次。
+// Groovy在已有的 ext 块末尾追加，没有时新建 ext 块；Kotlin使用 extra["key"] = "version"。
+// 版本目录策略不修改构建文件。
+func (x *VersionExtractor) Define(ge *GradleEditor) error {
+	if ge.sourceMappedProject == nil {
+		return ErrNilProject
+	}
+	if x.strategy != ExtractToExt {
+		return nil
+	}
+
+	definitions := make([]string, 0)
+	defined := make(map[string]bool)
+	for _, v := range x.versions {
+		if defined[v.Key] {
+			continue
+		}
+		defined[v.Key] = true
+		if ge.isKotlinDSL() {
+			definitions = append(definitions, ge.assignment(fmt.Sprintf("extra[\"%s\"]", v.Key), ge.quoted(v.Version)))
+		} else {
+			definitions = append(definitions, ge.formatProperty(v.Key, v.Version))
+		}
+	}
+	if len(definitions) == 0 {
+		return nil
+	}
+
+	lines := ge.sourceMappedProject.Lines
+	if !ge.isKotlinDSL() {
+		if startLine, endLine := ge.findTopLevelBlock("ext"); startLine != -1 && endLine > startLine {
+			indent := ge.detectBlockIndent(startLine, endLine)
+			ge.modifications = append(ge.modifications, Modification{
+				Type:        ModificationTypeInsert,
+				SourceRange: pointRange(endLine, 1, ge.lineStartPos(endLine)),
+				NewText:     indent + strings.Join(definitions, "\n"+indent) + "\n",
+				Description: fmt.Sprintf("Define %d extracted versions in ext block", len(definitions)),
+			})
+			return nil
+		}
+		definitions = append([]string{"ext {"}, append(indentLines(definitions, ge.Style().Indent), "}")...)
+	}
+
+	insertLine, _ := ge.findPropertyInsertLine()
+	newText := strings.Join(definitions, "\n") + "\n"
+	if insertLine > 1 && insertLine-2 < len(lines) && strings.TrimSpace(lines[insertLine-2]) != "" {
+		newText = "\n" + newText
+	}
+	if insertLine-1 < len(lines) && strings.TrimSpace(lines[insertLine-1]) != "" {
+		newText += "\n"
+	}
+	insertPos := ge.lineStartPos(insertLine)
+	if original := ge.sourceMappedProject.OriginalText; insertPos == len(original) &&
+		original != "" && !strings.HasSuffix(original, "\n") {
+		newText = "\n" + newText
+	}
+
+	ge.modifications = append(ge.modifications, Modification{
+		Type:        ModificationTypeInsert,
+		SourceRange: pointRange(insertLine, 1, insertPos),
+		NewText:     newText,
+		Description: fmt.Sprintf("Define %d extracted versions", len(definitions)),
+	})
+	return nil
+}
+
+// Result 返回目前为止提取的版本，使用版本目录策略时包括需要加入版本目录的条目。
+func (x *VersionExtractor) Result() *VersionExtraction {
+	result := &VersionExtraction{
+		Strategy: x.strategy,
+		Versions: x.versions,
+	}
+	if x.strategy == ExtractToCatalog && len(x.versions) > 0 {
+		result.CatalogEntries = x.catalogEntries()
+	}
+	return result
+}
+
+// extractable 判断依赖声明是否可以提取版本。
+func (x *VersionExtractor) extractable(dep *model.SourceMappedDependency) bool {
+	if dep.Dependency == nil || dep.Project != nil || dep.Group == "" || dep.Name == "" || dep.Version == "" ||
+		strings.ContainsAny(dep.Version, "$\"'") || !strings.Contains(dep.RawText, dep.Version) {
+		return false
+	}
+	return x.strategy != ExtractToCatalog || dep.Classifier == "" && dep.Extension == ""
+}
+
+// allocate 返回坐标对应的提取结果，第一次遇到时分配属性名和别名。
+func (x *VersionExtractor) allocate(dep *model.Dependency) *ExtractedVersion {
+	coordinate := dep.Group + ":" + dep.Name + ":" + dep.Version
+	if extracted, ok := x.byCoordinate[coordinate]; ok {
+		return extracted
+	}
+
+	extracted := &ExtractedVersion{Group: dep.Group, Name: dep.Name, Version: dep.Version}
+	if x.strategy == ExtractToCatalog {
+		extracted.Alias = x.uniqueName(x.aliases, dep.Group, dep.Name, catalogAlias)
+	}
+
+	groupKey := dep.Group + ":" + dep.Version
+	if key, ok := x.byGroup[groupKey]; ok {
+		extracted.Key = key
+	} else {
+		if x.strategy == ExtractToCatalog {
+			extracted.Key = x.uniqueName(x.keys, dep.Group, dep.Name, catalogAlias)
+		} else {
+			extracted.Key = x.uniqueName(x.keys, dep.Group, dep.Name, func(name string) string {
+				return lowerCamel(name) + "Version"
+			})
+		}
+		x.byGroup[groupKey] = extracted.Key
+	}
+
+	x.byCoordinate[coordinate] = extracted
+	x.versions = append(x.versions, extracted)
+	return extracted
+}
+
+// uniqueName 由制品名生成名称，与已有名称冲突时依次加上group的最后几段（与制品名相同的段除外），仍然冲突时加数字后缀。
+func (x *VersionExtractor) uniqueName(taken map[string]bool, group, name string, format func(string) string) string {
+	segments := strings.Split(group, ".")
+	if len(segments) > 1 && segments[len(segments)-1] == name {
+		// com.google.guava:guava 加上 google 而不是 guava。
+		segments = segments[:len(segments)-1]
+	}
+	candidate := format(name)
+	for i := len(segments) - 1; taken[candidate] && i >= 0; i-- {
+		candidate = format(strings.Join(segments[i:], "-") + "-" + name)
+	}
+	for n := 2; taken[candidate]; n++ {
+		candidate = format(fmt.Sprintf("%s-%s-v%d", group, name, n))
+	}
+	taken[candidate] = true
+	return candidate
+}
+
+// catalogEntries 生成版本目录的 [versions] 和 [libraries] 条目，按键排序。
+func (x *VersionExtractor) catalogEntries() string {
+	versions := make(map[string]string)
+	libraries := make([]*ExtractedVersion, len(x.versions))
+	copy(libraries, x.versions)
+	for _, v := range x.versions {
+		versions[v.Key] = v.Version
+	}
+	keys := make([]string, 0, len(versions))
+	for key := range versions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sort.SliceStable(libraries, func(i, j int) bool { return libraries[i].Alias < libraries[j].Alias })
+
+	var sb strings.Builder
+	sb.WriteString("[versions]\n")
+	for _, key := range keys {
+		fmt.Fprintf(&sb, "%s = \"%s\"\n", key, versions[key])
+	}
+	sb.WriteString("\n[libraries]\n")
+	for _, lib := range libraries {
+		fmt.Fprintf(&sb, "%s = { module = \"%s:%s\", version.ref = \"%s\" }\n", lib.Alias, lib.Group, lib.Name, lib.Key)
+	}
+	return sb.String()
+}
+
+// replaceVersionLiteral 把声明中的版本字面量替换为引用。字符串坐标改为双引号字符串以便插值，
+// map形式只替换 version 的值。
+func replaceVersionLiteral(rawText, version, reference string) string {
+	if strings.HasPrefix(rawText, "'") || strings.HasPrefix(rawText, "\"") {
+		body := rawText[1 : len(rawText)-1]
+		if i := strings.LastIndex(body, ":"+version); i != -1 {
+			body = body[:i+1] + reference + body[i+1+len(version):]
+		}
+		return "\"" + body + "\""
+	}
+	mapVersionRegex := regexp.MustCompile(`(version\s*[:=]\s*)['"]` + regexp.QuoteMeta(version) + `['"]`)
+	return mapVersionRegex.ReplaceAllString(rawText, "${1}\""+escapeReplacement(reference)+"\"")
+}
+
+// catalogAlias 把制品名转换为版本目录别名：小写，用 - 分隔，以数字开头的段并入前一段。
+func catalogAlias(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	segments := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len(segments) > 0 && field[0] >= '0' && field[0] <= '9' {
+			segments[len(segments)-1] += field
+			continue
+		}
+		segments = append(segments, field)
+	}
+	return strings.Join(segments, "-")
+}
+
+// catalogAccessor 返回版本目录别名对应的访问路径，例如 slf4j-api → libs.slf4j.api。
+func catalogAccessor(alias string) string {
+	return "libs." + strings.ReplaceAll(alias, "-", ".")
+}
+
+// lowerCamel 把制品名转换为小驼峰形式，例如 slf4j-api → slf4jApi。
+func lowerCamel(name string) string {
+	fields := strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	var sb strings.Builder
+	for i, field := range fields {
+		if i == 0 {
+			if field[0] >= '0' && field[0] <= '9' {
+				sb.WriteByte('v')
+			}
+			sb.WriteString(strings.ToLower(field[:1]) + field[1:])
+			continue
+		}
+		sb.WriteString(strings.ToUpper(field[:1]) + field[1:])
+	}
+	return sb.String()
+}
+
+// indentLines 为每一行加上缩进。
+func indentLines(lines []string, indent string) []string {
+	indented := make([]string, len(lines))
+	for i, line := range lines {
+		indented[i] = indent + line
+	}
+	return indented
+}
@@ -0,0 +1,172 @@
+package editor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/parser"
+)
+
+func newExtractionEditor(t *testing.T, content, filePath string) *GradleEditor {
+	result, err := parser.NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("Failed to parse content: %v", err)
+	}
+	result.SourceMappedProject.FilePath = filePath
+	return NewGradleEditor(result.SourceMappedProject)
+}
+
+func TestExtractVersionsToExt(t *testing.T) {
+	ge := createTestEditor(t)
+	extraction, err := ge.ExtractVersions(ExtractToExt)
+	if err != nil {
+		t.Fatalf("ExtractVersions() error = %v", err)
+	}
+	if len(extraction.Versions) != 4 || extraction.CatalogEntries != "" {
+		t.Fatalf("Unexpected extraction: %+v", extraction)
+	}
+
+	text := applyEditorModifications(t, ge)
+	for _, want := range []string{
+		"description = 'Test project'\n\next {\n    mysqlConnectorJavaVersion = '8.0.29'\n    guavaVersion = '31.0-jre'\n    junitJupiterApiVersion = '5.8.2'\n}\n\nsourceCompatibility",
+		`implementation "com.google.guava:guava:$guavaVersion"`,
+		`testImplementation "org.junit.jupiter:junit-jupiter-api:$junitJupiterApiVersion"`,
+		// 同一group下版本相同的依赖共用一个属性。
+		`testRuntimeOnly "org.junit.jupiter:junit-jupiter-engine:$junitJupiterApiVersion"`,
+		"implementation 'org.springframework.boot:spring-boot-starter-web'\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected result to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestExtractVersionsIntoExistingExtBlock(t *testing.T) {
+	content := `ext {
+    guavaVersion = '30.0-jre'
+}
+
+dependencies {
+    implementation 'com.google.guava:guava:31.0-jre'
+    implementation group: 'org.slf4j', name: 'slf4j-api', version: '2.0.9'
+    implementation 'net.sf.json-lib:json-lib:2.4:jdk15'
+}
+`
+	ge := createRepositoryTestEditor(t, content)
+	if _, err := ge.ExtractVersions(ExtractToExt); err != nil {
+		t.Fatalf("ExtractVersions() error = %v", err)
+	}
+
+	want := `ext {
+    guavaVersion = '30.0-jre'
+    googleGuavaVersion = '31.0-jre'
+    slf4jApiVersion = '2.0.9'
+    jsonLibVersion = '2.4'
+}
+
+dependencies {
+    implementation "com.google.guava:guava:$googleGuavaVersion"
+    implementation group: 'org.slf4j', name: 'slf4j-api', version: "$slf4jApiVersion"
+    implementation "net.sf.json-lib:json-lib:$jsonLibVersion:jdk15"
+}
+`
+	if text := applyEditorModifications(t, ge); text != want {
+		t.Errorf("Unexpected result:\n%s", text)
+	}
+}
+
+func TestExtractVersionsKotlin(t *testing.T) {
+	content := `plugins {
+    java
+}
+
+dependencies {
+    implementation("com.google.guava:guava:31.0-jre")
+}
+`
+	ge := newExtractionEditor(t, content, "build.gradle.kts")
+	if _, err := ge.ExtractVersions(ExtractToExt); err != nil {
+		t.Fatalf("ExtractVersions() error = %v", err)
+	}
+
+	want := `plugins {
+    java
+}
+
+extra["guavaVersion"] = "31.0-jre"
+
+dependencies {
+    implementation("com.google.guava:guava:${property("guavaVersion")}")
+}
+`
+	if text := applyEditorModifications(t, ge); text != want {
+		t.Errorf("Unexpected result:\n%s", text)
+	}
+}
+
+func TestExtractVersionsToCatalog(t *testing.T) {
+	ge := createTestEditor(t)
+	extraction, err := ge.ExtractVersions(ExtractToCatalog)
+	if err != nil {
+		t.Fatalf("ExtractVersions() error = %v", err)
+	}
+
+	text := applyEditorModifications(t, ge)
+	for _, want := range []string{
+		"implementation libs.mysql.connector.java\n",
+		"testImplementation libs.junit.jupiter.api\n",
+		"testRuntimeOnly libs.junit.jupiter.engine\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected result to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	wantEntries := `[versions]
+guava = "31.0-jre"
+junit-jupiter-api = "5.8.2"
+mysql-connector-java = "8.0.29"
+
+[libraries]
+guava = { module = "com.google.guava:guava", version.ref = "guava" }
+junit-jupiter-api = { module = "org.junit.jupiter:junit-jupiter-api", version.ref = "junit-jupiter-api" }
+junit-jupiter-engine = { module = "org.junit.jupiter:junit-jupiter-engine", version.ref = "junit-jupiter-api" }
+mysql-connector-java = { module = "mysql:mysql-connector-java", version.ref = "mysql-connector-java" }
+`
+	if extraction.CatalogEntries != wantEntries {
+		t.Errorf("Unexpected catalog entries:\n%s", extraction.CatalogEntries)
+	}
+}
+
+func TestVersionExtractorAcrossFiles(t *testing.T) {
+	root := newExtractionEditor(t, "dependencies {\n    implementation 'com.google.guava:guava:31.0-jre'\n}\n", "build.gradle")
+	app := newExtractionEditor(t, "dependencies {\n    implementation(\"com.google.guava:guava:31.0-jre\")\n}\n", "app/build.gradle.kts")
+
+	extractor, err := NewVersionExtractor(ExtractToExt)
+	if err != nil {
+		t.Fatalf("NewVersionExtractor() error = %v", err)
+	}
+	for _, ge := range []*GradleEditor{root, app} {
+		if err := extractor.Extract(ge); err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+	}
+	if err := extractor.Define(root); err != nil {
+		t.Fatalf("Define() error = %v", err)
+	}
+
+	if n := len(extractor.Result().Versions); n != 1 {
+		t.Errorf("Expected 1 shared version, got %d", n)
+	}
+	if text := applyEditorModifications(t, root); !strings.HasPrefix(text, "ext {\n    guavaVersion = '31.0-jre'\n}\n\ndependencies") {
+		t.Errorf("Unexpected root result:\n%s", text)
+	}
+	if text := applyEditorModifications(t, app); !strings.Contains(text, `${property("guavaVersion")}`) {
+		t.Errorf("Unexpected app result:\n%s", text)
+	}
+
+	if _, err := NewVersionExtractor("toml"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("Expected ErrInvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+// Package export 提供将解析结果序列化为JSON、YAML和TOML的编码器。
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Format 表示输出格式。
+type Format string
+
+const (
+	// FormatJSON 表示JSON格式。
+	FormatJSON Format = "json"
+	// FormatYAML 表示YAML格式。
+	FormatYAML Format = "yaml"
+	// FormatTOML 表示TOML格式。
+	FormatTOML Format = "toml"
+)
+
+// Encode 将值编码为指定格式。
+// 字段名与省略规则沿用json标签，因此三种格式的结构保持一致。
+func Encode(v any, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON, "":
+		return ToJSON(v)
+	case FormatYAML, "yml":
+		return ToYAML(v)
+	case FormatTOML:
+		return ToTOML(v)
+	default:
+		return nil, fmt.Errorf("不支持的输出格式: %s", format)
+	}
+}
+
+// ToJSON 将值编码为缩进的JSON。
+func ToJSON(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// field 表示对象中的一个键值对。
+type field struct {
+	key   string
+	value any
+}
+
+// object 表示保持键顺序的对象。
+type object []field
+
+// toTree 先按json标签编码，再解码为保持字段顺序的树。
+// 树中的值为 object、[]any、string、json.Number、bool 或 nil。
+func toTree(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeValue(dec)
+}
+
+// decodeValue 从JSON标记流中读取一个值。
+func decodeValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+
+	switch delim {
+	case '{':
+		obj := object{}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyTok.(string)
+			value, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, field{key: key, value: value})
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case '[':
+		arr := []any{}
+		for dec.More() {
+			value, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return arr, nil
+	default:
+		return nil, io.ErrUnexpectedEOF
+	}
+}
+
+// escapeString 返回双引号包围并转义的字符串，YAML和TOML均可使用。
+func escapeString(s string) string {
+	var buf bytes.Buffer
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&buf, `\u%04X`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
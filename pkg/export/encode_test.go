@@ -0,0 +1,101 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func sampleProject() *model.Project {
+	return &model.Project{
+		Group:      "com.example",
+		Name:       "demo",
+		Version:    "1.0.0",
+		Properties: map[string]string{"kotlin.version": "1.9.0"},
+		Plugins:    []*model.Plugin{{ID: "java", Apply: true}},
+		Dependencies: []*model.Dependency{
+			{Group: "org.slf4j", Name: "slf4j-api", Version: "2.0.9", Scope: "implementation", Raw: "implementation 'org.slf4j:slf4j-api:2.0.9'"},
+		},
+		Repositories: []*model.Repository{},
+	}
+}
+
+func TestToYAML(t *testing.T) {
+	data, err := ToYAML(sampleProject())
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	out := string(data)
+
+	expected := []string{
+		"group: com.example\n",
+		"version: \"1.0.0\"\n",
+		"properties:\n  kotlin.version: \"1.9.0\"\n",
+		"plugins:\n  - id: java\n    apply: true\n",
+		"    raw: \"implementation 'org.slf4j:slf4j-api:2.0.9'\"\n",
+		"repositories: []\n",
+		"subProjects: null\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("YAML output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestToTOML(t *testing.T) {
+	data, err := ToTOML(sampleProject())
+	if err != nil {
+		t.Fatalf("ToTOML() error = %v", err)
+	}
+	out := string(data)
+
+	expected := []string{
+		"group = \"com.example\"\n",
+		"repositories = []\n",
+		"[properties]\n\"kotlin.version\" = \"1.9.0\"\n",
+		"[[plugins]]\nid = \"java\"\napply = true\n",
+		"[[dependencies]]\ngroup = \"org.slf4j\"\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("TOML output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "subProjects") {
+		t.Errorf("Null values should be omitted from TOML:\n%s", out)
+	}
+	// 所有键值对都应位于第一个表头之前。
+	if strings.Index(out, "filePath") > strings.Index(out, "\n[") {
+		t.Errorf("Top-level keys should precede tables:\n%s", out)
+	}
+}
+
+func TestToTOML_RejectsNonObject(t *testing.T) {
+	if _, err := ToTOML([]string{"a"}); err == nil {
+		t.Error("Expected error for non-object root")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatYAML, FormatTOML} {
+		data, err := Encode(sampleProject(), format)
+		if err != nil {
+			t.Errorf("Encode(%s) error = %v", format, err)
+		}
+		if !strings.Contains(string(data), "slf4j-api") {
+			t.Errorf("Encode(%s) output missing dependency", format)
+		}
+	}
+	if _, err := Encode(sampleProject(), "xml"); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestEscapeString(t *testing.T) {
+	got := escapeString("a\"b\\c\n\x01")
+	if got != `"a\"b\\c\n\u0001"` {
+		t.Errorf("escapeString() = %s", got)
+	}
+}
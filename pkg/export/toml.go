@@ -0,0 +1,137 @@
+// Package export 提供TOML编码器。
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// tomlBareKeyRegex 匹配TOML中可以不加引号的键。
+var tomlBareKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ToTOML 将值编码为TOML。
+// TOML没有空值，因此值为null的字段会被省略。
+func ToTOML(v any) ([]byte, error) {
+	tree, err := toTree(v)
+	if err != nil {
+		return nil, err
+	}
+
+	root, ok := tree.(object)
+	if !ok {
+		return nil, fmt.Errorf("TOML的根节点必须是对象")
+	}
+
+	var buf bytes.Buffer
+	writeTOMLTable(&buf, root, nil)
+	return buf.Bytes(), nil
+}
+
+// writeTOMLTable 先输出表中的键值对，再依次输出子表和表数组。
+func writeTOMLTable(buf *bytes.Buffer, obj object, path []string) {
+	for _, f := range obj {
+		if f.value == nil || isTOMLTable(f.value) || isTOMLArrayOfTables(f.value) {
+			continue
+		}
+		buf.WriteString(tomlKey(f.key))
+		buf.WriteString(" = ")
+		buf.WriteString(tomlInline(f.value))
+		buf.WriteByte('\n')
+	}
+
+	for _, f := range obj {
+		childPath := append(append([]string{}, path...), f.key)
+		switch {
+		case isTOMLTable(f.value):
+			writeTOMLHeader(buf, "[", childPath, "]")
+			writeTOMLTable(buf, f.value.(object), childPath)
+		case isTOMLArrayOfTables(f.value):
+			for _, item := range f.value.([]any) {
+				writeTOMLHeader(buf, "[[", childPath, "]]")
+				writeTOMLTable(buf, item.(object), childPath)
+			}
+		}
+	}
+}
+
+// writeTOMLHeader 输出表头，表头之间以空行分隔。
+func writeTOMLHeader(buf *bytes.Buffer, open string, path []string, closing string) {
+	if buf.Len() > 0 {
+		buf.WriteByte('\n')
+	}
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = tomlKey(key)
+	}
+	buf.WriteString(open)
+	buf.WriteString(strings.Join(keys, "."))
+	buf.WriteString(closing)
+	buf.WriteByte('\n')
+}
+
+// isTOMLTable 判断值是否应输出为子表。
+func isTOMLTable(value any) bool {
+	_, ok := value.(object)
+	return ok
+}
+
+// isTOMLArrayOfTables 判断值是否应输出为表数组。
+func isTOMLArrayOfTables(value any) bool {
+	arr, ok := value.([]any)
+	if !ok || len(arr) == 0 {
+		return false
+	}
+	for _, item := range arr {
+		if _, ok := item.(object); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// tomlInline 返回值的行内表示。
+func tomlInline(value any) string {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return v.String()
+	case string:
+		return escapeString(v)
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if item != nil {
+				items = append(items, tomlInline(item))
+			}
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case object:
+		items := make([]string, 0, len(v))
+		for _, f := range v {
+			if f.value != nil {
+				items = append(items, tomlKey(f.key)+" = "+tomlInline(f.value))
+			}
+		}
+		if len(items) == 0 {
+			return "{}"
+		}
+		return "{ " + strings.Join(items, ", ") + " }"
+	default:
+		return `""`
+	}
+}
+
+// tomlKey 返回TOML键的文本。
+func tomlKey(key string) string {
+	if tomlBareKeyRegex.MatchString(key) {
+		return key
+	}
+	return escapeString(key)
+}
@@ -0,0 +1,138 @@
+// Package export 提供供依赖更新机器人使用的更新清单导出功能。
+package export
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// 更新清单中的数据源。
+const (
+	DatasourceMaven        = "maven"         // Maven仓库中的依赖，depName 为 group:name。
+	DatasourceGradlePlugin = "gradle-plugin" // Gradle插件门户中的插件，depName 为插件ID。
+)
+
+// 依赖无法自动更新的原因。
+const (
+	SkipReasonUnspecifiedVersion = "unspecified-version" // 没有声明版本，通常由BOM或平台管理。
+	SkipReasonContainsVariable   = "contains-variable"   // 版本由变量插值给出。
+)
+
+// UpdateDependency 是更新清单中的一条记录，字段与Renovate等依赖更新机器人的提取结果保持一致。
+type UpdateDependency struct {
+	File         string   `json:"file"`
+	Line         int      `json:"line"`
+	Datasource   string   `json:"datasource"`
+	DepName      string   `json:"depName"`
+	CurrentValue string   `json:"currentValue,omitempty"`
+	DepType      string   `json:"depType,omitempty"`      // 依赖配置，例如 implementation；插件为 plugin。
+	RegistryURLs []string `json:"registryUrls,omitempty"` // 文件中声明的仓库地址。
+	SkipReason   string   `json:"skipReason,omitempty"`
+
+	// ReplaceRange 是当前版本在文件中的位置，更新时只需替换这一段文本；无法定位时为nil。
+	ReplaceRange *model.SourceRange `json:"replaceRange,omitempty"`
+}
+
+// UpdateManifest 根据带源码位置的解析结果生成更新清单。项目依赖（project(...)）不会出现在清单中，
+// 没有版本或版本含变量的依赖会带上 SkipReason。
+func UpdateManifest(project *model.SourceMappedProject) []*UpdateDependency {
+	entries := make([]*UpdateDependency, 0)
+	if project == nil {
+		return entries
+	}
+
+	file := ""
+	if project.Project != nil {
+		file = project.FilePath
+	}
+
+	registries := make([]string, 0)
+	for _, repo := range project.SourceMappedRepositories {
+		if repo.URL != "" && !containsString(registries, repo.URL) {
+			registries = append(registries, repo.URL)
+		}
+	}
+	if len(registries) == 0 {
+		registries = nil
+	}
+
+	for _, dep := range project.SourceMappedDependencies {
+		if dep.Project != nil || dep.Group == "" || dep.Name == "" {
+			continue
+		}
+		entry := &UpdateDependency{
+			File:         file,
+			Line:         dep.SourceRange.Start.Line,
+			Datasource:   DatasourceMaven,
+			DepName:      dep.Group + ":" + dep.Name,
+			CurrentValue: dep.Version,
+			DepType:      dep.Scope,
+			RegistryURLs: registries,
+		}
+		switch {
+		case dep.Version == "":
+			entry.SkipReason = SkipReasonUnspecifiedVersion
+		case dep.HasDynamicVersion || strings.Contains(dep.Version, "$"):
+			entry.SkipReason = SkipReasonContainsVariable
+		default:
+			entry.ReplaceRange = versionRange(project.OriginalText, dep.SourceRange, dep.Version)
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, plugin := range project.SourceMappedPlugins {
+		if plugin.ID == "" || plugin.Version == "" {
+			continue
+		}
+		entry := &UpdateDependency{
+			File:         file,
+			Line:         plugin.SourceRange.Start.Line,
+			Datasource:   DatasourceGradlePlugin,
+			DepName:      plugin.ID,
+			CurrentValue: plugin.Version,
+			DepType:      "plugin",
+		}
+		if strings.Contains(plugin.Version, "$") {
+			entry.SkipReason = SkipReasonContainsVariable
+		} else {
+			entry.ReplaceRange = versionRange(project.OriginalText, plugin.SourceRange, plugin.Version)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// versionRange 在声明的源码范围内查找版本文本，返回版本本身的范围。
+func versionRange(text string, declaration model.SourceRange, version string) *model.SourceRange {
+	start, end := declaration.Start.StartPos, declaration.End.StartPos
+	if start < 0 || end > len(text) || start > end {
+		return nil
+	}
+	offset := strings.LastIndex(text[start:end], version)
+	if offset == -1 {
+		return nil
+	}
+
+	pos := start + offset
+	column := model.RuneColumn(text, pos)
+	line := declaration.Start.Line + strings.Count(text[start:pos], "\n")
+
+	return &model.SourceRange{
+		Start: model.SourcePosition{Line: line, Column: column, StartPos: pos, EndPos: pos + len(version), Length: len(version)},
+		End: model.SourcePosition{Line: line, Column: column + utf8.RuneCountInString(version) - 1,
+			StartPos: pos + len(version), EndPos: pos + len(version)},
+	}
+}
+
+// containsString 判断字符串是否在切片中。
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/parser"
+)
+
+func TestUpdateManifest(t *testing.T) {
+	content := `plugins {
+    id 'org.springframework.boot' version '3.1.0'
+    id 'java'
+}
+
+repositories {
+    maven { url 'https://repo.example.com/maven' }
+}
+
+dependencies {
+    implementation 'com.google.guava:guava:31.1-jre'
+    implementation 'org.springframework.boot:spring-boot-starter-web'
+    testImplementation "junit:junit:$junitVersion"
+    runtimeOnly group: 'com.h2database',
+        name: 'h2',
+        version: '2.2.220'
+    implementation project(':core')
+}
+`
+	result, err := parser.NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping() error = %v", err)
+	}
+	result.SourceMappedProject.FilePath = "build.gradle"
+
+	entries := UpdateManifest(result.SourceMappedProject)
+	want := []struct {
+		datasource, depName, currentValue, depType, skipReason string
+		line                                                   int
+	}{
+		{DatasourceMaven, "com.google.guava:guava", "31.1-jre", "implementation", "", 11},
+		{DatasourceMaven, "org.springframework.boot:spring-boot-starter-web", "", "implementation", SkipReasonUnspecifiedVersion, 12},
+		{DatasourceMaven, "junit:junit", "$junitVersion", "testImplementation", SkipReasonContainsVariable, 13},
+		{DatasourceMaven, "com.h2database:h2", "2.2.220", "runtimeOnly", "", 14},
+		{DatasourceGradlePlugin, "org.springframework.boot", "3.1.0", "plugin", "", 2},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %d", len(want), len(entries))
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.File != "build.gradle" || e.Datasource != w.datasource || e.DepName != w.depName ||
+			e.CurrentValue != w.currentValue || e.DepType != w.depType || e.SkipReason != w.skipReason || e.Line != w.line {
+			t.Errorf("Entry %d: got %+v, want %+v", i, e, w)
+		}
+		if (e.SkipReason == "") != (e.ReplaceRange != nil) {
+			t.Errorf("Entry %d: replace range should be set exactly when the entry is updatable", i)
+		}
+		if e.ReplaceRange != nil {
+			r := e.ReplaceRange
+			if got := content[r.Start.StartPos:r.End.StartPos]; got != e.CurrentValue {
+				t.Errorf("Entry %d: replace range covers %q, want %q", i, got, e.CurrentValue)
+			}
+		}
+	}
+	if h2 := entries[3].ReplaceRange; h2.Start.Line != 16 || h2.Start.Column != 19 {
+		t.Errorf("Expected h2 version at line 16, column 19, got %s", h2.Start)
+	}
+	if len(entries[0].RegistryURLs) != 1 || entries[0].RegistryURLs[0] != "https://repo.example.com/maven" {
+		t.Errorf("Unexpected registry URLs: %v", entries[0].RegistryURLs)
+	}
+
+	data, err := json.Marshal(entries[0])
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	for _, key := range []string{`"file":"build.gradle"`, `"line":11`, `"datasource":"maven"`, `"depName":"com.google.guava:guava"`, `"currentValue":"31.1-jre"`} {
+		if !strings.Contains(string(data), key) {
+			t.Errorf("Expected %s in %s", key, data)
+		}
+	}
+
+	if entries := UpdateManifest(nil); len(entries) != 0 {
+		t.Errorf("Expected no entries for nil project, got %d", len(entries))
+	}
+}
@@ -0,0 +1,150 @@
+// Package export 提供YAML编码器。
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// yamlPlainRegex 匹配可以不加引号输出的YAML标量。
+var yamlPlainRegex = regexp.MustCompile(`^[A-Za-z_/][A-Za-z0-9_./-]*$`)
+
+// yamlReservedWords 是YAML中会被解析为布尔值或空值的单词。
+var yamlReservedWords = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true,
+	"on": true, "off": true, "y": true, "n": true,
+	"null": true, "~": true,
+}
+
+// ToYAML 将值编码为YAML。
+func ToYAML(v any) ([]byte, error) {
+	tree, err := toTree(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch value := tree.(type) {
+	case object:
+		if len(value) == 0 {
+			buf.WriteString("{}\n")
+		} else {
+			writeYAMLObject(&buf, value, 0, false)
+		}
+	case []any:
+		if len(value) == 0 {
+			buf.WriteString("[]\n")
+		} else {
+			writeYAMLArray(&buf, value, 0)
+		}
+	default:
+		buf.WriteString(yamlScalar(value))
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// writeYAMLObject 输出对象的各个字段。
+// inline为true时第一个字段紧跟在已输出的 "- " 之后。
+func writeYAMLObject(buf *bytes.Buffer, obj object, indent int, inline bool) {
+	for i, f := range obj {
+		if i > 0 || !inline {
+			buf.WriteString(strings.Repeat(" ", indent))
+		}
+		buf.WriteString(yamlKey(f.key))
+		buf.WriteByte(':')
+		writeYAMLChild(buf, f.value, indent)
+	}
+}
+
+// writeYAMLChild 输出键之后的值。
+func writeYAMLChild(buf *bytes.Buffer, value any, indent int) {
+	switch v := value.(type) {
+	case object:
+		if len(v) == 0 {
+			buf.WriteString(" {}\n")
+			return
+		}
+		buf.WriteByte('\n')
+		writeYAMLObject(buf, v, indent+2, false)
+	case []any:
+		if len(v) == 0 {
+			buf.WriteString(" []\n")
+			return
+		}
+		buf.WriteByte('\n')
+		writeYAMLArray(buf, v, indent+2)
+	default:
+		buf.WriteByte(' ')
+		buf.WriteString(yamlScalar(v))
+		buf.WriteByte('\n')
+	}
+}
+
+// writeYAMLArray 输出数组的各个元素。
+func writeYAMLArray(buf *bytes.Buffer, arr []any, indent int) {
+	for _, item := range arr {
+		buf.WriteString(strings.Repeat(" ", indent))
+		buf.WriteByte('-')
+		switch v := item.(type) {
+		case object:
+			if len(v) == 0 {
+				buf.WriteString(" {}\n")
+				continue
+			}
+			buf.WriteByte(' ')
+			writeYAMLObject(buf, v, indent+2, true)
+		case []any:
+			if len(v) == 0 {
+				buf.WriteString(" []\n")
+				continue
+			}
+			buf.WriteByte('\n')
+			writeYAMLArray(buf, v, indent+2)
+		default:
+			buf.WriteByte(' ')
+			buf.WriteString(yamlScalar(v))
+			buf.WriteByte('\n')
+		}
+	}
+}
+
+// yamlKey 返回YAML键的文本。
+func yamlKey(key string) string {
+	if yamlNeedsQuote(key) {
+		return escapeString(key)
+	}
+	return key
+}
+
+// yamlScalar 返回YAML标量的文本。
+func yamlScalar(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return v.String()
+	case string:
+		if yamlNeedsQuote(v) {
+			return escapeString(v)
+		}
+		return v
+	default:
+		return "null"
+	}
+}
+
+// yamlNeedsQuote 判断字符串是否需要加引号才能保持为字符串。
+func yamlNeedsQuote(s string) bool {
+	if !yamlPlainRegex.MatchString(s) {
+		return true
+	}
+	return yamlReservedWords[strings.ToLower(s)]
+}
@@ -0,0 +1,453 @@
+// Package generate 提供从模型生成Gradle构建脚本的功能。
+package generate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// 写入ext属性时去除的键前缀，与解析器保存属性时使用的赋值左侧对应。
+var propertyKeyPrefixes = []string{"project.ext.", "rootProject.ext.", "ext.", "def ", "val ", "var "}
+
+// GradleWriter 把 model.Project 或其中的部分渲染为格式化的 build.gradle 或 build.gradle.kts 文本，
+// 用于脚手架等需要从零生成构建脚本的场景。
+type GradleWriter struct {
+	kotlin bool
+	indent string
+}
+
+// NewGradleWriter 创建生成Groovy DSL、使用4个空格缩进的写入器。
+func NewGradleWriter() *GradleWriter {
+	return &GradleWriter{indent: "    "}
+}
+
+// WithKotlinDSL 设置是否生成Kotlin DSL（build.gradle.kts）。
+func (w *GradleWriter) WithKotlinDSL(kotlin bool) *GradleWriter {
+	w.kotlin = kotlin
+	return w
+}
+
+// WithIndent 设置块内的缩进字符串。
+func (w *GradleWriter) WithIndent(indent string) *GradleWriter {
+	w.indent = indent
+	return w
+}
+
+// Write 生成完整的构建脚本，依次包含plugins、group/version/description、ext属性、
+// java兼容性、repositories、dependencies和任务，各部分之间用空行分隔，没有内容的部分省略。
+func (w *GradleWriter) Write(project *model.Project) (string, error) {
+	if project == nil {
+		return "", fmt.Errorf("项目为空")
+	}
+
+	sections := []string{
+		w.WritePlugins(project.Plugins),
+		w.writeCoordinates(project),
+		w.WriteProperties(project.Properties),
+		w.writeJavaCompatibility(project),
+		w.WriteRepositories(project.Repositories),
+		w.WriteDependencies(project.Dependencies),
+		w.WriteTasks(project.Tasks),
+	}
+
+	var sb strings.Builder
+	for _, section := range sections {
+		if section == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(section)
+	}
+	return sb.String(), nil
+}
+
+// WritePlugins 生成 plugins { } 块，Apply为false的插件加上 apply false。
+func (w *GradleWriter) WritePlugins(plugins []*model.Plugin) string {
+	lines := make([]string, 0, len(plugins))
+	for _, plugin := range plugins {
+		if plugin == nil || plugin.ID == "" {
+			continue
+		}
+		line := "id " + w.quote(plugin.ID)
+		if w.kotlin {
+			line = "id(" + w.quote(plugin.ID) + ")"
+		}
+		if plugin.Version != "" {
+			line += " version " + w.quote(plugin.Version)
+		}
+		if !plugin.Apply {
+			line += " apply false"
+		}
+		lines = append(lines, line)
+	}
+	return w.block("plugins", lines)
+}
+
+// WriteProperties 生成额外属性，Groovy DSL写入 ext { } 块，Kotlin DSL写为 extra["key"] = "value"。
+// 键的 ext.、def 等前缀会被去除，去除后不是标识符的键被跳过。属性按键名排序。
+func (w *GradleWriter) WriteProperties(properties map[string]string) string {
+	values := make(map[string]string, len(properties))
+	for key, value := range properties {
+		if name := propertyName(key); name != "" {
+			values[name] = value
+		}
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if w.kotlin {
+			lines = append(lines, fmt.Sprintf("extra[%s] = %s", w.quote(key), w.quote(values[key])))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s = %s", key, w.quote(values[key])))
+		}
+	}
+
+	if w.kotlin {
+		if len(lines) == 0 {
+			return ""
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+	return w.block("ext", lines)
+}
+
+// WriteRepositories 生成 repositories { } 块。mavenCentral、google等内置仓库写为方法调用，
+// 其他maven和ivy仓库写为带url、名称、凭证和 allowInsecureProtocol 的块，没有URL的自定义仓库被跳过。
+func (w *GradleWriter) WriteRepositories(repositories []*model.Repository) string {
+	lines := make([]string, 0, len(repositories))
+	for _, repo := range repositories {
+		if repo == nil {
+			continue
+		}
+		switch repo.Name {
+		case "mavenCentral", "mavenLocal", "google", "gradlePluginPortal", "jcenter":
+			if repo.URL == "" {
+				lines = append(lines, repo.Name+"()")
+				continue
+			}
+		}
+		if repo.URL == "" {
+			continue
+		}
+		lines = append(lines, w.repositoryLines(repo)...)
+	}
+	return w.block("repositories", lines)
+}
+
+// repositoryLines 生成 maven { } 或 ivy { } 块的各行（未缩进）。
+func (w *GradleWriter) repositoryLines(repo *model.Repository) []string {
+	repoType := repo.Type
+	if repoType != "ivy" {
+		repoType = "maven"
+	}
+
+	body := make([]string, 0)
+	// 名称与从URL推断的名称相同时不必写出。
+	if repo.Name != "" && repo.Name != repoType && repo.Name != hostOf(repo.URL) {
+		body = append(body, "name = "+w.quote(repo.Name))
+	}
+	if w.kotlin {
+		body = append(body, "url = uri("+w.quote(repo.URL)+")")
+	} else {
+		body = append(body, "url = "+w.quote(repo.URL))
+	}
+	body = append(body, w.credentialLines(repo)...)
+	if repo.AllowInsecureProtocol {
+		if w.kotlin {
+			body = append(body, "isAllowInsecureProtocol = true")
+		} else {
+			body = append(body, "allowInsecureProtocol = true")
+		}
+	}
+
+	return strings.Split(strings.TrimSuffix(w.block(repoType, body), "\n"), "\n")
+}
+
+// credentialLines 生成仓库的凭证配置：credentials(PasswordCredentials) 形式，
+// 或带有各项凭证的 credentials { } 块。没有凭证时返回nil。
+func (w *GradleWriter) credentialLines(repo *model.Repository) []string {
+	credentials := repo.Credentials
+	if credentials == nil && (repo.Username != "" || repo.Password != "") {
+		credentials = &model.RepositoryCredentials{Values: make(map[string]*model.CredentialValue)}
+		if repo.Username != "" {
+			credentials.Values["username"] = &model.CredentialValue{Source: model.CredentialLiteral, Value: repo.Username}
+		}
+		if repo.Password != "" {
+			credentials.Values["password"] = &model.CredentialValue{Source: model.CredentialLiteral, Value: repo.Password}
+		}
+	}
+	if credentials == nil {
+		return nil
+	}
+
+	credentialsType := credentials.Type
+	if credentials.Provided {
+		if w.kotlin {
+			return []string{"credentials(" + credentialsType + "::class)"}
+		}
+		return []string{"credentials(" + credentialsType + ")"}
+	}
+
+	keys := make([]string, 0, len(credentials.Values))
+	for key := range credentials.Values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, key+" = "+w.credentialValue(credentials.Values[key]))
+	}
+
+	header := "credentials"
+	if credentialsType != "" && credentialsType != model.CredentialsPassword {
+		if w.kotlin {
+			header = "credentials(" + credentialsType + "::class)"
+		} else {
+			header = "credentials(" + credentialsType + ")"
+		}
+	}
+	return strings.Split(strings.TrimSuffix(w.block(header, lines), "\n"), "\n")
+}
+
+// credentialValue 生成凭证值的表达式，优先使用原始表达式。
+func (w *GradleWriter) credentialValue(value *model.CredentialValue) string {
+	if value.Raw != "" && value.Source != model.CredentialLiteral {
+		return value.Raw
+	}
+	switch value.Source {
+	case model.CredentialEnvironment:
+		return "System.getenv(" + w.quote(value.Value) + ")"
+	case model.CredentialProperty:
+		if w.kotlin {
+			return "findProperty(" + w.quote(value.Value) + ") as String?"
+		}
+		return "findProperty(" + w.quote(value.Value) + ")"
+	case model.CredentialSystemProperty:
+		return "System.getProperty(" + w.quote(value.Value) + ")"
+	default:
+		return w.quote(value.Value)
+	}
+}
+
+// WriteDependencies 按原有顺序生成 dependencies { } 块，配置为空时使用 implementation。
+// 项目依赖写为 project(':path')，既没有坐标也不是项目依赖的条目被跳过。
+func (w *GradleWriter) WriteDependencies(dependencies []*model.Dependency) string {
+	lines := make([]string, 0, len(dependencies))
+	for _, dep := range dependencies {
+		if dep == nil {
+			continue
+		}
+		scope := dep.Scope
+		if scope == "" {
+			scope = "implementation"
+		}
+
+		notation := ""
+		switch {
+		case dep.Project != nil:
+			notation = w.projectNotation(dep.Project)
+		case dep.Group != "" && dep.Name != "":
+			notation = w.quote(coordinate(dep))
+		default:
+			continue
+		}
+
+		if w.kotlin {
+			lines = append(lines, scope+"("+notation+")")
+		} else {
+			lines = append(lines, scope+" "+notation)
+		}
+	}
+	return w.block("dependencies", lines)
+}
+
+// projectNotation 生成项目依赖的引用，例如 project(':app') 或 project(path: ':app', configuration: 'shadow')。
+func (w *GradleWriter) projectNotation(ref *model.ProjectRef) string {
+	if ref.Configuration == "" {
+		return "project(" + w.quote(ref.Path) + ")"
+	}
+	if w.kotlin {
+		return fmt.Sprintf("project(path = %s, configuration = %s)", w.quote(ref.Path), w.quote(ref.Configuration))
+	}
+	return fmt.Sprintf("project(path: %s, configuration: %s)", w.quote(ref.Path), w.quote(ref.Configuration))
+}
+
+// WriteTasks 把任务写为 tasks.register(...) 块，包括group、description以及
+// dependsOn、finalizedBy、mustRunAfter、shouldRunAfter关系。
+func (w *GradleWriter) WriteTasks(tasks []*model.Task) string {
+	var sb strings.Builder
+	for _, task := range tasks {
+		if task == nil || task.Name == "" {
+			continue
+		}
+
+		header := "tasks.register(" + w.quote(task.Name) + ")"
+		if task.Type != "" {
+			if w.kotlin {
+				header = "tasks.register<" + task.Type + ">(" + w.quote(task.Name) + ")"
+			} else {
+				header = "tasks.register(" + w.quote(task.Name) + ", " + task.Type + ")"
+			}
+		}
+
+		lines := make([]string, 0)
+		if task.Group != "" {
+			lines = append(lines, "group = "+w.quote(task.Group))
+		}
+		if task.Description != "" {
+			lines = append(lines, "description = "+w.quote(task.Description))
+		}
+		for _, relation := range []struct {
+			name  string
+			tasks []string
+		}{
+			{"dependsOn", task.DependsOn},
+			{"finalizedBy", task.FinalizedBy},
+			{"mustRunAfter", task.MustRunAfter},
+			{"shouldRunAfter", task.ShouldRunAfter},
+		} {
+			if len(relation.tasks) == 0 {
+				continue
+			}
+			names := make([]string, len(relation.tasks))
+			for i, name := range relation.tasks {
+				names[i] = w.quote(name)
+			}
+			if w.kotlin {
+				lines = append(lines, relation.name+"("+strings.Join(names, ", ")+")")
+			} else {
+				lines = append(lines, relation.name+" "+strings.Join(names, ", "))
+			}
+		}
+
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		if len(lines) == 0 {
+			sb.WriteString(header + "\n")
+			continue
+		}
+		sb.WriteString(w.block(header, lines))
+	}
+	return sb.String()
+}
+
+// writeCoordinates 生成 group、version 和 description 赋值。
+func (w *GradleWriter) writeCoordinates(project *model.Project) string {
+	var sb strings.Builder
+	for _, field := range []struct{ key, value string }{
+		{"group", project.Group},
+		{"version", project.Version},
+		{"description", project.Description},
+	} {
+		if field.value != "" {
+			sb.WriteString(field.key + " = " + w.quote(field.value) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// writeJavaCompatibility 在 java { } 块中生成 sourceCompatibility 和 targetCompatibility。
+// Kotlin DSL中非 JavaVersion 表达式的值写为 JavaVersion.toVersion("17")。
+func (w *GradleWriter) writeJavaCompatibility(project *model.Project) string {
+	lines := make([]string, 0, 2)
+	for _, field := range []struct{ key, value string }{
+		{"sourceCompatibility", project.SourceCompatibility},
+		{"targetCompatibility", project.TargetCompatibility},
+	} {
+		switch {
+		case field.value == "":
+			continue
+		case strings.HasPrefix(field.value, "JavaVersion."):
+			lines = append(lines, field.key+" = "+field.value)
+		case w.kotlin:
+			lines = append(lines, field.key+" = JavaVersion.toVersion("+w.quote(field.value)+")")
+		default:
+			lines = append(lines, field.key+" = "+w.quote(field.value))
+		}
+	}
+	return w.block("java", lines)
+}
+
+// block 生成名为header的块，lines中的每一行（可以是多行块的一部分）缩进一级。没有行时返回空字符串。
+func (w *GradleWriter) block(header string, lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(header + " {\n")
+	for _, line := range lines {
+		sb.WriteString(w.indent + line + "\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// quote 按DSL生成字符串字面量：Kotlin使用双引号；Groovy使用单引号，含有 $ 插值时使用双引号。
+func (w *GradleWriter) quote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	if w.kotlin || strings.Contains(value, "$") {
+		return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+	}
+	return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+}
+
+// coordinate 拼接依赖的坐标字符串，例如 group:name:version:classifier@extension。
+func coordinate(dep *model.Dependency) string {
+	text := dep.Group + ":" + dep.Name
+	if dep.Version != "" || dep.Classifier != "" {
+		text += ":" + dep.Version
+	}
+	if dep.Classifier != "" {
+		text += ":" + dep.Classifier
+	}
+	if dep.Extension != "" {
+		text += "@" + dep.Extension
+	}
+	return text
+}
+
+// propertyName 返回属性键去除前缀后的名称，不是标识符时返回空字符串。
+func propertyName(key string) string {
+	key = strings.TrimSpace(key)
+	for _, prefix := range propertyKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			key = strings.TrimSpace(key[len(prefix):])
+			break
+		}
+	}
+	// Kotlin DSL的 extra["key"] 形式。
+	if strings.HasPrefix(key, "extra[") && strings.HasSuffix(key, "]") {
+		key = strings.Trim(key[len("extra["):len(key)-1], `"'`)
+	}
+
+	if key == "" {
+		return ""
+	}
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9') {
+			return ""
+		}
+	}
+	return key
+}
+
+// hostOf 返回URL中的域名部分，与解析器从URL推断仓库名称的方式一致。
+func hostOf(url string) string {
+	parts := strings.Split(url, "/")
+	if len(parts) > 2 {
+		return parts[2]
+	}
+	return ""
+}
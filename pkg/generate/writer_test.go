@@ -0,0 +1,187 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/parser"
+)
+
+func sampleProject() *model.Project {
+	return &model.Project{
+		Group:               "com.example",
+		Version:             "1.0.0",
+		Description:         "Demo service",
+		SourceCompatibility: "17",
+		Properties:          map[string]string{"ext.springVersion": "6.0.11", "not a key": "x"},
+		Plugins: []*model.Plugin{
+			{ID: "java", Apply: true},
+			{ID: "org.springframework.boot", Version: "3.1.0", Apply: false},
+		},
+		Repositories: []*model.Repository{
+			{Name: "mavenCentral", Type: "maven"},
+			{Name: "jitpack.io", Type: "maven", URL: "https://jitpack.io"},
+			{Name: "internal", Type: "maven", URL: "https://repo.example.com/maven", Username: "deployer", Password: "secret"},
+		},
+		Dependencies: []*model.Dependency{
+			{Group: "org.springframework", Name: "spring-core", Version: "$springVersion", Scope: "implementation"},
+			{Group: "net.sf.json-lib", Name: "json-lib", Version: "2.4", Classifier: "jdk15", Scope: "implementation"},
+			{Project: &model.ProjectRef{Path: ":core"}, Scope: "api"},
+			{Group: "org.junit.jupiter", Name: "junit-jupiter", Version: "5.10.0", Scope: "testImplementation"},
+		},
+		Tasks: []*model.Task{
+			{Name: "hello", Group: "demo", DependsOn: []string{"build"}},
+		},
+	}
+}
+
+func TestWriteGroovy(t *testing.T) {
+	text, err := NewGradleWriter().Write(sampleProject())
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	expected := `plugins {
+    id 'java'
+    id 'org.springframework.boot' version '3.1.0' apply false
+}
+
+group = 'com.example'
+version = '1.0.0'
+description = 'Demo service'
+
+ext {
+    springVersion = '6.0.11'
+}
+
+java {
+    sourceCompatibility = '17'
+}
+
+repositories {
+    mavenCentral()
+    maven {
+        url = 'https://jitpack.io'
+    }
+    maven {
+        name = 'internal'
+        url = 'https://repo.example.com/maven'
+        credentials {
+            password = 'secret'
+            username = 'deployer'
+        }
+    }
+}
+
+dependencies {
+    implementation "org.springframework:spring-core:$springVersion"
+    implementation 'net.sf.json-lib:json-lib:2.4:jdk15'
+    api project(':core')
+    testImplementation 'org.junit.jupiter:junit-jupiter:5.10.0'
+}
+
+tasks.register('hello') {
+    group = 'demo'
+    dependsOn 'build'
+}
+`
+	if text != expected {
+		t.Errorf("Unexpected output:\n%s", text)
+	}
+}
+
+func TestWriteKotlin(t *testing.T) {
+	text, err := NewGradleWriter().WithKotlinDSL(true).WithIndent("  ").Write(&model.Project{
+		SourceCompatibility: "17",
+		Plugins:             []*model.Plugin{{ID: "application", Apply: true}},
+		Properties:          map[string]string{"kotlinVersion": "1.9.0"},
+		Repositories:        []*model.Repository{{Name: "google", Type: "maven"}},
+		Dependencies: []*model.Dependency{
+			{Group: "com.squareup.okhttp3", Name: "okhttp", Version: "4.12.0"},
+			{Project: &model.ProjectRef{Path: ":lib", Configuration: "shadow"}, Scope: "runtimeOnly"},
+		},
+		Tasks: []*model.Task{{Name: "fatJar", Type: "Jar"}},
+	})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	expected := `plugins {
+  id("application")
+}
+
+extra["kotlinVersion"] = "1.9.0"
+
+java {
+  sourceCompatibility = JavaVersion.toVersion("17")
+}
+
+repositories {
+  google()
+}
+
+dependencies {
+  implementation("com.squareup.okhttp3:okhttp:4.12.0")
+  runtimeOnly(project(path = ":lib", configuration = "shadow"))
+}
+
+tasks.register<Jar>("fatJar")
+`
+	if text != expected {
+		t.Errorf("Unexpected output:\n%s", text)
+	}
+}
+
+func TestWriteNilProject(t *testing.T) {
+	if _, err := NewGradleWriter().Write(nil); err == nil {
+		t.Error("Expected error for nil project")
+	}
+	if text, err := NewGradleWriter().Write(&model.Project{}); err != nil || text != "" {
+		t.Errorf("Expected empty output for empty project, got %q, %v", text, err)
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	// 逐行解析器会把块内的赋值（例如任务的group）当作项目属性，这里只比较它能还原的部分。
+	project := sampleProject()
+	project.Tasks = nil
+	writer := NewGradleWriter()
+	text, err := writer.Write(project)
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	result, err := parser.NewParser().Parse(text)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	parsed := result.Project
+
+	if parsed.Group != project.Group || parsed.Version != project.Version || parsed.Description != project.Description {
+		t.Errorf("coordinates not preserved: %s:%s %q", parsed.Group, parsed.Version, parsed.Description)
+	}
+	if len(parsed.Plugins) != 2 || parsed.Plugins[1].ID != "org.springframework.boot" || parsed.Plugins[1].Version != "3.1.0" {
+		t.Errorf("plugins not preserved: %+v", parsed.Plugins)
+	}
+	if len(parsed.Repositories) != 3 || parsed.Repositories[1].URL != "https://jitpack.io" ||
+		parsed.Repositories[2].Name != "internal" || parsed.Repositories[2].Password != "secret" {
+		t.Errorf("repositories not preserved: %+v", parsed.Repositories)
+	}
+	if len(parsed.Dependencies) != 4 {
+		t.Fatalf("expected 4 dependencies, got %d", len(parsed.Dependencies))
+	}
+	for i, dep := range parsed.Dependencies {
+		want := project.Dependencies[i]
+		if dep.Scope != want.Scope || dep.Group != want.Group || dep.Version != want.Version || dep.Classifier != want.Classifier {
+			t.Errorf("dependency %d = %+v, want %+v", i, dep, want)
+		}
+	}
+
+	// 依赖和仓库再次生成应得到相同的文本。
+	if got, want := writer.WriteDependencies(parsed.Dependencies), writer.WriteDependencies(project.Dependencies); got != want {
+		t.Errorf("dependencies not stable:\n%s\n---\n%s", want, got)
+	}
+	if got, want := writer.WriteRepositories(parsed.Repositories), writer.WriteRepositories(project.Repositories); got != want {
+		t.Errorf("repositories not stable:\n%s\n---\n%s", want, got)
+	}
+}
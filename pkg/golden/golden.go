@@ -0,0 +1,256 @@
+// Package golden 提供基于golden文件的解析结果快照测试工具。
+//
+// 语料目录中的每个构建文件（*.gradle、*.gradle.kts）、版本目录（*.versions.toml）和包含settings文件的目录
+// （以项目模式解析，用于多模块和复合构建）都是一个用例。用例的解析结果被规范化为稳定的JSON快照，
+// 与提交在仓库中的golden文件比较，提取行为的变化因此表现为可以审查的golden文件差异。
+// 使用方可以把自己的构建文件放进任意目录，用 Run 对该目录运行同样的检查。
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/api"
+	"github.com/scagogogo/gradle-parser/pkg/catalog"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// 快照文件名的后缀，以及目录用例的快照文件名。
+const (
+	goldenSuffix      = ".golden.json"
+	projectGoldenFile = "project" + goldenSuffix
+)
+
+// 快照中被删除的字段，它们的值每次解析都不同，或者只是原始文本的副本。
+var volatileKeys = map[string]bool{
+	"parseTime": true,
+	"rawText":   true,
+}
+
+// Fixture 表示语料中的一个用例。
+type Fixture struct {
+	// Name 是用例相对语料目录的路径，用作子测试名称，例如 android-app/build.gradle。
+	Name string
+	// Path 是构建文件或项目目录的路径。
+	Path string
+	// Project 为true时 Path 是包含settings文件的目录，以项目模式解析。
+	Project bool
+	// Golden 是快照文件的路径：文件用例为 <文件名>.golden.json，目录用例为目录下的 project.golden.json。
+	Golden string
+}
+
+// Fixtures 查找 dir 下的所有用例，按名称排序。包含settings文件的目录作为一个项目用例，不再查找其中的文件。
+func Fixtures(dir string) ([]*Fixture, error) {
+	fixtures := make([]*Fixture, 0)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name, relErr := filepath.Rel(dir, path)
+		if relErr != nil {
+			return relErr
+		}
+		name = filepath.ToSlash(name)
+
+		if entry.IsDir() {
+			if path != dir && hasSettingsFile(path) {
+				fixtures = append(fixtures, &Fixture{Name: name, Path: path, Project: true, Golden: filepath.Join(path, projectGoldenFile)})
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isFixtureFile(entry.Name()) {
+			fixtures = append(fixtures, &Fixture{Name: name, Path: path, Golden: path + goldenSuffix})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查找用例失败: %w", err)
+	}
+
+	sort.Slice(fixtures, func(i, j int) bool { return fixtures[i].Name < fixtures[j].Name })
+	return fixtures, nil
+}
+
+// Snapshot 解析用例并返回规范化的JSON快照。
+// 快照中删除了解析耗时和原始文本，用例路径之下的文件路径改为相对用例的路径，解析错误记录为错误消息。
+func Snapshot(fixture *Fixture) ([]byte, error) {
+	var value any
+	root := fixture.Path
+	switch {
+	case fixture.Project:
+		tree, err := api.ParseProjectTree(fixture.Path)
+		if err != nil {
+			return nil, err
+		}
+		value = &struct {
+			*model.ProjectTree
+			Errors []string `json:"errors,omitempty"`
+		}{tree, errorMessages(tree.Errors)}
+	case strings.HasSuffix(fixture.Path, ".toml"):
+		versionCatalog, err := catalog.ParseFile(fixture.Path)
+		if err != nil {
+			return nil, err
+		}
+		value = versionCatalog
+		root = filepath.Dir(fixture.Path)
+	default:
+		result, err := api.ParseFile(fixture.Path)
+		if err != nil {
+			return nil, err
+		}
+		value = &struct {
+			*model.ParseResult
+			Errors []string `json:"errors,omitempty"`
+		}{result, errorMessages(result.Errors)}
+		root = filepath.Dir(fixture.Path)
+	}
+
+	return normalize(value, root)
+}
+
+// Check 比较用例的快照与golden文件，update为true时改为写入golden文件。
+// 快照与golden文件不同时返回的错误中包含第一处不同的行。
+func Check(fixture *Fixture, update bool) error {
+	got, err := Snapshot(fixture)
+	if err != nil {
+		return fmt.Errorf("解析用例 %s 失败: %w", fixture.Name, err)
+	}
+
+	if update {
+		return os.WriteFile(fixture.Golden, got, 0o644)
+	}
+
+	want, err := os.ReadFile(fixture.Golden)
+	if err != nil {
+		return fmt.Errorf("读取golden文件失败（可以用update模式生成）: %w", err)
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("用例 %s 的快照与 %s 不同:\n%s", fixture.Name, fixture.Golden, firstDifference(want, got))
+	}
+	return nil
+}
+
+// Run 为 dir 下的每个用例运行一个子测试，比较快照与golden文件；update为true时改为写入golden文件。
+// 通常在测试中用命令行参数控制 update，例如 go test -run TestGolden -update。
+func Run(t *testing.T, dir string, update bool) {
+	t.Helper()
+
+	fixtures, err := Fixtures(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatalf("%s 下没有用例", dir)
+	}
+
+	for _, fixture := range fixtures {
+		t.Run(fixture.Name, func(t *testing.T) {
+			if err := Check(fixture, update); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// normalize 把值编码为JSON，删除易变字段，把root之下的路径改为相对路径，再以缩进格式重新编码。
+func normalize(value any, root string) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+
+	prefixes := []string{filepath.ToSlash(filepath.Clean(root))}
+	if abs, err := filepath.Abs(root); err == nil {
+		prefixes = append(prefixes, filepath.ToSlash(abs))
+	}
+	decoded = normalizeValue(decoded, prefixes)
+
+	normalized, err := json.MarshalIndent(decoded, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(normalized, '\n'), nil
+}
+
+// normalizeValue 递归删除易变字段并替换路径前缀。
+func normalizeValue(value any, prefixes []string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if volatileKeys[key] {
+				delete(v, key)
+				continue
+			}
+			v[key] = normalizeValue(item, prefixes)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeValue(item, prefixes)
+		}
+	case string:
+		for _, prefix := range prefixes {
+			if v == prefix {
+				return "."
+			}
+			v = strings.ReplaceAll(v, prefix+"/", "")
+		}
+		return v
+	}
+	return value
+}
+
+// errorMessages 把错误转换为消息，error 本身编码为JSON时会丢失内容。
+func errorMessages(errs []error) []string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
+
+// firstDifference 返回两段文本中第一处不同的行。
+func firstDifference(want, got []byte) string {
+	wantLines := strings.Split(string(want), "\n")
+	gotLines := strings.Split(string(got), "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("第%d行:\n- %s\n+ %s", i+1, w, g)
+		}
+	}
+	return ""
+}
+
+// hasSettingsFile 判断目录中是否有settings文件。
+func hasSettingsFile(dir string) bool {
+	for _, name := range []string{"settings.gradle", "settings.gradle.kts"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isFixtureFile 判断文件是否是构建文件或版本目录。
+func isFixtureFile(name string) bool {
+	return strings.HasSuffix(name, ".gradle") || strings.HasSuffix(name, ".gradle.kts") ||
+		strings.HasSuffix(name, ".versions.toml")
+}
@@ -0,0 +1,68 @@
+package golden
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "用当前的解析结果更新golden文件")
+
+func TestGoldenCorpus(t *testing.T) {
+	Run(t, filepath.Join("testdata", "corpus"), *update)
+}
+
+func TestFixtures(t *testing.T) {
+	fixtures, err := Fixtures(filepath.Join("testdata", "corpus"))
+	if err != nil {
+		t.Fatalf("Fixtures() error = %v", err)
+	}
+	names := make([]string, 0, len(fixtures))
+	for _, fixture := range fixtures {
+		names = append(names, fixture.Name)
+	}
+	expected := "android-app/build.gradle,composite,kotlin-dsl/build.gradle.kts,spring-boot/build.gradle,version-catalog"
+	if strings.Join(names, ",") != expected {
+		t.Errorf("Fixtures() = %v, want %s", names, expected)
+	}
+	if !fixtures[1].Project || filepath.Base(fixtures[1].Golden) != "project.golden.json" {
+		t.Errorf("Directories with a settings file should be project fixtures, got %+v", fixtures[1])
+	}
+}
+
+func TestCheckReportsDifference(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "build.gradle")
+	if err := os.WriteFile(path, []byte("version = '1.0'\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fixture := &Fixture{Name: "build.gradle", Path: path, Golden: path + goldenSuffix}
+
+	if err := Check(fixture, false); err == nil {
+		t.Error("Expected error when the golden file is missing")
+	}
+	if err := Check(fixture, true); err != nil {
+		t.Fatalf("Check() with update error = %v", err)
+	}
+	if err := Check(fixture, false); err != nil {
+		t.Errorf("Check() after update error = %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("version = '2.0'\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := Check(fixture, false)
+	if err == nil || !strings.Contains(err.Error(), `+     "version": "2.0"`) {
+		t.Errorf("Expected the changed line in the error, got %v", err)
+	}
+
+	snapshot, err := Snapshot(fixture)
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if strings.Contains(string(snapshot), dir) || strings.Contains(string(snapshot), "parseTime") {
+		t.Errorf("Snapshot should not contain volatile values:\n%s", snapshot)
+	}
+}
@@ -0,0 +1,33 @@
+plugins {
+    kotlin("jvm") version "1.9.22"
+    `java-library`
+    `maven-publish`
+}
+
+group = "com.example.lib"
+version = "1.4.0"
+
+repositories {
+    mavenCentral()
+    maven("https://repo.example.com/releases")
+}
+
+dependencies {
+    api("com.squareup.okhttp3:okhttp:4.12.0")
+    implementation(kotlin("stdlib"))
+    implementation("org.jetbrains.kotlinx:kotlinx-coroutines-core:1.7.3")
+    testImplementation("org.junit.jupiter:junit-jupiter:5.10.1")
+    testRuntimeOnly("org.junit.platform:junit-platform-launcher")
+}
+
+kotlin {
+    jvmToolchain(17)
+}
+
+publishing {
+    publications {
+        create<MavenPublication>("maven") {
+            from(components["java"])
+        }
+    }
+}
@@ -0,0 +1,14 @@
+plugins {
+    alias(libs.plugins.kotlin.jvm)
+    application
+}
+
+dependencies {
+    implementation(libs.guava)
+    implementation(libs.bundles.logging)
+    testImplementation(libs.junit.jupiter)
+}
+
+application {
+    mainClass.set("com.example.AppKt")
+}
@@ -0,0 +1,156 @@
+// Package graph 提供包含传递依赖的依赖图的构建功能。
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// TransitiveResolver 返回依赖的直接传递依赖，例如从Maven仓库的POM或Gradle的依赖报告中读取。
+// 返回的依赖的Scope作为边的配置，可以为空。
+type TransitiveResolver func(dep *model.Dependency) ([]*model.Dependency, error)
+
+// BuildDependencyGraph 从项目（及其子项目）声明的依赖构建依赖图，模块之间的 project(':x') 依赖也作为边。
+// resolver不为nil时对每个外部依赖调用一次，返回的传递依赖递归加入图中；
+// resolver为nil或解析失败时，外部依赖保留为占位节点（Placeholder），其传递依赖未知。
+// 解析失败不会中止构建，错误记录在节点的Error中并合并后返回。
+func BuildDependencyGraph(project *model.Project, resolver TransitiveResolver) (*model.DependencyGraph, error) {
+	b := &dependencyGraphBuilder{
+		graph: &model.DependencyGraph{
+			Nodes: make([]*model.DependencyGraphNode, 0),
+			Edges: make([]*model.DependencyGraphEdge, 0),
+		},
+		nodes:    make(map[string]*model.DependencyGraphNode),
+		edges:    make(map[model.DependencyGraphEdge]bool),
+		resolver: resolver,
+	}
+	if project != nil {
+		b.addProject(project, ":")
+	}
+	return b.graph, errors.Join(b.errs...)
+}
+
+// dependencyGraphBuilder 保存构建依赖图时的索引和解析错误。
+type dependencyGraphBuilder struct {
+	graph    *model.DependencyGraph
+	nodes    map[string]*model.DependencyGraphNode
+	edges    map[model.DependencyGraphEdge]bool
+	resolver TransitiveResolver
+	errs     []error
+}
+
+// addProject 递归添加模块节点及其声明的依赖，子项目路径的规则与 Build 相同。
+func (b *dependencyGraphBuilder) addProject(project *model.Project, modulePath string) {
+	moduleID := b.addModule(modulePath)
+
+	for _, dep := range project.Dependencies {
+		if dep == nil {
+			continue
+		}
+		if isProjectDependency(dep) {
+			target := dep.Name
+			if dep.Project != nil {
+				target = dep.Project.Path
+			}
+			if !strings.HasPrefix(target, ":") {
+				target = ":" + target
+			}
+			b.addEdge(moduleID, b.addModule(target), dep.Scope)
+			continue
+		}
+		if id := b.addExternal(dep, true); id != "" {
+			b.addEdge(moduleID, id, dep.Scope)
+		}
+	}
+
+	for _, sub := range project.SubProjects {
+		if sub == nil || sub.Name == "" {
+			continue
+		}
+		subPath := modulePath + ":" + sub.Name
+		if modulePath == ":" {
+			subPath = ":" + sub.Name
+		}
+		b.addProject(sub, subPath)
+	}
+}
+
+// addModule 添加模块节点（已存在时复用）并返回节点ID。
+func (b *dependencyGraphBuilder) addModule(path string) string {
+	if _, ok := b.nodes[path]; !ok {
+		b.addNode(&model.DependencyGraphNode{ID: path, Kind: model.DependencyNodeModule, Name: path})
+	}
+	return path
+}
+
+// addExternal 添加外部依赖节点并解析其传递依赖，节点已存在时只更新Direct。
+// 缺少group或name的依赖（例如整个坐标由变量给出）无法确定节点，返回空字符串。
+func (b *dependencyGraphBuilder) addExternal(dep *model.Dependency, direct bool) string {
+	if dep.Group == "" || dep.Name == "" {
+		return ""
+	}
+	version := dep.Version
+	if dep.ResolvedVersion != "" {
+		version = dep.ResolvedVersion
+	}
+	id := dep.Group + ":" + dep.Name
+	if version != "" {
+		id += ":" + version
+	}
+
+	if node, ok := b.nodes[id]; ok {
+		node.Direct = node.Direct || direct
+		return id
+	}
+
+	node := &model.DependencyGraphNode{
+		ID:          id,
+		Kind:        model.DependencyNodeExternal,
+		Group:       dep.Group,
+		Name:        dep.Name,
+		Version:     version,
+		Direct:      direct,
+		Placeholder: true,
+	}
+	b.addNode(node)
+	if b.resolver == nil {
+		return id
+	}
+
+	// 先登记节点再解析，传递依赖中的环会指向已有节点而不是无限递归。
+	children, err := b.resolver(dep)
+	if err != nil {
+		node.Error = err.Error()
+		b.errs = append(b.errs, fmt.Errorf("解析 %s 的传递依赖失败: %w", id, err))
+		return id
+	}
+	node.Placeholder = false
+	for _, child := range children {
+		if child == nil {
+			continue
+		}
+		if childID := b.addExternal(child, false); childID != "" {
+			b.addEdge(id, childID, child.Scope)
+		}
+	}
+	return id
+}
+
+// addNode 添加节点。
+func (b *dependencyGraphBuilder) addNode(node *model.DependencyGraphNode) {
+	b.nodes[node.ID] = node
+	b.graph.Nodes = append(b.graph.Nodes, node)
+}
+
+// addEdge 添加边，重复的边会被忽略。
+func (b *dependencyGraphBuilder) addEdge(from, to, scope string) {
+	edge := model.DependencyGraphEdge{From: from, To: to, Scope: scope}
+	if b.edges[edge] {
+		return
+	}
+	b.edges[edge] = true
+	b.graph.Edges = append(b.graph.Edges, &edge)
+}
@@ -0,0 +1,95 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestBuildDependencyGraph(t *testing.T) {
+	project := &model.Project{
+		Dependencies: []*model.Dependency{
+			{Group: "org.foo", Name: "a", Version: "1.0", Scope: "implementation"},
+			{Name: "core", Scope: "implementation", Project: &model.ProjectRef{Path: ":core"}},
+			{Group: "org.foo", Name: "broken", Version: "1.0", Scope: "runtimeOnly"},
+			{Name: "${deps.spring}", Scope: "implementation"},
+		},
+		SubProjects: []*model.Project{
+			{Name: "core", Dependencies: []*model.Dependency{{Group: "org.foo", Name: "b", Version: "2.0", Scope: "api"}}},
+		},
+	}
+
+	transitive := map[string][]*model.Dependency{
+		"a": {{Group: "org.foo", Name: "b", Version: "2.0", Scope: "compile"}},
+		"b": {{Group: "org.foo", Name: "a", Version: "1.0", Scope: "runtime"}},
+	}
+	calls := make(map[string]int)
+	errBroken := errors.New("pom not found")
+	resolver := func(dep *model.Dependency) ([]*model.Dependency, error) {
+		calls[dep.Name]++
+		if dep.Name == "broken" {
+			return nil, errBroken
+		}
+		return transitive[dep.Name], nil
+	}
+
+	g, err := BuildDependencyGraph(project, resolver)
+	if !errors.Is(err, errBroken) {
+		t.Errorf("Expected resolver error to be returned, got %v", err)
+	}
+	if !reflect.DeepEqual(calls, map[string]int{"a": 1, "b": 1, "broken": 1}) {
+		t.Errorf("Expected each dependency to be resolved once, got %v", calls)
+	}
+
+	ids := make([]string, 0, len(g.Nodes))
+	for _, node := range g.Nodes {
+		ids = append(ids, node.ID)
+	}
+	wantIDs := []string{":", "org.foo:a:1.0", "org.foo:b:2.0", ":core", "org.foo:broken:1.0"}
+	if !reflect.DeepEqual(ids, wantIDs) {
+		t.Errorf("Nodes = %v, want %v", ids, wantIDs)
+	}
+
+	edges := make([]model.DependencyGraphEdge, 0, len(g.Edges))
+	for _, edge := range g.Edges {
+		edges = append(edges, *edge)
+	}
+	wantEdges := []model.DependencyGraphEdge{
+		{From: "org.foo:b:2.0", To: "org.foo:a:1.0", Scope: "runtime"},
+		{From: "org.foo:a:1.0", To: "org.foo:b:2.0", Scope: "compile"},
+		{From: ":", To: "org.foo:a:1.0", Scope: "implementation"},
+		{From: ":", To: ":core", Scope: "implementation"},
+		{From: ":", To: "org.foo:broken:1.0", Scope: "runtimeOnly"},
+		{From: ":core", To: "org.foo:b:2.0", Scope: "api"},
+	}
+	if !reflect.DeepEqual(edges, wantEdges) {
+		t.Errorf("Edges = %+v, want %+v", edges, wantEdges)
+	}
+
+	if b := g.Node("org.foo:b:2.0"); !b.Direct || b.Placeholder {
+		t.Errorf("Expected b to be a resolved direct dependency, got %+v", b)
+	}
+	if broken := g.Node("org.foo:broken:1.0"); !broken.Placeholder || broken.Error != "pom not found" {
+		t.Errorf("Expected broken to be a placeholder with an error, got %+v", broken)
+	}
+	if cycles := g.Cycles(); len(cycles) != 1 || len(cycles[0]) != 2 {
+		t.Errorf("Expected the a <-> b cycle, got %v", cycles)
+	}
+}
+
+func TestBuildDependencyGraphWithoutResolver(t *testing.T) {
+	project := &model.Project{
+		Dependencies: []*model.Dependency{{Group: "org.foo", Name: "a", Version: "1.0", Scope: "implementation"}},
+	}
+
+	g, err := BuildDependencyGraph(project, nil)
+	if err != nil {
+		t.Fatalf("BuildDependencyGraph() error = %v", err)
+	}
+	stats := g.Stats()
+	if stats.Modules != 1 || stats.Direct != 1 || stats.Placeholders != 1 || stats.MaxDepth != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
@@ -0,0 +1,208 @@
+// Package graph 提供依赖关系图的构建与导出功能。
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// Format 表示依赖图的导出格式。
+type Format string
+
+const (
+	// FormatDOT 表示Graphviz DOT格式。
+	FormatDOT Format = "dot"
+	// FormatMermaid 表示Mermaid流程图格式。
+	FormatMermaid Format = "mermaid"
+)
+
+// NodeKind 表示节点类型。
+type NodeKind string
+
+const (
+	NodeKindModule     NodeKind = "module"
+	NodeKindDependency NodeKind = "dependency"
+	NodeKindTask       NodeKind = "task"
+)
+
+// Node 表示图中的一个节点。
+type Node struct {
+	ID    string   `json:"id"`
+	Label string   `json:"label"`
+	Kind  NodeKind `json:"kind"`
+}
+
+// Edge 表示图中的一条边。
+type Edge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Scope string `json:"scope,omitempty"` // 依赖的作用域，任务关系图中为关系类型。
+}
+
+// Graph 表示模块与依赖之间的关系图。
+type Graph struct {
+	Nodes []*Node `json:"nodes"`
+	Edges []*Edge `json:"edges"`
+
+	nodeIndex map[string]*Node
+	edgeIndex map[string]bool
+}
+
+// Build 从项目（及其子项目）构建依赖图。
+func Build(project *model.Project) *Graph {
+	g := newGraph()
+	if project != nil {
+		g.addProject(project, ":", true)
+	}
+	return g
+}
+
+// newGraph 创建空图。
+func newGraph() *Graph {
+	return &Graph{
+		Nodes:     make([]*Node, 0),
+		Edges:     make([]*Edge, 0),
+		nodeIndex: make(map[string]*Node),
+		edgeIndex: make(map[string]bool),
+	}
+}
+
+// addProject 递归添加项目节点及其依赖边。
+// 根项目的路径固定为":"，子项目路径为"父路径:名称"。
+func (g *Graph) addProject(project *model.Project, modulePath string, isRoot bool) {
+	label := modulePath
+	if isRoot && project.Name != "" {
+		label = project.Name
+	}
+	moduleID := g.addNode("module "+modulePath, label, NodeKindModule)
+
+	for _, dep := range project.Dependencies {
+		if dep == nil {
+			continue
+		}
+
+		if isProjectDependency(dep) {
+			target := dep.Name
+			if !strings.HasPrefix(target, ":") {
+				target = ":" + target
+			}
+			toID := g.addNode("module "+target, target, NodeKindModule)
+			g.addEdge(moduleID, toID, dep.Scope)
+			continue
+		}
+
+		coordinate := dep.Group + ":" + dep.Name
+		label := coordinate
+		if dep.Version != "" {
+			label += ":" + dep.Version
+		}
+		toID := g.addNode("dependency "+label, label, NodeKindDependency)
+		g.addEdge(moduleID, toID, dep.Scope)
+	}
+
+	for _, sub := range project.SubProjects {
+		if sub == nil || sub.Name == "" {
+			continue
+		}
+		subPath := modulePath + ":" + sub.Name
+		if modulePath == ":" {
+			subPath = ":" + sub.Name
+		}
+		g.addProject(sub, subPath, false)
+	}
+}
+
+// addNode 添加节点（已存在时复用）并返回节点ID。
+func (g *Graph) addNode(key, label string, kind NodeKind) string {
+	if node, ok := g.nodeIndex[key]; ok {
+		return node.ID
+	}
+	node := &Node{
+		ID:    fmt.Sprintf("n%d", len(g.Nodes)),
+		Label: label,
+		Kind:  kind,
+	}
+	g.nodeIndex[key] = node
+	g.Nodes = append(g.Nodes, node)
+	return node.ID
+}
+
+// addEdge 添加边，重复的边会被忽略。
+func (g *Graph) addEdge(from, to, scope string) {
+	key := from + "->" + to + "@" + scope
+	if g.edgeIndex[key] {
+		return
+	}
+	g.edgeIndex[key] = true
+	g.Edges = append(g.Edges, &Edge{From: from, To: to, Scope: scope})
+}
+
+// Export 将项目依赖图导出为指定格式的文本。
+func Export(project *model.Project, format Format) (string, error) {
+	g := Build(project)
+	switch format {
+	case FormatDOT:
+		return g.ToDOT(), nil
+	case FormatMermaid:
+		return g.ToMermaid(), nil
+	default:
+		return "", fmt.Errorf("不支持的图导出格式: %s", format)
+	}
+}
+
+// ToDOT 将依赖图转换为Graphviz DOT文本。
+func (g *Graph) ToDOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph dependencies {\n")
+	sb.WriteString("    rankdir=LR;\n")
+	for _, node := range g.Nodes {
+		shape := "ellipse"
+		if node.Kind == NodeKindModule {
+			shape = "box"
+		}
+		fmt.Fprintf(&sb, "    %s [label=%q, shape=%s];\n", node.ID, node.Label, shape)
+	}
+	for _, edge := range g.Edges {
+		if edge.Scope != "" {
+			fmt.Fprintf(&sb, "    %s -> %s [label=%q];\n", edge.From, edge.To, edge.Scope)
+		} else {
+			fmt.Fprintf(&sb, "    %s -> %s;\n", edge.From, edge.To)
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// ToMermaid 将依赖图转换为Mermaid流程图文本。
+func (g *Graph) ToMermaid() string {
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+	for _, node := range g.Nodes {
+		label := escapeMermaid(node.Label)
+		if node.Kind == NodeKindModule {
+			fmt.Fprintf(&sb, "    %s[\"%s\"]\n", node.ID, label)
+		} else {
+			fmt.Fprintf(&sb, "    %s([\"%s\"])\n", node.ID, label)
+		}
+	}
+	for _, edge := range g.Edges {
+		if edge.Scope != "" {
+			fmt.Fprintf(&sb, "    %s -->|%s| %s\n", edge.From, escapeMermaid(edge.Scope), edge.To)
+		} else {
+			fmt.Fprintf(&sb, "    %s --> %s\n", edge.From, edge.To)
+		}
+	}
+	return sb.String()
+}
+
+// isProjectDependency 判断是否是project(':x')形式的模块依赖。
+func isProjectDependency(dep *model.Dependency) bool {
+	return dep.Project != nil || dep.Group == "" && strings.HasPrefix(strings.TrimSpace(dep.Raw), "project(")
+}
+
+// escapeMermaid 转义Mermaid标签中的特殊字符。
+func escapeMermaid(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
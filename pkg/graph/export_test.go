@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func createTestProject() *model.Project {
+	return &model.Project{
+		Name: "demo",
+		Dependencies: []*model.Dependency{
+			{Group: "com.google.guava", Name: "guava", Version: "31.1-jre", Scope: "implementation"},
+			{Name: "core", Scope: "implementation", Raw: "project(':core')"},
+		},
+		SubProjects: []*model.Project{
+			{
+				Name: "core",
+				Dependencies: []*model.Dependency{
+					{Group: "com.google.guava", Name: "guava", Version: "31.1-jre", Scope: "api"},
+					{Group: "org.junit.jupiter", Name: "junit-jupiter", Scope: "testImplementation"},
+				},
+			},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	g := Build(createTestProject())
+
+	// 根模块、guava、:core、junit。
+	if len(g.Nodes) != 4 {
+		t.Fatalf("Expected 4 nodes, got %d", len(g.Nodes))
+	}
+
+	modules := 0
+	for _, node := range g.Nodes {
+		if node.Kind == NodeKindModule {
+			modules++
+		}
+	}
+	if modules != 2 {
+		t.Errorf("Expected 2 module nodes (project(':core') should reuse the subproject node), got %d", modules)
+	}
+
+	if len(g.Edges) != 4 {
+		t.Errorf("Expected 4 edges, got %d", len(g.Edges))
+	}
+}
+
+func TestBuildNilProject(t *testing.T) {
+	g := Build(nil)
+	if len(g.Nodes) != 0 || len(g.Edges) != 0 {
+		t.Error("Expected empty graph for nil project")
+	}
+}
+
+func TestExportDOT(t *testing.T) {
+	out, err := Export(createTestProject(), FormatDOT)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	expected := []string{
+		"digraph dependencies {",
+		`n0 [label="demo", shape=box];`,
+		`n1 [label="com.google.guava:guava:31.1-jre", shape=ellipse];`,
+		`n2 [label=":core", shape=box];`,
+		`n0 -> n1 [label="implementation"];`,
+		`n0 -> n2 [label="implementation"];`,
+		`n2 -> n1 [label="api"];`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestExportMermaid(t *testing.T) {
+	out, err := Export(createTestProject(), FormatMermaid)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	expected := []string{
+		"graph LR",
+		`n0["demo"]`,
+		`n1(["com.google.guava:guava:31.1-jre"])`,
+		"n0 -->|implementation| n2",
+		"n2 -->|testImplementation| n3",
+	}
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("Mermaid output missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	if _, err := Export(createTestProject(), Format("svg")); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
@@ -0,0 +1,64 @@
+// Package graph 提供任务依赖关系图的构建功能。
+package graph
+
+import (
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// 任务关系图中边的类型，保存在 Edge.Scope 中。
+const (
+	TaskEdgeDependsOn      = "dependsOn"
+	TaskEdgeFinalizedBy    = "finalizedBy"
+	TaskEdgeMustRunAfter   = "mustRunAfter"
+	TaskEdgeShouldRunAfter = "shouldRunAfter"
+)
+
+// BuildTaskGraph 从项目（及其子项目）的任务构建任务关系图。
+// 边从声明关系的任务指向被引用的任务，例如 build dependsOn dist 生成 build -> dist。
+// 子项目中的任务标签带有项目路径前缀，例如 :app:assemble。
+func BuildTaskGraph(project *model.Project) *Graph {
+	g := newGraph()
+	if project != nil {
+		g.addProjectTasks(project, "")
+	}
+	return g
+}
+
+// addProjectTasks 递归添加项目中的任务节点和关系边。
+func (g *Graph) addProjectTasks(project *model.Project, modulePath string) {
+	taskID := func(name string) string {
+		label := name
+		if modulePath != "" {
+			label = modulePath + ":" + name
+		}
+		return g.addNode("task "+label, label, NodeKindTask)
+	}
+
+	for _, task := range project.Tasks {
+		if task == nil || task.Name == "" {
+			continue
+		}
+		from := taskID(task.Name)
+		relations := []struct {
+			kind  string
+			names []string
+		}{
+			{TaskEdgeDependsOn, task.DependsOn},
+			{TaskEdgeFinalizedBy, task.FinalizedBy},
+			{TaskEdgeMustRunAfter, task.MustRunAfter},
+			{TaskEdgeShouldRunAfter, task.ShouldRunAfter},
+		}
+		for _, relation := range relations {
+			for _, name := range relation.names {
+				g.addEdge(from, taskID(name), relation.kind)
+			}
+		}
+	}
+
+	for _, sub := range project.SubProjects {
+		if sub == nil || sub.Name == "" {
+			continue
+		}
+		g.addProjectTasks(sub, modulePath+":"+sub.Name)
+	}
+}
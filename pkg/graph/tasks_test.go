@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestBuildTaskGraph(t *testing.T) {
+	project := &model.Project{
+		Tasks: []*model.Task{
+			{Name: "build", DependsOn: []string{"dist"}},
+			{Name: "dist", DependsOn: []string{"jar"}, FinalizedBy: []string{"report"}},
+			{Name: "report", MustRunAfter: []string{"dist"}},
+		},
+		SubProjects: []*model.Project{
+			{Name: "app", Tasks: []*model.Task{{Name: "assemble", DependsOn: []string{"jar"}}}},
+		},
+	}
+
+	g := BuildTaskGraph(project)
+	labels := make(map[string]string)
+	for _, node := range g.Nodes {
+		if node.Kind != NodeKindTask {
+			t.Errorf("Unexpected node kind %s", node.Kind)
+		}
+		labels[node.ID] = node.Label
+	}
+	if len(g.Nodes) != 6 {
+		t.Errorf("Expected 6 nodes, got %d", len(g.Nodes))
+	}
+
+	edges := make([]string, 0, len(g.Edges))
+	for _, edge := range g.Edges {
+		edges = append(edges, labels[edge.From]+" "+edge.Scope+" "+labels[edge.To])
+	}
+	want := []string{
+		"build dependsOn dist",
+		"dist dependsOn jar",
+		"dist finalizedBy report",
+		"report mustRunAfter dist",
+		":app:assemble dependsOn :app:jar",
+	}
+	if strings.Join(edges, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Edges = %v, want %v", edges, want)
+	}
+
+	if dot := g.ToDOT(); !strings.Contains(dot, `[label="finalizedBy"]`) {
+		t.Errorf("Expected DOT output to label relations, got:\n%s", dot)
+	}
+}
+
+func TestBuildTaskGraphNilProject(t *testing.T) {
+	if g := BuildTaskGraph(nil); len(g.Nodes) != 0 || len(g.Edges) != 0 {
+		t.Error("Expected empty graph for nil project")
+	}
+}
@@ -0,0 +1,266 @@
+// Package importer 提供Gradle实际解析结果的导入功能，例如 gradle dependencies 任务输出的依赖树。
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/graph"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// projectHeaderRegex 匹配报告的项目标题，例如 "Root project 'demo'" 或 "Project ':app'"，后面可能跟描述。
+	projectHeaderRegex = regexp.MustCompile(`^(Root project|Project) '([^']*)'`)
+
+	// configurationHeaderRegex 匹配配置标题，例如 "runtimeClasspath - Runtime classpath of source set 'main'."。
+	configurationHeaderRegex = regexp.MustCompile(`^([A-Za-z_][\w-]*)(?: - .*)?$`)
+
+	// treeLineRegex 匹配依赖树中的一行，第一组为缩进，每层缩进5个字符。
+	treeLineRegex = regexp.MustCompile(`^((?:[| ]    )*)[+\\]--- (.+)$`)
+
+	// markerRegex 匹配依赖行末尾的标记：(*) 已在前面列出，(c) 依赖约束，(n) 未解析。
+	markerRegex = regexp.MustCompile(`\s+\(([*cn])\)$`)
+)
+
+// ParseDependencies 解析 ./gradlew dependencies 任务输出的依赖树，构建依赖图。
+// 输出可以包含多个项目（例如 :app:dependencies :core:dependencies）和多个配置，结果合并到同一个图中，
+// 边的Scope为所在的配置。冲突解决后的版本（a:b:1.0 -> 1.2）作为节点版本，
+// 依赖约束 (c) 不是实际依赖，会被忽略；未解析 (n) 或解析失败（FAILED）的依赖为占位节点。
+func ParseDependencies(r io.Reader) (*model.DependencyGraph, error) {
+	b := newReportBuilder()
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	module := ""
+	configuration := ""
+	var parents []string
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if match := treeLineRegex.FindStringSubmatch(line); match != nil {
+			if configuration == "" {
+				return nil, fmt.Errorf("第%d行: 依赖树前缺少配置名称", lineNum)
+			}
+			depth := len(match[1]) / 5
+			if depth > len(parents) {
+				return nil, fmt.Errorf("第%d行: 依赖树缩进无效", lineNum)
+			}
+			parents = parents[:depth]
+
+			parent := module
+			if depth > 0 {
+				parent = parents[depth-1]
+			}
+			id := b.addEntry(match[2], depth == 0)
+			if id != "" && parent != "" {
+				b.addEdge(parent, id, configuration)
+			}
+			parents = append(parents, id)
+			continue
+		}
+
+		parents = parents[:0]
+		if match := projectHeaderRegex.FindStringSubmatch(line); match != nil {
+			module = ":"
+			if match[1] == "Project" {
+				module = match[2]
+			}
+			b.addModule(module)
+			configuration = ""
+			continue
+		}
+		if match := configurationHeaderRegex.FindStringSubmatch(line); match != nil {
+			if module == "" {
+				// 单项目构建的旧版报告可能没有项目标题。
+				module = ":"
+				b.addModule(module)
+			}
+			configuration = match[1]
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if module == "" {
+		return nil, fmt.Errorf("未找到依赖报告")
+	}
+	return b.graph, nil
+}
+
+// ParseDependenciesString 解析依赖树字符串。
+func ParseDependenciesString(content string) (*model.DependencyGraph, error) {
+	return ParseDependencies(strings.NewReader(content))
+}
+
+// ParseDependenciesFile 解析保存了 dependencies 任务输出的文件。
+func ParseDependenciesFile(filePath string) (*model.DependencyGraph, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	g, err := ParseDependencies(file)
+	if err != nil {
+		return nil, fmt.Errorf("解析依赖报告 %s 失败: %w", filePath, err)
+	}
+	return g, nil
+}
+
+// TransitiveResolver 返回从导入的依赖图中查找传递依赖的解析器，可传给 graph.BuildDependencyGraph，
+// 把静态解析得到的声明依赖与Gradle实际解析的传递依赖结合起来。
+// 依赖按group和name查找，版本相同的节点优先；图中没有的依赖返回错误，在结果中为占位节点。
+func TransitiveResolver(imported *model.DependencyGraph) graph.TransitiveResolver {
+	return func(dep *model.Dependency) ([]*model.Dependency, error) {
+		var node *model.DependencyGraphNode
+		for _, candidate := range imported.Nodes {
+			if candidate.Kind != model.DependencyNodeExternal || candidate.Group != dep.Group || candidate.Name != dep.Name {
+				continue
+			}
+			if node == nil || candidate.Version == dep.Version {
+				node = candidate
+			}
+		}
+		if node == nil {
+			return nil, fmt.Errorf("依赖报告中没有 %s:%s", dep.Group, dep.Name)
+		}
+		if node.Placeholder {
+			return nil, fmt.Errorf("依赖报告中 %s 未解析", node.ID)
+		}
+
+		children := make([]*model.Dependency, 0)
+		seen := make(map[string]bool)
+		for _, edge := range imported.Edges {
+			if edge.From != node.ID || seen[edge.To] {
+				continue
+			}
+			child := imported.Node(edge.To)
+			if child == nil || child.Kind != model.DependencyNodeExternal {
+				continue
+			}
+			seen[edge.To] = true
+			children = append(children, &model.Dependency{
+				Group:   child.Group,
+				Name:    child.Name,
+				Version: child.Version,
+				Scope:   edge.Scope,
+			})
+		}
+		return children, nil
+	}
+}
+
+// reportBuilder 保存导入依赖报告时的节点和边索引。
+type reportBuilder struct {
+	graph *model.DependencyGraph
+	nodes map[string]*model.DependencyGraphNode
+	edges map[model.DependencyGraphEdge]bool
+}
+
+// newReportBuilder 创建空的依赖图构建器。
+func newReportBuilder() *reportBuilder {
+	return &reportBuilder{
+		graph: &model.DependencyGraph{
+			Nodes: make([]*model.DependencyGraphNode, 0),
+			Edges: make([]*model.DependencyGraphEdge, 0),
+		},
+		nodes: make(map[string]*model.DependencyGraphNode),
+		edges: make(map[model.DependencyGraphEdge]bool),
+	}
+}
+
+// addModule 添加模块节点（已存在时复用）。
+func (b *reportBuilder) addModule(path string) string {
+	if _, ok := b.nodes[path]; !ok {
+		b.addNode(&model.DependencyGraphNode{ID: path, Kind: model.DependencyNodeModule, Name: path})
+	}
+	return path
+}
+
+// addEntry 解析依赖树中的一项并添加对应的节点，返回节点ID。依赖约束返回空字符串。
+func (b *reportBuilder) addEntry(entry string, direct bool) string {
+	marker := ""
+	if match := markerRegex.FindStringSubmatch(entry); match != nil {
+		marker = match[1]
+		entry = entry[:len(entry)-len(match[0])]
+	}
+	if marker == "c" {
+		return ""
+	}
+
+	if path, ok := strings.CutPrefix(entry, "project "); ok {
+		return b.addModule(strings.TrimSpace(path))
+	}
+
+	failed := false
+	if trimmed, ok := strings.CutSuffix(entry, " FAILED"); ok {
+		entry = trimmed
+		failed = true
+	}
+
+	// 冲突解决或动态版本会显示为 "a:b:1.0 -> 1.2"，省略版本的依赖为 "a:b -> 1.2"。
+	coordinate, resolved, _ := strings.Cut(entry, " -> ")
+	parts := strings.SplitN(strings.TrimSpace(coordinate), ":", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	group, name := parts[0], parts[1]
+	version := ""
+	if len(parts) == 3 {
+		version = parts[2]
+	}
+	if resolved = strings.TrimSpace(resolved); resolved != "" {
+		version = resolved
+	}
+
+	id := group + ":" + name
+	if version != "" {
+		id += ":" + version
+	}
+	node, ok := b.nodes[id]
+	if !ok {
+		node = &model.DependencyGraphNode{
+			ID:      id,
+			Kind:    model.DependencyNodeExternal,
+			Group:   group,
+			Name:    name,
+			Version: version,
+		}
+		b.addNode(node)
+	}
+	node.Direct = node.Direct || direct
+	switch {
+	case failed:
+		node.Placeholder = true
+		node.Error = "FAILED"
+	case marker == "n" && !ok:
+		node.Placeholder = true
+	case marker == "":
+		// 完整列出的依赖说明已经解析，其他配置中的未解析标记不再适用。
+		node.Placeholder = node.Error != ""
+	}
+	return id
+}
+
+// addNode 添加节点。
+func (b *reportBuilder) addNode(node *model.DependencyGraphNode) {
+	b.nodes[node.ID] = node
+	b.graph.Nodes = append(b.graph.Nodes, node)
+}
+
+// addEdge 添加边，重复的边会被忽略。
+func (b *reportBuilder) addEdge(from, to, scope string) {
+	edge := model.DependencyGraphEdge{From: from, To: to, Scope: scope}
+	if b.edges[edge] {
+		return
+	}
+	b.edges[edge] = true
+	b.graph.Edges = append(b.graph.Edges, &edge)
+}
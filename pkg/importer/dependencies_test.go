@@ -0,0 +1,132 @@
+package importer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/graph"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+const dependenciesReport = `
+> Task :app:dependencies
+
+------------------------------------------------------------
+Project ':app'
+------------------------------------------------------------
+
+runtimeClasspath - Runtime classpath of source set 'main'.
++--- org.springframework.boot:spring-boot-starter -> 3.1.0
+|    +--- org.springframework.boot:spring-boot:3.1.0
+|    |    \--- org.springframework:spring-core:6.0.9
+|    \--- org.yaml:snakeyaml:1.33
++--- project :core
+|    +--- com.google.guava:guava:31.0-jre -> 32.1.2-jre
+|    \--- org.springframework:spring-core:6.0.9 (*)
++--- com.google.guava:guava:32.1.2-jre (c)
+\--- org.foo:missing:1.0 FAILED
+
+testCompileClasspath - Compile classpath for source set 'test'.
+No dependencies
+
+(c) - A dependency constraint, not a dependency. The dependency affected by the constraint occurs elsewhere in the tree.
+(*) - Indicates repeated occurrences of a transitive dependency subtree. Gradle expands transitive dependency subtrees only once per project; repeat occurrences only display the root of the subtree, followed by this annotation.
+
+A web-based, searchable dependency report is available by adding the --scan option.
+
+BUILD SUCCESSFUL in 1s
+`
+
+func TestParseDependencies(t *testing.T) {
+	g, err := ParseDependenciesString(dependenciesReport)
+	if err != nil {
+		t.Fatalf("ParseDependenciesString() error = %v", err)
+	}
+
+	ids := make([]string, 0, len(g.Nodes))
+	for _, node := range g.Nodes {
+		ids = append(ids, node.ID)
+	}
+	wantIDs := []string{
+		":app",
+		"org.springframework.boot:spring-boot-starter:3.1.0",
+		"org.springframework.boot:spring-boot:3.1.0",
+		"org.springframework:spring-core:6.0.9",
+		"org.yaml:snakeyaml:1.33",
+		":core",
+		"com.google.guava:guava:32.1.2-jre",
+		"org.foo:missing:1.0",
+	}
+	if !reflect.DeepEqual(ids, wantIDs) {
+		t.Errorf("Nodes = %v, want %v", ids, wantIDs)
+	}
+
+	if got := g.Successors(":core"); !reflect.DeepEqual(got, []string{"com.google.guava:guava:32.1.2-jre", "org.springframework:spring-core:6.0.9"}) {
+		t.Errorf("Unexpected successors of :core: %v", got)
+	}
+	for _, edge := range g.Edges {
+		if edge.Scope != "runtimeClasspath" {
+			t.Errorf("Expected edge scope runtimeClasspath, got %+v", edge)
+		}
+	}
+
+	starter := g.Node("org.springframework.boot:spring-boot-starter:3.1.0")
+	if !starter.Direct || starter.Version != "3.1.0" || starter.Placeholder {
+		t.Errorf("Unexpected starter node %+v", starter)
+	}
+	if guava := g.Node("com.google.guava:guava:32.1.2-jre"); guava.Direct {
+		t.Error("Expected constraint not to mark guava as direct")
+	}
+	if missing := g.Node("org.foo:missing:1.0"); !missing.Placeholder || missing.Error == "" {
+		t.Errorf("Expected failed dependency to be a placeholder, got %+v", missing)
+	}
+	if stats := g.Stats(); stats.MaxDepth != 2 {
+		t.Errorf("Expected max depth 2, got %d", stats.MaxDepth)
+	}
+}
+
+func TestParseDependenciesErrors(t *testing.T) {
+	if _, err := ParseDependenciesString("BUILD SUCCESSFUL in 1s\n"); err == nil {
+		t.Error("Expected error for output without a report")
+	}
+	if _, err := ParseDependenciesString("runtimeClasspath\n|    |    \\--- a:b:1.0\n"); err == nil {
+		t.Error("Expected error for invalid indentation")
+	}
+}
+
+func TestTransitiveResolver(t *testing.T) {
+	imported, err := ParseDependenciesString(dependenciesReport)
+	if err != nil {
+		t.Fatalf("ParseDependenciesString() error = %v", err)
+	}
+
+	project := &model.Project{
+		Dependencies: []*model.Dependency{
+			{Group: "org.springframework.boot", Name: "spring-boot-starter", Scope: "implementation"},
+			{Group: "org.foo", Name: "missing", Version: "1.0", Scope: "implementation"},
+			{Group: "org.foo", Name: "unknown", Version: "1.0", Scope: "implementation"},
+		},
+	}
+	g, err := graph.BuildDependencyGraph(project, TransitiveResolver(imported))
+	if err == nil {
+		t.Error("Expected errors for dependencies missing from the report")
+	}
+
+	boot := g.Node("org.springframework.boot:spring-boot:3.1.0")
+	if boot == nil || boot.Placeholder {
+		t.Fatalf("Expected transitive dependency from the report, got %+v", boot)
+	}
+	if got := g.Successors(boot.ID); !reflect.DeepEqual(got, []string{"org.springframework:spring-core:6.0.9"}) {
+		t.Errorf("Unexpected successors %v", got)
+	}
+	for _, id := range []string{"org.foo:missing:1.0", "org.foo:unknown:1.0"} {
+		if node := g.Node(id); node == nil || !node.Placeholder {
+			t.Errorf("Expected %s to be a placeholder, got %+v", id, node)
+		}
+	}
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) || len(joined.Unwrap()) != 2 {
+		t.Errorf("Expected 2 resolution errors, got %v", err)
+	}
+}
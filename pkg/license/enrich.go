@@ -0,0 +1,86 @@
+// Package license 提供依赖许可证补全与汇总报告功能。
+package license
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// UnknownLicense 是无法识别许可证时在汇总中使用的分组键。
+const UnknownLicense = "UNKNOWN"
+
+// DependencyLicenses 表示单个依赖的许可证查询结果。
+type DependencyLicenses struct {
+	Dependency *model.Dependency `json:"dependency"`
+	Licenses   []*License        `json:"licenses"`
+	Error      string            `json:"error,omitempty"` // 查询失败的原因。
+}
+
+// Report 表示许可证汇总报告。
+type Report struct {
+	Dependencies []*DependencyLicenses          `json:"dependencies"`
+	BySPDXID     map[string][]*model.Dependency `json:"bySpdxId"` // 按SPDX ID分组，无法识别的归入UNKNOWN。
+}
+
+// SPDXIDs 返回报告中出现的SPDX ID，按字母排序，UNKNOWN排在最后。
+func (r *Report) SPDXIDs() []string {
+	ids := make([]string, 0, len(r.BySPDXID))
+	for id := range r.BySPDXID {
+		if id != UnknownLicense {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if _, ok := r.BySPDXID[UnknownLicense]; ok {
+		ids = append(ids, UnknownLicense)
+	}
+	return ids
+}
+
+// Enrich 查询每个依赖的许可证，写入Dependency.Licenses并生成汇总报告。
+// 单个依赖查询失败不会中断，失败原因记录在报告中，该依赖归入UNKNOWN。
+// 没有group或version的依赖（如项目依赖）会被跳过。
+func Enrich(deps []*model.Dependency, source Source) (*Report, error) {
+	if source == nil {
+		return nil, fmt.Errorf("许可证来源为空")
+	}
+
+	report := &Report{
+		Dependencies: make([]*DependencyLicenses, 0, len(deps)),
+		BySPDXID:     make(map[string][]*model.Dependency),
+	}
+
+	for _, dep := range deps {
+		if dep == nil || dep.Group == "" || dep.Name == "" || dep.Version == "" {
+			continue
+		}
+
+		entry := &DependencyLicenses{Dependency: dep, Licenses: []*License{}}
+		licenses, err := source.Licenses(dep)
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Licenses = licenses
+		}
+		report.Dependencies = append(report.Dependencies, entry)
+
+		dep.Licenses = make([]string, 0, len(entry.Licenses))
+		identified := false
+		for _, license := range entry.Licenses {
+			if license.SPDXID != "" {
+				dep.Licenses = append(dep.Licenses, license.SPDXID)
+				report.BySPDXID[license.SPDXID] = append(report.BySPDXID[license.SPDXID], dep)
+				identified = true
+			} else if license.Name != "" {
+				dep.Licenses = append(dep.Licenses, license.Name)
+			}
+		}
+		if !identified {
+			report.BySPDXID[UnknownLicense] = append(report.BySPDXID[UnknownLicense], dep)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,142 @@
+package license
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+const guavaPOM = `<project>
+  <parent>
+    <groupId>com.google.guava</groupId>
+    <artifactId>guava-parent</artifactId>
+    <version>31.1-jre</version>
+  </parent>
+  <artifactId>guava</artifactId>
+</project>`
+
+const guavaParentPOM = `<project>
+  <licenses>
+    <license>
+      <name>The Apache Software License, Version 2.0</name>
+      <url>http://www.apache.org/licenses/LICENSE-2.0.txt</url>
+    </license>
+  </licenses>
+</project>`
+
+const customPOM = `<project>
+  <licenses>
+    <license><name>Custom Corporate License</name></license>
+  </licenses>
+</project>`
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name, url, want string
+	}{
+		{"Apache-2.0", "", "Apache-2.0"},
+		{"The Apache Software License, Version 2.0", "", "Apache-2.0"},
+		{"", "https://opensource.org/licenses/MIT", "MIT"},
+		{"MIT License", "", "MIT"},
+		{"Eclipse Public License - v 2.0", "", "EPL-2.0"},
+		{"GNU Lesser General Public License", "http://www.gnu.org/licenses/lgpl-2.1.html", "LGPL-2.1-only"},
+		{"New BSD License", "", "BSD-3-Clause"},
+		{"CDDL + GPLv2 with classpath exception", "", "CDDL-1.0"},
+		{"Custom Corporate License", "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.name, tt.url); got != tt.want {
+			t.Errorf("Normalize(%q, %q) = %q, want %q", tt.name, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestRepositorySource(t *testing.T) {
+	poms := map[string]string{
+		"/com/google/guava/guava/31.1-jre/guava-31.1-jre.pom":               guavaPOM,
+		"/com/google/guava/guava-parent/31.1-jre/guava-parent-31.1-jre.pom": guavaParentPOM,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := poms[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	source := &RepositorySource{BaseURL: server.URL + "/", Client: server.Client()}
+
+	licenses, err := source.Licenses(&model.Dependency{Group: "com.google.guava", Name: "guava", Version: "31.1-jre"})
+	if err != nil {
+		t.Fatalf("Licenses() error = %v", err)
+	}
+	if len(licenses) != 1 || licenses[0].SPDXID != "Apache-2.0" {
+		t.Errorf("Expected Apache-2.0 inherited from parent, got %+v", licenses)
+	}
+
+	if _, err := source.Licenses(&model.Dependency{Group: "missing", Name: "missing", Version: "1.0"}); err == nil {
+		t.Error("Expected error for missing POM")
+	}
+}
+
+func TestEnrichWithLocalRepository(t *testing.T) {
+	root := t.TempDir()
+	writePOM(t, root, "com/google/guava/guava/31.1-jre/guava-31.1-jre.pom", guavaPOM)
+	writePOM(t, root, "com/google/guava/guava-parent/31.1-jre/guava-parent-31.1-jre.pom", guavaParentPOM)
+	writePOM(t, root, "com/example/custom/1.0/custom-1.0.pom", customPOM)
+
+	deps := []*model.Dependency{
+		{Group: "com.google.guava", Name: "guava", Version: "31.1-jre"},
+		{Group: "com.example", Name: "custom", Version: "1.0"},
+		{Group: "com.example", Name: "absent", Version: "1.0"},
+		{Name: "local-module"},
+	}
+
+	report, err := Enrich(deps, NewLocalRepositorySource(root))
+	if err != nil {
+		t.Fatalf("Enrich() error = %v", err)
+	}
+
+	if len(report.Dependencies) != 3 {
+		t.Fatalf("Expected 3 dependency entries, got %d", len(report.Dependencies))
+	}
+	if strings.Join(deps[0].Licenses, ",") != "Apache-2.0" {
+		t.Errorf("Expected guava licenses to be attached, got %v", deps[0].Licenses)
+	}
+	if strings.Join(deps[1].Licenses, ",") != "Custom Corporate License" {
+		t.Errorf("Unidentified licenses should keep their name, got %v", deps[1].Licenses)
+	}
+	if report.Dependencies[2].Error == "" {
+		t.Error("Missing POM should be recorded as an error")
+	}
+
+	if ids := report.SPDXIDs(); strings.Join(ids, ",") != "Apache-2.0,UNKNOWN" {
+		t.Errorf("Unexpected SPDX IDs: %v", ids)
+	}
+	if len(report.BySPDXID[UnknownLicense]) != 2 {
+		t.Errorf("Expected 2 unknown dependencies, got %d", len(report.BySPDXID[UnknownLicense]))
+	}
+
+	if _, err := Enrich(deps, nil); err == nil {
+		t.Error("Expected error for nil source")
+	}
+}
+
+func writePOM(t *testing.T, root, path, content string) {
+	t.Helper()
+	full := filepath.Join(root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
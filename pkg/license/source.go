@@ -0,0 +1,160 @@
+// Package license 提供依赖许可证元数据的读取功能。
+package license
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// DefaultMavenRepositoryURL 是Maven中央仓库的默认地址。
+const DefaultMavenRepositoryURL = "https://repo.maven.apache.org/maven2"
+
+// maxParentDepth 是查找父POM许可证的最大层数。
+const maxParentDepth = 5
+
+// License 表示一个许可证声明。
+type License struct {
+	Name   string `json:"name,omitempty"`
+	URL    string `json:"url,omitempty"`
+	SPDXID string `json:"spdxId,omitempty"` // 识别出的SPDX ID，无法识别时为空。
+}
+
+// Source 定义许可证元数据来源。
+type Source interface {
+	// Licenses 返回指定依赖声明的许可证。
+	Licenses(dep *model.Dependency) ([]*License, error)
+}
+
+// pomLoader 读取指定坐标的POM内容。
+type pomLoader func(group, name, version string) ([]byte, error)
+
+// pom 是POM中与许可证相关的部分。
+type pom struct {
+	Parent struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+	} `xml:"parent"`
+	Licenses []struct {
+		Name string `xml:"name"`
+		URL  string `xml:"url"`
+	} `xml:"licenses>license"`
+}
+
+// RepositorySource 从远程Maven仓库下载POM并读取许可证。
+type RepositorySource struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewRepositorySource 创建使用Maven中央仓库的许可证来源。
+func NewRepositorySource() *RepositorySource {
+	return &RepositorySource{
+		BaseURL: DefaultMavenRepositoryURL,
+		Client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Licenses 返回依赖POM中声明的许可证，POM未声明时沿父POM查找。
+func (s *RepositorySource) Licenses(dep *model.Dependency) ([]*License, error) {
+	return resolveLicenses(s.load, dep)
+}
+
+// load 下载指定坐标的POM。
+func (s *RepositorySource) load(group, name, version string) ([]byte, error) {
+	baseURL := s.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultMavenRepositoryURL
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	url := strings.TrimSuffix(baseURL, "/") + "/" + pomPath(group, name, version)
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("下载POM失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("下载POM %s 返回状态码 %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// LocalRepositorySource 从本地Maven仓库目录（如 ~/.m2/repository）读取POM中的许可证。
+type LocalRepositorySource struct {
+	Root string
+}
+
+// NewLocalRepositorySource 创建读取本地Maven仓库的许可证来源，root为空时使用 ~/.m2/repository。
+func NewLocalRepositorySource(root string) *LocalRepositorySource {
+	if root == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			root = filepath.Join(home, ".m2", "repository")
+		}
+	}
+	return &LocalRepositorySource{Root: root}
+}
+
+// Licenses 返回依赖POM中声明的许可证，POM未声明时沿父POM查找。
+func (s *LocalRepositorySource) Licenses(dep *model.Dependency) ([]*License, error) {
+	return resolveLicenses(s.load, dep)
+}
+
+// load 读取本地仓库中指定坐标的POM。
+func (s *LocalRepositorySource) load(group, name, version string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.Root, filepath.FromSlash(pomPath(group, name, version))))
+}
+
+// resolveLicenses 读取依赖POM中的许可证，没有声明时沿父POM查找。
+func resolveLicenses(load pomLoader, dep *model.Dependency) ([]*License, error) {
+	if dep == nil || dep.Group == "" || dep.Name == "" || dep.Version == "" {
+		return nil, fmt.Errorf("依赖坐标不完整")
+	}
+
+	group, name, version := dep.Group, dep.Name, dep.Version
+	for depth := 0; depth <= maxParentDepth; depth++ {
+		data, err := load(group, name, version)
+		if err != nil {
+			return nil, err
+		}
+
+		var p pom
+		if err := xml.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("解析POM %s:%s:%s 失败: %w", group, name, version, err)
+		}
+
+		if len(p.Licenses) > 0 {
+			licenses := make([]*License, 0, len(p.Licenses))
+			for _, l := range p.Licenses {
+				license := &License{Name: strings.TrimSpace(l.Name), URL: strings.TrimSpace(l.URL)}
+				license.SPDXID = Normalize(license.Name, license.URL)
+				licenses = append(licenses, license)
+			}
+			return licenses, nil
+		}
+
+		if p.Parent.ArtifactID == "" {
+			break
+		}
+		group, name, version = p.Parent.GroupID, p.Parent.ArtifactID, p.Parent.Version
+	}
+
+	return []*License{}, nil
+}
+
+// pomPath 返回坐标在Maven仓库中的POM路径。
+func pomPath(group, name, version string) string {
+	return fmt.Sprintf("%s/%s/%s/%s-%s.pom", strings.ReplaceAll(group, ".", "/"), name, version, name, version)
+}
@@ -0,0 +1,101 @@
+// Package license 提供许可证名称到SPDX ID的识别功能。
+package license
+
+import (
+	"strings"
+)
+
+// spdxPattern 表示一条识别规则，名称或URL包含所有关键字时匹配。
+type spdxPattern struct {
+	id       string
+	keywords []string
+}
+
+// spdxNamePatterns 按优先级排列，更具体的规则在前。
+var spdxNamePatterns = []spdxPattern{
+	{"Apache-2.0", []string{"apache", "2"}},
+	{"MIT", []string{"mit"}},
+	{"BSD-2-Clause", []string{"bsd", "2"}},
+	{"BSD-3-Clause", []string{"bsd", "3"}},
+	{"BSD-3-Clause", []string{"new bsd"}},
+	{"BSD-3-Clause", []string{"revised bsd"}},
+	{"EPL-2.0", []string{"eclipse public license", "2"}},
+	{"EPL-1.0", []string{"eclipse public license", "1"}},
+	{"EDL-1.0", []string{"eclipse distribution license"}},
+	{"LGPL-2.1-only", []string{"lesser general public license", "2.1"}},
+	{"LGPL-3.0-only", []string{"lesser general public license", "3"}},
+	{"LGPL-2.1-only", []string{"lgpl", "2.1"}},
+	{"LGPL-3.0-only", []string{"lgpl", "3"}},
+	{"CDDL-1.1", []string{"cddl", "1.1"}},
+	{"CDDL-1.0", []string{"cddl"}},
+	{"CDDL-1.0", []string{"common development and distribution license"}},
+	{"GPL-2.0-with-classpath-exception", []string{"gpl", "classpath"}},
+	{"GPL-2.0-only", []string{"general public license", "2"}},
+	{"GPL-3.0-only", []string{"general public license", "3"}},
+	{"MPL-2.0", []string{"mozilla public license", "2"}},
+	{"CC0-1.0", []string{"cc0"}},
+	{"Unlicense", []string{"unlicense"}},
+	{"ISC", []string{"isc license"}},
+}
+
+// spdxURLs 是常见许可证URL片段到SPDX ID的映射，更具体的片段在前。
+var spdxURLs = []struct {
+	fragment string
+	id       string
+}{
+	{"apache.org/licenses/license-2.0", "Apache-2.0"},
+	{"opensource.org/licenses/mit", "MIT"},
+	{"opensource.org/licenses/bsd-2-clause", "BSD-2-Clause"},
+	{"opensource.org/licenses/bsd-3-clause", "BSD-3-Clause"},
+	{"eclipse.org/legal/epl-2.0", "EPL-2.0"},
+	{"eclipse.org/legal/epl-v20", "EPL-2.0"},
+	{"eclipse.org/legal/epl-v10", "EPL-1.0"},
+	{"eclipse.org/org/documents/edl-v10", "EDL-1.0"},
+	{"gnu.org/licenses/lgpl-2.1", "LGPL-2.1-only"},
+	{"gnu.org/licenses/lgpl", "LGPL-3.0-only"},
+	{"mozilla.org/mpl/2.0", "MPL-2.0"},
+	{"creativecommons.org/publicdomain/zero/", "CC0-1.0"},
+}
+
+// Normalize 根据许可证名称和URL识别SPDX ID，无法识别时返回空字符串。
+// 名称本身已经是SPDX ID时直接返回。
+func Normalize(name, url string) string {
+	if id := knownSPDXID(name); id != "" {
+		return id
+	}
+
+	lowerURL := strings.ToLower(url)
+	for _, u := range spdxURLs {
+		if strings.Contains(lowerURL, u.fragment) {
+			return u.id
+		}
+	}
+
+	lowerName := strings.ToLower(name)
+	for _, pattern := range spdxNamePatterns {
+		if containsAll(lowerName, pattern.keywords) {
+			return pattern.id
+		}
+	}
+	return ""
+}
+
+// knownSPDXID 判断名称是否已经是已知的SPDX ID。
+func knownSPDXID(name string) string {
+	for _, pattern := range spdxNamePatterns {
+		if strings.EqualFold(name, pattern.id) {
+			return pattern.id
+		}
+	}
+	return ""
+}
+
+// containsAll 判断文本是否包含所有关键字。
+func containsAll(text string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if !strings.Contains(text, keyword) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+// Package lint 提供与批准的基线构建文件进行比较的规则。
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// 基线比较的规则ID和诊断代码。
+const (
+	RuleBaseline                  = "baseline"
+	RuleBaselineMissingPlugin     = "baseline-missing-plugin"
+	RuleBaselineMissingRepository = "baseline-missing-repository"
+	RuleBaselineExtraRepository   = "baseline-extra-repository"
+	RuleBaselineDisallowedScope   = "baseline-disallowed-scope"
+)
+
+// Baseline 是平台团队批准的构建模板，作为规则使用时报告项目与模板的偏差。
+type Baseline struct {
+	// RequiredPlugins 是必须应用的插件ID，Gradle核心插件写不写 org.gradle. 前缀都可以。
+	RequiredPlugins []string `json:"requiredPlugins,omitempty"`
+	// Repositories 是批准的仓库：缺少的仓库和不在列表中的仓库都会报告。为nil时不检查仓库。
+	Repositories []*model.Repository `json:"repositories,omitempty"`
+	// DisallowedScopes 是不允许使用的依赖配置，例如 compile、testCompile，也匹配Android变体配置的基础配置。
+	DisallowedScopes []string `json:"disallowedScopes,omitempty"`
+}
+
+// NewBaseline 从模板构建文件的解析结果创建基线：模板中的插件是必须应用的插件，模板中的仓库是批准的仓库。
+// 不允许使用的配置无法从模板中得到，需要另外设置。
+func NewBaseline(template *model.Project) *Baseline {
+	baseline := &Baseline{}
+	if template == nil {
+		return baseline
+	}
+	for _, plugin := range template.Plugins {
+		baseline.RequiredPlugins = append(baseline.RequiredPlugins, plugin.ID)
+	}
+	baseline.Repositories = append(make([]*model.Repository, 0, len(template.Repositories)), template.Repositories...)
+	return baseline
+}
+
+// ID 返回规则ID，各项偏差使用 RuleBaseline* 诊断代码。
+func (b *Baseline) ID() string { return RuleBaseline }
+
+// Description 返回规则说明。
+func (b *Baseline) Description() string {
+	return "构建文件必须符合批准的基线模板"
+}
+
+// Deviation 是项目与基线的一项偏差，除诊断外记录涉及的插件、仓库或依赖，便于定位和自动修复。
+type Deviation struct {
+	Diagnostic *model.Diagnostic `json:"diagnostic"`
+	PluginID   string            `json:"pluginId,omitempty"`   // 缺少的插件。
+	Repository *model.Repository `json:"repository,omitempty"` // 缺少的基线仓库或多出的项目仓库。
+	Dependency *model.Dependency `json:"dependency,omitempty"` // 使用了不允许的配置的依赖。
+}
+
+// Check 按插件、仓库、依赖配置的顺序报告项目与基线的偏差。
+func (b *Baseline) Check(project *model.Project) []*model.Diagnostic {
+	diagnostics := make([]*model.Diagnostic, 0)
+	for _, deviation := range b.Deviations(project) {
+		diagnostics = append(diagnostics, deviation.Diagnostic)
+	}
+	return diagnostics
+}
+
+// Deviations 按插件、仓库、依赖配置的顺序返回项目与基线的偏差。
+func (b *Baseline) Deviations(project *model.Project) []*Deviation {
+	deviations := make([]*Deviation, 0)
+	if project == nil {
+		return deviations
+	}
+	add := func(deviation *Deviation, code string, severity model.Severity, format string, args ...any) {
+		deviation.Diagnostic = &model.Diagnostic{Code: code, Severity: severity, Message: fmt.Sprintf(format, args...)}
+		deviations = append(deviations, deviation)
+	}
+
+	applied := make(map[string]bool, len(project.Plugins))
+	for _, plugin := range project.Plugins {
+		applied[normalizePluginID(plugin.ID)] = true
+	}
+	for _, id := range b.RequiredPlugins {
+		if !applied[normalizePluginID(id)] {
+			add(&Deviation{PluginID: id}, RuleBaselineMissingPlugin, model.SeverityError, "缺少基线要求的插件 %s", id)
+		}
+	}
+
+	for _, repo := range b.MissingRepositories(project) {
+		add(&Deviation{Repository: repo}, RuleBaselineMissingRepository, model.SeverityWarning,
+			"缺少基线中的仓库 %s", RepositoryKey(repo))
+	}
+	for _, repo := range b.ExtraRepositories(project) {
+		add(&Deviation{Repository: repo}, RuleBaselineExtraRepository, model.SeverityError,
+			"仓库 %s 不在基线中", RepositoryKey(repo))
+	}
+
+	for _, dep := range b.DisallowedDependencies(project) {
+		add(&Deviation{Dependency: dep}, RuleBaselineDisallowedScope, model.SeverityError,
+			"依赖 %s:%s 使用了基线不允许的配置 %s", dep.Group, dep.Name, dep.Scope)
+	}
+	return deviations
+}
+
+// MissingRepositories 返回基线中有而项目中没有声明的仓库。
+func (b *Baseline) MissingRepositories(project *model.Project) []*model.Repository {
+	declared := repositoryKeys(project.Repositories)
+	missing := make([]*model.Repository, 0)
+	for _, repo := range b.Repositories {
+		if !declared[RepositoryKey(repo)] {
+			missing = append(missing, repo)
+		}
+	}
+	return missing
+}
+
+// ExtraRepositories 返回项目中声明而基线中没有的仓库，基线没有设置仓库时返回空列表。
+func (b *Baseline) ExtraRepositories(project *model.Project) []*model.Repository {
+	extra := make([]*model.Repository, 0)
+	if b.Repositories == nil {
+		return extra
+	}
+	approved := repositoryKeys(b.Repositories)
+	for _, repo := range project.Repositories {
+		if !approved[RepositoryKey(repo)] {
+			extra = append(extra, repo)
+		}
+	}
+	return extra
+}
+
+// DisallowedDependencies 返回使用了不允许的配置的依赖。
+func (b *Baseline) DisallowedDependencies(project *model.Project) []*model.Dependency {
+	deps := make([]*model.Dependency, 0)
+	if len(b.DisallowedScopes) == 0 {
+		return deps
+	}
+	disallowed := make(map[string]bool, len(b.DisallowedScopes))
+	for _, scope := range b.DisallowedScopes {
+		disallowed[scope] = true
+	}
+	for _, dep := range project.Dependencies {
+		if disallowed[dep.Scope] || (dep.BaseScope != "" && disallowed[dep.BaseScope]) {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// RepositoryKey 返回用于比较仓库的标识：有地址时为去掉末尾斜杠的地址，否则为名称（例如 mavenCentral）。
+func RepositoryKey(repo *model.Repository) string {
+	if repo.URL != "" {
+		return strings.TrimSuffix(repo.URL, "/")
+	}
+	return repo.Name
+}
+
+// repositoryKeys 返回仓库标识的集合。
+func repositoryKeys(repos []*model.Repository) map[string]bool {
+	keys := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		keys[RepositoryKey(repo)] = true
+	}
+	return keys
+}
+
+// normalizePluginID 去掉核心插件的 org.gradle. 前缀，使 java 和 org.gradle.java 被视为同一插件。
+func normalizePluginID(id string) string {
+	return strings.TrimPrefix(id, corePluginNamespacePrefix)
+}
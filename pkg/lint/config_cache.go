@@ -0,0 +1,78 @@
+// Package lint 提供检查与配置缓存不兼容写法的规则。
+package lint
+
+import (
+	"os"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/parser"
+)
+
+// 配置缓存规则的ID，与 parser.FindConfigurationCacheProblems 报告的诊断代码一致。
+var (
+	RuleConfigCacheSystemProperty     = parser.ConfigurationCacheDiagnosticCode(model.ConfigCacheSystemProperty)
+	RuleConfigCacheEnvironment        = parser.ConfigurationCacheDiagnosticCode(model.ConfigCacheEnvironment)
+	RuleConfigCacheBuildDirAssignment = parser.ConfigurationCacheDiagnosticCode(model.ConfigCacheBuildDirAssignment)
+	RuleConfigCacheProjectAtExecution = parser.ConfigurationCacheDiagnosticCode(model.ConfigCacheProjectAtExecution)
+	RuleConfigCacheBuildListener      = parser.ConfigurationCacheDiagnosticCode(model.ConfigCacheBuildListener)
+)
+
+// configCacheDescriptions 是各配置缓存规则的说明。
+var configCacheDescriptions = map[string]string{
+	model.ConfigCacheSystemProperty:     "配置阶段不允许调用 System.getProperty",
+	model.ConfigCacheEnvironment:        "配置阶段不允许调用 System.getenv",
+	model.ConfigCacheBuildDirAssignment: "不允许直接给 buildDir 赋值",
+	model.ConfigCacheProjectAtExecution: "doFirst/doLast 中不允许访问 project",
+	model.ConfigCacheBuildListener:      "不允许注册构建监听器",
+}
+
+// ConfigurationCacheRule 报告一种与配置缓存不兼容的写法，诊断带有源码位置。
+// 规则需要脚本内容：通过 Ruleset.RunSource 运行时使用传入的内容，否则读取 project.FilePath 指向的文件。
+type ConfigurationCacheRule struct {
+	// Construct 是 model.ConfigCache* 写法之一。
+	Construct string
+}
+
+// ConfigurationCacheRules 返回检查全部配置缓存不兼容写法的规则集。
+func ConfigurationCacheRules() Ruleset {
+	return Ruleset{
+		&ConfigurationCacheRule{Construct: model.ConfigCacheProjectAtExecution},
+		&ConfigurationCacheRule{Construct: model.ConfigCacheSystemProperty},
+		&ConfigurationCacheRule{Construct: model.ConfigCacheEnvironment},
+		&ConfigurationCacheRule{Construct: model.ConfigCacheBuildDirAssignment},
+		&ConfigurationCacheRule{Construct: model.ConfigCacheBuildListener},
+	}
+}
+
+// ID 返回规则ID。
+func (r *ConfigurationCacheRule) ID() string {
+	return parser.ConfigurationCacheDiagnosticCode(r.Construct)
+}
+
+// Description 返回规则说明。
+func (r *ConfigurationCacheRule) Description() string {
+	return configCacheDescriptions[r.Construct]
+}
+
+// Check 读取项目的构建文件并检查，项目没有文件路径或文件无法读取时不报告。
+func (r *ConfigurationCacheRule) Check(project *model.Project) []*model.Diagnostic {
+	if project.FilePath == "" {
+		return make([]*model.Diagnostic, 0)
+	}
+	data, err := os.ReadFile(project.FilePath)
+	if err != nil {
+		return make([]*model.Diagnostic, 0)
+	}
+	return r.CheckSource(project, string(data))
+}
+
+// CheckSource 检查脚本内容中的不兼容写法。
+func (r *ConfigurationCacheRule) CheckSource(_ *model.Project, content string) []*model.Diagnostic {
+	diagnostics := make([]*model.Diagnostic, 0)
+	for _, diag := range parser.FindConfigurationCacheProblems(content) {
+		if diag.Code == r.ID() {
+			diagnostics = append(diagnostics, diag)
+		}
+	}
+	return diagnostics
+}
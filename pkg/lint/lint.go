@@ -0,0 +1,137 @@
+// Package lint 提供基于可插拔规则的构建脚本策略检查功能。
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// Rule 定义一条策略规则。
+type Rule interface {
+	// ID 返回规则的唯一标识，同时作为诊断代码。
+	ID() string
+	// Description 返回规则的说明。
+	Description() string
+	// Check 检查项目并返回违反规则的诊断。
+	Check(project *model.Project) []*model.Diagnostic
+}
+
+// SourceRule 是需要脚本内容才能检查的规则，诊断带有源码位置。
+type SourceRule interface {
+	Rule
+	// CheckSource 检查项目及其脚本内容并返回违反规则的诊断。
+	CheckSource(project *model.Project, content string) []*model.Diagnostic
+}
+
+// Ruleset 表示一组要执行的规则。
+type Ruleset []Rule
+
+// Run 依次执行规则集中的所有规则，返回全部诊断。
+func (rs Ruleset) Run(project *model.Project) []*model.Diagnostic {
+	diagnostics := make([]*model.Diagnostic, 0)
+	if project == nil {
+		return diagnostics
+	}
+	for _, rule := range rs {
+		diagnostics = append(diagnostics, rule.Check(project)...)
+	}
+	return diagnostics
+}
+
+// RunSource 依次执行规则集中的所有规则，SourceRule 使用传入的脚本内容检查，返回全部诊断。
+func (rs Ruleset) RunSource(project *model.Project, content string) []*model.Diagnostic {
+	diagnostics := make([]*model.Diagnostic, 0)
+	if project == nil {
+		return diagnostics
+	}
+	for _, rule := range rs {
+		if sourceRule, ok := rule.(SourceRule); ok {
+			diagnostics = append(diagnostics, sourceRule.CheckSource(project, content)...)
+			continue
+		}
+		diagnostics = append(diagnostics, rule.Check(project)...)
+	}
+	return diagnostics
+}
+
+// Registry 按ID管理可用的规则，可以并发使用。
+type Registry struct {
+	mu    sync.RWMutex
+	rules map[string]Rule
+}
+
+// NewRegistry 创建空的规则注册表。
+func NewRegistry() *Registry {
+	return &Registry{rules: make(map[string]Rule)}
+}
+
+// Register 注册规则，ID重复时返回错误。
+func (r *Registry) Register(rule Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.rules[rule.ID()]; exists {
+		return fmt.Errorf("规则 %s 已注册", rule.ID())
+	}
+	r.rules[rule.ID()] = rule
+	return nil
+}
+
+// Get 根据ID获取规则。
+func (r *Registry) Get(id string) (Rule, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[id]
+	return rule, ok
+}
+
+// Rules 返回所有已注册的规则，按ID排序。
+func (r *Registry) Rules() Ruleset {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make(Ruleset, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID() < rules[j].ID() })
+	return rules
+}
+
+// Ruleset 根据ID创建规则集，不传ID时返回全部规则。
+func (r *Registry) Ruleset(ids ...string) (Ruleset, error) {
+	if len(ids) == 0 {
+		return r.Rules(), nil
+	}
+
+	rules := make(Ruleset, 0, len(ids))
+	for _, id := range ids {
+		rule, ok := r.Get(id)
+		if !ok {
+			return nil, fmt.Errorf("未知的规则: %s", id)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// DefaultRegistry 是包含内置规则的注册表。
+// 仓库白名单规则需要配置，因此不在默认注册表中。
+var DefaultRegistry = newDefaultRegistry()
+
+// newDefaultRegistry 创建包含内置规则的注册表。
+func newDefaultRegistry() *Registry {
+	registry := NewRegistry()
+	rules := append(Ruleset{
+		&NoSnapshotInReleaseRule{},
+		&PluginVersionPinningRule{},
+	}, ConfigurationCacheRules()...)
+	for _, rule := range rules {
+		_ = registry.Register(rule)
+	}
+	return registry
+}
@@ -0,0 +1,261 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func lintTestProject() *model.Project {
+	return &model.Project{
+		Version: "1.0.0",
+		Plugins: []*model.Plugin{
+			{ID: "java"},
+			{ID: "org.gradle.java-library"},
+			{ID: "org.springframework.boot", Version: "3.1.0"},
+			{ID: "com.github.johnrengelman.shadow"},
+			{ID: "com.diffplug.spotless", Version: "6.+"},
+		},
+		Dependencies: []*model.Dependency{
+			{Group: "com.example", Name: "stable", Version: "1.0.0"},
+			{Group: "com.example", Name: "unstable", Version: "2.0.0-SNAPSHOT"},
+		},
+		Repositories: []*model.Repository{
+			{Name: "mavenCentral", URL: "https://repo.maven.apache.org/maven2/"},
+			{Name: "google"},
+			{Name: "internal", URL: "https://nexus.example.com/repository/releases/"},
+			{Name: "jitpack", URL: "https://jitpack.io"},
+		},
+	}
+}
+
+func TestNoSnapshotInReleaseRule(t *testing.T) {
+	rule := &NoSnapshotInReleaseRule{}
+	project := lintTestProject()
+
+	diagnostics := rule.Check(project)
+	if len(diagnostics) != 1 || diagnostics[0].Code != RuleNoSnapshotInRelease || diagnostics[0].Severity != model.SeverityError {
+		t.Errorf("Expected one snapshot error, got %v", diagnostics)
+	}
+
+	project.Version = "1.1.0-SNAPSHOT"
+	if diagnostics := rule.Check(project); len(diagnostics) != 0 {
+		t.Errorf("SNAPSHOT projects should not be checked, got %v", diagnostics)
+	}
+}
+
+func TestRepositoryAllowListRule(t *testing.T) {
+	rule := NewRepositoryAllowListRule("https://repo.maven.apache.org/maven2", "google", "https://nexus.example.com/")
+
+	diagnostics := rule.Check(lintTestProject())
+	if len(diagnostics) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %v", diagnostics)
+	}
+	if diagnostics[0].Message != "仓库 https://jitpack.io 不在白名单中" {
+		t.Errorf("Unexpected message: %s", diagnostics[0].Message)
+	}
+}
+
+func TestRepositoryAllowListRule_DependencyResolutionManagement(t *testing.T) {
+	rule := NewRepositoryAllowListRule("mavenCentral")
+	settings := &model.Project{
+		Repositories: []*model.Repository{{Name: "mavenCentral"}},
+		DependencyResolutionManagement: &model.DependencyResolutionManagement{
+			Repositories: []*model.Repository{{Name: "mavenCentral"}, {Name: "jitpack.io", URL: "https://jitpack.io"}},
+		},
+	}
+
+	diagnostics := rule.Check(settings)
+	if len(diagnostics) != 1 || diagnostics[0].Message != "仓库 https://jitpack.io 不在白名单中" {
+		t.Errorf("Expected centrally declared jitpack to be reported once, got %v", diagnostics)
+	}
+}
+
+func TestPluginVersionPinningRule(t *testing.T) {
+	diagnostics := (&PluginVersionPinningRule{}).Check(lintTestProject())
+	if len(diagnostics) != 2 {
+		t.Fatalf("Expected 2 diagnostics, got %v", diagnostics)
+	}
+	if diagnostics[0].Message != "插件 com.github.johnrengelman.shadow 未声明版本" {
+		t.Errorf("Unexpected message: %s", diagnostics[0].Message)
+	}
+	if diagnostics[1].Message != "插件 com.diffplug.spotless 使用了动态版本 6.+" {
+		t.Errorf("Unexpected message: %s", diagnostics[1].Message)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(&NoSnapshotInReleaseRule{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := registry.Register(&NoSnapshotInReleaseRule{}); err == nil {
+		t.Error("Registering a duplicate rule should fail")
+	}
+	if err := registry.Register(NewRepositoryAllowListRule("mavenCentral")); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	all, err := registry.Ruleset()
+	if err != nil || len(all) != 2 || all[0].ID() != RuleNoSnapshotInRelease {
+		t.Errorf("Unexpected full ruleset: %v, %v", all, err)
+	}
+
+	subset, err := registry.Ruleset(RuleRepositoryAllowList)
+	if err != nil || len(subset) != 1 {
+		t.Errorf("Unexpected subset: %v, %v", subset, err)
+	}
+
+	if _, err := registry.Ruleset("missing"); err == nil {
+		t.Error("Unknown rule IDs should return an error")
+	}
+}
+
+func TestDefaultRegistryRun(t *testing.T) {
+	diagnostics := DefaultRegistry.Rules().Run(lintTestProject())
+	if len(diagnostics) != 3 {
+		t.Errorf("Expected 3 diagnostics from default rules, got %d", len(diagnostics))
+	}
+	if len(Ruleset(nil).Run(nil)) != 0 {
+		t.Error("Running on a nil project should return no diagnostics")
+	}
+}
+
+func TestConfigurationCacheRules(t *testing.T) {
+	content := "buildDir = 'out'\ntask hello {\n    doLast { println project.name }\n}\n"
+	project := &model.Project{Version: "1.0.0"}
+
+	diagnostics := ConfigurationCacheRules().RunSource(project, content)
+	if len(diagnostics) != 2 {
+		t.Fatalf("Expected 2 diagnostics, got %v", diagnostics)
+	}
+	if diagnostics[0].Code != RuleConfigCacheProjectAtExecution || diagnostics[1].Code != RuleConfigCacheBuildDirAssignment {
+		t.Errorf("Unexpected diagnostic codes: %s, %s", diagnostics[0].Code, diagnostics[1].Code)
+	}
+	if diagnostics[0].SourceRange.Start.Line != 3 {
+		t.Errorf("Expected project access on line 3, got %s", diagnostics[0].SourceRange.Start.String())
+	}
+
+	// 没有脚本内容时Check读取FilePath指向的文件。
+	project.FilePath = filepath.Join(t.TempDir(), "build.gradle")
+	if err := os.WriteFile(project.FilePath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rule, ok := DefaultRegistry.Get(RuleConfigCacheProjectAtExecution)
+	if !ok {
+		t.Fatal("Expected configuration cache rules in the default registry")
+	}
+	if got := rule.Check(project); len(got) != 1 {
+		t.Errorf("Expected Check to read the build file, got %v", got)
+	}
+	if got := rule.Check(&model.Project{}); len(got) != 0 {
+		t.Errorf("Expected no diagnostics without a build file, got %v", got)
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	baseline := NewBaseline(&model.Project{
+		Plugins: []*model.Plugin{{ID: "org.gradle.java"}, {ID: "jacoco"}},
+		Repositories: []*model.Repository{
+			{Name: "mavenCentral", URL: "https://repo.maven.apache.org/maven2"},
+			{Name: "google"},
+			{Name: "internal", URL: "https://nexus.example.com/repository/releases"},
+		},
+	})
+	baseline.DisallowedScopes = []string{"compile"}
+
+	project := lintTestProject()
+	project.Dependencies = append(project.Dependencies,
+		&model.Dependency{Group: "com.example", Name: "legacy", Scope: "freeCompile", BaseScope: "compile"})
+
+	var codes []string
+	for _, diag := range baseline.Check(project) {
+		codes = append(codes, diag.Code+" "+diag.Message)
+	}
+	want := []string{
+		RuleBaselineMissingPlugin + " 缺少基线要求的插件 jacoco",
+		RuleBaselineExtraRepository + " 仓库 https://jitpack.io 不在基线中",
+		RuleBaselineDisallowedScope + " 依赖 com.example:legacy 使用了基线不允许的配置 freeCompile",
+	}
+	if len(codes) != len(want) {
+		t.Fatalf("Expected %d diagnostics, got %v", len(want), codes)
+	}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Errorf("diagnostic %d = %q, want %q", i, codes[i], want[i])
+		}
+	}
+
+	project.Repositories = project.Repositories[:1]
+	missing := baseline.MissingRepositories(project)
+	if len(missing) != 2 || RepositoryKey(missing[0]) != "google" {
+		t.Errorf("Unexpected missing repositories: %v", missing)
+	}
+	if extra := (&Baseline{}).ExtraRepositories(project); len(extra) != 0 {
+		t.Errorf("A baseline without repositories should not report extra repositories, got %v", extra)
+	}
+}
+
+func TestCheckReleaseReadiness(t *testing.T) {
+	tree := &model.ProjectTree{
+		Settings: &model.ParseResult{Project: &model.Project{
+			FilePath: "settings.gradle",
+			DependencyResolutionManagement: &model.DependencyResolutionManagement{
+				Repositories: []*model.Repository{{Name: "snapshots", URL: "https://repo.example.com/snapshots/"}},
+			},
+		}},
+		Modules: []*model.Module{
+			{Path: ":", FilePath: "build.gradle", Result: &model.ParseResult{Project: &model.Project{Version: "1.0.0"}}},
+			{Path: ":app", FilePath: "app/build.gradle", Result: &model.ParseResult{Project: &model.Project{
+				Version: "1.1.0-SNAPSHOT",
+				Dependencies: []*model.Dependency{
+					{Group: "org.slf4j", Name: "slf4j-api", Version: "2.0.9"},
+					{Group: "org.foo", Name: "bar", Version: "1.+", DeclaredAtLine: 7},
+					{Group: "org.foo", Name: "baz", Version: "${bazVersion}", ResolvedVersion: "2.0-SNAPSHOT"},
+				},
+				Repositories: []*model.Repository{
+					{Name: "internal", URL: "https://repo.example.com/releases",
+						Content: &model.RepositoryContent{SnapshotsOnly: true}},
+				},
+			}}},
+			{Path: ":docs"},
+		},
+	}
+
+	readiness := CheckReleaseReadiness(tree)
+	if readiness.Ready {
+		t.Error("Expected release check to fail")
+	}
+	kinds := make([]string, 0, len(readiness.Findings))
+	for _, finding := range readiness.Findings {
+		kinds = append(kinds, finding.Module+" "+finding.Kind)
+	}
+	expected := []string{
+		" " + ReleaseSnapshotRepository,
+		":app " + ReleaseSnapshotVersion,
+		":app " + ReleaseDynamicVersion,
+		":app " + ReleaseSnapshotDependency,
+		":app " + ReleaseSnapshotRepository,
+	}
+	if strings.Join(kinds, ",") != strings.Join(expected, ",") {
+		t.Errorf("Findings = %v, want %v", kinds, expected)
+	}
+	if f := readiness.Findings[2]; f.Line != 7 || f.FilePath != "app/build.gradle" || f.Severity != model.SeverityError {
+		t.Errorf("Unexpected dynamic version finding %+v", f)
+	}
+	if f := readiness.Findings[0]; f.Severity != model.SeverityWarning || f.FilePath != "settings.gradle" {
+		t.Errorf("Unexpected settings repository finding %+v", f)
+	}
+
+	// 只有SNAPSHOT仓库时仍然可以发布。
+	tree.Modules = tree.Modules[:1]
+	if readiness := CheckReleaseReadiness(tree); !readiness.Ready || len(readiness.Findings) != 1 {
+		t.Errorf("Expected ready with one warning, got %+v", readiness)
+	}
+	if readiness := CheckReleaseReadiness(nil); !readiness.Ready || len(readiness.Findings) != 0 {
+		t.Errorf("Expected nil tree to be ready, got %+v", readiness)
+	}
+}
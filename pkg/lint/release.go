@@ -0,0 +1,134 @@
+// Package lint 提供发布前的SNAPSHOT和动态版本检查。
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/parser"
+)
+
+// 发布检查发现的问题种类。
+const (
+	ReleaseSnapshotDependency = "snapshot-dependency"
+	ReleaseDynamicVersion     = "dynamic-version"
+	ReleaseSnapshotRepository = "snapshot-repository"
+	ReleaseSnapshotVersion    = "snapshot-project-version"
+)
+
+// ReleaseFinding 是发布检查发现的一个问题。
+type ReleaseFinding struct {
+	Kind     string         `json:"kind"` // Release* 常量之一。
+	Severity model.Severity `json:"severity"`
+	Message  string         `json:"message"`
+
+	// Module 是问题所在模块的Gradle路径，settings文件中的问题为空。
+	Module   string `json:"module,omitempty"`
+	FilePath string `json:"filePath,omitempty"`
+	// Line 是声明所在的行号，未知时为0。
+	Line int `json:"line,omitempty"`
+
+	Dependency *model.Dependency `json:"dependency,omitempty"`
+	Repository *model.Repository `json:"repository,omitempty"`
+}
+
+// ReleaseReadiness 是发布检查的结果。
+type ReleaseReadiness struct {
+	// Ready 为true表示没有error级别的问题，可以发布。
+	Ready    bool              `json:"ready"`
+	Findings []*ReleaseFinding `json:"findings"`
+}
+
+// CheckReleaseReadiness 检查构建中的各模块是否可以发布：SNAPSHOT依赖、动态版本和SNAPSHOT项目版本是错误，
+// 声明了SNAPSHOT仓库（URL中包含snapshot或声明了 snapshotsOnly()）是警告。settings中集中声明的仓库同样会检查。
+// 依赖版本优先使用变量解析后的ResolvedVersion。结果按模块顺序排列。
+func CheckReleaseReadiness(tree *model.ProjectTree) *ReleaseReadiness {
+	readiness := &ReleaseReadiness{Ready: true, Findings: make([]*ReleaseFinding, 0)}
+	if tree == nil {
+		return readiness
+	}
+
+	if tree.Settings != nil && tree.Settings.Project != nil {
+		readiness.checkRepositories(tree.Settings.Project.FilePath, "", declaredRepositories(tree.Settings.Project))
+	}
+	tree.EachModule(func(module *model.Module) bool {
+		if module.Result == nil || module.Result.Project == nil {
+			return true
+		}
+		project := module.Result.Project
+		if strings.HasSuffix(project.Version, snapshotSuffix) {
+			readiness.add(&ReleaseFinding{
+				Kind:     ReleaseSnapshotVersion,
+				Severity: model.SeverityError,
+				Message:  fmt.Sprintf("模块 %s 的版本 %s 是SNAPSHOT版本", module.Path, project.Version),
+				Module:   module.Path,
+				FilePath: module.FilePath,
+			})
+		}
+		for _, dep := range project.Dependencies {
+			readiness.checkDependency(module, dep)
+		}
+		readiness.checkRepositories(module.FilePath, module.Path, project.Repositories)
+		return true
+	})
+	return readiness
+}
+
+// checkDependency 检查依赖是否使用了SNAPSHOT或动态版本。
+func (r *ReleaseReadiness) checkDependency(module *model.Module, dep *model.Dependency) {
+	version := dep.Version
+	if dep.ResolvedVersion != "" {
+		version = dep.ResolvedVersion
+	}
+	finding := &ReleaseFinding{
+		Severity:   model.SeverityError,
+		Module:     module.Path,
+		FilePath:   module.FilePath,
+		Line:       dep.DeclaredAtLine,
+		Dependency: dep,
+	}
+	coordinate := fmt.Sprintf("%s:%s:%s", dep.Group, dep.Name, version)
+	switch {
+	case strings.HasSuffix(version, snapshotSuffix):
+		finding.Kind = ReleaseSnapshotDependency
+		finding.Message = fmt.Sprintf("模块 %s 依赖了SNAPSHOT版本 %s", module.Path, coordinate)
+	case parser.IsDynamicVersion(version):
+		finding.Kind = ReleaseDynamicVersion
+		finding.Message = fmt.Sprintf("模块 %s 的依赖 %s 使用了动态版本，发布结果不可重现", module.Path, coordinate)
+	default:
+		return
+	}
+	r.add(finding)
+}
+
+// checkRepositories 检查仓库列表中的SNAPSHOT仓库。
+func (r *ReleaseReadiness) checkRepositories(filePath, modulePath string, repos []*model.Repository) {
+	for _, repo := range repos {
+		snapshotOnly := repo.Content != nil && repo.Content.SnapshotsOnly
+		if !snapshotOnly && !strings.Contains(strings.ToLower(repo.URL), "snapshot") {
+			continue
+		}
+		label := repo.URL
+		if label == "" {
+			label = repo.Name
+		}
+		r.add(&ReleaseFinding{
+			Kind:       ReleaseSnapshotRepository,
+			Severity:   model.SeverityWarning,
+			Message:    fmt.Sprintf("声明了SNAPSHOT仓库 %s", label),
+			Module:     modulePath,
+			FilePath:   filePath,
+			Line:       repo.DeclaredAtLine,
+			Repository: repo,
+		})
+	}
+}
+
+// add 记录问题，error级别的问题使检查不通过。
+func (r *ReleaseReadiness) add(finding *ReleaseFinding) {
+	if finding.Severity == model.SeverityError {
+		r.Ready = false
+	}
+	r.Findings = append(r.Findings, finding)
+}
@@ -0,0 +1,170 @@
+// Package lint 提供内置的策略规则。
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+	"github.com/scagogogo/gradle-parser/pkg/parser"
+)
+
+// 内置规则的ID。
+const (
+	RuleNoSnapshotInRelease  = "no-snapshot-in-release"
+	RuleRepositoryAllowList  = "repository-allow-list"
+	RulePluginVersionPinning = "plugin-version-pinning"
+)
+
+const (
+	snapshotSuffix            = "-SNAPSHOT"
+	corePluginNamespacePrefix = "org.gradle."
+)
+
+// NoSnapshotInReleaseRule 禁止发布版本依赖SNAPSHOT版本。
+// 项目自身版本为SNAPSHOT时不做检查。
+type NoSnapshotInReleaseRule struct{}
+
+// ID 返回规则ID。
+func (r *NoSnapshotInReleaseRule) ID() string { return RuleNoSnapshotInRelease }
+
+// Description 返回规则说明。
+func (r *NoSnapshotInReleaseRule) Description() string {
+	return "发布版本不允许依赖SNAPSHOT版本"
+}
+
+// Check 检查项目中的SNAPSHOT依赖。
+func (r *NoSnapshotInReleaseRule) Check(project *model.Project) []*model.Diagnostic {
+	diagnostics := make([]*model.Diagnostic, 0)
+	if strings.HasSuffix(project.Version, snapshotSuffix) {
+		return diagnostics
+	}
+
+	for _, dep := range project.Dependencies {
+		if strings.HasSuffix(dep.Version, snapshotSuffix) {
+			diagnostics = append(diagnostics, &model.Diagnostic{
+				Code:     r.ID(),
+				Severity: model.SeverityError,
+				Message:  fmt.Sprintf("发布版本 %s 依赖了SNAPSHOT版本 %s:%s:%s", project.Version, dep.Group, dep.Name, dep.Version),
+			})
+		}
+	}
+	return diagnostics
+}
+
+// RepositoryAllowListRule 只允许使用白名单中的仓库。
+// Allowed 中的每一项可以是仓库名称（如 mavenCentral）或URL前缀。
+type RepositoryAllowListRule struct {
+	Allowed []string
+}
+
+// NewRepositoryAllowListRule 创建仓库白名单规则。
+func NewRepositoryAllowListRule(allowed ...string) *RepositoryAllowListRule {
+	return &RepositoryAllowListRule{Allowed: allowed}
+}
+
+// ID 返回规则ID。
+func (r *RepositoryAllowListRule) ID() string { return RuleRepositoryAllowList }
+
+// Description 返回规则说明。
+func (r *RepositoryAllowListRule) Description() string {
+	return "只允许使用白名单中的仓库"
+}
+
+// Check 检查项目中不在白名单中的仓库，包括settings文件中 dependencyResolutionManagement 集中声明的仓库。
+func (r *RepositoryAllowListRule) Check(project *model.Project) []*model.Diagnostic {
+	diagnostics := make([]*model.Diagnostic, 0)
+	for _, repo := range declaredRepositories(project) {
+		if r.allows(repo) {
+			continue
+		}
+		label := repo.Name
+		if repo.URL != "" {
+			label = repo.URL
+		}
+		diagnostics = append(diagnostics, &model.Diagnostic{
+			Code:     r.ID(),
+			Severity: model.SeverityError,
+			Message:  fmt.Sprintf("仓库 %s 不在白名单中", label),
+		})
+	}
+	return diagnostics
+}
+
+// declaredRepositories 返回项目中声明的仓库以及 dependencyResolutionManagement 中集中声明的仓库。
+// settings文件自身的仓库列表已经包含集中声明的仓库，这些仓库不会重复返回。
+func declaredRepositories(project *model.Project) []*model.Repository {
+	drm := project.DependencyResolutionManagement
+	if drm == nil {
+		return project.Repositories
+	}
+
+	seen := make(map[string]bool, len(project.Repositories))
+	for _, repo := range project.Repositories {
+		seen[repo.Name+"\x00"+repo.URL] = true
+	}
+	repos := append([]*model.Repository(nil), project.Repositories...)
+	for _, repo := range drm.Repositories {
+		if !seen[repo.Name+"\x00"+repo.URL] {
+			repos = append(repos, repo)
+		}
+	}
+	return repos
+}
+
+// allows 判断仓库是否在白名单中。
+func (r *RepositoryAllowListRule) allows(repo *model.Repository) bool {
+	for _, allowed := range r.Allowed {
+		if repo.URL != "" && strings.HasPrefix(strings.TrimSuffix(repo.URL, "/"), strings.TrimSuffix(allowed, "/")) {
+			return true
+		}
+		if repo.URL == "" && repo.Name == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// PluginVersionPinningRule 要求第三方插件声明固定版本。
+// Gradle核心插件（如 java、org.gradle.java-library）不需要版本。
+type PluginVersionPinningRule struct{}
+
+// ID 返回规则ID。
+func (r *PluginVersionPinningRule) ID() string { return RulePluginVersionPinning }
+
+// Description 返回规则说明。
+func (r *PluginVersionPinningRule) Description() string {
+	return "第三方插件必须声明固定版本"
+}
+
+// Check 检查未声明版本或使用动态版本的插件。
+func (r *PluginVersionPinningRule) Check(project *model.Project) []*model.Diagnostic {
+	diagnostics := make([]*model.Diagnostic, 0)
+	for _, plugin := range project.Plugins {
+		if isCorePlugin(plugin.ID) {
+			continue
+		}
+
+		var message string
+		switch {
+		case plugin.Version == "":
+			message = fmt.Sprintf("插件 %s 未声明版本", plugin.ID)
+		case parser.IsDynamicVersion(plugin.Version):
+			message = fmt.Sprintf("插件 %s 使用了动态版本 %s", plugin.ID, plugin.Version)
+		default:
+			continue
+		}
+
+		diagnostics = append(diagnostics, &model.Diagnostic{
+			Code:     r.ID(),
+			Severity: model.SeverityError,
+			Message:  message,
+		})
+	}
+	return diagnostics
+}
+
+// isCorePlugin 判断插件是否为Gradle核心插件，核心插件ID不含点号或以org.gradle.开头。
+func isCorePlugin(id string) bool {
+	return !strings.Contains(id, ".") || strings.HasPrefix(id, corePluginNamespacePrefix)
+}
@@ -0,0 +1,92 @@
+// Package lockfile 提供声明版本与锁定版本的差异比较功能。
+package lockfile
+
+import (
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// DriftKind 表示声明与锁定之间的差异类型。
+type DriftKind string
+
+const (
+	// DriftVersionMismatch 表示声明的版本与锁定的版本不一致。
+	DriftVersionMismatch DriftKind = "version-mismatch"
+	// DriftNotLocked 表示声明的依赖没有出现在锁文件中。
+	DriftNotLocked DriftKind = "not-locked"
+)
+
+// Drift 表示一个声明依赖与锁文件之间的差异。
+type Drift struct {
+	Dependency      *model.Dependency `json:"dependency"`
+	Kind            DriftKind         `json:"kind"`
+	DeclaredVersion string            `json:"declaredVersion"`
+	LockedVersions  []string          `json:"lockedVersions,omitempty"`
+}
+
+// Compare 比较项目中声明的依赖与锁文件，返回存在差异的依赖。
+// 未声明版本的依赖（如由BOM管理）只检查是否被锁定；
+// 动态版本（如 1.+）在锁定版本满足前缀时视为一致，版本范围不做比较。
+func Compare(project *model.Project, lock *Lockfile) []*Drift {
+	drifts := make([]*Drift, 0)
+	if project == nil || lock == nil {
+		return drifts
+	}
+
+	for _, dep := range project.Dependencies {
+		if dep == nil || dep.Group == "" || dep.Name == "" {
+			continue
+		}
+
+		entries := lock.Lookup(dep.Group, dep.Name)
+		if len(entries) == 0 {
+			drifts = append(drifts, &Drift{
+				Dependency:      dep,
+				Kind:            DriftNotLocked,
+				DeclaredVersion: dep.Version,
+			})
+			continue
+		}
+
+		if dep.Version == "" {
+			continue
+		}
+
+		lockedVersions := make([]string, 0, len(entries))
+		matched := false
+		for _, entry := range entries {
+			lockedVersions = append(lockedVersions, entry.Version)
+			if versionSatisfies(dep.Version, entry.Version) {
+				matched = true
+			}
+		}
+
+		if !matched {
+			drifts = append(drifts, &Drift{
+				Dependency:      dep,
+				Kind:            DriftVersionMismatch,
+				DeclaredVersion: dep.Version,
+				LockedVersions:  lockedVersions,
+			})
+		}
+	}
+
+	return drifts
+}
+
+// versionSatisfies 判断锁定版本是否满足声明的版本。
+func versionSatisfies(declared, locked string) bool {
+	switch {
+	case declared == locked:
+		return true
+	case declared == "+" || strings.HasPrefix(declared, "latest."):
+		return true
+	case strings.HasSuffix(declared, "+"):
+		return strings.HasPrefix(locked, strings.TrimSuffix(declared, "+"))
+	case strings.ContainsAny(declared, "[]()"):
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,226 @@
+// Package lockfile 提供Gradle依赖锁文件与依赖校验元数据的解析功能。
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// emptyEntryKey 是锁文件中记录无依赖配置的特殊键。
+const emptyEntryKey = "empty"
+
+// LockedDependency 表示锁文件中的一条锁定记录。
+type LockedDependency struct {
+	Group          string   `json:"group"`
+	Name           string   `json:"name"`
+	Version        string   `json:"version"`
+	Configurations []string `json:"configurations"` // 锁定该版本的配置，例如 compileClasspath。
+}
+
+// Lockfile 表示解析后的gradle.lockfile。
+type Lockfile struct {
+	Dependencies        []*LockedDependency `json:"dependencies"`
+	EmptyConfigurations []string            `json:"emptyConfigurations,omitempty"` // 已锁定但没有依赖的配置。
+	FilePath            string              `json:"filePath,omitempty"`
+}
+
+// Parse 解析gradle.lockfile格式的内容。
+// 每行形如 group:name:version=conf1,conf2，以#开头的行为注释。
+func Parse(r io.Reader) (*Lockfile, error) {
+	lock := &Lockfile{Dependencies: make([]*LockedDependency, 0)}
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		coordinate, configurations, _ := strings.Cut(line, "=")
+		confs := splitConfigurations(configurations)
+
+		if coordinate == emptyEntryKey {
+			lock.EmptyConfigurations = append(lock.EmptyConfigurations, confs...)
+			continue
+		}
+
+		dep, err := parseCoordinate(coordinate)
+		if err != nil {
+			return nil, fmt.Errorf("第%d行: %w", lineNum, err)
+		}
+		dep.Configurations = confs
+		lock.Dependencies = append(lock.Dependencies, dep)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+// ParseString 解析锁文件字符串。
+func ParseString(content string) (*Lockfile, error) {
+	return Parse(strings.NewReader(content))
+}
+
+// ParseFile 解析指定路径的锁文件。
+func ParseFile(filePath string) (*Lockfile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	lock, err := Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("解析锁文件 %s 失败: %w", filePath, err)
+	}
+	lock.FilePath = filePath
+	return lock, nil
+}
+
+// ParseLegacy 解析旧版按配置拆分的锁文件，例如 gradle/dependency-locks/compileClasspath.lockfile。
+// 旧版文件每行只有坐标，所属配置由文件名决定。
+func ParseLegacy(configuration string, r io.Reader) (*Lockfile, error) {
+	lock, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, dep := range lock.Dependencies {
+		dep.Configurations = []string{configuration}
+	}
+	if len(lock.Dependencies) == 0 {
+		lock.EmptyConfigurations = []string{configuration}
+	}
+	return lock, nil
+}
+
+// ParseLegacyDir 解析目录下所有旧版 *.lockfile 文件并合并结果。
+func ParseLegacyDir(dir string) (*Lockfile, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.lockfile"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	merged := &Lockfile{Dependencies: make([]*LockedDependency, 0), FilePath: dir}
+	for _, path := range paths {
+		configuration := strings.TrimSuffix(filepath.Base(path), ".lockfile")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		lock, err := ParseLegacy(configuration, strings.NewReader(string(content)))
+		if err != nil {
+			return nil, fmt.Errorf("解析锁文件 %s 失败: %w", path, err)
+		}
+		merged.merge(lock)
+	}
+	return merged, nil
+}
+
+// merge 将另一个锁文件的记录合并进来，相同坐标的配置会合并。
+func (l *Lockfile) merge(other *Lockfile) {
+	for _, dep := range other.Dependencies {
+		if existing := l.find(dep.Group, dep.Name, dep.Version); existing != nil {
+			existing.Configurations = append(existing.Configurations, dep.Configurations...)
+			continue
+		}
+		l.Dependencies = append(l.Dependencies, dep)
+	}
+	l.EmptyConfigurations = append(l.EmptyConfigurations, other.EmptyConfigurations...)
+}
+
+// find 查找指定坐标的锁定记录。
+func (l *Lockfile) find(group, name, version string) *LockedDependency {
+	for _, dep := range l.Dependencies {
+		if dep.Group == group && dep.Name == name && dep.Version == version {
+			return dep
+		}
+	}
+	return nil
+}
+
+// Lookup 返回指定模块的所有锁定记录，不同配置可能锁定不同版本。
+func (l *Lockfile) Lookup(group, name string) []*LockedDependency {
+	result := make([]*LockedDependency, 0)
+	for _, dep := range l.Dependencies {
+		if dep.Group == group && dep.Name == name {
+			result = append(result, dep)
+		}
+	}
+	return result
+}
+
+// LockedVersion 返回指定模块在某个配置中锁定的版本，未锁定时返回空字符串。
+func (l *Lockfile) LockedVersion(group, name, configuration string) string {
+	for _, dep := range l.Lookup(group, name) {
+		for _, conf := range dep.Configurations {
+			if conf == configuration {
+				return dep.Version
+			}
+		}
+	}
+	return ""
+}
+
+// Configurations 返回锁文件涉及的所有配置名称，按字母排序。
+func (l *Lockfile) Configurations() []string {
+	seen := make(map[string]bool)
+	for _, dep := range l.Dependencies {
+		for _, conf := range dep.Configurations {
+			seen[conf] = true
+		}
+	}
+	for _, conf := range l.EmptyConfigurations {
+		seen[conf] = true
+	}
+
+	confs := make([]string, 0, len(seen))
+	for conf := range seen {
+		confs = append(confs, conf)
+	}
+	sort.Strings(confs)
+	return confs
+}
+
+// ByConfiguration 按配置分组返回锁定的依赖。
+func (l *Lockfile) ByConfiguration() map[string][]*LockedDependency {
+	result := make(map[string][]*LockedDependency)
+	for _, conf := range l.EmptyConfigurations {
+		result[conf] = make([]*LockedDependency, 0)
+	}
+	for _, dep := range l.Dependencies {
+		for _, conf := range dep.Configurations {
+			result[conf] = append(result[conf], dep)
+		}
+	}
+	return result
+}
+
+// parseCoordinate 解析 group:name:version 坐标。
+func parseCoordinate(coordinate string) (*LockedDependency, error) {
+	parts := strings.Split(strings.TrimSpace(coordinate), ":")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("无效的锁定坐标: %s", coordinate)
+	}
+	return &LockedDependency{Group: parts[0], Name: parts[1], Version: parts[2]}, nil
+}
+
+// splitConfigurations 拆分逗号分隔的配置列表。
+func splitConfigurations(s string) []string {
+	confs := make([]string, 0)
+	for _, conf := range strings.Split(s, ",") {
+		if conf = strings.TrimSpace(conf); conf != "" {
+			confs = append(confs, conf)
+		}
+	}
+	return confs
+}
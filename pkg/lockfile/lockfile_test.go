@@ -0,0 +1,279 @@
+package lockfile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+const testLockfile = `# This is a Gradle generated file for dependency locking.
+# Manual edits can break the build and are not advised.
+# This file is expected to be part of source control.
+com.google.guava:guava:31.1-jre=compileClasspath,runtimeClasspath
+com.google.guava:guava:30.0-jre=testCompileClasspath
+junit:junit:4.13.2=testCompileClasspath,testRuntimeClasspath
+empty=annotationProcessor
+`
+
+func TestParse(t *testing.T) {
+	lock, err := ParseString(testLockfile)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	if len(lock.Dependencies) != 3 {
+		t.Fatalf("Expected 3 locked dependencies, got %d", len(lock.Dependencies))
+	}
+	if len(lock.EmptyConfigurations) != 1 || lock.EmptyConfigurations[0] != "annotationProcessor" {
+		t.Errorf("Unexpected empty configurations: %v", lock.EmptyConfigurations)
+	}
+
+	if v := lock.LockedVersion("com.google.guava", "guava", "runtimeClasspath"); v != "31.1-jre" {
+		t.Errorf("Expected 31.1-jre for runtimeClasspath, got %q", v)
+	}
+	if v := lock.LockedVersion("com.google.guava", "guava", "testCompileClasspath"); v != "30.0-jre" {
+		t.Errorf("Expected 30.0-jre for testCompileClasspath, got %q", v)
+	}
+
+	byConf := lock.ByConfiguration()
+	if len(byConf["testCompileClasspath"]) != 2 {
+		t.Errorf("Expected 2 dependencies in testCompileClasspath, got %d", len(byConf["testCompileClasspath"]))
+	}
+	if deps, ok := byConf["annotationProcessor"]; !ok || len(deps) != 0 {
+		t.Errorf("Empty configuration should be present without dependencies")
+	}
+
+	confs := lock.Configurations()
+	if len(confs) != 5 || confs[0] != "annotationProcessor" {
+		t.Errorf("Unexpected configurations: %v", confs)
+	}
+}
+
+func TestParse_InvalidCoordinate(t *testing.T) {
+	_, err := ParseString("com.example:broken=compileClasspath\n")
+	if err == nil || !strings.Contains(err.Error(), "第1行") {
+		t.Errorf("Expected line-numbered error, got %v", err)
+	}
+}
+
+func TestParseLegacyDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"compileClasspath.lockfile":     "# comment\ncom.google.guava:guava:31.1-jre\n",
+		"runtimeClasspath.lockfile":     "com.google.guava:guava:31.1-jre\n",
+		"annotationProcessor.lockfile":  "# empty\n",
+		"testCompileClasspath.lockfile": "junit:junit:4.13.2\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lock, err := ParseLegacyDir(dir)
+	if err != nil {
+		t.Fatalf("ParseLegacyDir() error = %v", err)
+	}
+
+	guava := lock.Lookup("com.google.guava", "guava")
+	if len(guava) != 1 || len(guava[0].Configurations) != 2 {
+		t.Errorf("Guava should be merged across two configurations, got %+v", guava)
+	}
+	if len(lock.EmptyConfigurations) != 1 {
+		t.Errorf("Expected 1 empty configuration, got %v", lock.EmptyConfigurations)
+	}
+}
+
+func TestParseVerificationMetadata(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<verification-metadata xmlns="https://schema.gradle.org/dependency-verification">
+   <configuration>
+      <verify-metadata>true</verify-metadata>
+      <verify-signatures>false</verify-signatures>
+   </configuration>
+   <components>
+      <component group="com.google.guava" name="guava" version="31.1-jre">
+         <artifact name="guava-31.1-jre.jar">
+            <sha256 value="a42edc9c" origin="Generated by Gradle"/>
+            <sha512 value="8f3a9b"/>
+         </artifact>
+      </component>
+   </components>
+</verification-metadata>`
+
+	metadata, err := ParseVerificationMetadata(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("ParseVerificationMetadata() error = %v", err)
+	}
+	if !metadata.VerifyMetadata || metadata.VerifySignatures {
+		t.Errorf("Unexpected configuration flags: %+v", metadata)
+	}
+
+	component := metadata.FindComponent("com.google.guava", "guava", "31.1-jre")
+	if component == nil || len(component.Artifacts) != 1 {
+		t.Fatalf("Expected guava component with one artifact, got %+v", component)
+	}
+	checksums := component.Artifacts[0].Checksums
+	if len(checksums) != 2 || checksums[0].Algorithm != "sha256" || checksums[0].Value != "a42edc9c" || checksums[0].Origin != "Generated by Gradle" {
+		t.Errorf("Unexpected checksums: %+v", checksums)
+	}
+
+	if lock := metadata.ToLockfile(); len(lock.Lookup("com.google.guava", "guava")) != 1 {
+		t.Error("ToLockfile should include verified components")
+	}
+}
+
+const testVerificationMetadata = `<?xml version="1.0" encoding="UTF-8"?>
+<verification-metadata xmlns="https://schema.gradle.org/dependency-verification">
+   <configuration>
+      <verify-metadata>true</verify-metadata>
+      <verify-signatures>true</verify-signatures>
+      <key-servers>
+         <key-server uri="https://keys.openpgp.org"/>
+      </key-servers>
+      <trusted-artifacts>
+         <trust group="com.example.internal" regex="true"/>
+         <trust file=".*-javadoc[.]jar" regex="true"/>
+      </trusted-artifacts>
+      <ignored-keys>
+         <ignored-key id="ABCDEF01" reason="Key couldn't be downloaded"/>
+      </ignored-keys>
+      <trusted-keys>
+         <trusted-key id="7C25280EAE63EBE5" group="org.apache.commons"/>
+         <trusted-key id="475F3B8E59E6E63AA78067482C7B12F2A5A7B9B5">
+            <trusting group="^org[.]slf4j($|([.].*))" regex="true"/>
+            <trusting group="junit" name="junit" version="4.13.2"/>
+         </trusted-key>
+      </trusted-keys>
+   </configuration>
+   <components>
+      <component group="com.google.guava" name="guava" version="31.1-jre">
+         <artifact name="guava-31.1-jre.jar">
+            <sha256 value="a42edc9c" origin="Generated by Gradle">
+               <also-trust value="b53fed0d"/>
+            </sha256>
+         </artifact>
+      </component>
+   </components>
+</verification-metadata>`
+
+func TestParseVerificationMetadataTrust(t *testing.T) {
+	metadata, err := ParseVerificationMetadata(strings.NewReader(testVerificationMetadata))
+	if err != nil {
+		t.Fatalf("ParseVerificationMetadata() error = %v", err)
+	}
+
+	if len(metadata.KeyServers) != 1 || metadata.KeyServers[0].URI != "https://keys.openpgp.org" {
+		t.Errorf("Unexpected key servers: %+v", metadata.KeyServers)
+	}
+	if len(metadata.TrustedArtifacts) != 2 || !metadata.TrustedArtifacts[0].Regex || metadata.TrustedArtifacts[1].File != ".*-javadoc[.]jar" {
+		t.Errorf("Unexpected trusted artifacts: %+v", metadata.TrustedArtifacts)
+	}
+	if len(metadata.IgnoredKeys) != 1 || metadata.IgnoredKeys[0].ID != "ABCDEF01" {
+		t.Errorf("Unexpected ignored keys: %+v", metadata.IgnoredKeys)
+	}
+	if len(metadata.TrustedKeys) != 2 {
+		t.Fatalf("Expected 2 trusted keys, got %d", len(metadata.TrustedKeys))
+	}
+	if rules := metadata.TrustedKeys[0].Rules(); len(rules) != 1 || rules[0].Group != "org.apache.commons" {
+		t.Errorf("Unexpected rules for attribute key: %+v", rules)
+	}
+	if rules := metadata.TrustedKeys[1].Rules(); len(rules) != 2 || rules[1].Version != "4.13.2" {
+		t.Errorf("Unexpected rules for trusting key: %+v", rules)
+	}
+
+	checksum := metadata.FindComponent("com.google.guava", "guava", "31.1-jre").Artifacts[0].Checksums[0]
+	if checksum.Value != "a42edc9c" || len(checksum.AlsoTrust) != 1 || checksum.AlsoTrust[0] != "b53fed0d" {
+		t.Errorf("Unexpected checksum: %+v", checksum)
+	}
+
+	for _, tt := range []struct {
+		group, name, version string
+		trusted              bool
+	}{
+		{"org.apache.commons", "commons-lang3", "3.12.0", true},
+		{"org.slf4j", "slf4j-api", "2.0.9", true},
+		{"org.slf4j.ext", "slf4j-ext", "2.0.9", true},
+		{"org.slf4jx", "foo", "1.0", false},
+		{"junit", "junit", "4.13.2", true},
+		{"junit", "junit", "4.12", false},
+		{"com.example.internal", "lib", "1.0", true},
+		{"com.google.guava", "guava", "31.1-jre", false},
+	} {
+		if got := metadata.IsTrusted(tt.group, tt.name, tt.version); got != tt.trusted {
+			t.Errorf("IsTrusted(%s:%s:%s) = %v, want %v", tt.group, tt.name, tt.version, got, tt.trusted)
+		}
+	}
+
+	// 关闭签名校验时，受信任的密钥不再覆盖依赖。
+	metadata.VerifySignatures = false
+	if metadata.IsTrusted("org.apache.commons", "commons-lang3", "3.12.0") {
+		t.Error("Trusted keys should not apply when signature verification is off")
+	}
+}
+
+func TestCheckVerification(t *testing.T) {
+	metadata, err := ParseVerificationMetadata(strings.NewReader(testVerificationMetadata))
+	if err != nil {
+		t.Fatalf("ParseVerificationMetadata() error = %v", err)
+	}
+
+	project := &model.Project{
+		Dependencies: []*model.Dependency{
+			{Group: "com.google.guava", Name: "guava", Version: "31.1-jre"},
+			{Group: "com.google.guava", Name: "guava", Version: "32.1.2-jre"},
+			{Group: "com.google.guava", Name: "guava", Version: "$guavaVersion", HasDynamicVersion: true},
+			{Group: "org.apache.commons", Name: "commons-lang3", Version: "3.12.0"},
+			{Group: "io.netty", Name: "netty-all", Version: "4.1.100.Final"},
+			{Group: "io.netty", Name: "netty-all", Version: "4.1.100.Final", ResolvedVersion: "4.1.100.Final", Scope: "runtimeOnly"},
+			{Name: "core", Project: &model.ProjectRef{Path: ":core"}},
+		},
+	}
+
+	issues := CheckVerification(project, metadata)
+	if len(issues) != 3 {
+		t.Fatalf("Expected 3 issues, got %d: %+v", len(issues), issues)
+	}
+	if issues[0].Kind != VerificationVersionMissing || issues[0].Dependency.Version != "32.1.2-jre" ||
+		len(issues[0].VerifiedVersions) != 1 || issues[0].VerifiedVersions[0] != "31.1-jre" {
+		t.Errorf("Unexpected first issue: %+v", issues[0])
+	}
+	if issues[1].Kind != VerificationMissing || issues[1].Dependency.Group != "io.netty" {
+		t.Errorf("Unexpected second issue: %+v", issues[1])
+	}
+
+	if issues := CheckVerification(nil, metadata); len(issues) != 0 {
+		t.Errorf("Expected no issues for nil project, got %d", len(issues))
+	}
+}
+
+func TestCompare(t *testing.T) {
+	lock, err := ParseString(testLockfile)
+	if err != nil {
+		t.Fatalf("ParseString() error = %v", err)
+	}
+
+	project := &model.Project{
+		Dependencies: []*model.Dependency{
+			{Group: "com.google.guava", Name: "guava", Version: "31.1-jre"},
+			{Group: "junit", Name: "junit", Version: "4.12"},
+			{Group: "org.slf4j", Name: "slf4j-api", Version: "2.0.9"},
+			{Group: "junit", Name: "junit", Version: "4.+"},
+			{Name: "local-module"},
+		},
+	}
+
+	drifts := Compare(project, lock)
+	if len(drifts) != 2 {
+		t.Fatalf("Expected 2 drifts, got %d", len(drifts))
+	}
+	if drifts[0].Kind != DriftVersionMismatch || drifts[0].LockedVersions[0] != "4.13.2" {
+		t.Errorf("Unexpected first drift: %+v", drifts[0])
+	}
+	if drifts[1].Kind != DriftNotLocked || drifts[1].Dependency.Name != "slf4j-api" {
+		t.Errorf("Unexpected second drift: %+v", drifts[1])
+	}
+}
@@ -0,0 +1,283 @@
+// Package lockfile 提供依赖校验元数据（gradle/verification-metadata.xml）的解析功能。
+package lockfile
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// VerificationMetadata 表示verification-metadata.xml的内容。
+type VerificationMetadata struct {
+	XMLName          xml.Name             `xml:"verification-metadata" json:"-"`
+	VerifyMetadata   bool                 `xml:"configuration>verify-metadata" json:"verifyMetadata"`
+	VerifySignatures bool                 `xml:"configuration>verify-signatures" json:"verifySignatures"`
+	KeyServers       []*KeyServer         `xml:"configuration>key-servers>key-server" json:"keyServers,omitempty"`
+	TrustedArtifacts []*TrustRule         `xml:"configuration>trusted-artifacts>trust" json:"trustedArtifacts,omitempty"`
+	IgnoredKeys      []*IgnoredKey        `xml:"configuration>ignored-keys>ignored-key" json:"ignoredKeys,omitempty"`
+	TrustedKeys      []*TrustedKey        `xml:"configuration>trusted-keys>trusted-key" json:"trustedKeys,omitempty"`
+	Components       []*VerifiedComponent `xml:"components>component" json:"components"`
+	FilePath         string               `xml:"-" json:"filePath,omitempty"`
+}
+
+// KeyServer 表示下载PGP公钥使用的密钥服务器。
+type KeyServer struct {
+	URI string `xml:"uri,attr" json:"uri"`
+}
+
+// IgnoredKey 表示校验签名时忽略的PGP密钥。
+type IgnoredKey struct {
+	ID     string `xml:"id,attr" json:"id"`
+	Reason string `xml:"reason,attr" json:"reason,omitempty"`
+}
+
+// TrustRule 表示按坐标匹配模块或制品的信任规则，空字段匹配任意值，Regex为true时各字段按正则表达式完整匹配。
+type TrustRule struct {
+	Group   string `xml:"group,attr" json:"group,omitempty"`
+	Name    string `xml:"name,attr" json:"name,omitempty"`
+	Version string `xml:"version,attr" json:"version,omitempty"`
+	File    string `xml:"file,attr" json:"file,omitempty"`
+	Regex   bool   `xml:"regex,attr" json:"regex,omitempty"`
+}
+
+// Matches 判断规则是否匹配指定的模块版本，version为空时不比较版本。
+// 限定了文件的规则只信任部分制品，不视为匹配整个模块。
+func (r *TrustRule) Matches(group, name, version string) bool {
+	if r.File != "" {
+		return false
+	}
+	return r.matchField(r.Group, group) && r.matchField(r.Name, name) && (version == "" || r.matchField(r.Version, version))
+}
+
+// matchField 判断规则中的一个字段是否匹配值。
+func (r *TrustRule) matchField(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	if !r.Regex {
+		return pattern == value
+	}
+	matched, err := regexp.MatchString("^(?:"+pattern+")$", value)
+	return err == nil && matched
+}
+
+// TrustedKey 表示全局信任的PGP密钥。密钥可以直接在属性中限定信任的坐标，
+// 也可以包含多个 <trusting> 规则，二者都没有时信任该密钥签名的所有制品。
+type TrustedKey struct {
+	ID       string       `xml:"id,attr" json:"id"`
+	Group    string       `xml:"group,attr" json:"group,omitempty"`
+	Name     string       `xml:"name,attr" json:"name,omitempty"`
+	Version  string       `xml:"version,attr" json:"version,omitempty"`
+	File     string       `xml:"file,attr" json:"file,omitempty"`
+	Regex    bool         `xml:"regex,attr" json:"regex,omitempty"`
+	Trusting []*TrustRule `xml:"trusting" json:"trusting,omitempty"`
+}
+
+// Rules 返回密钥的所有信任规则，属性中的规则排在最前。
+func (k *TrustedKey) Rules() []*TrustRule {
+	rules := make([]*TrustRule, 0, len(k.Trusting)+1)
+	if k.Group != "" || k.Name != "" || k.Version != "" || k.File != "" || len(k.Trusting) == 0 {
+		rules = append(rules, &TrustRule{Group: k.Group, Name: k.Name, Version: k.Version, File: k.File, Regex: k.Regex})
+	}
+	return append(rules, k.Trusting...)
+}
+
+// VerifiedComponent 表示一个带校验信息的模块版本。
+type VerifiedComponent struct {
+	Group     string              `xml:"group,attr" json:"group"`
+	Name      string              `xml:"name,attr" json:"name"`
+	Version   string              `xml:"version,attr" json:"version"`
+	Artifacts []*VerifiedArtifact `xml:"artifact" json:"artifacts"`
+}
+
+// VerifiedArtifact 表示模块中的一个制品及其校验和。
+type VerifiedArtifact struct {
+	Name      string      `xml:"name,attr" json:"name"`
+	Checksums []*Checksum `xml:",any" json:"checksums"`
+}
+
+// Checksum 表示一个校验和，Algorithm取元素名，例如 sha256、sha512、pgp。
+type Checksum struct {
+	Algorithm string   `json:"algorithm"`
+	Value     string   `json:"value"`
+	Origin    string   `json:"origin,omitempty"`
+	AlsoTrust []string `json:"alsoTrust,omitempty"` // <also-trust> 给出的其他可接受的值。
+}
+
+// UnmarshalXML 从 <sha256 value=".." origin=".."/> 形式的元素中读取校验和。
+func (c *Checksum) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	c.Algorithm = start.Name.Local
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "value":
+			c.Value = attr.Value
+		case "origin":
+			c.Origin = attr.Value
+		}
+	}
+
+	var body struct {
+		AlsoTrust []struct {
+			Value string `xml:"value,attr"`
+		} `xml:"also-trust"`
+	}
+	if err := d.DecodeElement(&body, &start); err != nil {
+		return err
+	}
+	for _, alsoTrust := range body.AlsoTrust {
+		c.AlsoTrust = append(c.AlsoTrust, alsoTrust.Value)
+	}
+	return nil
+}
+
+// ParseVerificationMetadata 解析依赖校验元数据。
+func ParseVerificationMetadata(r io.Reader) (*VerificationMetadata, error) {
+	metadata := &VerificationMetadata{}
+	if err := xml.NewDecoder(r).Decode(metadata); err != nil {
+		return nil, fmt.Errorf("解析依赖校验元数据失败: %w", err)
+	}
+	return metadata, nil
+}
+
+// ParseVerificationMetadataFile 解析指定路径的依赖校验元数据文件。
+func ParseVerificationMetadataFile(filePath string) (*VerificationMetadata, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	metadata, err := ParseVerificationMetadata(file)
+	if err != nil {
+		return nil, err
+	}
+	metadata.FilePath = filePath
+	return metadata, nil
+}
+
+// FindComponent 查找指定坐标的校验记录。
+func (vm *VerificationMetadata) FindComponent(group, name, version string) *VerifiedComponent {
+	for _, component := range vm.Components {
+		if component.Group == group && component.Name == name && component.Version == version {
+			return component
+		}
+	}
+	return nil
+}
+
+// FindComponents 查找指定模块所有版本的校验记录。
+func (vm *VerificationMetadata) FindComponents(group, name string) []*VerifiedComponent {
+	components := make([]*VerifiedComponent, 0)
+	for _, component := range vm.Components {
+		if component.Group == group && component.Name == name {
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// IsTrusted 判断模块版本是否被 trusted-artifacts 规则或（开启签名校验时）全局信任的密钥覆盖，
+// 被覆盖的模块不需要单独的校验记录。version为空时只比较group和name。
+func (vm *VerificationMetadata) IsTrusted(group, name, version string) bool {
+	for _, rule := range vm.TrustedArtifacts {
+		if rule.Matches(group, name, version) {
+			return true
+		}
+	}
+	if !vm.VerifySignatures {
+		return false
+	}
+	for _, key := range vm.TrustedKeys {
+		for _, rule := range key.Rules() {
+			if rule.Matches(group, name, version) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ToLockfile 将校验元数据中记录的模块版本转换为锁定记录。
+// 校验元数据不区分配置，因此转换结果中的Configurations为空。
+func (vm *VerificationMetadata) ToLockfile() *Lockfile {
+	lock := &Lockfile{Dependencies: make([]*LockedDependency, 0), FilePath: vm.FilePath}
+	for _, component := range vm.Components {
+		lock.Dependencies = append(lock.Dependencies, &LockedDependency{
+			Group:          component.Group,
+			Name:           component.Name,
+			Version:        component.Version,
+			Configurations: []string{},
+		})
+	}
+	return lock
+}
+
+// VerificationIssueKind 表示依赖缺少校验信息的类型。
+type VerificationIssueKind string
+
+const (
+	// VerificationMissing 表示依赖的模块没有任何校验记录，也没有被信任规则覆盖。
+	VerificationMissing VerificationIssueKind = "missing"
+	// VerificationVersionMissing 表示模块有其他版本的校验记录，但声明的版本没有。
+	VerificationVersionMissing VerificationIssueKind = "version-missing"
+)
+
+// VerificationIssue 表示一个缺少校验信息的声明依赖。
+type VerificationIssue struct {
+	Dependency       *model.Dependency     `json:"dependency"`
+	Kind             VerificationIssueKind `json:"kind"`
+	VerifiedVersions []string              `json:"verifiedVersions,omitempty"` // 已有校验记录的其他版本。
+}
+
+// CheckVerification 检查项目中声明的依赖是否都有对应的校验记录，开启依赖校验后缺少记录的依赖会导致构建失败。
+// 优先使用解析后的版本；未声明版本或使用动态版本、版本范围的依赖只检查模块是否有任意版本的记录。
+// 项目依赖不检查。
+func CheckVerification(project *model.Project, metadata *VerificationMetadata) []*VerificationIssue {
+	issues := make([]*VerificationIssue, 0)
+	if project == nil || metadata == nil {
+		return issues
+	}
+
+	for _, dep := range project.Dependencies {
+		if dep == nil || dep.Project != nil || dep.Group == "" || dep.Name == "" {
+			continue
+		}
+
+		version := dep.Version
+		if dep.ResolvedVersion != "" {
+			version = dep.ResolvedVersion
+		} else if dep.HasDynamicVersion || strings.HasSuffix(version, "+") || strings.ContainsAny(version, "[]()$") ||
+			strings.HasPrefix(version, "latest.") {
+			version = ""
+		}
+
+		if metadata.IsTrusted(dep.Group, dep.Name, version) {
+			continue
+		}
+		components := metadata.FindComponents(dep.Group, dep.Name)
+		if len(components) == 0 {
+			issues = append(issues, &VerificationIssue{Dependency: dep, Kind: VerificationMissing})
+			continue
+		}
+		if version == "" || metadata.FindComponent(dep.Group, dep.Name, version) != nil {
+			continue
+		}
+
+		verified := make([]string, 0, len(components))
+		for _, component := range components {
+			verified = append(verified, component.Version)
+		}
+		issues = append(issues, &VerificationIssue{
+			Dependency:       dep,
+			Kind:             VerificationVersionMissing,
+			VerifiedVersions: verified,
+		})
+	}
+
+	return issues
+}
@@ -0,0 +1,84 @@
+// Package model 提供版本目录（libs.versions.toml）的数据结构。
+package model
+
+import "strings"
+
+// VersionCatalog 表示解析后的版本目录文件。
+type VersionCatalog struct {
+	Versions  map[string]string   `json:"versions"`
+	Libraries []*CatalogLibrary   `json:"libraries"`
+	Bundles   map[string][]string `json:"bundles,omitempty"` // bundle别名到库别名列表。
+	Plugins   []*CatalogPlugin    `json:"plugins,omitempty"`
+	FilePath  string              `json:"filePath,omitempty"`
+}
+
+// CatalogLibrary 表示版本目录 [libraries] 中的一个库。
+type CatalogLibrary struct {
+	Alias      string `json:"alias"` // 目录中的别名，例如 log4j-core。
+	Group      string `json:"group"`
+	Name       string `json:"name"`
+	Version    string `json:"version,omitempty"`    // 直接声明的版本，富版本取 strictly、require 或 prefer。
+	VersionRef string `json:"versionRef,omitempty"` // version.ref 引用的 [versions] 键。
+	Line       int    `json:"line"`
+}
+
+// CatalogPlugin 表示版本目录 [plugins] 中的一个插件。
+type CatalogPlugin struct {
+	Alias      string `json:"alias"`
+	ID         string `json:"id"`
+	Version    string `json:"version,omitempty"`
+	VersionRef string `json:"versionRef,omitempty"`
+	Line       int    `json:"line"`
+}
+
+// CatalogAccessor 把目录别名转换为构建脚本中的访问路径，别名中的 -、_ 和 . 都对应 .。
+// 例如 log4j-core 对应 libs.log4j.core 中的 log4j.core。
+func CatalogAccessor(alias string) string {
+	return strings.NewReplacer("-", ".", "_", ".").Replace(alias)
+}
+
+// Library 按别名或访问路径（可带 libs. 前缀）查找库，找不到时返回nil。
+func (c *VersionCatalog) Library(accessor string) *CatalogLibrary {
+	if c == nil {
+		return nil
+	}
+	accessor = CatalogAccessor(strings.TrimPrefix(accessor, "libs."))
+	for _, lib := range c.Libraries {
+		if CatalogAccessor(lib.Alias) == accessor {
+			return lib
+		}
+	}
+	return nil
+}
+
+// Bundle 按别名或访问路径（可带 libs.bundles. 前缀）返回bundle中的库，找不到的别名会被忽略。
+func (c *VersionCatalog) Bundle(accessor string) []*CatalogLibrary {
+	if c == nil {
+		return nil
+	}
+	accessor = CatalogAccessor(strings.TrimPrefix(strings.TrimPrefix(accessor, "libs."), "bundles."))
+	for alias, members := range c.Bundles {
+		if CatalogAccessor(alias) != accessor {
+			continue
+		}
+		libs := make([]*CatalogLibrary, 0, len(members))
+		for _, member := range members {
+			if lib := c.Library(member); lib != nil {
+				libs = append(libs, lib)
+			}
+		}
+		return libs
+	}
+	return nil
+}
+
+// ResolveVersion 返回库的版本，通过 version.ref 引用时从 [versions] 中取值。
+func (c *VersionCatalog) ResolveVersion(lib *CatalogLibrary) string {
+	if lib == nil {
+		return ""
+	}
+	if lib.Version != "" || c == nil {
+		return lib.Version
+	}
+	return c.Versions[lib.VersionRef]
+}
@@ -0,0 +1,35 @@
+package model
+
+import "testing"
+
+func TestVersionCatalogLookup(t *testing.T) {
+	catalog := &VersionCatalog{
+		Versions: map[string]string{"log4j": "2.20.0"},
+		Libraries: []*CatalogLibrary{
+			{Alias: "log4j-core", Group: "org.apache.logging.log4j", Name: "log4j-core", VersionRef: "log4j"},
+			{Alias: "guava", Group: "com.google.guava", Name: "guava", Version: "32.1.2-jre"},
+		},
+		Bundles: map[string][]string{"logging-all": {"log4j-core", "missing"}},
+	}
+
+	core := catalog.Library("libs.log4j.core")
+	if core == nil || core != catalog.Library("log4j-core") || core != catalog.Library("log4j_core") {
+		t.Fatalf("Expected log4j-core to be found by accessor and alias, got %+v", core)
+	}
+	if v := catalog.ResolveVersion(core); v != "2.20.0" {
+		t.Errorf("Expected version ref to resolve to 2.20.0, got %s", v)
+	}
+	if v := catalog.ResolveVersion(catalog.Library("guava")); v != "32.1.2-jre" {
+		t.Errorf("Expected inline version, got %s", v)
+	}
+
+	bundle := catalog.Bundle("libs.bundles.logging.all")
+	if len(bundle) != 1 || bundle[0] != core {
+		t.Errorf("Expected bundle to contain log4j-core, got %+v", bundle)
+	}
+
+	var nilCatalog *VersionCatalog
+	if nilCatalog.Library("guava") != nil || nilCatalog.Bundle("logging") != nil {
+		t.Error("Lookups on nil catalog should return nil")
+	}
+}
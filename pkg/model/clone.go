@@ -0,0 +1,104 @@
+// Package model 提供解析结果的深拷贝。
+package model
+
+import "reflect"
+
+// moduleType 是 ProjectRef.Module 的类型。模块属于项目树而不属于单个项目，克隆时不复制。
+var moduleType = reflect.TypeOf((*Module)(nil))
+
+// errorType 是 error 接口的类型，错误值被视为不可变，克隆时不复制。
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// CloneProject 返回项目的深拷贝，修改拷贝不会影响原项目。
+// 原项目中多处引用同一个对象时，拷贝中也引用同一个新对象；
+// ProjectRef.Module 指向项目树中的模块，拷贝仍指向原模块。
+func CloneProject(project *Project) *Project {
+	return deepCopy(project)
+}
+
+// CloneParseResult 返回解析结果的深拷贝，包括项目、警告和脚本块结构树，Errors中的错误值被共享。
+func CloneParseResult(result *ParseResult) *ParseResult {
+	return deepCopy(result)
+}
+
+// CloneSourceMappedProject 返回带位置信息的项目的深拷贝。
+// 编辑器会原地修改项目中的依赖等组件，需要保留原项目时先克隆再创建编辑器。
+// 位置信息中的依赖与项目的 Dependencies 共享对象时，拷贝中同样共享。
+func CloneSourceMappedProject(project *SourceMappedProject) *SourceMappedProject {
+	return deepCopy(project)
+}
+
+// CloneSourceMappedParseResult 返回带位置信息的解析结果的深拷贝。
+func CloneSourceMappedParseResult(result *SourceMappedParseResult) *SourceMappedParseResult {
+	return deepCopy(result)
+}
+
+// pointerKey 标识一个已复制的指针。
+type pointerKey struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// deepCopy 递归复制value引用的所有指针、切片和map。
+func deepCopy[T any](value T) T {
+	src := reflect.ValueOf(&value).Elem()
+	dst := reflect.New(src.Type()).Elem()
+	copyValue(dst, src, make(map[pointerKey]reflect.Value))
+	return dst.Interface().(T)
+}
+
+// copyValue 把src深拷贝到dst。copied记录已复制的指针，用于保持共享引用和处理父子之间的循环引用。
+func copyValue(dst, src reflect.Value, copied map[pointerKey]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() || src.Type() == moduleType {
+			dst.Set(src)
+			return
+		}
+		key := pointerKey{addr: src.Pointer(), typ: src.Type()}
+		if existing, ok := copied[key]; ok {
+			dst.Set(existing)
+			return
+		}
+		ptr := reflect.New(src.Type().Elem())
+		copied[key] = ptr
+		copyValue(ptr.Elem(), src.Elem(), copied)
+		dst.Set(ptr)
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				copyValue(dst.Field(i), src.Field(i), copied)
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i), copied)
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		iter := src.MapRange()
+		for iter.Next() {
+			elem := reflect.New(src.Type().Elem()).Elem()
+			copyValue(elem, iter.Value(), copied)
+			dst.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.Interface:
+		if src.IsNil() || src.Type() == errorType {
+			dst.Set(src)
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		copyValue(elem, src.Elem(), copied)
+		dst.Set(elem)
+	default:
+		dst.Set(src)
+	}
+}
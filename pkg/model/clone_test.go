@@ -0,0 +1,101 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCloneProject(t *testing.T) {
+	module := &Module{Path: ":core"}
+	dep := &Dependency{Group: "org.foo", Name: "bar", Version: "1.0", Licenses: []string{"MIT"}}
+	project := &Project{
+		Name:         "app",
+		Properties:   map[string]string{"kotlinVersion": "1.9.0"},
+		Dependencies: []*Dependency{dep, {Name: "core", Project: &ProjectRef{Path: ":core", Module: module}}},
+		Plugins:      []*Plugin{{ID: "java", Config: map[string]interface{}{"tags": []interface{}{"a"}}}},
+		Extensions:   map[string]any{"jib": map[string]any{"image": "app"}},
+		SubProjects:  []*Project{{Name: "lib"}},
+		PropertyExpressions: map[string]*Expression{
+			"version": {Kind: ExpressionReference, Path: []string{"rootProject", "version"}},
+		},
+	}
+
+	clone := CloneProject(project)
+	if !reflect.DeepEqual(clone, project) {
+		t.Fatal("Clone is not equal to the original project")
+	}
+
+	clone.Dependencies[0].Version = "2.0"
+	clone.Dependencies[0].Licenses[0] = "Apache-2.0"
+	clone.Properties["kotlinVersion"] = "2.0.0"
+	clone.Plugins[0].Config["tags"].([]interface{})[0] = "b"
+	clone.Extensions["jib"].(map[string]any)["image"] = "other"
+	clone.SubProjects[0].Name = "other"
+	clone.PropertyExpressions["version"].Path[0] = "project"
+
+	if dep.Version != "1.0" || dep.Licenses[0] != "MIT" || project.Properties["kotlinVersion"] != "1.9.0" ||
+		project.Plugins[0].Config["tags"].([]interface{})[0] != "a" ||
+		project.Extensions["jib"].(map[string]any)["image"] != "app" ||
+		project.SubProjects[0].Name != "lib" || project.PropertyExpressions["version"].Path[0] != "rootProject" {
+		t.Error("Modifying the clone changed the original project")
+	}
+
+	// 模块属于项目树，克隆后仍指向原模块。
+	if clone.Dependencies[1].Project == project.Dependencies[1].Project || clone.Dependencies[1].Project.Module != module {
+		t.Error("Expected project reference to be copied and module to be shared")
+	}
+
+	if CloneProject(nil) != nil {
+		t.Error("CloneProject(nil) should return nil")
+	}
+}
+
+func TestCloneParseResult(t *testing.T) {
+	root := &ScriptBlock{Name: "root"}
+	child := &ScriptBlock{Name: "android", Parent: root, Values: map[string]interface{}{"compileSdk": "34"}}
+	root.Children = []*ScriptBlock{child}
+	errBad := errors.New("bad block")
+
+	result := &ParseResult{
+		Project:   &Project{Name: "app"},
+		Errors:    []error{errBad},
+		Warnings:  []*Diagnostic{{Code: DiagnosticJCenterRepository}},
+		RootBlock: root,
+	}
+
+	clone := CloneParseResult(result)
+	if clone.RootBlock == root || clone.RootBlock.Children[0] == child {
+		t.Fatal("Expected script blocks to be copied")
+	}
+	if clone.RootBlock.Children[0].Parent != clone.RootBlock {
+		t.Error("Expected parent of copied block to be the copied root")
+	}
+	if clone.Errors[0] != errBad {
+		t.Error("Expected errors to be shared")
+	}
+
+	clone.Warnings[0].Code = DiagnosticDynamicVersion
+	clone.RootBlock.Children[0].Values["compileSdk"] = "35"
+	if result.Warnings[0].Code != DiagnosticJCenterRepository || child.Values["compileSdk"] != "34" {
+		t.Error("Modifying the clone changed the original result")
+	}
+}
+
+func TestCloneSourceMappedProject(t *testing.T) {
+	dep := &Dependency{Group: "org.foo", Name: "bar", Version: "1.0"}
+	project := &SourceMappedProject{
+		Project:                  &Project{Dependencies: []*Dependency{dep}},
+		SourceMappedDependencies: []*SourceMappedDependency{{Dependency: dep, RawText: "'org.foo:bar:1.0'"}},
+	}
+
+	clone := CloneSourceMappedProject(project)
+	if clone.SourceMappedDependencies[0].Dependency != clone.Dependencies[0] {
+		t.Error("Expected shared dependency to stay shared in the clone")
+	}
+
+	clone.SourceMappedDependencies[0].Version = "2.0"
+	if dep.Version != "1.0" {
+		t.Error("Modifying the clone changed the original dependency")
+	}
+}
@@ -0,0 +1,22 @@
+// Package model 提供依赖约束的数据结构。
+package model
+
+// DependencyConstraint 表示 dependencies { constraints { } } 中的一条依赖约束，
+// 例如 implementation('org.foo:bar') { version { strictly '1.2.3' } }。
+type DependencyConstraint struct {
+	Group string `json:"group"`
+	Name  string `json:"name"`
+	Scope string `json:"scope"` // 声明约束的配置，例如 implementation、api。
+
+	// Version 是约束生效的版本：坐标中给出的版本，没有时依次取 strictly、require、prefer。
+	Version string `json:"version"`
+
+	// 配置闭包 version { } 中的富版本声明。
+	Strictly string   `json:"strictly,omitempty"`
+	Require  string   `json:"require,omitempty"`
+	Prefer   string   `json:"prefer,omitempty"`
+	Reject   []string `json:"reject,omitempty"`
+
+	Because string `json:"because,omitempty"` // because 给出的约束原因。
+	Raw     string `json:"raw"`               // 原始声明，包括配置闭包。
+}
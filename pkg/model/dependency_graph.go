@@ -0,0 +1,207 @@
+// Package model 提供依赖图的数据结构和分析方法。
+package model
+
+import "sort"
+
+// DependencyNodeKind 表示依赖图节点的类型。
+type DependencyNodeKind string
+
+const (
+	DependencyNodeModule   DependencyNodeKind = "module"   // 构建中的模块，包括 project(':x') 依赖指向的模块。
+	DependencyNodeExternal DependencyNodeKind = "external" // 外部依赖坐标。
+)
+
+// DependencyGraphNode 表示依赖图中的一个节点。
+type DependencyGraphNode struct {
+	ID      string             `json:"id"` // 模块为项目路径，外部依赖为 group:name[:version]。
+	Kind    DependencyNodeKind `json:"kind"`
+	Group   string             `json:"group,omitempty"`
+	Name    string             `json:"name,omitempty"`
+	Version string             `json:"version,omitempty"`
+
+	// Direct 表示外部依赖由某个模块直接声明。
+	Direct bool `json:"direct,omitempty"`
+
+	// Placeholder 表示外部依赖的传递依赖未知：没有提供解析器，或解析失败。
+	// 解析器返回空列表表示依赖确实没有传递依赖，此时不是占位节点。
+	Placeholder bool `json:"placeholder,omitempty"`
+
+	// Error 是解析传递依赖失败时的错误信息。
+	Error string `json:"error,omitempty"`
+}
+
+// DependencyGraphEdge 表示依赖图中从依赖方指向被依赖方的一条边。
+type DependencyGraphEdge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Scope string `json:"scope,omitempty"` // 声明的配置，传递依赖的边为解析器给出的配置。
+}
+
+// DependencyGraph 表示模块、声明的依赖及其传递依赖组成的有向图。
+type DependencyGraph struct {
+	Nodes []*DependencyGraphNode `json:"nodes"`
+	Edges []*DependencyGraphEdge `json:"edges"`
+}
+
+// DependencyGraphStats 是依赖图的统计信息。
+type DependencyGraphStats struct {
+	Modules      int `json:"modules"`
+	Direct       int `json:"direct"`       // 直接声明的外部依赖数量。
+	Transitive   int `json:"transitive"`   // 只通过传递依赖引入的外部依赖数量。
+	Placeholders int `json:"placeholders"` // 传递依赖未知的外部依赖数量。
+	MaxDepth     int `json:"maxDepth"`     // 从模块出发的最长最短路径，直接依赖为1。
+
+	// DepthCounts 是每个深度上的外部依赖数量。
+	DepthCounts map[int]int `json:"depthCounts"`
+}
+
+// Node 按ID查找节点，找不到时返回nil。
+func (g *DependencyGraph) Node(id string) *DependencyGraphNode {
+	for _, node := range g.Nodes {
+		if node.ID == id {
+			return node
+		}
+	}
+	return nil
+}
+
+// Successors 返回节点直接依赖的节点ID，按边的顺序排列且不重复。
+func (g *DependencyGraph) Successors(id string) []string {
+	return g.adjacency()[id]
+}
+
+// adjacency 返回每个节点的后继节点列表。
+func (g *DependencyGraph) adjacency() map[string][]string {
+	adjacency := make(map[string][]string, len(g.Nodes))
+	seen := make(map[[2]string]bool, len(g.Edges))
+	for _, edge := range g.Edges {
+		key := [2]string{edge.From, edge.To}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		adjacency[edge.From] = append(adjacency[edge.From], edge.To)
+	}
+	return adjacency
+}
+
+// Depths 返回每个外部依赖到最近模块的距离，直接依赖为1，结果中不包含模块。
+func (g *DependencyGraph) Depths() map[string]int {
+	adjacency := g.adjacency()
+	depths := make(map[string]int, len(g.Nodes))
+	queue := make([]string, 0, len(g.Nodes))
+	for _, node := range g.Nodes {
+		if node.Kind == DependencyNodeModule {
+			depths[node.ID] = 0
+			queue = append(queue, node.ID)
+		}
+	}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range adjacency[id] {
+			if _, ok := depths[next]; ok {
+				continue
+			}
+			depths[next] = depths[id] + 1
+			queue = append(queue, next)
+		}
+	}
+
+	for _, node := range g.Nodes {
+		if node.Kind == DependencyNodeModule {
+			delete(depths, node.ID)
+		}
+	}
+	return depths
+}
+
+// Stats 返回依赖图的统计信息。
+func (g *DependencyGraph) Stats() DependencyGraphStats {
+	stats := DependencyGraphStats{DepthCounts: make(map[int]int)}
+	for _, node := range g.Nodes {
+		switch {
+		case node.Kind == DependencyNodeModule:
+			stats.Modules++
+		case node.Direct:
+			stats.Direct++
+		default:
+			stats.Transitive++
+		}
+		if node.Placeholder {
+			stats.Placeholders++
+		}
+	}
+	for _, depth := range g.Depths() {
+		stats.DepthCounts[depth]++
+		if depth > stats.MaxDepth {
+			stats.MaxDepth = depth
+		}
+	}
+	return stats
+}
+
+// Cycles 返回图中的环，每个环是一个强连通分量中的节点ID，按节点在图中的顺序排列。
+// 只有一个节点的分量仅在节点依赖自身时才视为环。
+func (g *DependencyGraph) Cycles() [][]string {
+	adjacency := g.adjacency()
+	order := make(map[string]int, len(g.Nodes))
+	for i, node := range g.Nodes {
+		order[node.ID] = i
+	}
+
+	// Tarjan强连通分量算法。
+	index := make(map[string]int, len(g.Nodes))
+	lowLink := make(map[string]int, len(g.Nodes))
+	onStack := make(map[string]bool)
+	stack := make([]string, 0)
+	cycles := make([][]string, 0)
+
+	var visit func(id string)
+	visit = func(id string) {
+		index[id] = len(index)
+		lowLink[id] = index[id]
+		stack = append(stack, id)
+		onStack[id] = true
+
+		selfLoop := false
+		for _, next := range adjacency[id] {
+			if next == id {
+				selfLoop = true
+			}
+			if _, visited := index[next]; !visited {
+				visit(next)
+				lowLink[id] = min(lowLink[id], lowLink[next])
+			} else if onStack[next] {
+				lowLink[id] = min(lowLink[id], index[next])
+			}
+		}
+
+		if lowLink[id] != index[id] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop {
+			sort.Slice(component, func(i, j int) bool { return order[component[i]] < order[component[j]] })
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, node := range g.Nodes {
+		if _, visited := index[node.ID]; !visited {
+			visit(node.ID)
+		}
+	}
+	sort.SliceStable(cycles, func(i, j int) bool { return order[cycles[i][0]] < order[cycles[j][0]] })
+	return cycles
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	g := &DependencyGraph{
+		Nodes: []*DependencyGraphNode{
+			{ID: ":", Kind: DependencyNodeModule},
+			{ID: ":core", Kind: DependencyNodeModule},
+			{ID: "org.foo:a:1.0", Kind: DependencyNodeExternal, Direct: true},
+			{ID: "org.foo:b:1.0", Kind: DependencyNodeExternal},
+			{ID: "org.foo:c:1.0", Kind: DependencyNodeExternal, Placeholder: true},
+			{ID: "org.foo:d:1.0", Kind: DependencyNodeExternal, Direct: true},
+		},
+		Edges: []*DependencyGraphEdge{
+			{From: ":", To: ":core", Scope: "implementation"},
+			{From: ":", To: "org.foo:a:1.0", Scope: "implementation"},
+			{From: ":", To: "org.foo:a:1.0", Scope: "testImplementation"},
+			{From: "org.foo:a:1.0", To: "org.foo:b:1.0"},
+			{From: "org.foo:b:1.0", To: "org.foo:c:1.0"},
+			{From: "org.foo:c:1.0", To: "org.foo:a:1.0"},
+			{From: ":core", To: "org.foo:d:1.0"},
+			{From: "org.foo:d:1.0", To: "org.foo:d:1.0"},
+		},
+	}
+
+	if g.Node("org.foo:b:1.0") != g.Nodes[3] || g.Node("missing") != nil {
+		t.Error("Unexpected Node lookup result")
+	}
+	if got := g.Successors(":"); !reflect.DeepEqual(got, []string{":core", "org.foo:a:1.0"}) {
+		t.Errorf("Unexpected successors %v", got)
+	}
+
+	wantDepths := map[string]int{"org.foo:a:1.0": 1, "org.foo:b:1.0": 2, "org.foo:c:1.0": 3, "org.foo:d:1.0": 1}
+	if got := g.Depths(); !reflect.DeepEqual(got, wantDepths) {
+		t.Errorf("Depths() = %v, want %v", got, wantDepths)
+	}
+
+	stats := g.Stats()
+	wantStats := DependencyGraphStats{
+		Modules: 2, Direct: 2, Transitive: 2, Placeholders: 1, MaxDepth: 3,
+		DepthCounts: map[int]int{1: 2, 2: 1, 3: 1},
+	}
+	if !reflect.DeepEqual(stats, wantStats) {
+		t.Errorf("Stats() = %+v, want %+v", stats, wantStats)
+	}
+
+	wantCycles := [][]string{{"org.foo:a:1.0", "org.foo:b:1.0", "org.foo:c:1.0"}, {"org.foo:d:1.0"}}
+	if got := g.Cycles(); !reflect.DeepEqual(got, wantCycles) {
+		t.Errorf("Cycles() = %v, want %v", got, wantCycles)
+	}
+}
@@ -0,0 +1,47 @@
+// Package model 提供settings文件中依赖解析管理配置的数据结构。
+package model
+
+// 依赖仓库模式，对应 dependencyResolutionManagement 中的 repositoriesMode。
+const (
+	RepositoriesModePreferProject      = "PREFER_PROJECT"
+	RepositoriesModePreferSettings     = "PREFER_SETTINGS"
+	RepositoriesModeFailOnProjectRepos = "FAIL_ON_PROJECT_REPOS"
+)
+
+// DependencyResolutionManagement 表示settings文件中的 dependencyResolutionManagement { } 配置。
+type DependencyResolutionManagement struct {
+	// RepositoriesMode 是 repositoriesMode 设置的模式，未设置时为空，Gradle按 PREFER_PROJECT 处理。
+	RepositoriesMode string              `json:"repositoriesMode,omitempty"`
+	Repositories     []*Repository       `json:"repositories"`              // 集中声明的依赖仓库。
+	VersionCatalogs  []*CatalogReference `json:"versionCatalogs,omitempty"` // versionCatalogs { } 中声明的版本目录。
+}
+
+// CatalogReference 表示 versionCatalogs { } 中声明的一个版本目录，例如 libs { from(files(...)) }。
+type CatalogReference struct {
+	Name string `json:"name"`           // 访问名称，例如 libs。
+	From string `json:"from,omitempty"` // from(...) 的来源：toml文件路径或发布的版本目录坐标。
+
+	// Catalog 保存直接在settings中声明的 version、library、bundle 和 plugin，没有时为nil。
+	Catalog *VersionCatalog `json:"catalog,omitempty"`
+}
+
+// Mode 返回生效的仓库模式，未设置时为 PREFER_PROJECT。
+func (m *DependencyResolutionManagement) Mode() string {
+	if m == nil || m.RepositoriesMode == "" {
+		return RepositoriesModePreferProject
+	}
+	return m.RepositoriesMode
+}
+
+// EffectiveRepositories 按仓库模式返回项目解析依赖时实际使用的仓库：
+// PREFER_PROJECT 下项目声明了仓库时使用项目的仓库，否则使用settings中的仓库；
+// PREFER_SETTINGS 和 FAIL_ON_PROJECT_REPOS 下总是使用settings中的仓库（后者在项目声明仓库时构建失败）。
+func (m *DependencyResolutionManagement) EffectiveRepositories(projectRepos []*Repository) []*Repository {
+	if m == nil {
+		return projectRepos
+	}
+	if m.Mode() == RepositoriesModePreferProject && len(projectRepos) > 0 {
+		return projectRepos
+	}
+	return m.Repositories
+}
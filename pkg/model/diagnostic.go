@@ -0,0 +1,50 @@
+// Package model 提供解析诊断相关的数据结构。
+package model
+
+import "fmt"
+
+// Severity 表示诊断的严重程度。
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// 内置的诊断代码。
+const (
+	DiagnosticParseError              = "parse-error"
+	DiagnosticDeprecatedConfiguration = "deprecated-configuration"
+	DiagnosticJCenterRepository       = "jcenter-repository"
+	DiagnosticInsecureRepository      = "insecure-repository"
+	DiagnosticDynamicVersion          = "dynamic-version"
+	DiagnosticUnbalancedBrace         = "unbalanced-brace"
+	DiagnosticUnterminatedString      = "unterminated-string"
+	DiagnosticUnterminatedComment     = "unterminated-comment"
+	DiagnosticDuplicatePlugin         = "duplicate-plugin"
+	DiagnosticDuplicateDependency     = "duplicate-dependency"
+	DiagnosticEmptyBlock              = "empty-block"
+	DiagnosticInvalidCoordinate       = "invalid-coordinate"
+
+	// Android Gradle插件（AGP）与compileSdk、Kotlin插件和Gradle版本的兼容性问题。
+	DiagnosticAndroidCompileSdk    = "android-compile-sdk"
+	DiagnosticAndroidKotlinVersion = "android-kotlin-version"
+	DiagnosticAndroidGradleVersion = "android-gradle-version"
+
+	// DiagnosticConfigurationCachePrefix 后接 ConfigCache* 写法构成诊断代码，例如 configuration-cache-system-property。
+	DiagnosticConfigurationCachePrefix = "configuration-cache-"
+)
+
+// Diagnostic 表示解析过程中发现的一个问题。
+type Diagnostic struct {
+	Code        string      `json:"code"`
+	Severity    Severity    `json:"severity"`
+	Message     string      `json:"message"`
+	SourceRange SourceRange `json:"sourceRange"`
+}
+
+// String 返回诊断的字符串表示，例如 "line 3, col 5: warning[jcenter-repository] ..."。
+func (d *Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s[%s] %s", d.SourceRange.Start.String(), d.Severity, d.Code, d.Message)
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestDiagnostic_String(t *testing.T) {
+	diag := &Diagnostic{
+		Code:        DiagnosticJCenterRepository,
+		Severity:    SeverityWarning,
+		Message:     "jcenter is deprecated",
+		SourceRange: SourceRange{Start: SourcePosition{Line: 3, Column: 5}},
+	}
+
+	expected := "line 3, col 5: warning[jcenter-repository] jcenter is deprecated"
+	if diag.String() != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, diag.String())
+	}
+}
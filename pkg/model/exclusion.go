@@ -0,0 +1,39 @@
+// Package model 提供配置级依赖排除规则的数据结构。
+package model
+
+// ExclusionRule 表示在配置上声明的传递依赖排除规则，例如 configurations.all { exclude group: 'commons-logging' }。
+// Group 和 Module 中为空的一项匹配任意值。
+type ExclusionRule struct {
+	// Configuration 是规则所属的配置，为空表示作用于所有配置（configurations.all、configureEach、all*.exclude）。
+	Configuration string `json:"configuration,omitempty"`
+	Group         string `json:"group,omitempty"`
+	Module        string `json:"module,omitempty"`
+	Raw           string `json:"raw"` // 原始exclude语句。
+}
+
+// Matches 判断规则是否排除指定的制品。
+func (r *ExclusionRule) Matches(group, module string) bool {
+	if r.Group != "" && r.Group != group {
+		return false
+	}
+	return r.Module == "" || r.Module == module
+}
+
+// AppliesTo 判断规则是否作用于指定配置。只比较配置名称，不考虑配置之间的继承关系。
+func (r *ExclusionRule) AppliesTo(configuration string) bool {
+	return r.Configuration == "" || r.Configuration == configuration
+}
+
+// ExcludedBy 返回排除该依赖的第一条规则，依赖没有被排除时返回nil。
+// 规则按依赖的Scope匹配配置，只比较配置名称。
+func (p *Project) ExcludedBy(dep *Dependency) *ExclusionRule {
+	if dep == nil {
+		return nil
+	}
+	for _, rule := range p.Exclusions {
+		if rule.AppliesTo(dep.Scope) && rule.Matches(dep.Group, dep.Name) {
+			return rule
+		}
+	}
+	return nil
+}
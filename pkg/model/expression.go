@@ -0,0 +1,75 @@
+// Package model 提供属性值表达式的数据结构。
+package model
+
+import "strings"
+
+// ExpressionKind 表示表达式节点的类型。
+type ExpressionKind string
+
+const (
+	ExpressionLiteral   ExpressionKind = "literal"   // 字符串、数字、布尔或null字面量。
+	ExpressionReference ExpressionKind = "reference" // 属性引用，例如 rootProject.version。
+	ExpressionGString   ExpressionKind = "gstring"   // 含插值的字符串，例如 "${project.name}.jar"。
+	ExpressionCall      ExpressionKind = "call"      // 方法调用，例如 findProperty('x')。
+	ExpressionBinary    ExpressionKind = "binary"    // 二元运算，例如 a + b、a ?: b。
+	ExpressionRaw       ExpressionKind = "raw"       // 无法识别的表达式，只保留原始文本。
+)
+
+// Expression 表示属性值的表达式树。
+//   - literal: Value 为去除引号和转义后的值。
+//   - reference: Path 为引用路径；Receiver 非空时引用的是 Receiver 上的成员 Value。
+//   - gstring: Parts 依次为字面量片段和插值表达式。
+//   - call: Value 为方法名，Receiver 非空时为调用对象，Parts 为参数。
+//   - binary: Operator 为运算符，Parts 为左右操作数。
+type Expression struct {
+	Kind     ExpressionKind `json:"kind"`
+	Raw      string         `json:"raw"` // 原始文本。
+	Value    string         `json:"value,omitempty"`
+	Path     []string       `json:"path,omitempty"`
+	Operator string         `json:"operator,omitempty"`
+	Receiver *Expression    `json:"receiver,omitempty"`
+	Parts    []*Expression  `json:"parts,omitempty"`
+}
+
+// IsLiteral 判断表达式是否为字面量。
+func (e *Expression) IsLiteral() bool {
+	return e != nil && e.Kind == ExpressionLiteral
+}
+
+// String 返回表达式的原始文本。
+func (e *Expression) String() string {
+	if e == nil {
+		return ""
+	}
+	return e.Raw
+}
+
+// References 返回表达式中引用的所有属性路径，按出现顺序排列并去重。
+// 例如 "${project.name}-${version}" 返回 project.name 和 version。
+func (e *Expression) References() []string {
+	refs := make([]string, 0)
+	seen := make(map[string]bool)
+	e.walk(func(node *Expression) {
+		if node.Kind != ExpressionReference || node.Receiver != nil {
+			return
+		}
+		ref := strings.Join(node.Path, ".")
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	})
+	return refs
+}
+
+// walk 按先序遍历表达式树。
+func (e *Expression) walk(visit func(node *Expression)) {
+	if e == nil {
+		return
+	}
+	visit(e)
+	e.Receiver.walk(visit)
+	for _, part := range e.Parts {
+		part.walk(visit)
+	}
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpression_References(t *testing.T) {
+	expr := &Expression{
+		Kind: ExpressionGString,
+		Raw:  `"${project.name}-${version}-${project.name}"`,
+		Parts: []*Expression{
+			{Kind: ExpressionReference, Raw: "project.name", Path: []string{"project", "name"}},
+			{Kind: ExpressionLiteral, Raw: "-", Value: "-"},
+			{Kind: ExpressionReference, Raw: "version", Path: []string{"version"}},
+			{Kind: ExpressionLiteral, Raw: "-", Value: "-"},
+			{Kind: ExpressionReference, Raw: "project.name", Path: []string{"project", "name"}},
+			{
+				Kind:     ExpressionReference,
+				Raw:      "foo().bar",
+				Value:    "bar",
+				Receiver: &Expression{Kind: ExpressionCall, Raw: "foo()", Value: "foo"},
+			},
+		},
+	}
+
+	want := []string{"project.name", "version"}
+	if got := expr.References(); !reflect.DeepEqual(got, want) {
+		t.Errorf("References() = %v, want %v", got, want)
+	}
+	if expr.IsLiteral() {
+		t.Error("Expected GString not to be literal")
+	}
+	if expr.String() != expr.Raw {
+		t.Errorf("String() = %q, want %q", expr.String(), expr.Raw)
+	}
+
+	var nilExpr *Expression
+	if nilExpr.IsLiteral() || nilExpr.String() != "" || len(nilExpr.References()) != 0 {
+		t.Error("Expected nil expression to be empty")
+	}
+}
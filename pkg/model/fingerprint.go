@@ -0,0 +1,56 @@
+// Package model 提供构建特性指纹的数据模型。
+package model
+
+// 对配置缓存敏感的写法。
+const (
+	ConfigCacheSystemProperty     = "system-property"      // 配置阶段调用 System.getProperty。
+	ConfigCacheEnvironment        = "environment-variable" // 配置阶段调用 System.getenv。
+	ConfigCacheBuildDirAssignment = "build-dir-assignment" // 直接给 buildDir 赋值。
+	ConfigCacheProjectAtExecution = "project-at-execution" // 在 doFirst/doLast 中访问 project。
+	ConfigCacheBuildListener      = "build-listener"       // gradle.buildFinished 等构建监听器。
+)
+
+// Fingerprint 汇总构建使用的Gradle特性，用于大规模的构建清点分析。
+type Fingerprint struct {
+	KotlinDSL                   bool `json:"kotlinDsl"`                   // 使用 .gradle.kts 脚本。
+	VersionCatalog              bool `json:"versionCatalog"`              // 使用版本目录（libs.versions.toml 或 libs.xxx 引用）。
+	BuildSrc                    bool `json:"buildSrc"`                    // 构建根目录下存在 buildSrc。
+	CompositeBuild              bool `json:"compositeBuild"`              // 通过 includeBuild 组合构建。
+	Toolchains                  bool `json:"toolchains"`                  // 配置了Java工具链。
+	ConfigurationCacheSensitive bool `json:"configurationCacheSensitive"` // 存在对配置缓存敏感的写法。
+
+	IncludedBuilds               []string `json:"includedBuilds,omitempty"`               // includeBuild 引入的构建路径。
+	ConfigurationCacheConstructs []string `json:"configurationCacheConstructs,omitempty"` // 检测到的 ConfigCache* 写法。
+}
+
+// Merge 合并另一个指纹，任一方使用的特性都视为已使用。
+func (f *Fingerprint) Merge(other *Fingerprint) {
+	if other == nil {
+		return
+	}
+	f.KotlinDSL = f.KotlinDSL || other.KotlinDSL
+	f.VersionCatalog = f.VersionCatalog || other.VersionCatalog
+	f.BuildSrc = f.BuildSrc || other.BuildSrc
+	f.CompositeBuild = f.CompositeBuild || other.CompositeBuild
+	f.Toolchains = f.Toolchains || other.Toolchains
+	f.ConfigurationCacheSensitive = f.ConfigurationCacheSensitive || other.ConfigurationCacheSensitive
+	f.IncludedBuilds = appendUnique(f.IncludedBuilds, other.IncludedBuilds...)
+	f.ConfigurationCacheConstructs = appendUnique(f.ConfigurationCacheConstructs, other.ConfigurationCacheConstructs...)
+}
+
+// appendUnique 追加切片中尚不存在的值。
+func appendUnique(values []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+		for _, v := range values {
+			if v == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, item)
+		}
+	}
+	return values
+}
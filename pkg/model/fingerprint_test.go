@@ -0,0 +1,16 @@
+package model
+
+import "testing"
+
+func TestFingerprintMerge(t *testing.T) {
+	f := &Fingerprint{KotlinDSL: true, IncludedBuilds: []string{"a"}}
+	f.Merge(&Fingerprint{Toolchains: true, IncludedBuilds: []string{"a", "b"}, ConfigurationCacheConstructs: []string{ConfigCacheEnvironment}})
+	f.Merge(nil)
+
+	if !f.KotlinDSL || !f.Toolchains || f.BuildSrc {
+		t.Errorf("Unexpected flags after merge: %+v", f)
+	}
+	if len(f.IncludedBuilds) != 2 || len(f.ConfigurationCacheConstructs) != 1 {
+		t.Errorf("Unexpected lists after merge: %+v", f)
+	}
+}
@@ -14,6 +14,10 @@ type Project struct {
 	TargetCompatibility string            `json:"targetCompatibility"`
 	Properties          map[string]string `json:"properties"`
 
+	// PropertyExpressions 保存值不是字面量的属性赋值（包括group、version等基本属性）的表达式树，
+	// 例如 version = rootProject.version。对应的字符串值仍保存在原字段中。
+	PropertyExpressions map[string]*Expression `json:"propertyExpressions,omitempty"`
+
 	// 核心组件。
 	Plugins      []*Plugin      `json:"plugins"`
 	Dependencies []*Dependency  `json:"dependencies"`
@@ -22,6 +26,33 @@ type Project struct {
 	Tasks        []*Task        `json:"tasks"`
 	Extensions   map[string]any `json:"extensions"`
 
+	// dependencies { constraints { } } 中声明的依赖约束，约束不会出现在Dependencies中。
+	Constraints []*DependencyConstraint `json:"constraints,omitempty"`
+
+	// configurations 中声明的传递依赖排除规则，例如 configurations.all { exclude group: 'commons-logging' }。
+	Exclusions []*ExclusionRule `json:"exclusions,omitempty"`
+
+	// settings文件中的插件管理配置，没有pluginManagement块时为nil。
+	PluginManagement *PluginManagement `json:"pluginManagement,omitempty"`
+
+	// settings文件中的依赖解析管理配置，没有dependencyResolutionManagement块时为nil。
+	DependencyResolutionManagement *DependencyResolutionManagement `json:"dependencyResolutionManagement,omitempty"`
+
+	// 初始化脚本中的配置，只有解析初始化脚本时才不为nil。
+	InitScript *InitScript `json:"initScript,omitempty"`
+
+	// sourceSets { } 和 android { sourceSets { } } 中声明的源码集，按首次出现的顺序排列。
+	SourceSets []*SourceSet `json:"sourceSets,omitempty"`
+
+	// 测试任务配置，没有任何test配置时为nil。
+	TestConfig *TestConfig `json:"testConfig,omitempty"`
+
+	// springBoot扩展和bootJar任务的配置，没有相关配置时为nil。
+	SpringBoot *SpringBootConfig `json:"springBoot,omitempty"`
+
+	// 从脚本内容中检测到的Gradle特性，文件系统相关的特性由 api.Fingerprint 补充。
+	Features *Fingerprint `json:"features,omitempty"`
+
 	// 原始文件路径。
 	FilePath string `json:"filePath"`
 }
@@ -34,6 +65,47 @@ type Dependency struct {
 	Scope      string `json:"scope"` // implementation, api, testImplementation, etc.
 	Transitive bool   `json:"transitive"`
 	Raw        string `json:"raw"` // 原始依赖声明。
+
+	// BaseScope 和 Variant 是Android变体配置拆分出的基础配置和变体名称，
+	// 例如 freeDebugImplementation 拆分为 implementation 和 freeDebug；不是变体配置时都为空。
+	BaseScope string `json:"baseScope,omitempty"`
+	Variant   string `json:"variant,omitempty"`
+
+	// Classifier 和 Extension 是坐标中的分类器和制品扩展名，
+	// 例如 'net.sf.json-lib:json-lib:2.4:jdk15' 和 'org.foo:bar:1.0@zip'。
+	Classifier string `json:"classifier,omitempty"`
+	Extension  string `json:"extension,omitempty"`
+
+	// HasDynamicVersion 表示版本（或整个坐标）由变量插值给出，例如 "org.foo:bar:$barVersion"，
+	// 此时Version保存原始表达式。
+	HasDynamicVersion bool `json:"hasDynamicVersion,omitempty"`
+
+	// ResolvedVersion 是用ext或gradle.properties中的属性替换变量后的版本，仅在开启变量解析且全部引用都能解析时设置。
+	ResolvedVersion string `json:"resolvedVersion,omitempty"`
+
+	// ResolvedFrom 记录版本的来源，仅在版本由BOM或dependencyManagement补全时设置。
+	ResolvedFrom string `json:"resolvedFrom,omitempty"`
+
+	// Licenses 是依赖的许可证，优先使用SPDX ID，仅在许可证补全后设置。
+	Licenses []string `json:"licenses,omitempty"`
+
+	// Project 是 project(':app') 形式的项目依赖引用，其他依赖为nil。
+	Project *ProjectRef `json:"project,omitempty"`
+
+	// DeclaredAtLine 是声明所在的行号（从1开始），仅在开启行号跟踪时设置。
+	DeclaredAtLine int `json:"declaredAtLine,omitempty"`
+
+	// Origin 是声明所在的文件和块，仅在开启来源跟踪（包括项目模式解析）时设置。
+	Origin *Origin `json:"origin,omitempty"`
+}
+
+// ProjectRef 表示对构建中另一个项目的依赖，例如 project(path: ':app', configuration: 'shadow')。
+type ProjectRef struct {
+	Path          string `json:"path"`                    // 项目路径，按原样保存，例如 ":app"。
+	Configuration string `json:"configuration,omitempty"` // 依赖的目标配置，未指定时为空。
+
+	// Module 是项目模式下路径对应的模块，由 parser.ParseProjectTree 设置，找不到时为nil。
+	Module *Module `json:"-"`
 }
 
 // Plugin 表示Gradle插件。
@@ -42,26 +114,148 @@ type Plugin struct {
 	Version string                 `json:"version,omitempty"`
 	Apply   bool                   `json:"apply"`
 	Config  map[string]interface{} `json:"config,omitempty"`
+
+	// Style 是声明插件的写法，例如plugins块中的 id 或 apply plugin 语句。
+	Style PluginStyle `json:"style,omitempty"`
+
+	// Class 是以插件类应用时的类名，例如 apply plugin: JavaPlugin 中的 "JavaPlugin"；
+	// 已知的Gradle核心插件类会把 ID 换成对应的插件ID，其他插件类的 ID 与类名相同。
+	Class string `json:"class,omitempty"`
+
+	// Classpath 是版本的来源：插件由 apply plugin 应用而没有版本时，版本取自buildscript classpath中提供该插件的依赖，
+	// 这里记录该依赖的坐标，例如 "com.android.tools.build:gradle:7.4.2"。
+	Classpath string `json:"classpath,omitempty"`
+
+	// DeclaredAtLine 是声明所在的行号（从1开始），仅在开启行号跟踪时设置。
+	DeclaredAtLine int `json:"declaredAtLine,omitempty"`
+
+	// Origin 是声明所在的文件和块，仅在开启来源跟踪（包括项目模式解析）时设置。
+	Origin *Origin `json:"origin,omitempty"`
 }
 
+// PluginStyle 表示插件的声明写法。
+type PluginStyle string
+
+const (
+	PluginStylePluginsBlock PluginStyle = "plugins-block" // plugins { id 'x' }。
+	PluginStyleApplyPlugin  PluginStyle = "apply-plugin"  // Groovy的 apply plugin: 'x' 或 apply plugin: JavaPlugin。
+	PluginStyleApplyKotlin  PluginStyle = "apply-kotlin"  // Kotlin DSL的 apply(plugin = "x")。
+	PluginStylePluginsApply PluginStyle = "plugins-apply" // plugins.apply("x")、pluginManager.apply("x") 等方法调用。
+)
+
 // Repository 表示Gradle仓库配置。
 type Repository struct {
 	Name     string                 `json:"name"`
 	URL      string                 `json:"url,omitempty"`
 	Type     string                 `json:"type"` // maven, ivy, flatDir, etc.
 	Config   map[string]interface{} `json:"config,omitempty"`
-	Username string                 `json:"username,omitempty"`
-	Password string                 `json:"password,omitempty"`
+	Username string                 `json:"username,omitempty"` // 字面量形式的用户名。
+	Password string                 `json:"password,omitempty"` // 字面量形式的密码。
+
+	// UsernameRef 和 PasswordRef 是不以字面量给出的用户名和密码的引用，格式为 "<来源>:<名称>"，
+	// 例如 "environment:TOKEN"、"property:repoUser"；无法识别来源的表达式保存原文。不包含凭证本身的值。
+	UsernameRef string `json:"usernameRef,omitempty"`
+	PasswordRef string `json:"passwordRef,omitempty"`
+
+	// Credentials 凭证配置，包括环境变量、Gradle属性等引用，没有配置凭证时为nil。
+	Credentials *RepositoryCredentials `json:"credentials,omitempty"`
+	// Authentication 认证方式的类型，例如 BasicAuthentication、HttpHeaderAuthentication。
+	Authentication []string `json:"authentication,omitempty"`
+	// AllowInsecureProtocol 是否允许通过HTTP等不安全协议访问仓库。
+	AllowInsecureProtocol bool `json:"allowInsecureProtocol,omitempty"`
+	// Content 是仓库的内容过滤条件，没有声明时为nil，表示仓库可以被查询任何模块。
+	Content *RepositoryContent `json:"content,omitempty"`
+
+	// DeclaredAtLine 是声明所在的行号（从1开始），仅在开启行号跟踪时设置。
+	DeclaredAtLine int `json:"declaredAtLine,omitempty"`
+
+	// Origin 是声明所在的文件和块，仅在开启来源跟踪（包括项目模式解析）时设置。
+	Origin *Origin `json:"origin,omitempty"`
+}
+
+// 仓库凭证类型。
+const (
+	CredentialsPassword   = "PasswordCredentials"
+	CredentialsHTTPHeader = "HttpHeaderCredentials"
+	CredentialsAWS        = "AwsCredentials"
+)
+
+// RepositoryCredentials 表示仓库的凭证配置。
+type RepositoryCredentials struct {
+	Type string `json:"type"` // 凭证类型，例如 PasswordCredentials。
+
+	// Provided 表示使用 credentials(PasswordCredentials) 形式，由Gradle从
+	// 以仓库名为前缀的属性（如 mySecureRepoUsername）中读取凭证。
+	Provided bool `json:"provided,omitempty"`
+
+	// Values 凭证各项的值，键为 username、password、name、value、accessKey 等。
+	Values map[string]*CredentialValue `json:"values,omitempty"`
+}
+
+// CredentialSource 表示凭证值的来源。
+type CredentialSource string
+
+const (
+	CredentialLiteral        CredentialSource = "literal"        // 直接写在脚本中的字面量。
+	CredentialEnvironment    CredentialSource = "environment"    // 环境变量，例如 System.getenv("TOKEN")。
+	CredentialProperty       CredentialSource = "property"       // Gradle属性，例如 findProperty('repoUser')。
+	CredentialSystemProperty CredentialSource = "systemProperty" // 系统属性，例如 System.getProperty('repo.user')。
+	CredentialExpression     CredentialSource = "expression"     // 其他无法识别来源的表达式。
+)
+
+// CredentialValue 表示一项凭证的值。
+type CredentialValue struct {
+	Source CredentialSource `json:"source"`
+	Value  string           `json:"value"` // 字面量的值，或引用的环境变量、属性名称。
+	Raw    string           `json:"raw"`   // 原始表达式文本。
+}
+
+// Reference 返回凭证值的引用，格式为 "<来源>:<名称>"，例如 "environment:TOKEN"。
+// 无法识别来源的表达式返回原始文本，字面量返回空字符串。
+func (v *CredentialValue) Reference() string {
+	switch v.Source {
+	case CredentialLiteral:
+		return ""
+	case CredentialExpression:
+		return v.Raw
+	default:
+		return string(v.Source) + ":" + v.Value
+	}
 }
 
 // Task 表示Gradle任务。
 type Task struct {
-	Name        string                 `json:"name"`
-	Type        string                 `json:"type,omitempty"`
-	Description string                 `json:"description,omitempty"`
-	Group       string                 `json:"group,omitempty"`
-	DependsOn   []string               `json:"dependsOn,omitempty"`
-	Config      map[string]interface{} `json:"config,omitempty"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Group       string   `json:"group,omitempty"`
+	DependsOn   []string `json:"dependsOn,omitempty"`
+
+	// 任务执行顺序相关的关系。
+	FinalizedBy    []string               `json:"finalizedBy,omitempty"`
+	MustRunAfter   []string               `json:"mustRunAfter,omitempty"`
+	ShouldRunAfter []string               `json:"shouldRunAfter,omitempty"`
+	Config         map[string]interface{} `json:"config,omitempty"`
+}
+
+// 测试框架类型。
+const (
+	TestFrameworkJUnitPlatform = "junit-platform"
+	TestFrameworkJUnit         = "junit"
+	TestFrameworkTestNG        = "testng"
+)
+
+// TestConfig 表示test任务的配置，来自 test { }、tasks.named('test') { } 和 tasks.withType(Test) { } 等块。
+type TestConfig struct {
+	Framework        string            `json:"framework,omitempty"` // junit-platform、junit 或 testng。
+	IncludeTags      []string          `json:"includeTags,omitempty"`
+	ExcludeTags      []string          `json:"excludeTags,omitempty"`
+	SystemProperties map[string]string `json:"systemProperties,omitempty"`
+	Environment      map[string]string `json:"environment,omitempty"`
+	JVMArgs          []string          `json:"jvmArgs,omitempty"`
+	MaxParallelForks string            `json:"maxParallelForks,omitempty"` // 保留原始表达式，例如 4 或 Runtime.runtime.availableProcessors()。
+	MaxHeapSize      string            `json:"maxHeapSize,omitempty"`
+	IgnoreFailures   bool              `json:"ignoreFailures,omitempty"`
 }
 
 // ScriptBlock 表示Gradle脚本块。
@@ -71,19 +265,85 @@ type ScriptBlock struct {
 	Children []*ScriptBlock            `json:"children,omitempty"`
 	Values   map[string]interface{}    `json:"values,omitempty"`
 	Closures map[string][]*ScriptBlock `json:"closures,omitempty"`
+
+	// 从源码构建时记录的信息。
+	Header      string      `json:"header,omitempty"`     // 左花括号之前的头部文本，例如 tasks.named('test')。
+	Statements  []string    `json:"statements,omitempty"` // 块中不属于子块的语句，按出现顺序排列。
+	SourceRange SourceRange `json:"sourceRange"`          // 从头部到右花括号的范围，根块为整个文件。
 }
 
-// DependencySet 表示一组依赖，用于按范围分组。
+// DependencySet 表示一组依赖，用于按范围分组。Scope为空表示未声明配置的依赖。
 type DependencySet struct {
 	Scope        string        `json:"scope"`
 	Dependencies []*Dependency `json:"dependencies"`
 }
 
+// Count 返回分组中的依赖数量，set为nil时返回0。
+func (s *DependencySet) Count() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.Dependencies)
+}
+
+// Percentage 返回分组中的依赖占total的百分比（0到100），total不大于0时返回0。
+func (s *DependencySet) Percentage(total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(s.Count()) * 100 / float64(total)
+}
+
+// CountDependencies 返回各分组的依赖总数，可以作为 Percentage 的total。
+func CountDependencies(sets []*DependencySet) int {
+	total := 0
+	for _, set := range sets {
+		total += set.Count()
+	}
+	return total
+}
+
 // ParseResult 表示解析结果。
 type ParseResult struct {
-	Project   *Project `json:"project"`
-	RawText   string   `json:"rawText,omitempty"`
-	Errors    []error  `json:"errors,omitempty"`
-	Warnings  []string `json:"warnings,omitempty"`
-	ParseTime string   `json:"parseTime,omitempty"`
+	Project   *Project      `json:"project"`
+	RawText   string        `json:"rawText,omitempty"`
+	Errors    []error       `json:"errors,omitempty"`
+	Warnings  []*Diagnostic `json:"warnings,omitempty"`
+	ParseTime string        `json:"parseTime,omitempty"`
+
+	// FileKind 是脚本文件的类型。ParseFile按文件名判断，其他解析方法按脚本内容判断。
+	FileKind FileKind `json:"fileKind,omitempty"`
+
+	// RootBlock 是脚本块结构树的根，名称为 root。
+	RootBlock *ScriptBlock `json:"-"`
+}
+
+// WrapperInfo 表示gradle/wrapper/gradle-wrapper.properties中的Wrapper配置。
+type WrapperInfo struct {
+	DistributionURL       string            `json:"distributionUrl"`
+	GradleVersion         string            `json:"gradleVersion"`              // 从distributionUrl中提取的Gradle版本。
+	DistributionType      string            `json:"distributionType,omitempty"` // bin 或 all。
+	DistributionSHA256Sum string            `json:"distributionSha256Sum,omitempty"`
+	DistributionBase      string            `json:"distributionBase,omitempty"`
+	DistributionPath      string            `json:"distributionPath,omitempty"`
+	ZipStoreBase          string            `json:"zipStoreBase,omitempty"`
+	ZipStorePath          string            `json:"zipStorePath,omitempty"`
+	Properties            map[string]string `json:"properties,omitempty"` // 全部原始属性。
+	FilePath              string            `json:"filePath,omitempty"`
+
+	// Task 是构建文件中 wrapper { } 任务的配置，没有声明时为nil。
+	// 任务中声明的值优先于properties文件，GradleVersion、DistributionType 等字段已经按任务配置更新。
+	Task *WrapperTask `json:"task,omitempty"`
+}
+
+// WrapperTask 表示构建文件中 wrapper 任务的配置，例如 wrapper { gradleVersion = '8.7'; distributionType = Wrapper.DistributionType.ALL }。
+// 未声明的值为空。
+type WrapperTask struct {
+	GradleVersion         string `json:"gradleVersion,omitempty"`
+	DistributionType      string `json:"distributionType,omitempty"` // bin 或 all。
+	DistributionURL       string `json:"distributionUrl,omitempty"`
+	DistributionSHA256Sum string `json:"distributionSha256Sum,omitempty"`
+
+	FilePath string `json:"filePath,omitempty"`
+	Line     int    `json:"line,omitempty"` // 任务配置开始的行号（从1开始）。
 }
@@ -191,10 +191,13 @@ func TestParseResult(t *testing.T) {
 	}
 
 	result := &ParseResult{
-		Project:   project,
-		RawText:   "sample raw text",
-		Errors:    []error{nil},
-		Warnings:  []string{"warning1", "warning2"},
+		Project: project,
+		RawText: "sample raw text",
+		Errors:  []error{nil},
+		Warnings: []*Diagnostic{
+			{Code: DiagnosticJCenterRepository, Severity: SeverityWarning, Message: "warning1"},
+			{Code: DiagnosticDynamicVersion, Severity: SeverityWarning, Message: "warning2"},
+		},
 		ParseTime: "100ms",
 	}
 
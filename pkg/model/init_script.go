@@ -0,0 +1,27 @@
+// Package model 提供Gradle脚本文件类型和初始化脚本配置的数据结构。
+package model
+
+// FileKind 表示Gradle脚本文件的类型。
+type FileKind string
+
+const (
+	FileKindBuild    FileKind = "build"    // build.gradle 或 build.gradle.kts。
+	FileKindSettings FileKind = "settings" // settings.gradle 或 settings.gradle.kts。
+	FileKindInit     FileKind = "init"     // 初始化脚本，例如 init.gradle、*.init.gradle 或 init.d 目录下的脚本。
+	FileKindScript   FileKind = "script"   // 通过 apply from 应用的脚本插件等其他脚本文件。
+)
+
+// InitScript 表示初始化脚本中的配置。
+type InitScript struct {
+	// initscript { } 中声明的classpath依赖和解析它们使用的仓库。
+	Classpath    []*Dependency `json:"classpath"`
+	Repositories []*Repository `json:"repositories"`
+
+	// 通过 allprojects、rootProject、beforeProject 等注入到各个项目中的仓库和插件。
+	ProjectRepositories []*Repository `json:"projectRepositories"`
+	ProjectPlugins      []*Plugin     `json:"projectPlugins"`
+
+	// 通过 settingsEvaluated、beforeSettings 注入到settings中的仓库，
+	// 包括 pluginManagement 和 dependencyResolutionManagement 中的仓库。
+	SettingsRepositories []*Repository `json:"settingsRepositories"`
+}
@@ -0,0 +1,164 @@
+// Package model 提供遍历解析结果的迭代器。
+package model
+
+import "iter"
+
+// Each* 方法按深度优先顺序遍历项目本身和所有子项目中的元素，fn返回false时停止遍历。
+// All* 方法返回同样顺序的 iter.Seq，可以直接用于 for range。nil元素会被跳过。
+
+// EachProject 遍历项目本身和所有子项目。
+func (p *Project) EachProject(fn func(*Project) bool) {
+	p.walk(fn)
+}
+
+// EachDependency 遍历项目及所有子项目的依赖。
+func (p *Project) EachDependency(fn func(*Dependency) bool) {
+	eachItem(p, func(p *Project) []*Dependency { return p.Dependencies }, fn)
+}
+
+// EachPlugin 遍历项目及所有子项目的插件。
+func (p *Project) EachPlugin(fn func(*Plugin) bool) {
+	eachItem(p, func(p *Project) []*Plugin { return p.Plugins }, fn)
+}
+
+// EachRepository 遍历项目及所有子项目的仓库。
+func (p *Project) EachRepository(fn func(*Repository) bool) {
+	eachItem(p, func(p *Project) []*Repository { return p.Repositories }, fn)
+}
+
+// EachTask 遍历项目及所有子项目的任务。
+func (p *Project) EachTask(fn func(*Task) bool) {
+	eachItem(p, func(p *Project) []*Task { return p.Tasks }, fn)
+}
+
+// AllProjects 返回项目本身和所有子项目的迭代器。
+func (p *Project) AllProjects() iter.Seq[*Project] { return p.EachProject }
+
+// AllDependencies 返回项目及所有子项目的依赖的迭代器。
+func (p *Project) AllDependencies() iter.Seq[*Dependency] { return p.EachDependency }
+
+// AllPlugins 返回项目及所有子项目的插件的迭代器。
+func (p *Project) AllPlugins() iter.Seq[*Plugin] { return p.EachPlugin }
+
+// AllRepositories 返回项目及所有子项目的仓库的迭代器。
+func (p *Project) AllRepositories() iter.Seq[*Repository] { return p.EachRepository }
+
+// AllTasks 返回项目及所有子项目的任务的迭代器。
+func (p *Project) AllTasks() iter.Seq[*Task] { return p.EachTask }
+
+// walk 按深度优先顺序对项目和子项目调用fn，遍历被中止时返回false。
+func (p *Project) walk(fn func(*Project) bool) bool {
+	if p == nil {
+		return true
+	}
+	if !fn(p) {
+		return false
+	}
+	for _, sub := range p.SubProjects {
+		if !sub.walk(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// eachItem 遍历项目及所有子项目中由items选出的元素。
+func eachItem[T any](p *Project, items func(*Project) []*T, fn func(*T) bool) {
+	p.walk(func(project *Project) bool {
+		for _, item := range items(project) {
+			if item != nil && !fn(item) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// EachDependency 遍历解析结果中项目及所有子项目的依赖，结果为空时不调用fn。
+func (r *ParseResult) EachDependency(fn func(*Dependency) bool) {
+	r.project().EachDependency(fn)
+}
+
+// EachPlugin 遍历解析结果中项目及所有子项目的插件。
+func (r *ParseResult) EachPlugin(fn func(*Plugin) bool) {
+	r.project().EachPlugin(fn)
+}
+
+// EachRepository 遍历解析结果中项目及所有子项目的仓库。
+func (r *ParseResult) EachRepository(fn func(*Repository) bool) {
+	r.project().EachRepository(fn)
+}
+
+// EachTask 遍历解析结果中项目及所有子项目的任务。
+func (r *ParseResult) EachTask(fn func(*Task) bool) {
+	r.project().EachTask(fn)
+}
+
+// EachWarning 遍历解析警告。
+func (r *ParseResult) EachWarning(fn func(*Diagnostic) bool) {
+	if r == nil {
+		return
+	}
+	for _, warning := range r.Warnings {
+		if warning != nil && !fn(warning) {
+			return
+		}
+	}
+}
+
+// AllDependencies 返回解析结果中所有依赖的迭代器。
+func (r *ParseResult) AllDependencies() iter.Seq[*Dependency] { return r.EachDependency }
+
+// AllPlugins 返回解析结果中所有插件的迭代器。
+func (r *ParseResult) AllPlugins() iter.Seq[*Plugin] { return r.EachPlugin }
+
+// AllRepositories 返回解析结果中所有仓库的迭代器。
+func (r *ParseResult) AllRepositories() iter.Seq[*Repository] { return r.EachRepository }
+
+// AllTasks 返回解析结果中所有任务的迭代器。
+func (r *ParseResult) AllTasks() iter.Seq[*Task] { return r.EachTask }
+
+// AllWarnings 返回解析警告的迭代器。
+func (r *ParseResult) AllWarnings() iter.Seq[*Diagnostic] { return r.EachWarning }
+
+// project 返回解析结果中的项目，结果为nil时返回nil。
+func (r *ParseResult) project() *Project {
+	if r == nil {
+		return nil
+	}
+	return r.Project
+}
+
+// EachModule 按Modules的顺序遍历构建中的模块，不包括buildSrc和includeBuild引入的构建。
+func (t *ProjectTree) EachModule(fn func(*Module) bool) {
+	if t == nil {
+		return
+	}
+	for _, module := range t.Modules {
+		if module != nil && !fn(module) {
+			return
+		}
+	}
+}
+
+// EachDependency 遍历各模块构建文件中直接声明的依赖，同时给出依赖所在的模块。
+// 模块的项目已挂在父模块的SubProjects下，这里只取各模块自身的依赖，不会重复。
+func (t *ProjectTree) EachDependency(fn func(*Module, *Dependency) bool) {
+	t.EachModule(func(module *Module) bool {
+		if module.Result == nil || module.Result.Project == nil {
+			return true
+		}
+		for _, dep := range module.Result.Project.Dependencies {
+			if dep != nil && !fn(module, dep) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// AllModules 返回构建中模块的迭代器。
+func (t *ProjectTree) AllModules() iter.Seq[*Module] { return t.EachModule }
+
+// AllDependencies 返回各模块及其依赖的迭代器。
+func (t *ProjectTree) AllDependencies() iter.Seq2[*Module, *Dependency] { return t.EachDependency }
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProjectIterators(t *testing.T) {
+	root := &Project{
+		Name:         "root",
+		Dependencies: []*Dependency{{Name: "a"}, nil},
+		SubProjects: []*Project{
+			{Name: "app", Dependencies: []*Dependency{{Name: "b"}, {Name: "c"}},
+				SubProjects: []*Project{{Name: "feature", Dependencies: []*Dependency{{Name: "d"}}}}},
+			{Name: "lib", Dependencies: []*Dependency{{Name: "e"}}, Plugins: []*Plugin{{ID: "java-library"}}},
+		},
+	}
+
+	var projects []string
+	for p := range root.AllProjects() {
+		projects = append(projects, p.Name)
+	}
+	if want := []string{"root", "app", "feature", "lib"}; !reflect.DeepEqual(projects, want) {
+		t.Errorf("projects = %v, want %v", projects, want)
+	}
+
+	var deps []string
+	for dep := range root.AllDependencies() {
+		deps = append(deps, dep.Name)
+		if dep.Name == "d" {
+			break
+		}
+	}
+	if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(deps, want) {
+		t.Errorf("dependencies = %v, want %v", deps, want)
+	}
+
+	count := 0
+	root.EachPlugin(func(*Plugin) bool { count++; return true })
+	if count != 1 {
+		t.Errorf("Expected 1 plugin, got %d", count)
+	}
+
+	var result *ParseResult
+	for range result.AllDependencies() {
+		t.Error("Expected no dependencies from a nil result")
+	}
+	result = &ParseResult{Project: root, Warnings: []*Diagnostic{{Code: "x"}, {Code: "y"}}}
+	count = 0
+	result.EachWarning(func(*Diagnostic) bool { count++; return false })
+	if count != 1 {
+		t.Errorf("Expected iteration to stop after 1 warning, got %d", count)
+	}
+}
+
+func TestProjectTreeIterators(t *testing.T) {
+	app := &Project{Name: "app", Dependencies: []*Dependency{{Name: "b"}}}
+	root := &Project{Name: "root", Dependencies: []*Dependency{{Name: "a"}}, SubProjects: []*Project{app}}
+	tree := &ProjectTree{Modules: []*Module{
+		{Path: ":", Result: &ParseResult{Project: root}},
+		{Path: ":docs"},
+		{Path: ":app", Result: &ParseResult{Project: app}},
+	}}
+
+	var got []string
+	for module, dep := range tree.AllDependencies() {
+		got = append(got, module.Path+" "+dep.Name)
+	}
+	if want := []string{": a", ":app b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dependencies = %v, want %v", got, want)
+	}
+}
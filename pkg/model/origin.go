@@ -0,0 +1,30 @@
+// Package model 提供声明来源（文件、块路径和行号）的数据结构。
+package model
+
+import "fmt"
+
+// Origin 记录依赖、插件或仓库在哪个文件的哪个块中声明，使汇总多个文件的结果后仍能指出声明位置。
+type Origin struct {
+	File string `json:"file,omitempty"` // 声明所在的文件，解析字符串或Reader时为空。
+	// BlockPath 是声明所在的块路径，例如 "buildscript.dependencies"、"subprojects.repositories"，顶层声明为空。
+	BlockPath string `json:"blockPath,omitempty"`
+	Line      int    `json:"line,omitempty"` // 声明所在的行号（从1开始），未知时为0。
+}
+
+// String 返回 "文件:行号 (块路径)" 形式的描述，缺少的部分被省略。
+func (o *Origin) String() string {
+	if o == nil {
+		return ""
+	}
+	location := o.File
+	if o.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, o.Line)
+	}
+	if o.BlockPath == "" {
+		return location
+	}
+	if location == "" {
+		return o.BlockPath
+	}
+	return fmt.Sprintf("%s (%s)", location, o.BlockPath)
+}
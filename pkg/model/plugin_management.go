@@ -0,0 +1,119 @@
+// Package model 提供settings文件中插件管理配置的数据结构。
+package model
+
+import "strings"
+
+// DefaultPluginRepository 是未配置pluginManagement仓库时Gradle使用的插件门户。
+const DefaultPluginRepository = "gradlePluginPortal"
+
+// PluginManagement 表示settings文件中的 pluginManagement { } 配置。
+type PluginManagement struct {
+	Repositories    []*Repository           `json:"repositories"`              // 插件解析使用的仓库。
+	Plugins         []*Plugin               `json:"plugins"`                   // plugins { } 中集中声明的插件版本。
+	ResolutionRules []*PluginResolutionRule `json:"resolutionRules,omitempty"` // resolutionStrategy.eachPlugin 中的规则。
+	IncludedBuilds  []string                `json:"includedBuilds,omitempty"`  // includeBuild 引入的插件构建。
+}
+
+// PluginResolutionRule 表示 eachPlugin { } 中的一条规则。
+// PluginID 和 Namespace 都为空时规则作用于所有插件。
+type PluginResolutionRule struct {
+	PluginID   string `json:"pluginId,omitempty"`   // 条件 requested.id.id == '...' 中的插件ID。
+	Namespace  string `json:"namespace,omitempty"`  // 条件 requested.id.namespace == '...' 中的命名空间。
+	Condition  string `json:"condition,omitempty"`  // 原始条件文本。
+	UseModule  string `json:"useModule,omitempty"`  // useModule 指定的模块坐标。
+	UseVersion string `json:"useVersion,omitempty"` // useVersion 指定的版本。
+}
+
+// Matches 判断规则是否作用于指定插件。
+func (r *PluginResolutionRule) Matches(pluginID string) bool {
+	if r.PluginID != "" && r.PluginID != pluginID {
+		return false
+	}
+	if r.Namespace != "" && !strings.HasPrefix(pluginID, r.Namespace+".") {
+		return false
+	}
+	return true
+}
+
+// PluginResolution 表示插件实际的解析结果。
+type PluginResolution struct {
+	PluginID      string   `json:"pluginId"`
+	Version       string   `json:"version,omitempty"`
+	VersionSource string   `json:"versionSource,omitempty"` // declared、pluginManagement 或 resolutionStrategy。
+	Module        string   `json:"module"`                  // 实际解析的模块坐标。
+	Repositories  []string `json:"repositories"`            // 按顺序查找的仓库名称或URL。
+}
+
+// 插件版本的来源。
+const (
+	PluginVersionDeclared           = "declared"
+	PluginVersionPluginManagement   = "pluginManagement"
+	PluginVersionResolutionStrategy = "resolutionStrategy"
+)
+
+// Resolve 根据插件管理配置确定插件的版本、模块坐标和仓库。
+// 插件自身声明的版本优先，其次是 pluginManagement.plugins，最后是 eachPlugin 中的 useVersion；
+// 没有 useModule 规则时使用插件标记模块 <id>:<id>.gradle.plugin:<version>。
+func (pm *PluginManagement) Resolve(plugin *Plugin) *PluginResolution {
+	resolution := &PluginResolution{PluginID: plugin.ID}
+	if plugin.Version != "" {
+		resolution.Version = plugin.Version
+		resolution.VersionSource = PluginVersionDeclared
+	}
+
+	if pm != nil && resolution.Version == "" {
+		for _, pinned := range pm.Plugins {
+			if pinned.ID == plugin.ID && pinned.Version != "" {
+				resolution.Version = pinned.Version
+				resolution.VersionSource = PluginVersionPluginManagement
+				break
+			}
+		}
+	}
+
+	module := ""
+	if pm != nil {
+		for _, rule := range pm.ResolutionRules {
+			if !rule.Matches(plugin.ID) {
+				continue
+			}
+			if rule.UseVersion != "" && resolution.Version == "" {
+				resolution.Version = rule.UseVersion
+				resolution.VersionSource = PluginVersionResolutionStrategy
+			}
+			if rule.UseModule != "" && module == "" {
+				module = rule.UseModule
+			}
+		}
+	}
+
+	if module == "" {
+		module = plugin.ID + ":" + plugin.ID + ".gradle.plugin"
+		if resolution.Version != "" {
+			module += ":" + resolution.Version
+		}
+	} else {
+		module = strings.NewReplacer(
+			"${requested.version}", resolution.Version,
+			"$requested.version", resolution.Version,
+			"${requested.id.id}", plugin.ID,
+		).Replace(module)
+	}
+	resolution.Module = module
+
+	resolution.Repositories = make([]string, 0)
+	if pm != nil {
+		for _, repo := range pm.Repositories {
+			if repo.URL != "" {
+				resolution.Repositories = append(resolution.Repositories, repo.URL)
+			} else {
+				resolution.Repositories = append(resolution.Repositories, repo.Name)
+			}
+		}
+	}
+	if len(resolution.Repositories) == 0 {
+		resolution.Repositories = append(resolution.Repositories, DefaultPluginRepository)
+	}
+
+	return resolution
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPluginManagement_Resolve(t *testing.T) {
+	pm := &PluginManagement{
+		Repositories: []*Repository{
+			{Name: "plugins.example.com", URL: "https://plugins.example.com/m2", Type: "maven"},
+			{Name: "gradlePluginPortal", Type: "maven"},
+		},
+		Plugins: []*Plugin{{ID: "org.springframework.boot", Version: "3.1.0"}},
+		ResolutionRules: []*PluginResolutionRule{
+			{Namespace: "com.android", UseModule: "com.android.tools.build:gradle:${requested.version}"},
+			{PluginID: "io.freefair.lombok", UseVersion: "8.0.1"},
+		},
+	}
+
+	boot := pm.Resolve(&Plugin{ID: "org.springframework.boot"})
+	if boot.Version != "3.1.0" || boot.VersionSource != PluginVersionPluginManagement {
+		t.Errorf("Unexpected version for boot: %+v", boot)
+	}
+	if boot.Module != "org.springframework.boot:org.springframework.boot.gradle.plugin:3.1.0" {
+		t.Errorf("Unexpected marker module: %s", boot.Module)
+	}
+	if !reflect.DeepEqual(boot.Repositories, []string{"https://plugins.example.com/m2", "gradlePluginPortal"}) {
+		t.Errorf("Unexpected repositories: %v", boot.Repositories)
+	}
+
+	android := pm.Resolve(&Plugin{ID: "com.android.application", Version: "8.1.0"})
+	if android.Module != "com.android.tools.build:gradle:8.1.0" || android.VersionSource != PluginVersionDeclared {
+		t.Errorf("Unexpected android resolution: %+v", android)
+	}
+
+	lombok := pm.Resolve(&Plugin{ID: "io.freefair.lombok"})
+	if lombok.Version != "8.0.1" || lombok.VersionSource != PluginVersionResolutionStrategy {
+		t.Errorf("Unexpected lombok resolution: %+v", lombok)
+	}
+
+	var none *PluginManagement
+	def := none.Resolve(&Plugin{ID: "com.example.foo"})
+	if def.Module != "com.example.foo:com.example.foo.gradle.plugin" || !reflect.DeepEqual(def.Repositories, []string{DefaultPluginRepository}) {
+		t.Errorf("Unexpected default resolution: %+v", def)
+	}
+}
@@ -0,0 +1,97 @@
+// Package model 提供多模块构建（项目模式）的数据模型。
+package model
+
+// ProjectTree 表示以项目模式解析的整个Gradle构建，包括settings文件、各模块的构建文件、
+// buildSrc和includeBuild引入的构建以及其中定义的约定插件。
+type ProjectTree struct {
+	RootDir  string       `json:"rootDir"`
+	Settings *ParseResult `json:"settings,omitempty"` // settings文件的解析结果，没有settings文件时为nil。
+
+	// Modules 按根项目、settings中include的顺序排列。
+	Modules []*Module `json:"modules"`
+
+	// VersionCatalog 是构建根目录下 gradle/libs.versions.toml 的解析结果，没有版本目录时为nil。
+	VersionCatalog *VersionCatalog `json:"versionCatalog,omitempty"`
+
+	// BuildSrc 和 IncludedBuilds 是buildSrc目录和includeBuild引入的构建，同样以项目模式解析。
+	BuildSrc       *ProjectTree   `json:"buildSrc,omitempty"`
+	IncludedBuilds []*ProjectTree `json:"includedBuilds,omitempty"`
+
+	// ConventionPlugins 是buildSrc和includeBuild中的预编译脚本插件，只在最外层的构建上汇总。
+	ConventionPlugins []*ConventionPlugin `json:"conventionPlugins,omitempty"`
+
+	// Errors 记录解析单个构建文件时的错误，不影响其他文件的解析。
+	Errors []error `json:"errors,omitempty"`
+}
+
+// Module 表示构建中的一个模块。
+type Module struct {
+	Path     string       `json:"path"`               // Gradle项目路径，例如 ":"、":app"、":libs:core"。
+	Dir      string       `json:"dir"`                // 模块目录。
+	FilePath string       `json:"filePath,omitempty"` // 构建文件路径，模块没有构建文件时为空。
+	Result   *ParseResult `json:"result,omitempty"`   // 构建文件的解析结果，没有构建文件时为nil。
+}
+
+// ConventionPlugin 表示 src/main/groovy 或 src/main/kotlin 下的预编译脚本插件。
+type ConventionPlugin struct {
+	ID        string       `json:"id"`                  // 插件ID，由文件名（Kotlin脚本还包括package）决定。
+	FilePath  string       `json:"filePath"`            // 定义插件的脚本文件。
+	BuildDir  string       `json:"buildDir"`            // 插件所在构建（buildSrc或includeBuild）的目录。
+	Result    *ParseResult `json:"result,omitempty"`    // 插件脚本的解析结果。
+	AppliedBy []string     `json:"appliedBy,omitempty"` // 应用了该插件的模块路径。
+}
+
+// DependencyUsage 表示依赖坐标在构建中的一处声明。
+type DependencyUsage struct {
+	Module           string      `json:"module,omitempty"`           // 声明所在模块的项目路径。
+	ConventionPlugin string      `json:"conventionPlugin,omitempty"` // 声明位于约定插件中时为插件ID。
+	FilePath         string      `json:"filePath"`
+	Scope            string      `json:"scope"`
+	Buildscript      bool        `json:"buildscript,omitempty"`  // 声明位于 buildscript { dependencies { } } 中。
+	CatalogAlias     string      `json:"catalogAlias,omitempty"` // 通过版本目录引用时的访问路径，例如 libs.log4j.core。
+	Group            string      `json:"group"`
+	Name             string      `json:"name"`
+	Version          string      `json:"version,omitempty"` // 声明的版本，通过版本目录引用时为目录中的版本。
+	SourceRange      SourceRange `json:"sourceRange"`
+	RawText          string      `json:"rawText"` // 整条声明语句。
+}
+
+// Module 按Gradle项目路径查找模块，找不到时返回nil。
+func (t *ProjectTree) Module(path string) *Module {
+	if t == nil {
+		return nil
+	}
+	for _, module := range t.Modules {
+		if module.Path == path {
+			return module
+		}
+	}
+	return nil
+}
+
+// EffectiveRepositories 返回模块解析依赖时实际使用的仓库，会考虑settings中
+// dependencyResolutionManagement 集中声明的仓库和仓库模式。
+func (t *ProjectTree) EffectiveRepositories(module *Module) []*Repository {
+	var projectRepos []*Repository
+	if module != nil && module.Result != nil && module.Result.Project != nil {
+		projectRepos = module.Result.Project.Repositories
+	}
+	var drm *DependencyResolutionManagement
+	if t != nil && t.Settings != nil && t.Settings.Project != nil {
+		drm = t.Settings.Project.DependencyResolutionManagement
+	}
+	return drm.EffectiveRepositories(projectRepos)
+}
+
+// ConventionPlugin 按插件ID查找定义它的约定插件，找不到时返回nil。
+func (t *ProjectTree) ConventionPlugin(id string) *ConventionPlugin {
+	if t == nil {
+		return nil
+	}
+	for _, plugin := range t.ConventionPlugins {
+		if plugin.ID == id {
+			return plugin
+		}
+	}
+	return nil
+}
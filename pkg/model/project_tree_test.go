@@ -0,0 +1,54 @@
+package model
+
+import "testing"
+
+func TestProjectTree_Lookups(t *testing.T) {
+	tree := &ProjectTree{
+		Modules:           []*Module{{Path: ":"}, {Path: ":app"}},
+		ConventionPlugins: []*ConventionPlugin{{ID: "myorg.conventions"}},
+	}
+
+	if tree.Module(":app") == nil || tree.Module(":missing") != nil {
+		t.Error("Module() returned unexpected result")
+	}
+	if tree.ConventionPlugin("myorg.conventions") == nil || tree.ConventionPlugin("java") != nil {
+		t.Error("ConventionPlugin() returned unexpected result")
+	}
+
+	var empty *ProjectTree
+	if empty.Module(":") != nil || empty.ConventionPlugin("x") != nil {
+		t.Error("Lookups on nil tree should return nil")
+	}
+}
+
+func TestProjectTree_EffectiveRepositories(t *testing.T) {
+	central := &Repository{Name: "mavenCentral"}
+	jitpack := &Repository{Name: "jitpack", URL: "https://jitpack.io"}
+	app := &Module{Path: ":app", Result: &ParseResult{Project: &Project{Repositories: []*Repository{jitpack}}}}
+	lib := &Module{Path: ":lib", Result: &ParseResult{Project: &Project{}}}
+
+	tree := &ProjectTree{
+		Settings: &ParseResult{Project: &Project{
+			DependencyResolutionManagement: &DependencyResolutionManagement{Repositories: []*Repository{central}},
+		}},
+		Modules: []*Module{app, lib},
+	}
+
+	// 默认PREFER_PROJECT：项目声明了仓库时使用项目的仓库。
+	if repos := tree.EffectiveRepositories(app); len(repos) != 1 || repos[0] != jitpack {
+		t.Errorf("PREFER_PROJECT app repositories = %v", repos)
+	}
+	if repos := tree.EffectiveRepositories(lib); len(repos) != 1 || repos[0] != central {
+		t.Errorf("PREFER_PROJECT lib repositories = %v", repos)
+	}
+
+	tree.Settings.Project.DependencyResolutionManagement.RepositoriesMode = RepositoriesModePreferSettings
+	if repos := tree.EffectiveRepositories(app); len(repos) != 1 || repos[0] != central {
+		t.Errorf("PREFER_SETTINGS app repositories = %v", repos)
+	}
+
+	// 没有settings时使用模块自身的仓库。
+	if repos := (&ProjectTree{}).EffectiveRepositories(app); len(repos) != 1 || repos[0] != jitpack {
+		t.Errorf("repositories without settings = %v", repos)
+	}
+}
@@ -0,0 +1,27 @@
+// Package model 提供顶层属性赋值的数据结构。
+package model
+
+// PropertyType 是根据属性值推断出的类型。
+type PropertyType string
+
+const (
+	PropertyTypeString     PropertyType = "string"
+	PropertyTypeBool       PropertyType = "bool"
+	PropertyTypeInt        PropertyType = "int"
+	PropertyTypeFloat      PropertyType = "float"
+	PropertyTypeList       PropertyType = "list"
+	PropertyTypeExpression PropertyType = "expression" // 引用、插值、方法调用等非字面量的值。
+)
+
+// Property 表示构建脚本中的一条顶层赋值，例如 version = '1.0'、ext.springVersion = "5.3.21"
+// 或 val kotlinVersion = "1.9.0"。
+type Property struct {
+	Key        string       `json:"key"`               // 赋值左侧的名称，不含 def/val/var 和类型声明。
+	Keyword    string       `json:"keyword,omitempty"` // 局部变量的声明关键字 def、val 或 var。
+	Value      string       `json:"value"`             // 字面量为去除引号后的值，其他类型为原始文本。
+	Type       PropertyType `json:"type"`
+	Expression *Expression  `json:"expression,omitempty"`
+
+	SourceRange SourceRange `json:"sourceRange"`
+	RawText     string      `json:"rawText"`
+}
@@ -0,0 +1,66 @@
+// Package model 提供依赖的Package URL（purl）表示。
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultMavenExtension 是Maven制品的默认类型，purl中省略。
+const defaultMavenExtension = "jar"
+
+// PackageURL 返回依赖的purl，例如 pkg:maven/org.foo/bar@1.0?classifier=jdk15&type=zip。
+// 版本优先使用ResolvedVersion；版本由无法解析的变量给出时省略版本。
+// 项目依赖以及缺少group或name的依赖无法表示为Maven坐标，返回空字符串。
+func (d *Dependency) PackageURL() string {
+	if d == nil || d.Project != nil || d.Group == "" || d.Name == "" ||
+		strings.Contains(d.Group, "$") || strings.Contains(d.Name, "$") {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("pkg:maven/")
+	sb.WriteString(purlEscape(d.Group))
+	sb.WriteString("/")
+	sb.WriteString(purlEscape(d.Name))
+
+	version := d.Version
+	if d.ResolvedVersion != "" {
+		version = d.ResolvedVersion
+	} else if d.HasDynamicVersion {
+		version = ""
+	}
+	if version != "" {
+		sb.WriteString("@")
+		sb.WriteString(purlEscape(version))
+	}
+
+	// 限定符按键名排序。
+	qualifiers := make([]string, 0, 2)
+	if d.Classifier != "" {
+		qualifiers = append(qualifiers, "classifier="+purlEscape(d.Classifier))
+	}
+	if d.Extension != "" && d.Extension != defaultMavenExtension {
+		qualifiers = append(qualifiers, "type="+purlEscape(d.Extension))
+	}
+	if len(qualifiers) > 0 {
+		sb.WriteString("?")
+		sb.WriteString(strings.Join(qualifiers, "&"))
+	}
+	return sb.String()
+}
+
+// purlEscape 对purl的组成部分进行百分号编码，只保留字母、数字和 .-_~。
+func purlEscape(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+			c == '.' || c == '-' || c == '_' || c == '~' {
+			sb.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&sb, "%%%02X", c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,28 @@
+package model
+
+import "testing"
+
+func TestDependencyPackageURL(t *testing.T) {
+	tests := []struct {
+		dep  *Dependency
+		want string
+	}{
+		{&Dependency{Group: "com.google.guava", Name: "guava", Version: "32.1.2-jre"}, "pkg:maven/com.google.guava/guava@32.1.2-jre"},
+		{&Dependency{Group: "org.foo", Name: "bar"}, "pkg:maven/org.foo/bar"},
+		{&Dependency{Group: "net.sf.json-lib", Name: "json-lib", Version: "2.4", Classifier: "jdk15"}, "pkg:maven/net.sf.json-lib/json-lib@2.4?classifier=jdk15"},
+		{&Dependency{Group: "org.foo", Name: "bar", Version: "1.0", Classifier: "sources", Extension: "zip"}, "pkg:maven/org.foo/bar@1.0?classifier=sources&type=zip"},
+		{&Dependency{Group: "org.foo", Name: "bar", Version: "1.0", Extension: "jar"}, "pkg:maven/org.foo/bar@1.0"},
+		{&Dependency{Group: "org.foo", Name: "bar", Version: "$barVersion", HasDynamicVersion: true}, "pkg:maven/org.foo/bar"},
+		{&Dependency{Group: "org.foo", Name: "bar", Version: "$barVersion", HasDynamicVersion: true, ResolvedVersion: "2.0"}, "pkg:maven/org.foo/bar@2.0"},
+		{&Dependency{Group: "org.foo", Name: "bar", Version: "[1.0,2.0)"}, "pkg:maven/org.foo/bar@%5B1.0%2C2.0%29"},
+		{&Dependency{Name: "core", Project: &ProjectRef{Path: ":core"}}, ""},
+		{&Dependency{Name: "${deps.spring}", HasDynamicVersion: true}, ""},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.dep.PackageURL(); got != tt.want {
+			t.Errorf("PackageURL() of %+v = %q, want %q", tt.dep, got, tt.want)
+		}
+	}
+}
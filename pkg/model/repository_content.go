@@ -0,0 +1,106 @@
+// Package model 提供仓库内容过滤条件的数据结构。
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// 内容过滤规则的种类，对应 includeGroup、includeModuleByRegex 等方法名中 include/exclude 之后的部分。
+const (
+	ContentRuleGroup             = "group"
+	ContentRuleGroupByRegex      = "groupByRegex"
+	ContentRuleGroupAndSubgroups = "groupAndSubgroups"
+	ContentRuleModule            = "module"
+	ContentRuleModuleByRegex     = "moduleByRegex"
+	ContentRuleVersion           = "version"
+	ContentRuleVersionByRegex    = "versionByRegex"
+)
+
+// snapshotVersionSuffix 是SNAPSHOT版本的后缀。
+const snapshotVersionSuffix = "-SNAPSHOT"
+
+// ContentRule 是仓库内容过滤中的一条 include* 或 exclude* 规则。
+// Group、Module 和 Version 按规则种类使用，ByRegex 规则中保存的是正则表达式。
+type ContentRule struct {
+	Kind    string `json:"kind"`
+	Group   string `json:"group"`
+	Module  string `json:"module,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// RepositoryContent 表示仓库的 content { }、mavenContent { } 或 exclusiveContent { filter { } } 过滤条件。
+type RepositoryContent struct {
+	Includes []*ContentRule `json:"includes,omitempty"`
+	Excludes []*ContentRule `json:"excludes,omitempty"`
+
+	// ReleasesOnly 和 SnapshotsOnly 来自 mavenContent { releasesOnly() } 等声明。
+	ReleasesOnly  bool `json:"releasesOnly,omitempty"`
+	SnapshotsOnly bool `json:"snapshotsOnly,omitempty"`
+
+	// Exclusive 为true表示条件来自 exclusiveContent：匹配Includes的模块只会在该仓库中查找。
+	Exclusive bool `json:"exclusive,omitempty"`
+}
+
+// Matches 判断规则是否匹配模块，version为空时版本规则只比较group和module。
+func (r *ContentRule) Matches(group, module, version string) bool {
+	switch r.Kind {
+	case ContentRuleGroup:
+		return r.Group == group
+	case ContentRuleGroupByRegex:
+		return fullMatch(r.Group, group)
+	case ContentRuleGroupAndSubgroups:
+		return group == r.Group || strings.HasPrefix(group, r.Group+".")
+	case ContentRuleModule:
+		return r.Group == group && r.Module == module
+	case ContentRuleModuleByRegex:
+		return fullMatch(r.Group, group) && fullMatch(r.Module, module)
+	case ContentRuleVersion:
+		return r.Group == group && r.Module == module && (version == "" || r.Version == version)
+	case ContentRuleVersionByRegex:
+		return fullMatch(r.Group, group) && fullMatch(r.Module, module) && (version == "" || fullMatch(r.Version, version))
+	}
+	return false
+}
+
+// IncludesModule 判断模块是否匹配某条 include* 规则。
+func (c *RepositoryContent) IncludesModule(group, module, version string) bool {
+	return matchesAny(c.Includes, group, module, version)
+}
+
+// Allows 判断仓库是否会被查询该模块：声明了 include* 规则时模块必须匹配其中之一，
+// 匹配 exclude* 规则的模块总是被排除，releasesOnly/snapshotsOnly 按版本是否以 -SNAPSHOT 结尾判断。
+func (c *RepositoryContent) Allows(group, module, version string) bool {
+	if c == nil {
+		return true
+	}
+	if len(c.Includes) > 0 && !c.IncludesModule(group, module, version) {
+		return false
+	}
+	if matchesAny(c.Excludes, group, module, version) {
+		return false
+	}
+	if version != "" {
+		snapshot := strings.HasSuffix(version, snapshotVersionSuffix)
+		if (c.ReleasesOnly && snapshot) || (c.SnapshotsOnly && !snapshot) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAny 判断模块是否匹配任意一条规则。
+func matchesAny(rules []*ContentRule, group, module, version string) bool {
+	for _, rule := range rules {
+		if rule.Matches(group, module, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// fullMatch 判断正则表达式是否匹配整个字符串，与Gradle使用的Java Matcher.matches一致；表达式无效时返回false。
+func fullMatch(pattern, value string) bool {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	return err == nil && re.MatchString(value)
+}
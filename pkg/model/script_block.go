@@ -0,0 +1,61 @@
+// Package model 提供脚本块结构树的查询功能。
+package model
+
+import "strings"
+
+// FindBlock 按点号分隔的路径查找子孙块，例如 "android.defaultConfig"。
+// 同名块出现多次时按出现顺序查找，返回第一个匹配的块，找不到时返回nil。
+func (b *ScriptBlock) FindBlock(path string) *ScriptBlock {
+	blocks := b.FindBlocks(path)
+	if len(blocks) == 0 {
+		return nil
+	}
+	return blocks[0]
+}
+
+// FindBlocks 按点号分隔的路径查找所有匹配的子孙块，按出现顺序返回。
+// 块名称本身可以包含点号，例如 tasks.named('test') 的名称为 tasks.named。
+func (b *ScriptBlock) FindBlocks(path string) []*ScriptBlock {
+	if b == nil || path == "" {
+		return nil
+	}
+	return b.findBlocks(strings.Split(path, "."))
+}
+
+// findBlocks 递归查找与路径段匹配的子孙块。
+func (b *ScriptBlock) findBlocks(segments []string) []*ScriptBlock {
+	found := make([]*ScriptBlock, 0)
+	for _, child := range b.Children {
+		names := strings.Split(child.Name, ".")
+		if len(names) > len(segments) || strings.Join(segments[:len(names)], ".") != child.Name {
+			continue
+		}
+		if len(names) == len(segments) {
+			found = append(found, child)
+		} else {
+			found = append(found, child.findBlocks(segments[len(names):])...)
+		}
+	}
+	return found
+}
+
+// Path 返回从根块的子块开始的点号分隔路径，根块返回空字符串。
+func (b *ScriptBlock) Path() string {
+	names := make([]string, 0)
+	for cur := b; cur != nil && cur.Parent != nil; cur = cur.Parent {
+		names = append(names, cur.Name)
+	}
+	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+		names[i], names[j] = names[j], names[i]
+	}
+	return strings.Join(names, ".")
+}
+
+// GetValue 返回块中指定键的值及其是否存在。
+func (b *ScriptBlock) GetValue(key string) (interface{}, bool) {
+	if b == nil || b.Values == nil {
+		return nil, false
+	}
+	value, ok := b.Values[key]
+	return value, ok
+}
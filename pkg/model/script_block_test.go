@@ -0,0 +1,48 @@
+package model
+
+import "testing"
+
+func TestScriptBlock_FindBlocks(t *testing.T) {
+	root := &ScriptBlock{Name: "root"}
+	add := func(parent *ScriptBlock, name string) *ScriptBlock {
+		child := &ScriptBlock{Name: name, Parent: parent, Values: map[string]interface{}{}}
+		parent.Children = append(parent.Children, child)
+		return child
+	}
+
+	first := add(root, "android")
+	add(first, "buildTypes")
+	second := add(root, "android")
+	config := add(second, "defaultConfig")
+	config.Values["minSdk"] = "24"
+	named := add(root, "tasks.named")
+	add(named, "doLast")
+
+	if got := root.FindBlock("android.defaultConfig"); got != config {
+		t.Errorf("FindBlock returned %+v, want defaultConfig in second android block", got)
+	}
+	if got := root.FindBlocks("android"); len(got) != 2 {
+		t.Errorf("Expected 2 android blocks, got %d", len(got))
+	}
+	if got := root.FindBlock("tasks.named.doLast"); got == nil {
+		t.Error("Expected to match block names containing dots")
+	}
+	if root.FindBlock("android.missing") != nil || root.FindBlock("") != nil {
+		t.Error("Expected nil for missing path")
+	}
+
+	if config.Path() != "android.defaultConfig" || root.Path() != "" {
+		t.Errorf("Unexpected paths %q, %q", config.Path(), root.Path())
+	}
+	if value, ok := config.GetValue("minSdk"); !ok || value != "24" {
+		t.Errorf("GetValue(minSdk) = %v, %v", value, ok)
+	}
+
+	var nilBlock *ScriptBlock
+	if nilBlock.FindBlock("android") != nil {
+		t.Error("Expected nil block to find nothing")
+	}
+	if _, ok := nilBlock.GetValue("x"); ok {
+		t.Error("Expected nil block to have no values")
+	}
+}
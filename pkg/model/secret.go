@@ -0,0 +1,22 @@
+// Package model 提供硬编码凭证扫描结果的数据模型。
+package model
+
+// CredentialKind 表示硬编码凭证的类别。
+type CredentialKind string
+
+const (
+	CredentialKindUsername CredentialKind = "username"
+	CredentialKindPassword CredentialKind = "password"
+	CredentialKindToken    CredentialKind = "token" // 令牌、访问密钥、HTTP头认证值等。
+)
+
+// HardcodedCredential 表示直接以字面量写在构建脚本或properties文件中的凭证。
+type HardcodedCredential struct {
+	FilePath string         `json:"filePath,omitempty"`
+	Key      string         `json:"key"` // 凭证项，例如 password、secretKey，或properties文件中的属性名。
+	Kind     CredentialKind `json:"kind"`
+	// Preview 是声明所在的行，其中的凭证值已被遮盖，可以直接用于报告。
+	Preview string `json:"preview"`
+	// SourceRange 是凭证值（不含引号）在文件中的位置。
+	SourceRange SourceRange `json:"sourceRange"`
+}
@@ -1,15 +1,33 @@
 // Package model 提供源码位置追踪相关的数据结构。
 package model
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
 
 // SourcePosition 表示源码中的位置信息。
+// 偏移量和长度按字节计算，可以直接用于切分原始文本；列号按字符（rune）计算，多字节字符计为一列。
 type SourcePosition struct {
 	Line     int `json:"line"`     // 行号（1-based）。
-	Column   int `json:"column"`   // 列号（1-based）。
-	StartPos int `json:"startPos"` // 在原始文本中的起始位置（0-based）。
-	EndPos   int `json:"endPos"`   // 在原始文本中的结束位置（0-based）。
-	Length   int `json:"length"`   // 文本长度。
+	Column   int `json:"column"`   // 字符列号（1-based）。
+	StartPos int `json:"startPos"` // 在原始文本中的起始字节偏移量（0-based）。
+	EndPos   int `json:"endPos"`   // 在原始文本中的结束字节偏移量（0-based）。
+	Length   int `json:"length"`   // 文本的字节长度。
+}
+
+// RuneColumn 返回字节偏移量在所在行中的字符列号（1-based）。
+// 偏移量超出文本时按文本末尾计算。
+func RuneColumn(text string, offset int) int {
+	if offset > len(text) {
+		offset = len(text)
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+	return utf8.RuneCountInString(text[lineStart:offset]) + 1
 }
 
 // SourceRange 表示源码中的范围。
@@ -32,7 +50,18 @@ func (sr SourceRange) String() string {
 type SourceMappedDependency struct {
 	*Dependency
 	SourceRange SourceRange `json:"sourceRange"`
-	RawText     string      `json:"rawText"` // 原始文本片段。
+	RawText     string      `json:"rawText"`            // 原始文本片段。
+	Comments    []*Comment  `json:"comments,omitempty"` // 附加到该声明的注释。
+}
+
+// SourceMappedConstraint 带位置信息的依赖约束。
+type SourceMappedConstraint struct {
+	*DependencyConstraint
+	SourceRange     SourceRange `json:"sourceRange"`     // 从配置名到配置闭包结束的范围。
+	RawText         string      `json:"rawText"`         // 原始文本片段。
+	CoordinateRange SourceRange `json:"coordinateRange"` // 坐标字符串（不含引号）的范围，map形式时为零值。
+	// VersionRange 是生效版本（见 DependencyConstraint.Version）文本的范围，没有版本时为零值。
+	VersionRange SourceRange `json:"versionRange"`
 }
 
 // SourceMappedPlugin 带源码位置信息的插件。
@@ -40,6 +69,7 @@ type SourceMappedPlugin struct {
 	*Plugin
 	SourceRange SourceRange `json:"sourceRange"`
 	RawText     string      `json:"rawText"`
+	Comments    []*Comment  `json:"comments,omitempty"`
 }
 
 // SourceMappedRepository 带源码位置信息的仓库。
@@ -53,8 +83,68 @@ type SourceMappedRepository struct {
 type SourceMappedProperty struct {
 	Key         string      `json:"key"`
 	Value       string      `json:"value"`
+	Expression  *Expression `json:"expression,omitempty"` // 值的表达式树。
 	SourceRange SourceRange `json:"sourceRange"`
 	RawText     string      `json:"rawText"`
+	Comments    []*Comment  `json:"comments,omitempty"`
+}
+
+// SourceMappedTask 带源码位置信息的任务。
+type SourceMappedTask struct {
+	*Task
+	SourceRange SourceRange `json:"sourceRange"`
+	RawText     string      `json:"rawText"` // 任务声明的头部文本。
+}
+
+// SourceMappedBlock 带源码位置信息的脚本块，例如 android、publishing、buildscript。
+type SourceMappedBlock struct {
+	Name        string      `json:"name"`        // 块名称，例如 defaultConfig。
+	Path        string      `json:"path"`        // 从顶层开始的路径，例如 android.defaultConfig。
+	Header      string      `json:"header"`      // 左花括号之前的头部文本。
+	Depth       int         `json:"depth"`       // 嵌套深度，顶层块为0。
+	SourceRange SourceRange `json:"sourceRange"` // 从头部到右花括号的范围。
+	BodyRange   SourceRange `json:"bodyRange"`   // 花括号内部的范围。
+}
+
+// CommentKind 表示注释的类型。
+type CommentKind string
+
+const (
+	CommentLine  CommentKind = "line"  // 以 // 开头的行注释。
+	CommentBlock CommentKind = "block" // /* */ 块注释，包括 /** */ 文档注释。
+)
+
+// Comment 表示源码中的一条注释。
+type Comment struct {
+	Kind        CommentKind `json:"kind"`
+	Text        string      `json:"text"`     // 去除注释标记和首尾空白后的内容。
+	RawText     string      `json:"rawText"`  // 包含注释标记的原始文本。
+	Trailing    bool        `json:"trailing"` // 是否为跟在代码之后的行尾注释。
+	SourceRange SourceRange `json:"sourceRange"`
+}
+
+// SourceNodeKind 表示源码节点的类型。
+type SourceNodeKind string
+
+const (
+	SourceNodeDependency SourceNodeKind = "dependency"
+	SourceNodePlugin     SourceNodeKind = "plugin"
+	SourceNodeRepository SourceNodeKind = "repository"
+	SourceNodeProperty   SourceNodeKind = "property"
+	SourceNodeTask       SourceNodeKind = "task"
+	SourceNodeBlock      SourceNodeKind = "block"
+)
+
+// SourceNode 表示位于某个位置的源码节点，根据Kind只有对应的字段非空。
+type SourceNode struct {
+	Kind        SourceNodeKind          `json:"kind"`
+	SourceRange SourceRange             `json:"sourceRange"`
+	Dependency  *SourceMappedDependency `json:"dependency,omitempty"`
+	Plugin      *SourceMappedPlugin     `json:"plugin,omitempty"`
+	Repository  *SourceMappedRepository `json:"repository,omitempty"`
+	Property    *SourceMappedProperty   `json:"property,omitempty"`
+	Task        *SourceMappedTask       `json:"task,omitempty"`
+	Block       *SourceMappedBlock      `json:"block,omitempty"`
 }
 
 // SourceMappedProject 带源码位置信息的项目。
@@ -66,10 +156,33 @@ type SourceMappedProject struct {
 	SourceMappedPlugins      []*SourceMappedPlugin     `json:"sourceMappedPlugins"`
 	SourceMappedRepositories []*SourceMappedRepository `json:"sourceMappedRepositories"`
 	SourceMappedProperties   []*SourceMappedProperty   `json:"sourceMappedProperties"`
+	SourceMappedTasks        []*SourceMappedTask       `json:"sourceMappedTasks"`
+	SourceMappedBlocks       []*SourceMappedBlock      `json:"sourceMappedBlocks"`
+	SourceMappedConstraints  []*SourceMappedConstraint `json:"sourceMappedConstraints,omitempty"`
+
+	// 保留注释时收集的所有注释，按出现顺序排列。
+	Comments []*Comment `json:"comments,omitempty"`
 
 	// 原始文本信息。
 	OriginalText string   `json:"originalText"`
-	Lines        []string `json:"lines"` // 按行分割的原始文本。
+	Lines        []string `json:"lines"` // 按 \n 分割的原始文本，CRLF文件中每行末尾保留 \r，以便按行计算偏移量。
+
+	// LineEnding 是文件使用的换行符（LineEndingLF 或 LineEndingCRLF）。
+	LineEnding string `json:"lineEnding,omitempty"`
+}
+
+// 文件的换行风格。
+const (
+	LineEndingLF   = "\n"
+	LineEndingCRLF = "\r\n"
+)
+
+// DetectLineEnding 根据第一个换行符检测文本的换行风格，没有换行符时返回 LineEndingLF。
+func DetectLineEnding(text string) string {
+	if i := strings.IndexByte(text, '\n'); i > 0 && text[i-1] == '\r' {
+		return LineEndingCRLF
+	}
+	return LineEndingLF
 }
 
 // SourceMappedParseResult 带源码位置信息的解析结果。
@@ -135,3 +248,86 @@ func (smp *SourceMappedProject) FindPropertyByKey(key string) *SourceMappedPrope
 	}
 	return nil
 }
+
+// FindTaskByName 根据名称查找任务。
+func (smp *SourceMappedProject) FindTaskByName(name string) *SourceMappedTask {
+	for _, task := range smp.SourceMappedTasks {
+		if task.Name == name {
+			return task
+		}
+	}
+	return nil
+}
+
+// FindBlockByPosition 根据位置查找包含该位置的最内层块。
+func (smp *SourceMappedProject) FindBlockByPosition(line, column int) *SourceMappedBlock {
+	var found *SourceMappedBlock
+	for _, block := range smp.SourceMappedBlocks {
+		if !rangeContains(block.SourceRange, line, column) {
+			continue
+		}
+		if found == nil || block.Depth > found.Depth {
+			found = block
+		}
+	}
+	return found
+}
+
+// FindBlocksByPath 根据路径查找块，例如 "android.defaultConfig"。
+func (smp *SourceMappedProject) FindBlocksByPath(path string) []*SourceMappedBlock {
+	blocks := make([]*SourceMappedBlock, 0)
+	for _, block := range smp.SourceMappedBlocks {
+		if block.Path == path {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// FindNodeAtPosition 查找位置上最具体的源码节点。
+// 依赖、插件、仓库和属性优先于任务，任务优先于块；未找到时返回nil。
+func (smp *SourceMappedProject) FindNodeAtPosition(line, column int) *SourceNode {
+	for _, dep := range smp.SourceMappedDependencies {
+		if rangeContains(dep.SourceRange, line, column) {
+			return &SourceNode{Kind: SourceNodeDependency, SourceRange: dep.SourceRange, Dependency: dep}
+		}
+	}
+	for _, plugin := range smp.SourceMappedPlugins {
+		if rangeContains(plugin.SourceRange, line, column) {
+			return &SourceNode{Kind: SourceNodePlugin, SourceRange: plugin.SourceRange, Plugin: plugin}
+		}
+	}
+	for _, repo := range smp.SourceMappedRepositories {
+		if rangeContains(repo.SourceRange, line, column) {
+			return &SourceNode{Kind: SourceNodeRepository, SourceRange: repo.SourceRange, Repository: repo}
+		}
+	}
+	for _, prop := range smp.SourceMappedProperties {
+		if rangeContains(prop.SourceRange, line, column) {
+			return &SourceNode{Kind: SourceNodeProperty, SourceRange: prop.SourceRange, Property: prop}
+		}
+	}
+	for _, task := range smp.SourceMappedTasks {
+		if rangeContains(task.SourceRange, line, column) {
+			return &SourceNode{Kind: SourceNodeTask, SourceRange: task.SourceRange, Task: task}
+		}
+	}
+	if block := smp.FindBlockByPosition(line, column); block != nil {
+		return &SourceNode{Kind: SourceNodeBlock, SourceRange: block.SourceRange, Block: block}
+	}
+	return nil
+}
+
+// rangeContains 判断行列位置是否位于范围内。
+func rangeContains(sourceRange SourceRange, line, column int) bool {
+	if line < sourceRange.Start.Line || line > sourceRange.End.Line {
+		return false
+	}
+	if line == sourceRange.Start.Line && column < sourceRange.Start.Column {
+		return false
+	}
+	if line == sourceRange.End.Line && column > sourceRange.End.Column {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+// Package model 提供源码集配置的数据结构。
+package model
+
+// 源码集中常见的目录类型。
+const (
+	SourceDirJava      = "java"
+	SourceDirKotlin    = "kotlin"
+	SourceDirGroovy    = "groovy"
+	SourceDirScala     = "scala"
+	SourceDirResources = "resources"
+	SourceDirRes       = "res"    // Android资源目录。
+	SourceDirAssets    = "assets" // Android assets目录。
+	SourceDirAIDL      = "aidl"
+	SourceDirJniLibs   = "jniLibs"
+)
+
+// SourceSet 表示 sourceSets { } 中声明的一个源码集。
+// 只记录脚本中显式声明的目录，不包含Gradle按约定使用的默认目录（例如 src/main/java）。
+type SourceSet struct {
+	Name string `json:"name"`
+	// Android 为true表示在 android { sourceSets { } } 中声明。
+	Android bool `json:"android,omitempty"`
+	// Directories 按目录类型（java、kotlin、resources、res 等）分组的源码目录，按声明顺序排列。
+	// 使用 srcDirs = [...] 或 setSrcDirs(...) 赋值时替换之前声明的目录。
+	Directories map[string][]string `json:"directories,omitempty"`
+	// Manifest 是Android源码集 manifest.srcFile 指定的清单文件。
+	Manifest string `json:"manifest,omitempty"`
+}
+
+// Dirs 返回指定类型的目录，没有声明时返回nil。
+func (s *SourceSet) Dirs(kind string) []string {
+	return s.Directories[kind]
+}
@@ -371,3 +371,127 @@ func TestSourceMappedPlugin(t *testing.T) {
 		t.Errorf("Expected start line 3, got %d", sourceMappedPlugin.SourceRange.Start.Line)
 	}
 }
+
+func TestSourceMappedProject_FindTaskAndBlock(t *testing.T) {
+	project := &SourceMappedProject{
+		SourceMappedTasks: []*SourceMappedTask{
+			{Task: &Task{Name: "hello"}},
+		},
+		SourceMappedBlocks: []*SourceMappedBlock{
+			{
+				Name: "android", Path: "android", Depth: 0,
+				SourceRange: SourceRange{Start: SourcePosition{Line: 1, Column: 1}, End: SourcePosition{Line: 10, Column: 1}},
+			},
+			{
+				Name: "defaultConfig", Path: "android.defaultConfig", Depth: 1,
+				SourceRange: SourceRange{Start: SourcePosition{Line: 2, Column: 5}, End: SourcePosition{Line: 4, Column: 5}},
+			},
+		},
+	}
+
+	if task := project.FindTaskByName("hello"); task == nil || task.Name != "hello" {
+		t.Error("Should find task hello")
+	}
+	if task := project.FindTaskByName("missing"); task != nil {
+		t.Error("Should not find missing task")
+	}
+
+	if block := project.FindBlockByPosition(3, 1); block == nil || block.Name != "defaultConfig" {
+		t.Errorf("Expected innermost defaultConfig block, got %+v", block)
+	}
+	if block := project.FindBlockByPosition(2, 1); block == nil || block.Name != "android" {
+		t.Errorf("Column before defaultConfig should resolve to android, got %+v", block)
+	}
+	if block := project.FindBlockByPosition(11, 1); block != nil {
+		t.Errorf("Position outside blocks should return nil, got %+v", block)
+	}
+
+	if blocks := project.FindBlocksByPath("android.defaultConfig"); len(blocks) != 1 {
+		t.Errorf("Expected 1 block for path, got %d", len(blocks))
+	}
+}
+
+func TestSourceMappedProject_FindNodeAtPosition(t *testing.T) {
+	dep := &SourceMappedDependency{
+		Dependency:  &Dependency{Group: "com.example", Name: "lib"},
+		SourceRange: SourceRange{Start: SourcePosition{Line: 3, Column: 5}, End: SourcePosition{Line: 3, Column: 30}},
+	}
+	task := &SourceMappedTask{
+		Task:        &Task{Name: "hello"},
+		SourceRange: SourceRange{Start: SourcePosition{Line: 6, Column: 1}, End: SourcePosition{Line: 8, Column: 1}},
+	}
+	block := &SourceMappedBlock{
+		Name: "dependencies", Path: "dependencies",
+		SourceRange: SourceRange{Start: SourcePosition{Line: 2, Column: 1}, End: SourcePosition{Line: 4, Column: 1}},
+	}
+	project := &SourceMappedProject{
+		SourceMappedDependencies: []*SourceMappedDependency{dep},
+		SourceMappedTasks:        []*SourceMappedTask{task},
+		SourceMappedBlocks:       []*SourceMappedBlock{block},
+	}
+
+	tests := []struct {
+		name   string
+		line   int
+		column int
+		kind   SourceNodeKind
+	}{
+		{"Dependency wins over enclosing block", 3, 10, SourceNodeDependency},
+		{"Block outside dependency", 2, 3, SourceNodeBlock},
+		{"Task", 7, 5, SourceNodeTask},
+		{"Nothing", 10, 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := project.FindNodeAtPosition(tt.line, tt.column)
+			if tt.kind == "" {
+				if node != nil {
+					t.Errorf("Expected nil, got %+v", node)
+				}
+				return
+			}
+			if node == nil || node.Kind != tt.kind {
+				t.Fatalf("Expected kind %s, got %+v", tt.kind, node)
+			}
+		})
+	}
+
+	if node := project.FindNodeAtPosition(3, 10); node.Dependency != dep || node.Block != nil {
+		t.Error("Dependency node should only carry the dependency")
+	}
+}
+
+func TestDetectLineEnding(t *testing.T) {
+	tests := map[string]string{
+		"":                    LineEndingLF,
+		"plugins {}":          LineEndingLF,
+		"a\nb\r\n":            LineEndingLF,
+		"a\r\nb\n":            LineEndingCRLF,
+		"group = 'x'\r\n\r\n": LineEndingCRLF,
+	}
+	for text, expected := range tests {
+		if got := DetectLineEnding(text); got != expected {
+			t.Errorf("DetectLineEnding(%q) = %q, want %q", text, got, expected)
+		}
+	}
+}
+
+func TestRuneColumn(t *testing.T) {
+	text := "a\n中文x\n"
+	tests := []struct {
+		offset   int
+		expected int
+	}{
+		{0, 1},
+		{2, 1},
+		{5, 2},
+		{8, 3},
+		{100, 1},
+	}
+	for _, tt := range tests {
+		if got := RuneColumn(text, tt.offset); got != tt.expected {
+			t.Errorf("RuneColumn(%d) = %d, want %d", tt.offset, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Package model 提供Spring Boot项目元数据的数据结构。
+package model
+
+// Spring Boot主类的来源。
+const (
+	MainClassSourceBootJar     = "bootJar"     // bootJar { mainClass }。
+	MainClassSourceSpringBoot  = "springBoot"  // springBoot { mainClass }。
+	MainClassSourceApplication = "application" // application { mainClass } 或 mainClassName。
+)
+
+// SpringBootConfig 表示脚本中springBoot扩展和bootJar任务的配置，值为字面量时去掉引号，否则保留原始表达式。
+type SpringBootConfig struct {
+	// MainClass 是bootJar使用的主类，依次取 bootJar、springBoot 和 application 中的配置。
+	MainClass       string `json:"mainClass,omitempty"`
+	MainClassSource string `json:"mainClassSource,omitempty"` // MainClassSource* 之一。
+
+	// bootJar归档命名，也识别旧版本的 archiveName、baseName 和 classifier 写法。
+	ArchiveFileName   string `json:"archiveFileName,omitempty"`
+	ArchiveBaseName   string `json:"archiveBaseName,omitempty"`
+	ArchiveVersion    string `json:"archiveVersion,omitempty"`
+	ArchiveClassifier string `json:"archiveClassifier,omitempty"`
+
+	// BootJarDisabled 为true表示 bootJar { enabled = false }，通常是只发布普通jar的库模块。
+	BootJarDisabled bool `json:"bootJarDisabled,omitempty"`
+}
+
+// SpringBootInfo 汇总Spring Boot项目的元数据，供部署工具使用。
+type SpringBootInfo struct {
+	// PluginVersion 是 org.springframework.boot 插件的版本，包括通过buildscript classpath确定的版本。
+	PluginVersion string `json:"pluginVersion,omitempty"`
+
+	// DependencyManagement 表示是否应用了 io.spring.dependency-management 插件。
+	DependencyManagement        bool   `json:"dependencyManagement"`
+	DependencyManagementVersion string `json:"dependencyManagementVersion,omitempty"`
+
+	SpringBootConfig
+}
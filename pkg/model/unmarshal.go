@@ -0,0 +1,79 @@
+// Package model 提供从导出的JSON还原解析结果的功能。
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// parseResultFields 与 ParseResult 字段相同，但没有方法，用于在还原时替换 Errors 字段的解码方式。
+type parseResultFields ParseResult
+
+// parseResultJSON 是还原解析结果时使用的结构。Errors 在导出时是字符串或空对象，不能直接解码为 error。
+type parseResultJSON struct {
+	*parseResultFields
+	Errors              []json.RawMessage    `json:"errors,omitempty"`
+	SourceMappedProject *SourceMappedProject `json:"sourceMappedProject,omitempty"`
+}
+
+// UnmarshalProject 从 json.Marshal 或 export.ToJSON 导出的JSON还原项目。
+// ProjectRef.Module 不会导出，还原后为nil。
+func UnmarshalProject(data []byte) (*Project, error) {
+	project := &Project{}
+	if err := json.Unmarshal(data, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// UnmarshalParseResult 从导出的JSON还原解析结果。
+// Errors 中的错误还原为只包含消息的错误，导出时丢失了内容的错误使用原始JSON作为消息；RootBlock 不会导出，还原后为nil。
+func UnmarshalParseResult(data []byte) (*ParseResult, error) {
+	decoded, err := unmarshalParseResult(data)
+	if err != nil {
+		return nil, err
+	}
+	return (*ParseResult)(decoded.parseResultFields), nil
+}
+
+// UnmarshalSourceMappedParseResult 从导出的JSON还原带位置信息的解析结果，还原后可以直接用于创建编辑器。
+// 解析结果与带位置信息的项目引用同一个 Project，与解析时相同；只导出了原始文本时按原始文本重建 Lines。
+func UnmarshalSourceMappedParseResult(data []byte) (*SourceMappedParseResult, error) {
+	decoded, err := unmarshalParseResult(data)
+	if err != nil {
+		return nil, err
+	}
+	result := &SourceMappedParseResult{
+		ParseResult:         (*ParseResult)(decoded.parseResultFields),
+		SourceMappedProject: decoded.SourceMappedProject,
+	}
+
+	if smp := result.SourceMappedProject; smp != nil {
+		if result.Project != nil {
+			smp.Project = result.Project
+		} else {
+			result.Project = smp.Project
+		}
+		if len(smp.Lines) == 0 && smp.OriginalText != "" {
+			smp.Lines = strings.Split(smp.OriginalText, "\n")
+		}
+	}
+	return result, nil
+}
+
+// unmarshalParseResult 解码解析结果并还原其中的错误。
+func unmarshalParseResult(data []byte) (*parseResultJSON, error) {
+	decoded := &parseResultJSON{parseResultFields: &parseResultFields{}}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		return nil, err
+	}
+	for _, raw := range decoded.Errors {
+		var message string
+		if err := json.Unmarshal(raw, &message); err != nil {
+			message = string(raw)
+		}
+		decoded.parseResultFields.Errors = append(decoded.parseResultFields.Errors, errors.New(message))
+	}
+	return decoded, nil
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalProject(t *testing.T) {
+	project := &Project{
+		Name:         "app",
+		Version:      "1.0.0",
+		Properties:   map[string]string{"kotlinVersion": "1.9.0"},
+		Dependencies: []*Dependency{{Group: "org.foo", Name: "bar", Version: "1.0", Scope: "implementation"}},
+		Plugins:      []*Plugin{{ID: "java", Apply: true}},
+		Repositories: []*Repository{{Name: "mavenCentral", Type: "maven",
+			Content: &RepositoryContent{Excludes: []*ContentRule{{Kind: ContentRuleGroup, Group: "com.mycorp"}}}}},
+		SubProjects: []*Project{{Name: "lib"}},
+		PropertyExpressions: map[string]*Expression{
+			"version": {Kind: ExpressionReference, Raw: "rootProject.version", Path: []string{"rootProject", "version"}},
+		},
+	}
+	data, err := json.Marshal(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := UnmarshalProject(data)
+	if err != nil {
+		t.Fatalf("UnmarshalProject() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, project) {
+		t.Errorf("UnmarshalProject() = %+v, want %+v", got, project)
+	}
+
+	if _, err := UnmarshalProject([]byte("{")); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestUnmarshalParseResult(t *testing.T) {
+	data := []byte(`{"project":{"name":"app"},"errors":["第3行: 未闭合的块",{}],"warnings":[{"code":"parse-error","severity":"warning","message":"x","sourceRange":{"start":{"line":1},"end":{"line":1}}}],"fileKind":"build"}`)
+	result, err := UnmarshalParseResult(data)
+	if err != nil {
+		t.Fatalf("UnmarshalParseResult() error = %v", err)
+	}
+	if result.Project == nil || result.Project.Name != "app" || result.FileKind != FileKindBuild || len(result.Warnings) != 1 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	want := []error{errors.New("第3行: 未闭合的块"), errors.New("{}")}
+	if len(result.Errors) != len(want) {
+		t.Fatalf("Errors = %v, want %v", result.Errors, want)
+	}
+	for i := range want {
+		if result.Errors[i].Error() != want[i].Error() {
+			t.Errorf("Errors[%d] = %q, want %q", i, result.Errors[i], want[i])
+		}
+	}
+}
+
+func TestUnmarshalSourceMappedParseResult(t *testing.T) {
+	project := &Project{Name: "app", Dependencies: []*Dependency{{Group: "org.foo", Name: "bar", Version: "1.0"}}}
+	original := &SourceMappedParseResult{
+		ParseResult: &ParseResult{Project: project},
+		SourceMappedProject: &SourceMappedProject{
+			Project: project,
+			SourceMappedDependencies: []*SourceMappedDependency{{
+				Dependency: &Dependency{Group: "org.foo", Name: "bar", Version: "1.0"},
+				RawText:    "implementation 'org.foo:bar:1.0'",
+			}},
+			OriginalText: "dependencies {\n    implementation 'org.foo:bar:1.0'\n}\n",
+		},
+	}
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := UnmarshalSourceMappedParseResult(data)
+	if err != nil {
+		t.Fatalf("UnmarshalSourceMappedParseResult() error = %v", err)
+	}
+	if result.SourceMappedProject == nil || result.SourceMappedProject.Project != result.Project {
+		t.Fatal("Source-mapped project should share the result's project")
+	}
+	if result.Project.Name != "app" || len(result.Project.Dependencies) != 1 {
+		t.Errorf("Unexpected project %+v", result.Project)
+	}
+	if got := result.SourceMappedProject.SourceMappedDependencies[0]; got.Version != "1.0" || got.RawText != "implementation 'org.foo:bar:1.0'" {
+		t.Errorf("Unexpected source-mapped dependency %+v", got)
+	}
+	if lines := result.SourceMappedProject.Lines; len(lines) != 4 || lines[1] != "    implementation 'org.foo:bar:1.0'" {
+		t.Errorf("Lines should be rebuilt from the original text, got %q", lines)
+	}
+}
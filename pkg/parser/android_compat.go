@@ -0,0 +1,89 @@
+// Package parser 提供Android Gradle插件兼容性问题的定位功能。
+package parser
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/scagogogo/gradle-parser/pkg/config"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配plugins块中声明了版本的AGP插件，例如 id 'com.android.application' version '8.1.0'。
+	agpPluginVersionRegex = regexp.MustCompile(
+		`\bid\s*\(?\s*['"]com\.android\.(?:application|library|test|dynamic-feature)['"]\s*\)?\s*version\s*\(?\s*['"](\d[^'"]*)['"]`)
+	// 匹配buildscript classpath中的AGP，例如 classpath 'com.android.tools.build:gradle:8.1.0'。
+	agpClasspathRegex = regexp.MustCompile(`['"]com\.android\.tools\.build:gradle:(\d[^'"]*)['"]`)
+
+	// 匹配声明了版本的Kotlin插件，例如 id 'org.jetbrains.kotlin.android' version '1.9.0' 或 kotlin("android") version "1.9.0"。
+	kotlinPluginVersionRegex = regexp.MustCompile(
+		`(?:\bid\s*\(?\s*['"]org\.jetbrains\.kotlin\.(?:android|jvm|multiplatform)['"]\s*\)?|\bkotlin\s*\(\s*"(?:android|jvm|multiplatform)"\s*\))` +
+			`\s*version\s*\(?\s*['"](\d[^'"]*)['"]`)
+	// 匹配buildscript classpath中的Kotlin插件，例如 classpath 'org.jetbrains.kotlin:kotlin-gradle-plugin:1.9.0'。
+	kotlinClasspathRegex = regexp.MustCompile(`['"]org\.jetbrains\.kotlin:kotlin-gradle-plugin:(\d[^'"]*)['"]`)
+
+	// 匹配compileSdk配置，例如 compileSdk 34、compileSdk = 34、compileSdkVersion "android-34"。
+	compileSdkRegex = regexp.MustCompile(`\bcompileSdk(?:Version)?\s*(?:=\s*|\(\s*|[ \t]+)['"]?(?:android-)?(\d+)`)
+)
+
+// FindAndroidCompatibilityProblems 从脚本中读取AGP版本、Kotlin插件版本和compileSdk，按 config.CheckAndroidCompatibility
+// 检查版本组合，并把问题定位到相应的声明：compileSdk问题定位到compileSdk的值，Kotlin问题定位到Kotlin插件版本，
+// Gradle版本问题定位到AGP版本。注释中的内容会被忽略。
+// toolchain 提供脚本中没有声明的值，例如根项目中声明的AGP版本和Wrapper中的Gradle版本，可以为nil；
+// 脚本中声明的值优先。值来自toolchain时诊断没有位置信息。
+func FindAndroidCompatibilityProblems(content string, toolchain *config.AndroidToolchain) []*model.Diagnostic {
+	code := maskComments(content)
+	index := newLineIndex(content)
+
+	effective := &config.AndroidToolchain{}
+	if toolchain != nil {
+		*effective = *toolchain
+	}
+	// 各个值在脚本中的位置，未在脚本中声明时为nil。
+	var agpRange, kotlinRange, sdkRange *model.SourceRange
+	locate := func(regexes ...*regexp.Regexp) (string, *model.SourceRange) {
+		for _, re := range regexes {
+			if m := re.FindStringSubmatchIndex(code); m != nil {
+				r := index.rangeOf(m[2], m[3])
+				return code[m[2]:m[3]], &r
+			}
+		}
+		return "", nil
+	}
+
+	if version, r := locate(agpPluginVersionRegex, agpClasspathRegex); r != nil {
+		effective.AGPVersion, agpRange = version, r
+	}
+	if version, r := locate(kotlinPluginVersionRegex, kotlinClasspathRegex); r != nil {
+		effective.KotlinVersion, kotlinRange = version, r
+	}
+	if sdk, r := locate(compileSdkRegex); r != nil {
+		if n, err := strconv.Atoi(sdk); err == nil {
+			effective.CompileSdk, sdkRange = n, r
+		}
+	}
+
+	diagnostics := make([]*model.Diagnostic, 0)
+	for _, problem := range config.CheckAndroidCompatibility(effective) {
+		diag := &model.Diagnostic{
+			Code:     problem.Code,
+			Severity: problem.Severity,
+			Message:  problem.Message,
+		}
+		var r *model.SourceRange
+		switch problem.Code {
+		case model.DiagnosticAndroidCompileSdk:
+			r = sdkRange
+		case model.DiagnosticAndroidKotlinVersion:
+			r = kotlinRange
+		case model.DiagnosticAndroidGradleVersion:
+			r = agpRange
+		}
+		if r != nil {
+			diag.SourceRange = *r
+		}
+		diagnostics = append(diagnostics, diag)
+	}
+	return diagnostics
+}
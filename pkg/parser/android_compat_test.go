@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/config"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestFindAndroidCompatibilityProblems(t *testing.T) {
+	content := `plugins {
+    id 'com.android.application' version '7.4.2'
+    id 'org.jetbrains.kotlin.android' version '1.8.0'
+}
+
+android {
+    // compileSdk 30
+    compileSdk 34
+}
+`
+	diagnostics := FindAndroidCompatibilityProblems(content, &config.AndroidToolchain{GradleVersion: "7.4", CompileSdk: 30})
+
+	want := []struct {
+		code   string
+		line   int
+		column int
+	}{
+		{model.DiagnosticAndroidCompileSdk, 8, 16},
+		{model.DiagnosticAndroidKotlinVersion, 3, 48},
+		{model.DiagnosticAndroidGradleVersion, 2, 43},
+	}
+	if len(diagnostics) != len(want) {
+		t.Fatalf("Expected %d diagnostics, got %d: %v", len(want), len(diagnostics), diagnostics)
+	}
+	for i, w := range want {
+		diag := diagnostics[i]
+		if diag.Code != w.code || diag.SourceRange.Start.Line != w.line || diag.SourceRange.Start.Column != w.column {
+			t.Errorf("diagnostics[%d] = %s, want %s at line %d, col %d", i, diag, w.code, w.line, w.column)
+		}
+	}
+}
+
+func TestFindAndroidCompatibilityProblems_Classpath(t *testing.T) {
+	content := `buildscript {
+    dependencies {
+        classpath "com.android.tools.build:gradle:8.0.2"
+        classpath "org.jetbrains.kotlin:kotlin-gradle-plugin:1.8.22"
+    }
+}
+`
+	diagnostics := FindAndroidCompatibilityProblems(content, nil)
+	if len(diagnostics) != 1 || diagnostics[0].Code != model.DiagnosticAndroidKotlinVersion {
+		t.Fatalf("Expected one Kotlin diagnostic, got %v", diagnostics)
+	}
+	if diagnostics[0].Severity != model.SeverityWarning || diagnostics[0].SourceRange.Start.Line != 4 {
+		t.Errorf("Unexpected diagnostic: %s", diagnostics[0])
+	}
+
+	// 脚本中没有声明的值来自toolchain，诊断没有位置信息。
+	diagnostics = FindAndroidCompatibilityProblems("android { }", &config.AndroidToolchain{AGPVersion: "8.0.0", CompileSdk: 35})
+	if len(diagnostics) != 1 || diagnostics[0].SourceRange.Start.Line != 0 {
+		t.Errorf("Expected one unpositioned compileSdk diagnostic, got %v", diagnostics)
+	}
+}
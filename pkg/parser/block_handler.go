@@ -0,0 +1,124 @@
+// Package parser 提供自定义脚本块处理器的注册功能。
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// ErrInvalidBlockHandler 表示注册的块处理器为nil或没有块名称。
+var ErrInvalidBlockHandler = errors.New("无效的块处理器")
+
+// BlockHandler 处理解析过程中遇到的脚本块，例如 shadowJar { }、jib { } 或私有DSL，
+// 可以把从块中提取的数据写入 Project.Extensions。
+type BlockHandler interface {
+	// BlockName 返回处理器关心的块名称，与 ScriptBlock.Name 比较，例如 shadowJar。
+	BlockName() string
+
+	// HandleBlock 处理一个名称匹配的块，同名的块出现多次时会被调用多次。
+	// 返回的错误记录在解析结果的Errors中，不会中止解析。
+	HandleBlock(block *model.ScriptBlock, project *model.Project) error
+}
+
+// blockHandlerFunc 是用函数实现的块处理器。
+type blockHandlerFunc struct {
+	name string
+	fn   func(block *model.ScriptBlock, project *model.Project) error
+}
+
+func (h *blockHandlerFunc) BlockName() string { return h.name }
+
+func (h *blockHandlerFunc) HandleBlock(block *model.ScriptBlock, project *model.Project) error {
+	return h.fn(block, project)
+}
+
+// NewBlockHandler 用函数创建处理name块的处理器。
+func NewBlockHandler(name string, fn func(block *model.ScriptBlock, project *model.Project) error) BlockHandler {
+	return &blockHandlerFunc{name: name, fn: fn}
+}
+
+// BlockHandlerRegistry 保存按块名称注册的处理器，可以被多个goroutine并发使用。
+type BlockHandlerRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string][]BlockHandler
+}
+
+// DefaultBlockHandlers 是 NewParser 创建的解析器默认使用的注册表。
+var DefaultBlockHandlers = NewBlockHandlerRegistry()
+
+// NewBlockHandlerRegistry 创建空的处理器注册表。
+func NewBlockHandlerRegistry() *BlockHandlerRegistry {
+	return &BlockHandlerRegistry{handlers: make(map[string][]BlockHandler)}
+}
+
+// Register 注册处理器，同一个块可以有多个处理器，按注册顺序调用。
+func (r *BlockHandlerRegistry) Register(handler BlockHandler) error {
+	if handler == nil || handler.BlockName() == "" {
+		return ErrInvalidBlockHandler
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name := handler.BlockName()
+	r.handlers[name] = append(r.handlers[name], handler)
+	return nil
+}
+
+// Handlers 返回为块名称注册的处理器。
+func (r *BlockHandlerRegistry) Handlers(name string) []BlockHandler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]BlockHandler(nil), r.handlers[name]...)
+}
+
+// RegisterBlockHandler 在 DefaultBlockHandlers 中注册处理器。
+func RegisterBlockHandler(handler BlockHandler) error {
+	return DefaultBlockHandlers.Register(handler)
+}
+
+// WithBlockHandlers 设置解析时使用的处理器注册表，nil表示不调用任何处理器。
+func (p *GradleParser) WithBlockHandlers(registry *BlockHandlerRegistry) *GradleParser {
+	p.blockHandlers = registry
+	return p
+}
+
+// runBlockHandlers 按源码顺序遍历脚本块，把每个块交给为其名称注册的处理器。
+// 处理器的错误和panic都记录为解析错误。
+func (p *GradleParser) runBlockHandlers(state *parseState, project *model.Project) {
+	if p.blockHandlers == nil || state.rootBlock == nil {
+		return
+	}
+
+	// WithOnlyBlocks限制了块时，只调用这些块及其子块的处理器。
+	var visit func(block *model.ScriptBlock, enabled bool)
+	visit = func(block *model.ScriptBlock, enabled bool) {
+		for _, child := range block.Children {
+			childEnabled := enabled || p.onlyBlocks[child.Name]
+			if !childEnabled {
+				visit(child, false)
+				continue
+			}
+			for _, handler := range p.blockHandlers.Handlers(child.Name) {
+				if err := callBlockHandler(handler, child, project); err != nil {
+					state.errors = append(state.errors, fmt.Errorf("处理 %s 块失败 (第%d行): %w",
+						child.Name, child.SourceRange.Start.Line, err))
+				}
+			}
+			visit(child, true)
+		}
+	}
+	visit(state.rootBlock, p.onlyBlocks == nil)
+}
+
+// callBlockHandler 调用处理器，并把处理器中的panic转换为错误。
+func callBlockHandler(handler BlockHandler, block *model.ScriptBlock, project *model.Project) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("处理器panic: %v", r)
+		}
+	}()
+	return handler.HandleBlock(block, project)
+}
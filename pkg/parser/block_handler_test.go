@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestBlockHandlers(t *testing.T) {
+	content := `
+plugins {
+    id 'com.github.johnrengelman.shadow' version '8.1.1'
+}
+
+shadowJar {
+    archiveClassifier = 'all'
+    mergeServiceFiles()
+}
+
+jib {
+    from {
+        image = 'eclipse-temurin:17-jre'
+    }
+    to {
+        image = 'registry.example.com/app'
+    }
+}
+`
+	registry := NewBlockHandlerRegistry()
+	err := registry.Register(NewBlockHandler("shadowJar", func(block *model.ScriptBlock, project *model.Project) error {
+		project.Extensions["shadowJar"] = block.Values["archiveClassifier"]
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	// 嵌套的块同样会被交给处理器，按源码顺序调用。
+	var images []string
+	for _, name := range []string{"from", "to"} {
+		_ = registry.Register(NewBlockHandler(name, func(block *model.ScriptBlock, project *model.Project) error {
+			if block.Parent == nil || block.Parent.Name != "jib" {
+				return nil
+			}
+			images = append(images, block.Values["image"].(string))
+			project.Extensions["jib."+block.Name] = block.Values["image"]
+			return nil
+		}))
+	}
+
+	p := NewParser().(*GradleParser).WithBlockHandlers(registry)
+	result, err := p.Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if got := result.Project.Extensions["shadowJar"]; got != "'all'" {
+		t.Errorf("Extensions[shadowJar] = %v, want 'all'", got)
+	}
+	if got := result.Project.Extensions["jib.to"]; got != "'registry.example.com/app'" {
+		t.Errorf("Extensions[jib.to] = %v, want 'registry.example.com/app'", got)
+	}
+	if len(images) != 2 || !strings.Contains(images[0], "temurin") {
+		t.Errorf("images = %v, want from image before to image", images)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("Errors = %v, want none", result.Errors)
+	}
+}
+
+func TestBlockHandlerErrors(t *testing.T) {
+	registry := NewBlockHandlerRegistry()
+	if err := registry.Register(nil); !errors.Is(err, ErrInvalidBlockHandler) {
+		t.Errorf("Register(nil) error = %v, want ErrInvalidBlockHandler", err)
+	}
+	if err := registry.Register(NewBlockHandler("", nil)); !errors.Is(err, ErrInvalidBlockHandler) {
+		t.Errorf("Register(empty name) error = %v, want ErrInvalidBlockHandler", err)
+	}
+
+	errBad := errors.New("bad block")
+	_ = registry.Register(NewBlockHandler("custom", func(*model.ScriptBlock, *model.Project) error {
+		return errBad
+	}))
+	_ = registry.Register(NewBlockHandler("broken", func(*model.ScriptBlock, *model.Project) error {
+		panic("boom")
+	}))
+
+	p := NewParser().(*GradleParser).WithBlockHandlers(registry)
+	result, err := p.Parse("custom {\n    enabled = true\n}\nbroken {\n}\n")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Errors) != 2 {
+		t.Fatalf("Errors = %v, want 2 errors", result.Errors)
+	}
+	if !errors.Is(result.Errors[0], errBad) || !strings.Contains(result.Errors[0].Error(), "custom") {
+		t.Errorf("Errors[0] = %v, want wrapped handler error", result.Errors[0])
+	}
+	if !strings.Contains(result.Errors[1].Error(), "boom") {
+		t.Errorf("Errors[1] = %v, want recovered panic", result.Errors[1])
+	}
+
+	// 关闭处理器后不再调用。
+	result, _ = p.WithBlockHandlers(nil).Parse("custom {\n}\n")
+	if len(result.Errors) != 0 {
+		t.Errorf("Errors = %v, want none without handlers", result.Errors)
+	}
+}
+
+func TestBlockHandlersWithOnlyBlocks(t *testing.T) {
+	var called []string
+	registry := NewBlockHandlerRegistry()
+	for _, name := range []string{"android", "defaultConfig", "shadowJar"} {
+		_ = registry.Register(NewBlockHandler(name, func(block *model.ScriptBlock, _ *model.Project) error {
+			called = append(called, block.Name)
+			return nil
+		}))
+	}
+
+	content := "android {\n    defaultConfig {\n        minSdk 24\n    }\n}\nshadowJar {\n}\n"
+	p := NewParser().(*GradleParser).WithBlockHandlers(registry).WithOnlyBlocks("android")
+	if _, err := p.Parse(content); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	// 选中块的子块同样会被交给处理器。
+	if strings.Join(called, ",") != "android,defaultConfig" {
+		t.Errorf("Handlers called for %v, want android and defaultConfig", called)
+	}
+}
+
+func TestDefaultBlockHandlers(t *testing.T) {
+	saved := DefaultBlockHandlers
+	DefaultBlockHandlers = NewBlockHandlerRegistry()
+	defer func() { DefaultBlockHandlers = saved }()
+
+	calls := 0
+	if err := RegisterBlockHandler(NewBlockHandler("sonar", func(block *model.ScriptBlock, project *model.Project) error {
+		calls++
+		project.Extensions["sonar"] = len(block.Statements)
+		return nil
+	})); err != nil {
+		t.Fatalf("RegisterBlockHandler() error = %v", err)
+	}
+
+	result, err := NewParser().Parse("sonar {\n    property 'a', 'b'\n}\n")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if calls != 1 || result.Project.Extensions["sonar"] != 1 {
+		t.Errorf("calls = %d, Extensions[sonar] = %v, want 1 and 1", calls, result.Project.Extensions["sonar"])
+	}
+}
@@ -0,0 +1,330 @@
+// Package parser 提供识别Gradle脚本块结构的扫描器。
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// 匹配块头部的名称，例如 android、tasks.named、maven。
+var blockNameRegex = regexp.MustCompile(`^[A-Za-z_$][\w$]*(?:\s*\.\s*[A-Za-z_$][\w$]*)*`)
+
+// blockSpan 表示扫描得到的一个花括号块。
+type blockSpan struct {
+	header      string     // 块头部文本，例如 "task hello(type: Copy)"。
+	name        string     // 块名称，例如 "task"、"android"。
+	headerStart int        // 头部起始位置（0-based）。
+	openPos     int        // 左花括号位置。
+	closePos    int        // 右花括号位置，未闭合时为-1。
+	depth       int        // 嵌套深度，顶层块为0。
+	parent      *blockSpan // 父块。
+}
+
+// end 返回块结束位置（不含）。
+func (b *blockSpan) end(contentLen int) int {
+	if b.closePos == -1 {
+		return contentLen
+	}
+	return b.closePos + 1
+}
+
+// path 返回从顶层块到当前块的名称路径，例如 "android.defaultConfig"。
+func (b *blockSpan) path() string {
+	names := make([]string, 0, b.depth+1)
+	for cur := b; cur != nil; cur = cur.parent {
+		names = append(names, cur.name)
+	}
+	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+		names[i], names[j] = names[j], names[i]
+	}
+	return strings.Join(names, ".")
+}
+
+// contains 判断位置是否位于块的花括号内部。
+func (b *blockSpan) contains(pos, contentLen int) bool {
+	return pos > b.openPos && pos < b.end(contentLen)
+}
+
+// scanBlocks 扫描文本中的所有花括号块，忽略字符串和注释中的花括号。
+// 返回的块按左花括号位置排序。
+func scanBlocks(content string) []*blockSpan {
+	blocks := make([]*blockSpan, 0)
+	stack := make([]*blockSpan, 0)
+	parenStack := make([]int, 0)
+
+	stmtStart := 0
+	parenDepth := 0
+	n := len(content)
+
+	for i := 0; i < n; i++ {
+		c := content[i]
+		switch {
+		case c == '/' && i+1 < n && content[i+1] == '/':
+			if j := strings.IndexByte(content[i:], '\n'); j == -1 {
+				i = n
+			} else {
+				i += j - 1
+			}
+		case c == '/' && i+1 < n && content[i+1] == '*':
+			if j := strings.Index(content[i+2:], "*/"); j == -1 {
+				i = n
+			} else {
+				i += j + 3
+			}
+		case c == '\'' || c == '"':
+			i = skipString(content, i) - 1
+		case c == '(':
+			parenDepth++
+		case c == ')':
+			if parenDepth > 0 {
+				parenDepth--
+			}
+		case c == '{':
+			header := strings.TrimSpace(stripLineComments(content[stmtStart:i]))
+			headerStart := i
+			if header != "" {
+				segment := content[stmtStart:i]
+				headerStart = stmtStart + len(segment) - len(strings.TrimLeft(segment, " \t\r\n"))
+			}
+			block := &blockSpan{
+				header:      header,
+				name:        normalizeBlockName(blockNameRegex.FindString(header)),
+				headerStart: headerStart,
+				openPos:     i,
+				closePos:    -1,
+				depth:       len(stack),
+			}
+			if len(stack) > 0 {
+				block.parent = stack[len(stack)-1]
+			}
+			blocks = append(blocks, block)
+			stack = append(stack, block)
+			parenStack = append(parenStack, parenDepth)
+			parenDepth = 0
+			stmtStart = i + 1
+		case c == '}':
+			if len(stack) > 0 {
+				stack[len(stack)-1].closePos = i
+				stack = stack[:len(stack)-1]
+				parenDepth = parenStack[len(parenStack)-1]
+				parenStack = parenStack[:len(parenStack)-1]
+			}
+			stmtStart = i + 1
+		case c == '\n' || c == ';':
+			if parenDepth == 0 {
+				stmtStart = i + 1
+			}
+		}
+	}
+
+	return blocks
+}
+
+// skipString 跳过从start开始的字符串字面量，返回字符串结束后的位置。
+// 支持单引号、双引号、三引号以及GString中的 ${...} 表达式。
+func skipString(content string, start int) int {
+	end, _ := scanString(content, start)
+	return end
+}
+
+// scanString 与 skipString 相同，另外返回字符串是否以结束引号闭合。
+// 单行字符串未闭合时在行尾结束，三引号字符串未闭合时在文本末尾结束。
+func scanString(content string, start int) (int, bool) {
+	quote := content[start]
+	delim := content[start : start+1]
+	if start+3 <= len(content) && content[start+1] == quote && content[start+2] == quote {
+		delim = content[start : start+3]
+	}
+
+	n := len(content)
+	i := start + len(delim)
+	for i < n {
+		c := content[i]
+		switch {
+		case c == '\\':
+			i += 2
+			continue
+		case c == '\n' && len(delim) == 1:
+			return i, false
+		case c == quote && strings.HasPrefix(content[i:], delim):
+			return i + len(delim), true
+		case quote == '"' && c == '$' && i+1 < n && content[i+1] == '{':
+			i = skipInterpolation(content, i+2)
+			continue
+		}
+		i++
+	}
+	return n, false
+}
+
+// skipInterpolation 跳过GString中 ${ 之后的表达式，返回右花括号之后的位置。
+func skipInterpolation(content string, start int) int {
+	depth := 1
+	for i := start; i < len(content); i++ {
+		switch content[i] {
+		case '\'', '"':
+			i = skipString(content, i) - 1
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case '\n':
+			return i
+		}
+	}
+	return len(content)
+}
+
+// stripLineComments 移除文本中的 // 行注释。
+func stripLineComments(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if idx := strings.Index(line, "//"); idx != -1 && !strings.Contains(line[:idx], "'") &&
+			!strings.Contains(line[:idx], "\"") {
+			lines[i] = line[:idx]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// normalizeBlockName 去除块名称中的空白。
+func normalizeBlockName(name string) string {
+	return strings.Join(strings.Fields(name), "")
+}
+
+// lineIndex 用于在文本偏移量（字节）和行列号之间转换，列号按字符（rune）计算。
+type lineIndex struct {
+	content string
+	starts  []int  // 每行的起始偏移量。
+	crlf    []bool // 每行是否以 \r\n 结束。
+}
+
+// newLineIndex 为文本创建行索引。
+func newLineIndex(content string) *lineIndex {
+	starts := []int{0}
+	crlf := make([]bool, 0)
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			starts = append(starts, i+1)
+			crlf = append(crlf, i > 0 && content[i-1] == '\r')
+		}
+	}
+	return &lineIndex{content: content, starts: starts, crlf: crlf}
+}
+
+// position 返回偏移量对应的行号和列号（均为1-based）。
+func (li *lineIndex) position(offset int) (int, int) {
+	line := sort.Search(len(li.starts), func(i int) bool { return li.starts[i] > offset }) - 1
+	if line < 0 {
+		line = 0
+	}
+	if offset > len(li.content) {
+		offset = len(li.content)
+	}
+	return line + 1, utf8.RuneCountInString(li.content[li.starts[line]:offset]) + 1
+}
+
+// rangeOf 根据起止偏移量（end不含）创建源码范围，格式与其他源码映射保持一致。
+func (li *lineIndex) rangeOf(start, end int) model.SourceRange {
+	startLine, startColumn := li.position(start)
+	// 结束位置取最后一个字符的起始字节，多字节字符不会被截断。
+	last := start
+	if end > start && end <= len(li.content) {
+		_, size := utf8.DecodeLastRuneInString(li.content[start:end])
+		last = end - size
+	}
+	endLine, endColumn := li.position(last)
+
+	return model.SourceRange{
+		Start: model.SourcePosition{
+			Line:     startLine,
+			Column:   startColumn,
+			StartPos: start,
+			EndPos:   end,
+			Length:   end - start,
+		},
+		End: model.SourcePosition{
+			Line:     endLine,
+			Column:   endColumn,
+			StartPos: end,
+			EndPos:   end,
+			Length:   0,
+		},
+	}
+}
+
+// lineRange 返回指定行（1-based）不含换行符的范围。
+func (li *lineIndex) lineRange(line, contentLen int) model.SourceRange {
+	if line < 1 || line > len(li.starts) {
+		return model.SourceRange{}
+	}
+	start := li.starts[line-1]
+	end := contentLen
+	if line < len(li.starts) {
+		end = li.starts[line] - 1
+		if end > start && li.crlf[line-1] {
+			end--
+		}
+	}
+	return li.rangeOf(start, end)
+}
+
+// commentSpan 表示一条注释在文本中的范围。
+type commentSpan struct {
+	start int  // 注释标记的起始位置。
+	end   int  // 注释结束位置（不含），行注释不含换行符。
+	block bool // 是否为 /* */ 块注释。
+}
+
+// scanComments 返回文本中所有注释的范围，忽略字符串中的注释标记。
+func scanComments(content string) []commentSpan {
+	comments := make([]commentSpan, 0)
+	n := len(content)
+
+	for i := 0; i < n; i++ {
+		c := content[i]
+		switch {
+		case c == '/' && i+1 < n && content[i+1] == '/':
+			end := n
+			if j := strings.IndexByte(content[i:], '\n'); j != -1 {
+				end = i + j
+			}
+			if end > i && content[end-1] == '\r' {
+				end--
+			}
+			comments = append(comments, commentSpan{start: i, end: end})
+			i = end - 1
+		case c == '/' && i+1 < n && content[i+1] == '*':
+			end := n
+			if j := strings.Index(content[i+2:], "*/"); j != -1 {
+				end = i + 2 + j + 2
+			}
+			comments = append(comments, commentSpan{start: i, end: end, block: true})
+			i = end - 1
+		case c == '\'' || c == '"':
+			i = skipString(content, i) - 1
+		}
+	}
+
+	return comments
+}
+
+// maskComments 将注释替换为空格，保留换行和字符串，使偏移量与原文一致。
+func maskComments(content string) string {
+	masked := []byte(content)
+	for _, comment := range scanComments(content) {
+		for k := comment.start; k < comment.end; k++ {
+			if masked[k] != '\n' {
+				masked[k] = ' '
+			}
+		}
+	}
+	return string(masked)
+}
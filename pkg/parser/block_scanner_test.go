@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestScanBlocks(t *testing.T) {
+	content := `buildscript {
+    repositories {
+        mavenCentral() // not a { block
+    }
+}
+
+android {
+    defaultConfig {
+        applicationId "com.example.${flavor}"
+        resValue "string", "braces", "{}"
+    }
+    /* comment { */
+}
+
+def s = '''
+not { a block
+'''
+`
+	blocks := scanBlocks(content)
+
+	expected := []struct {
+		name  string
+		path  string
+		depth int
+	}{
+		{"buildscript", "buildscript", 0},
+		{"repositories", "buildscript.repositories", 1},
+		{"android", "android", 0},
+		{"defaultConfig", "android.defaultConfig", 1},
+	}
+
+	if len(blocks) != len(expected) {
+		t.Fatalf("Expected %d blocks, got %d", len(expected), len(blocks))
+	}
+
+	for i, want := range expected {
+		block := blocks[i]
+		if block.name != want.name || block.path() != want.path || block.depth != want.depth {
+			t.Errorf("Block %d: got name=%s path=%s depth=%d, want %+v", i, block.name, block.path(), block.depth, want)
+		}
+		if block.closePos == -1 {
+			t.Errorf("Block %s should be closed", block.name)
+		}
+		if content[block.openPos] != '{' || content[block.closePos] != '}' {
+			t.Errorf("Block %s has wrong brace positions", block.name)
+		}
+	}
+}
+
+func TestScanBlocksHeaders(t *testing.T) {
+	content := "tasks.register('hello', Copy) {\n}\nconfigurations.all { config ->\n}\nimplementation('a:b:1') {\n    exclude group: 'x'\n}\n"
+	blocks := scanBlocks(content)
+
+	expected := []struct {
+		header string
+		name   string
+	}{
+		{"tasks.register('hello', Copy)", "tasks.register"},
+		{"configurations.all", "configurations.all"},
+		{"implementation('a:b:1')", "implementation"},
+	}
+
+	if len(blocks) != len(expected) {
+		t.Fatalf("Expected %d blocks, got %d", len(expected), len(blocks))
+	}
+	for i, want := range expected {
+		if blocks[i].header != want.header || blocks[i].name != want.name {
+			t.Errorf("Block %d: got header=%q name=%q, want %+v", i, blocks[i].header, blocks[i].name, want)
+		}
+	}
+}
+
+func TestLineIndex(t *testing.T) {
+	index := newLineIndex("ab\ncde\n\nf")
+
+	tests := []struct {
+		offset int
+		line   int
+		column int
+	}{
+		{0, 1, 1},
+		{2, 1, 3},
+		{3, 2, 1},
+		{5, 2, 3},
+		{7, 3, 1},
+		{8, 4, 1},
+	}
+	for _, tt := range tests {
+		line, column := index.position(tt.offset)
+		if line != tt.line || column != tt.column {
+			t.Errorf("position(%d) = (%d, %d), want (%d, %d)", tt.offset, line, column, tt.line, tt.column)
+		}
+	}
+
+	r := index.rangeOf(3, 6)
+	if r.Start.Line != 2 || r.Start.Column != 1 || r.End.Line != 2 || r.End.Column != 3 || r.Start.Length != 3 {
+		t.Errorf("Unexpected range: %+v", r)
+	}
+}
@@ -0,0 +1,44 @@
+// Package parser 提供buildscript classpath和插件依赖的提取功能。
+package parser
+
+import (
+	"github.com/scagogogo/gradle-parser/pkg/config"
+	"github.com/scagogogo/gradle-parser/pkg/dependency"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// extractBuildToolingDependencies 提取 buildscript { dependencies { classpath ... } } 中的依赖和plugins块中声明了版本的插件，
+// 配置分别为 dependency.ScopeBuildscriptClasspath 和 dependency.ScopePlugin，按在脚本中出现的顺序排列。
+// index不为nil时记录声明所在的行号。
+func extractBuildToolingDependencies(content string, depParser *dependency.Parser, index *lineIndex) []*model.Dependency {
+	deps := extractClasspathDependencies(content, depParser, index)
+	for _, plugin := range config.NewPluginParser().WithLineNumbers(index != nil).ExtractPluginsFromText(content) {
+		if dep := depParser.PluginDependency(plugin); dep != nil {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// extractClasspathDependencies 提取 buildscript { dependencies { classpath ... } } 中的依赖，按在脚本中出现的顺序排列。
+// index不为nil时记录声明所在的行号。
+func extractClasspathDependencies(content string, depParser *dependency.Parser, index *lineIndex) []*model.Dependency {
+	deps := make([]*model.Dependency, 0)
+	blocks := scanBlocks(content)
+	masked := maskComments(content)
+
+	for _, block := range blocks {
+		if block.name != contextDependencies || block.parent == nil || block.parent.name != "buildscript" {
+			continue
+		}
+		forEachDeclaration(masked, blocks, block, func(_, _ string, start, end int) {
+			if dep := depParser.ParseClasspathDeclaration(content[start:end]); dep != nil {
+				if index != nil {
+					dep.DeclaredAtLine, _ = index.position(start)
+				}
+				deps = append(deps, dep)
+			}
+		})
+	}
+	return deps
+}
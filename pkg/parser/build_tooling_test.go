@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/dependency"
+)
+
+func TestWithBuildToolingDependencies(t *testing.T) {
+	content := `
+buildscript {
+    repositories {
+        google()
+    }
+    dependencies {
+        classpath 'com.android.tools.build:gradle:8.1.0'
+        classpath("org.jetbrains.kotlin:kotlin-gradle-plugin:$kotlinVersion") {
+            exclude group: 'org.foo'
+        }
+        classpath files('libs/tool.jar')
+    }
+}
+
+plugins {
+    id 'java'
+    id 'org.springframework.boot' version '3.1.0'
+}
+
+dependencies {
+    implementation 'org.slf4j:slf4j-api:1.7.36'
+}
+`
+
+	result, err := NewParser().Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Project.Dependencies) != 1 {
+		t.Errorf("Expected only application dependencies by default, got %d", len(result.Project.Dependencies))
+	}
+
+	result, err = NewParser().(*GradleParser).WithBuildToolingDependencies(true).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	got := make([]string, 0)
+	for _, dep := range result.Project.Dependencies {
+		got = append(got, dep.Scope+" "+dep.Group+":"+dep.Name+":"+dep.Version)
+	}
+	want := []string{
+		"implementation org.slf4j:slf4j-api:1.7.36",
+		"buildscript-classpath com.android.tools.build:gradle:8.1.0",
+		"buildscript-classpath org.jetbrains.kotlin:kotlin-gradle-plugin:$kotlinVersion",
+		"plugin org.springframework.boot:org.springframework.boot.gradle.plugin:3.1.0",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependencies = %v, want %v", got, want)
+	}
+
+	result, err = NewParser().(*GradleParser).
+		WithBuildToolingDependencies(true).
+		WithScopeFilter(nil, []string{dependency.ScopePlugin}).
+		Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	for _, dep := range result.Project.Dependencies {
+		if dep.Scope == dependency.ScopePlugin {
+			t.Errorf("Expected plugin dependencies to be filtered, got %+v", dep)
+		}
+	}
+}
+
+func TestLegacyPluginClasspathVersions(t *testing.T) {
+	content := `
+buildscript {
+    ext.kotlin_version = '1.8.22'
+    repositories {
+        google()
+    }
+    dependencies {
+        classpath 'com.android.tools.build:gradle:7.4.2'
+        classpath "org.jetbrains.kotlin:kotlin-gradle-plugin:$kotlin_version"
+        classpath 'com.example.custom:com.example.custom.gradle.plugin:0.3.0'
+    }
+}
+
+apply plugin: 'com.android.application'
+apply plugin: 'kotlin-android'
+apply plugin: 'com.example.custom'
+apply plugin: 'maven-publish'
+`
+
+	result, err := NewParser().Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	expected := map[string][2]string{
+		"com.android.application": {"7.4.2", "com.android.tools.build:gradle:7.4.2"},
+		"kotlin-android":          {"1.8.22", "org.jetbrains.kotlin:kotlin-gradle-plugin:1.8.22"},
+		"com.example.custom":      {"0.3.0", "com.example.custom:com.example.custom.gradle.plugin:0.3.0"},
+		"maven-publish":           {"", ""},
+	}
+	if len(result.Project.Plugins) != len(expected) {
+		t.Fatalf("Expected %d plugins, got %d", len(expected), len(result.Project.Plugins))
+	}
+	for _, plugin := range result.Project.Plugins {
+		want := expected[plugin.ID]
+		if plugin.Version != want[0] || plugin.Classpath != want[1] {
+			t.Errorf("Plugin %s = version %q classpath %q, want %q %q", plugin.ID, plugin.Version, plugin.Classpath, want[0], want[1])
+		}
+	}
+}
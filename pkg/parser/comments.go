@@ -0,0 +1,130 @@
+// Package parser 提供注释的收集与附加功能。
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// commentTarget 表示可以附加注释的声明。
+type commentTarget struct {
+	sourceRange model.SourceRange
+	attach      func(comment *model.Comment)
+}
+
+// collectComments 收集文本中的所有注释，并附加到相应的依赖、插件和属性声明上。
+// 行尾注释附加到同一行中位于其前面的声明，其他注释附加到紧随其后的声明；
+// 注释与声明之间隔有其他代码时不附加。
+func (sap *SourceAwareParser) collectComments(content string, project *model.SourceMappedProject) {
+	masked := maskComments(content)
+	targets := commentTargets(project)
+
+	project.Comments = make([]*model.Comment, 0)
+	for _, span := range scanComments(content) {
+		comment := newComment(content, span, sap.index)
+		project.Comments = append(project.Comments, comment)
+
+		if comment.Trailing {
+			if target := precedingTarget(targets, comment); target != nil {
+				target.attach(comment)
+			}
+			continue
+		}
+		if target := followingTarget(targets, masked, span); target != nil {
+			target.attach(comment)
+		}
+	}
+}
+
+// newComment 根据注释范围创建注释节点。
+func newComment(content string, span commentSpan, index *lineIndex) *model.Comment {
+	raw := content[span.start:span.end]
+	lineStart := strings.LastIndexByte(content[:span.start], '\n') + 1
+
+	comment := &model.Comment{
+		Kind:        model.CommentLine,
+		RawText:     raw,
+		Trailing:    strings.TrimSpace(maskComments(content[lineStart:span.start])) != "",
+		SourceRange: index.rangeOf(span.start, span.end),
+	}
+
+	if span.block {
+		comment.Kind = model.CommentBlock
+		comment.Text = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(raw, "/*"), "*/"))
+	} else {
+		comment.Text = strings.TrimSpace(strings.TrimPrefix(raw, "//"))
+	}
+	return comment
+}
+
+// commentTargets 返回所有可附加注释的声明，按起始位置排序。
+func commentTargets(project *model.SourceMappedProject) []*commentTarget {
+	targets := make([]*commentTarget, 0,
+		len(project.SourceMappedDependencies)+len(project.SourceMappedPlugins)+len(project.SourceMappedProperties))
+
+	for _, dep := range project.SourceMappedDependencies {
+		dep := dep
+		targets = append(targets, &commentTarget{
+			sourceRange: dep.SourceRange,
+			attach:      func(c *model.Comment) { dep.Comments = append(dep.Comments, c) },
+		})
+	}
+	for _, plugin := range project.SourceMappedPlugins {
+		plugin := plugin
+		targets = append(targets, &commentTarget{
+			sourceRange: plugin.SourceRange,
+			attach:      func(c *model.Comment) { plugin.Comments = append(plugin.Comments, c) },
+		})
+	}
+	for _, prop := range project.SourceMappedProperties {
+		prop := prop
+		targets = append(targets, &commentTarget{
+			sourceRange: prop.SourceRange,
+			attach:      func(c *model.Comment) { prop.Comments = append(prop.Comments, c) },
+		})
+	}
+
+	sort.SliceStable(targets, func(i, j int) bool {
+		return targets[i].sourceRange.Start.StartPos < targets[j].sourceRange.Start.StartPos
+	})
+	return targets
+}
+
+// followingTarget 返回紧随注释之后的声明。
+// 注释与声明所在行之间只能有空白或其他注释。
+func followingTarget(targets []*commentTarget, masked string, span commentSpan) *commentTarget {
+	for _, target := range targets {
+		start := target.sourceRange.Start.StartPos
+		if start < span.end {
+			continue
+		}
+		between := masked[span.end:start]
+		if nl := strings.LastIndexByte(between, '\n'); nl != -1 {
+			between = between[:nl]
+		} else {
+			between = ""
+		}
+		if strings.TrimSpace(between) != "" {
+			return nil
+		}
+		return target
+	}
+	return nil
+}
+
+// precedingTarget 返回与行尾注释位于同一行、且起始于注释之前的最后一个声明。
+func precedingTarget(targets []*commentTarget, comment *model.Comment) *commentTarget {
+	var found *commentTarget
+	line := comment.SourceRange.Start.Line
+	for _, target := range targets {
+		if target.sourceRange.Start.StartPos >= comment.SourceRange.Start.StartPos {
+			break
+		}
+		if target.sourceRange.Start.Line == line || target.sourceRange.End.Line == line {
+			found = target
+		}
+	}
+	return found
+}
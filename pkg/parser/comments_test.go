@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestSourceAwareParser_RetainComments(t *testing.T) {
+	content := `plugins {
+    // 应用Spring Boot插件
+    id 'org.springframework.boot' version '2.7.0'
+}
+
+/* 项目版本 */
+version = '1.0.0'
+
+dependencies {
+    // renovate: datasource=maven depName=com.google.guava:guava
+    implementation 'com.google.guava:guava:31.1-jre' // pinned
+
+    testImplementation 'junit:junit:4.13.2'
+}
+
+// 文件末尾的注释
+`
+
+	result, err := NewSourceAwareParser().WithRetainComments(true).ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+	project := result.SourceMappedProject
+
+	if len(project.Comments) != 5 {
+		t.Fatalf("Expected 5 comments, got %d", len(project.Comments))
+	}
+	last := project.Comments[4]
+	if last.Kind != model.CommentLine || last.Text != "文件末尾的注释" || last.SourceRange.Start.Line != 16 {
+		t.Errorf("Unexpected last comment: %+v", last)
+	}
+
+	plugin := project.SourceMappedPlugins[0]
+	if len(plugin.Comments) != 1 || plugin.Comments[0].Text != "应用Spring Boot插件" {
+		t.Errorf("Expected plugin comment to be attached, got %+v", plugin.Comments)
+	}
+
+	version := findSourceMappedProperty(project, "version")
+	if version == nil {
+		t.Fatal("Expected version property")
+	}
+	if len(version.Comments) != 1 || version.Comments[0].Kind != model.CommentBlock || version.Comments[0].Text != "项目版本" {
+		t.Errorf("Expected block comment on version, got %+v", version.Comments)
+	}
+
+	var guava, junit *model.SourceMappedDependency
+	for _, dep := range project.SourceMappedDependencies {
+		switch dep.Name {
+		case "guava":
+			guava = dep
+		case "junit":
+			junit = dep
+		}
+	}
+	if guava == nil || junit == nil {
+		t.Fatal("Expected guava and junit dependencies")
+	}
+	if len(guava.Comments) != 2 {
+		t.Fatalf("Expected 2 comments on guava, got %d", len(guava.Comments))
+	}
+	if guava.Comments[0].Text != "renovate: datasource=maven depName=com.google.guava:guava" || guava.Comments[0].Trailing {
+		t.Errorf("Unexpected leading comment: %+v", guava.Comments[0])
+	}
+	if guava.Comments[1].Text != "pinned" || !guava.Comments[1].Trailing {
+		t.Errorf("Unexpected trailing comment: %+v", guava.Comments[1])
+	}
+	if len(junit.Comments) != 0 {
+		t.Errorf("Expected no comments on junit, got %+v", junit.Comments)
+	}
+
+	for _, prop := range project.SourceMappedProperties {
+		if prop.Key != "version" {
+			t.Errorf("Unexpected property %q parsed from comment", prop.Key)
+		}
+	}
+}
+
+func TestSourceAwareParser_CommentsNotRetainedByDefault(t *testing.T) {
+	result, err := NewSourceAwareParser().ParseWithSourceMapping("// comment\nversion = '1.0'\n")
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+	if result.SourceMappedProject.Comments != nil {
+		t.Errorf("Expected no comments, got %d", len(result.SourceMappedProject.Comments))
+	}
+}
+
+func TestFollowingTargetSkipsSeparatedDeclarations(t *testing.T) {
+	content := "// 说明\nfoo()\nversion = '1.0'\n"
+	result, err := NewSourceAwareParser().WithRetainComments(true).ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+	version := findSourceMappedProperty(result.SourceMappedProject, "version")
+	if version == nil {
+		t.Fatal("Expected version property")
+	}
+	if len(version.Comments) != 0 {
+		t.Errorf("Expected comment separated by code not to be attached, got %+v", version.Comments)
+	}
+}
+
+func findSourceMappedProperty(project *model.SourceMappedProject, key string) *model.SourceMappedProperty {
+	for _, prop := range project.SourceMappedProperties {
+		if prop.Key == key {
+			return prop
+		}
+	}
+	return nil
+}
+
+func TestSourceAwareParser_IgnoresAssignmentsInComments(t *testing.T) {
+	content := "// a = b\n/*\n * c = d\n */\nversion = '1.0'\n"
+	result, err := NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+	props := result.SourceMappedProject.SourceMappedProperties
+	if len(props) != 1 || props[0].Key != "version" {
+		t.Errorf("Expected only version property, got %d properties", len(props))
+	}
+}
@@ -0,0 +1,99 @@
+// Package parser 提供与配置缓存不兼容写法的定位功能。
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// configCacheMessages 是各种不兼容写法的诊断说明。
+var configCacheMessages = map[string]string{
+	model.ConfigCacheSystemProperty:     "配置阶段调用 System.getProperty 会使系统属性成为配置缓存的输入，请改用 providers.systemProperty()",
+	model.ConfigCacheEnvironment:        "配置阶段调用 System.getenv 会使环境变量成为配置缓存的输入，请改用 providers.environmentVariable()",
+	model.ConfigCacheBuildDirAssignment: "直接给 buildDir 赋值已废弃，请改用 layout.buildDirectory",
+	model.ConfigCacheProjectAtExecution: "任务执行阶段访问 project 与配置缓存不兼容，请在配置阶段读取需要的值",
+	model.ConfigCacheBuildListener:      "构建监听器与配置缓存不兼容，请改用构建服务（BuildService）",
+}
+
+// ConfigurationCacheDiagnosticCode 返回不兼容写法对应的诊断代码，例如 configuration-cache-system-property。
+func ConfigurationCacheDiagnosticCode(construct string) string {
+	return model.DiagnosticConfigurationCachePrefix + construct
+}
+
+// FindConfigurationCacheProblems 定位脚本中与配置缓存不兼容的写法，忽略注释中的内容，结果按位置排序。
+// System.getProperty 和 System.getenv 只在配置阶段调用时报告，doFirst/doLast 中的调用在执行阶段运行，不受影响；
+// 相反，project 只在 doFirst/doLast 中访问时报告。
+func FindConfigurationCacheProblems(content string) []*model.Diagnostic {
+	code := maskComments(content)
+	index := newLineIndex(content)
+	blocks := scanBlocks(code)
+	diagnostics := make([]*model.Diagnostic, 0)
+
+	add := func(construct string, start, end int) {
+		diagnostics = append(diagnostics, &model.Diagnostic{
+			Code:        ConfigurationCacheDiagnosticCode(construct),
+			Severity:    model.SeverityWarning,
+			Message:     configCacheMessages[construct],
+			SourceRange: index.rangeOf(start, end),
+		})
+	}
+
+	actions := make([]*blockSpan, 0)
+	for _, block := range blocks {
+		if block.name == "doFirst" || block.name == "doLast" {
+			actions = append(actions, block)
+		}
+	}
+	inAction := func(pos int) bool {
+		for _, block := range actions {
+			if block.contains(pos, len(code)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, pattern := range configCachePatterns {
+		if !strings.Contains(code, pattern.literal) {
+			continue
+		}
+		executionSafe := pattern.construct == model.ConfigCacheSystemProperty || pattern.construct == model.ConfigCacheEnvironment
+		for _, m := range pattern.regex.FindAllStringIndex(code, -1) {
+			// 多行模式的正则可能从行首空白开始匹配，定位时去掉。
+			start := m[0] + len(code[m[0]:m[1]]) - len(strings.TrimLeft(code[m[0]:m[1]], " \t\r\n"))
+			if executionSafe && inAction(start) {
+				continue
+			}
+			add(pattern.construct, start, m[1])
+		}
+	}
+
+	// 嵌套的doFirst/doLast只检查一次，避免重复报告。
+	for _, block := range actions {
+		if block.closePos < 0 || (block.parent != nil && hasActionAncestor(block.parent)) {
+			continue
+		}
+		body := code[block.openPos+1 : block.closePos]
+		for _, m := range projectAccessRegex.FindAllStringIndex(body, -1) {
+			start := block.openPos + 1 + m[0]
+			add(model.ConfigCacheProjectAtExecution, start, start+len("project"))
+		}
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].SourceRange.Start.StartPos < diagnostics[j].SourceRange.Start.StartPos
+	})
+	return diagnostics
+}
+
+// hasActionAncestor 判断块本身或其祖先是否是 doFirst/doLast。
+func hasActionAncestor(block *blockSpan) bool {
+	for cur := block; cur != nil; cur = cur.parent {
+		if cur.name == "doFirst" || cur.name == "doLast" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestFindConfigurationCacheProblems(t *testing.T) {
+	content := `def flavor = System.getProperty('flavor')
+buildDir = file('out')
+
+tasks.register('report') {
+    doLast {
+        println project.version
+        def home = System.getenv('HOME')
+        doFirst { println project.name }
+    }
+}
+
+// System.getProperty('commented')
+gradle.buildFinished { }
+`
+	diagnostics := FindConfigurationCacheProblems(content)
+
+	want := []struct {
+		construct string
+		line      int
+		column    int
+	}{
+		{model.ConfigCacheSystemProperty, 1, 14},
+		{model.ConfigCacheBuildDirAssignment, 2, 1},
+		{model.ConfigCacheProjectAtExecution, 6, 17},
+		{model.ConfigCacheProjectAtExecution, 8, 27},
+		{model.ConfigCacheBuildListener, 13, 1},
+	}
+	if len(diagnostics) != len(want) {
+		t.Fatalf("Expected %d diagnostics, got %d: %v", len(want), len(diagnostics), diagnostics)
+	}
+	for i, w := range want {
+		diag := diagnostics[i]
+		if diag.Code != ConfigurationCacheDiagnosticCode(w.construct) {
+			t.Errorf("diagnostics[%d].Code = %q, want %q", i, diag.Code, ConfigurationCacheDiagnosticCode(w.construct))
+		}
+		if diag.SourceRange.Start.Line != w.line || diag.SourceRange.Start.Column != w.column {
+			t.Errorf("diagnostics[%d] at %s, want line %d, col %d", i, diag.SourceRange.Start.String(), w.line, w.column)
+		}
+		if diag.Severity != model.SeverityWarning || diag.Message == "" {
+			t.Errorf("diagnostics[%d] has unexpected severity or empty message: %v", i, diag)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Package parser 提供依赖约束的提取功能。
+package parser
+
+import (
+	"regexp"
+
+	"github.com/scagogogo/gradle-parser/pkg/dependency"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// blockConstraints 是 dependencies 块中声明依赖约束的子块。
+const blockConstraints = "constraints"
+
+var (
+	// 匹配约束配置闭包中的富版本声明。
+	// 例如: strictly '1.2.3'、require("1.2")、reject '1.0', '1.1'。
+	richVersionRegex = regexp.MustCompile(`\b(strictly|require|prefer|reject)\b\s*\(?\s*((?:['"][^'"\n]*['"][ \t]*,?[ \t]*)+)`)
+
+	// 匹配约束原因，例如 because 'CVE-2023-1234'。
+	becauseRegex = regexp.MustCompile(`\bbecause\b\s*\(?\s*['"]([^'"\n]*)['"]`)
+)
+
+// constraintSpan 是一条依赖约束及其在原文中的位置，偏移量为-1表示不存在。
+type constraintSpan struct {
+	constraint   *model.DependencyConstraint
+	start, end   int // 从配置名到配置闭包结束的范围。
+	coordStart   int // 坐标字符串（不含引号）的起始位置。
+	coordEnd     int
+	versionStart int // 生效版本文本的起始位置。
+	versionEnd   int
+}
+
+// extractConstraints 提取 dependencies { constraints { } } 中的依赖约束，按在脚本中出现的顺序排列。
+func extractConstraints(content string) []*model.DependencyConstraint {
+	spans := scanConstraints(content, maskComments(content), scanBlocks(content))
+	constraints := make([]*model.DependencyConstraint, 0, len(spans))
+	for _, span := range spans {
+		constraints = append(constraints, span.constraint)
+	}
+	return constraints
+}
+
+// isConstraintsBlock 判断块是否是 dependencies 块中的 constraints 块。
+func isConstraintsBlock(block *blockSpan) bool {
+	return block.name == blockConstraints && block.parent != nil && block.parent.name == contextDependencies
+}
+
+// maskConstraints 把constraints块的内容替换为空格（保留换行），使逐语句的依赖提取不把约束当作依赖。
+func maskConstraints(content string) string {
+	var masked []byte
+	for _, block := range scanBlocks(content) {
+		if !isConstraintsBlock(block) {
+			continue
+		}
+		if masked == nil {
+			masked = []byte(content)
+		}
+		for i := block.openPos + 1; i < block.end(len(content))-1; i++ {
+			if masked[i] != '\n' {
+				masked[i] = ' '
+			}
+		}
+	}
+	if masked == nil {
+		return content
+	}
+	return string(masked)
+}
+
+// scanConstraints 扫描所有constraints块中的约束声明。masked是去掉注释后的文本，blocks是content的块结构。
+func scanConstraints(content, masked string, blocks []*blockSpan) []*constraintSpan {
+	spans := make([]*constraintSpan, 0)
+	for _, block := range blocks {
+		if !isConstraintsBlock(block) {
+			continue
+		}
+		forEachDeclaration(masked, blocks, block, func(scope, _ string, start, end int) {
+			dep := dependency.ParseDeclaration(masked[start:end])
+			if dep == nil || dep.Project != nil {
+				return
+			}
+
+			span := &constraintSpan{
+				constraint: &model.DependencyConstraint{
+					Group:   dep.Group,
+					Name:    dep.Name,
+					Scope:   scope,
+					Version: dep.Version,
+				},
+				start:        start,
+				end:          end,
+				coordStart:   -1,
+				coordEnd:     -1,
+				versionStart: -1,
+				versionEnd:   -1,
+			}
+			span.locateVersion(masked[start:end], dep)
+
+			if closure := declarationClosure(masked, blocks, block, end); closure != nil {
+				span.end = closure.end(len(content))
+				span.parseClosure(masked, closure)
+			}
+			span.constraint.Raw = content[span.start:span.end]
+			spans = append(spans, span)
+		})
+	}
+	return spans
+}
+
+// locateVersion 定位声明中的坐标字符串和坐标版本，text为从配置名开始的声明文本。
+func (s *constraintSpan) locateVersion(text string, dep *model.Dependency) {
+	if m := quotedRegex.FindStringSubmatchIndex(text); m != nil {
+		if coordinate := dependency.ParseCoordinate(text[m[2]:m[3]]); coordinate != nil &&
+			coordinate.Group == dep.Group && coordinate.Name == dep.Name {
+			s.coordStart, s.coordEnd = s.start+m[2], s.start+m[3]
+			if dep.Version != "" {
+				s.versionStart = s.coordStart + len(dep.Group) + len(dep.Name) + 2
+				s.versionEnd = s.versionStart + len(dep.Version)
+			}
+			return
+		}
+	}
+
+	// map形式: implementation(group: 'g', name: 'a', version: '1.0')。
+	for _, m := range mapDependencyAttrRegex.FindAllStringSubmatchIndex(text, -1) {
+		if text[m[2]:m[3]] == "version" {
+			s.versionStart, s.versionEnd = s.start+m[4], s.start+m[5]
+		}
+	}
+}
+
+// parseClosure 解析约束配置闭包中的富版本声明和原因。
+// 坐标中没有版本时，生效版本依次取 strictly、require、prefer。
+func (s *constraintSpan) parseClosure(masked string, closure *blockSpan) {
+	bodyStart := closure.openPos + 1
+	body := masked[bodyStart : closure.end(len(masked))-1]
+	c := s.constraint
+
+	rich := make(map[string][2]int)
+	for _, m := range richVersionRegex.FindAllStringSubmatchIndex(body, -1) {
+		kind := body[m[2]:m[3]]
+		values := quotedRegex.FindAllStringSubmatchIndex(body[m[4]:m[5]], -1)
+		if len(values) == 0 {
+			continue
+		}
+		first := body[m[4]+values[0][2] : m[4]+values[0][3]]
+		switch kind {
+		case "strictly":
+			c.Strictly = first
+		case "require":
+			c.Require = first
+		case "prefer":
+			c.Prefer = first
+		case "reject":
+			for _, v := range values {
+				c.Reject = append(c.Reject, body[m[4]+v[2]:m[4]+v[3]])
+			}
+			continue
+		}
+		rich[kind] = [2]int{bodyStart + m[4] + values[0][2], bodyStart + m[4] + values[0][3]}
+	}
+	if m := becauseRegex.FindStringSubmatch(body); m != nil {
+		c.Because = m[1]
+	}
+
+	if c.Version != "" {
+		return
+	}
+	for _, kind := range []string{"strictly", "require", "prefer"} {
+		if pos, ok := rich[kind]; ok {
+			c.Version = masked[pos[0]:pos[1]]
+			s.versionStart, s.versionEnd = pos[0], pos[1]
+			return
+		}
+	}
+}
+
+// declarationClosure 返回紧跟在声明之后的配置闭包，没有时返回nil。
+func declarationClosure(masked string, blocks []*blockSpan, parent *blockSpan, end int) *blockSpan {
+	i := end
+	for i < len(masked) && (masked[i] == ' ' || masked[i] == '\t') {
+		i++
+	}
+	if i >= len(masked) || masked[i] != '{' {
+		return nil
+	}
+	for _, block := range blocks {
+		if block.parent == parent && block.openPos == i {
+			return block
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+const constraintsTestContent = `dependencies {
+    implementation 'org.foo:bar:1.0'
+    constraints {
+        implementation('org.foo:bar') {
+            version { strictly '1.2.3' }
+            because 'CVE-2023-1234'
+        }
+        api "com.example:util:2.0" // pinned
+        runtimeOnly(group: 'org.acme', name: 'driver', version: '3.0')
+        implementation("org.baz:qux") { version { require("1.1"); reject("1.0", "1.0.1") } }
+    }
+}
+`
+
+func TestParseConstraints(t *testing.T) {
+	result, err := NewParser().Parse(constraintsTestContent)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(result.Project.Dependencies) != 1 || result.Project.Dependencies[0].Version != "1.0" {
+		t.Errorf("Constraints should not be reported as dependencies, got %+v", result.Project.Dependencies)
+	}
+
+	expected := []*model.DependencyConstraint{
+		{Group: "org.foo", Name: "bar", Scope: "implementation", Version: "1.2.3", Strictly: "1.2.3", Because: "CVE-2023-1234"},
+		{Group: "com.example", Name: "util", Scope: "api", Version: "2.0"},
+		{Group: "org.acme", Name: "driver", Scope: "runtimeOnly", Version: "3.0"},
+		{Group: "org.baz", Name: "qux", Scope: "implementation", Version: "1.1", Require: "1.1", Reject: []string{"1.0", "1.0.1"}},
+	}
+	if len(result.Project.Constraints) != len(expected) {
+		t.Fatalf("Expected %d constraints, got %d", len(expected), len(result.Project.Constraints))
+	}
+	for i, constraint := range result.Project.Constraints {
+		got := *constraint
+		got.Raw = ""
+		if !reflect.DeepEqual(&got, expected[i]) {
+			t.Errorf("Constraint %d = %+v, want %+v", i, got, expected[i])
+		}
+	}
+	if raw := result.Project.Constraints[1].Raw; raw != `api "com.example:util:2.0"` {
+		t.Errorf("Unexpected raw declaration %q", raw)
+	}
+}
+
+func TestSourceMappedConstraints(t *testing.T) {
+	result, err := NewSourceAwareParser().ParseWithSourceMapping(constraintsTestContent)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping() error = %v", err)
+	}
+
+	project := result.SourceMappedProject
+	if len(project.SourceMappedDependencies) != 1 {
+		t.Errorf("Expected only the top-level dependency to be mapped, got %d", len(project.SourceMappedDependencies))
+	}
+	if len(project.SourceMappedConstraints) != 4 {
+		t.Fatalf("Expected 4 mapped constraints, got %d", len(project.SourceMappedConstraints))
+	}
+
+	text := func(r model.SourceRange) string {
+		return constraintsTestContent[r.Start.StartPos:r.End.StartPos]
+	}
+	first := project.SourceMappedConstraints[0]
+	if first.SourceRange.Start.Line != 4 || first.SourceRange.End.Line != 7 || text(first.VersionRange) != "1.2.3" ||
+		text(first.CoordinateRange) != "org.foo:bar" || first.DependencyConstraint != project.Constraints[0] {
+		t.Errorf("Unexpected mapping for the strict constraint: %+v", first)
+	}
+	if r := project.SourceMappedConstraints[1].VersionRange; text(r) != "2.0" || r.Start.Line != 8 {
+		t.Errorf("Expected the coordinate version to be mapped, got %q at line %d", text(r), r.Start.Line)
+	}
+	if mapped := project.SourceMappedConstraints[2]; text(mapped.VersionRange) != "3.0" || mapped.CoordinateRange.Start.Length != 0 {
+		t.Errorf("Unexpected mapping for the map-style constraint: %+v", mapped)
+	}
+}
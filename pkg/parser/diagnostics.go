@@ -0,0 +1,116 @@
+// Package parser 提供构建脚本常见问题的诊断检测。
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// deprecatedConfigurations 是已废弃的依赖配置及其替代配置。
+var deprecatedConfigurations = map[string]string{
+	"compile":            "implementation",
+	"runtime":            "runtimeOnly",
+	"testCompile":        "testImplementation",
+	"testRuntime":        "testRuntimeOnly",
+	"androidTestCompile": "androidTestImplementation",
+	"debugCompile":       "debugImplementation",
+	"releaseCompile":     "releaseImplementation",
+	"provided":           "compileOnly",
+}
+
+var (
+	// 匹配使用废弃配置的依赖声明，例如 compile 'g:a:v' 或 testCompile("g:a:v")。
+	deprecatedConfigurationRegex = regexp.MustCompile(
+		`(?m)^[ \t]*(compile|runtime|testCompile|testRuntime|androidTestCompile|debugCompile|releaseCompile|provided)` +
+			`[ \t]*(?:\(|[ \t])[ \t]*(?:['"]|group\s*:|project\s*\(|files\s*\(|fileTree\s*\()`)
+
+	// 匹配 jcenter() 仓库。
+	jcenterRegex = regexp.MustCompile(`\bjcenter\s*\(\s*\)`)
+
+	// 匹配仓库中使用http协议的url。
+	insecureURLRegex = regexp.MustCompile(`\burl\s*(?:=|\()?\s*(?:uri\s*\(\s*)?['"](http://[^'"]+)['"]`)
+
+	// 匹配字符串形式的依赖坐标，用于检查版本。
+	coordinateVersionRegex = regexp.MustCompile(`['"][^:'"\s]+:[^:'"\s]+:([^:'"\s@]+)[^'"\s]*['"]`)
+
+	// 匹配Map形式依赖中的版本。
+	mapVersionRegex = regexp.MustCompile(`\bversion\s*:\s*['"]([^'"]+)['"]`)
+)
+
+// detectDiagnostics 检测构建脚本中的常见问题：废弃的依赖配置、jcenter仓库、http仓库和动态版本。
+// 注释中的内容会被忽略，结果按位置排序。无效的依赖坐标只在 Validate 和严格模式中检查。
+func detectDiagnostics(content string) []*model.Diagnostic {
+	code := maskComments(content)
+	index := newLineIndex(content)
+	diagnostics := make([]*model.Diagnostic, 0)
+
+	add := func(diagCode string, severity model.Severity, start, end int, message string) {
+		diagnostics = append(diagnostics, &model.Diagnostic{
+			Code:        diagCode,
+			Severity:    severity,
+			Message:     message,
+			SourceRange: index.rangeOf(start, end),
+		})
+	}
+
+	// 各正则都无法利用字面量前缀加速，先用必然出现的关键字过滤掉不可能匹配的脚本。
+	if strings.Contains(code, "ompile") || strings.Contains(code, "untime") || strings.Contains(code, "provided") {
+		for _, m := range deprecatedConfigurationRegex.FindAllStringSubmatchIndex(code, -1) {
+			name := code[m[2]:m[3]]
+			add(model.DiagnosticDeprecatedConfiguration, model.SeverityWarning, m[2], m[3],
+				fmt.Sprintf("配置 %s 已废弃，请改用 %s", name, deprecatedConfigurations[name]))
+		}
+	}
+
+	if strings.Contains(code, "jcenter") {
+		for _, m := range jcenterRegex.FindAllStringIndex(code, -1) {
+			add(model.DiagnosticJCenterRepository, model.SeverityWarning, m[0], m[1], "JCenter仓库已停止服务，请改用 mavenCentral()")
+		}
+	}
+
+	if strings.Contains(code, "http://") {
+		for _, m := range insecureURLRegex.FindAllStringSubmatchIndex(code, -1) {
+			add(model.DiagnosticInsecureRepository, model.SeverityWarning, m[2], m[3],
+				fmt.Sprintf("仓库地址 %s 使用不安全的http协议", code[m[2]:m[3]]))
+		}
+	}
+
+	// 坐标中的版本紧跟在冒号之后，动态版本必然包含 + 或以 [、(、latest. 开头。
+	versionRegexes := make([]*regexp.Regexp, 0, 2)
+	if strings.Contains(code, "+") || strings.Contains(code, ":[") || strings.Contains(code, ":(") ||
+		strings.Contains(code, ":latest.") {
+		versionRegexes = append(versionRegexes, coordinateVersionRegex)
+	}
+	if strings.Contains(code, "version") {
+		versionRegexes = append(versionRegexes, mapVersionRegex)
+	}
+	for _, re := range versionRegexes {
+		for _, m := range re.FindAllStringSubmatchIndex(code, -1) {
+			version := code[m[2]:m[3]]
+			if IsDynamicVersion(version) {
+				add(model.DiagnosticDynamicVersion, model.SeverityWarning, m[2], m[3],
+					fmt.Sprintf("动态版本 %s 会导致构建不可复现", version))
+			}
+		}
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].SourceRange.Start.StartPos < diagnostics[j].SourceRange.Start.StartPos
+	})
+	return diagnostics
+}
+
+// IsDynamicVersion 判断版本号是否为动态版本，例如 1.+、latest.release 或 [1.0,2.0)。
+func IsDynamicVersion(version string) bool {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return false
+	}
+	return strings.HasSuffix(version, "+") ||
+		strings.HasPrefix(version, "latest.") ||
+		strings.ContainsAny(version[:1], "[(]")
+}
@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestDetectDiagnostics(t *testing.T) {
+	content := `repositories {
+    jcenter()
+    maven { url 'http://repo.example.com/maven' }
+    // jcenter()
+}
+
+dependencies {
+    compile 'org.apache.commons:commons-lang3:3.12.0'
+    testCompile("junit:junit:4.+")
+    implementation group: 'com.google.guava', name: 'guava', version: 'latest.release'
+    implementation 'org.slf4j:slf4j-api:[1.7,2.0)'
+    implementation 'com.example:fixed:1.0.0'
+    /* compile 'a:b:1.+' */
+}
+`
+	diagnostics := detectDiagnostics(content)
+
+	expected := []struct {
+		code   string
+		line   int
+		column int
+	}{
+		{model.DiagnosticJCenterRepository, 2, 5},
+		{model.DiagnosticInsecureRepository, 3, 18},
+		{model.DiagnosticDeprecatedConfiguration, 8, 5},
+		{model.DiagnosticDeprecatedConfiguration, 9, 5},
+		{model.DiagnosticDynamicVersion, 9, 30},
+		{model.DiagnosticDynamicVersion, 10, 72},
+		{model.DiagnosticDynamicVersion, 11, 41},
+	}
+
+	if len(diagnostics) != len(expected) {
+		for _, d := range diagnostics {
+			t.Log(d)
+		}
+		t.Fatalf("Expected %d diagnostics, got %d", len(expected), len(diagnostics))
+	}
+
+	for i, want := range expected {
+		got := diagnostics[i]
+		if got.Code != want.code || got.SourceRange.Start.Line != want.line || got.SourceRange.Start.Column != want.column {
+			t.Errorf("Diagnostic %d: got %s, want %s at line %d, col %d", i, got, want.code, want.line, want.column)
+		}
+		if got.Severity != model.SeverityWarning || got.Message == "" {
+			t.Errorf("Diagnostic %d should be a warning with a message: %s", i, got)
+		}
+	}
+
+	if text := content[diagnostics[1].SourceRange.Start.StartPos:diagnostics[1].SourceRange.End.StartPos]; text != "http://repo.example.com/maven" {
+		t.Errorf("Insecure repository range should cover the URL, got %q", text)
+	}
+}
+
+func TestIsDynamicVersion(t *testing.T) {
+	tests := map[string]bool{
+		"1.+":            true,
+		"+":              true,
+		"latest.release": true,
+		"[1.0,2.0)":      true,
+		"1.0.0":          false,
+		"1.0-SNAPSHOT":   false,
+		"":               false,
+	}
+	for version, want := range tests {
+		if got := IsDynamicVersion(version); got != want {
+			t.Errorf("IsDynamicVersion(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestParse_ReportsDiagnostics(t *testing.T) {
+	result, err := NewParser().Parse("repositories {\n    jcenter()\n}\n")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Code != model.DiagnosticJCenterRepository {
+		t.Errorf("Expected a jcenter diagnostic, got %v", result.Warnings)
+	}
+}
+
+func TestParse_ReportsInvalidCoordinates(t *testing.T) {
+	content := "dependencies {\n    implementation 'org.foo;bar:1.0'\n    implementation 'org.foo:baz:1.0'\n}\n"
+
+	// 默认模式不检查坐标，严格模式把无效坐标记录为错误而不是警告。
+	result, err := NewParser().Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Warnings) != 0 || len(result.Errors) != 0 {
+		t.Errorf("Expected no coordinate findings in lenient mode, got %v %v", result.Warnings, result.Errors)
+	}
+
+	result, err = NewParser().(*GradleParser).WithStrict(true).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Expected no warnings in strict mode, got %v", result.Warnings)
+	}
+	if len(result.Errors) != 1 || !errors.Is(result.Errors[0], ErrInvalidCoordinate) || !strings.Contains(result.Errors[0].Error(), "第2行") {
+		t.Errorf("Expected an invalid coordinate error on line 2, got %v", result.Errors)
+	}
+}
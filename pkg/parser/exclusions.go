@@ -0,0 +1,134 @@
+// Package parser 提供配置级依赖排除规则的提取功能。
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// blockConfigurations 是声明依赖配置的块名称。
+const blockConfigurations = "configurations"
+
+var (
+	// 匹配exclude语句，第1组是访问路径前缀，第2组是参数。
+	// 例如 exclude group: 'commons-logging'、all*.exclude module: 'x'、exclude(group = "x", module = "y")。
+	excludeStatementRegex = regexp.MustCompile(`(?s)^(.*?)\bexclude\b\s*(.*)$`)
+
+	// 匹配Groovy的 group: 'x' 和Kotlin的 group = "x" 形式的命名参数。
+	excludeArgRegex = regexp.MustCompile(`\b(group|module)\s*[:=]\s*['"]([^'"]*)['"]`)
+
+	// 匹配Kotlin DSL中以字符串调用的配置块头部，例如 "implementation" { }。
+	quotedHeaderRegex = regexp.MustCompile(`^['"]([\w-]+)['"]$`)
+)
+
+// extractExclusions 提取在配置上声明的排除规则，按在脚本中出现的顺序排列。支持的写法包括
+// configurations.all { exclude ... }、configurations.configureEach { }、configurations { all*.exclude ... }、
+// configurations { runtimeClasspath { exclude ... } }、configurations.named("x") { } 和 configurations["x"].exclude(...)。
+// 依赖声明闭包中的 exclude 只作用于该依赖，不会被提取。
+func extractExclusions(content string) []*model.ExclusionRule {
+	code := maskComments(content)
+	if !strings.Contains(code, blockConfigurations) || !strings.Contains(code, "exclude") {
+		return nil
+	}
+	blocks := scanBlocks(code)
+
+	paths := make(map[*blockSpan][]string, len(blocks))
+	for _, block := range blocks {
+		var segments []string
+		if block.parent != nil {
+			segments = append(segments, paths[block.parent]...)
+		}
+		if m := quotedHeaderRegex.FindStringSubmatch(block.header); m != nil {
+			segments = append(segments, m[1])
+		} else {
+			segments = append(segments, accessPathSegments(configurationAccessPath(block.header))...)
+		}
+		paths[block] = segments
+	}
+
+	// 各条规则在脚本中的起始位置，用于按出现顺序排序。
+	var rules []*model.ExclusionRule
+	starts := make(map[*model.ExclusionRule]int)
+	collect := func(block *blockSpan) {
+		var prefix []string
+		if block != nil {
+			prefix = paths[block]
+		}
+		for _, statement := range blockStatements(code, blocks, block) {
+			m := excludeStatementRegex.FindStringSubmatch(statement.Text)
+			if m == nil {
+				continue
+			}
+			accessPath := strings.TrimSpace(configurationAccessPath(m[1]))
+			if accessPath != "" && !strings.HasSuffix(accessPath, ".") {
+				continue
+			}
+			segments := accessPathSegments(strings.TrimSuffix(accessPath, "."))
+			if accessPath != "" && segments == nil {
+				continue
+			}
+			segments = append(append([]string{}, prefix...), segments...)
+			// it.exclude 中的 it 指向当前配置。
+			if n := len(segments); n > 0 && segments[n-1] == "it" {
+				segments = segments[:n-1]
+			}
+
+			configuration, ok := exclusionConfiguration(segments)
+			if !ok {
+				continue
+			}
+			rule := &model.ExclusionRule{Configuration: configuration, Raw: content[statement.Start:statement.End]}
+			for _, arg := range excludeArgRegex.FindAllStringSubmatch(m[2], -1) {
+				if arg[1] == "group" {
+					rule.Group = arg[2]
+				} else {
+					rule.Module = arg[2]
+				}
+			}
+			for _, entry := range mapEntryRegex.FindAllStringSubmatch(m[2], -1) {
+				switch entry[1] {
+				case "group":
+					rule.Group = entry[2]
+				case "module":
+					rule.Module = entry[2]
+				}
+			}
+			if rule.Group != "" || rule.Module != "" {
+				rules = append(rules, rule)
+				starts[rule] = statement.Start
+			}
+		}
+	}
+	collect(nil)
+	for _, block := range blocks {
+		if lastIndexOf(paths[block], blockConfigurations) >= 0 ||
+			strings.Contains(code[block.openPos:block.end(len(code))], blockConfigurations) {
+			collect(block)
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return starts[rules[i]] < starts[rules[j]] })
+	return rules
+}
+
+// configurationAccessPath 把 all*.exclude 中的展开运算符替换为普通的点号访问。
+func configurationAccessPath(text string) string {
+	return strings.ReplaceAll(text, "*.", ".")
+}
+
+// exclusionConfiguration 根据访问路径判断exclude语句作用的配置。
+// 路径必须恰好在 configurations 之后指定一个配置，all 和 configureEach 表示所有配置。
+func exclusionConfiguration(segments []string) (string, bool) {
+	idx := lastIndexOf(segments, blockConfigurations)
+	if idx < 0 || idx+2 != len(segments) {
+		return "", false
+	}
+	switch name := segments[idx+1]; name {
+	case "all", "configureEach":
+		return "", true
+	default:
+		return name, true
+	}
+}
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestExtractExclusions(t *testing.T) {
+	content := `configurations.all {
+    exclude group: 'commons-logging', module: 'commons-logging'
+}
+configurations {
+    all*.exclude group: 'log4j'
+    runtimeClasspath {
+        exclude module: 'jsr305' // 注释 exclude group: 'ignored'
+    }
+    compileOnly.exclude group: 'org.projectlombok'
+}
+configurations.configureEach { it.exclude(module: 'slf4j-simple') }
+configurations.named("testRuntimeClasspath") {
+    exclude(group = "junit", module = "junit")
+}
+configurations["implementation"].exclude(mapOf("group" to "com.google.code.findbugs"))
+configurations {
+    "annotationProcessor" {
+        exclude(group = "org.checkerframework")
+    }
+}
+dependencies {
+    implementation('org.springframework.boot:spring-boot-starter-web:3.1.0') {
+        exclude group: 'org.springframework.boot', module: 'spring-boot-starter-tomcat'
+    }
+}
+sourceSets.main.java { exclude '**/Generated.java' }
+`
+	result, err := NewParser().Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []model.ExclusionRule{
+		{Group: "commons-logging", Module: "commons-logging", Raw: "exclude group: 'commons-logging', module: 'commons-logging'"},
+		{Group: "log4j", Raw: "all*.exclude group: 'log4j'"},
+		{Configuration: "runtimeClasspath", Module: "jsr305", Raw: "exclude module: 'jsr305'"},
+		{Configuration: "compileOnly", Group: "org.projectlombok", Raw: "compileOnly.exclude group: 'org.projectlombok'"},
+		{Module: "slf4j-simple", Raw: "it.exclude(module: 'slf4j-simple')"},
+		{Configuration: "testRuntimeClasspath", Group: "junit", Module: "junit", Raw: `exclude(group = "junit", module = "junit")`},
+		{Configuration: "implementation", Group: "com.google.code.findbugs",
+			Raw: `configurations["implementation"].exclude(mapOf("group" to "com.google.code.findbugs"))`},
+		{Configuration: "annotationProcessor", Group: "org.checkerframework", Raw: `exclude(group = "org.checkerframework")`},
+	}
+	got := make([]model.ExclusionRule, 0, len(result.Project.Exclusions))
+	for _, rule := range result.Project.Exclusions {
+		got = append(got, *rule)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Exclusions mismatch:\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestProjectExcludedBy(t *testing.T) {
+	result, err := NewParser().Parse(`configurations {
+    all*.exclude group: 'commons-logging'
+    testRuntimeOnly { exclude module: 'hamcrest-core' }
+}
+dependencies {
+    implementation 'commons-logging:commons-logging:1.2'
+    testRuntimeOnly 'org.hamcrest:hamcrest-core:1.3'
+    testImplementation 'org.hamcrest:hamcrest-core:1.3'
+}
+`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	project := result.Project
+	if len(project.Dependencies) != 3 {
+		t.Fatalf("Expected 3 dependencies, got %d", len(project.Dependencies))
+	}
+	if rule := project.ExcludedBy(project.Dependencies[0]); rule == nil || rule.Group != "commons-logging" {
+		t.Errorf("Expected commons-logging to be excluded globally, got %+v", rule)
+	}
+	if rule := project.ExcludedBy(project.Dependencies[1]); rule == nil || rule.Configuration != "testRuntimeOnly" {
+		t.Errorf("Expected hamcrest-core to be excluded from testRuntimeOnly, got %+v", rule)
+	}
+	if rule := project.ExcludedBy(project.Dependencies[2]); rule != nil {
+		t.Errorf("Expected testImplementation hamcrest-core not to be excluded, got %+v", rule)
+	}
+}
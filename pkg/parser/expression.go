@@ -0,0 +1,370 @@
+// Package parser 提供属性值表达式的解析功能。
+package parser
+
+import (
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// ParseExpression 将属性值文本解析为表达式树，忽略其中的注释。
+// 支持字符串、数字等字面量、属性引用、GString插值、方法调用以及 + 和 ?: 运算；
+// 无法识别的文本返回 raw 类型的表达式。文本为空时返回nil。
+func ParseExpression(text string) *model.Expression {
+	text = strings.TrimSpace(maskComments(text))
+	if text == "" {
+		return nil
+	}
+
+	p := &expressionParser{src: text}
+	expr, ok := p.parseBinary()
+	p.skipSpace()
+	if !ok || p.pos != len(text) {
+		return &model.Expression{Kind: model.ExpressionRaw, Raw: text}
+	}
+	return expr
+}
+
+// maxExpressionDepth 是表达式嵌套的最大深度，超过时按raw表达式处理。
+const maxExpressionDepth = 64
+
+// expressionParser 是简单的递归下降表达式解析器。
+type expressionParser struct {
+	src   string
+	pos   int
+	depth int
+}
+
+// binaryOperators 是支持的二元运算符，较长的运算符在前。
+var binaryOperators = []string{"?:", "+"}
+
+// parseBinary 解析左结合的二元运算。
+func (p *expressionParser) parseBinary() (*model.Expression, bool) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxExpressionDepth {
+		return nil, false
+	}
+
+	start := p.pos
+	left, ok := p.parsePostfix()
+	if !ok {
+		return nil, false
+	}
+
+	for {
+		p.skipSpace()
+		op := p.operator()
+		if op == "" {
+			return left, true
+		}
+		p.pos += len(op)
+		p.skipSpace()
+		right, ok := p.parsePostfix()
+		if !ok {
+			return nil, false
+		}
+		left = &model.Expression{
+			Kind:     model.ExpressionBinary,
+			Raw:      p.src[start:p.pos],
+			Operator: op,
+			Parts:    []*model.Expression{left, right},
+		}
+	}
+}
+
+// operator 返回当前位置的二元运算符，不是运算符时返回空字符串。
+func (p *expressionParser) operator() string {
+	rest := p.src[p.pos:]
+	for _, op := range binaryOperators {
+		if strings.HasPrefix(rest, op) && !strings.HasPrefix(rest, op+op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// parsePostfix 解析基本表达式及其后的成员访问和方法调用。
+func (p *expressionParser) parsePostfix() (*model.Expression, bool) {
+	start := p.pos
+	expr, ok := p.parsePrimary()
+	if !ok {
+		return nil, false
+	}
+
+	for {
+		rest := p.src[p.pos:]
+		switch {
+		case strings.HasPrefix(rest, "?.") || strings.HasPrefix(rest, "."):
+			save := p.pos
+			if rest[0] == '?' {
+				p.pos++
+			}
+			p.pos++
+			name := p.identifier()
+			if name == "" {
+				p.pos = save
+				return expr, true
+			}
+			if expr.Kind == model.ExpressionReference && expr.Receiver == nil {
+				expr.Path = append(expr.Path, name)
+				expr.Value = strings.Join(expr.Path, ".")
+				expr.Raw = p.src[start:p.pos]
+			} else {
+				expr = &model.Expression{
+					Kind:     model.ExpressionReference,
+					Raw:      p.src[start:p.pos],
+					Value:    name,
+					Receiver: expr,
+				}
+			}
+		case strings.HasPrefix(rest, "("):
+			if expr.Kind != model.ExpressionReference {
+				return nil, false
+			}
+			args, ok := p.parseArguments()
+			if !ok {
+				return nil, false
+			}
+			call := &model.Expression{
+				Kind:     model.ExpressionCall,
+				Raw:      p.src[start:p.pos],
+				Value:    expr.Value,
+				Receiver: expr.Receiver,
+				Parts:    args,
+			}
+			if expr.Receiver == nil && len(expr.Path) > 1 {
+				// a.b.c(...) 中 a.b 是调用对象，c 是方法名。
+				call.Value = expr.Path[len(expr.Path)-1]
+				call.Receiver = &model.Expression{
+					Kind:  model.ExpressionReference,
+					Raw:   strings.Join(expr.Path[:len(expr.Path)-1], "."),
+					Value: strings.Join(expr.Path[:len(expr.Path)-1], "."),
+					Path:  expr.Path[:len(expr.Path)-1],
+				}
+			}
+			expr = call
+		default:
+			return expr, true
+		}
+	}
+}
+
+// parsePrimary 解析字面量、字符串、属性引用或括号表达式。
+func (p *expressionParser) parsePrimary() (*model.Expression, bool) {
+	if p.pos >= len(p.src) {
+		return nil, false
+	}
+
+	start := p.pos
+	c := p.src[p.pos]
+	switch {
+	case c == '\'' || c == '"':
+		return p.parseString()
+	case isDigit(c) || (c == '-' && p.pos+1 < len(p.src) && isDigit(p.src[p.pos+1])):
+		p.pos++
+		for p.pos < len(p.src) && (isIdentifierChar(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		text := p.src[start:p.pos]
+		return &model.Expression{Kind: model.ExpressionLiteral, Raw: text, Value: text}, true
+	case c == '(':
+		p.pos++
+		p.skipSpace()
+		inner, ok := p.parseBinary()
+		p.skipSpace()
+		if !ok || p.pos >= len(p.src) || p.src[p.pos] != ')' {
+			return nil, false
+		}
+		p.pos++
+		return inner, true
+	}
+
+	name := p.identifier()
+	if name == "" {
+		return nil, false
+	}
+	switch name {
+	case "true", "false", "null":
+		return &model.Expression{Kind: model.ExpressionLiteral, Raw: name, Value: name}, true
+	}
+	return &model.Expression{
+		Kind:  model.ExpressionReference,
+		Raw:   name,
+		Value: name,
+		Path:  []string{name},
+	}, true
+}
+
+// parseString 解析字符串字面量，含插值的双引号字符串解析为GString。
+func (p *expressionParser) parseString() (*model.Expression, bool) {
+	start := p.pos
+	quote := p.src[start]
+	delim := string(quote)
+	if strings.HasPrefix(p.src[start:], strings.Repeat(delim, 3)) {
+		delim = strings.Repeat(delim, 3)
+	}
+
+	end := skipString(p.src, start)
+	if end-start < 2*len(delim) || !strings.HasSuffix(p.src[:end], delim) {
+		return nil, false
+	}
+	p.pos = end
+
+	raw := p.src[start:end]
+	body := raw[len(delim) : len(raw)-len(delim)]
+	if quote == '\'' {
+		return &model.Expression{Kind: model.ExpressionLiteral, Raw: raw, Value: unescapeString(body)}, true
+	}
+	return parseGString(raw, body), true
+}
+
+// parseGString 解析双引号字符串中的 ${expr} 和 $name 插值。
+func parseGString(raw, body string) *model.Expression {
+	parts := make([]*model.Expression, 0)
+	interpolated := false
+	var literal strings.Builder
+
+	flush := func() {
+		if literal.Len() > 0 {
+			text := literal.String()
+			parts = append(parts, &model.Expression{Kind: model.ExpressionLiteral, Raw: text, Value: unescapeString(text)})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\\' && i+1 < len(body):
+			literal.WriteString(body[i : i+2])
+			i++
+		case c == '$' && i+1 < len(body) && body[i+1] == '{':
+			end := skipInterpolation(body, i+2)
+			flush()
+			inner := ParseExpression(strings.TrimSuffix(body[i+2:end], "}"))
+			if inner == nil {
+				inner = &model.Expression{Kind: model.ExpressionRaw, Raw: ""}
+			}
+			parts = append(parts, inner)
+			interpolated = true
+			i = end - 1
+		case c == '$' && i+1 < len(body) && isIdentifierStart(body[i+1]):
+			p := &expressionParser{src: body, pos: i + 1}
+			path := []string{p.identifier()}
+			for p.pos+1 < len(body) && body[p.pos] == '.' && isIdentifierStart(body[p.pos+1]) {
+				p.pos++
+				path = append(path, p.identifier())
+			}
+			flush()
+			parts = append(parts, &model.Expression{
+				Kind:  model.ExpressionReference,
+				Raw:   body[i+1 : p.pos],
+				Value: strings.Join(path, "."),
+				Path:  path,
+			})
+			interpolated = true
+			i = p.pos - 1
+		default:
+			literal.WriteByte(c)
+		}
+	}
+
+	if !interpolated {
+		return &model.Expression{Kind: model.ExpressionLiteral, Raw: raw, Value: unescapeString(body)}
+	}
+	flush()
+	return &model.Expression{Kind: model.ExpressionGString, Raw: raw, Parts: parts}
+}
+
+// parseArguments 解析括号中以逗号分隔的参数列表。
+func (p *expressionParser) parseArguments() ([]*model.Expression, bool) {
+	p.pos++ // 跳过 (。
+	args := make([]*model.Expression, 0)
+	p.skipSpace()
+	if p.pos < len(p.src) && p.src[p.pos] == ')' {
+		p.pos++
+		return args, true
+	}
+
+	for {
+		p.skipSpace()
+		arg, ok := p.parseBinary()
+		if !ok {
+			return nil, false
+		}
+		args = append(args, arg)
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return nil, false
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return args, true
+		default:
+			return nil, false
+		}
+	}
+}
+
+// identifier 读取当前位置的标识符，不是标识符时返回空字符串。
+func (p *expressionParser) identifier() string {
+	start := p.pos
+	if p.pos >= len(p.src) || !isIdentifierStart(p.src[p.pos]) {
+		return ""
+	}
+	for p.pos < len(p.src) && isIdentifierChar(p.src[p.pos]) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// skipSpace 跳过空白字符。
+func (p *expressionParser) skipSpace() {
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n", p.src[p.pos]) != -1 {
+		p.pos++
+	}
+}
+
+// unescapeString 处理字符串中的转义字符。
+func unescapeString(text string) string {
+	if !strings.Contains(text, `\`) {
+		return text
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\\' || i+1 >= len(text) {
+			sb.WriteByte(text[i])
+			continue
+		}
+		i++
+		switch text[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		default:
+			sb.WriteByte(text[i])
+		}
+	}
+	return sb.String()
+}
+
+// isDigit 判断字符是否为数字。
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isIdentifierStart 判断字符能否作为标识符的首字符。
+func isIdentifierStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isIdentifierChar 判断字符能否出现在标识符中。
+func isIdentifierChar(c byte) bool {
+	return isIdentifierStart(c) || isDigit(c)
+}
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestParseExpression_Literals(t *testing.T) {
+	tests := []struct {
+		input string
+		value string
+	}{
+		{`'1.0.0'`, "1.0.0"},
+		{`"1.0.0"`, "1.0.0"},
+		{`'it\'s'`, "it's"},
+		{`"""multi"""`, "multi"},
+		{`17`, "17"},
+		{`1.5`, "1.5"},
+		{`true`, "true"},
+		{`'1.0' // 注释`, "1.0"},
+	}
+
+	for _, tt := range tests {
+		expr := ParseExpression(tt.input)
+		if !expr.IsLiteral() || expr.Value != tt.value {
+			t.Errorf("ParseExpression(%q) = %+v, want literal %q", tt.input, expr, tt.value)
+		}
+	}
+
+	if ParseExpression("  ") != nil {
+		t.Error("Expected nil for empty expression")
+	}
+}
+
+func TestParseExpression_Reference(t *testing.T) {
+	expr := ParseExpression("rootProject.version")
+	if expr.Kind != model.ExpressionReference {
+		t.Fatalf("Expected reference, got %s", expr.Kind)
+	}
+	if !reflect.DeepEqual(expr.Path, []string{"rootProject", "version"}) || expr.Value != "rootProject.version" {
+		t.Errorf("Unexpected reference: %+v", expr)
+	}
+}
+
+func TestParseExpression_GString(t *testing.T) {
+	expr := ParseExpression(`"${project.name}-${version}.jar"`)
+	if expr.Kind != model.ExpressionGString {
+		t.Fatalf("Expected gstring, got %s", expr.Kind)
+	}
+	if len(expr.Parts) != 4 {
+		t.Fatalf("Expected 4 parts, got %d", len(expr.Parts))
+	}
+	if expr.Parts[1].Value != "-" || expr.Parts[3].Value != ".jar" {
+		t.Errorf("Unexpected literal parts: %q, %q", expr.Parts[1].Value, expr.Parts[3].Value)
+	}
+	want := []string{"project.name", "version"}
+	if got := expr.References(); !reflect.DeepEqual(got, want) {
+		t.Errorf("References() = %v, want %v", got, want)
+	}
+
+	// Groovy中 $name 形式的插值会继续读取点号后的属性。
+	short := ParseExpression(`"v$project.version"`)
+	if len(short.Parts) != 2 || short.Parts[1].Value != "project.version" {
+		t.Errorf("Unexpected short interpolation: %+v", short.Parts)
+	}
+}
+
+func TestParseExpression_CallsAndOperators(t *testing.T) {
+	expr := ParseExpression(`project.findProperty('lib.version') ?: "1.0"`)
+	if expr.Kind != model.ExpressionBinary || expr.Operator != "?:" {
+		t.Fatalf("Expected elvis expression, got %+v", expr)
+	}
+	call := expr.Parts[0]
+	if call.Kind != model.ExpressionCall || call.Value != "findProperty" {
+		t.Fatalf("Expected findProperty call, got %+v", call)
+	}
+	if call.Receiver == nil || call.Receiver.Value != "project" {
+		t.Errorf("Expected project receiver, got %+v", call.Receiver)
+	}
+	if len(call.Parts) != 1 || call.Parts[0].Value != "lib.version" {
+		t.Errorf("Unexpected call arguments: %+v", call.Parts)
+	}
+
+	chained := ParseExpression(`providers.gradleProperty("v").get()`)
+	if chained.Kind != model.ExpressionCall || chained.Value != "get" || chained.Receiver.Kind != model.ExpressionCall {
+		t.Errorf("Unexpected chained call: %+v", chained)
+	}
+
+	concat := ParseExpression(`"lib-" + version`)
+	if concat.Kind != model.ExpressionBinary || concat.Operator != "+" || concat.Parts[1].Value != "version" {
+		t.Errorf("Unexpected concatenation: %+v", concat)
+	}
+
+	raw := ParseExpression(`files { it }`)
+	if raw.Kind != model.ExpressionRaw || raw.Raw != "files { it }" {
+		t.Errorf("Expected raw expression, got %+v", raw)
+	}
+}
+
+func TestGradleParser_PropertyExpressions(t *testing.T) {
+	content := `group = 'com.example'
+version = rootProject.version
+ext.archiveName = "${project.name}-${project.version}.jar"
+`
+	result, err := NewParser().Parse(content)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	exprs := result.Project.PropertyExpressions
+	if _, ok := exprs["group"]; ok {
+		t.Error("Expected literal group not to have an expression")
+	}
+	if exprs["version"] == nil || exprs["version"].Kind != model.ExpressionReference {
+		t.Errorf("Expected reference expression for version, got %+v", exprs["version"])
+	}
+	archive := exprs["ext.archiveName"]
+	if archive == nil || archive.Kind != model.ExpressionGString {
+		t.Fatalf("Expected gstring expression for ext.archiveName, got %+v", archive)
+	}
+	if got := archive.References(); !reflect.DeepEqual(got, []string{"project.name", "project.version"}) {
+		t.Errorf("Unexpected references: %v", got)
+	}
+}
+
+func TestParseExpression_DepthLimit(t *testing.T) {
+	deep := strings.Repeat("f(", maxExpressionDepth+1) + "1" + strings.Repeat(")", maxExpressionDepth+1)
+	expr := ParseExpression(deep)
+	if expr.Kind != model.ExpressionRaw {
+		t.Errorf("Expected raw expression for deeply nested input, got %s", expr.Kind)
+	}
+
+	shallow := strings.Repeat("f(", 3) + "1" + strings.Repeat(")", 3)
+	if expr := ParseExpression(shallow); expr.Kind != model.ExpressionCall {
+		t.Errorf("Expected call expression, got %s", expr.Kind)
+	}
+}
@@ -0,0 +1,71 @@
+// Package parser 提供构建特性的检测功能。
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配版本目录的使用，例如 libs.guava、alias(libs.plugins.kotlin) 或 versionCatalogs { }。
+	versionCatalogRegex = regexp.MustCompile(`\blibs\.[A-Za-z]|\bversionCatalogs\s*\{`)
+
+	// 匹配 includeBuild('path') 或 includeBuild("path")。
+	includeBuildRegex = regexp.MustCompile(`\bincludeBuild\s*\(?\s*['"]([^'"]+)['"]`)
+
+	// 匹配Java工具链配置，例如 java { toolchain { } }、kotlin { jvmToolchain(17) }。
+	toolchainRegex = regexp.MustCompile(`\btoolchain\s*\{|\btoolchain\.languageVersion\b|\bjvmToolchain\s*[({]`)
+
+	// 对配置缓存敏感的写法。literal 是匹配时必然出现的文本，不包含它的脚本无需执行正则。
+	configCachePatterns = []struct {
+		construct string
+		literal   string
+		regex     *regexp.Regexp
+	}{
+		{model.ConfigCacheSystemProperty, "System.getProperty", regexp.MustCompile(`\bSystem\.getProperty\s*\(`)},
+		{model.ConfigCacheEnvironment, "System.getenv", regexp.MustCompile(`\bSystem\.getenv\s*\(`)},
+		{model.ConfigCacheBuildDirAssignment, "buildDir", regexp.MustCompile(`(?m)^\s*(?:project\.)?buildDir\s*=`)},
+		{model.ConfigCacheBuildListener, "gradle.", regexp.MustCompile(`\bgradle\.(?:buildFinished|addBuildListener|addListener)\b`)},
+	}
+
+	// 匹配对project的访问。
+	projectAccessRegex = regexp.MustCompile(`\bproject\s*\.`)
+)
+
+// detectFeatures 从脚本内容中检测使用的Gradle特性，忽略注释中的内容。
+// 正则以单词边界开头，无法利用字面量前缀加速，因此先用关键字过滤掉不可能匹配的脚本。
+func detectFeatures(content string) *model.Fingerprint {
+	code := maskComments(content)
+	features := &model.Fingerprint{
+		VersionCatalog: (strings.Contains(code, "libs.") || strings.Contains(code, "versionCatalogs")) &&
+			versionCatalogRegex.MatchString(code),
+		Toolchains: (strings.Contains(code, "toolchain") || strings.Contains(code, "Toolchain")) && toolchainRegex.MatchString(code),
+	}
+
+	if strings.Contains(code, "includeBuild") {
+		for _, match := range includeBuildRegex.FindAllStringSubmatch(code, -1) {
+			features.CompositeBuild = true
+			features.IncludedBuilds = appendUnique(features.IncludedBuilds, match[1])
+		}
+	}
+
+	for _, pattern := range configCachePatterns {
+		if strings.Contains(code, pattern.literal) && pattern.regex.MatchString(code) {
+			features.ConfigurationCacheConstructs = append(features.ConfigurationCacheConstructs, pattern.construct)
+		}
+	}
+
+	// 任务动作在执行阶段运行，此时访问project与配置缓存不兼容。
+	for _, block := range scanBlocks(code) {
+		if (block.name == "doFirst" || block.name == "doLast") && block.closePos > block.openPos &&
+			projectAccessRegex.MatchString(code[block.openPos+1:block.closePos]) {
+			features.ConfigurationCacheConstructs = append(features.ConfigurationCacheConstructs, model.ConfigCacheProjectAtExecution)
+			break
+		}
+	}
+
+	features.ConfigurationCacheSensitive = len(features.ConfigurationCacheConstructs) > 0
+	return features
+}
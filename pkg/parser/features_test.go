@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestDetectFeatures(t *testing.T) {
+	content := `
+plugins {
+    alias(libs.plugins.kotlin.jvm)
+}
+
+java {
+    toolchain {
+        languageVersion = JavaLanguageVersion.of(17)
+    }
+}
+
+buildDir = 'out'
+def token = System.getenv('TOKEN') // System.getProperty('ignored')
+
+tasks.register('report') {
+    doLast {
+        println project.version
+    }
+}
+
+includeBuild('../shared-logic')
+`
+	features := detectFeatures(content)
+	if !features.VersionCatalog || !features.Toolchains || !features.CompositeBuild {
+		t.Errorf("Expected version catalog, toolchains and composite build, got %+v", features)
+	}
+	if !reflect.DeepEqual(features.IncludedBuilds, []string{"../shared-logic"}) {
+		t.Errorf("Unexpected included builds: %v", features.IncludedBuilds)
+	}
+
+	want := []string{model.ConfigCacheEnvironment, model.ConfigCacheBuildDirAssignment, model.ConfigCacheProjectAtExecution}
+	if !features.ConfigurationCacheSensitive || !reflect.DeepEqual(features.ConfigurationCacheConstructs, want) {
+		t.Errorf("ConfigurationCacheConstructs = %v, want %v", features.ConfigurationCacheConstructs, want)
+	}
+
+	plain := detectFeatures("group = 'com.example'\ndependencies {\n    implementation 'a:b:1.0'\n}\n")
+	if plain.VersionCatalog || plain.Toolchains || plain.CompositeBuild || plain.ConfigurationCacheSensitive {
+		t.Errorf("Expected no features, got %+v", plain)
+	}
+}
@@ -0,0 +1,161 @@
+// Package parser 提供脚本文件类型的判断和初始化脚本配置的提取功能。
+package parser
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/config"
+	"github.com/scagogogo/gradle-parser/pkg/dependency"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// initScriptBlock 是初始化脚本中声明自身classpath的块名称。
+const initScriptBlock = "initscript"
+
+// projectInjectionBlocks 是初始化脚本中向项目注入配置的块名称（去掉 gradle.、rootProject. 等前缀后）。
+var projectInjectionBlocks = map[string]bool{
+	"allprojects":       true,
+	"subprojects":       true,
+	"rootProject":       true,
+	"beforeProject":     true,
+	"afterProject":      true,
+	"projectsLoaded":    true,
+	"projectsEvaluated": true,
+}
+
+// settingsInjectionBlocks 是初始化脚本中向settings注入配置的块名称。
+var settingsInjectionBlocks = map[string]bool{
+	"settingsEvaluated": true,
+	"beforeSettings":    true,
+}
+
+// settingsStatementRegex 匹配只出现在settings文件中的顶层语句。
+var settingsStatementRegex = regexp.MustCompile(`(?m)^\s*(?:rootProject\.name\s*=|include(?:Build)?\s*\(?\s*['"])`)
+
+// fileKindFromPath 按文件名判断脚本类型。
+// init.gradle、*.init.gradle 和 init.d 目录下的脚本是初始化脚本，其他 .gradle 文件是脚本插件，
+// 不是 .gradle 或 .gradle.kts 文件时返回空字符串。
+func fileKindFromPath(filePath string) model.FileKind {
+	base := strings.TrimSuffix(filepath.Base(filePath), ".kts")
+	if !strings.HasSuffix(base, ".gradle") {
+		return ""
+	}
+	switch {
+	case base == "build.gradle":
+		return model.FileKindBuild
+	case base == "settings.gradle":
+		return model.FileKindSettings
+	case base == "init.gradle" || strings.HasSuffix(base, ".init.gradle"):
+		return model.FileKindInit
+	case filepath.Base(filepath.Dir(filePath)) == "init.d":
+		return model.FileKindInit
+	}
+	return model.FileKindScript
+}
+
+// detectFileKind 按脚本内容判断脚本类型。
+// 包含 initscript、settingsEvaluated 等只在初始化脚本中使用的顶层块时是初始化脚本，
+// 包含 rootProject.name 赋值或 include 语句时是settings脚本，否则视为构建脚本。
+func detectFileKind(content string) model.FileKind {
+	masked := maskComments(content)
+	for _, block := range scanBlocks(masked) {
+		if block.parent != nil {
+			continue
+		}
+		name := lastBlockSegment(block.name)
+		if name == initScriptBlock || settingsInjectionBlocks[name] ||
+			(name != block.name && projectInjectionBlocks[name] && strings.HasPrefix(block.name, "gradle.")) {
+			return model.FileKindInit
+		}
+	}
+	if settingsStatementRegex.MatchString(masked) {
+		return model.FileKindSettings
+	}
+	return model.FileKindBuild
+}
+
+// lastBlockSegment 返回带点号的块名称的最后一段，例如 "gradle.allprojects" 返回 "allprojects"。
+func lastBlockSegment(name string) string {
+	return name[strings.LastIndexByte(name, '.')+1:]
+}
+
+// injectionBlock 返回块所在的最外层注入块，以及它是否向settings注入配置，不在注入块中时返回nil。
+func injectionBlock(block *blockSpan) (*blockSpan, bool) {
+	var outer *blockSpan
+	settings := false
+	for cur := block; cur != nil; cur = cur.parent {
+		name := lastBlockSegment(cur.name)
+		switch {
+		case projectInjectionBlocks[name]:
+			outer, settings = cur, false
+		case settingsInjectionBlocks[name]:
+			outer, settings = cur, true
+		}
+	}
+	return outer, settings
+}
+
+// extractInitScript 提取初始化脚本中的classpath依赖、classpath仓库以及注入到项目和settings中的仓库和插件。
+func extractInitScript(content string, depParser *dependency.Parser, lineNumbers bool) *model.InitScript {
+	initScript := &model.InitScript{
+		Classpath:            make([]*model.Dependency, 0),
+		Repositories:         make([]*model.Repository, 0),
+		ProjectRepositories:  make([]*model.Repository, 0),
+		ProjectPlugins:       make([]*model.Plugin, 0),
+		SettingsRepositories: make([]*model.Repository, 0),
+	}
+	var index *lineIndex
+	if lineNumbers {
+		index = newLineIndex(content)
+	}
+	repoParser := config.NewRepositoryParser().WithLineNumbers(lineNumbers)
+	pluginParser := config.NewPluginParser().WithLineNumbers(lineNumbers)
+	blocks := scanBlocks(content)
+	masked := maskComments(content)
+
+	for _, block := range blocks {
+		switch {
+		case block.name == initScriptBlock && block.parent == nil:
+			isolated := isolateBlock(content, block)
+			initScript.Repositories = append(initScript.Repositories, repoParser.ExtractRepositoriesFromText(isolated)...)
+		case block.name == contextDependencies && block.parent != nil && block.parent.name == initScriptBlock:
+			forEachDeclaration(masked, blocks, block, func(_, _ string, start, end int) {
+				if dep := depParser.ParseClasspathDeclaration(content[start:end]); dep != nil {
+					if index != nil {
+						dep.DeclaredAtLine, _ = index.position(start)
+					}
+					initScript.Classpath = append(initScript.Classpath, dep)
+				}
+			})
+		default:
+			// 只处理最外层的注入块，嵌套的注入块（例如 projectsLoaded 中的 allprojects）已包含在其中。
+			outer, settings := injectionBlock(block)
+			if outer != block {
+				continue
+			}
+			isolated := isolateBlock(content, block)
+			repos := repoParser.ExtractRepositoriesFromText(isolated)
+			if settings {
+				initScript.SettingsRepositories = append(initScript.SettingsRepositories, repos...)
+				continue
+			}
+			initScript.ProjectRepositories = append(initScript.ProjectRepositories, repos...)
+			initScript.ProjectPlugins = append(initScript.ProjectPlugins, pluginParser.ExtractPluginsFromText(isolated)...)
+		}
+	}
+	return initScript
+}
+
+// isolateBlock 把块以外的内容替换为空格（保留换行），使提取结果中的行号仍与原文一致。
+func isolateBlock(content string, block *blockSpan) string {
+	isolated := []byte(content)
+	end := block.end(len(content))
+	for i := range isolated {
+		if (i < block.headerStart || i >= end) && isolated[i] != '\n' {
+			isolated[i] = ' '
+		}
+	}
+	return string(isolated)
+}
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+const testInitScript = `
+initscript {
+    repositories {
+        maven { url 'https://plugins.example.com/m2' }
+    }
+    dependencies {
+        classpath 'com.example:init-plugin:1.2.0'
+    }
+}
+
+allprojects {
+    repositories {
+        maven { url 'https://mirror.example.com/maven' }
+    }
+    apply plugin: 'idea'
+}
+
+gradle.settingsEvaluated { settings ->
+    settings.pluginManagement {
+        repositories {
+            maven { url 'https://mirror.example.com/plugins' }
+        }
+    }
+}
+`
+
+func TestParse_InitScript(t *testing.T) {
+	result, err := NewParser().Parse(testInitScript)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if result.FileKind != model.FileKindInit {
+		t.Fatalf("Expected file kind init, got %q", result.FileKind)
+	}
+
+	initScript := result.Project.InitScript
+	if initScript == nil {
+		t.Fatal("Expected InitScript to be set")
+	}
+	if len(initScript.Classpath) != 1 || initScript.Classpath[0].Name != "init-plugin" {
+		t.Errorf("Expected init-plugin classpath dependency, got %+v", initScript.Classpath)
+	}
+	checkRepositoryURLs(t, "Repositories", initScript.Repositories, "https://plugins.example.com/m2")
+	checkRepositoryURLs(t, "ProjectRepositories", initScript.ProjectRepositories, "https://mirror.example.com/maven")
+	checkRepositoryURLs(t, "SettingsRepositories", initScript.SettingsRepositories, "https://mirror.example.com/plugins")
+	if len(initScript.ProjectPlugins) != 1 || initScript.ProjectPlugins[0].ID != "idea" {
+		t.Errorf("Expected injected idea plugin, got %+v", initScript.ProjectPlugins)
+	}
+}
+
+func checkRepositoryURLs(t *testing.T, field string, repos []*model.Repository, want ...string) {
+	t.Helper()
+	if len(repos) != len(want) {
+		t.Errorf("Expected %d %s, got %d", len(want), field, len(repos))
+		return
+	}
+	for i, repo := range repos {
+		if repo.URL != want[i] {
+			t.Errorf("Expected %s[%d] URL %q, got %q", field, i, want[i], repo.URL)
+		}
+	}
+}
+
+func TestParse_InitScriptNestedInjection(t *testing.T) {
+	content := `
+gradle.projectsLoaded { g ->
+    g.rootProject.allprojects {
+        repositories {
+            mavenCentral()
+        }
+    }
+}
+`
+	result, err := NewParser().Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if result.FileKind != model.FileKindInit {
+		t.Fatalf("Expected file kind init, got %q", result.FileKind)
+	}
+	if got := len(result.Project.InitScript.ProjectRepositories); got != 1 {
+		t.Errorf("Expected nested injection to be counted once, got %d repositories", got)
+	}
+}
+
+func TestDetectFileKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    model.FileKind
+	}{
+		{"build script", "plugins { id 'java' }\nallprojects { repositories { mavenCentral() } }", model.FileKindBuild},
+		{"settings script", "rootProject.name = 'demo'\ninclude 'app'", model.FileKindSettings},
+		{"kotlin settings script", "include(\":app\")", model.FileKindSettings},
+		{"init script", "initscript { }", model.FileKindInit},
+		{"before settings", "beforeSettings { settings -> }", model.FileKindInit},
+		{"gradle allprojects", "gradle.allprojects { }", model.FileKindInit},
+		{"commented init block", "// initscript { }\napply plugin: 'java'", model.FileKindBuild},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectFileKind(tt.content); got != tt.want {
+				t.Errorf("detectFileKind() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFile_FileKind(t *testing.T) {
+	dir := t.TempDir()
+	initDir := filepath.Join(dir, "init.d")
+	if err := os.Mkdir(initDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(dir, "build.gradle"):        "apply plugin: 'java'",
+		filepath.Join(dir, "settings.gradle.kts"): "rootProject.name = \"demo\"",
+		filepath.Join(dir, "mirror.init.gradle"):  "allprojects { repositories { mavenCentral() } }",
+		filepath.Join(initDir, "repos.gradle"):    "allprojects { repositories { google() } }",
+		filepath.Join(dir, "publishing.gradle"):   "apply plugin: 'maven-publish'",
+		filepath.Join(dir, "custom-init.gradle"):  testInitScript,
+	}
+	want := map[string]model.FileKind{
+		filepath.Join(dir, "build.gradle"):        model.FileKindBuild,
+		filepath.Join(dir, "settings.gradle.kts"): model.FileKindSettings,
+		filepath.Join(dir, "mirror.init.gradle"):  model.FileKindInit,
+		filepath.Join(initDir, "repos.gradle"):    model.FileKindInit,
+		filepath.Join(dir, "publishing.gradle"):   model.FileKindScript,
+		filepath.Join(dir, "custom-init.gradle"):  model.FileKindInit,
+	}
+
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		result, err := NewParser().ParseFile(path)
+		if err != nil {
+			t.Fatalf("ParseFile(%s) error = %v", path, err)
+		}
+		if result.FileKind != want[path] {
+			t.Errorf("ParseFile(%s) file kind = %q, want %q", filepath.Base(path), result.FileKind, want[path])
+		}
+		isInit := result.FileKind == model.FileKindInit
+		if (result.Project.InitScript != nil) != isInit {
+			t.Errorf("ParseFile(%s) InitScript set = %v, want %v", filepath.Base(path), result.Project.InitScript != nil, isInit)
+		}
+		if (isInit || result.FileKind == model.FileKindScript) && result.Project.Name != "" {
+			t.Errorf("ParseFile(%s) unexpected project name %q", filepath.Base(path), result.Project.Name)
+		}
+	}
+}
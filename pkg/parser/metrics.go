@@ -0,0 +1,76 @@
+// Package parser 提供解析指标的上报接口。
+package parser
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// Metrics 接收解析过程中的指标，嵌入解析器的服务可以把它绑定到Prometheus等监控系统，
+// 例如用计数器记录解析的文件数和提取的依赖数，用直方图记录解析耗时。
+// 方法可能被多个goroutine并发调用，实现需要是并发安全的。
+type Metrics interface {
+	// FileParsed 在每次解析结束时调用。kind 为脚本类型，无法判断时为空；解析失败时 err 不为nil。
+	FileParsed(kind model.FileKind, duration time.Duration, err error)
+
+	// DependenciesExtracted 在解析成功后调用，count 为提取到的依赖数量。
+	DependenciesExtracted(count int)
+
+	// CacheHit 在从缓存还原解析结果（例如 api.LoadResult）而没有重新解析时调用。
+	CacheHit()
+}
+
+// NopMetrics 是不做任何事的 Metrics，只关心部分指标的实现可以嵌入它。
+type NopMetrics struct{}
+
+func (NopMetrics) FileParsed(model.FileKind, time.Duration, error) {}
+
+func (NopMetrics) DependenciesExtracted(int) {}
+
+func (NopMetrics) CacheHit() {}
+
+var (
+	defaultMetricsMu sync.RWMutex
+	defaultMetrics   Metrics = NopMetrics{}
+)
+
+// SetDefaultMetrics 设置没有通过 WithMetrics 指定指标接收者的解析器使用的接收者，nil表示不上报。
+func SetDefaultMetrics(metrics Metrics) {
+	if metrics == nil {
+		metrics = NopMetrics{}
+	}
+	defaultMetricsMu.Lock()
+	defer defaultMetricsMu.Unlock()
+	defaultMetrics = metrics
+}
+
+// DefaultMetrics 返回 SetDefaultMetrics 设置的指标接收者，没有设置时返回 NopMetrics。
+func DefaultMetrics() Metrics {
+	defaultMetricsMu.RLock()
+	defer defaultMetricsMu.RUnlock()
+	return defaultMetrics
+}
+
+// WithMetrics 设置解析器上报指标的接收者，nil表示使用 DefaultMetrics。
+func (p *GradleParser) WithMetrics(metrics Metrics) *GradleParser {
+	p.metrics = metrics
+	return p
+}
+
+// reportParse 上报一次解析的耗时、结果和提取的依赖数量。
+func (p *GradleParser) reportParse(kind model.FileKind, start time.Time, result *model.ParseResult, err error) {
+	metrics := p.metrics
+	if metrics == nil {
+		metrics = DefaultMetrics()
+	}
+
+	if result != nil && result.FileKind != "" {
+		kind = result.FileKind
+	}
+	metrics.FileParsed(kind, time.Since(start), err)
+	if err == nil && result != nil && result.Project != nil {
+		metrics.DependenciesExtracted(len(result.Project.Dependencies))
+	}
+}
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// recordingMetrics 记录上报的指标。
+type recordingMetrics struct {
+	NopMetrics
+	mu           sync.Mutex
+	kinds        []model.FileKind
+	errs         []error
+	dependencies int
+}
+
+func (m *recordingMetrics) FileParsed(kind model.FileKind, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.kinds = append(m.kinds, kind)
+	m.errs = append(m.errs, err)
+}
+
+func (m *recordingMetrics) DependenciesExtracted(count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dependencies += count
+}
+
+func TestParserMetrics(t *testing.T) {
+	metrics := &recordingMetrics{}
+	p := NewParser().(*GradleParser).WithMetrics(metrics)
+
+	content := `dependencies {
+    implementation 'com.google.guava:guava:31.1-jre'
+    testImplementation 'junit:junit:4.13.2'
+}
+`
+	if _, err := p.Parse(content); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.ParseContext(ctx, content); err == nil {
+		t.Fatal("Expected error for cancelled context")
+	}
+
+	if len(metrics.kinds) != 2 || metrics.kinds[0] != model.FileKindBuild {
+		t.Errorf("Unexpected parsed file kinds %v", metrics.kinds)
+	}
+	if metrics.errs[0] != nil || !errors.Is(metrics.errs[1], context.Canceled) {
+		t.Errorf("Unexpected parse errors %v", metrics.errs)
+	}
+	if metrics.dependencies != 2 {
+		t.Errorf("Expected 2 dependencies, got %d", metrics.dependencies)
+	}
+}
+
+func TestDefaultMetrics(t *testing.T) {
+	metrics := &recordingMetrics{}
+	SetDefaultMetrics(metrics)
+	defer SetDefaultMetrics(nil)
+
+	if _, err := NewParser().Parse("version = '1.0'\n"); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(metrics.kinds) != 1 {
+		t.Errorf("Parsers without explicit metrics should report to the default, got %d reports", len(metrics.kinds))
+	}
+
+	SetDefaultMetrics(nil)
+	if _, ok := DefaultMetrics().(NopMetrics); !ok {
+		t.Errorf("SetDefaultMetrics(nil) should restore NopMetrics, got %T", DefaultMetrics())
+	}
+}
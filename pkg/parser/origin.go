@@ -0,0 +1,70 @@
+// Package parser 提供记录依赖、插件和仓库声明来源的功能。
+package parser
+
+import (
+	"sort"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// annotateOrigins 根据声明的行号为项目的依赖、插件和仓库设置来源中的行号和块路径，没有行号的元素不设置。
+// 块路径取包含该行第一个非空白字符的最内层块。
+func annotateOrigins(project *model.Project, content string) {
+	blocks := scanBlocks(maskComments(content))
+	index := newLineIndex(content)
+
+	origin := func(line int) *model.Origin {
+		if line <= 0 || line > len(index.starts) {
+			return nil
+		}
+		pos := index.starts[line-1]
+		for pos < len(content) && (content[pos] == ' ' || content[pos] == '\t') {
+			pos++
+		}
+		return &model.Origin{Line: line, BlockPath: blockPathAt(blocks, pos, len(content))}
+	}
+
+	for _, dep := range project.Dependencies {
+		dep.Origin = origin(dep.DeclaredAtLine)
+	}
+	for _, plugin := range project.Plugins {
+		plugin.Origin = origin(plugin.DeclaredAtLine)
+	}
+	for _, repo := range project.Repositories {
+		repo.Origin = origin(repo.DeclaredAtLine)
+	}
+}
+
+// blockPathAt 返回包含位置的最内层块的路径，位置不在任何块中时返回空字符串。blocks按左花括号位置排序。
+func blockPathAt(blocks []*blockSpan, pos, contentLen int) string {
+	// 左花括号在pos之前的块才可能包含pos，其中最后一个包含pos的块是最内层的块。
+	n := sort.Search(len(blocks), func(i int) bool { return blocks[i].openPos >= pos })
+	for i := n - 1; i >= 0; i-- {
+		if blocks[i].contains(pos, contentLen) {
+			return blocks[i].path()
+		}
+	}
+	return ""
+}
+
+// setOriginFile 为解析结果中项目的依赖、插件和仓库设置来源文件，没有来源的元素会创建只有文件的来源。
+func setOriginFile(project *model.Project, filePath string) {
+	if project == nil {
+		return
+	}
+	set := func(origin **model.Origin) {
+		if *origin == nil {
+			*origin = &model.Origin{}
+		}
+		(*origin).File = filePath
+	}
+	for _, dep := range project.Dependencies {
+		set(&dep.Origin)
+	}
+	for _, plugin := range project.Plugins {
+		set(&plugin.Origin)
+	}
+	for _, repo := range project.Repositories {
+		set(&repo.Origin)
+	}
+}
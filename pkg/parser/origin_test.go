@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestWithOrigins(t *testing.T) {
+	content := `buildscript {
+    dependencies {
+        classpath 'com.android.tools.build:gradle:8.1.0'
+    }
+}
+plugins {
+    id 'java'
+}
+subprojects {
+    repositories {
+        mavenCentral()
+    }
+}
+dependencies {
+    implementation 'org.slf4j:slf4j-api:2.0.9'
+}
+`
+	result, err := NewParser().(*GradleParser).WithOrigins(true).WithBuildToolingDependencies(true).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	project := result.Project
+
+	want := map[string]model.Origin{
+		"gradle":    {BlockPath: "buildscript.dependencies", Line: 3},
+		"slf4j-api": {BlockPath: "dependencies", Line: 15},
+	}
+	for _, dep := range project.Dependencies {
+		expected, ok := want[dep.Name]
+		if !ok {
+			continue
+		}
+		if dep.Origin == nil || *dep.Origin != expected {
+			t.Errorf("Origin of %s = %+v, want %+v", dep.Name, dep.Origin, expected)
+		}
+		delete(want, dep.Name)
+	}
+	if len(want) != 0 {
+		t.Errorf("Dependencies not found: %v", want)
+	}
+
+	if len(project.Plugins) != 1 || project.Plugins[0].Origin == nil || project.Plugins[0].Origin.BlockPath != "plugins" {
+		t.Errorf("Unexpected plugin origins: %+v", project.Plugins)
+	}
+	if len(project.Repositories) != 1 || project.Repositories[0].Origin == nil ||
+		project.Repositories[0].Origin.String() != ":11 (subprojects.repositories)" {
+		t.Errorf("Unexpected repository origins: %+v", project.Repositories)
+	}
+}
+
+func TestParseProjectTree_Origins(t *testing.T) {
+	root := writeTreeFiles(t, map[string]string{
+		"settings.gradle": "include ':app'\n",
+		"app/build.gradle": `plugins {
+    id 'java'
+}
+dependencies {
+    implementation 'org.slf4j:slf4j-api:2.0.9'
+}
+`,
+	})
+
+	p := NewParser()
+	tree, err := ParseProjectTree(p, root)
+	if err != nil {
+		t.Fatalf("ParseProjectTree() error = %v", err)
+	}
+	if p.(*GradleParser).trackOrigins {
+		t.Error("ParseProjectTree should not modify the given parser")
+	}
+
+	app := tree.Module(":app")
+	if app == nil || app.Result == nil || len(app.Result.Project.Dependencies) != 1 {
+		t.Fatalf("Unexpected app module: %+v", app)
+	}
+	file := filepath.Join(root, "app", "build.gradle")
+	origin := app.Result.Project.Dependencies[0].Origin
+	if origin == nil || origin.File != file || origin.BlockPath != "dependencies" || origin.Line != 5 {
+		t.Errorf("Unexpected dependency origin: %+v", origin)
+	}
+	if plugin := app.Result.Project.Plugins[0]; plugin.Origin == nil || plugin.Origin.File != file {
+		t.Errorf("Unexpected plugin origin: %+v", plugin.Origin)
+	}
+}
@@ -3,11 +3,16 @@ package parser
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/scagogogo/gradle-parser/pkg/config"
@@ -25,9 +30,35 @@ type Parser interface {
 
 	// ParseReader 从Reader中解析Gradle内容。
 	ParseReader(reader io.Reader) (*model.ParseResult, error)
+
+	// ParseContext 解析Gradle字符串内容，ctx被取消或超时时中止解析。
+	ParseContext(ctx context.Context, content string) (*model.ParseResult, error)
+
+	// ParseFileContext 解析Gradle文件，ctx被取消或超时时中止解析。
+	ParseFileContext(ctx context.Context, filePath string) (*model.ParseResult, error)
+
+	// ParseReaderContext 从Reader中解析Gradle内容，ctx被取消或超时时中止读取和解析。
+	ParseReaderContext(ctx context.Context, reader io.Reader) (*model.ParseResult, error)
 }
 
+// 默认的输入限制，用于防止解析异常巨大或嵌套过深的文件。
+const (
+	DefaultMaxFileSizeBytes = 10 << 20 // 10 MiB。
+	DefaultMaxBlockDepth    = 64
+)
+
+var (
+	// ErrFileTooLarge 表示输入超过了文件大小限制。
+	ErrFileTooLarge = errors.New("Gradle文件超过大小限制")
+	// ErrBlockTooDeep 表示输入中的块嵌套超过了深度限制。
+	ErrBlockTooDeep = errors.New("Gradle块嵌套超过深度限制")
+	// ErrNotDirectory 表示项目模式的根路径不是目录。
+	ErrNotDirectory = errors.New("项目根路径不是目录")
+)
+
 // GradleParser 是默认的Gradle解析器实现。
+// 解析状态保存在每次调用独立的 parseState 中，配置完成后同一个实例可以被多个goroutine并发使用；
+// With* 方法会修改配置，应在共享实例之前调用。
 type GradleParser struct {
 	// 解析配置选项。
 	skipComments      bool
@@ -37,12 +68,68 @@ type GradleParser struct {
 	parseRepositories bool
 	parseTasks        bool
 
-	// 当前解析状态。
-	currentBlock *model.ScriptBlock
-	errors       []error
-	warnings     []string
+	// 是否保留重复的依赖声明。
+	keepDuplicateDependencies bool
+
+	// 是否把buildscript classpath和plugins块中的插件作为依赖提取。
+	buildToolingDependencies bool
+
+	// 是否用ext和gradle.properties中的属性解析依赖版本中的变量，以及额外提供的属性。
+	resolveVariables bool
+	gradleProperties map[string]string
+
+	// 依赖的配置过滤条件，为空表示不限制。
+	includeScopes []string
+	excludeScopes []string
+
+	// 只提取这些块的内容，为空表示提取所有块。
+	onlyBlocks map[string]bool
+
+	// 严格模式下结构问题和无法解析的依赖声明记录在解析结果的Errors中。
+	strict bool
+
+	// 是否为依赖、插件和仓库记录声明所在的行号。
+	trackLineNumbers bool
+
+	// 是否为依赖、插件和仓库记录声明来源（文件、块路径和行号）。
+	trackOrigins bool
+
+	// 输入限制，负数表示不限制；WithMaxFileSize 和 WithMaxBlockDepth 收到0时使用默认值。
+	maxFileSize   int64
+	maxBlockDepth int
+
+	// 自定义脚本块处理器，nil表示不调用处理器。
+	blockHandlers *BlockHandlerRegistry
+
+	// 指标接收者，nil表示使用 DefaultMetrics。
+	metrics Metrics
 }
 
+// parseState 保存单次解析调用的状态。
+type parseState struct {
+	rootBlock *model.ScriptBlock
+	errors    []error
+	warnings  []*model.Diagnostic
+}
+
+// 复用读取和逐行扫描时使用的缓冲区，减少并发解析时的内存分配。
+// 逐行扫描的缓冲区只覆盖不超过4 KiB的行：遇到更长的行时 bufio.Scanner 自行分配更大的缓冲区，
+// 该缓冲区不会放回池中，池里始终是初始的4 KiB缓冲区。
+var (
+	readBufferPool = sync.Pool{
+		New: func() any { return new(bytes.Buffer) },
+	}
+	scanBufferPool = sync.Pool{
+		New: func() any {
+			buf := make([]byte, 0, 4096)
+			return &buf
+		},
+	}
+)
+
+// maxPooledBufferSize 是放回读取缓冲池的缓冲区的最大容量，更大的缓冲区直接丢弃，避免长期占用内存。
+const maxPooledBufferSize = 1 << 20
+
 // NewParser 创建新的默认解析器实例。
 func NewParser() Parser {
 	return &GradleParser{
@@ -52,58 +139,153 @@ func NewParser() Parser {
 		parseDependencies: true,
 		parseRepositories: true,
 		parseTasks:        true,
-		errors:            make([]error, 0),
-		warnings:          make([]string, 0),
+		maxFileSize:       DefaultMaxFileSizeBytes,
+		maxBlockDepth:     DefaultMaxBlockDepth,
+		blockHandlers:     DefaultBlockHandlers,
 	}
 }
 
 // ParseFile 从文件解析Gradle配置。
 func (p *GradleParser) ParseFile(filePath string) (*model.ParseResult, error) {
+	return p.ParseFileContext(context.Background(), filePath)
+}
+
+// ParseFileContext 从文件解析Gradle配置，ctx被取消或超时时返回包装了ctx.Err()的错误。
+func (p *GradleParser) ParseFileContext(ctx context.Context, filePath string) (*model.ParseResult, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("无法打开Gradle文件: %w", err)
 	}
 	defer file.Close()
 
-	result, err := p.ParseReader(file)
+	if info, err := file.Stat(); err == nil && p.maxFileSize > 0 && info.Size() > p.maxFileSize {
+		return nil, fmt.Errorf("%w: %s 大小为 %d 字节，限制为 %d 字节", ErrFileTooLarge, filePath, info.Size(), p.maxFileSize)
+	}
+
+	result, err := p.parseReader(ctx, file, fileKindFromPath(filePath))
 	if err != nil {
 		return nil, err
 	}
 
+	// 解析文件时同时使用同目录下gradle.properties中的属性。
+	var fileProps map[string]string
+	if p.resolveVariables {
+		if data, err := os.ReadFile(filepath.Join(filepath.Dir(filePath), "gradle.properties")); err == nil {
+			fileProps = config.ParseProperties(string(data))
+		}
+	}
+
+	p.applyFileInfo(result, filePath, fileProps)
+	return result, nil
+}
+
+// ParseFileContent 把内存中的内容当作位于filePath的文件解析，不访问文件系统。
+// 与 ParseFile 相同地按文件名判断脚本类型、设置文件路径并推断项目名称；
+// fileProps 是同目录下gradle.properties中的属性，开启变量解析时用于解析依赖版本，可以为nil。
+func (p *GradleParser) ParseFileContent(ctx context.Context, filePath, content string, fileProps map[string]string) (*model.ParseResult, error) {
+	result, err := p.parseContent(ctx, content, fileKindFromPath(filePath))
+	if err != nil {
+		return nil, err
+	}
+
+	p.applyFileInfo(result, filePath, fileProps)
+	return result, nil
+}
+
+// applyFileInfo 为从文件解析的结果解析属性变量、设置文件路径并推断项目名称。
+func (p *GradleParser) applyFileInfo(result *model.ParseResult, filePath string, fileProps map[string]string) {
+	if p.resolveVariables && fileProps != nil && result.Project != nil {
+		p.resolveDependencyVariables(result.Project, fileProps)
+	}
+
 	// 设置文件路径。
 	if result.Project != nil {
 		result.Project.FilePath = filePath
-		// 如果项目名称为空，尝试从文件名推断。
-		if result.Project.Name == "" {
+		if p.trackOrigins {
+			setOriginFile(result.Project, filePath)
+		}
+		// 如果项目名称为空，尝试从文件名推断；初始化脚本和脚本插件不属于某个项目，不推断。
+		if result.Project.Name == "" && (result.FileKind == model.FileKindBuild || result.FileKind == model.FileKindSettings) {
 			dir := filepath.Dir(filePath)
 			result.Project.Name = filepath.Base(dir)
 		}
 	}
-
-	return result, nil
 }
 
 // ParseReader 从Reader中解析Gradle配置。
 func (p *GradleParser) ParseReader(reader io.Reader) (*model.ParseResult, error) {
-	content, err := io.ReadAll(reader)
-	if err != nil {
+	return p.ParseReaderContext(context.Background(), reader)
+}
+
+// ParseReaderContext 从Reader中解析Gradle配置，读取过程中同样响应ctx的取消。
+func (p *GradleParser) ParseReaderContext(ctx context.Context, reader io.Reader) (*model.ParseResult, error) {
+	return p.parseReader(ctx, reader, "")
+}
+
+// parseReader 从Reader中解析Gradle配置，kind为空时按内容判断脚本类型。
+func (p *GradleParser) parseReader(ctx context.Context, reader io.Reader, kind model.FileKind) (*model.ParseResult, error) {
+	reader = &contextReader{ctx: ctx, reader: reader}
+	if p.maxFileSize > 0 {
+		// 多读取一个字节用于判断是否超过限制，避免把超大输入全部读入内存。
+		reader = io.LimitReader(reader, p.maxFileSize+1)
+	}
+	buf := readBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			readBufferPool.Put(buf)
+		}
+	}()
+
+	if _, err := buf.ReadFrom(reader); err != nil {
+		if ctxErr := checkContext(ctx); ctxErr != nil {
+			return nil, ctxErr
+		}
 		return nil, fmt.Errorf("读取Gradle内容失败: %w", err)
 	}
 
-	return p.Parse(string(content))
+	// 转换为字符串时会复制内容，缓冲区可以安全地放回池中。
+	return p.parseContent(ctx, buf.String(), kind)
 }
 
 // Parse 从字符串解析Gradle配置。
 func (p *GradleParser) Parse(content string) (*model.ParseResult, error) {
-	// 重置解析状态。
-	p.currentBlock = &model.ScriptBlock{
-		Name:     "root",
-		Children: make([]*model.ScriptBlock, 0),
-		Values:   make(map[string]interface{}),
-		Closures: make(map[string][]*model.ScriptBlock),
+	return p.ParseContext(context.Background(), content)
+}
+
+// ParseContext 从字符串解析Gradle配置。
+// 解析过程中会定期检查ctx，被取消或超时时返回包装了ctx.Err()的错误。
+func (p *GradleParser) ParseContext(ctx context.Context, content string) (*model.ParseResult, error) {
+	return p.parseContent(ctx, content, "")
+}
+
+// parseContent 从字符串解析Gradle配置，kind为空时按内容判断脚本类型，并上报解析指标。
+func (p *GradleParser) parseContent(ctx context.Context, content string, kind model.FileKind) (*model.ParseResult, error) {
+	start := time.Now()
+	result, err := p.parseContentUnreported(ctx, content, kind)
+	p.reportParse(kind, start, result, err)
+	return result, err
+}
+
+// parseContentUnreported 从字符串解析Gradle配置，不上报指标。
+func (p *GradleParser) parseContentUnreported(ctx context.Context, content string, kind model.FileKind) (*model.ParseResult, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	if err := p.checkLimits(content); err != nil {
+		return nil, err
+	}
+
+	// 每次调用使用独立的解析状态。
+	state := &parseState{
+		rootBlock: buildScriptBlocks(content),
+		errors:    make([]error, 0),
+		warnings:  make([]*model.Diagnostic, 0),
 	}
-	p.errors = make([]error, 0)
-	p.warnings = make([]string, 0)
 
 	// 记录开始时间。
 	startTime := time.Now()
@@ -121,14 +303,23 @@ func (p *GradleParser) Parse(content string) (*model.ParseResult, error) {
 
 	// 使用scanner逐行解析。
 	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanBuf := scanBufferPool.Get().(*[]byte)
+	defer scanBufferPool.Put(scanBuf)
+	scanner.Buffer((*scanBuf)[:0], bufio.MaxScanTokenSize)
 	var rawLines []string
 	if p.collectRawContent {
 		rawLines = make([]string, 0, strings.Count(content, "\n")+1)
 	}
 
+	index := newLineIndex(content)
 	lineNumber := 0
 	for scanner.Scan() {
 		lineNumber++
+		if lineNumber%contextCheckInterval == 0 {
+			if err := checkContext(ctx); err != nil {
+				return nil, err
+			}
+		}
 		line := scanner.Text()
 
 		// 收集原始内容。
@@ -146,36 +337,118 @@ func (p *GradleParser) Parse(content string) (*model.ParseResult, error) {
 		// 解析行内容。
 		if err := p.parseLine(trimmedLine, lineNumber, project); err != nil {
 			// 不把解析错误当作致命错误，只记录警告。
-			p.warnings = append(p.warnings, fmt.Sprintf("行 %d: %v", lineNumber, err))
+			state.warnings = append(state.warnings, &model.Diagnostic{
+				Code:        model.DiagnosticParseError,
+				Severity:    model.SeverityWarning,
+				Message:     fmt.Sprintf("行 %d: %v", lineNumber, err),
+				SourceRange: index.lineRange(lineNumber, len(content)),
+			})
 		}
 	}
 
-	// 使用专门的解析器来提取依赖、插件和仓库。
-	if p.parseDependencies {
-		depParser := dependency.NewParser()
-		project.Dependencies = depParser.ExtractDependenciesFromText(content)
+	// 使用专门的解析器来提取依赖、插件和仓库，每个阶段之间检查ctx。
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	if p.parseDependencies && p.blockEnabled(contextDependencies) {
+		depParser := dependency.NewParser().
+			WithDeduplicate(!p.keepDuplicateDependencies).
+			WithScopeFilter(p.includeScopes, p.excludeScopes).
+			WithLineNumbers(p.trackLineNumbers)
+		project.Dependencies = depParser.ExtractDependenciesFromText(maskConstraints(content))
+		project.Constraints = extractConstraints(content)
+		project.Exclusions = extractExclusions(content)
+		if p.buildToolingDependencies {
+			var toolingIndex *lineIndex
+			if p.trackLineNumbers {
+				toolingIndex = index
+			}
+			tooling := extractBuildToolingDependencies(content, depParser, toolingIndex)
+			if !p.keepDuplicateDependencies {
+				tooling = dependency.Deduplicate(tooling)
+			}
+			project.Dependencies = append(project.Dependencies, tooling...)
+		}
+		if p.resolveVariables {
+			p.resolveDependencyVariables(project, nil)
+		}
 	}
 
-	if p.parsePlugins {
-		pluginParser := config.NewPluginParser()
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	if p.parsePlugins && p.blockEnabled(contextPlugins) {
+		pluginParser := config.NewPluginParser().WithLineNumbers(p.trackLineNumbers)
 		project.Plugins = pluginParser.ExtractPluginsFromText(content)
+		// 传统的 buildscript classpath + apply plugin 写法中，插件版本由classpath依赖决定。
+		if strings.Contains(content, "buildscript") {
+			classpath := extractClasspathDependencies(content, dependency.NewParser(), nil)
+			dependency.ResolveVersionVariables(classpath, p.variableProperties(project, nil))
+			config.ResolvePluginClasspathVersions(project.Plugins, classpath)
+		}
+		project.PluginManagement = config.ParsePluginManagement(content)
 	}
 
-	if p.parseRepositories {
-		repoParser := config.NewRepositoryParser()
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	if p.parseRepositories && p.blockEnabled(contextRepositories) {
+		repoParser := config.NewRepositoryParser().WithLineNumbers(p.trackLineNumbers)
 		project.Repositories = repoParser.ExtractRepositoriesFromText(content)
+		project.DependencyResolutionManagement = config.ParseDependencyResolutionManagement(content)
+	}
+
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	if p.parseTasks && p.blockEnabled(blockTasks) {
+		project.Tasks = extractTasks(content)
+		project.TestConfig = extractTestConfig(content)
+		project.SpringBoot = extractSpringBootConfig(content)
+	}
+
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+
+	// 文件名不是约定名称的脚本按内容判断是否是初始化脚本（例如通过 --init-script 指定的任意文件名）。
+	detected := detectFileKind(content)
+	if kind == "" || (kind == model.FileKindScript && detected == model.FileKindInit) {
+		kind = detected
+	}
+	if kind == model.FileKindInit {
+		project.InitScript = extractInitScript(content, dependency.NewParser(), p.trackLineNumbers)
 	}
 
+	project.SourceSets = extractSourceSets(content)
+	project.Features = detectFeatures(content)
+	if p.trackOrigins {
+		annotateOrigins(project, content)
+	}
+	p.runBlockHandlers(state, project)
+
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("扫描内容时出错: %w", err)
 	}
 
+	// 检测常见问题并与解析警告一起按位置排序。
+	state.warnings = append(state.warnings, detectDiagnostics(content)...)
+	sort.SliceStable(state.warnings, func(i, j int) bool {
+		return state.warnings[i].SourceRange.Start.StartPos < state.warnings[j].SourceRange.Start.StartPos
+	})
+
+	if p.strict {
+		state.errors = append(state.errors, strictErrors(content)...)
+	}
+
 	// 完成解析。
 	result := &model.ParseResult{
 		Project:   project,
-		Errors:    p.errors,
-		Warnings:  p.warnings,
+		Errors:    state.errors,
+		Warnings:  state.warnings,
 		ParseTime: time.Since(startTime).String(),
+		FileKind:  kind,
+		RootBlock: state.rootBlock,
 	}
 
 	if p.collectRawContent {
@@ -215,7 +488,7 @@ func (p *GradleParser) parseLine(line string, _ int, project *model.Project) err
 	}
 
 	// 解析任务定义。
-	if strings.HasPrefix(line, "task ") || strings.Contains(line, "task(") {
+	if (strings.HasPrefix(line, "task ") || strings.Contains(line, "task(")) && p.blockEnabled(blockTasks) {
 		return p.parseTaskDefinition(line, project)
 	}
 
@@ -223,6 +496,187 @@ func (p *GradleParser) parseLine(line string, _ int, project *model.Project) err
 	return nil
 }
 
+// blockTasks 是WithOnlyBlocks中表示任务定义和测试配置的名称。
+const blockTasks = "tasks"
+
+// contextCheckInterval 是逐行解析时检查ctx的行数间隔。
+const contextCheckInterval = 256
+
+// checkContext 在ctx已被取消或超时时返回包装了ctx.Err()的错误。
+func checkContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("解析已中止: %w", err)
+	}
+	return nil
+}
+
+// contextReader 在每次读取前检查ctx，使读取大文件时也能及时中止。
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+// Read 实现 io.Reader 接口。
+func (r *contextReader) Read(buf []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(buf)
+}
+
+// checkLimits 检查输入是否超过文件大小和块嵌套深度限制。
+func (p *GradleParser) checkLimits(content string) error {
+	if p.maxFileSize > 0 && int64(len(content)) > p.maxFileSize {
+		return fmt.Errorf("%w: 内容大小为 %d 字节，限制为 %d 字节", ErrFileTooLarge, len(content), p.maxFileSize)
+	}
+
+	if p.maxBlockDepth > 0 {
+		for _, block := range scanBlocks(content) {
+			if block.depth+1 > p.maxBlockDepth {
+				line, _ := newLineIndex(content).position(block.openPos)
+				return fmt.Errorf("%w: 第 %d 行的块嵌套深度超过 %d", ErrBlockTooDeep, line, p.maxBlockDepth)
+			}
+		}
+	}
+
+	return nil
+}
+
+// WithMaxFileSize 设置允许解析的最大字节数，0表示使用 DefaultMaxFileSizeBytes，负数表示不限制。
+func (p *GradleParser) WithMaxFileSize(size int64) *GradleParser {
+	if size == 0 {
+		size = DefaultMaxFileSizeBytes
+	}
+	p.maxFileSize = size
+	return p
+}
+
+// WithMaxBlockDepth 设置允许的最大块嵌套深度，0表示使用 DefaultMaxBlockDepth，负数表示不限制。
+func (p *GradleParser) WithMaxBlockDepth(depth int) *GradleParser {
+	if depth == 0 {
+		depth = DefaultMaxBlockDepth
+	}
+	p.maxBlockDepth = depth
+	return p
+}
+
+// WithScopeFilter 设置只提取哪些配置中声明的依赖，include为空表示所有配置，exclude中的配置总是被忽略。
+// 变体配置（例如 testFreeDebugImplementation）既按本身也按基础配置（testImplementation）匹配。
+func (p *GradleParser) WithScopeFilter(include, exclude []string) *GradleParser {
+	p.includeScopes = include
+	p.excludeScopes = exclude
+	return p
+}
+
+// WithOnlyBlocks 设置只提取哪些块的内容，不设置时提取所有块。
+// plugins、dependencies、repositories 和 tasks（任务定义和测试配置）对应各自的提取阶段，
+// 其他名称只影响自定义块处理器，例如 WithOnlyBlocks("plugins") 只提取插件，不调用 android 块的处理器。
+// 与 WithParse* 开关同时使用时，两者都开启的部分才会提取。
+func (p *GradleParser) WithOnlyBlocks(blocks ...string) *GradleParser {
+	p.onlyBlocks = nil
+	if len(blocks) > 0 {
+		p.onlyBlocks = make(map[string]bool, len(blocks))
+		for _, block := range blocks {
+			p.onlyBlocks[block] = true
+		}
+	}
+	return p
+}
+
+// blockEnabled 判断WithOnlyBlocks是否允许提取指定的块。
+func (p *GradleParser) blockEnabled(name string) bool {
+	return p.onlyBlocks == nil || p.onlyBlocks[name]
+}
+
+// WithStrict 设置是否使用严格模式，默认关闭。
+// 严格模式下括号不配对、字符串或块注释没有结束以及dependencies块中无法解析的依赖声明
+// 会作为错误记录在 ParseResult.Errors 中，错误分别包装了 ErrMalformedScript 和 ErrUnparseableDependency；
+// 宽松模式尽力解析并忽略这些问题。两种模式下解析出的内容相同。
+func (p *GradleParser) WithStrict(strict bool) *GradleParser {
+	p.strict = strict
+	return p
+}
+
+// WithLineNumbers 设置是否在依赖、插件和仓库的 DeclaredAtLine 中记录声明所在的行号，默认关闭。
+// 比 SourceAwareParser 的完整源码映射开销小，适合只需要指出大致位置的诊断。
+func (p *GradleParser) WithLineNumbers(track bool) *GradleParser {
+	p.trackLineNumbers = track
+	return p
+}
+
+// WithOrigins 设置是否为依赖、插件和仓库记录声明来源（model.Origin），默认关闭。
+// 开启后同时开启行号跟踪；来源中的文件只在ParseFile时设置。ParseProjectTree 总是记录来源。
+func (p *GradleParser) WithOrigins(track bool) *GradleParser {
+	p.trackOrigins = track
+	if track {
+		p.trackLineNumbers = true
+	}
+	return p
+}
+
+// WithDeduplicateDependencies 设置是否对提取出的依赖去重，默认开启。
+func (p *GradleParser) WithDeduplicateDependencies(dedup bool) *GradleParser {
+	p.keepDuplicateDependencies = !dedup
+	return p
+}
+
+// WithBuildToolingDependencies 设置是否把构建工具依赖也加入项目的依赖列表，默认关闭。
+// buildscript中的classpath依赖的配置为 dependency.ScopeBuildscriptClasspath，
+// plugins块中声明了版本的插件以插件标记制品（<id>:<id>.gradle.plugin:<version>）的形式加入，配置为 dependency.ScopePlugin。
+func (p *GradleParser) WithBuildToolingDependencies(include bool) *GradleParser {
+	p.buildToolingDependencies = include
+	return p
+}
+
+// WithResolveVariables 设置是否解析依赖版本中引用的变量，例如 "org.foo:bar:$barVersion"。
+// 变量依次从gradle.properties（仅ParseFile）、WithGradleProperties提供的属性和脚本中的ext属性中查找，后者优先。
+func (p *GradleParser) WithResolveVariables(resolve bool) *GradleParser {
+	p.resolveVariables = resolve
+	return p
+}
+
+// WithGradleProperties 设置解析变量时额外使用的属性，例如从gradle.properties或命令行读取的属性。
+func (p *GradleParser) WithGradleProperties(props map[string]string) *GradleParser {
+	p.gradleProperties = props
+	return p
+}
+
+// resolveDependencyVariables 合并各来源的属性并解析依赖版本中的变量。
+func (p *GradleParser) resolveDependencyVariables(project *model.Project, fileProps map[string]string) {
+	dependency.ResolveVersionVariables(project.Dependencies, p.variableProperties(project, fileProps))
+}
+
+// variableProperties 合并可在版本插值中引用的属性，脚本中的属性优先于gradle.properties中的属性。
+func (p *GradleParser) variableProperties(project *model.Project, fileProps map[string]string) map[string]string {
+	props := make(map[string]string, len(fileProps)+len(p.gradleProperties)+len(project.Properties)+2)
+	for key, value := range fileProps {
+		props[key] = value
+	}
+	for key, value := range p.gradleProperties {
+		props[key] = value
+	}
+	if project.Group != "" {
+		props["group"] = project.Group
+	}
+	if project.Version != "" {
+		props["version"] = project.Version
+	}
+	for key, value := range project.Properties {
+		props[scriptVariableName(key)] = value
+	}
+	return props
+}
+
+// scriptVariableName 去除属性赋值左侧的 ext.、def 等前缀，返回可在插值中引用的变量名。
+func scriptVariableName(key string) string {
+	for _, prefix := range []string{"project.ext.", "rootProject.ext.", "ext.", "def ", "val ", "var "} {
+		if strings.HasPrefix(key, prefix) {
+			return strings.TrimSpace(key[len(prefix):])
+		}
+	}
+	return key
+}
+
 // WithSkipComments 设置是否跳过注释。
 func (p *GradleParser) WithSkipComments(skip bool) *GradleParser {
 	p.skipComments = skip
@@ -271,6 +725,14 @@ func (p *GradleParser) parseProjectProperty(line string, project *model.Project)
 		key := strings.TrimSpace(parts[0])
 		value := strings.TrimSpace(parts[1])
 
+		// 非字面量的值保留表达式树。
+		if expr := ParseExpression(value); expr != nil && !expr.IsLiteral() {
+			if project.PropertyExpressions == nil {
+				project.PropertyExpressions = make(map[string]*model.Expression)
+			}
+			project.PropertyExpressions[key] = expr
+		}
+
 		// 移除引号。
 		value = strings.Trim(value, `"'`)
 
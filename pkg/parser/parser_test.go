@@ -1,10 +1,14 @@
 package parser
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -228,22 +232,15 @@ func TestParseLine(t *testing.T) {
 // Test for parsing with errors。
 func TestParseWithErrors(t *testing.T) {
 	// Create a new parser。
-	parser := &GradleParser{
-		errors: make([]error, 0),
-	}
-
-	// Add some errors to the parser。
-	parser.errors = append(parser.errors, fmt.Errorf("test error 1"))
-	parser.errors = append(parser.errors, fmt.Errorf("test error 2"))
+	parser := &GradleParser{}
 
-	// Since Parse() resets errors, we need to modify it a bit。
 	content := `
 		line1
 		line2
 		line3
 	`
 
-	// Parse will reset errors, so this test is more of a sanity check。
+	// Unrecognized lines are not fatal, so this test is more of a sanity check。
 	result, err := parser.Parse(content)
 	if err != nil {
 		t.Fatalf("Parse() error = %v", err)
@@ -647,3 +644,453 @@ func createTempGradleProject(t *testing.T) string {
 func cleanupTempGradleProject(tmpDir string) {
 	os.RemoveAll(tmpDir)
 }
+
+func TestMaxFileSize(t *testing.T) {
+	content := "group = 'com.example'\nversion = '1.0.0'\n"
+
+	p := NewParser().(*GradleParser).WithMaxFileSize(10)
+	if _, err := p.Parse(content); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("Parse() error = %v, want ErrFileTooLarge", err)
+	}
+	if _, err := p.ParseReader(strings.NewReader(content)); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("ParseReader() error = %v, want ErrFileTooLarge", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "build.gradle")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	if _, err := p.ParseFile(path); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf("ParseFile() error = %v, want ErrFileTooLarge", err)
+	}
+
+	p.WithMaxFileSize(-1)
+	result, err := p.Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() without limit error = %v", err)
+	}
+	if result.Project.Group != "com.example" {
+		t.Errorf("Expected group 'com.example', got '%s'", result.Project.Group)
+	}
+}
+
+func TestMaxBlockDepth(t *testing.T) {
+	content := `
+allprojects {
+    repositories {
+        maven {
+            url 'https://repo.example.com'
+        }
+    }
+}
+`
+	p := NewParser().(*GradleParser).WithMaxBlockDepth(2)
+	_, err := p.Parse(content)
+	if !errors.Is(err, ErrBlockTooDeep) {
+		t.Fatalf("Parse() error = %v, want ErrBlockTooDeep", err)
+	}
+	if !strings.Contains(err.Error(), "第 4 行") {
+		t.Errorf("Expected error to report line 4, got %v", err)
+	}
+
+	if _, err := p.WithMaxBlockDepth(3).Parse(content); err != nil {
+		t.Errorf("Parse() with depth 3 error = %v", err)
+	}
+	// 默认限制足以容纳常规脚本，但能拒绝异常深的嵌套；0表示使用默认限制，负数表示不限制。
+	deep := strings.Repeat("a {\n", DefaultMaxBlockDepth+1) + strings.Repeat("}\n", DefaultMaxBlockDepth+1)
+	if _, err := NewParser().Parse(deep); !errors.Is(err, ErrBlockTooDeep) {
+		t.Errorf("Parse() of deeply nested blocks error = %v, want ErrBlockTooDeep", err)
+	}
+	if _, err := p.WithMaxBlockDepth(0).Parse(deep); !errors.Is(err, ErrBlockTooDeep) {
+		t.Errorf("Parse() with depth 0 error = %v, want ErrBlockTooDeep", err)
+	}
+	if _, err := p.WithMaxBlockDepth(-1).Parse(deep); err != nil {
+		t.Errorf("Parse() without limit error = %v", err)
+	}
+}
+
+// cancelAfterReader 在读取指定次数后取消ctx，模拟解析过程中的取消。
+type cancelAfterReader struct {
+	reads  int
+	cancel context.CancelFunc
+}
+
+func (r *cancelAfterReader) Read(buf []byte) (int, error) {
+	r.reads--
+	if r.reads <= 0 {
+		r.cancel()
+	}
+	return copy(buf, "group = 'com.example'\n"), nil
+}
+
+func TestParseContext(t *testing.T) {
+	p := NewParser()
+	content := "group = 'com.example'\nversion = '1.0.0'\n"
+
+	result, err := p.ParseContext(context.Background(), content)
+	if err != nil {
+		t.Fatalf("ParseContext() error = %v", err)
+	}
+	if result.Project.Version != "1.0.0" {
+		t.Errorf("Expected version '1.0.0', got '%s'", result.Project.Version)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.ParseContext(ctx, content); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseContext() error = %v, want context.Canceled", err)
+	}
+
+	deadline, cancelDeadline := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelDeadline()
+	if _, err := p.ParseContext(deadline, content); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ParseContext() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "build.gradle")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	if _, err := p.ParseFileContext(ctx, path); !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseFileContext() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestParseReaderContext_CancelDuringRead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 没有结束的Reader只能依靠ctx中止。
+	p := NewParser().(*GradleParser).WithMaxFileSize(-1)
+	_, err := p.ParseReaderContext(ctx, &cancelAfterReader{reads: 3, cancel: cancel})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseReaderContext() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestParseConcurrent(t *testing.T) {
+	p := NewParser()
+	contents := []string{
+		"group = 'com.example.a'\nversion = '1.0.0'\ndependencies {\n    implementation 'org.a:a:1.0'\n}\n",
+		"group = 'com.example.b'\nversion = '2.0.0'\ndependencies {\n    implementation 'org.b:b:2.0'\n    testImplementation 'org.c:c:3.0'\n}\n",
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			content := contents[i%len(contents)]
+
+			var result *model.ParseResult
+			var err error
+			if i%2 == 0 {
+				result, err = p.Parse(content)
+			} else {
+				result, err = p.ParseReader(strings.NewReader(content))
+			}
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			wantGroup := []string{"com.example.a", "com.example.b"}[i%len(contents)]
+			if result.Project.Group != wantGroup {
+				errs <- fmt.Errorf("goroutine %d: group = %s, want %s", i, result.Project.Group, wantGroup)
+			}
+			if len(result.Project.Dependencies) != i%len(contents)+1 {
+				errs <- fmt.Errorf("goroutine %d: got %d dependencies", i, len(result.Project.Dependencies))
+			}
+			if result.RawText != strings.TrimSuffix(content, "\n") {
+				errs <- fmt.Errorf("goroutine %d: unexpected raw text %q", i, result.RawText)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestWithDeduplicateDependencies(t *testing.T) {
+	content := `
+dependencies {
+    implementation 'org.slf4j:slf4j-api:1.7.36'
+    implementation 'org.slf4j:slf4j-api:1.7.36'
+}
+`
+	result, err := NewParser().Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Project.Dependencies) != 1 {
+		t.Errorf("Expected 1 dependency with dedup, got %d", len(result.Project.Dependencies))
+	}
+
+	result, err = NewParser().(*GradleParser).WithDeduplicateDependencies(false).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(result.Project.Dependencies) != 2 {
+		t.Errorf("Expected 2 dependencies without dedup, got %d", len(result.Project.Dependencies))
+	}
+}
+
+func TestWithScopeFilter(t *testing.T) {
+	content := `
+dependencies {
+    implementation 'org.slf4j:slf4j-api:1.7.36'
+    testImplementation 'org.junit.jupiter:junit-jupiter:5.10.0'
+    testFreeDebugImplementation 'org.mockito:mockito-core:5.5.0'
+    testRuntimeOnly 'org.junit.platform:junit-platform-launcher:1.10.0'
+}
+`
+	scopes := func(deps []*model.Dependency) []string {
+		result := make([]string, 0, len(deps))
+		for _, dep := range deps {
+			result = append(result, dep.Scope)
+		}
+		return result
+	}
+
+	result, err := NewParser().(*GradleParser).WithScopeFilter([]string{"testImplementation"}, nil).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := scopes(result.Project.Dependencies); !reflect.DeepEqual(got, []string{"testImplementation", "testFreeDebugImplementation"}) {
+		t.Errorf("Scopes with include filter = %v", got)
+	}
+
+	result, err = NewParser().(*GradleParser).WithScopeFilter(nil, []string{"testImplementation", "testRuntimeOnly"}).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := scopes(result.Project.Dependencies); !reflect.DeepEqual(got, []string{"implementation"}) {
+		t.Errorf("Scopes with exclude filter = %v", got)
+	}
+}
+
+func TestWithLineNumbers(t *testing.T) {
+	content := `buildscript {
+    dependencies {
+        classpath 'com.android.tools.build:gradle:8.1.0'
+    }
+}
+
+plugins {
+    id 'java'
+    id 'org.springframework.boot' version '3.1.0'
+}
+apply plugin: 'idea'
+
+repositories {
+    mavenCentral()
+    exclusiveContent {
+        forRepository {
+            maven { url 'https://jitpack.io' }
+        }
+        filter { includeGroup 'com.github.foo' }
+    }
+    google()
+}
+
+dependencies {
+    implementation 'org.slf4j:slf4j-api:1.7.36'; runtimeOnly 'ch.qos.logback:logback-classic:1.4.11'
+    testImplementation(
+        "org.junit.jupiter:junit-jupiter:5.10.0"
+    )
+}
+`
+	result, err := NewParser().(*GradleParser).WithLineNumbers(true).WithBuildToolingDependencies(true).Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	project := result.Project
+
+	depLines := make(map[string]int)
+	for _, dep := range project.Dependencies {
+		depLines[dep.Name] = dep.DeclaredAtLine
+	}
+	wantDeps := map[string]int{
+		"slf4j-api":                              25,
+		"logback-classic":                        25,
+		"junit-jupiter":                          26,
+		"gradle":                                 3,
+		"org.springframework.boot.gradle.plugin": 9,
+	}
+	if !reflect.DeepEqual(depLines, wantDeps) {
+		t.Errorf("Dependency lines = %v, want %v", depLines, wantDeps)
+	}
+
+	pluginLines := make([]int, 0, len(project.Plugins))
+	for _, plugin := range project.Plugins {
+		pluginLines = append(pluginLines, plugin.DeclaredAtLine)
+	}
+	if !reflect.DeepEqual(pluginLines, []int{8, 9, 11}) {
+		t.Errorf("Plugin lines = %v", pluginLines)
+	}
+
+	repoLines := make([]int, 0, len(project.Repositories))
+	for _, repo := range project.Repositories {
+		repoLines = append(repoLines, repo.DeclaredAtLine)
+	}
+	if !reflect.DeepEqual(repoLines, []int{14, 17, 21}) {
+		t.Errorf("Repository lines = %v", repoLines)
+	}
+
+	// 默认不记录行号。
+	result, err = NewParser().Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if result.Project.Dependencies[0].DeclaredAtLine != 0 || result.Project.Plugins[0].DeclaredAtLine != 0 ||
+		result.Project.Repositories[0].DeclaredAtLine != 0 {
+		t.Error("Expected no line numbers by default")
+	}
+}
+
+func TestWithOnlyBlocks(t *testing.T) {
+	content := `
+plugins {
+    id 'java'
+}
+
+repositories {
+    mavenCentral()
+}
+
+dependencies {
+    implementation 'org.slf4j:slf4j-api:1.7.36'
+}
+
+task hello {
+    doLast { println 'hello' }
+}
+`
+	result, err := NewParser().(*GradleParser).WithOnlyBlocks("plugins").Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	project := result.Project
+	if len(project.Plugins) != 1 {
+		t.Errorf("Expected 1 plugin, got %d", len(project.Plugins))
+	}
+	if len(project.Dependencies) != 0 || len(project.Repositories) != 0 || len(project.Tasks) != 0 {
+		t.Errorf("Expected only plugins, got %d dependencies, %d repositories, %d tasks",
+			len(project.Dependencies), len(project.Repositories), len(project.Tasks))
+	}
+
+	// 块过滤和 WithParse* 开关同时生效。
+	result, err = NewParser().(*GradleParser).WithParseDependencies(false).WithOnlyBlocks("dependencies", "repositories").Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	project = result.Project
+	if len(project.Dependencies) != 0 || len(project.Repositories) != 1 || len(project.Plugins) != 0 {
+		t.Errorf("Unexpected extraction: %d dependencies, %d repositories, %d plugins",
+			len(project.Dependencies), len(project.Repositories), len(project.Plugins))
+	}
+}
+
+func TestWithResolveVariables(t *testing.T) {
+	content := `
+version = '2.0.0'
+ext {
+    barVersion = '1.2.3'
+}
+ext.bazVersion = '4.5.6'
+
+dependencies {
+    implementation "org.foo:bar:$barVersion"
+    implementation "org.foo:baz:${bazVersion}"
+    implementation "com.example:core:$version"
+    implementation "org.foo:qux:$quxVersion"
+}
+`
+	result, err := NewParser().Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if dep := result.Project.Dependencies[0]; !dep.HasDynamicVersion || dep.ResolvedVersion != "" {
+		t.Errorf("Expected unresolved dynamic version by default, got %+v", dep)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "build.gradle")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write build file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "gradle.properties"), []byte("quxVersion=7.8.9\nbarVersion=0.0.1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write gradle.properties: %v", err)
+	}
+
+	result, err = NewParser().(*GradleParser).WithResolveVariables(true).ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	// 脚本中的ext属性优先于gradle.properties。
+	want := []string{"1.2.3", "4.5.6", "2.0.0", "7.8.9"}
+	for i, version := range want {
+		dep := result.Project.Dependencies[i]
+		if dep.ResolvedVersion != version {
+			t.Errorf("%s: ResolvedVersion = %q, want %q", dep.Name, dep.ResolvedVersion, version)
+		}
+	}
+
+	p := NewParser().(*GradleParser).WithResolveVariables(true).WithGradleProperties(map[string]string{"quxVersion": "1.0"})
+	result, err = p.Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := result.Project.Dependencies[3].ResolvedVersion; got != "1.0" {
+		t.Errorf("Expected version from WithGradleProperties, got %q", got)
+	}
+}
+
+// largeDependencyContent 生成包含n个依赖声明的构建脚本。
+func largeDependencyContent(n int) string {
+	var content strings.Builder
+	content.WriteString("dependencies {\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&content, "    implementation 'com.example:lib%d:1.0.0'\n", i)
+	}
+	content.WriteString("}\n")
+	return content.String()
+}
+
+func BenchmarkParseLargeFile(b *testing.B) {
+	content := largeDependencyContent(1000)
+	p := NewParser()
+	b.ReportAllocs()
+	b.SetBytes(int64(len(content)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := p.Parse(content); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestParseFileContent(t *testing.T) {
+	content := "dependencies {\n    implementation \"org.slf4j:slf4j-api:$slf4jVersion\"\n}\n"
+	p := NewParser().(*GradleParser).WithResolveVariables(true)
+
+	result, err := p.ParseFileContent(context.Background(), "service/build.gradle", content, map[string]string{"slf4jVersion": "2.0.9"})
+	if err != nil {
+		t.Fatalf("ParseFileContent() error = %v", err)
+	}
+	if result.Project.FilePath != "service/build.gradle" || result.Project.Name != "service" {
+		t.Errorf("Expected path and name from filePath, got %q and %q", result.Project.FilePath, result.Project.Name)
+	}
+	if len(result.Project.Dependencies) != 1 || result.Project.Dependencies[0].ResolvedVersion != "2.0.9" {
+		t.Errorf("Expected version resolved from fileProps, got %+v", result.Project.Dependencies)
+	}
+
+	if _, err := p.ParseFileContent(context.Background(), "build.gradle", content, nil); err != nil {
+		t.Errorf("ParseFileContent() with nil fileProps error = %v", err)
+	}
+}
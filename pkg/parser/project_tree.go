@@ -0,0 +1,296 @@
+// Package parser 提供以项目模式解析整个Gradle构建的功能。
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/catalog"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配settings文件中的 include ':a', ':b' 和 include(":a", ":b")。
+	settingsIncludeRegex = regexp.MustCompile(`\binclude\s*\(?\s*((?:['"][^'"]+['"]\s*,\s*)*['"][^'"]+['"])`)
+
+	// 匹配 project(':a').projectDir = file('dir') 和 new File(settingsDir, 'dir') 形式的模块目录重定向。
+	projectDirRegex = regexp.MustCompile(`project\s*\(\s*['"]([^'"]+)['"]\s*\)\.projectDir\s*=\s*` +
+		`(?:file|new\s+File)\s*\(\s*(?:(?:settingsDir|rootDir)\s*,\s*)?['"]([^'"]+)['"]`)
+
+	// 匹配Kotlin脚本开头的package声明。
+	kotlinPackageRegex = regexp.MustCompile(`(?m)^\s*package\s+([\w.]+)`)
+)
+
+// 构建文件、settings文件和预编译脚本插件的文件名。
+var (
+	buildFileNames    = []string{"build.gradle", "build.gradle.kts"}
+	settingsFileNames = []string{"settings.gradle", "settings.gradle.kts"}
+)
+
+// ParseProjectTree 以项目模式解析rootDir下的整个构建：settings文件中include的各模块、
+// buildSrc和includeBuild引入的构建，以及其中的预编译脚本插件（约定插件）。
+// 约定插件会与应用了它的模块关联。单个构建文件解析失败时记录在 ProjectTree.Errors 中。
+// 依赖、插件和仓库都带有声明来源（model.Origin）：p是 *GradleParser 时使用开启了来源跟踪的副本，
+// 包括文件、块路径和行号；其他Parser实现只记录文件。
+func ParseProjectTree(p Parser, rootDir string) (*model.ProjectTree, error) {
+	info, err := os.Stat(rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("无法访问项目目录: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%w: %s", ErrNotDirectory, rootDir)
+	}
+
+	if gp, ok := p.(*GradleParser); ok && !gp.trackOrigins {
+		copied := *gp
+		p = copied.WithOrigins(true)
+	}
+
+	visited := make(map[string]bool)
+	tree := parseBuild(p, rootDir, visited)
+
+	collectConventionPlugins(p, tree, tree)
+	linkConventionPlugins(tree, tree)
+	return tree, nil
+}
+
+// parseBuild 解析一个构建（根构建、buildSrc或includeBuild引入的构建）。
+func parseBuild(p Parser, rootDir string, visited map[string]bool) *model.ProjectTree {
+	rootDir = filepath.Clean(rootDir)
+	visited[rootDir] = true
+
+	tree := &model.ProjectTree{
+		RootDir: rootDir,
+		Modules: make([]*model.Module, 0),
+	}
+
+	var includes []string
+	var projectDirs map[string]string
+	var includedBuilds []string
+	if settingsFile := findFile(rootDir, settingsFileNames); settingsFile != "" {
+		result, err := p.ParseFile(settingsFile)
+		if err != nil {
+			tree.Errors = append(tree.Errors, err)
+		} else {
+			setOriginFile(result.Project, settingsFile)
+			tree.Settings = result
+			content, _ := os.ReadFile(settingsFile)
+			includes, projectDirs = parseSettingsIncludes(string(content))
+			if result.Project.Features != nil {
+				includedBuilds = result.Project.Features.IncludedBuilds
+			}
+		}
+	}
+
+	catalogFile := filepath.Join(rootDir, filepath.FromSlash(catalog.DefaultPath))
+	if _, err := os.Stat(catalogFile); err == nil {
+		if versionCatalog, err := catalog.ParseFile(catalogFile); err != nil {
+			tree.Errors = append(tree.Errors, err)
+		} else {
+			tree.VersionCatalog = versionCatalog
+		}
+	}
+
+	root := parseModule(p, tree, ":", rootDir)
+	for _, path := range includes {
+		dir := filepath.Join(rootDir, filepath.FromSlash(strings.ReplaceAll(strings.TrimPrefix(path, ":"), ":", "/")))
+		if custom, ok := projectDirs[path]; ok {
+			dir = filepath.Join(rootDir, filepath.FromSlash(custom))
+		}
+		module := parseModule(p, tree, path, dir)
+		attachSubProject(tree, root, module)
+	}
+
+	if buildSrc := filepath.Join(rootDir, "buildSrc"); isDir(buildSrc) && !visited[buildSrc] {
+		tree.BuildSrc = parseBuild(p, buildSrc, visited)
+	}
+	for _, path := range includedBuilds {
+		dir := filepath.Join(rootDir, filepath.FromSlash(path))
+		if isDir(dir) && !visited[filepath.Clean(dir)] {
+			tree.IncludedBuilds = append(tree.IncludedBuilds, parseBuild(p, dir, visited))
+		}
+	}
+
+	linkProjectRefs(tree)
+	return tree
+}
+
+// linkProjectRefs 把模块中的 project(...) 依赖关联到同一构建中对应的模块。
+// 不以 : 开头的路径相对于声明它的模块解析。
+func linkProjectRefs(tree *model.ProjectTree) {
+	for _, module := range tree.Modules {
+		if module.Result == nil {
+			continue
+		}
+		for _, dep := range module.Result.Project.Dependencies {
+			if dep.Project == nil {
+				continue
+			}
+			path := dep.Project.Path
+			if !strings.HasPrefix(path, ":") {
+				path = strings.TrimSuffix(module.Path, ":") + ":" + path
+			}
+			dep.Project.Module = tree.Module(path)
+		}
+	}
+}
+
+// parseModule 解析模块目录中的构建文件并把模块加入构建树。
+func parseModule(p Parser, tree *model.ProjectTree, path, dir string) *model.Module {
+	module := &model.Module{Path: path, Dir: dir}
+	if buildFile := findFile(dir, buildFileNames); buildFile != "" {
+		module.FilePath = buildFile
+		result, err := p.ParseFile(buildFile)
+		if err != nil {
+			tree.Errors = append(tree.Errors, err)
+		} else {
+			setOriginFile(result.Project, buildFile)
+			module.Result = result
+		}
+	}
+	tree.Modules = append(tree.Modules, module)
+	return module
+}
+
+// attachSubProject 把模块的项目挂到父模块（不存在时为根模块）的SubProjects下，使依赖图等功能可以遍历整个构建。
+func attachSubProject(tree *model.ProjectTree, root, module *model.Module) {
+	if module.Result == nil || root.Result == nil {
+		return
+	}
+
+	parent := root
+	if i := strings.LastIndex(module.Path, ":"); i > 0 {
+		if m := tree.Module(module.Path[:i]); m != nil && m.Result != nil {
+			parent = m
+		}
+	}
+
+	module.Result.Project.Name = module.Path[strings.LastIndex(module.Path, ":")+1:]
+	parent.Result.Project.SubProjects = append(parent.Result.Project.SubProjects, module.Result.Project)
+}
+
+// parseSettingsIncludes 返回settings文件中include的模块路径（统一以 : 开头）以及重定向的模块目录。
+func parseSettingsIncludes(content string) ([]string, map[string]string) {
+	code := maskComments(content)
+
+	includes := make([]string, 0)
+	for _, match := range settingsIncludeRegex.FindAllStringSubmatch(code, -1) {
+		for _, path := range quotedValues(match[1]) {
+			if !strings.HasPrefix(path, ":") {
+				path = ":" + path
+			}
+			includes = appendUnique(includes, path)
+		}
+	}
+
+	projectDirs := make(map[string]string)
+	for _, match := range projectDirRegex.FindAllStringSubmatch(code, -1) {
+		path := match[1]
+		if !strings.HasPrefix(path, ":") {
+			path = ":" + path
+		}
+		projectDirs[path] = match[2]
+	}
+
+	return includes, projectDirs
+}
+
+// collectConventionPlugins 收集构建树中buildSrc和includeBuild里的预编译脚本插件，汇总到target上。
+func collectConventionPlugins(p Parser, target, tree *model.ProjectTree) {
+	nested := tree.IncludedBuilds
+	if tree.BuildSrc != nil {
+		nested = append([]*model.ProjectTree{tree.BuildSrc}, nested...)
+	}
+
+	for _, build := range nested {
+		for _, module := range build.Modules {
+			target.ConventionPlugins = append(target.ConventionPlugins, findPrecompiledPlugins(p, module.Dir, build.RootDir)...)
+		}
+		collectConventionPlugins(p, target, build)
+	}
+}
+
+// findPrecompiledPlugins 查找模块 src/main/groovy 下的 *.gradle 和 src/main/kotlin 下的 *.gradle.kts 脚本插件。
+func findPrecompiledPlugins(p Parser, moduleDir, buildDir string) []*model.ConventionPlugin {
+	plugins := make([]*model.ConventionPlugin, 0)
+	sources := []struct {
+		dir    string
+		suffix string
+	}{
+		{filepath.Join(moduleDir, "src", "main", "groovy"), ".gradle"},
+		{filepath.Join(moduleDir, "src", "main", "kotlin"), ".gradle.kts"},
+	}
+
+	for _, source := range sources {
+		files := make([]string, 0)
+		_ = filepath.Walk(source.dir, func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() && strings.HasSuffix(path, source.suffix) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		sort.Strings(files)
+
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				continue
+			}
+
+			id := strings.TrimSuffix(filepath.Base(file), source.suffix)
+			if source.suffix == ".gradle.kts" {
+				if match := kotlinPackageRegex.FindStringSubmatch(maskComments(string(content))); match != nil {
+					id = match[1] + "." + id
+				}
+			}
+
+			plugin := &model.ConventionPlugin{ID: id, FilePath: file, BuildDir: buildDir}
+			if result, err := p.Parse(string(content)); err == nil {
+				result.Project.FilePath = file
+				setOriginFile(result.Project, file)
+				plugin.Result = result
+			}
+			plugins = append(plugins, plugin)
+		}
+	}
+
+	return plugins
+}
+
+// linkConventionPlugins 把模块中应用的插件与定义它的约定插件关联起来。
+func linkConventionPlugins(target, tree *model.ProjectTree) {
+	if len(target.ConventionPlugins) == 0 {
+		return
+	}
+	for _, module := range tree.Modules {
+		if module.Result == nil {
+			continue
+		}
+		for _, plugin := range module.Result.Project.Plugins {
+			if conventionPlugin := target.ConventionPlugin(plugin.ID); conventionPlugin != nil {
+				conventionPlugin.AppliedBy = appendUnique(conventionPlugin.AppliedBy, module.Path)
+			}
+		}
+	}
+}
+
+// findFile 返回目录中第一个存在的候选文件，都不存在时返回空字符串。
+func findFile(dir string, names []string) string {
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// isDir 判断路径是否是已存在的目录。
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
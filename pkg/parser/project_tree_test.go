@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTreeFiles 在临时目录中创建测试用的构建文件。
+func writeTreeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestParseProjectTree(t *testing.T) {
+	root := writeTreeFiles(t, map[string]string{
+		"settings.gradle": `rootProject.name = 'demo'
+include ':app', ':libs:core'
+include 'legacy'
+project(':legacy').projectDir = file('old/legacy')
+includeBuild('build-logic')
+dependencyResolutionManagement {
+    repositories {
+        mavenCentral()
+    }
+}
+`,
+		"build.gradle": "group = 'com.example'\n",
+		"app/build.gradle": `plugins {
+    id 'myorg.java-conventions'
+    id 'com.example.service'
+}
+dependencies {
+    implementation project(path: ':libs:core', configuration: 'shadow')
+}
+`,
+		"libs/core/build.gradle.kts": "plugins {\n    id(\"myorg.java-conventions\")\n}\ndependencies {\n    implementation(project(\":legacy\"))\n}\n",
+		"old/legacy/build.gradle":    "version = '0.1'\n",
+
+		"buildSrc/build.gradle":                                  "plugins {\n    id 'groovy-gradle-plugin'\n}\n",
+		"buildSrc/src/main/groovy/myorg.java-conventions.gradle": "plugins {\n    id 'java'\n}\n",
+		"build-logic/settings.gradle.kts":                        "include(\"service\")\n",
+		"build-logic/service/build.gradle.kts":                   "plugins {\n    `kotlin-dsl`\n}\n",
+		"build-logic/service/src/main/kotlin/service.gradle.kts": "package com.example\n\nplugins {\n    java\n}\n",
+		"build-logic/service/src/main/kotlin/Helper.kt":          "package com.example\n",
+	})
+
+	tree, err := ParseProjectTree(NewParser(), root)
+	if err != nil {
+		t.Fatalf("ParseProjectTree() error = %v", err)
+	}
+	if len(tree.Errors) > 0 {
+		t.Fatalf("Unexpected errors: %v", tree.Errors)
+	}
+
+	paths := make([]string, 0, len(tree.Modules))
+	for _, module := range tree.Modules {
+		paths = append(paths, module.Path)
+	}
+	if want := []string{":", ":app", ":libs:core", ":legacy"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("Module paths = %v, want %v", paths, want)
+	}
+
+	legacy := tree.Module(":legacy")
+	if legacy == nil || legacy.Result == nil || legacy.Result.Project.Version != "0.1" {
+		t.Errorf("Expected :legacy to be parsed from old/legacy, got %+v", legacy)
+	}
+
+	appRef := tree.Module(":app").Result.Project.Dependencies[0].Project
+	if appRef == nil || appRef.Path != ":libs:core" || appRef.Configuration != "shadow" || appRef.Module != tree.Module(":libs:core") {
+		t.Errorf("Expected :app to reference :libs:core with configuration shadow, got %+v", appRef)
+	}
+	coreDeps := tree.Module(":libs:core").Result.Project.Dependencies
+	if len(coreDeps) != 1 || coreDeps[0].Project == nil || coreDeps[0].Project.Module != legacy {
+		t.Errorf("Expected Kotlin project dependency of :libs:core to resolve to :legacy, got %+v", coreDeps)
+	}
+
+	if repos := tree.EffectiveRepositories(tree.Module(":app")); len(repos) != 1 || repos[0].Name != "mavenCentral" {
+		t.Errorf("Expected :app to use the centrally declared repositories, got %v", repos)
+	}
+
+	rootProject := tree.Module(":").Result.Project
+	if len(rootProject.SubProjects) != 3 || rootProject.SubProjects[0].Name != "app" {
+		t.Errorf("Expected modules to be attached as sub-projects, got %d", len(rootProject.SubProjects))
+	}
+
+	if tree.BuildSrc == nil || len(tree.IncludedBuilds) != 1 {
+		t.Fatalf("Expected buildSrc and one included build, got %v and %d", tree.BuildSrc, len(tree.IncludedBuilds))
+	}
+	if got := tree.IncludedBuilds[0].Module(":service"); got == nil || got.Result == nil {
+		t.Errorf("Expected :service module in included build")
+	}
+
+	if len(tree.ConventionPlugins) != 2 {
+		t.Fatalf("Expected 2 convention plugins, got %d", len(tree.ConventionPlugins))
+	}
+
+	javaConventions := tree.ConventionPlugin("myorg.java-conventions")
+	if javaConventions == nil {
+		t.Fatal("myorg.java-conventions not found")
+	}
+	if !reflect.DeepEqual(javaConventions.AppliedBy, []string{":app", ":libs:core"}) {
+		t.Errorf("AppliedBy = %v", javaConventions.AppliedBy)
+	}
+	if javaConventions.BuildDir != tree.BuildSrc.RootDir || javaConventions.Result == nil {
+		t.Errorf("Unexpected convention plugin: %+v", javaConventions)
+	}
+
+	service := tree.ConventionPlugin("com.example.service")
+	if service == nil || !reflect.DeepEqual(service.AppliedBy, []string{":app"}) {
+		t.Errorf("Expected com.example.service applied by :app, got %+v", service)
+	}
+}
+
+func TestParseProjectTree_SingleProject(t *testing.T) {
+	root := writeTreeFiles(t, map[string]string{
+		"build.gradle": "version = '1.0'\n",
+	})
+
+	tree, err := ParseProjectTree(NewParser(), root)
+	if err != nil {
+		t.Fatalf("ParseProjectTree() error = %v", err)
+	}
+	if tree.Settings != nil || len(tree.Modules) != 1 || tree.Modules[0].Result == nil {
+		t.Errorf("Unexpected tree for single project: %+v", tree)
+	}
+
+	if _, err := ParseProjectTree(NewParser(), filepath.Join(root, "missing")); err == nil {
+		t.Error("Expected error for missing directory")
+	}
+	if _, err := ParseProjectTree(NewParser(), filepath.Join(root, "build.gradle")); !errors.Is(err, ErrNotDirectory) {
+		t.Errorf("Expected ErrNotDirectory for a file path, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+// Package parser 提供顶层属性赋值的提取与类型推断。
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配一行中的赋值语句，例如 version = '1.0'、ext.foo = 1、val kotlinVersion: String = "1.9.0"。
+	// 分组依次为声明关键字、名称和值。
+	assignmentLineRegex = regexp.MustCompile(
+		`(?m)^[ \t]*(?:(def|val|var)[ \t]+)?([A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)*)` +
+			`(?:[ \t]*:[ \t]*[\w.<>?, ]+?)?[ \t]*=[ \t]*([^=\s].*?)[ \t]*;?[ \t]*$`)
+
+	// 匹配Kotlin的集合构造函数，例如 listOf("a", "b")。
+	listCallRegex = regexp.MustCompile(`^(?:listOf|mutableListOf|arrayOf|setOf|mutableSetOf)\s*\(`)
+)
+
+// ExtractProperties 返回脚本中所有顶层赋值（不在任何块中的赋值），按出现顺序排列，并推断值的类型。
+// 注释中的赋值会被忽略，值只取赋值所在的一行。
+func ExtractProperties(content string) []*model.Property {
+	masked := []byte(maskComments(content))
+	for _, block := range scanBlocks(content) {
+		if block.parent != nil {
+			continue
+		}
+		for i := block.headerStart; i < block.end(len(content)); i++ {
+			if masked[i] != '\n' {
+				masked[i] = ' '
+			}
+		}
+	}
+
+	index := newLineIndex(content)
+	properties := make([]*model.Property, 0)
+	for _, m := range assignmentLineRegex.FindAllStringSubmatchIndex(string(masked), -1) {
+		start := m[4]
+		if m[2] != -1 {
+			start = m[2]
+		}
+		rawValue := content[m[6]:m[7]]
+		expr := ParseExpression(rawValue)
+
+		property := &model.Property{
+			Key:         content[m[4]:m[5]],
+			Value:       rawValue,
+			Type:        inferPropertyType(rawValue, expr),
+			Expression:  expr,
+			SourceRange: index.rangeOf(start, m[7]),
+			RawText:     content[start:m[7]],
+		}
+		if m[2] != -1 {
+			property.Keyword = content[m[2]:m[3]]
+		}
+		if expr.IsLiteral() {
+			property.Value = expr.Value
+		}
+		properties = append(properties, property)
+	}
+
+	return properties
+}
+
+// inferPropertyType 根据值的文本和表达式树推断属性类型。
+func inferPropertyType(rawValue string, expr *model.Expression) model.PropertyType {
+	if strings.HasPrefix(rawValue, "[") || listCallRegex.MatchString(rawValue) {
+		return model.PropertyTypeList
+	}
+	if !expr.IsLiteral() {
+		return model.PropertyTypeExpression
+	}
+
+	raw := strings.TrimSpace(expr.Raw)
+	switch {
+	case strings.HasPrefix(raw, "'") || strings.HasPrefix(raw, `"`):
+		return model.PropertyTypeString
+	case raw == "true" || raw == "false":
+		return model.PropertyTypeBool
+	}
+	number := strings.TrimRight(raw, "lLfFdD")
+	if _, err := strconv.ParseInt(number, 10, 64); err == nil {
+		return model.PropertyTypeInt
+	}
+	if _, err := strconv.ParseFloat(number, 64); err == nil {
+		return model.PropertyTypeFloat
+	}
+	return model.PropertyTypeExpression
+}
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestExtractProperties(t *testing.T) {
+	content := `group = 'com.example'
+version = "1.0.${buildNumber}" // CI build
+// commented = 1
+ext.springVersion = "5.3.21"
+def retries = 3
+val kotlinVersion: String = "1.9.0"
+sourceCompatibility = 1.8
+ext.enabled = true
+ext.modules = ['core', 'web']
+val libs = listOf("a", "b")
+description = rootProject.name
+android {
+    compileSdk = 33
+}
+if (a == b) {
+}
+`
+
+	want := []struct {
+		key, keyword, value string
+		typ                 model.PropertyType
+		line                int
+	}{
+		{"group", "", "com.example", model.PropertyTypeString, 1},
+		{"version", "", `"1.0.${buildNumber}"`, model.PropertyTypeExpression, 2},
+		{"ext.springVersion", "", "5.3.21", model.PropertyTypeString, 4},
+		{"retries", "def", "3", model.PropertyTypeInt, 5},
+		{"kotlinVersion", "val", "1.9.0", model.PropertyTypeString, 6},
+		{"sourceCompatibility", "", "1.8", model.PropertyTypeFloat, 7},
+		{"ext.enabled", "", "true", model.PropertyTypeBool, 8},
+		{"ext.modules", "", "['core', 'web']", model.PropertyTypeList, 9},
+		{"libs", "val", `listOf("a", "b")`, model.PropertyTypeList, 10},
+		{"description", "", "rootProject.name", model.PropertyTypeExpression, 11},
+	}
+
+	properties := ExtractProperties(content)
+	if len(properties) != len(want) {
+		t.Fatalf("Expected %d properties, got %d", len(want), len(properties))
+	}
+	for i, w := range want {
+		p := properties[i]
+		if p.Key != w.key || p.Keyword != w.keyword || p.Value != w.value || p.Type != w.typ {
+			t.Errorf("Property %d: got %s/%s/%q/%s, want %s/%s/%q/%s",
+				i, p.Key, p.Keyword, p.Value, p.Type, w.key, w.keyword, w.value, w.typ)
+		}
+		if p.SourceRange.Start.Line != w.line {
+			t.Errorf("Property %s: expected line %d, got %d", p.Key, w.line, p.SourceRange.Start.Line)
+		}
+	}
+
+	version := properties[1]
+	if version.RawText != `version = "1.0.${buildNumber}"` {
+		t.Errorf("Trailing comment should not be part of the raw text, got %q", version.RawText)
+	}
+	if got := content[version.SourceRange.Start.StartPos:version.SourceRange.End.StartPos]; got != version.RawText {
+		t.Errorf("Source range does not cover the raw text, got %q", got)
+	}
+	if refs := version.Expression.References(); len(refs) != 1 || refs[0] != "buildNumber" {
+		t.Errorf("Expected expression to reference buildNumber, got %v", refs)
+	}
+}
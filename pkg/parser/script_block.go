@@ -0,0 +1,118 @@
+// Package parser 提供脚本块结构树的构建功能。
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// 匹配块中语句开头的属性或方法名，例如 minSdk、android.namespace。
+var blockStatementKeyRegex = regexp.MustCompile(`^[A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)*`)
+
+// buildScriptBlocks 根据花括号块结构构建脚本块树，忽略注释。
+// 每个块的Values记录其直接语句，键为属性或方法名，值为原始表达式文本；
+// 没有参数的调用（如 mavenCentral()）以语句本身作为键和值。
+func buildScriptBlocks(content string) *model.ScriptBlock {
+	code := maskComments(content)
+	blocks := scanBlocks(code)
+	index := newLineIndex(content)
+
+	root := newScriptBlock("root", "")
+	root.SourceRange = index.rangeOf(0, len(content))
+	applyBlockStatements(root, topLevelText(code, blocks))
+
+	mapped := make(map[*blockSpan]*model.ScriptBlock, len(blocks))
+	for _, span := range blocks {
+		parent := root
+		if span.parent != nil {
+			parent = mapped[span.parent]
+		}
+
+		block := newScriptBlock(span.name, span.header)
+		block.Parent = parent
+		block.SourceRange = index.rangeOf(span.headerStart, span.end(len(content)))
+		applyBlockStatements(block, directBlockBody(code, span, blocks))
+
+		parent.Children = append(parent.Children, block)
+		parent.Closures[span.name] = append(parent.Closures[span.name], block)
+		mapped[span] = block
+	}
+
+	return root
+}
+
+// newScriptBlock 创建空的脚本块。
+func newScriptBlock(name, header string) *model.ScriptBlock {
+	return &model.ScriptBlock{
+		Name:       name,
+		Header:     header,
+		Children:   make([]*model.ScriptBlock, 0),
+		Values:     make(map[string]interface{}),
+		Closures:   make(map[string][]*model.ScriptBlock),
+		Statements: make([]string, 0),
+	}
+}
+
+// applyBlockStatements 将块体中的语句记录到块的Statements和Values中，同名键保留最后一次的值。
+func applyBlockStatements(block *model.ScriptBlock, body string) {
+	for _, statement := range splitStatements(body) {
+		block.Statements = append(block.Statements, statement)
+		if key, value, ok := splitBlockStatement(statement); ok {
+			block.Values[key] = value
+		} else {
+			block.Values[statement] = statement
+		}
+	}
+}
+
+// splitBlockStatement 将 key value、key = value 或 key(value) 形式的语句拆分为键和值。
+// 没有参数的语句返回false。
+func splitBlockStatement(statement string) (string, string, bool) {
+	key := blockStatementKeyRegex.FindString(statement)
+	if key == "" {
+		return "", "", false
+	}
+
+	rest := statement[len(key):]
+	trimmed := strings.TrimSpace(rest)
+	switch {
+	case trimmed == "":
+		return "", "", false
+	case strings.HasPrefix(trimmed, "="):
+		return key, strings.TrimSpace(trimmed[1:]), true
+	case strings.HasPrefix(trimmed, "("):
+		end := matchingParen(trimmed, 0)
+		if end != len(trimmed)-1 {
+			return "", "", false
+		}
+		value := strings.TrimSpace(trimmed[1:end])
+		if value == "" {
+			return "", "", false
+		}
+		return key, value, true
+	case rest[0] == ' ' || rest[0] == '\t':
+		return key, trimmed, true
+	}
+	return "", "", false
+}
+
+// matchingParen 返回与open处左括号匹配的右括号位置，忽略字符串中的括号，未闭合时返回-1。
+func matchingParen(text string, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '\'', '"':
+			i = skipString(text, i) - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
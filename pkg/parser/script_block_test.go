@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildScriptBlocks(t *testing.T) {
+	content := `version = '1.0'
+
+android {
+    namespace "com.example.app"
+    compileSdk 34
+
+    defaultConfig {
+        minSdk = 24
+        targetSdkVersion(34)
+        // versionCode 0
+        versionName "1.0"
+    }
+}
+
+repositories {
+    mavenCentral()
+}
+`
+
+	root := buildScriptBlocks(content)
+	if root.Name != "root" || root.Values["version"] != "'1.0'" {
+		t.Fatalf("Unexpected root block: %+v", root.Values)
+	}
+	if len(root.Children) != 2 || len(root.Closures["android"]) != 1 {
+		t.Fatalf("Expected android and repositories children, got %d", len(root.Children))
+	}
+
+	defaultConfig := root.FindBlock("android.defaultConfig")
+	if defaultConfig == nil {
+		t.Fatal("Expected android.defaultConfig block")
+	}
+	want := map[string]interface{}{
+		"minSdk":           "24",
+		"targetSdkVersion": "34",
+		"versionName":      `"1.0"`,
+	}
+	if !reflect.DeepEqual(defaultConfig.Values, want) {
+		t.Errorf("defaultConfig.Values = %v, want %v", defaultConfig.Values, want)
+	}
+	if len(defaultConfig.Statements) != 3 {
+		t.Errorf("Expected 3 statements, got %v", defaultConfig.Statements)
+	}
+	if defaultConfig.SourceRange.Start.Line != 7 || defaultConfig.SourceRange.End.Line != 12 {
+		t.Errorf("Unexpected source range: %s", defaultConfig.SourceRange)
+	}
+	if defaultConfig.Path() != "android.defaultConfig" || defaultConfig.Parent.Name != "android" {
+		t.Errorf("Unexpected path %q", defaultConfig.Path())
+	}
+
+	android := root.FindBlock("android")
+	if android.Values["compileSdk"] != "34" || android.Values["namespace"] != `"com.example.app"` {
+		t.Errorf("Unexpected android values: %v", android.Values)
+	}
+
+	repos := root.FindBlock("repositories")
+	if repos.Values["mavenCentral()"] != "mavenCentral()" {
+		t.Errorf("Expected no-argument call to be stored as statement, got %v", repos.Values)
+	}
+}
+
+func TestSplitBlockStatement(t *testing.T) {
+	tests := []struct {
+		statement string
+		key       string
+		value     string
+		ok        bool
+	}{
+		{"minSdk 21", "minSdk", "21", true},
+		{"minSdk = 21", "minSdk", "21", true},
+		{"minSdk(libs.versions.min.get().toInt())", "minSdk", "libs.versions.min.get().toInt()", true},
+		{"proguardFiles getDefaultProguardFile('a.txt'), 'rules.pro'", "proguardFiles", "getDefaultProguardFile('a.txt'), 'rules.pro'", true},
+		{"mavenCentral()", "", "", false},
+		{"foo(1).bar()", "", "", false},
+		{"useJUnitPlatform", "", "", false},
+	}
+
+	for _, tt := range tests {
+		key, value, ok := splitBlockStatement(tt.statement)
+		if key != tt.key || value != tt.value || ok != tt.ok {
+			t.Errorf("splitBlockStatement(%q) = %q, %q, %v", tt.statement, key, value, ok)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Package parser 提供构建脚本和properties文件中硬编码凭证的扫描功能。
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配credentials块中以字面量给出的凭证，例如 password 'secret'、username = "deployer"。
+	// 插值字符串（"${token}"）不是硬编码的凭证。
+	credentialLiteralRegex = regexp.MustCompile(
+		`\b(username|password|value|accessKey|secretKey|sessionToken)\s*(?:=\s*|\(\s*)?(?:'([^'\n]*)'|"([^"$\n]*)")`)
+
+	// 按属性名识别properties文件中保存密码、令牌（密钥）和用户名的属性。
+	passwordPropertyKeyRegex = regexp.MustCompile(`(?i)(passw(or)?d|pwd)`)
+	tokenPropertyKeyRegex    = regexp.MustCompile(`(?i)(secret|token|api[._-]?key|access[._-]?key|private[._-]?key|credential)`)
+	usernamePropertyKeyRegex = regexp.MustCompile(`(?i)(user(name)?|login)$`)
+)
+
+// FindHardcodedCredentials 找出构建脚本中 credentials { } 块里以字面量给出的用户名、密码和令牌，
+// 包括仓库和发布仓库的凭证。引用环境变量或Gradle属性的凭证不会被报告。
+func FindHardcodedCredentials(content string) []*model.HardcodedCredential {
+	masked := maskComments(content)
+	index := newLineIndex(content)
+	findings := make([]*model.HardcodedCredential, 0)
+
+	for _, block := range scanBlocks(content) {
+		if block.name != "credentials" {
+			continue
+		}
+		bodyStart, bodyEnd := block.openPos+1, block.end(len(content))-1
+		if bodyEnd < bodyStart {
+			continue
+		}
+		for _, m := range credentialLiteralRegex.FindAllStringSubmatchIndex(masked[bodyStart:bodyEnd], -1) {
+			start, end := m[4], m[5]
+			if start == -1 {
+				start, end = m[6], m[7]
+			}
+			if start == end {
+				continue
+			}
+			key := masked[bodyStart+m[2] : bodyStart+m[3]]
+			findings = append(findings, newHardcodedCredential(content, index, key, credentialKind(key), bodyStart+start, bodyStart+end))
+		}
+	}
+	return findings
+}
+
+// FindHardcodedPropertyCredentials 找出properties文件（例如gradle.properties）中属性名表示密码、令牌或用户名且值非空的属性。
+func FindHardcodedPropertyCredentials(content string) []*model.HardcodedCredential {
+	index := newLineIndex(content)
+	findings := make([]*model.HardcodedCredential, 0)
+
+	offset := 0
+	continued := false
+	for _, line := range strings.SplitAfter(content, "\n") {
+		lineStart := offset
+		offset += len(line)
+		text := strings.TrimRight(line, "\r\n")
+		// 续行属于上一个属性的值，不是新的属性。
+		isContinuation := continued
+		continued = strings.HasSuffix(text, "\\") && !strings.HasSuffix(text, "\\\\")
+		if isContinuation {
+			continue
+		}
+
+		trimmed := strings.TrimLeft(text, " \t\f")
+		if trimmed == "" || trimmed[0] == '#' || trimmed[0] == '!' {
+			continue
+		}
+		keyEnd := strings.IndexAny(trimmed, "=: \t\f")
+		if keyEnd <= 0 {
+			continue
+		}
+		key := trimmed[:keyEnd]
+		kind, ok := propertyCredentialKind(key)
+		if !ok {
+			continue
+		}
+
+		rest := strings.TrimLeft(trimmed[keyEnd:], " \t\f")
+		if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+			rest = strings.TrimLeft(rest[1:], " \t\f")
+		}
+		// rest是行尾的后缀，据此计算值在原文中的起始偏移量。
+		start := lineStart + len(text) - len(rest)
+		value := strings.TrimRight(rest, " \t\f\\")
+		if value == "" || strings.HasPrefix(value, "${") {
+			continue
+		}
+		findings = append(findings, newHardcodedCredential(content, index, key, kind, start, start+len(value)))
+	}
+	return findings
+}
+
+// newHardcodedCredential 为 content[start:end] 处的凭证值创建扫描结果，预览为遮盖了凭证值的整行。
+func newHardcodedCredential(content string, index *lineIndex, key string, kind model.CredentialKind, start, end int) *model.HardcodedCredential {
+	lineStart := strings.LastIndexByte(content[:start], '\n') + 1
+	lineEnd := len(content)
+	if i := strings.IndexByte(content[end:], '\n'); i != -1 {
+		lineEnd = end + i
+	}
+	preview := content[lineStart:start] + maskSecret(content[start:end]) + content[end:lineEnd]
+
+	return &model.HardcodedCredential{
+		Key:         key,
+		Kind:        kind,
+		Preview:     strings.TrimSpace(preview),
+		SourceRange: index.rangeOf(start, end),
+	}
+}
+
+// credentialKind 返回credentials块中凭证项的类别。
+func credentialKind(key string) model.CredentialKind {
+	switch key {
+	case "username":
+		return model.CredentialKindUsername
+	case "password":
+		return model.CredentialKindPassword
+	default:
+		return model.CredentialKindToken
+	}
+}
+
+// propertyCredentialKind 根据属性名判断属性是否保存凭证以及凭证的类别。
+func propertyCredentialKind(key string) (model.CredentialKind, bool) {
+	switch {
+	case strings.HasPrefix(key, "org.gradle."):
+		return "", false
+	case passwordPropertyKeyRegex.MatchString(key):
+		return model.CredentialKindPassword, true
+	case tokenPropertyKeyRegex.MatchString(key):
+		return model.CredentialKindToken, true
+	case usernamePropertyKeyRegex.MatchString(key):
+		return model.CredentialKindUsername, true
+	}
+	return "", false
+}
+
+// maskSecret 遮盖凭证值，较长的值保留前两个字符便于辨认，较短的值全部遮盖，且不暴露长度。
+func maskSecret(value string) string {
+	if utf8.RuneCountInString(value) < 8 {
+		return "****"
+	}
+	runes := []rune(value)
+	return string(runes[:2]) + "****"
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestFindHardcodedCredentials(t *testing.T) {
+	content := `repositories {
+    maven {
+        url 'https://repo.example.com/releases'
+        credentials {
+            username 'deployer'
+            password "s3cr3t-passw0rd"
+        }
+    }
+    maven {
+        url = uri("https://maven.pkg.github.com/acme/repo")
+        credentials {
+            username = project.findProperty("gpr.user")
+            password = System.getenv("GITHUB_TOKEN")
+        }
+    }
+    maven {
+        url "https://gitlab.example.com/api/v4/packages/maven"
+        credentials(HttpHeaderCredentials) {
+            name = "Private-Token"
+            value = "glpat-abcdefghijklmnop"
+        }
+    }
+    maven {
+        url "s3://bucket/maven"
+        credentials(AwsCredentials) {
+            // accessKey 'AKIAOLDKEY0000000000'
+            accessKey "${awsAccessKey}"
+        }
+    }
+}
+`
+	findings := FindHardcodedCredentials(content)
+
+	expected := []struct {
+		key     string
+		kind    model.CredentialKind
+		preview string
+		line    int
+	}{
+		{"username", model.CredentialKindUsername, "username 'de****'", 5},
+		{"password", model.CredentialKindPassword, `password "s3****"`, 6},
+		{"value", model.CredentialKindToken, `value = "gl****"`, 20},
+	}
+	if len(findings) != len(expected) {
+		t.Fatalf("Expected %d findings, got %d: %+v", len(expected), len(findings), findings)
+	}
+	for i, want := range expected {
+		got := findings[i]
+		if got.Key != want.key || got.Kind != want.kind || got.Preview != want.preview || got.SourceRange.Start.Line != want.line {
+			t.Errorf("Finding %d = %+v, want %+v", i, got, want)
+		}
+		if value := content[got.SourceRange.Start.StartPos:got.SourceRange.End.StartPos]; strings.ContainsAny(value, `'"`) {
+			t.Errorf("Finding %d: expected range to exclude quotes, got %q", i, value)
+		}
+	}
+}
+
+func TestFindHardcodedPropertyCredentials(t *testing.T) {
+	content := "# credentials\n" +
+		"org.gradle.jvmargs=-Xmx2g\n" +
+		"mavenUser=deployer\n" +
+		"mavenPassword = hunter2-long-password\r\n" +
+		"gpr.token: ghp_0123456789abcdef\n" +
+		"signing.keyId=24875D73\n" +
+		"emptyPassword=\n" +
+		"sonatypePassword=${SONATYPE_PASSWORD}\n" +
+		"description=first \\\n" +
+		"  password continues\n"
+
+	findings := FindHardcodedPropertyCredentials(content)
+
+	expected := []struct {
+		key     string
+		kind    model.CredentialKind
+		preview string
+		line    int
+	}{
+		{"mavenUser", model.CredentialKindUsername, "mavenUser=de****", 3},
+		{"mavenPassword", model.CredentialKindPassword, "mavenPassword = hu****", 4},
+		{"gpr.token", model.CredentialKindToken, "gpr.token: gh****", 5},
+	}
+	if len(findings) != len(expected) {
+		t.Fatalf("Expected %d findings, got %d: %+v", len(expected), len(findings), findings)
+	}
+	for i, want := range expected {
+		got := findings[i]
+		if got.Key != want.key || got.Kind != want.kind || got.Preview != want.preview || got.SourceRange.Start.Line != want.line {
+			t.Errorf("Finding %d = %+v, want %+v", i, got, want)
+		}
+	}
+	if value := content[findings[1].SourceRange.Start.StartPos:findings[1].SourceRange.End.StartPos]; value != "hunter2-long-password" {
+		t.Errorf("Expected password value range, got %q", value)
+	}
+}
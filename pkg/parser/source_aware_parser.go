@@ -2,15 +2,39 @@
 package parser
 
 import (
-	"bufio"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/scagogogo/gradle-parser/pkg/dependency"
 	"github.com/scagogogo/gradle-parser/pkg/model"
 )
 
+var (
+	// 匹配map形式的依赖声明的开头和属性。
+	// 例如: implementation group: 'org.foo', name: 'bar', version: '1.0'。
+	mapDependencyStartRegex = regexp.MustCompile(`^\s*[A-Za-z_]\w*\s*\(?\s*group\s*[:=]\s*['"]`)
+	mapDependencyAttrRegex  = regexp.MustCompile(`\b(group|name|version|classifier|ext)\s*[:=]\s*['"]([^'"]*)['"]`)
+
+	// 匹配依赖声明开头的配置名称，例如 implementation 'g:a:v' 或 testImplementation("g:a:v")。
+	declarationScopeRegex = regexp.MustCompile(`^\s*([A-Za-z_]\w*)\s*[\s(]`)
+
+	// 匹配单行的maven仓库声明。
+	// 例如: maven { url 'https://jitpack.io' }。
+	inlineMavenRepoRegex = regexp.MustCompile(`maven\s*\{[^{}]*\burl\b[^{}]*\}`)
+
+	// 匹配plugins块中的插件声明，与插件解析器使用相同的规则，匹配文本包括 apply false。
+	// 例如: id 'org.springframework.boot' version '3.1.0' apply false。
+	sourceMappedPluginRegex = regexp.MustCompile(`id\s*\(?['"](.*?)['"](\))?(\s+version\s*['"](.*?)['"])?(\s+apply\s*\(?\s*(true|false)\s*\)?)?`)
+
+	// 匹配仓库URL声明。
+	// 例如: url 'https://jitpack.io' 或 url = uri("https://jitpack.io")。
+	repoURLRegex = regexp.MustCompile(`\burl\s*(?:=\s*)?(?:uri\s*\(\s*)?['"]((?:https?|file)://[^'"]+)['"]\)?`)
+)
+
 // SourceAwareParser 位置感知的Gradle解析器。
+// 它在实例上保存位置追踪状态，不能被多个goroutine同时使用。
 type SourceAwareParser struct {
 	*GradleParser
 
@@ -22,6 +46,40 @@ type SourceAwareParser struct {
 	// 原始文本信息。
 	originalText string
 	lines        []string
+
+	// 块结构信息，用于判断声明所在的块。
+	blocks []*blockSpan
+	index  *lineIndex
+	masked string // 注释被替换为空格后的文本。
+
+	// 按语句切分的原文，用于识别一行中的多条依赖声明和跨行的依赖声明。
+	statements []dependency.Statement
+
+	// 是否保留注释并附加到声明上。
+	retainComments bool
+}
+
+// 源码映射时声明所在的块上下文。
+const (
+	contextOther        = ""
+	contextDependencies = "dependencies"
+	contextPlugins      = "plugins"
+	contextRepositories = "repositories"
+)
+
+// 仓库声明内部可能出现的子块，这些块中的内容仍属于repositories上下文。
+var repositoryChildBlocks = map[string]bool{
+	"maven":            true,
+	"ivy":              true,
+	"flatDir":          true,
+	"credentials":      true,
+	"authentication":   true,
+	"content":          true,
+	"mavenContent":     true,
+	"metadataSources":  true,
+	"exclusiveContent": true,
+	"forRepository":    true,
+	"filter":           true,
 }
 
 // NewSourceAwareParser 创建新的位置感知解析器。
@@ -36,6 +94,14 @@ func NewSourceAwareParser() *SourceAwareParser {
 	}
 }
 
+// WithRetainComments 设置是否保留注释。
+// 开启后注释会作为 model.Comment 收集到 SourceMappedProject.Comments 中，
+// 并附加到紧随其后的依赖、插件或属性声明上，行尾注释附加到同一行的声明上。
+func (sap *SourceAwareParser) WithRetainComments(retain bool) *SourceAwareParser {
+	sap.retainComments = retain
+	return sap
+}
+
 // ParseWithSourceMapping 解析并返回带源码位置信息的结果。
 func (sap *SourceAwareParser) ParseWithSourceMapping(content string) (*model.SourceMappedParseResult, error) {
 	// 初始化位置追踪。
@@ -56,10 +122,13 @@ func (sap *SourceAwareParser) ParseWithSourceMapping(content string) (*model.Sou
 		Project:                  result.Project,
 		OriginalText:             content,
 		Lines:                    sap.lines,
+		LineEnding:               model.DetectLineEnding(content),
 		SourceMappedDependencies: make([]*model.SourceMappedDependency, 0),
 		SourceMappedPlugins:      make([]*model.SourceMappedPlugin, 0),
 		SourceMappedRepositories: make([]*model.SourceMappedRepository, 0),
 		SourceMappedProperties:   make([]*model.SourceMappedProperty, 0),
+		SourceMappedTasks:        make([]*model.SourceMappedTask, 0),
+		SourceMappedBlocks:       make([]*model.SourceMappedBlock, 0),
 	}
 
 	// 解析带位置信息的组件。
@@ -67,6 +136,14 @@ func (sap *SourceAwareParser) ParseWithSourceMapping(content string) (*model.Sou
 		return nil, err
 	}
 
+	// 解析带位置信息的块和任务。
+	sap.parseSourceMappedBlocks(content, sourceMappedProject)
+	sap.parseSourceMappedConstraints(content, sourceMappedProject)
+
+	if sap.retainComments {
+		sap.collectComments(content, sourceMappedProject)
+	}
+
 	return &model.SourceMappedParseResult{
 		ParseResult:         result,
 		SourceMappedProject: sourceMappedProject,
@@ -74,22 +151,44 @@ func (sap *SourceAwareParser) ParseWithSourceMapping(content string) (*model.Sou
 }
 
 // parseSourceMappedComponents 解析带位置信息的组件。
+// 每种声明只在对应的块中识别，例如依赖只在 dependencies 块中识别。
 func (sap *SourceAwareParser) parseSourceMappedComponents(content string, project *model.SourceMappedProject) error {
-	scanner := bufio.NewScanner(strings.NewReader(content))
-	lineNumber := 0
+	sap.blocks = scanBlocks(content)
+	sap.index = newLineIndex(content)
+	sap.masked = maskComments(content)
+	sap.statements = dependency.SplitStatements(content)
+
+	lines := strings.Split(content, "\n")
+	lineStarts := make([]int, len(lines))
 	currentPos := 0
+	for i, line := range lines {
+		lineStarts[i] = currentPos
+		// 更新位置（+1 for newline character）。
+		currentPos += len(line) + 1
+	}
 
-	for scanner.Scan() {
-		lineNumber++
-		line := scanner.Text()
-		lineStart := currentPos
-		lineEnd := currentPos + len(line)
+	for i := 0; i < len(lines); i++ {
+		// CRLF文件中去掉行尾的 \r，使声明的范围和原始文本不包含换行符。
+		line := strings.TrimSuffix(lines[i], "\r")
+		lineNumber := i + 1
+		lineStart := lineStarts[i]
 
-		// 解析属性。
-		if err := sap.parseSourceMappedProperty(line, lineNumber, lineStart, project); err == nil {
+		// 跨多行的map形式依赖声明。
+		if consumed := sap.parseSourceMappedMapDependency(lines, i, lineStarts, project); consumed > 0 {
+			i += consumed - 1
+			continue
+		}
+
+		// 先识别URL仓库，避免URL被误识别为属性或依赖。
+		if err := sap.parseSourceMappedURLRepository(line, lineNumber, lineStart, project); err == nil {
+			// URL仓库解析成功。
+		} else if err := sap.parseSourceMappedProperty(line, lineNumber, lineStart, project); err == nil {
 			// 属性解析成功，继续下一行。
-		} else if err := sap.parseSourceMappedDependency(line, lineNumber, lineStart, project); err == nil {
-			// 依赖解析成功。
+		} else if end, err := sap.parseSourceMappedDependency(line, lineStart, project); err == nil {
+			// 依赖解析成功，跳过声明延续到的行。
+			for i+1 < len(lines) && lineStarts[i+1] < end {
+				i++
+			}
 		} else if err := sap.parseSourceMappedPlugin(line, lineNumber, lineStart, project); err == nil {
 			// 插件解析成功。
 		} else {
@@ -97,12 +196,51 @@ func (sap *SourceAwareParser) parseSourceMappedComponents(content string, projec
 			_ = sap.parseSourceMappedRepository(line, lineNumber, lineStart, project) //nolint:errcheck
 			// 仓库解析成功或失败都继续处理，无法解析的行跳过。
 		}
+	}
 
-		// 更新位置（+1 for newline character）。
-		currentPos = lineEnd + 1
+	return nil
+}
+
+// contextAt 返回指定位置所在的块上下文，不在任何已知块中时返回 contextOther。
+func (sap *SourceAwareParser) contextAt(pos int) string {
+	var inner *blockSpan
+	for _, block := range sap.blocks {
+		if block.openPos >= pos {
+			break
+		}
+		if block.contains(pos, len(sap.originalText)) {
+			inner = block
+		}
+	}
+
+	for block := inner; block != nil; block = block.parent {
+		switch {
+		case block.name == "":
+			continue
+		case block.name == contextDependencies:
+			return contextDependencies
+		case block.name == contextPlugins:
+			return contextPlugins
+		case block.name == contextRepositories:
+			for ancestor := block.parent; ancestor != nil; ancestor = ancestor.parent {
+				if ancestor.name == "publishing" {
+					return contextOther
+				}
+			}
+			return contextRepositories
+		case repositoryChildBlocks[block.name]:
+			continue
+		default:
+			return contextOther
+		}
 	}
 
-	return scanner.Err()
+	return contextOther
+}
+
+// inContext 判断位置是否位于指定的块上下文中。
+func (sap *SourceAwareParser) inContext(pos int, context string) bool {
+	return sap.contextAt(pos) == context
 }
 
 // parseSourceMappedProperty 解析带位置信息的属性。
@@ -119,8 +257,8 @@ func (sap *SourceAwareParser) parseSourceMappedProperty(line string, lineNumber,
 		}
 
 		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-		value = strings.Trim(value, `"'`)
+		rawValue := strings.TrimSpace(parts[1])
+		value := strings.Trim(rawValue, `"'`)
 
 		// 计算在行内的位置。
 		keyStart := strings.Index(line, key)
@@ -128,32 +266,28 @@ func (sap *SourceAwareParser) parseSourceMappedProperty(line string, lineNumber,
 			return fmt.Errorf("key not found in line")
 		}
 
+		// 注释中的赋值不是属性。
+		if sap.masked[lineStart+keyStart] == ' ' {
+			return fmt.Errorf("assignment inside comment")
+		}
+
+		// dependencies、plugins、repositories 块中的赋值不是项目属性。
+		if sap.contextAt(lineStart+keyStart) != contextOther {
+			return fmt.Errorf("assignment inside %s block", sap.contextAt(lineStart+keyStart))
+		}
+
 		valueStart := strings.Index(line, parts[1])
 		if valueStart == -1 {
 			return fmt.Errorf("value not found in line")
 		}
 
 		// 创建源码位置信息。
-		sourceRange := model.SourceRange{
-			Start: model.SourcePosition{
-				Line:     lineNumber,
-				Column:   keyStart + 1,
-				StartPos: lineStart + keyStart,
-				EndPos:   lineStart + len(line),
-				Length:   len(line) - keyStart,
-			},
-			End: model.SourcePosition{
-				Line:     lineNumber,
-				Column:   len(line),
-				StartPos: lineStart + len(line),
-				EndPos:   lineStart + len(line),
-				Length:   0,
-			},
-		}
+		sourceRange := sap.index.rangeOf(lineStart+keyStart, lineStart+len(line))
 
 		sourceMappedProperty := &model.SourceMappedProperty{
 			Key:         key,
 			Value:       value,
+			Expression:  ParseExpression(rawValue),
 			SourceRange: sourceRange,
 			RawText:     line,
 		}
@@ -165,82 +299,99 @@ func (sap *SourceAwareParser) parseSourceMappedProperty(line string, lineNumber,
 	return fmt.Errorf("not a property assignment")
 }
 
-// parseSourceMappedDependency 解析带位置信息的依赖。
-func (sap *SourceAwareParser) parseSourceMappedDependency(line string, lineNumber, lineStart int,
+// sourceMappedDependencyPatterns 匹配依赖声明中的坐标字面量或项目依赖。
+// 项目依赖放在最前面，避免 project(path: ':app', ...) 中的命名参数被误认为坐标。
+var sourceMappedDependencyPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`project\s*\([^()]*\)`),                         // project(":name") 或 project(path: ":name", configuration: "x")。
+	regexp.MustCompile(`['"]([^'"]+):([^'"]+):([^'"]+)['"]`),           // "group:name:version"。
+	regexp.MustCompile(`['"]([^'"]+):([^'"]+)['"]`),                    // "group:name" (没有版本号)。
+	regexp.MustCompile(`['"]([^'"]+)\.([^'"]+):([^'"]+):([^'"]+)['"]`), // "group.name:name:version"。
+}
+
+// parseSourceMappedDependency 解析从本行开始的依赖声明，一行中用分号分隔的多条声明各自记录为一个依赖。
+// 声明可以跨越多行，返回最后一条声明在原文中的结束位置。
+func (sap *SourceAwareParser) parseSourceMappedDependency(line string, lineStart int,
 	project *model.SourceMappedProject,
-) error {
-	trimmedLine := strings.TrimSpace(line)
+) (int, error) {
+	lineEnd := lineStart + len(line)
+	first := sort.Search(len(sap.statements), func(i int) bool {
+		return sap.statements[i].Start >= lineStart
+	})
+
+	end := -1
+	for _, statement := range sap.statements[first:] {
+		if statement.Start >= lineEnd {
+			break
+		}
+		if !sap.inContext(statement.Start, contextDependencies) {
+			continue
+		}
+		dep, start, depEnd := statementDependency(statement)
+		if dep == nil {
+			continue
+		}
 
-	// 使用依赖解析器的正则表达式。
-	patterns := []string{
-		`['"]([^'"]+):([^'"]+):([^'"]+)['"]`,           // "group:name:version"。
-		`['"]([^'"]+):([^'"]+)['"]`,                    // "group:name" (没有版本号)。
-		`['"]([^'"]+)\.([^'"]+):([^'"]+):([^'"]+)['"]`, // "group.name:name:version"。
-		`project\(['"]:(.*)['"]\)`,                     // project(":name")。
+		rawText := sap.originalText[start:depEnd]
+		project.SourceMappedDependencies = append(project.SourceMappedDependencies, &model.SourceMappedDependency{
+			Dependency:  dep,
+			SourceRange: sap.index.rangeOf(start, depEnd),
+			RawText:     rawText,
+		})
+		end = statement.End
 	}
 
-	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		matches := re.FindAllStringSubmatch(trimmedLine, -1)
-
-		for _, match := range matches {
-			if len(match) > 0 {
-				rawDep := match[0]
-
-				// 查找依赖在行中的位置。
-				depStart := strings.Index(line, rawDep)
-				if depStart == -1 {
-					continue
-				}
-
-				// 解析依赖 - 使用简单的解析逻辑。
-				dep := &model.Dependency{
-					Raw: rawDep,
-				}
-
-				// 简单解析group:name:version格式。
-				if strings.Contains(rawDep, ":") {
-					parts := strings.Split(strings.Trim(rawDep, `"'`), ":")
-					if len(parts) >= 2 {
-						dep.Group = parts[0]
-						dep.Name = parts[1]
-						if len(parts) >= 3 {
-							dep.Version = parts[2]
-						}
-					}
-				}
+	if end == -1 {
+		return 0, fmt.Errorf("not a dependency")
+	}
+	return end, nil
+}
 
-				// 创建源码位置信息。
-				sourceRange := model.SourceRange{
-					Start: model.SourcePosition{
-						Line:     lineNumber,
-						Column:   depStart + 1,
-						StartPos: lineStart + depStart,
-						EndPos:   lineStart + depStart + len(rawDep),
-						Length:   len(rawDep),
-					},
-					End: model.SourcePosition{
-						Line:     lineNumber,
-						Column:   depStart + len(rawDep),
-						StartPos: lineStart + depStart + len(rawDep),
-						EndPos:   lineStart + depStart + len(rawDep),
-						Length:   0,
-					},
-				}
+// statementDependency 解析一条依赖声明语句，返回依赖及其坐标字面量（或project(...)）在原文中的起止位置。
+// 用 + 连接的坐标（可能跨行）作为一个整体，范围覆盖整个连接表达式。
+func statementDependency(statement dependency.Statement) (*model.Dependency, int, int) {
+	text := statement.Text
+	scope := declarationScope(text)
 
-				sourceMappedDep := &model.SourceMappedDependency{
-					Dependency:  dep,
-					SourceRange: sourceRange,
-					RawText:     rawDep,
-				}
+	args := len(scope)
+	for args < len(text) && (text[args] == '(' || text[args] == ' ' || text[args] == '\t') {
+		args++
+	}
+	if _, n := dependency.FoldConcatenation(text[args:]); n > 0 {
+		if dep := dependency.ParseDeclaration(text); dep != nil {
+			return dep, statement.Start + args, statement.Start + args + n
+		}
+	}
 
-				project.SourceMappedDependencies = append(project.SourceMappedDependencies, sourceMappedDep)
-				return nil
-			}
+	for _, re := range sourceMappedDependencyPatterns {
+		match := re.FindStringIndex(text)
+		if match == nil {
+			continue
 		}
+		rawDep := text[match[0]:match[1]]
+
+		// 解析group:name:version[:classifier][@extension]格式。
+		dep := &model.Dependency{Raw: rawDep}
+		if ref := dependency.ParseProjectRef(rawDep); ref != nil {
+			dep.Name = strings.TrimPrefix(ref.Path, ":")
+			dep.Project = ref
+		} else if coordinate := dependency.ParseCoordinate(rawDep); coordinate != nil {
+			coordinate.Raw = rawDep
+			dep = coordinate
+		}
+		dep.Scope = scope
+		dependency.ApplyVariantScope(dep)
+		return dep, statement.Start + match[0], statement.Start + match[1]
 	}
 
-	return fmt.Errorf("not a dependency")
+	return nil, 0, 0
+}
+
+// declarationScope 返回依赖声明行开头的配置名称，没有时返回空字符串。
+func declarationScope(line string) string {
+	if m := declarationScopeRegex.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	return ""
 }
 
 // parseSourceMappedPlugin 解析带位置信息的插件.
@@ -251,43 +402,24 @@ func (sap *SourceAwareParser) parseSourceMappedPlugin(
 ) error {
 	trimmedLine := strings.TrimSpace(line)
 
-	// 使用插件解析器的正则表达式。
-	pluginRegex := regexp.MustCompile(`id\s*\(?['"](.*?)['"](\))?(\s+version\s*['"](.*?)['"])?`)
-
-	if matches := pluginRegex.FindStringSubmatch(trimmedLine); len(matches) > 1 {
+	if matches := sourceMappedPluginRegex.FindStringSubmatch(trimmedLine); len(matches) > 1 {
 		// 查找插件声明在行中的位置。
 		pluginStart := strings.Index(line, matches[0])
 		if pluginStart == -1 {
 			return fmt.Errorf("plugin declaration not found in line")
 		}
-
-		plugin := &model.Plugin{
-			ID:    matches[1],
-			Apply: true,
+		if !sap.inContext(lineStart+pluginStart, contextPlugins) {
+			return fmt.Errorf("plugin declaration outside plugins block")
 		}
 
-		// 检查是否有版本信息。
-		if len(matches) > 4 && matches[4] != "" {
-			plugin.Version = matches[4]
+		plugin := &model.Plugin{
+			ID:      matches[1],
+			Version: matches[4],
+			Apply:   matches[6] != "false",
 		}
 
 		// 创建源码位置信息。
-		sourceRange := model.SourceRange{
-			Start: model.SourcePosition{
-				Line:     lineNumber,
-				Column:   pluginStart + 1,
-				StartPos: lineStart + pluginStart,
-				EndPos:   lineStart + pluginStart + len(matches[0]),
-				Length:   len(matches[0]),
-			},
-			End: model.SourcePosition{
-				Line:     lineNumber,
-				Column:   pluginStart + len(matches[0]),
-				StartPos: lineStart + pluginStart + len(matches[0]),
-				EndPos:   lineStart + pluginStart + len(matches[0]),
-				Length:   0,
-			},
-		}
+		sourceRange := sap.index.rangeOf(lineStart+pluginStart, lineStart+pluginStart+len(matches[0]))
 
 		sourceMappedPlugin := &model.SourceMappedPlugin{
 			Plugin:      plugin,
@@ -316,12 +448,14 @@ func (sap *SourceAwareParser) parseSourceMappedRepository(
 		"google()":       "google",
 		"jcenter()":      "jcenter",
 		"mavenLocal()":   "mavenLocal",
+
+		"gradlePluginPortal()": "gradlePluginPortal",
 	}
 
 	for pattern, name := range repoPatterns {
 		if strings.Contains(trimmedLine, pattern) {
 			repoStart := strings.Index(line, pattern)
-			if repoStart == -1 {
+			if repoStart == -1 || !sap.inContext(lineStart+repoStart, contextRepositories) {
 				continue
 			}
 
@@ -331,22 +465,7 @@ func (sap *SourceAwareParser) parseSourceMappedRepository(
 			}
 
 			// 创建源码位置信息。
-			sourceRange := model.SourceRange{
-				Start: model.SourcePosition{
-					Line:     lineNumber,
-					Column:   repoStart + 1,
-					StartPos: lineStart + repoStart,
-					EndPos:   lineStart + repoStart + len(pattern),
-					Length:   len(pattern),
-				},
-				End: model.SourcePosition{
-					Line:     lineNumber,
-					Column:   repoStart + len(pattern),
-					StartPos: lineStart + repoStart + len(pattern),
-					EndPos:   lineStart + repoStart + len(pattern),
-					Length:   0,
-				},
-			}
+			sourceRange := sap.index.rangeOf(lineStart+repoStart, lineStart+repoStart+len(pattern))
 
 			sourceMappedRepo := &model.SourceMappedRepository{
 				Repository:  repo,
@@ -361,3 +480,104 @@ func (sap *SourceAwareParser) parseSourceMappedRepository(
 
 	return fmt.Errorf("not a repository")
 }
+
+// parseSourceMappedURLRepository 解析带位置信息的URL仓库.
+// 单行的 maven { url '...' } 会整体映射，多行块只映射url语句。
+func (sap *SourceAwareParser) parseSourceMappedURLRepository(
+	line string,
+	lineNumber, lineStart int,
+	project *model.SourceMappedProject,
+) error {
+	urlMatch := repoURLRegex.FindStringSubmatch(line)
+	if len(urlMatch) < 2 {
+		return fmt.Errorf("not a url repository")
+	}
+
+	rawText := urlMatch[0]
+	if inline := inlineMavenRepoRegex.FindString(line); inline != "" && strings.Contains(inline, urlMatch[1]) {
+		rawText = inline
+	}
+
+	repoStart := strings.Index(line, rawText)
+	if repoStart == -1 {
+		return fmt.Errorf("repository declaration not found in line")
+	}
+	if !sap.inContext(lineStart+strings.Index(line, urlMatch[0]), contextRepositories) {
+		return fmt.Errorf("url declaration outside repositories block")
+	}
+
+	url := urlMatch[1]
+	name := "custom-maven"
+	if parts := strings.Split(url, "/"); len(parts) > 2 && parts[2] != "" {
+		name = parts[2]
+	}
+
+	repo := &model.Repository{
+		Name: name,
+		URL:  url,
+		Type: "maven",
+	}
+
+	sourceRange := sap.index.rangeOf(lineStart+repoStart, lineStart+repoStart+len(rawText))
+
+	project.SourceMappedRepositories = append(project.SourceMappedRepositories, &model.SourceMappedRepository{
+		Repository:  repo,
+		SourceRange: sourceRange,
+		RawText:     rawText,
+	})
+	return nil
+}
+
+// parseSourceMappedBlocks 解析带位置信息的脚本块和任务.
+func (sap *SourceAwareParser) parseSourceMappedBlocks(content string, project *model.SourceMappedProject) {
+	blocks := sap.blocks
+	index := sap.index
+
+	for _, block := range blocks {
+		if block.name == "" {
+			continue
+		}
+
+		end := block.end(len(content))
+		bodyEnd := end
+		if block.closePos != -1 {
+			bodyEnd = block.closePos
+		}
+
+		project.SourceMappedBlocks = append(project.SourceMappedBlocks, &model.SourceMappedBlock{
+			Name:        block.name,
+			Path:        block.path(),
+			Header:      block.header,
+			Depth:       block.depth,
+			SourceRange: index.rangeOf(block.headerStart, end),
+			BodyRange:   index.rangeOf(block.openPos+1, bodyEnd),
+		})
+	}
+
+	project.SourceMappedTasks = append(project.SourceMappedTasks, scanTasks(content, blocks, index)...)
+}
+
+// parseSourceMappedConstraints 解析带位置信息的依赖约束，约束对象与 Project.Constraints 中的相同。
+func (sap *SourceAwareParser) parseSourceMappedConstraints(content string, project *model.SourceMappedProject) {
+	spans := scanConstraints(content, sap.masked, sap.blocks)
+	if len(spans) == 0 {
+		return
+	}
+
+	project.Constraints = make([]*model.DependencyConstraint, 0, len(spans))
+	for _, span := range spans {
+		mapped := &model.SourceMappedConstraint{
+			DependencyConstraint: span.constraint,
+			SourceRange:          sap.index.rangeOf(span.start, span.end),
+			RawText:              span.constraint.Raw,
+		}
+		if span.coordStart != -1 {
+			mapped.CoordinateRange = sap.index.rangeOf(span.coordStart, span.coordEnd)
+		}
+		if span.versionStart != -1 {
+			mapped.VersionRange = sap.index.rangeOf(span.versionStart, span.versionEnd)
+		}
+		project.Constraints = append(project.Constraints, span.constraint)
+		project.SourceMappedConstraints = append(project.SourceMappedConstraints, mapped)
+	}
+}
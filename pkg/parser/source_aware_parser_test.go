@@ -331,3 +331,380 @@ func TestSourceAwareParser_PositionAccuracy(t *testing.T) {
 		}
 	}
 }
+
+func TestSourceAwareParser_ParseSourceMappedURLRepositories(t *testing.T) {
+	content := `repositories {
+    maven { url 'https://jitpack.io' }
+    maven {
+        url = uri("https://repo.spring.io/milestone")
+    }
+}
+`
+	result, err := NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+
+	repos := result.SourceMappedProject.SourceMappedRepositories
+	if len(repos) != 2 {
+		t.Fatalf("Expected 2 repositories, got %d", len(repos))
+	}
+
+	if repos[0].URL != "https://jitpack.io" || repos[0].RawText != "maven { url 'https://jitpack.io' }" {
+		t.Errorf("Unexpected inline repository: url=%s raw=%s", repos[0].URL, repos[0].RawText)
+	}
+
+	if repos[1].URL != "https://repo.spring.io/milestone" || repos[1].SourceRange.Start.Line != 4 {
+		t.Errorf("Unexpected block repository: url=%s line=%d", repos[1].URL, repos[1].SourceRange.Start.Line)
+	}
+
+	// URL不应被识别为依赖或属性。
+	if len(result.SourceMappedProject.SourceMappedDependencies) != 0 {
+		t.Errorf("URLs should not be mapped as dependencies, got %d", len(result.SourceMappedProject.SourceMappedDependencies))
+	}
+	if len(result.SourceMappedProject.SourceMappedProperties) != 0 {
+		t.Errorf("URLs should not be mapped as properties, got %d", len(result.SourceMappedProject.SourceMappedProperties))
+	}
+}
+
+func TestSourceAwareParser_ParseSourceMappedBlocksAndTasks(t *testing.T) {
+	content := `android {
+    defaultConfig {
+        minSdk 21
+    }
+}
+
+task hello(type: Copy) {
+    group = 'custom'
+    description = 'Copies things'
+    dependsOn 'clean', tasks.compileJava
+    doLast {
+        group = 'ignored'
+    }
+}
+
+task cleanAll(type: Delete)
+
+tasks.register("integrationTest", Test) {
+    description = "Runs integration tests"
+}
+`
+	result, err := NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+	project := result.SourceMappedProject
+
+	defaultConfigs := project.FindBlocksByPath("android.defaultConfig")
+	if len(defaultConfigs) != 1 {
+		t.Fatalf("Expected android.defaultConfig block, got %d", len(defaultConfigs))
+	}
+	block := defaultConfigs[0]
+	if block.SourceRange.Start.Line != 2 || block.SourceRange.End.Line != 4 || block.Depth != 1 {
+		t.Errorf("Unexpected defaultConfig range: %s depth=%d", block.SourceRange, block.Depth)
+	}
+	if project.GetTextRange(block.SourceRange) != "defaultConfig {\n        minSdk 21\n    }" {
+		t.Errorf("Unexpected block text: %q", project.GetTextRange(block.SourceRange))
+	}
+
+	if found := project.FindBlockByPosition(3, 10); found == nil || found.Path != "android.defaultConfig" {
+		t.Errorf("FindBlockByPosition should return innermost block, got %+v", found)
+	}
+
+	if len(project.SourceMappedTasks) != 3 {
+		t.Fatalf("Expected 3 tasks, got %d", len(project.SourceMappedTasks))
+	}
+
+	hello := project.FindTaskByName("hello")
+	if hello == nil {
+		t.Fatal("Should find task hello")
+	}
+	if hello.Type != "Copy" || hello.Group != "custom" || hello.Description != "Copies things" {
+		t.Errorf("Unexpected task hello: %+v", hello.Task)
+	}
+	if len(hello.DependsOn) != 2 || hello.DependsOn[0] != "clean" || hello.DependsOn[1] != "compileJava" {
+		t.Errorf("Unexpected dependsOn: %v", hello.DependsOn)
+	}
+	if hello.SourceRange.Start.Line != 7 || hello.SourceRange.End.Line != 14 {
+		t.Errorf("Unexpected task range: %s", hello.SourceRange)
+	}
+
+	cleanAll := project.FindTaskByName("cleanAll")
+	if cleanAll == nil || cleanAll.Type != "Delete" || cleanAll.RawText != "task cleanAll(type: Delete)" {
+		t.Errorf("Unexpected task cleanAll: %+v", cleanAll)
+	}
+
+	integrationTest := project.FindTaskByName("integrationTest")
+	if integrationTest == nil || integrationTest.Type != "Test" || integrationTest.Description != "Runs integration tests" {
+		t.Errorf("Unexpected task integrationTest: %+v", integrationTest)
+	}
+}
+
+func TestSourceAwareParser_BlockAwareMapping(t *testing.T) {
+	content := `plugins {
+    id 'com.android.application'
+}
+
+android {
+    defaultConfig {
+        applicationId "com.example.app"
+        versionName = "1.0"
+    }
+}
+
+dependencies {
+    implementation('org.hibernate:hibernate-core:5.6.0') {
+        exclude group: 'org.jboss', module: 'jandex'
+        because 'see: issue:123'
+    }
+    implementation group: 'org.foo', name: 'bar',
+        version: '1.2.3'
+    testImplementation 'junit:junit:4.13.2'
+}
+
+publishing {
+    publications {
+        maven(MavenPublication) {
+            artifactId = 'demo'
+            pom {
+                url = 'https://example.com'
+                scm { connection = 'scm:git:git://example.com/demo.git' }
+            }
+        }
+    }
+    repositories {
+        maven { url 'https://repo.example.com/releases' }
+    }
+}
+`
+	result, err := NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+	project := result.SourceMappedProject
+
+	// applicationId 不应被识别为插件。
+	if len(project.SourceMappedPlugins) != 1 || project.SourceMappedPlugins[0].ID != "com.android.application" {
+		t.Errorf("Expected only the plugins-block plugin, got %d plugins", len(project.SourceMappedPlugins))
+	}
+
+	// 闭包中的内容和pom中的字符串不应被识别为依赖。
+	if len(project.SourceMappedDependencies) != 3 {
+		for _, dep := range project.SourceMappedDependencies {
+			t.Logf("dependency: %s (line %d)", dep.RawText, dep.SourceRange.Start.Line)
+		}
+		t.Fatalf("Expected 3 dependencies, got %d", len(project.SourceMappedDependencies))
+	}
+
+	mapDep := project.SourceMappedDependencies[1]
+	if mapDep.Group != "org.foo" || mapDep.Name != "bar" || mapDep.Version != "1.2.3" {
+		t.Errorf("Unexpected map dependency: %+v", mapDep.Dependency)
+	}
+	if mapDep.SourceRange.Start.Line != 17 || mapDep.SourceRange.End.Line != 18 {
+		t.Errorf("Map dependency should span lines 17-18, got %s", mapDep.SourceRange)
+	}
+	if project.GetTextRange(mapDep.SourceRange) != mapDep.RawText {
+		t.Errorf("Raw text should match source range, got %q", mapDep.RawText)
+	}
+
+	// 发布仓库不是依赖解析仓库。
+	if len(project.SourceMappedRepositories) != 0 {
+		t.Errorf("Publishing repositories should not be mapped, got %d", len(project.SourceMappedRepositories))
+	}
+
+	// 插件和依赖块中的内容不应被识别为属性，但其他块中的赋值仍然保留。
+	if project.FindPropertyByKey("versionName") == nil {
+		t.Error("Assignments in other blocks should still be mapped as properties")
+	}
+}
+
+func TestSourceAwareParser_ClassifierAndExtension(t *testing.T) {
+	content := `dependencies {
+    implementation 'net.sf.json-lib:json-lib:2.4:jdk15'
+    implementation 'org.foo:bar:1.0@zip'
+    implementation group: 'org.baz', name: 'qux', version: '3.0', classifier: 'tests', ext: 'jar'
+}
+`
+	result, err := NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+	deps := result.SourceMappedProject.SourceMappedDependencies
+	if len(deps) != 3 {
+		t.Fatalf("Expected 3 dependencies, got %d", len(deps))
+	}
+
+	if deps[0].Version != "2.4" || deps[0].Classifier != "jdk15" {
+		t.Errorf("Expected version 2.4 and classifier jdk15, got %q and %q", deps[0].Version, deps[0].Classifier)
+	}
+	if deps[1].Version != "1.0" || deps[1].Extension != "zip" {
+		t.Errorf("Expected version 1.0 and extension zip, got %q and %q", deps[1].Version, deps[1].Extension)
+	}
+	if deps[2].Version != "3.0" || deps[2].Classifier != "tests" || deps[2].Extension != "jar" {
+		t.Errorf("Unexpected map dependency: %+v", deps[2].Dependency)
+	}
+	if deps[0].RawText != "'net.sf.json-lib:json-lib:2.4:jdk15'" {
+		t.Errorf("Unexpected raw text %q", deps[0].RawText)
+	}
+}
+
+func TestSourceAwareParser_MultipleStatements(t *testing.T) {
+	content := `dependencies {
+    implementation 'a:b:1'; testImplementation "c:d:2"
+    implementation "com.example:lib:" +
+        "2.0"
+    api 'e:f:3'
+}
+`
+	result, err := NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+	deps := result.SourceMappedProject.SourceMappedDependencies
+	if len(deps) != 4 {
+		t.Fatalf("Expected 4 dependencies, got %d", len(deps))
+	}
+
+	tests := []struct {
+		scope, name, rawText string
+		line, column         int
+	}{
+		{"implementation", "b", "'a:b:1'", 2, 20},
+		{"testImplementation", "d", `"c:d:2"`, 2, 48},
+		{"implementation", "lib", "\"com.example:lib:\" +\n        \"2.0\"", 3, 20},
+		{"api", "f", "'e:f:3'", 5, 9},
+	}
+	for i, tt := range tests {
+		dep := deps[i]
+		if dep.Scope != tt.scope || dep.Name != tt.name || dep.RawText != tt.rawText {
+			t.Errorf("Dependency %d = %s %s %q, want %s %s %q", i, dep.Scope, dep.Name, dep.RawText, tt.scope, tt.name, tt.rawText)
+		}
+		if dep.SourceRange.Start.Line != tt.line || dep.SourceRange.Start.Column != tt.column {
+			t.Errorf("Dependency %d starts at %d:%d, want %d:%d", i, dep.SourceRange.Start.Line, dep.SourceRange.Start.Column, tt.line, tt.column)
+		}
+		if content[dep.SourceRange.Start.StartPos:dep.SourceRange.Start.EndPos] != dep.RawText {
+			t.Errorf("Dependency %d range does not cover its raw text", i)
+		}
+	}
+	if deps[2].Version != "2.0" || deps[2].SourceRange.End.Line != 4 {
+		t.Errorf("Unexpected wrapped dependency: version %q, end line %d", deps[2].Version, deps[2].SourceRange.End.Line)
+	}
+}
+
+func TestSourceAwareParser_ProjectDependencies(t *testing.T) {
+	content := `dependencies {
+    implementation project(path: ':app', configuration: 'shadow')
+    testImplementation project(':testing')
+}
+`
+	result, err := NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+	deps := result.SourceMappedProject.SourceMappedDependencies
+	if len(deps) != 2 {
+		t.Fatalf("Expected 2 dependencies, got %d", len(deps))
+	}
+
+	if deps[0].Project == nil || deps[0].Project.Path != ":app" || deps[0].Project.Configuration != "shadow" || deps[0].Group != "" {
+		t.Errorf("Unexpected project dependency: %+v", deps[0].Dependency)
+	}
+	if deps[0].RawText != "project(path: ':app', configuration: 'shadow')" {
+		t.Errorf("Unexpected raw text %q", deps[0].RawText)
+	}
+	if deps[1].Name != "testing" || deps[1].Project == nil || deps[1].Project.Path != ":testing" {
+		t.Errorf("Unexpected project dependency: %+v", deps[1].Dependency)
+	}
+}
+
+func TestSourceAwareParser_VariantScopes(t *testing.T) {
+	content := `dependencies {
+    freeDebugImplementation 'com.example:free-debug:1.0'
+    implementation 'com.example:core:1.0'
+}
+`
+	result, err := NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+	deps := result.SourceMappedProject.SourceMappedDependencies
+	if len(deps) != 2 {
+		t.Fatalf("Expected 2 dependencies, got %d", len(deps))
+	}
+	if deps[0].Scope != "freeDebugImplementation" || deps[0].BaseScope != "implementation" || deps[0].Variant != "freeDebug" {
+		t.Errorf("Unexpected variant dependency: %+v", deps[0].Dependency)
+	}
+	if deps[1].BaseScope != "" || deps[1].Variant != "" {
+		t.Errorf("Expected no variant for %+v", deps[1].Dependency)
+	}
+}
+
+func TestSourceAwareParser_CRLF(t *testing.T) {
+	content := strings.ReplaceAll(`// Build file
+group = 'com.example'
+
+dependencies {
+    implementation 'org.slf4j:slf4j-api:1.7.36' // logging
+}
+`, "\n", "\r\n")
+
+	result, err := NewSourceAwareParser().WithRetainComments(true).ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+	project := result.SourceMappedProject
+	if project.LineEnding != model.LineEndingCRLF {
+		t.Errorf("Expected CRLF line ending, got %q", project.LineEnding)
+	}
+
+	prop := project.FindPropertyByKey("group")
+	if prop == nil || prop.RawText != "group = 'com.example'" || prop.Value != "com.example" {
+		t.Fatalf("Unexpected property %+v", prop)
+	}
+	if got := content[prop.SourceRange.Start.StartPos:prop.SourceRange.End.StartPos]; got != "group = 'com.example'" {
+		t.Errorf("Property range should not include the carriage return, got %q", got)
+	}
+
+	dep := project.SourceMappedDependencies[0]
+	if dep.SourceRange.Start.Line != 5 || dep.SourceRange.Start.Column != 20 ||
+		content[dep.SourceRange.Start.StartPos:dep.SourceRange.End.StartPos] != dep.RawText {
+		t.Errorf("Unexpected dependency range %+v", dep.SourceRange)
+	}
+	for _, comment := range project.Comments {
+		if strings.HasSuffix(comment.RawText, "\r") {
+			t.Errorf("Comment should not include the carriage return: %q", comment.RawText)
+		}
+	}
+}
+
+func TestSourceAwareParser_MultiByteColumns(t *testing.T) {
+	content := `description = '构建脚本'
+
+dependencies {
+    /* 日志 */ implementation 'org.slf4j:slf4j-api:1.7.36'
+}
+`
+	result, err := NewSourceAwareParser().ParseWithSourceMapping(content)
+	if err != nil {
+		t.Fatalf("ParseWithSourceMapping failed: %v", err)
+	}
+	project := result.SourceMappedProject
+
+	dep := project.SourceMappedDependencies[0]
+	if got := content[dep.SourceRange.Start.StartPos:dep.SourceRange.End.StartPos]; got != dep.RawText {
+		t.Errorf("Byte offsets should slice the raw text, got %q", got)
+	}
+	// "    /* 日志 */ implementation " 共28个字符，坐标从第29列开始。
+	if dep.SourceRange.Start.Column != 29 || dep.SourceRange.End.Column != 29+len(dep.RawText)-1 {
+		t.Errorf("Expected rune columns 29-%d, got %d-%d",
+			29+len(dep.RawText)-1, dep.SourceRange.Start.Column, dep.SourceRange.End.Column)
+	}
+	if found := project.FindDependencyByPosition(4, 29); found != dep {
+		t.Errorf("FindDependencyByPosition() should use rune columns, got %+v", found)
+	}
+
+	prop := project.FindPropertyByKey("description")
+	if prop == nil || prop.SourceRange.End.Column != 20 || prop.SourceRange.End.StartPos != len("description = '构建脚本'") {
+		t.Errorf("Unexpected property range %+v", prop)
+	}
+}
@@ -0,0 +1,213 @@
+// Package parser 提供源码集配置的提取功能。
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/dependency"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// blockSourceSets 是声明源码集的块名称。
+const blockSourceSets = "sourceSets"
+
+var (
+	// 匹配源码目录声明，例如 srcDirs = ['src']、java.srcDir 'gen'、setSrcDirs(listOf("src"))、manifest.srcFile 'AndroidManifest.xml'。
+	// 第1组是访问路径前缀，第2组是方法名，第3组是赋值运算符，第4组是参数。
+	sourceDirStatementRegex = regexp.MustCompile(`(?s)^(.*?)\b(srcDirs|srcDir|setSrcDirs|srcFile)\b\s*(\+=|=)?\s*(.*)$`)
+
+	// 匹配Kotlin DSL的委托声明，例如 val integrationTest by creating。
+	sourceSetDelegateRegex = regexp.MustCompile(`^val\s+(\w+)\s+by\s+(?:creating|getting|registering)\b`)
+
+	// 匹配按名称访问容器元素的写法，例如 create("it")、getByName("main")、named('test')。
+	namedAccessorRegex = regexp.MustCompile(`\.?\s*\b(?:create|getByName|named|register|maybeCreate|getAt)\s*\(\s*['"]([^'"]+)['"][^)]*\)`)
+
+	// 匹配下标访问容器元素的写法，例如 sourceSets["main"]。
+	indexAccessorRegex = regexp.MustCompile(`\s*\[\s*['"]([^'"]+)['"]\s*\]`)
+
+	// 匹配开头的点号分隔标识符路径。
+	identifierPathRegex = regexp.MustCompile(`^[A-Za-z_]\w*(?:\s*\.\s*[A-Za-z_]\w*)*`)
+)
+
+// sourceSetEvent 是按位置排序的一条源码集声明：声明源码集的块或一条源码目录语句。
+type sourceSetEvent struct {
+	pos      int
+	segments []string
+	method   string
+	operator string
+	args     string
+}
+
+// extractSourceSets 提取 sourceSets { } 和 android { sourceSets { } } 中声明的源码集，
+// 包括块写法、main.java.srcDirs 形式的点号写法和Kotlin DSL的 create("x")、getByName("x")、val x by creating 写法。
+// 没有声明源码集时返回nil。
+func extractSourceSets(content string) []*model.SourceSet {
+	code := maskComments(content)
+	if !strings.Contains(code, blockSourceSets) {
+		return nil
+	}
+	blocks := scanBlocks(code)
+
+	// 每个块在访问路径中对应的名称段，包含所有祖先块。
+	paths := make(map[*blockSpan][]string, len(blocks))
+	events := make([]*sourceSetEvent, 0)
+	for _, block := range blocks {
+		var segments []string
+		if block.parent != nil {
+			segments = append(segments, paths[block.parent]...)
+		}
+		segments = append(segments, accessPathSegments(block.header)...)
+		paths[block] = segments
+		events = append(events, &sourceSetEvent{pos: block.openPos, segments: segments})
+	}
+
+	collect := func(block *blockSpan) {
+		var prefix []string
+		if block != nil {
+			prefix = paths[block]
+		}
+		for _, statement := range blockStatements(code, blocks, block) {
+			// 没有配置块的委托声明，例如 val benchmark by creating。
+			if m := sourceSetDelegateRegex.FindStringSubmatch(statement.Text); m != nil {
+				events = append(events, &sourceSetEvent{pos: statement.Start, segments: append(append([]string{}, prefix...), m[1])})
+				continue
+			}
+			m := sourceDirStatementRegex.FindStringSubmatch(statement.Text)
+			if m == nil {
+				continue
+			}
+			accessPath := strings.TrimSpace(m[1])
+			if accessPath != "" && !strings.HasSuffix(accessPath, ".") {
+				continue
+			}
+			segments := accessPathSegments(strings.TrimSuffix(accessPath, "."))
+			if accessPath != "" && segments == nil {
+				continue
+			}
+			events = append(events, &sourceSetEvent{
+				pos:      statement.Start,
+				segments: append(append([]string{}, prefix...), segments...),
+				method:   m[2],
+				operator: m[3],
+				args:     m[4],
+			})
+		}
+	}
+	collect(nil)
+	for _, block := range blocks {
+		// 只有路径或内容中出现 sourceSets 的块才可能包含源码目录语句。
+		if lastIndexOf(paths[block], blockSourceSets) >= 0 ||
+			strings.Contains(code[block.openPos:block.end(len(code))], blockSourceSets) {
+			collect(block)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].pos < events[j].pos })
+
+	var sourceSets []*model.SourceSet
+	byName := make(map[string]*model.SourceSet)
+	for _, event := range events {
+		idx := lastIndexOf(event.segments, blockSourceSets)
+		if idx < 0 || idx+1 >= len(event.segments) {
+			continue
+		}
+		name := event.segments[idx+1]
+		set := byName[name]
+		if set == nil {
+			set = &model.SourceSet{Name: name}
+			byName[name] = set
+			sourceSets = append(sourceSets, set)
+		}
+		if lastIndexOf(event.segments[:idx], "android") >= 0 {
+			set.Android = true
+		}
+		if event.method == "" || idx+2 >= len(event.segments) {
+			continue
+		}
+		applySourceDirStatement(set, event.segments[idx+2], event)
+	}
+	return sourceSets
+}
+
+// applySourceDirStatement 把一条源码目录语句应用到源码集。
+// srcDirs = [...]、setSrcDirs(...) 替换已声明的目录，srcDir、srcDirs(...) 和 srcDirs += [...] 追加目录。
+func applySourceDirStatement(set *model.SourceSet, kind string, event *sourceSetEvent) {
+	values := quotedValues(event.args)
+	if event.method == "srcFile" {
+		if kind == "manifest" && len(values) > 0 {
+			set.Manifest = values[0]
+		}
+		return
+	}
+
+	if set.Directories == nil {
+		set.Directories = make(map[string][]string)
+	}
+	if event.method == "setSrcDirs" || event.operator == "=" {
+		set.Directories[kind] = values
+		return
+	}
+	set.Directories[kind] = append(set.Directories[kind], values...)
+}
+
+// accessPathSegments 把块头部或访问路径转换为名称段，例如 sourceSets.getByName("main").java 转换为 [sourceSets main java]，
+// val integrationTest by creating 转换为 [integrationTest]。不是访问路径时返回nil。
+func accessPathSegments(text string) []string {
+	if m := sourceSetDelegateRegex.FindStringSubmatch(text); m != nil {
+		return []string{m[1]}
+	}
+	text = namedAccessorRegex.ReplaceAllString(text, ".$1")
+	text = indexAccessorRegex.ReplaceAllString(text, ".$1")
+	text = strings.TrimPrefix(strings.TrimSpace(text), ".")
+
+	path := identifierPathRegex.FindString(text)
+	if path == "" {
+		return nil
+	}
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		segments[i] = strings.TrimSpace(segment)
+	}
+	return segments
+}
+
+// blockStatements 返回块中不属于子块的语句，block为nil时返回顶层语句。语句位置是在content中的偏移量。
+func blockStatements(code string, blocks []*blockSpan, block *blockSpan) []dependency.Statement {
+	bodyStart, bodyEnd := 0, len(code)
+	if block != nil {
+		bodyStart, bodyEnd = block.openPos+1, block.end(len(code))
+		if block.closePos != -1 {
+			bodyEnd = block.closePos
+		}
+	}
+	body := []byte(code[bodyStart:bodyEnd])
+	for _, child := range blocks {
+		if child.parent != block {
+			continue
+		}
+		// 子块的头部属于子块，同样去掉，避免 java { srcDir 'x' } 的头部被当作语句。
+		for i := child.headerStart; i < child.end(len(code)) && i-bodyStart < len(body); i++ {
+			if i >= bodyStart && body[i-bodyStart] != '\n' {
+				body[i-bodyStart] = ' '
+			}
+		}
+	}
+
+	statements := dependency.SplitStatements(string(body))
+	for i := range statements {
+		statements[i].Start += bodyStart
+		statements[i].End += bodyStart
+	}
+	return statements
+}
+
+// lastIndexOf 返回字符串在切片中最后一次出现的位置，不存在时返回-1。
+func lastIndexOf(values []string, target string) int {
+	for i := len(values) - 1; i >= 0; i-- {
+		if values[i] == target {
+			return i
+		}
+	}
+	return -1
+}
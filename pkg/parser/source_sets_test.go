@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestExtractSourceSets_Groovy(t *testing.T) {
+	content := `
+sourceSets {
+    main {
+        java {
+            srcDirs = ['src/main/java', 'build/generated/java']
+        }
+        resources.srcDir 'src/main/config'
+    }
+    // legacy { java.srcDir 'ignored' }
+    integrationTest {
+        java.srcDir file('src/it/java')
+        compileClasspath += sourceSets.main.output
+    }
+}
+
+sourceSets.main.java.srcDirs += 'src/extra/java'
+
+test {
+    useJUnitPlatform()
+}
+`
+	sets := extractSourceSets(content)
+	if len(sets) != 2 {
+		t.Fatalf("Expected 2 source sets, got %d", len(sets))
+	}
+	main := sets[0]
+	if main.Name != "main" || main.Android {
+		t.Errorf("Unexpected main source set: %+v", main)
+	}
+	if got, want := main.Dirs(model.SourceDirJava), []string{"src/main/java", "build/generated/java", "src/extra/java"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("main java dirs = %v, want %v", got, want)
+	}
+	if got, want := main.Dirs(model.SourceDirResources), []string{"src/main/config"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("main resources dirs = %v, want %v", got, want)
+	}
+	if sets[1].Name != "integrationTest" || !reflect.DeepEqual(sets[1].Dirs(model.SourceDirJava), []string{"src/it/java"}) {
+		t.Errorf("Unexpected integrationTest source set: %+v", sets[1])
+	}
+}
+
+func TestExtractSourceSets_KotlinDSL(t *testing.T) {
+	content := `
+sourceSets {
+    main {
+        java.setSrcDirs(listOf("src"))
+        kotlin.srcDirs("src/kotlin", "gen/kotlin")
+    }
+    create("functionalTest") {
+        java.srcDir("src/functionalTest/java")
+    }
+    val benchmark by creating
+}
+
+sourceSets["test"].resources.srcDir("fixtures")
+sourceSets.getByName("main").java.srcDir("gen/java")
+`
+	sets := extractSourceSets(content)
+	names := make([]string, 0, len(sets))
+	for _, set := range sets {
+		names = append(names, set.Name)
+	}
+	if want := []string{"main", "functionalTest", "benchmark", "test"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("source set names = %v, want %v", names, want)
+	}
+	if got, want := sets[0].Dirs(model.SourceDirJava), []string{"src", "gen/java"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("main java dirs = %v, want %v", got, want)
+	}
+	if got, want := sets[0].Dirs(model.SourceDirKotlin), []string{"src/kotlin", "gen/kotlin"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("main kotlin dirs = %v, want %v", got, want)
+	}
+	if got := sets[1].Dirs(model.SourceDirJava); !reflect.DeepEqual(got, []string{"src/functionalTest/java"}) {
+		t.Errorf("functionalTest java dirs = %v", got)
+	}
+	if got := sets[3].Dirs(model.SourceDirResources); !reflect.DeepEqual(got, []string{"fixtures"}) {
+		t.Errorf("test resources dirs = %v", got)
+	}
+}
+
+func TestExtractSourceSets_Android(t *testing.T) {
+	content := `
+android {
+    sourceSets {
+        main {
+            manifest.srcFile 'AndroidManifest.xml'
+            java.srcDirs = ['src']
+            res.srcDirs = ['res']
+            assets.srcDirs = ['assets']
+        }
+        androidTest.setRoot('tests')
+    }
+}
+`
+	result, err := NewParser().Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	sets := result.Project.SourceSets
+	if len(sets) != 1 {
+		t.Fatalf("Expected 1 source set, got %d", len(sets))
+	}
+	main := sets[0]
+	if !main.Android || main.Manifest != "AndroidManifest.xml" {
+		t.Errorf("Unexpected android main source set: %+v", main)
+	}
+	for kind, want := range map[string][]string{
+		model.SourceDirJava:   {"src"},
+		model.SourceDirRes:    {"res"},
+		model.SourceDirAssets: {"assets"},
+	} {
+		if got := main.Dirs(kind); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s dirs = %v, want %v", kind, got, want)
+		}
+	}
+	if result.Project.TestConfig != nil {
+		t.Error("Source sets should not be parsed as test configuration")
+	}
+}
+
+func TestExtractSourceSets_None(t *testing.T) {
+	if sets := extractSourceSets("plugins { id 'java' }"); sets != nil {
+		t.Errorf("Expected nil without sourceSets, got %v", sets)
+	}
+}
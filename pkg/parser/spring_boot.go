@@ -0,0 +1,122 @@
+// Package parser 提供Spring Boot相关配置的提取功能。
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配配置bootJar任务的块头部。
+	// 例如: bootJar、tasks.bootJar、tasks.named<BootJar>("bootJar")、tasks.withType<BootJar>()。
+	bootJarBlockHeaderRegex = regexp.MustCompile(`^(?:bootJar|tasks\.bootJar|` +
+		`tasks\.(?:named|getByName)\s*(?:<BootJar>)?\s*\(\s*['"]bootJar['"]\s*(?:,\s*BootJar(?:::class(?:\.java)?)?\s*)?\)|` +
+		`tasks\.withType\s*(?:<BootJar>\s*(?:\(\s*\))?|\(\s*BootJar(?:::class(?:\.java)?)?\s*\))(?:\s*\.configureEach)?)$`)
+
+	// 匹配配置springBoot扩展和application插件的块头部。
+	springBootBlockHeaderRegex  = regexp.MustCompile(`^(?:project\.)?springBoot$`)
+	applicationBlockHeaderRegex = regexp.MustCompile(`^(?:project\.)?application$`)
+
+	// 匹配块中的属性配置，例如 mainClass = 'x'、mainClass.set("x")、archiveClassifier('boot')。
+	springBootPropertyRegex = regexp.MustCompile(
+		`^(mainClass|mainClassName|archiveFileName|archiveName|archiveBaseName|baseName|archiveVersion|archiveClassifier|classifier|enabled)` +
+			`\s*(?:=\s*|\.set\s*\(\s*|\(\s*|\s+)(.+?)\s*\)?$`)
+
+	// 匹配顶层的单行配置，例如 bootJar.enabled = false、mainClassName = 'com.example.App'（application插件，只匹配行首的写法）。
+	bootJarStatementRegex       = regexp.MustCompile(`(?m)^\s*(?:tasks\.)?bootJar\s*\.\s*(enabled|mainClass|archiveFileName|archiveClassifier)\s*(?:=|\.set\s*\()\s*(.+?)\s*\)?\s*$`)
+	applicationMainClassRegex   = regexp.MustCompile(`(?m)^mainClassName\s*=\s*(.+?)\s*$`)
+	springBootMainClassOneLiner = regexp.MustCompile(`(?m)^\s*springBoot\s*\.\s*mainClass(?:Name)?\s*(?:=|\.set\s*\()\s*(.+?)\s*\)?\s*$`)
+)
+
+// extractSpringBootConfig 从springBoot扩展、bootJar任务和application配置中提取Spring Boot相关配置。
+// 没有找到任何配置时返回nil。
+func extractSpringBootConfig(content string) *model.SpringBootConfig {
+	code := maskComments(content)
+	if !strings.Contains(code, "springBoot") && !strings.Contains(code, "bootJar") && !strings.Contains(code, "mainClass") {
+		return nil
+	}
+
+	// 各来源的主类，按 bootJar、springBoot、application 的优先级选择。
+	mainClasses := make(map[string]string)
+	config := &model.SpringBootConfig{}
+	found := false
+	apply := func(source, key, value string) {
+		found = true
+		value = literalValue(value)
+		switch key {
+		case "mainClass", "mainClassName":
+			mainClasses[source] = value
+		case "archiveFileName", "archiveName":
+			config.ArchiveFileName = value
+		case "archiveBaseName", "baseName":
+			config.ArchiveBaseName = value
+		case "archiveVersion":
+			config.ArchiveVersion = value
+		case "archiveClassifier", "classifier":
+			config.ArchiveClassifier = value
+		case "enabled":
+			config.BootJarDisabled = value == "false"
+		}
+	}
+
+	for _, block := range scanBlocks(code) {
+		if block.closePos < 0 || (block.parent != nil && !isProjectScopeBlock(block.parent)) {
+			continue
+		}
+		var source string
+		switch {
+		case bootJarBlockHeaderRegex.MatchString(block.header):
+			source = model.MainClassSourceBootJar
+		case springBootBlockHeaderRegex.MatchString(block.header):
+			source = model.MainClassSourceSpringBoot
+		case applicationBlockHeaderRegex.MatchString(block.header):
+			source = model.MainClassSourceApplication
+		default:
+			continue
+		}
+		for _, statement := range splitStatements(code[block.openPos+1 : block.closePos]) {
+			m := springBootPropertyRegex.FindStringSubmatch(statement)
+			if m == nil {
+				continue
+			}
+			// springBoot和application块中只有主类属于Spring Boot配置。
+			if source != model.MainClassSourceBootJar && m[1] != "mainClass" && m[1] != "mainClassName" {
+				continue
+			}
+			apply(source, m[1], m[2])
+		}
+	}
+
+	for _, m := range bootJarStatementRegex.FindAllStringSubmatch(code, -1) {
+		apply(model.MainClassSourceBootJar, m[1], m[2])
+	}
+	for _, m := range springBootMainClassOneLiner.FindAllStringSubmatch(code, -1) {
+		apply(model.MainClassSourceSpringBoot, "mainClass", m[1])
+	}
+	for _, m := range applicationMainClassRegex.FindAllStringSubmatch(code, -1) {
+		apply(model.MainClassSourceApplication, "mainClass", m[1])
+	}
+
+	if !found {
+		return nil
+	}
+	for _, source := range []string{model.MainClassSourceBootJar, model.MainClassSourceSpringBoot, model.MainClassSourceApplication} {
+		if mainClass, ok := mainClasses[source]; ok {
+			config.MainClass = mainClass
+			config.MainClassSource = source
+			break
+		}
+	}
+	return config
+}
+
+// literalValue 去掉字面量值的引号，表达式保持原样。
+func literalValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestExtractSpringBootConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *model.SpringBootConfig
+	}{
+		{
+			name:    "no spring boot configuration",
+			content: "plugins { id 'java' }",
+			want:    nil,
+		},
+		{
+			name: "springBoot extension and bootJar naming",
+			content: `
+springBoot {
+    mainClass = 'com.example.Application'
+}
+bootJar {
+    archiveFileName = "service.jar"
+    archiveClassifier = 'boot'
+    manifest { attributes 'Implementation-Title': 'svc' }
+}
+`,
+			want: &model.SpringBootConfig{
+				MainClass:         "com.example.Application",
+				MainClassSource:   model.MainClassSourceSpringBoot,
+				ArchiveFileName:   "service.jar",
+				ArchiveClassifier: "boot",
+			},
+		},
+		{
+			name: "kotlin dsl bootJar overrides springBoot",
+			content: `
+springBoot {
+    mainClass.set("com.example.App")
+}
+tasks.named<BootJar>("bootJar") {
+    mainClass.set("com.example.Launcher")
+    archiveBaseName.set("launcher")
+    archiveVersion.set(project.version.toString())
+}
+`,
+			want: &model.SpringBootConfig{
+				MainClass:       "com.example.Launcher",
+				MainClassSource: model.MainClassSourceBootJar,
+				ArchiveBaseName: "launcher",
+				ArchiveVersion:  "project.version.toString()",
+			},
+		},
+		{
+			name: "legacy properties and disabled bootJar",
+			content: `
+mainClassName = 'com.example.Legacy'
+bootJar {
+    baseName = 'legacy'
+    classifier = 'exec'
+}
+bootJar.enabled = false
+`,
+			want: &model.SpringBootConfig{
+				MainClass:         "com.example.Legacy",
+				MainClassSource:   model.MainClassSourceApplication,
+				ArchiveBaseName:   "legacy",
+				ArchiveClassifier: "exec",
+				BootJarDisabled:   true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractSpringBootConfig(tt.content)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("Expected nil, got %+v", got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.want {
+				t.Errorf("extractSpringBootConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Package parser 提供严格模式下的结构检查。
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/dependency"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// ErrMalformedScript 表示严格模式下发现了括号不配对、字符串或块注释没有结束等结构问题。
+	ErrMalformedScript = errors.New("构建脚本结构错误")
+	// ErrUnparseableDependency 表示严格模式下dependencies块中有无法解析的依赖声明。
+	ErrUnparseableDependency = errors.New("无法解析的依赖声明")
+	// ErrInvalidCoordinate 表示严格模式下dependencies块中的依赖坐标不符合Maven的命名规则。
+	ErrInvalidCoordinate = errors.New("无效的依赖坐标")
+)
+
+// strictErrors 返回严格模式下报告的错误：结构问题包装 ErrMalformedScript，
+// dependencies块中无法解析的字符串坐标或map形式声明包装 ErrUnparseableDependency，
+// 能够解析但不符合命名规则的坐标包装 ErrInvalidCoordinate。
+// 变量、版本目录访问路径和函数调用等表达式无法静态解析，不视为错误。
+func strictErrors(content string) []error {
+	index := newLineIndex(content)
+	var errs []error
+
+	checkSyntax(content, func(_ string, _ model.Severity, start, _ int, message string) {
+		line, _ := index.position(start)
+		errs = append(errs, fmt.Errorf("%w (第%d行): %s", ErrMalformedScript, line, message))
+	})
+
+	code := maskComments(content)
+	blocks := scanBlocks(code)
+	// 已报告为无法解析的声明的范围，同一声明不再报告坐标错误。
+	var unparseable [][2]int
+	for _, block := range blocks {
+		if block.name != contextDependencies {
+			continue
+		}
+		forEachDeclaration(code, blocks, block, func(scope, args string, start, end int) {
+			if !dependency.IsDependencyScope(scope) || !isLiteralDeclaration(args) {
+				return
+			}
+			if dep := dependency.ParseDeclaration(scope + " " + args); dep != nil && dep.Group != "" {
+				return
+			}
+			unparseable = append(unparseable, [2]int{start, end})
+			line, _ := index.position(start)
+			errs = append(errs, fmt.Errorf("%w (第%d行): %s", ErrUnparseableDependency, line,
+				strings.Join(strings.Fields(content[start:end]), " ")))
+		})
+	}
+
+	checkCoordinates(code, blocks, func(_ string, _ model.Severity, start, _ int, message string) {
+		for _, span := range unparseable {
+			if start >= span[0] && start < span[1] {
+				return
+			}
+		}
+		line, _ := index.position(start)
+		errs = append(errs, fmt.Errorf("%w (第%d行): %s", ErrInvalidCoordinate, line, message))
+	})
+
+	return errs
+}
+
+// isLiteralDeclaration 判断声明参数是否为字符串坐标或map形式，可以带调用括号。
+func isLiteralDeclaration(args string) bool {
+	args = strings.TrimLeft(args, "( \t")
+	if strings.HasPrefix(args, `"$`) {
+		// 整个坐标由变量给出。
+		return false
+	}
+	if strings.HasPrefix(args, "'") || strings.HasPrefix(args, `"`) {
+		return true
+	}
+	loc := mapDependencyAttrRegex.FindStringIndex(args)
+	return loc != nil && loc[0] == 0
+}
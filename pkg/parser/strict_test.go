@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGradleParser_Strict(t *testing.T) {
+	content := `dependencies {
+    implementation 'com.google.guava:guava:32.1.2-jre'
+    implementation 'guava'
+    implementation group: 'org.foo'
+    implementation libs.log4j.core
+    implementation "${deps.spring}"
+    implementation platform('org.springframework.boot:spring-boot-dependencies:3.1.0')
+    implementation('com.example:lib:1.0') {
+        exclude group: 'com.google.guava', module: 'guava'
+    }
+}
+
+task hello {
+    doLast {
+        println 'hello'
+}
+`
+
+	lenient, err := NewParser().Parse(content)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(lenient.Errors) != 0 {
+		t.Errorf("Expected no errors in lenient mode, got %v", lenient.Errors)
+	}
+
+	p := NewParser().(*GradleParser).WithStrict(true)
+	result, err := p.Parse(content)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(result.Project.Dependencies) != len(lenient.Project.Dependencies) {
+		t.Errorf("Strict mode extracted %d dependencies, lenient mode %d",
+			len(result.Project.Dependencies), len(lenient.Project.Dependencies))
+	}
+
+	var malformed, unparseable int
+	for _, err := range result.Errors {
+		switch {
+		case errors.Is(err, ErrMalformedScript):
+			malformed++
+		case errors.Is(err, ErrUnparseableDependency):
+			unparseable++
+		default:
+			t.Errorf("Unexpected error %v", err)
+		}
+	}
+	if malformed != 1 {
+		t.Errorf("Expected 1 structural error, got %d: %v", malformed, result.Errors)
+	}
+	if unparseable != 2 {
+		t.Errorf("Expected 2 unparseable dependency errors, got %d: %v", unparseable, result.Errors)
+	}
+}
@@ -0,0 +1,357 @@
+// Package parser 提供任务声明及任务间关系的提取功能。
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/dependency"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配任务声明。
+	// 例如: task hello(type: Copy)、task('hello')、tasks.register('hello', Copy)、tasks.named<Test>("test")。
+	taskKeywordRegex  = regexp.MustCompile(`^task(?:\s+|\s*\(\s*)['"]?([A-Za-z_][\w-]*)['"]?\s*(?:[(,]\s*type\s*:\s*([\w.]+))?`)
+	taskRegisterRegex = regexp.MustCompile(
+		`^tasks\s*\.\s*(register|create|named|getByName)\s*(?:<([\w.]+)>)?\s*\(\s*['"]([^'"]+)['"]\s*(?:,\s*([\w.]+))?`)
+
+	// 匹配任务声明头部中的依赖，例如 task dist(type: Zip, dependsOn: [jar, docs])。
+	taskHeaderDependsOnRegex = regexp.MustCompile(`\bdependsOn\s*:\s*(\[[^\]]*\]|[\w'":.-]+)`)
+
+	// 匹配任务块中的属性赋值。
+	taskAttributeRegex = regexp.MustCompile(`^(group|description)\s*=?\s*\(?\s*['"]([^'"]*)['"]`)
+
+	// 匹配任务块中的任务关系，例如 dependsOn 'jar'、finalizedBy(tasks.named("report"))。
+	taskRelationRegex = regexp.MustCompile(`^(dependsOn|finalizedBy|mustRunAfter|shouldRunAfter)\b\s*(?:=\s*)?(.+?)\s*$`)
+
+	// 匹配顶层对已有任务添加关系的语句，例如 build.dependsOn(dist)、tasks.jar.finalizedBy 'sign'。
+	taskRelationStatementRegex = regexp.MustCompile(
+		`^(?:tasks\s*\.\s*)?([A-Za-z_]\w*)\s*\.\s*(dependsOn|finalizedBy|mustRunAfter|shouldRunAfter)\b\s*(.+?)\s*$`)
+
+	// 匹配任务引用，例如 tasks.named("jar")、tasks.getByName('jar')、project.tasks.jar。
+	taskReferenceCallRegex = regexp.MustCompile(`^(?:project\.)?tasks\s*\.\s*(?:named|getByName|findByName)\s*(?:<[\w.]+>)?\s*\(\s*['"]([^'"]+)['"]`)
+)
+
+// scanTasks 从块结构和单行声明中识别任务，按出现顺序返回带位置信息的任务。
+func scanTasks(content string, blocks []*blockSpan, index *lineIndex) []*model.SourceMappedTask {
+	tasks := make([]*model.SourceMappedTask, 0)
+	taskBlocks := make(map[int]bool)
+
+	// 带配置块的任务声明。
+	for _, block := range blocks {
+		if block.name == "" {
+			continue
+		}
+		if task := parseTaskHeader(block.header); task != nil {
+			applyTaskBody(task, directBlockBody(content, block, blocks))
+			headerLine, _ := index.position(block.headerStart)
+			taskBlocks[headerLine] = true
+			tasks = append(tasks, &model.SourceMappedTask{
+				Task:        task,
+				SourceRange: index.rangeOf(block.headerStart, block.end(len(content))),
+				RawText:     block.header,
+			})
+		}
+	}
+
+	// 没有配置块的任务声明，例如 task clean(type: Delete)。
+	lineStart := 0
+	for i, line := range strings.Split(content, "\n") {
+		lineNumber := i + 1
+		trimmed := strings.TrimSpace(line)
+		if !taskBlocks[lineNumber] && !strings.Contains(trimmed, "{") {
+			if task := parseTaskHeader(trimmed); task != nil {
+				start := lineStart + strings.Index(line, trimmed)
+				tasks = append(tasks, &model.SourceMappedTask{
+					Task:        task,
+					SourceRange: index.rangeOf(start, start+len(trimmed)),
+					RawText:     trimmed,
+				})
+			}
+		}
+		lineStart += len(line) + 1
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].SourceRange.Start.StartPos < tasks[j].SourceRange.Start.StartPos
+	})
+	return tasks
+}
+
+// extractTasks 提取脚本中声明或配置的所有任务，同名任务的配置合并到第一次出现的任务上。
+// 顶层的 build.dependsOn(dist) 等语句也会记录到对应任务上。
+func extractTasks(content string) []*model.Task {
+	code := maskComments(content)
+	blocks := scanBlocks(code)
+
+	tasks := make([]*model.Task, 0)
+	byName := make(map[string]*model.Task)
+	merge := func(task *model.Task) {
+		existing, ok := byName[task.Name]
+		if !ok {
+			byName[task.Name] = task
+			tasks = append(tasks, task)
+			return
+		}
+		if existing.Type == "" {
+			existing.Type = task.Type
+		}
+		if existing.Group == "" {
+			existing.Group = task.Group
+		}
+		if existing.Description == "" {
+			existing.Description = task.Description
+		}
+		existing.DependsOn = appendUnique(existing.DependsOn, task.DependsOn...)
+		existing.FinalizedBy = appendUnique(existing.FinalizedBy, task.FinalizedBy...)
+		existing.MustRunAfter = appendUnique(existing.MustRunAfter, task.MustRunAfter...)
+		existing.ShouldRunAfter = appendUnique(existing.ShouldRunAfter, task.ShouldRunAfter...)
+	}
+
+	for _, mapped := range scanTasks(code, blocks, newLineIndex(code)) {
+		merge(mapped.Task)
+	}
+
+	// 顶层语句形式的任务关系。
+	for _, statement := range splitStatements(topLevelText(code, blocks)) {
+		matches := taskRelationStatementRegex.FindStringSubmatch(statement)
+		if matches == nil {
+			continue
+		}
+		task := &model.Task{Name: matches[1]}
+		applyTaskRelation(task, matches[2], matches[3])
+		merge(task)
+	}
+
+	return tasks
+}
+
+// topLevelText 返回不属于任何块的文本，块所在位置以换行代替。
+func topLevelText(content string, blocks []*blockSpan) string {
+	var sb strings.Builder
+	pos := 0
+	for _, block := range blocks {
+		if block.parent != nil || block.headerStart < pos {
+			continue
+		}
+		sb.WriteString(content[pos:block.headerStart])
+		sb.WriteString("\n")
+		pos = block.end(len(content))
+	}
+	if pos < len(content) {
+		sb.WriteString(content[pos:])
+	}
+	return sb.String()
+}
+
+// parseTaskHeader 从任务声明的头部解析任务名称、类型和依赖任务。
+func parseTaskHeader(header string) *model.Task {
+	var task *model.Task
+	if matches := taskKeywordRegex.FindStringSubmatch(header); len(matches) > 1 {
+		task = &model.Task{Name: matches[1], Type: matches[2]}
+	} else if matches := taskRegisterRegex.FindStringSubmatch(header); len(matches) > 3 {
+		taskType := matches[2]
+		if taskType == "" {
+			taskType = strings.TrimSuffix(strings.TrimSuffix(matches[4], ".java"), "::class")
+		}
+		task = &model.Task{Name: matches[3], Type: taskType}
+	} else {
+		return nil
+	}
+
+	if matches := taskHeaderDependsOnRegex.FindStringSubmatch(header); matches != nil {
+		task.DependsOn = appendUnique(task.DependsOn, parseTaskReferences(matches[1])...)
+	}
+	return task
+}
+
+// applyTaskBody 从任务配置块中提取分组、描述和任务关系。
+func applyTaskBody(task *model.Task, body string) {
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if matches := taskAttributeRegex.FindStringSubmatch(trimmed); len(matches) > 2 {
+			if matches[1] == "group" {
+				task.Group = matches[2]
+			} else {
+				task.Description = matches[2]
+			}
+			continue
+		}
+
+		if matches := taskRelationRegex.FindStringSubmatch(trimmed); len(matches) > 2 {
+			applyTaskRelation(task, matches[1], matches[2])
+		}
+	}
+}
+
+// applyTaskRelation 将关系语句的参数添加到任务对应的关系列表中。
+func applyTaskRelation(task *model.Task, relation, args string) {
+	refs := parseTaskReferences(args)
+	switch relation {
+	case "dependsOn":
+		task.DependsOn = appendUnique(task.DependsOn, refs...)
+	case "finalizedBy":
+		task.FinalizedBy = appendUnique(task.FinalizedBy, refs...)
+	case "mustRunAfter":
+		task.MustRunAfter = appendUnique(task.MustRunAfter, refs...)
+	case "shouldRunAfter":
+		task.ShouldRunAfter = appendUnique(task.ShouldRunAfter, refs...)
+	}
+}
+
+// parseTaskReferences 解析以逗号分隔的任务引用，返回任务名称。
+// 支持 'jar'、jar、tasks.jar、tasks.named("jar")、':jar' 以及 [a, b] 列表。
+func parseTaskReferences(args string) []string {
+	args = strings.TrimSpace(args)
+	for len(args) >= 2 && ((args[0] == '(' && args[len(args)-1] == ')') || (args[0] == '[' && args[len(args)-1] == ']')) {
+		args = strings.TrimSpace(args[1 : len(args)-1])
+	}
+
+	refs := make([]string, 0)
+	for _, ref := range splitTopLevelCommas(args) {
+		ref = strings.TrimSpace(ref)
+		if matches := taskReferenceCallRegex.FindStringSubmatch(ref); matches != nil {
+			ref = matches[1]
+		}
+		ref = strings.Trim(ref, `'"`)
+		ref = strings.TrimPrefix(ref, "project.")
+		ref = strings.TrimPrefix(ref, "tasks.")
+		ref = strings.TrimPrefix(ref, ":")
+		if ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// splitTopLevelCommas 按不在括号和字符串中的逗号拆分文本。
+func splitTopLevelCommas(text string) []string {
+	parts := make([]string, 0)
+	depth := 0
+	start := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\'', '"':
+			i = skipString(text, i) - 1
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, text[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, text[start:])
+}
+
+// appendUnique 追加不重复的值。
+func appendUnique(values []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+		for _, v := range values {
+			if v == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// directBlockBody 返回块内部不属于任何子块的文本。
+func directBlockBody(content string, block *blockSpan, blocks []*blockSpan) string {
+	bodyEnd := block.end(len(content))
+	if block.closePos != -1 {
+		bodyEnd = block.closePos
+	}
+
+	var sb strings.Builder
+	pos := block.openPos + 1
+	for _, child := range blocks {
+		if child.parent != block || child.headerStart < pos {
+			continue
+		}
+		sb.WriteString(content[pos:child.headerStart])
+		sb.WriteString("\n")
+		pos = child.end(len(content))
+	}
+	if pos < bodyEnd {
+		sb.WriteString(content[pos:bodyEnd])
+	}
+	return sb.String()
+}
+
+// parseSourceMappedMapDependency 解析map形式的依赖声明，声明可以跨越多行。
+// 返回消耗的行数，0表示不是map形式的依赖。
+func (sap *SourceAwareParser) parseSourceMappedMapDependency(lines []string, lineIdx int, lineStarts []int,
+	project *model.SourceMappedProject,
+) int {
+	line := lines[lineIdx]
+	if !mapDependencyStartRegex.MatchString(line) {
+		return 0
+	}
+
+	groupStart := strings.Index(line, "group")
+	if !sap.inContext(lineStarts[lineIdx]+groupStart, contextDependencies) {
+		return 0
+	}
+
+	// 收集以逗号结尾或括号未闭合的后续行。
+	endIdx := lineIdx
+	parenDepth := strings.Count(line, "(") - strings.Count(line, ")")
+	for endIdx+1 < len(lines) {
+		trimmed := strings.TrimSpace(stripLineComments(lines[endIdx]))
+		if !strings.HasSuffix(trimmed, ",") && parenDepth <= 0 {
+			break
+		}
+		endIdx++
+		parenDepth += strings.Count(lines[endIdx], "(") - strings.Count(lines[endIdx], ")")
+	}
+
+	start := lineStarts[lineIdx] + groupStart
+	statementEnd := lineStarts[endIdx] + len(lines[endIdx])
+	statement := sap.originalText[start:statementEnd]
+
+	dep := &model.Dependency{Scope: declarationScope(line)}
+	dependency.ApplyVariantScope(dep)
+	end := start
+	for _, match := range mapDependencyAttrRegex.FindAllStringSubmatchIndex(statement, -1) {
+		key := statement[match[2]:match[3]]
+		value := statement[match[4]:match[5]]
+		switch key {
+		case "group":
+			dep.Group = value
+		case "name":
+			dep.Name = value
+		case "version":
+			dep.Version = value
+		case "classifier":
+			dep.Classifier = value
+		case "ext":
+			dep.Extension = value
+		}
+		end = start + match[1]
+	}
+	if dep.Group == "" || dep.Name == "" {
+		return 0
+	}
+
+	rawText := sap.originalText[start:end]
+	dep.Raw = rawText
+
+	project.SourceMappedDependencies = append(project.SourceMappedDependencies, &model.SourceMappedDependency{
+		Dependency:  dep,
+		SourceRange: sap.index.rangeOf(start, end),
+		RawText:     rawText,
+	})
+
+	return endIdx - lineIdx + 1
+}
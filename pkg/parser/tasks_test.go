@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractTasks(t *testing.T) {
+	content := `plugins {
+    id 'java'
+}
+
+task docs(type: Javadoc)
+
+task dist(type: Zip, dependsOn: [jar, 'docs']) {
+    group = 'distribution'
+    finalizedBy 'publishReport'
+}
+
+tasks.register("publishReport") {
+    description = "Publishes the report"
+    mustRunAfter(tasks.named("dist"))
+    shouldRunAfter tasks.test, ':check'
+}
+
+tasks.named('dist') {
+    dependsOn 'sourcesJar'
+}
+
+// build.dependsOn(ignored)
+build.dependsOn(dist)
+tasks.jar.finalizedBy 'sign'
+`
+
+	tasks := extractTasks(content)
+	names := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		names = append(names, task.Name)
+	}
+	if want := []string{"docs", "dist", "publishReport", "build", "jar"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Task names = %v, want %v", names, want)
+	}
+
+	dist := tasks[1]
+	if dist.Type != "Zip" || dist.Group != "distribution" {
+		t.Errorf("Unexpected dist task: %+v", dist)
+	}
+	if want := []string{"jar", "docs", "sourcesJar"}; !reflect.DeepEqual(dist.DependsOn, want) {
+		t.Errorf("dist.DependsOn = %v, want %v", dist.DependsOn, want)
+	}
+	if !reflect.DeepEqual(dist.FinalizedBy, []string{"publishReport"}) {
+		t.Errorf("dist.FinalizedBy = %v", dist.FinalizedBy)
+	}
+
+	report := tasks[2]
+	if !reflect.DeepEqual(report.MustRunAfter, []string{"dist"}) {
+		t.Errorf("publishReport.MustRunAfter = %v", report.MustRunAfter)
+	}
+	if !reflect.DeepEqual(report.ShouldRunAfter, []string{"test", "check"}) {
+		t.Errorf("publishReport.ShouldRunAfter = %v", report.ShouldRunAfter)
+	}
+
+	if !reflect.DeepEqual(tasks[3].DependsOn, []string{"dist"}) {
+		t.Errorf("build.DependsOn = %v", tasks[3].DependsOn)
+	}
+	if !reflect.DeepEqual(tasks[4].FinalizedBy, []string{"sign"}) {
+		t.Errorf("jar.FinalizedBy = %v", tasks[4].FinalizedBy)
+	}
+}
@@ -0,0 +1,144 @@
+// Package parser 提供test任务配置的提取功能。
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配配置test任务的块头部。
+	// 例如: test、tasks.test、tasks.named('test')、tasks.named<Test>("test")、tasks.withType(Test)。
+	testBlockHeaderRegex = regexp.MustCompile(`^(?:test|tasks\.test|` +
+		`tasks\.(?:named|getByName)\s*(?:<Test>)?\s*\(\s*['"]test['"]\s*(?:,\s*Test(?:::class(?:\.java)?)?\s*)?\)|` +
+		`tasks\.withType\s*(?:<Test>\s*(?:\(\s*\))?|\(\s*Test(?:::class(?:\.java)?)?\s*\))(?:\s*\.configureEach)?)$`)
+
+	useJUnitPlatformRegex = regexp.MustCompile(`\buseJUnitPlatform\b`)
+	useJUnitRegex         = regexp.MustCompile(`\buseJUnit\b`)
+	useTestNGRegex        = regexp.MustCompile(`\buseTestNG\b`)
+
+	includeTagsRegex      = regexp.MustCompile(`^\s*includeTags\b(.*)$`)
+	excludeTagsRegex      = regexp.MustCompile(`^\s*excludeTags\b(.*)$`)
+	systemPropertyRegex   = regexp.MustCompile(`^\s*systemProperty\s*\(?\s*['"]([^'"]+)['"]\s*,\s*(.+?)\s*\)?\s*$`)
+	systemPropertiesRegex = regexp.MustCompile(`^\s*systemProperties\b(.*)$`)
+	environmentRegex      = regexp.MustCompile(`^\s*environment\s*\(?\s*['"]([^'"]+)['"]\s*,\s*(.+?)\s*\)?\s*$`)
+	jvmArgsRegex          = regexp.MustCompile(`^\s*(?:jvmArgs|allJvmArgs)\b(.*)$`)
+	maxParallelForksRegex = regexp.MustCompile(`^\s*maxParallelForks\s*=?\s*(.+?)\s*$`)
+	maxHeapSizeRegex      = regexp.MustCompile(`^\s*maxHeapSize\s*=?\s*['"]([^'"]+)['"]`)
+	ignoreFailuresRegex   = regexp.MustCompile(`^\s*ignoreFailures\s*=?\s*(true|false)\b`)
+
+	// 匹配Groovy的 'k': 'v' 和Kotlin的 "k" to "v" 形式的映射条目。
+	mapEntryRegex = regexp.MustCompile(`['"]([^'"]+)['"]\s*(?::|\bto\b)\s*['"]([^'"]*)['"]`)
+	quotedRegex   = regexp.MustCompile(`['"]([^'"]*)['"]`)
+)
+
+// extractTestConfig 从所有test任务配置块中提取测试配置，多个块的配置按出现顺序合并。
+// 没有找到配置块时返回nil。
+func extractTestConfig(content string) *model.TestConfig {
+	code := maskComments(content)
+
+	var config *model.TestConfig
+	for _, block := range scanBlocks(code) {
+		if block.closePos < 0 || !testBlockHeaderRegex.MatchString(block.header) {
+			continue
+		}
+		// 单独的 test { } 也可能是 sourceSets 中的源码集，只接受顶层或项目级配置块中的写法。
+		if block.header == "test" && block.parent != nil && !isProjectScopeBlock(block.parent) {
+			continue
+		}
+		if config == nil {
+			config = &model.TestConfig{}
+		}
+		applyTestConfigBody(config, code[block.openPos+1:block.closePos])
+	}
+
+	return config
+}
+
+// isProjectScopeBlock 判断块是否为 allprojects、subprojects、project(...) 等项目级配置块。
+func isProjectScopeBlock(block *blockSpan) bool {
+	switch block.name {
+	case "allprojects", "subprojects":
+		return true
+	}
+	return strings.HasPrefix(block.header, "project(") || strings.HasPrefix(block.header, "configure(")
+}
+
+// applyTestConfigBody 解析块体中的各行配置。
+func applyTestConfigBody(config *model.TestConfig, body string) {
+	switch {
+	case useJUnitPlatformRegex.MatchString(body):
+		config.Framework = model.TestFrameworkJUnitPlatform
+	case useTestNGRegex.MatchString(body):
+		config.Framework = model.TestFrameworkTestNG
+	case useJUnitRegex.MatchString(body):
+		config.Framework = model.TestFrameworkJUnit
+	}
+
+	for _, line := range splitStatements(body) {
+		if m := includeTagsRegex.FindStringSubmatch(line); m != nil {
+			config.IncludeTags = append(config.IncludeTags, quotedValues(m[1])...)
+		} else if m := excludeTagsRegex.FindStringSubmatch(line); m != nil {
+			config.ExcludeTags = append(config.ExcludeTags, quotedValues(m[1])...)
+		} else if m := systemPropertyRegex.FindStringSubmatch(line); m != nil {
+			config.SystemProperties = putValue(config.SystemProperties, m[1], m[2])
+		} else if m := systemPropertiesRegex.FindStringSubmatch(line); m != nil {
+			for _, entry := range mapEntryRegex.FindAllStringSubmatch(m[1], -1) {
+				config.SystemProperties = putValue(config.SystemProperties, entry[1], entry[2])
+			}
+		} else if m := environmentRegex.FindStringSubmatch(line); m != nil {
+			config.Environment = putValue(config.Environment, m[1], m[2])
+		} else if m := jvmArgsRegex.FindStringSubmatch(line); m != nil {
+			config.JVMArgs = append(config.JVMArgs, quotedValues(m[1])...)
+		} else if m := maxParallelForksRegex.FindStringSubmatch(line); m != nil {
+			config.MaxParallelForks = m[1]
+		} else if m := maxHeapSizeRegex.FindStringSubmatch(line); m != nil {
+			config.MaxHeapSize = m[1]
+		} else if m := ignoreFailuresRegex.FindStringSubmatch(line); m != nil {
+			config.IgnoreFailures = m[1] == "true"
+		}
+	}
+}
+
+// putValue 写入映射，字面量值去除引号，表达式保持原样。
+func putValue(values map[string]string, key, value string) map[string]string {
+	if values == nil {
+		values = make(map[string]string)
+	}
+	values[key] = literalValue(value)
+	return values
+}
+
+// quotedValues 返回文本中所有引号包围的值。
+func quotedValues(text string) []string {
+	values := make([]string, 0)
+	for _, m := range quotedRegex.FindAllStringSubmatch(text, -1) {
+		values = append(values, m[1])
+	}
+	return values
+}
+
+// splitStatements 按换行、分号和花括号拆分语句，忽略字符串中的分隔符。
+func splitStatements(body string) []string {
+	statements := make([]string, 0)
+	start := 0
+	flush := func(end int) {
+		if stmt := strings.TrimSpace(body[start:end]); stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\'', '"':
+			i = skipString(body, i) - 1
+		case '\n', ';', '{', '}':
+			flush(i)
+			start = i + 1
+		}
+	}
+	flush(len(body))
+	return statements
+}
@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestExtractTestConfig_Groovy(t *testing.T) {
+	content := `sourceSets {
+    test {
+        java { srcDirs = ['src/test/java'] }
+    }
+}
+
+test {
+    useJUnitPlatform {
+        includeTags 'fast', 'unit'
+        excludeTags 'slow'
+    }
+    systemProperty 'spring.profiles.active', 'test'
+    systemProperty 'build.dir', "${buildDir}"
+    systemProperties 'file.encoding': 'UTF-8', 'user.timezone': 'UTC'
+    environment 'CI', 'true'
+    jvmArgs '-Xmx1g', '-XX:+UseG1GC'
+    maxParallelForks = Runtime.runtime.availableProcessors()
+    maxHeapSize = '2g'
+    ignoreFailures = true
+    // jvmArgs '-Dcommented=true'
+    testLogging { events 'passed', 'failed' }
+}
+`
+	config := extractTestConfig(content)
+	if config == nil {
+		t.Fatal("Expected test config")
+	}
+
+	if config.Framework != model.TestFrameworkJUnitPlatform {
+		t.Errorf("Expected junit-platform, got %s", config.Framework)
+	}
+	if strings.Join(config.IncludeTags, ",") != "fast,unit" || strings.Join(config.ExcludeTags, ",") != "slow" {
+		t.Errorf("Unexpected tags: %v / %v", config.IncludeTags, config.ExcludeTags)
+	}
+
+	expectedProps := map[string]string{
+		"spring.profiles.active": "test",
+		"build.dir":              "${buildDir}",
+		"file.encoding":          "UTF-8",
+		"user.timezone":          "UTC",
+	}
+	if len(config.SystemProperties) != len(expectedProps) {
+		t.Errorf("Unexpected system properties: %v", config.SystemProperties)
+	}
+	for key, want := range expectedProps {
+		if config.SystemProperties[key] != want {
+			t.Errorf("SystemProperties[%s] = %q, want %q", key, config.SystemProperties[key], want)
+		}
+	}
+
+	if config.Environment["CI"] != "true" {
+		t.Errorf("Unexpected environment: %v", config.Environment)
+	}
+	if strings.Join(config.JVMArgs, " ") != "-Xmx1g -XX:+UseG1GC" {
+		t.Errorf("Unexpected JVM args: %v", config.JVMArgs)
+	}
+	if config.MaxParallelForks != "Runtime.runtime.availableProcessors()" || config.MaxHeapSize != "2g" || !config.IgnoreFailures {
+		t.Errorf("Unexpected fork settings: %+v", config)
+	}
+}
+
+func TestExtractTestConfig_KotlinDSL(t *testing.T) {
+	content := `tasks.named<Test>("test") {
+    useTestNG()
+    systemProperties(mapOf("a" to "1", "b" to "2"))
+    jvmArgs("-Xss4m")
+}
+
+tasks.withType<Test>().configureEach {
+    maxParallelForks = 2
+}
+`
+	config := extractTestConfig(content)
+	if config == nil {
+		t.Fatal("Expected test config")
+	}
+	if config.Framework != model.TestFrameworkTestNG {
+		t.Errorf("Expected testng, got %s", config.Framework)
+	}
+	if config.SystemProperties["a"] != "1" || config.SystemProperties["b"] != "2" {
+		t.Errorf("Unexpected system properties: %v", config.SystemProperties)
+	}
+	if len(config.JVMArgs) != 1 || config.MaxParallelForks != "2" {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+}
+
+func TestExtractTestConfig_None(t *testing.T) {
+	content := `sourceSets {
+    test {
+        resources { srcDir 'src/test/resources' }
+    }
+}
+`
+	if config := extractTestConfig(content); config != nil {
+		t.Errorf("Source set named test should not produce a test config, got %+v", config)
+	}
+
+	result, err := NewParser().Parse("tasks.named('test') {\n    useJUnit()\n}\n")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if result.Project.TestConfig == nil || result.Project.TestConfig.Framework != model.TestFrameworkJUnit {
+		t.Errorf("Parse should populate TestConfig, got %+v", result.Project.TestConfig)
+	}
+}
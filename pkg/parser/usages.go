@@ -0,0 +1,177 @@
+// Package parser 提供在整个构建中查找依赖声明位置的功能。
+package parser
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/dependency"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+var (
+	// 匹配dependencies块中一条声明开头的配置名称，其后是参数。
+	// 例如: implementation 'g:a:v'、classpath("g:a:v")、implementation libs.log4j.core。
+	usageDeclarationRegex = regexp.MustCompile(`^([A-Za-z_]\w*)[ \t]*`)
+
+	// 匹配版本目录访问路径，例如 libs.log4j.core、libs.bundles.logging。
+	catalogAccessorRegex = regexp.MustCompile(`\blibs\.([A-Za-z_][\w.]*\w)`)
+)
+
+// FindDependencyUsages 在项目模式解析的构建中查找坐标的所有声明位置，包括各模块、buildSrc、
+// includeBuild引入的构建和约定插件。直接声明、通过版本目录别名（包括bundle）引用以及
+// buildscript中的classpath声明都会被找到。name为空时匹配group下的所有制品。
+func FindDependencyUsages(tree *model.ProjectTree, group, name string) []*model.DependencyUsage {
+	usages := make([]*model.DependencyUsage, 0)
+	if tree == nil {
+		return usages
+	}
+
+	var visit func(build *model.ProjectTree)
+	visit = func(build *model.ProjectTree) {
+		for _, module := range build.Modules {
+			for _, usage := range findUsagesInFile(module.FilePath, build.VersionCatalog, group, name) {
+				usage.Module = module.Path
+				usages = append(usages, usage)
+			}
+		}
+		if build.BuildSrc != nil {
+			visit(build.BuildSrc)
+		}
+		for _, included := range build.IncludedBuilds {
+			visit(included)
+		}
+	}
+	visit(tree)
+
+	for _, plugin := range tree.ConventionPlugins {
+		for _, usage := range findUsagesInFile(plugin.FilePath, tree.VersionCatalog, group, name) {
+			usage.ConventionPlugin = plugin.ID
+			usages = append(usages, usage)
+		}
+	}
+
+	return usages
+}
+
+// findUsagesInFile 读取构建文件并查找其中的依赖声明，文件不存在或无法读取时返回空结果。
+func findUsagesInFile(filePath string, versionCatalog *model.VersionCatalog, group, name string) []*model.DependencyUsage {
+	if filePath == "" {
+		return nil
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+
+	usages := findDependencyUsages(string(content), versionCatalog, group, name)
+	for _, usage := range usages {
+		usage.FilePath = filePath
+	}
+	return usages
+}
+
+// findDependencyUsages 查找脚本中所有dependencies块里与坐标匹配的声明。
+// 声明后面的配置闭包（例如 exclude）不参与匹配。
+func findDependencyUsages(content string, versionCatalog *model.VersionCatalog, group, name string) []*model.DependencyUsage {
+	usages := make([]*model.DependencyUsage, 0)
+	blocks := scanBlocks(content)
+	index := newLineIndex(content)
+	masked := maskComments(content)
+
+	for _, block := range blocks {
+		if block.name != contextDependencies {
+			continue
+		}
+		buildscript := false
+		for ancestor := block.parent; ancestor != nil; ancestor = ancestor.parent {
+			buildscript = buildscript || ancestor.name == "buildscript"
+		}
+
+		forEachDeclaration(masked, blocks, block, func(scope, args string, start, end int) {
+			usage := matchDependencyUsage(args, versionCatalog, group, name)
+			if usage == nil {
+				return
+			}
+
+			usage.Scope = scope
+			usage.Buildscript = buildscript
+			usage.SourceRange = index.rangeOf(start, end)
+			usage.RawText = content[start:end]
+			usages = append(usages, usage)
+		})
+	}
+
+	return usages
+}
+
+// forEachDeclaration 对dependencies块中的每条声明调用fn，子块（例如依赖的配置闭包）中的内容被跳过。
+// masked是去掉注释后的文本，start和end为声明从配置名到参数末尾的偏移量。
+func forEachDeclaration(masked string, blocks []*blockSpan, block *blockSpan, fn func(scope, args string, start, end int)) {
+	bodyStart, bodyEnd := block.openPos+1, block.end(len(masked))
+	if block.closePos != -1 {
+		bodyEnd = block.closePos
+	}
+	body := []byte(masked[bodyStart:bodyEnd])
+	for _, child := range blocks {
+		if child.parent != block {
+			continue
+		}
+		for i := child.openPos; i < child.end(len(masked)) && i-bodyStart < len(body); i++ {
+			if body[i-bodyStart] != '\n' {
+				body[i-bodyStart] = ' '
+			}
+		}
+	}
+
+	// 按语句遍历，一行中用分号分隔的多条声明分别处理，跨行的声明作为一个整体。
+	for _, statement := range dependency.SplitStatements(string(body)) {
+		m := usageDeclarationRegex.FindStringSubmatchIndex(statement.Text)
+		if m == nil || m[1] == len(statement.Text) {
+			continue
+		}
+		fn(statement.Text[m[2]:m[3]], statement.Text[m[1]:], bodyStart+statement.Start, bodyStart+statement.End)
+	}
+}
+
+// matchDependencyUsage 判断声明的参数是否引用了指定坐标，支持字符串坐标、map形式和版本目录访问路径。
+func matchDependencyUsage(args string, versionCatalog *model.VersionCatalog, group, name string) *model.DependencyUsage {
+	matches := func(g, n string) bool {
+		return g == group && (name == "" || n == name)
+	}
+
+	attrs := make(map[string]string)
+	for _, m := range mapDependencyAttrRegex.FindAllStringSubmatch(args, -1) {
+		attrs[m[1]] = m[2]
+	}
+	if attrs["group"] != "" && matches(attrs["group"], attrs["name"]) {
+		return &model.DependencyUsage{Group: attrs["group"], Name: attrs["name"], Version: attrs["version"]}
+	}
+
+	for _, value := range quotedValues(args) {
+		if dep := dependency.ParseCoordinate(value); dep != nil && matches(dep.Group, dep.Name) {
+			return &model.DependencyUsage{Group: dep.Group, Name: dep.Name, Version: dep.Version}
+		}
+	}
+
+	for _, m := range catalogAccessorRegex.FindAllStringSubmatch(args, -1) {
+		accessor := strings.TrimSuffix(m[1], ".get")
+		libs := []*model.CatalogLibrary{versionCatalog.Library(accessor)}
+		if strings.HasPrefix(accessor, "bundles.") {
+			libs = versionCatalog.Bundle(accessor)
+		}
+		for _, lib := range libs {
+			if lib != nil && matches(lib.Group, lib.Name) {
+				return &model.DependencyUsage{
+					CatalogAlias: "libs." + accessor,
+					Group:        lib.Group,
+					Name:         lib.Name,
+					Version:      versionCatalog.ResolveVersion(lib),
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestFindDependencyUsages(t *testing.T) {
+	root := writeTreeFiles(t, map[string]string{
+		"settings.gradle": "include ':app', ':lib'\n",
+		"build.gradle": `buildscript {
+    dependencies {
+        classpath 'org.apache.logging.log4j:log4j-core:2.17.0'
+    }
+}
+`,
+		"app/build.gradle": `dependencies {
+    // implementation 'org.apache.logging.log4j:log4j-core:2.14.1'
+    implementation 'org.apache.logging.log4j:log4j-core:2.20.0'
+    implementation('com.example:service:1.0') {
+        exclude group: 'org.apache.logging.log4j', module: 'log4j-core'
+    }
+    runtimeOnly group: 'org.apache.logging.log4j', name: 'log4j-api', version: '2.20.0'
+}
+`,
+		"lib/build.gradle.kts": `dependencies {
+    implementation(libs.log4j.core)
+    testImplementation(libs.bundles.logging)
+}
+`,
+		"gradle/libs.versions.toml": `[versions]
+log4j = "2.21.1"
+
+[libraries]
+log4j-core = { module = "org.apache.logging.log4j:log4j-core", version.ref = "log4j" }
+log4j-api = { module = "org.apache.logging.log4j:log4j-api", version.ref = "log4j" }
+
+[bundles]
+logging = ["log4j-api"]
+`,
+		"buildSrc/src/main/groovy/myorg.logging.gradle": "dependencies {\n    implementation \"org.apache.logging.log4j:log4j-core:2.20.0\"\n}\n",
+	})
+
+	tree, err := ParseProjectTree(NewParser(), root)
+	if err != nil {
+		t.Fatalf("ParseProjectTree() error = %v", err)
+	}
+	if tree.VersionCatalog == nil || len(tree.VersionCatalog.Libraries) != 2 {
+		t.Fatalf("Expected version catalog to be loaded, got %+v", tree.VersionCatalog)
+	}
+
+	usages := FindDependencyUsages(tree, "org.apache.logging.log4j", "log4j-core")
+	want := []struct {
+		module, plugin, scope, alias, version string
+		buildscript                           bool
+		line                                  int
+	}{
+		{":", "", "classpath", "", "2.17.0", true, 3},
+		{":app", "", "implementation", "", "2.20.0", false, 3},
+		{":lib", "", "implementation", "libs.log4j.core", "2.21.1", false, 2},
+		{"", "myorg.logging", "implementation", "", "2.20.0", false, 2},
+	}
+	if len(usages) != len(want) {
+		t.Fatalf("Expected %d usages, got %d: %+v", len(want), len(usages), usages)
+	}
+	for i, w := range want {
+		u := usages[i]
+		if u.Module != w.module || u.ConventionPlugin != w.plugin || u.Scope != w.scope || u.CatalogAlias != w.alias ||
+			u.Version != w.version || u.Buildscript != w.buildscript || u.SourceRange.Start.Line != w.line {
+			t.Errorf("Usage %d: got %+v, want %+v", i, u, w)
+		}
+	}
+	if usages[1].RawText != "implementation 'org.apache.logging.log4j:log4j-core:2.20.0'" {
+		t.Errorf("Unexpected raw text %q", usages[1].RawText)
+	}
+
+	all := FindDependencyUsages(tree, "org.apache.logging.log4j", "")
+	scopes := make(map[string]bool)
+	for _, u := range all {
+		scopes[u.Module+" "+u.Scope] = true
+	}
+	if len(all) != 6 || !scopes[":app runtimeOnly"] || !scopes[":lib testImplementation"] {
+		t.Errorf("Expected map and bundle declarations when searching the whole group, got %+v", all)
+	}
+
+	if got := FindDependencyUsages(nil, "a", "b"); len(got) != 0 {
+		t.Errorf("Expected no usages for nil tree, got %d", len(got))
+	}
+}
@@ -0,0 +1,296 @@
+// Package parser 提供不依赖完整Groovy解析器的构建脚本语法检查。
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/dependency"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// 匹配plugins块中的插件声明，例如 id 'java'、id("org.foo") 或 kotlin("jvm")。
+var pluginDeclarationRegex = regexp.MustCompile(`(?m)^[ \t]*(?:id\s*\(?\s*['"]([^'"]+)['"]|kotlin\s*\(\s*['"]([^'"]+)['"]\s*\))`)
+
+// closingBrackets 是右括号对应的左括号。
+var closingBrackets = map[byte]byte{'}': '{', ')': '(', ']': '['}
+
+// Validate 对构建脚本做语法健全性检查：括号是否配对、字符串和块注释是否闭合、
+// plugins块中是否重复声明插件、同一dependencies块的同一配置中是否重复声明依赖、依赖坐标是否符合命名规则以及是否有空块。
+// 返回的诊断按位置排序，括号、字符串和坐标问题为error，重复声明为warning，空块为info。
+func Validate(content string) []*model.Diagnostic {
+	index := newLineIndex(content)
+	diagnostics := make([]*model.Diagnostic, 0)
+	add := func(code string, severity model.Severity, start, end int, message string) {
+		diagnostics = append(diagnostics, &model.Diagnostic{
+			Code:        code,
+			Severity:    severity,
+			Message:     message,
+			SourceRange: index.rangeOf(start, end),
+		})
+	}
+
+	checkSyntax(content, add)
+
+	code := maskComments(content)
+	blocks := scanBlocks(code)
+	for _, block := range blocks {
+		if block.closePos != -1 && strings.TrimSpace(code[block.openPos+1:block.closePos]) == "" {
+			add(model.DiagnosticEmptyBlock, model.SeverityInfo, block.headerStart, block.closePos+1,
+				fmt.Sprintf("块 %s 为空", block.name))
+		}
+	}
+	checkDuplicatePlugins(code, blocks, add)
+	checkDuplicateDependencies(content, code, blocks, add)
+	checkCoordinates(code, blocks, add)
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].SourceRange.Start.StartPos < diagnostics[j].SourceRange.Start.StartPos
+	})
+	return diagnostics
+}
+
+// diagnosticFunc 记录一条诊断，start和end为文本偏移量。
+type diagnosticFunc func(code string, severity model.Severity, start, end int, message string)
+
+// checkSyntax 检查括号配对以及字符串和块注释是否闭合，注释和字符串中的括号不参与配对。
+func checkSyntax(content string, add diagnosticFunc) {
+	type opener struct {
+		char byte
+		pos  int
+	}
+	stack := make([]opener, 0)
+	n := len(content)
+
+	for i := 0; i < n; i++ {
+		c := content[i]
+		switch {
+		case c == '/' && i+1 < n && content[i+1] == '/':
+			if j := strings.IndexByte(content[i:], '\n'); j == -1 {
+				i = n
+			} else {
+				i += j
+			}
+		case c == '/' && i+1 < n && content[i+1] == '*':
+			j := strings.Index(content[i+2:], "*/")
+			if j == -1 {
+				add(model.DiagnosticUnterminatedComment, model.SeverityError, i, n, "块注释没有结束")
+				i = n
+			} else {
+				i += j + 3
+			}
+		case c == '\'' || c == '"':
+			end, closed := scanString(content, i)
+			if !closed {
+				add(model.DiagnosticUnterminatedString, model.SeverityError, i, end, "字符串没有结束")
+			}
+			i = end - 1
+		case c == '{' || c == '(' || c == '[':
+			stack = append(stack, opener{char: c, pos: i})
+		case c == '}' || c == ')' || c == ']':
+			want := closingBrackets[c]
+			if len(stack) == 0 {
+				add(model.DiagnosticUnbalancedBrace, model.SeverityError, i, i+1, fmt.Sprintf("多余的 %c", c))
+				continue
+			}
+			top := stack[len(stack)-1]
+			if top.char != want {
+				add(model.DiagnosticUnbalancedBrace, model.SeverityError, i, i+1,
+					fmt.Sprintf("%c 与第%d个字符处的 %c 不匹配", c, top.pos+1, top.char))
+				// 右括号可能属于更外层，找到时丢弃中间未闭合的左括号，否则忽略该右括号。
+				for k := len(stack) - 1; k >= 0; k-- {
+					if stack[k].char == want {
+						for _, unclosed := range stack[k+1:] {
+							add(model.DiagnosticUnbalancedBrace, model.SeverityError, unclosed.pos, unclosed.pos+1,
+								fmt.Sprintf("%c 没有闭合", unclosed.char))
+						}
+						stack = stack[:k]
+						break
+					}
+				}
+				continue
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	for _, unclosed := range stack {
+		add(model.DiagnosticUnbalancedBrace, model.SeverityError, unclosed.pos, unclosed.pos+1,
+			fmt.Sprintf("%c 没有闭合", unclosed.char))
+	}
+}
+
+// checkDuplicatePlugins 检查同一个plugins块中重复声明的插件。
+func checkDuplicatePlugins(code string, blocks []*blockSpan, add diagnosticFunc) {
+	for _, block := range blocks {
+		if block.name != "plugins" || block.closePos == -1 {
+			continue
+		}
+		bodyStart := block.openPos + 1
+		first := make(map[string]int)
+		for _, m := range pluginDeclarationRegex.FindAllStringSubmatchIndex(code[bodyStart:block.closePos], -1) {
+			// 跳过嵌套块中的声明。
+			if nestedIn(blocks, block, bodyStart+m[0], len(code)) {
+				continue
+			}
+			id := ""
+			if m[2] != -1 {
+				id = code[bodyStart+m[2] : bodyStart+m[3]]
+			} else {
+				id = "org.jetbrains.kotlin." + code[bodyStart+m[4]:bodyStart+m[5]]
+			}
+			start, end := bodyStart+m[0], bodyStart+m[1]
+			start = end - len(strings.TrimLeft(code[start:end], " \t"))
+			if line, ok := first[id]; ok {
+				add(model.DiagnosticDuplicatePlugin, model.SeverityWarning, start, end,
+					fmt.Sprintf("插件 %s 重复声明，首次声明于第%d行", id, line))
+				continue
+			}
+			first[id] = strings.Count(code[:start], "\n") + 1
+		}
+	}
+}
+
+// checkDuplicateDependencies 检查同一dependencies块的同一配置中重复声明的依赖坐标，
+// 版本不同也视为重复。platform(...) 等包装调用与直接声明分开比较。
+func checkDuplicateDependencies(content, code string, blocks []*blockSpan, add diagnosticFunc) {
+	for _, block := range blocks {
+		if block.name != contextDependencies {
+			continue
+		}
+		first := make(map[string]int)
+		forEachDeclaration(code, blocks, block, func(scope, args string, start, end int) {
+			group, name, classifier := declaredCoordinate(args)
+			if group == "" || name == "" {
+				return
+			}
+			wrapper := ""
+			if open := strings.IndexByte(args, '('); open > 0 && isIdentifierText(args[:open]) {
+				wrapper = strings.TrimSpace(args[:open])
+			}
+
+			key := scope + "\x00" + wrapper + "\x00" + group + ":" + name + ":" + classifier
+			if line, ok := first[key]; ok {
+				add(model.DiagnosticDuplicateDependency, model.SeverityWarning, start, end,
+					fmt.Sprintf("依赖 %s:%s 在 %s 中重复声明，首次声明于第%d行", group, name, scope, line))
+				return
+			}
+			first[key] = strings.Count(content[:start], "\n") + 1
+		})
+	}
+}
+
+// coordinateWrappers 是参数为依赖坐标的包装函数。
+var coordinateWrappers = map[string]bool{
+	"platform":         true,
+	"enforcedPlatform": true,
+	"testFixtures":     true,
+}
+
+// checkCoordinates 检查dependencies块中字符串坐标和map形式的group、name是否符合Maven的命名规则，
+// 诊断范围为出错的字符串字面量。project、files等非坐标参数以及版本目录访问路径不做检查。
+func checkCoordinates(code string, blocks []*blockSpan, add diagnosticFunc) {
+	report := func(err error, start, end int) {
+		add(model.DiagnosticInvalidCoordinate, model.SeverityError, start, end, err.Error())
+	}
+
+	for _, block := range blocks {
+		if block.name != contextDependencies {
+			continue
+		}
+		forEachDeclaration(code, blocks, block, func(scope, args string, start, end int) {
+			if !dependency.IsDependencyScope(scope) {
+				return
+			}
+			argsStart := end - len(args)
+
+			if attrs := mapDependencyAttrRegex.FindAllStringSubmatchIndex(args, -1); len(attrs) > 0 {
+				for _, m := range attrs {
+					var err error
+					switch args[m[2]:m[3]] {
+					case "group":
+						err = dependency.ValidateGroupID(args[m[4]:m[5]])
+					case "name":
+						err = dependency.ValidateArtifactID(args[m[4]:m[5]])
+					case "version":
+						err = dependency.ValidateVersion(args[m[4]:m[5]])
+					}
+					if err != nil {
+						report(err, argsStart+m[4]-1, argsStart+m[5]+1)
+					}
+				}
+				return
+			}
+
+			pos := 0
+			for {
+				rest := strings.TrimLeft(args[pos:], " \t")
+				pos = len(args) - len(rest)
+				if strings.HasPrefix(rest, "(") {
+					pos++
+					continue
+				}
+				if open := strings.IndexByte(rest, '('); open > 0 && coordinateWrappers[strings.TrimSpace(rest[:open])] {
+					pos += open + 1
+					continue
+				}
+				break
+			}
+			if pos >= len(args) || args[pos] != '\'' && args[pos] != '"' {
+				return
+			}
+			stringEnd, ok := scanString(args, pos)
+			if !ok {
+				return
+			}
+			if err := dependency.ValidateCoordinate(args[pos:stringEnd]); err != nil {
+				report(err, argsStart+pos, argsStart+stringEnd)
+			}
+		})
+	}
+}
+
+// declaredCoordinate 从声明参数中取出依赖坐标，支持字符串坐标和map形式。
+func declaredCoordinate(args string) (group, name, classifier string) {
+	attrs := make(map[string]string)
+	for _, m := range mapDependencyAttrRegex.FindAllStringSubmatch(args, -1) {
+		attrs[m[1]] = m[2]
+	}
+	if attrs["group"] != "" {
+		return attrs["group"], attrs["name"], attrs["classifier"]
+	}
+
+	for _, value := range quotedValues(args) {
+		if dep := dependency.ParseCoordinate(value); dep != nil {
+			return dep.Group, dep.Name, dep.Classifier
+		}
+	}
+	return "", "", ""
+}
+
+// nestedIn 判断位置是否位于block的某个子块中。
+func nestedIn(blocks []*blockSpan, block *blockSpan, pos, contentLen int) bool {
+	for _, child := range blocks {
+		if child.parent == block && pos >= child.headerStart && pos < child.end(contentLen) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdentifierText 判断文本是否为单个标识符。
+func isIdentifierText(text string) bool {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return false
+	}
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
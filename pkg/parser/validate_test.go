@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func diagnosticCodes(diagnostics []*model.Diagnostic) []string {
+	codes := make([]string, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		codes = append(codes, d.Code)
+	}
+	return codes
+}
+
+func TestValidateCleanFile(t *testing.T) {
+	content := `plugins {
+    id 'java'
+    kotlin("jvm") version "1.9.0"
+}
+
+// unbalanced in comment: {
+def msg = "brace in string: } and ${project.name}"
+
+dependencies {
+    implementation 'com.google.guava:guava:32.1.2-jre'
+    testImplementation 'com.google.guava:guava:32.1.2-jre'
+    implementation platform('org.springframework.boot:spring-boot-dependencies:3.1.0')
+    implementation 'org.springframework.boot:spring-boot-dependencies'
+    implementation('com.example:lib:1.0') {
+        exclude group: 'com.google.guava', module: 'guava'
+    }
+}
+`
+	if diagnostics := Validate(content); len(diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics, got %v", diagnostics)
+	}
+}
+
+func TestValidateSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		code    string
+		line    int
+		column  int
+	}{
+		{"unclosed brace", "android {\n    compileSdk 34\n", model.DiagnosticUnbalancedBrace, 1, 9},
+		{"extra brace", "android {\n    compileSdk 34\n}\n}\n", model.DiagnosticUnbalancedBrace, 4, 1},
+		{"mismatched", "android {\n    compileSdk 34)\n}\n", model.DiagnosticUnbalancedBrace, 2, 18},
+		{"unterminated string", "version = '1.0\ngroup = 'com.example'\n", model.DiagnosticUnterminatedString, 1, 11},
+		{"unterminated triple string", "description = \"\"\"text\n", model.DiagnosticUnterminatedString, 1, 15},
+		{"unterminated comment", "/* comment\nandroid {}\n", model.DiagnosticUnterminatedComment, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := Validate(tt.content)
+			if len(diagnostics) == 0 {
+				t.Fatalf("Expected diagnostics, got none")
+			}
+			d := diagnostics[0]
+			if d.Code != tt.code || d.Severity != model.SeverityError {
+				t.Errorf("Expected error %s, got %v", tt.code, diagnosticCodes(diagnostics))
+			}
+			if d.SourceRange.Start.Line != tt.line || d.SourceRange.Start.Column != tt.column {
+				t.Errorf("Expected position %d:%d, got %s", tt.line, tt.column, d.SourceRange.Start.String())
+			}
+		})
+	}
+}
+
+func TestValidateDuplicates(t *testing.T) {
+	content := `plugins {
+    id 'java'
+    id("java")
+    kotlin("jvm")
+    id 'org.jetbrains.kotlin.jvm'
+}
+
+dependencies {
+    implementation 'com.google.guava:guava:31.0-jre'
+    implementation "com.google.guava:guava:32.1.2-jre"
+    implementation group: 'org.slf4j', name: 'slf4j-api', version: '2.0.7'
+    implementation 'org.slf4j:slf4j-api:2.0.9'
+}
+`
+	diagnostics := Validate(content)
+	codes := diagnosticCodes(diagnostics)
+	expected := []string{
+		model.DiagnosticDuplicatePlugin,
+		model.DiagnosticDuplicatePlugin,
+		model.DiagnosticDuplicateDependency,
+		model.DiagnosticDuplicateDependency,
+	}
+	if len(codes) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, diagnostics)
+	}
+	for i, code := range expected {
+		if codes[i] != code {
+			t.Errorf("Expected diagnostic %d to be %s, got %s", i, code, codes[i])
+		}
+	}
+
+	if d := diagnostics[0]; d.SourceRange.Start.Line != 3 || d.SourceRange.Start.Column != 5 || d.Severity != model.SeverityWarning {
+		t.Errorf("Unexpected duplicate plugin diagnostic: %s", d)
+	}
+	if d := diagnostics[2]; d.SourceRange.Start.Line != 10 {
+		t.Errorf("Expected duplicate dependency on line 10, got %s", d)
+	}
+}
+
+func TestValidateEmptyBlock(t *testing.T) {
+	diagnostics := Validate("repositories {\n    // none yet\n}\n\ndependencies {\n    implementation 'a:b:1'\n}\n")
+	if len(diagnostics) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %v", diagnostics)
+	}
+	if d := diagnostics[0]; d.Code != model.DiagnosticEmptyBlock || d.Severity != model.SeverityInfo || d.SourceRange.Start.Line != 1 {
+		t.Errorf("Unexpected empty block diagnostic: %s", d)
+	}
+}
+
+func TestValidateCoordinates(t *testing.T) {
+	content := `dependencies {
+    implementation 'com.google.guava guava:31.0-jre'
+    implementation platform("org.springframework.boot:spring-boot-dependencies:")
+    implementation group: 'org.slf4j', name: 'slf4j api', version: '2.0.7'
+    implementation project(':core')
+    implementation libs.commons.lang3
+    implementation "org.foo:bar:$barVersion"
+    testImplementation('junit:junit:4.13.2') {
+        exclude group: 'org hamcrest'
+    }
+}
+`
+	diagnostics := Validate(content)
+	if len(diagnostics) != 3 {
+		t.Fatalf("Expected 3 diagnostics, got %v", diagnostics)
+	}
+	wantText := []string{
+		"'com.google.guava guava:31.0-jre'",
+		`"org.springframework.boot:spring-boot-dependencies:"`,
+		"'slf4j api'",
+	}
+	for i, d := range diagnostics {
+		if d.Code != model.DiagnosticInvalidCoordinate || d.Severity != model.SeverityError || d.SourceRange.Start.Line != i+2 {
+			t.Errorf("Unexpected diagnostic %d: %s", i, d)
+		}
+		if text := content[d.SourceRange.Start.StartPos:d.SourceRange.End.StartPos]; text != wantText[i] {
+			t.Errorf("Diagnostic %d range covers %q, want %q", i, text, wantText[i])
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Package security 提供依赖混淆风险分析功能。
+package security
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/config"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// publicRepositoryNames 是指向公共仓库的内置仓库声明。
+var publicRepositoryNames = map[string]bool{
+	"mavenCentral":       true,
+	"google":             true,
+	"jcenter":            true,
+	"gradlePluginPortal": true,
+}
+
+// publicRepositoryHosts 是常见公共仓库的域名。
+var publicRepositoryHosts = []string{
+	"repo.maven.apache.org",
+	"repo1.maven.org",
+	"maven.google.com",
+	"dl.google.com",
+	"jcenter.bintray.com",
+	"plugins.gradle.org",
+	"jitpack.io",
+	"oss.sonatype.org",
+	"s01.oss.sonatype.org",
+	"repo.spring.io",
+}
+
+// ConfusionFinding 是一个内部group的依赖混淆风险。
+type ConfusionFinding struct {
+	Group string `json:"group"`
+	// Pattern 是匹配该group的内部group模式。
+	Pattern      string              `json:"pattern"`
+	Dependencies []*model.Dependency `json:"dependencies"`
+	// PublicRepositories 是解析该group时会被查询的公共仓库，按查询顺序排列。
+	PublicRepositories []*model.Repository `json:"publicRepositories"`
+	// Severity 为error表示公共仓库先于所有私有仓库被查询（或者没有私有仓库），攻击者发布的同名制品会被直接使用；
+	// 为warning表示公共仓库排在私有仓库之后，私有仓库中缺少的模块或版本仍会从公共仓库解析。
+	Severity model.Severity `json:"severity"`
+	Message  string         `json:"message"`
+}
+
+// ConfusionAnalyzer 查找内部group在没有 exclusiveContent 保护的情况下会从公共仓库解析的依赖。
+type ConfusionAnalyzer struct {
+	// InternalGroups 是内部group的模式，* 匹配任意字符，以 .* 结尾的模式同时匹配group本身，例如 com.mycorp.* 匹配 com.mycorp 和 com.mycorp.billing。
+	InternalGroups []string
+	// PublicHosts 是内置列表之外需要视为公共仓库的域名。
+	PublicHosts []string
+
+	patterns []*regexp.Regexp
+}
+
+// NewConfusionAnalyzer 使用内部group模式创建分析器，模式为空时返回错误。
+func NewConfusionAnalyzer(internalGroups ...string) (*ConfusionAnalyzer, error) {
+	analyzer := &ConfusionAnalyzer{InternalGroups: internalGroups}
+	for _, pattern := range internalGroups {
+		if strings.TrimSpace(pattern) == "" {
+			return nil, fmt.Errorf("内部group模式为空")
+		}
+		analyzer.patterns = append(analyzer.patterns, regexp.MustCompile("^"+GroupPatternRegex(pattern)+"$"))
+	}
+	if len(analyzer.patterns) == 0 {
+		return nil, fmt.Errorf("没有指定内部group模式")
+	}
+	return analyzer, nil
+}
+
+// GroupPatternRegex 把内部group模式转换为Gradle内容过滤使用的正则表达式，例如 com.mycorp.* 转换为 com\.mycorp(\..*)?。
+func GroupPatternRegex(pattern string) string {
+	pattern = strings.TrimSpace(pattern)
+	suffix := ""
+	if strings.HasSuffix(pattern, ".*") {
+		pattern, suffix = strings.TrimSuffix(pattern, ".*"), `(\..*)?`
+	}
+	return strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + suffix
+}
+
+// IsPublicRepository 判断仓库是否是公共仓库：mavenCentral() 等内置仓库或URL指向常见公共仓库。
+func (a *ConfusionAnalyzer) IsPublicRepository(repo *model.Repository) bool {
+	if repo == nil {
+		return false
+	}
+	if repo.URL == "" {
+		return publicRepositoryNames[repo.Name]
+	}
+	host := repositoryHost(repo.URL)
+	for _, hosts := range [][]string{publicRepositoryHosts, a.PublicHosts} {
+		for _, public := range hosts {
+			if strings.EqualFold(host, public) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Analyze 按仓库声明顺序和内容过滤条件模拟项目中每个内部group的解析，报告会查询公共仓库的group。
+// 结果按严重程度排列，error在前，同等严重程度下受影响依赖多的group在前。
+func (a *ConfusionAnalyzer) Analyze(project *model.Project) []*ConfusionFinding {
+	findings := make([]*ConfusionFinding, 0)
+	if project == nil {
+		return findings
+	}
+
+	byGroup := make(map[string]*ConfusionFinding)
+	for _, dep := range project.Dependencies {
+		if dep == nil || dep.Group == "" || dep.Name == "" {
+			continue
+		}
+		if finding := byGroup[dep.Group]; finding != nil {
+			finding.Dependencies = append(finding.Dependencies, dep)
+			continue
+		}
+		pattern := a.matchPattern(dep.Group)
+		if pattern == "" {
+			continue
+		}
+		finding := a.analyzeGroup(project.Repositories, dep)
+		byGroup[dep.Group] = finding
+		if finding != nil {
+			finding.Pattern = pattern
+			findings = append(findings, finding)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity == model.SeverityError
+		}
+		return len(findings[i].Dependencies) > len(findings[j].Dependencies)
+	})
+	return findings
+}
+
+// analyzeGroup 模拟依赖所在group的解析，不会查询公共仓库时返回nil。
+// 同一group的依赖按group判断，因此模拟时不区分模块和版本。
+func (a *ConfusionAnalyzer) analyzeGroup(repos []*model.Repository, dep *model.Dependency) *ConfusionFinding {
+	sim := config.SimulateResolution(repos, dep.Group, dep.Name, "")
+	finding := &ConfusionFinding{Group: dep.Group, Dependencies: []*model.Dependency{dep}, Severity: model.SeverityWarning}
+	privateFirst := false
+	for _, repo := range sim.Candidates() {
+		if repo.URL == "" && repo.Name == "mavenLocal" {
+			// 本地仓库既不是公共仓库，也不能代替私有仓库。
+			continue
+		}
+		if !a.IsPublicRepository(repo) {
+			privateFirst = privateFirst || len(finding.PublicRepositories) == 0
+			continue
+		}
+		finding.PublicRepositories = append(finding.PublicRepositories, repo)
+	}
+	if len(finding.PublicRepositories) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(finding.PublicRepositories))
+	for _, repo := range finding.PublicRepositories {
+		names = append(names, repositoryLabel(repo))
+	}
+	if privateFirst {
+		finding.Message = fmt.Sprintf("内部group %s 在私有仓库之后还会从公共仓库 %s 解析，私有仓库中缺少的模块或版本可能被替换",
+			dep.Group, strings.Join(names, "、"))
+	} else {
+		finding.Severity = model.SeverityError
+		finding.Message = fmt.Sprintf("内部group %s 会先从公共仓库 %s 解析，攻击者可以发布同名制品", dep.Group, strings.Join(names, "、"))
+	}
+	finding.Message += "，建议使用 exclusiveContent 或在公共仓库中排除该group"
+	return finding
+}
+
+// matchPattern 返回匹配group的内部group模式，不是内部group时返回空字符串。
+func (a *ConfusionAnalyzer) matchPattern(group string) string {
+	for i, re := range a.patterns {
+		if re.MatchString(group) {
+			return a.InternalGroups[i]
+		}
+	}
+	return ""
+}
+
+// ExclusionContent 返回在公共仓库中排除所有内部group的内容过滤条件。
+func (a *ConfusionAnalyzer) ExclusionContent() *model.RepositoryContent {
+	content := &model.RepositoryContent{}
+	for _, pattern := range a.InternalGroups {
+		rule := &model.ContentRule{Kind: model.ContentRuleGroupByRegex, Group: GroupPatternRegex(pattern)}
+		if !strings.Contains(pattern, "*") {
+			rule = &model.ContentRule{Kind: model.ContentRuleGroup, Group: strings.TrimSpace(pattern)}
+		}
+		content.Excludes = append(content.Excludes, rule)
+	}
+	return content
+}
+
+// repositoryHost 返回URL中的域名，不含端口。
+func repositoryHost(url string) string {
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+	}
+	if i := strings.IndexAny(url, "/:"); i >= 0 {
+		url = url[:i]
+	}
+	return url
+}
+
+// repositoryLabel 返回仓库的描述文本，有URL时使用URL。
+func repositoryLabel(repo *model.Repository) string {
+	if repo.URL != "" {
+		return repo.URL
+	}
+	return repo.Name
+}
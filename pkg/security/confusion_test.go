@@ -0,0 +1,106 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/config"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestGroupPatternRegex(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"com.mycorp.*", `com\.mycorp(\..*)?`},
+		{"com.mycorp", `com\.mycorp`},
+		{"*.internal", `.*\.internal`},
+	}
+	for _, tt := range tests {
+		if got := GroupPatternRegex(tt.pattern); got != tt.want {
+			t.Errorf("GroupPatternRegex(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestConfusionAnalyzer(t *testing.T) {
+	if _, err := NewConfusionAnalyzer(); err == nil {
+		t.Error("NewConfusionAnalyzer() without patterns returned no error")
+	}
+
+	analyzer, err := NewConfusionAnalyzer("com.mycorp.*", "org.acme")
+	if err != nil {
+		t.Fatalf("NewConfusionAnalyzer() error = %v", err)
+	}
+
+	project := &model.Project{
+		Repositories: config.NewRepositoryParser().ExtractRepositoriesFromText(`repositories {
+    mavenCentral()
+    maven { url 'https://nexus.mycorp.com/repository/internal' }
+    exclusiveContent {
+        forRepository { maven { url 'https://nexus.mycorp.com/repository/acme' } }
+        filter { includeGroup 'org.acme' }
+    }
+}`),
+		Dependencies: []*model.Dependency{
+			{Group: "com.mycorp", Name: "core", Version: "1.0"},
+			{Group: "com.mycorp.billing", Name: "api", Version: "1.0"},
+			{Group: "com.mycorp.billing", Name: "client", Version: "1.0"},
+			{Group: "org.acme", Name: "tool", Version: "2.0"},
+			{Group: "org.slf4j", Name: "slf4j-api", Version: "2.0.9"},
+		},
+	}
+
+	findings := analyzer.Analyze(project)
+	if len(findings) != 2 {
+		t.Fatalf("Analyze() returned %d findings, want 2", len(findings))
+	}
+	if findings[0].Group != "com.mycorp.billing" || len(findings[0].Dependencies) != 2 {
+		t.Errorf("findings[0] = %s with %d dependencies, want com.mycorp.billing with 2", findings[0].Group, len(findings[0].Dependencies))
+	}
+	for _, finding := range findings {
+		if finding.Severity != model.SeverityError {
+			t.Errorf("%s severity = %s, want error", finding.Group, finding.Severity)
+		}
+		if finding.Pattern != "com.mycorp.*" {
+			t.Errorf("%s pattern = %q, want com.mycorp.*", finding.Group, finding.Pattern)
+		}
+		if len(finding.PublicRepositories) != 1 || finding.PublicRepositories[0].Name != "mavenCentral" {
+			t.Errorf("%s public repositories = %v, want mavenCentral", finding.Group, finding.PublicRepositories)
+		}
+	}
+
+	// 私有仓库在前时只是警告。
+	project.Repositories[0], project.Repositories[1] = project.Repositories[1], project.Repositories[0]
+	findings = analyzer.Analyze(project)
+	if len(findings) != 2 || findings[0].Severity != model.SeverityWarning {
+		t.Errorf("Analyze() with private repository first = %v, want warnings", findings)
+	}
+
+	// 公共仓库排除内部group后没有风险。
+	project.Repositories[1].Content = analyzer.ExclusionContent()
+	if findings := analyzer.Analyze(project); len(findings) != 0 {
+		t.Errorf("Analyze() after exclusion returned %d findings, want 0", len(findings))
+	}
+}
+
+func TestIsPublicRepository(t *testing.T) {
+	analyzer, _ := NewConfusionAnalyzer("com.mycorp.*")
+	analyzer.PublicHosts = []string{"mirror.example.org"}
+
+	tests := []struct {
+		repo *model.Repository
+		want bool
+	}{
+		{&model.Repository{Name: "google"}, true},
+		{&model.Repository{Name: "mavenLocal"}, false},
+		{&model.Repository{Name: "jitpack.io", URL: "https://jitpack.io"}, true},
+		{&model.Repository{Name: "x", URL: "https://mirror.example.org:8443/maven"}, true},
+		{&model.Repository{Name: "nexus", URL: "https://nexus.mycorp.com/repository/maven"}, false},
+	}
+	for _, tt := range tests {
+		if got := analyzer.IsPublicRepository(tt.repo); got != tt.want {
+			t.Errorf("IsPublicRepository(%s) = %v, want %v", tt.repo.Name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// 本文件实现基于OSV.dev的漏洞数据源。
+
+package security
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// DefaultOSVEndpoint 是OSV.dev查询接口的默认地址。
+const DefaultOSVEndpoint = "https://api.osv.dev/v1/query"
+
+// defaultOSVClient 是未指定 Client 时使用的HTTP客户端，带有超时以免查询无限期阻塞。
+var defaultOSVClient = &http.Client{Timeout: 30 * time.Second}
+
+// OSVProvider 通过OSV.dev HTTP接口查询Maven生态的漏洞。
+type OSVProvider struct {
+	Endpoint string
+	// Client 为nil时使用超时为30秒的默认客户端。
+	Client *http.Client
+}
+
+// NewOSVProvider 创建使用默认地址的OSV数据源。
+func NewOSVProvider() *OSVProvider {
+	return &OSVProvider{
+		Endpoint: DefaultOSVEndpoint,
+		Client:   defaultOSVClient,
+	}
+}
+
+// osvQuery 是OSV查询请求体。
+type osvQuery struct {
+	Package osvPackage `json:"package"`
+	Version string     `json:"version"`
+}
+
+type osvPackage struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+// osvResponse 是OSV查询响应体中我们关心的部分。
+type osvResponse struct {
+	Vulns []osvVuln `json:"vulns"`
+}
+
+type osvVuln struct {
+	ID       string   `json:"id"`
+	Aliases  []string `json:"aliases"`
+	Summary  string   `json:"summary"`
+	Severity []struct {
+		Type  string `json:"type"`
+		Score string `json:"score"`
+	} `json:"severity"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+	Affected []struct {
+		Package osvPackage `json:"package"`
+		Ranges  []struct {
+			Type   string `json:"type"`
+			Events []struct {
+				Introduced string `json:"introduced,omitempty"`
+				Fixed      string `json:"fixed,omitempty"`
+			} `json:"events"`
+		} `json:"ranges"`
+	} `json:"affected"`
+}
+
+// Query 查询指定依赖的已知漏洞。
+func (p *OSVProvider) Query(dep *model.Dependency) ([]*Vulnerability, error) {
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultOSVEndpoint
+	}
+	client := p.Client
+	if client == nil {
+		client = defaultOSVClient
+	}
+
+	packageName := dep.Group + ":" + dep.Name
+	body, err := json.Marshal(osvQuery{
+		Package: osvPackage{Name: packageName, Ecosystem: "Maven"},
+		Version: dep.Version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("构造OSV请求失败: %w", err)
+	}
+
+	resp, err := client.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("请求OSV失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512)) //nolint:errcheck
+		return nil, fmt.Errorf("OSV返回状态码 %d: %s", resp.StatusCode, string(msg))
+	}
+
+	var osvResp osvResponse
+	if err := json.NewDecoder(resp.Body).Decode(&osvResp); err != nil {
+		return nil, fmt.Errorf("解析OSV响应失败: %w", err)
+	}
+
+	vulns := make([]*Vulnerability, 0, len(osvResp.Vulns))
+	for _, v := range osvResp.Vulns {
+		vulns = append(vulns, convertOSVVuln(v, packageName))
+	}
+
+	return vulns, nil
+}
+
+// convertOSVVuln 将OSV漏洞记录转换为模型。
+func convertOSVVuln(v osvVuln, packageName string) *Vulnerability {
+	vuln := &Vulnerability{
+		ID:      v.ID,
+		Aliases: v.Aliases,
+		Summary: v.Summary,
+	}
+
+	// 优先使用数据库给出的等级，否则退回到CVSS向量。
+	if v.DatabaseSpecific.Severity != "" {
+		vuln.Severity = v.DatabaseSpecific.Severity
+	} else if len(v.Severity) > 0 {
+		vuln.Severity = v.Severity[0].Score
+	}
+
+	// 收集修复版本。
+	seen := make(map[string]bool)
+	for _, affected := range v.Affected {
+		if affected.Package.Name != "" && affected.Package.Name != packageName {
+			continue
+		}
+		for _, r := range affected.Ranges {
+			for _, event := range r.Events {
+				if event.Fixed != "" && !seen[event.Fixed] {
+					seen[event.Fixed] = true
+					vuln.FixedVersions = append(vuln.FixedVersions, event.Fixed)
+				}
+			}
+		}
+	}
+
+	return vuln
+}
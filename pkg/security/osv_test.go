@@ -0,0 +1,109 @@
+package security
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+const osvTestResponse = `{
+  "vulns": [
+    {
+      "id": "GHSA-jfh8-c2jp-5v3q",
+      "aliases": ["CVE-2021-44228"],
+      "summary": "Remote code injection in Log4j",
+      "database_specific": {"severity": "CRITICAL"},
+      "affected": [
+        {
+          "package": {"name": "org.apache.logging.log4j:log4j-core", "ecosystem": "Maven"},
+          "ranges": [
+            {"type": "ECOSYSTEM", "events": [{"introduced": "2.0-beta9"}, {"fixed": "2.15.0"}]},
+            {"type": "ECOSYSTEM", "events": [{"introduced": "2.13.0"}, {"fixed": "2.15.0"}]}
+          ]
+        },
+        {
+          "package": {"name": "org.ops4j.pax.logging:pax-logging-log4j2", "ecosystem": "Maven"},
+          "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "1.11.10"}]}]
+        }
+      ]
+    },
+    {
+      "id": "GHSA-7rjr-3q55-vv33",
+      "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:C/C:H/I:H/A:H"}]
+    }
+  ]
+}`
+
+func TestOSVProviderQuery(t *testing.T) {
+	var received osvQuery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST, got %s", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(osvTestResponse))
+	}))
+	defer server.Close()
+
+	provider := NewOSVProvider()
+	provider.Endpoint = server.URL
+
+	vulns, err := provider.Query(&model.Dependency{
+		Group:   "org.apache.logging.log4j",
+		Name:    "log4j-core",
+		Version: "2.14.1",
+	})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+
+	if received.Package.Name != "org.apache.logging.log4j:log4j-core" || received.Package.Ecosystem != "Maven" {
+		t.Errorf("Unexpected package in request: %+v", received.Package)
+	}
+	if received.Version != "2.14.1" {
+		t.Errorf("Expected version 2.14.1 in request, got %s", received.Version)
+	}
+
+	if len(vulns) != 2 {
+		t.Fatalf("Expected 2 vulnerabilities, got %d", len(vulns))
+	}
+
+	first := vulns[0]
+	if first.Severity != "CRITICAL" {
+		t.Errorf("Expected severity CRITICAL, got %s", first.Severity)
+	}
+	if len(first.FixedVersions) != 1 || first.FixedVersions[0] != "2.15.0" {
+		t.Errorf("Expected fixed versions [2.15.0], got %v", first.FixedVersions)
+	}
+
+	if vulns[1].Severity != "CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:C/C:H/I:H/A:H" {
+		t.Errorf("Expected CVSS score fallback, got %s", vulns[1].Severity)
+	}
+}
+
+func TestOSVProviderQueryHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	provider := &OSVProvider{Endpoint: server.URL}
+	if _, err := provider.Query(&model.Dependency{Group: "a", Name: "b", Version: "1"}); err == nil {
+		t.Error("Expected error for non-200 response")
+	}
+}
+
+func TestOSVProviderDefaultClientTimeout(t *testing.T) {
+	if NewOSVProvider().Client.Timeout <= 0 {
+		t.Error("Expected NewOSVProvider client to have a timeout")
+	}
+	if defaultOSVClient.Timeout <= 0 {
+		t.Error("Expected fallback client to have a timeout")
+	}
+}
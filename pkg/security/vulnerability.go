@@ -0,0 +1,58 @@
+// Package security 提供依赖漏洞扫描功能。
+package security
+
+import (
+	"fmt"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// Vulnerability 表示一个已知漏洞。
+type Vulnerability struct {
+	ID            string   `json:"id"`                      // 漏洞ID，例如 GHSA-xxxx 或 CVE-xxxx。
+	Aliases       []string `json:"aliases,omitempty"`       // 别名，通常包含 CVE/GHSA ID。
+	Summary       string   `json:"summary,omitempty"`       // 漏洞摘要。
+	Severity      string   `json:"severity,omitempty"`      // 严重程度，例如 HIGH 或 CVSS 向量。
+	FixedVersions []string `json:"fixedVersions,omitempty"` // 建议升级到的修复版本。
+}
+
+// DependencyReport 表示单个依赖的漏洞扫描结果。
+type DependencyReport struct {
+	Dependency      *model.Dependency `json:"dependency"`
+	Vulnerabilities []*Vulnerability  `json:"vulnerabilities"`
+}
+
+// Provider 定义漏洞数据源接口。
+type Provider interface {
+	// Query 查询指定依赖的已知漏洞。
+	Query(dep *model.Dependency) ([]*Vulnerability, error)
+}
+
+// Scan 使用给定数据源扫描依赖列表，只返回存在漏洞的依赖报告。
+// 没有group或version的依赖（如项目依赖）会被跳过。
+func Scan(deps []*model.Dependency, provider Provider) ([]*DependencyReport, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("漏洞数据源为空")
+	}
+
+	reports := make([]*DependencyReport, 0)
+	for _, dep := range deps {
+		if dep == nil || dep.Group == "" || dep.Name == "" || dep.Version == "" {
+			continue
+		}
+
+		vulns, err := provider.Query(dep)
+		if err != nil {
+			return nil, fmt.Errorf("查询依赖 %s:%s:%s 的漏洞失败: %w", dep.Group, dep.Name, dep.Version, err)
+		}
+
+		if len(vulns) > 0 {
+			reports = append(reports, &DependencyReport{
+				Dependency:      dep,
+				Vulnerabilities: vulns,
+			})
+		}
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,74 @@
+package security
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// mockProvider 是测试用的漏洞数据源。
+type mockProvider struct {
+	vulns   map[string][]*Vulnerability
+	err     error
+	queried []string
+}
+
+func (m *mockProvider) Query(dep *model.Dependency) ([]*Vulnerability, error) {
+	key := dep.Group + ":" + dep.Name + ":" + dep.Version
+	m.queried = append(m.queried, key)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.vulns[key], nil
+}
+
+func TestScan(t *testing.T) {
+	provider := &mockProvider{
+		vulns: map[string][]*Vulnerability{
+			"org.apache.logging.log4j:log4j-core:2.14.1": {
+				{ID: "GHSA-jfh8-c2jp-5v3q", Aliases: []string{"CVE-2021-44228"}, FixedVersions: []string{"2.15.0"}},
+			},
+		},
+	}
+
+	deps := []*model.Dependency{
+		{Group: "org.apache.logging.log4j", Name: "log4j-core", Version: "2.14.1"},
+		{Group: "com.google.guava", Name: "guava", Version: "31.1-jre"},
+		{Group: "org.springframework.boot", Name: "spring-boot-starter-web"},
+		{Name: "core", Scope: "implementation"},
+	}
+
+	reports, err := Scan(deps, provider)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	if len(provider.queried) != 2 {
+		t.Errorf("Expected 2 queries, got %d: %v", len(provider.queried), provider.queried)
+	}
+
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 report, got %d", len(reports))
+	}
+
+	if reports[0].Dependency.Name != "log4j-core" {
+		t.Errorf("Expected log4j-core report, got %s", reports[0].Dependency.Name)
+	}
+
+	if reports[0].Vulnerabilities[0].Aliases[0] != "CVE-2021-44228" {
+		t.Errorf("Expected CVE-2021-44228 alias, got %v", reports[0].Vulnerabilities[0].Aliases)
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	if _, err := Scan(nil, nil); err == nil {
+		t.Error("Expected error for nil provider")
+	}
+
+	provider := &mockProvider{err: errors.New("network down")}
+	deps := []*model.Dependency{{Group: "a", Name: "b", Version: "1.0"}}
+	if _, err := Scan(deps, provider); err == nil {
+		t.Error("Expected error when provider fails")
+	}
+}
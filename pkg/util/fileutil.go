@@ -65,6 +65,31 @@ func FindProjectRoot(startDir string) (string, error) {
 	return "", os.ErrNotExist
 }
 
+// FindSettingsRoot 查找包含settings.gradle或settings.gradle.kts的构建根目录.
+func FindSettingsRoot(startDir string) (string, error) {
+	currentDir := startDir
+	for {
+		if fileExists(filepath.Join(currentDir, "settings.gradle")) ||
+			fileExists(filepath.Join(currentDir, "settings.gradle.kts")) {
+			return currentDir, nil
+		}
+
+		parentDir := filepath.Dir(currentDir)
+		if parentDir == currentDir {
+			break
+		}
+		currentDir = parentDir
+	}
+
+	return "", os.ErrNotExist
+}
+
+// DirExists 检查目录是否存在.
+func DirExists(dirPath string) bool {
+	info, err := os.Stat(dirPath)
+	return err == nil && info.IsDir()
+}
+
 // fileExists 检查文件是否存在.
 func fileExists(filePath string) bool {
 	info, err := os.Stat(filePath)
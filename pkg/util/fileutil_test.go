@@ -208,3 +208,29 @@ func TestGetFileContent(t *testing.T) {
 		t.Error("GetFileContent() should return error for non-existent file")
 	}
 }
+
+func TestFindSettingsRoot(t *testing.T) {
+	tmpDir := t.TempDir()
+	moduleDir := filepath.Join(tmpDir, "app", "src")
+	if err := os.MkdirAll(moduleDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "app", "build.gradle"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "settings.gradle.kts"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := FindSettingsRoot(moduleDir)
+	if err != nil {
+		t.Fatalf("FindSettingsRoot() error = %v", err)
+	}
+	if root != tmpDir {
+		t.Errorf("FindSettingsRoot() = %v, want %v", root, tmpDir)
+	}
+
+	if !DirExists(moduleDir) || DirExists(filepath.Join(tmpDir, "settings.gradle.kts")) {
+		t.Error("DirExists() returned unexpected result")
+	}
+}
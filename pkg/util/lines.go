@@ -0,0 +1,25 @@
+// Package util 提供字节偏移量到行号的转换。
+package util
+
+import "sort"
+
+// LineIndex 记录文本中每行的起始偏移量，用于把字节偏移量转换为行号.
+type LineIndex struct {
+	starts []int
+}
+
+// NewLineIndex 为文本建立行索引.
+func NewLineIndex(text string) *LineIndex {
+	starts := []int{0}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &LineIndex{starts: starts}
+}
+
+// Line 返回偏移量所在的行号，从1开始.
+func (li *LineIndex) Line(offset int) int {
+	return sort.Search(len(li.starts), func(i int) bool { return li.starts[i] > offset })
+}
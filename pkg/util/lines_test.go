@@ -0,0 +1,26 @@
+package util
+
+import "testing"
+
+func TestLineIndex(t *testing.T) {
+	index := NewLineIndex("a\nbc\n\nd")
+
+	tests := []struct {
+		offset int
+		want   int
+	}{
+		{0, 1},
+		{1, 1},
+		{2, 2},
+		{4, 2},
+		{5, 3},
+		{6, 4},
+		{7, 4},
+	}
+
+	for _, tt := range tests {
+		if got := index.Line(tt.offset); got != tt.want {
+			t.Errorf("Line(%d) = %d, want %d", tt.offset, got, tt.want)
+		}
+	}
+}
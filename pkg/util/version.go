@@ -0,0 +1,70 @@
+// Package util 提供版本号比较函数。
+package util
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CompareVersions 比较两个版本号，a<b返回-1，a==b返回0，a>b返回1.
+// 数字段逐段比较，缺失的段视为0；数字相同时带限定符（如 -rc-1、-SNAPSHOT）的版本更小。
+func CompareVersions(a, b string) int {
+	aNums, aQualifier := splitVersion(a)
+	bNums, bQualifier := splitVersion(b)
+
+	for i := 0; i < len(aNums) || i < len(bNums); i++ {
+		var x, y int
+		if i < len(aNums) {
+			x = aNums[i]
+		}
+		if i < len(bNums) {
+			y = bNums[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case aQualifier == bQualifier:
+		return 0
+	case aQualifier == "":
+		return 1
+	case bQualifier == "":
+		return -1
+	case aQualifier < bQualifier:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// splitVersion 将版本号拆分为数字段和限定符，例如 "8.0-rc-1" 拆分为 [8 0] 和 "rc-1".
+func splitVersion(version string) ([]int, string) {
+	version = strings.TrimSpace(version)
+	nums := make([]int, 0, 3)
+	rest := version
+
+	for rest != "" {
+		end := 0
+		for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		n, _ := strconv.Atoi(rest[:end])
+		nums = append(nums, n)
+		rest = rest[end:]
+		if !strings.HasPrefix(rest, ".") {
+			break
+		}
+		rest = rest[1:]
+	}
+
+	qualifier := strings.TrimLeft(rest, ".-_")
+	return nums, strings.ToLower(qualifier)
+}
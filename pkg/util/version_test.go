@@ -0,0 +1,25 @@
+package util
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"8.5", "8.5", 0},
+		{"8.5", "8.5.0", 0},
+		{"7.6.4", "8.0", -1},
+		{"8.10", "8.9", 1},
+		{"8.0-rc-1", "8.0", -1},
+		{"8.0", "8.0-milestone-2", 1},
+		{"1.0-SNAPSHOT", "1.0", -1},
+		{"31.1-jre", "30.1-jre", 1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
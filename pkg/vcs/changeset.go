@@ -0,0 +1,166 @@
+// Package vcs 提供比较同一构建文件两个版本中依赖和插件变化的功能。
+package vcs
+
+import (
+	"sort"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// ChangeKind 表示声明的变化类型。
+type ChangeKind string
+
+const (
+	// ChangeAdded 表示新版本中新增的声明。
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved 表示新版本中删除的声明。
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeUpdated 表示两个版本中都有但版本号不同的声明。
+	ChangeUpdated ChangeKind = "updated"
+)
+
+// DependencyChange 表示一个依赖声明的变化，依赖以 group:name、分类器和配置名识别。
+type DependencyChange struct {
+	Kind       ChangeKind `json:"kind"`
+	Group      string     `json:"group"`
+	Name       string     `json:"name"`
+	Classifier string     `json:"classifier,omitempty"`
+	Scope      string     `json:"scope"`
+	// OldVersion 和 NewVersion 是变化前后的版本，版本变量已解析时取解析后的版本；新增时 OldVersion 为空，删除时 NewVersion 为空。
+	OldVersion string `json:"oldVersion,omitempty"`
+	NewVersion string `json:"newVersion,omitempty"`
+}
+
+// PluginChange 表示一个插件声明的变化，插件以ID识别。
+type PluginChange struct {
+	Kind       ChangeKind `json:"kind"`
+	ID         string     `json:"id"`
+	OldVersion string     `json:"oldVersion,omitempty"`
+	NewVersion string     `json:"newVersion,omitempty"`
+}
+
+// ChangeSet 是一个构建文件两个版本之间的依赖和插件变化。
+type ChangeSet struct {
+	// FilePath 是构建文件相对仓库根目录的路径。
+	FilePath     string              `json:"filePath"`
+	Dependencies []*DependencyChange `json:"dependencies"`
+	Plugins      []*PluginChange     `json:"plugins"`
+}
+
+// IsEmpty 判断变化集是否没有任何依赖和插件变化。
+func (cs *ChangeSet) IsEmpty() bool {
+	return len(cs.Dependencies) == 0 && len(cs.Plugins) == 0
+}
+
+// Diff 比较构建文件的两个版本，返回依赖和插件的变化。before 或 after 为nil表示文件在该版本中不存在。
+// 同一标识的声明出现多次时按出现顺序一一对应，多出的部分视为新增或删除。变化按类型、标识排序。
+func Diff(filePath string, before, after *model.Project) *ChangeSet {
+	cs := &ChangeSet{
+		FilePath:     filePath,
+		Dependencies: make([]*DependencyChange, 0),
+		Plugins:      make([]*PluginChange, 0),
+	}
+
+	oldDeps, newDeps := groupDependencies(before), groupDependencies(after)
+	for _, key := range unionKeys(oldDeps, newDeps) {
+		olds, news := oldDeps[key], newDeps[key]
+		for i := 0; i < len(olds) || i < len(news); i++ {
+			change := &DependencyChange{}
+			var dep *model.Dependency
+			switch {
+			case i >= len(olds):
+				dep = news[i]
+				change.Kind, change.NewVersion = ChangeAdded, dependencyVersion(dep)
+			case i >= len(news):
+				dep = olds[i]
+				change.Kind, change.OldVersion = ChangeRemoved, dependencyVersion(dep)
+			default:
+				dep = news[i]
+				change.Kind, change.OldVersion, change.NewVersion = ChangeUpdated, dependencyVersion(olds[i]), dependencyVersion(dep)
+				if change.OldVersion == change.NewVersion {
+					continue
+				}
+			}
+			change.Group, change.Name, change.Classifier, change.Scope = dep.Group, dep.Name, dep.Classifier, dep.Scope
+			cs.Dependencies = append(cs.Dependencies, change)
+		}
+	}
+
+	oldPlugins, newPlugins := groupPlugins(before), groupPlugins(after)
+	for _, id := range unionKeys(oldPlugins, newPlugins) {
+		oldPlugin, hadPlugin := oldPlugins[id]
+		newPlugin, hasPlugin := newPlugins[id]
+		switch {
+		case !hadPlugin:
+			cs.Plugins = append(cs.Plugins, &PluginChange{Kind: ChangeAdded, ID: id, NewVersion: newPlugin.Version})
+		case !hasPlugin:
+			cs.Plugins = append(cs.Plugins, &PluginChange{Kind: ChangeRemoved, ID: id, OldVersion: oldPlugin.Version})
+		case oldPlugin.Version != newPlugin.Version:
+			cs.Plugins = append(cs.Plugins, &PluginChange{Kind: ChangeUpdated, ID: id, OldVersion: oldPlugin.Version, NewVersion: newPlugin.Version})
+		}
+	}
+
+	sort.SliceStable(cs.Dependencies, func(i, j int) bool {
+		return cs.Dependencies[i].Kind < cs.Dependencies[j].Kind
+	})
+	sort.SliceStable(cs.Plugins, func(i, j int) bool {
+		return cs.Plugins[i].Kind < cs.Plugins[j].Kind
+	})
+	return cs
+}
+
+// groupDependencies 按标识对依赖分组，保持出现顺序。
+func groupDependencies(project *model.Project) map[string][]*model.Dependency {
+	groups := make(map[string][]*model.Dependency)
+	if project == nil {
+		return groups
+	}
+	for _, dep := range project.Dependencies {
+		if dep == nil {
+			continue
+		}
+		key := dep.Group + ":" + dep.Name + ":" + dep.Classifier + "@" + dep.Scope
+		groups[key] = append(groups[key], dep)
+	}
+	return groups
+}
+
+// groupPlugins 按ID索引插件，同一插件声明多次时取第一个。
+func groupPlugins(project *model.Project) map[string]*model.Plugin {
+	plugins := make(map[string]*model.Plugin)
+	if project == nil {
+		return plugins
+	}
+	for _, plugin := range project.Plugins {
+		if plugin == nil || plugin.ID == "" {
+			continue
+		}
+		if _, ok := plugins[plugin.ID]; !ok {
+			plugins[plugin.ID] = plugin
+		}
+	}
+	return plugins
+}
+
+// dependencyVersion 返回用于比较的版本，版本变量已解析时取解析后的版本。
+func dependencyVersion(dep *model.Dependency) string {
+	if dep.ResolvedVersion != "" {
+		return dep.ResolvedVersion
+	}
+	return dep.Version
+}
+
+// unionKeys 返回两个map中所有键的有序列表。
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,58 @@
+package vcs
+
+import (
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+func TestDiff(t *testing.T) {
+	before := &model.Project{
+		Dependencies: []*model.Dependency{
+			{Group: "org.slf4j", Name: "slf4j-api", Version: "1.7.36", Scope: "implementation"},
+			{Group: "junit", Name: "junit", Version: "4.13.2", Scope: "testImplementation"},
+			{Group: "com.google.guava", Name: "guava", Version: "$guavaVersion", ResolvedVersion: "32.0.0-jre", Scope: "implementation"},
+		},
+		Plugins: []*model.Plugin{{ID: "java"}, {ID: "org.springframework.boot", Version: "3.1.0"}},
+	}
+	after := &model.Project{
+		Dependencies: []*model.Dependency{
+			{Group: "org.slf4j", Name: "slf4j-api", Version: "2.0.9", Scope: "implementation"},
+			{Group: "com.google.guava", Name: "guava", Version: "$guavaVersion", ResolvedVersion: "32.0.0-jre", Scope: "implementation"},
+			{Group: "org.junit.jupiter", Name: "junit-jupiter", Version: "5.10.0", Scope: "testImplementation"},
+		},
+		Plugins: []*model.Plugin{{ID: "java"}, {ID: "org.springframework.boot", Version: "3.2.0"}, {ID: "jacoco"}},
+	}
+
+	cs := Diff("build.gradle", before, after)
+	if cs.FilePath != "build.gradle" || len(cs.Dependencies) != 3 || len(cs.Plugins) != 2 {
+		t.Fatalf("Unexpected change set %+v", cs)
+	}
+
+	deps := cs.Dependencies
+	if deps[0].Kind != ChangeAdded || deps[0].Name != "junit-jupiter" || deps[0].NewVersion != "5.10.0" || deps[0].OldVersion != "" {
+		t.Errorf("Expected junit-jupiter to be added, got %+v", deps[0])
+	}
+	if deps[1].Kind != ChangeRemoved || deps[1].Name != "junit" || deps[1].OldVersion != "4.13.2" || deps[1].Scope != "testImplementation" {
+		t.Errorf("Expected junit to be removed, got %+v", deps[1])
+	}
+	if deps[2].Kind != ChangeUpdated || deps[2].Name != "slf4j-api" || deps[2].OldVersion != "1.7.36" || deps[2].NewVersion != "2.0.9" {
+		t.Errorf("Expected slf4j-api to be updated, got %+v", deps[2])
+	}
+
+	if cs.Plugins[0].Kind != ChangeAdded || cs.Plugins[0].ID != "jacoco" {
+		t.Errorf("Expected jacoco to be added, got %+v", cs.Plugins[0])
+	}
+	if cs.Plugins[1].Kind != ChangeUpdated || cs.Plugins[1].OldVersion != "3.1.0" || cs.Plugins[1].NewVersion != "3.2.0" {
+		t.Errorf("Expected spring boot plugin to be updated, got %+v", cs.Plugins[1])
+	}
+
+	if !Diff("build.gradle", before, before).IsEmpty() {
+		t.Error("Comparing a project with itself should produce no changes")
+	}
+
+	created := Diff("app/build.gradle", nil, after)
+	if len(created.Dependencies) != 3 || len(created.Plugins) != 3 || created.Dependencies[0].Kind != ChangeAdded {
+		t.Errorf("A new file should add every declaration, got %+v", created)
+	}
+}
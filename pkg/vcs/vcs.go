@@ -0,0 +1,223 @@
+// Package vcs 提供从Git仓库的指定版本解析构建文件以及比较两个版本之间依赖和插件变化的功能，
+// 可以作为生成变更日志和代码评审机器人的基础。通过调用 git 命令读取仓库，运行环境中需要有 git。
+package vcs
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/scagogogo/gradle-parser/pkg/api"
+	"github.com/scagogogo/gradle-parser/pkg/model"
+)
+
+// 本包返回的错误包装了以下哨兵错误之一，调用方可以用 errors.Is 判断错误类别。
+var (
+	// ErrInvalidRevision 表示版本为空、以 - 开头（会被git当作命令行选项）或无法解析为提交，
+	// 包括 repoPath 不是Git仓库的情况；错误中带有git的错误输出。
+	ErrInvalidRevision = errors.New("invalid revision")
+	// ErrFileNotFound 表示文件在指定版本中不存在。
+	ErrFileNotFound = errors.New("file not found at revision")
+)
+
+// ParseFileAtRevision 使用默认选项解析构建文件在指定版本中的内容。
+// repoPath 是仓库中的任意目录，rev 是git能识别的任意版本（提交、分支、标签等），filePath 是相对仓库根目录的路径。
+func ParseFileAtRevision(repoPath, rev, filePath string) (*model.ParseResult, error) {
+	return ParseFileAtRevisionWithOptions(repoPath, rev, filePath, nil)
+}
+
+// ParseFileAtRevisionWithOptions 使用指定的选项解析构建文件在指定版本中的内容，options为nil时使用默认选项。
+// 开启 ResolveVersionVariables 时同一版本中同目录的 gradle.properties 用于解析版本变量。
+func ParseFileAtRevisionWithOptions(repoPath, rev, filePath string, options *api.Options) (*model.ParseResult, error) {
+	commit, err := resolveRevision(repoPath, rev)
+	if err != nil {
+		return nil, err
+	}
+	return parseFileAtCommit(repoPath, commit, filepath.ToSlash(filePath), options)
+}
+
+// parseFileAtCommit 解析文件在已解析的提交中的内容。
+func parseFileAtCommit(repoPath, commit, filePath string, options *api.Options) (*model.ParseResult, error) {
+	content, err := showFile(repoPath, commit, filePath)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]string{filePath: content}
+	if options != nil && options.ResolveVersionVariables {
+		propsPath := path.Join(path.Dir(filePath), "gradle.properties")
+		if props, err := showFile(repoPath, commit, propsPath); err == nil {
+			files[propsPath] = props
+		} else if !errors.Is(err, ErrFileNotFound) {
+			return nil, err
+		}
+	}
+
+	results, err := api.ParseAll(files, options)
+	if err != nil {
+		return nil, err
+	}
+	result, ok := results[filePath]
+	if !ok {
+		return nil, fmt.Errorf("%s 不是Gradle构建文件", filePath)
+	}
+	return result, nil
+}
+
+// DiffRevisions 使用默认选项比较两个版本之间所有发生变化的构建文件，返回每个文件的依赖和插件变化。
+func DiffRevisions(repoPath, revA, revB string) ([]*ChangeSet, error) {
+	return DiffRevisionsWithOptions(repoPath, revA, revB, nil)
+}
+
+// DiffRevisionsWithOptions 使用指定的选项比较两个版本之间所有发生变化的构建文件（.gradle 和 .gradle.kts），
+// 返回按文件路径排序的变化集，没有依赖和插件变化的文件不出现在结果中。options为nil时使用默认选项。
+// 开启 ResolveVersionVariables 时，gradle.properties 变化的目录中的构建文件也会被比较。
+// 重命名视为删除旧文件并新增新文件；通过版本目录（libs.versions.toml）声明的版本变化不在比较范围内。
+func DiffRevisionsWithOptions(repoPath, revA, revB string, options *api.Options) ([]*ChangeSet, error) {
+	commitA, err := resolveRevision(repoPath, revA)
+	if err != nil {
+		return nil, err
+	}
+	commitB, err := resolveRevision(repoPath, revB)
+	if err != nil {
+		return nil, err
+	}
+
+	changedFiles, err := changedBuildFiles(repoPath, commitA, commitB, options != nil && options.ResolveVersionVariables)
+	if err != nil {
+		return nil, err
+	}
+
+	changeSets := make([]*ChangeSet, 0)
+	for _, filePath := range changedFiles {
+		before, err := parseProjectAtCommit(repoPath, revA, commitA, filePath, options)
+		if err != nil {
+			return nil, err
+		}
+		after, err := parseProjectAtCommit(repoPath, revB, commitB, filePath, options)
+		if err != nil {
+			return nil, err
+		}
+
+		if cs := Diff(filePath, before, after); !cs.IsEmpty() {
+			changeSets = append(changeSets, cs)
+		}
+	}
+	return changeSets, nil
+}
+
+// parseProjectAtCommit 解析文件在已解析的提交中的项目，文件不存在时返回nil；rev 是用于错误消息的原始版本。
+func parseProjectAtCommit(repoPath, rev, commit, filePath string, options *api.Options) (*model.Project, error) {
+	result, err := parseFileAtCommit(repoPath, commit, filePath, options)
+	if errors.Is(err, ErrFileNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("解析 %s:%s 失败: %w", rev, filePath, err)
+	}
+	return result.Project, nil
+}
+
+// changedBuildFiles 返回两个提交之间发生变化的构建文件，按路径排序。
+// withProperties 为true时，gradle.properties 变化的目录中存在于任一版本的构建文件也被视为发生变化。
+func changedBuildFiles(repoPath, commitA, commitB string, withProperties bool) ([]string, error) {
+	output, err := runGit(repoPath, "diff", "--name-only", "--no-renames", "-z", commitA, commitB, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]bool)
+	for _, name := range strings.Split(string(output), "\x00") {
+		switch {
+		case isBuildFile(name):
+			files[name] = true
+		case withProperties && path.Base(name) == "gradle.properties":
+			dir := path.Dir(name)
+			for _, buildFile := range []string{"build.gradle", "build.gradle.kts"} {
+				buildPath := path.Join(dir, buildFile)
+				for _, commit := range []string{commitA, commitB} {
+					exists, err := fileExists(repoPath, commit, buildPath)
+					if err != nil {
+						return nil, err
+					}
+					if exists {
+						files[buildPath] = true
+					}
+				}
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(files))
+	for name := range files {
+		paths = append(paths, name)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// resolveRevision 把版本解析为提交ID，之后的git命令都使用提交ID，
+// 这样文件不存在与版本错误、目录不是仓库等其他失败可以区分开。
+func resolveRevision(repoPath, rev string) (string, error) {
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRevision, rev)
+	}
+	output, err := runGit(repoPath, "rev-parse", "--verify", rev+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("%w %q: %w", ErrInvalidRevision, rev, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// showFile 读取文件在提交中的内容，文件不存在时返回包装了 ErrFileNotFound 的错误。
+func showFile(repoPath, commit, filePath string) (string, error) {
+	content, err := runGit(repoPath, "show", commit+":"+filePath)
+	if isPathNotFound(err) {
+		return "", fmt.Errorf("%w: %s:%s", ErrFileNotFound, commit, filePath)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// fileExists 判断文件在提交中是否存在，只有git报告路径不存在时返回false，其他失败返回错误。
+func fileExists(repoPath, commit, filePath string) (bool, error) {
+	_, err := runGit(repoPath, "cat-file", "-e", commit+":"+filePath)
+	if isPathNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// isPathNotFound 判断git命令是否因为路径在提交中不存在而失败（退出码128）。
+func isPathNotFound(err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 128 {
+		return false
+	}
+	stderr := string(exitErr.Stderr)
+	return strings.Contains(stderr, "does not exist in") || strings.Contains(stderr, "exists on disk, but not in")
+}
+
+// runGit 在仓库目录中运行git命令并返回标准输出，失败时错误中带有git的错误输出，并包装 *exec.ExitError。
+func runGit(repoPath string, args ...string) ([]byte, error) {
+	output, err := exec.Command("git", append([]string{"-C", repoPath}, args...)...).Output()
+	if err != nil {
+		var stderr []byte
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			stderr = exitErr.Stderr
+		}
+		return nil, fmt.Errorf("git %s 失败: %w: %s", args[0], err, strings.TrimSpace(string(stderr)))
+	}
+	return output, nil
+}
+
+// isBuildFile 判断文件是否是Gradle构建文件。
+func isBuildFile(name string) bool {
+	return strings.HasSuffix(name, ".gradle") || strings.HasSuffix(name, ".gradle.kts")
+}
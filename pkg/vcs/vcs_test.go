@@ -0,0 +1,165 @@
+package vcs
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/gradle-parser/pkg/api"
+)
+
+// newTestRepo 创建一个临时Git仓库，依次提交每一组文件（nil内容表示删除），返回仓库路径。
+func newTestRepo(t *testing.T, commits ...map[string]*string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+	git("init", "-q")
+	for i, files := range commits {
+		for name, content := range files {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			if content == nil {
+				if err := os.Remove(path); err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(*content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		git("add", "-A")
+		git("commit", "-q", "-m", "commit")
+		git("tag", "v"+string(rune('1'+i)))
+	}
+	return dir
+}
+
+func text(s string) *string {
+	return &s
+}
+
+func TestParseFileAtRevision(t *testing.T) {
+	repo := newTestRepo(t,
+		map[string]*string{
+			"build.gradle":      text("plugins {\n    id 'java'\n}\ndependencies {\n    implementation \"org.slf4j:slf4j-api:$slf4jVersion\"\n}\n"),
+			"gradle.properties": text("slf4jVersion=1.7.36\n"),
+		},
+		map[string]*string{
+			"gradle.properties": text("slf4jVersion=2.0.9\n"),
+		},
+	)
+
+	result, err := ParseFileAtRevision(repo, "v1", "build.gradle")
+	if err != nil {
+		t.Fatalf("ParseFileAtRevision() error = %v", err)
+	}
+	if result.Project.FilePath != "build.gradle" || len(result.Project.Dependencies) != 1 || len(result.Project.Plugins) != 1 {
+		t.Errorf("Unexpected result %+v", result.Project)
+	}
+
+	options := api.DefaultOptions()
+	options.ResolveVersionVariables = true
+	for rev, want := range map[string]string{"v1": "1.7.36", "v2": "2.0.9"} {
+		result, err := ParseFileAtRevisionWithOptions(repo, rev, "build.gradle", options)
+		if err != nil {
+			t.Fatalf("ParseFileAtRevisionWithOptions(%s) error = %v", rev, err)
+		}
+		if got := result.Project.Dependencies[0].ResolvedVersion; got != want {
+			t.Errorf("At %s expected version %s from gradle.properties, got %s", rev, want, got)
+		}
+	}
+
+	if _, err := ParseFileAtRevision(repo, "v1", "app/build.gradle"); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("Expected ErrFileNotFound, got %v", err)
+	}
+	if _, err := ParseFileAtRevision(repo, "--output=x", "build.gradle"); !errors.Is(err, ErrInvalidRevision) {
+		t.Errorf("Expected ErrInvalidRevision, got %v", err)
+	}
+	_, err = ParseFileAtRevision(repo, "no-such-rev", "build.gradle")
+	if !errors.Is(err, ErrInvalidRevision) || errors.Is(err, ErrFileNotFound) {
+		t.Errorf("Expected ErrInvalidRevision for an unknown revision, got %v", err)
+	}
+
+	// 不是Git仓库的目录报告git的错误，而不是文件不存在。
+	_, err = ParseFileAtRevision(t.TempDir(), "HEAD", "build.gradle")
+	if errors.Is(err, ErrFileNotFound) || err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Errorf("Expected the git error for a directory outside a repository, got %v", err)
+	}
+}
+
+func TestDiffRevisions(t *testing.T) {
+	repo := newTestRepo(t,
+		map[string]*string{
+			"build.gradle":          text("plugins {\n    id 'java'\n}\ndependencies {\n    implementation 'org.slf4j:slf4j-api:1.7.36'\n}\n"),
+			"lib/build.gradle":      text("dependencies {\n    implementation \"com.google.guava:guava:$guavaVersion\"\n}\n"),
+			"lib/gradle.properties": text("guavaVersion=32.0.0-jre\n"),
+			"old/build.gradle":      text("dependencies {\n    implementation 'junit:junit:4.13.2'\n}\n"),
+			"README.md":             text("demo\n"),
+		},
+		map[string]*string{
+			"build.gradle":          text("plugins {\n    id 'java'\n    id 'jacoco'\n}\ndependencies {\n    implementation 'org.slf4j:slf4j-api:2.0.9'\n}\n"),
+			"lib/gradle.properties": text("guavaVersion=33.0.0-jre\n"),
+			"old/build.gradle":      nil,
+			"README.md":             text("demo project\n"),
+		},
+	)
+
+	changeSets, err := DiffRevisions(repo, "v1", "v2")
+	if err != nil {
+		t.Fatalf("DiffRevisions() error = %v", err)
+	}
+	if len(changeSets) != 2 || changeSets[0].FilePath != "build.gradle" || changeSets[1].FilePath != "old/build.gradle" {
+		t.Fatalf("Expected changes for build.gradle and old/build.gradle, got %+v", changeSets)
+	}
+
+	root := changeSets[0]
+	if len(root.Dependencies) != 1 || root.Dependencies[0].Kind != ChangeUpdated || root.Dependencies[0].NewVersion != "2.0.9" {
+		t.Errorf("Expected slf4j-api update, got %+v", root.Dependencies)
+	}
+	if len(root.Plugins) != 1 || root.Plugins[0].Kind != ChangeAdded || root.Plugins[0].ID != "jacoco" {
+		t.Errorf("Expected jacoco plugin to be added, got %+v", root.Plugins)
+	}
+	if deps := changeSets[1].Dependencies; len(deps) != 1 || deps[0].Kind != ChangeRemoved || deps[0].Name != "junit" {
+		t.Errorf("Expected junit removal for deleted file, got %+v", deps)
+	}
+
+	// 开启版本变量解析时，gradle.properties 中的版本变化也会反映到构建文件的依赖上。
+	options := api.DefaultOptions()
+	options.ResolveVersionVariables = true
+	changeSets, err = DiffRevisionsWithOptions(repo, "v1", "v2", options)
+	if err != nil {
+		t.Fatalf("DiffRevisionsWithOptions() error = %v", err)
+	}
+	if len(changeSets) != 3 || changeSets[1].FilePath != "lib/build.gradle" {
+		t.Fatalf("Expected lib/build.gradle to be compared, got %+v", changeSets)
+	}
+	if deps := changeSets[1].Dependencies; len(deps) != 1 || deps[0].OldVersion != "32.0.0-jre" || deps[0].NewVersion != "33.0.0-jre" {
+		t.Errorf("Expected guava update from gradle.properties, got %+v", deps)
+	}
+
+	if _, err := DiffRevisions(repo, "v1", "-p"); !errors.Is(err, ErrInvalidRevision) {
+		t.Errorf("Expected ErrInvalidRevision, got %v", err)
+	}
+	if _, err := DiffRevisions(repo, "v1", "no-such-rev"); !errors.Is(err, ErrInvalidRevision) {
+		t.Errorf("Expected ErrInvalidRevision for an unknown revision, got %v", err)
+	}
+	if _, err := DiffRevisions(t.TempDir(), "v1", "v2"); err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Errorf("Expected the git error for a directory outside a repository, got %v", err)
+	}
+}